@@ -10,3 +10,9 @@ var ErrNilFacadeHandler = errors.New("nil facade handler")
 
 // ErrNilPayloadHandler signals that a nil payload handler has been provided
 var ErrNilPayloadHandler = errors.New("nil payload handler")
+
+// ErrIncompleteTLSConfig signals that only one of the TLS cert and key files has been provided
+var ErrIncompleteTLSConfig = errors.New("incomplete TLS config, both cert file and key file have to be provided")
+
+// ErrTLSFileNotFound signals that a TLS file could not be found
+var ErrTLSFileNotFound = errors.New("TLS file not found")
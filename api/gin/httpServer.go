@@ -28,6 +28,16 @@ func NewHTTPServerWrapper(server HTTPServerHandler) (*httpServerWrapper, error)
 // Start will handle the starting of the gin web server
 func (h *httpServerWrapper) Start() {
 	err := h.server.ListenAndServe()
+	h.handleServeError(err)
+}
+
+// StartTLS will handle the starting of the gin web server, serving HTTPS with the provided files
+func (h *httpServerWrapper) StartTLS(certFile, keyFile string) {
+	err := h.server.ListenAndServeTLS(certFile, keyFile)
+	h.handleServeError(err)
+}
+
+func (h *httpServerWrapper) handleServeError(err error) {
 	if err != nil {
 		if err != http.ErrServerClosed {
 			log.Error("could not start webserver",
@@ -50,4 +50,27 @@ func TestNewHTTPServer(t *testing.T) {
 		require.Nil(t, err)
 		assert.True(t, shutdownWasCalled)
 	})
+	t.Run("start with tls should use the tls files", func(t *testing.T) {
+		t.Parallel()
+
+		var providedCertFile, providedKeyFile string
+		httpServer := &mocks.HTTPServerStub{
+			ListenAndServeCalled: func() error {
+				require.Fail(t, "should not serve plain http")
+				return nil
+			},
+			ListenAndServeTLSCalled: func(certFile, keyFile string) error {
+				providedCertFile = certFile
+				providedKeyFile = keyFile
+				return nil
+			},
+		}
+
+		server, err := gin.NewHTTPServerWrapper(httpServer)
+		require.Nil(t, err)
+
+		server.StartTLS("cert.pem", "key.pem")
+		assert.Equal(t, "cert.pem", providedCertFile)
+		assert.Equal(t, "key.pem", providedKeyFile)
+	})
 }
@@ -5,5 +5,6 @@ import "context"
 // HTTPServerHandler defines the behaviour of a http server
 type HTTPServerHandler interface {
 	ListenAndServe() error
+	ListenAndServeTLS(certFile, keyFile string) error
 	Shutdown(ctx context.Context) error
 }
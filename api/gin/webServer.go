@@ -3,6 +3,7 @@ package gin
 import (
 	"fmt"
 	"net/http"
+	"os"
 	"strings"
 	"sync"
 
@@ -13,6 +14,7 @@ import (
 	logger "github.com/multiversx/mx-chain-logger-go"
 	apiErrors "github.com/multiversx/mx-chain-notifier-go/api/errors"
 	"github.com/multiversx/mx-chain-notifier-go/api/groups"
+	"github.com/multiversx/mx-chain-notifier-go/api/middleware"
 	"github.com/multiversx/mx-chain-notifier-go/api/shared"
 	"github.com/multiversx/mx-chain-notifier-go/common"
 	"github.com/multiversx/mx-chain-notifier-go/config"
@@ -25,8 +27,11 @@ const (
 var log = logger.GetOrCreate("api/gin")
 
 const (
-	eventsGroupID = "events"
-	hubGroupID    = "hub"
+	eventsGroupID   = "events"
+	hubGroupID      = "hub"
+	rabbitMqGroupID = "rabbitmq"
+	metricsGroupID  = "metrics"
+	healthGroupID   = "health"
 )
 
 // ArgsWebServerHandler holds the arguments needed to create a web server handler
@@ -75,9 +80,34 @@ func checkArgs(args ArgsWebServerHandler) error {
 		return apiErrors.ErrNilPayloadHandler
 	}
 
+	return checkTLSConfig(args.Configs.MainConfig.ConnectorApi)
+}
+
+func checkTLSConfig(apiConfig config.ConnectorApiConfig) error {
+	hasCertFile := apiConfig.CertFile != ""
+	hasKeyFile := apiConfig.KeyFile != ""
+	if !hasCertFile && !hasKeyFile {
+		return nil
+	}
+	if hasCertFile != hasKeyFile {
+		return apiErrors.ErrIncompleteTLSConfig
+	}
+
+	for _, file := range []string{apiConfig.CertFile, apiConfig.KeyFile} {
+		_, err := os.Stat(file)
+		if err != nil {
+			return fmt.Errorf("%w: %s", apiErrors.ErrTLSFileNotFound, file)
+		}
+	}
+
 	return nil
 }
 
+func (w *webServer) isTLSEnabled() bool {
+	apiConfig := w.configs.MainConfig.ConnectorApi
+	return apiConfig.CertFile != "" && apiConfig.KeyFile != ""
+}
+
 func (w *webServer) getWSAddr() string {
 	addr := w.configs.MainConfig.ConnectorApi.Host
 	if addr == "" {
@@ -107,6 +137,11 @@ func (w *webServer) Run() error {
 	engine := gin.Default()
 	engine.Use(cors.Default())
 
+	apiConfig := w.configs.MainConfig.ConnectorApi
+	if apiConfig.GzipCompression {
+		engine.Use(middleware.GzipMiddleware(apiConfig.MinCompressBytes))
+	}
+
 	err = w.createGroups()
 	if err != nil {
 		return err
@@ -126,7 +161,12 @@ func (w *webServer) Run() error {
 		return err
 	}
 
-	go w.httpServer.Start()
+	if w.isTLSEnabled() {
+		log.Info("starting web server with TLS", "address", addr)
+		go w.httpServer.StartTLS(apiConfig.CertFile, apiConfig.KeyFile)
+	} else {
+		go w.httpServer.Start()
+	}
 
 	w.wasTriggered = true
 
@@ -136,12 +176,17 @@ func (w *webServer) Run() error {
 func (w *webServer) createGroups() error {
 	groupsMap := make(map[string]shared.GroupHandler)
 
-	eventsGroupArgs := groups.ArgsEventsGroup{
-		Facade:         w.facade,
-		PayloadHandler: w.payloadHandler,
-	}
-
 	if w.configs.MainConfig.ConnectorApi.Enabled {
+		eventsMiddlewares, err := w.createEventsMiddlewares()
+		if err != nil {
+			return err
+		}
+
+		eventsGroupArgs := groups.ArgsEventsGroup{
+			Facade:                w.facade,
+			PayloadHandler:        w.payloadHandler,
+			AdditionalMiddlewares: eventsMiddlewares,
+		}
 		eventsGroup, err := groups.NewEventsGroup(eventsGroupArgs)
 		if err != nil {
 			return err
@@ -155,19 +200,98 @@ func (w *webServer) createGroups() error {
 	}
 	groupsMap["status"] = statusGroup
 
-	if w.configs.Flags.PublisherType == common.WSPublisherType {
-		hubHandler, err := groups.NewHubGroup(w.facade)
+	metricsGroup, err := groups.NewMetricsGroup(w.facade)
+	if err != nil {
+		return err
+	}
+	groupsMap[metricsGroupID] = metricsGroup
+
+	healthGroup, err := groups.NewHealthGroup(w.facade)
+	if err != nil {
+		return err
+	}
+	groupsMap[healthGroupID] = healthGroup
+
+	if common.IsPublisherTypeEnabled(w.configs.Flags.PublisherType, common.WSPublisherType) {
+		hubAuthMiddleware, err := w.createHubAuthMiddleware()
+		if err != nil {
+			return err
+		}
+
+		hubGroupArgs := groups.ArgsHubGroup{
+			Facade:         w.facade,
+			AuthMiddleware: hubAuthMiddleware,
+		}
+		hubHandler, err := groups.NewHubGroup(hubGroupArgs)
 		if err != nil {
 			return err
 		}
 		groupsMap[hubGroupID] = hubHandler
 	}
 
+	if common.IsPublisherTypeEnabled(w.configs.Flags.PublisherType, common.MessageQueuePublisherType) {
+		rabbitMqAuthMiddleware, err := w.createHubAuthMiddleware()
+		if err != nil {
+			return err
+		}
+
+		rabbitMqGroupArgs := groups.ArgsRabbitMqGroup{
+			Facade:         w.facade,
+			AuthMiddleware: rabbitMqAuthMiddleware,
+		}
+		rabbitMqHandler, err := groups.NewRabbitMqGroup(rabbitMqGroupArgs)
+		if err != nil {
+			return err
+		}
+		groupsMap[rabbitMqGroupID] = rabbitMqHandler
+	}
+
 	w.groups = groupsMap
 
 	return nil
 }
 
+func (w *webServer) createEventsMiddlewares() ([]gin.HandlerFunc, error) {
+	middlewares := make([]gin.HandlerFunc, 0)
+
+	apiConfig := w.configs.MainConfig.ConnectorApi
+	if apiConfig.RateLimitRequestsPerSecond > 0 {
+		rateLimiter, err := middleware.NewRateLimiter(middleware.ArgsRateLimiter{
+			RequestsPerSecond: apiConfig.RateLimitRequestsPerSecond,
+			Burst:             apiConfig.RateLimitBurst,
+		})
+		if err != nil {
+			return nil, err
+		}
+		middlewares = append(middlewares, rateLimiter.MiddlewareHandlerFunc())
+	}
+
+	// the body size is checked after rate limiting, to avoid reading payloads of rejected requests
+	maxRequestBodyBytes := apiConfig.MaxRequestBodyBytes
+	if maxRequestBodyBytes <= 0 {
+		maxRequestBodyBytes = middleware.DefaultMaxRequestBodyBytes
+	}
+	middlewares = append(middlewares, middleware.BodySizeLimitMiddleware(maxRequestBodyBytes))
+
+	return middlewares, nil
+}
+
+func (w *webServer) createHubAuthMiddleware() (gin.HandlerFunc, error) {
+	apiConfig := w.configs.MainConfig.ConnectorApi
+	if !apiConfig.AuthRequired && apiConfig.AllowUnauthenticatedAdmin {
+		return nil, nil
+	}
+	if !apiConfig.AuthRequired {
+		log.Warn("the admin endpoints are disabled, since AuthRequired is not set")
+		return middleware.DisabledAuthMiddleware(), nil
+	}
+	if len(apiConfig.JWTSecretKey) == 0 {
+		return nil, middleware.ErrEmptyJWTSecretKey
+	}
+
+	return middleware.JWTMiddleware(apiConfig.JWTSecretKey), nil
+}
+
 func (w *webServer) registerRoutes(ginEngine *gin.Engine) {
 	for groupName, groupHandler := range w.groups {
 		log.Info("registering API group", "group name", groupName)
@@ -1,12 +1,29 @@
 package gin_test
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/multiversx/mx-chain-communication-go/testscommon"
 	"github.com/multiversx/mx-chain-core-go/core/check"
 	apiErrors "github.com/multiversx/mx-chain-notifier-go/api/errors"
 	"github.com/multiversx/mx-chain-notifier-go/api/gin"
+	"github.com/multiversx/mx-chain-notifier-go/api/middleware"
 	"github.com/multiversx/mx-chain-notifier-go/common"
 	"github.com/multiversx/mx-chain-notifier-go/config"
 	"github.com/multiversx/mx-chain-notifier-go/mocks"
@@ -66,6 +83,106 @@ func TestNewWebServerHandler(t *testing.T) {
 		require.Equal(t, common.ErrInvalidAPIType, err)
 	})
 
+	t.Run("invalid rate limit config, should fail on run", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsWebServerHandler()
+		args.Configs.MainConfig.ConnectorApi.Enabled = true
+		args.Configs.MainConfig.ConnectorApi.RateLimitRequestsPerSecond = 10
+		args.Configs.MainConfig.ConnectorApi.RateLimitBurst = 0
+
+		ws, err := gin.NewWebServerHandler(args)
+		require.Nil(t, err)
+
+		err = ws.Run()
+		require.Equal(t, middleware.ErrInvalidBurst, err)
+	})
+
+	t.Run("auth required without jwt secret key, should fail on run", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsWebServerHandler()
+		args.Configs.Flags.PublisherType = common.WSPublisherType
+		args.Configs.MainConfig.ConnectorApi.AuthRequired = true
+
+		ws, err := gin.NewWebServerHandler(args)
+		require.Nil(t, err)
+
+		err = ws.Run()
+		require.Equal(t, middleware.ErrEmptyJWTSecretKey, err)
+	})
+
+	t.Run("only tls cert file provided", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsWebServerHandler()
+		args.Configs.MainConfig.ConnectorApi.CertFile = "cert.pem"
+
+		ws, err := gin.NewWebServerHandler(args)
+		require.True(t, check.IfNil(ws))
+		require.Equal(t, apiErrors.ErrIncompleteTLSConfig, err)
+	})
+
+	t.Run("only tls key file provided", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsWebServerHandler()
+		args.Configs.MainConfig.ConnectorApi.KeyFile = "key.pem"
+
+		ws, err := gin.NewWebServerHandler(args)
+		require.True(t, check.IfNil(ws))
+		require.Equal(t, apiErrors.ErrIncompleteTLSConfig, err)
+	})
+
+	t.Run("missing tls files", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsWebServerHandler()
+		args.Configs.MainConfig.ConnectorApi.CertFile = "missing-cert.pem"
+		args.Configs.MainConfig.ConnectorApi.KeyFile = "missing-key.pem"
+
+		ws, err := gin.NewWebServerHandler(args)
+		require.True(t, check.IfNil(ws))
+		require.True(t, errors.Is(err, apiErrors.ErrTLSFileNotFound))
+	})
+
+	t.Run("with tls files should serve https", func(t *testing.T) {
+		t.Parallel()
+
+		certFile, keyFile := createTLSFiles(t)
+
+		args := createMockArgsWebServerHandler()
+		args.Configs.MainConfig.ConnectorApi.Host = getFreeAddress(t)
+		args.Configs.MainConfig.ConnectorApi.CertFile = certFile
+		args.Configs.MainConfig.ConnectorApi.KeyFile = keyFile
+
+		ws, err := gin.NewWebServerHandler(args)
+		require.Nil(t, err)
+
+		err = ws.Run()
+		require.Nil(t, err)
+
+		client := &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+			},
+		}
+
+		url := fmt.Sprintf("https://%s/health", args.Configs.MainConfig.ConnectorApi.Host)
+		require.Eventually(t, func() bool {
+			resp, errGet := client.Get(url)
+			if errGet != nil {
+				return false
+			}
+			_ = resp.Body.Close()
+
+			return resp.TLS != nil
+		}, 5*time.Second, 50*time.Millisecond)
+
+		err = ws.Close()
+		require.Nil(t, err)
+	})
+
 	t.Run("should work", func(t *testing.T) {
 		t.Parallel()
 
@@ -82,3 +199,108 @@ func TestNewWebServerHandler(t *testing.T) {
 		require.Nil(t, err)
 	})
 }
+
+func TestWebServer_AdminEndpointsAuth(t *testing.T) {
+	t.Parallel()
+
+	sendDisconnectRequest := func(t *testing.T, allowUnauthenticatedAdmin bool) int {
+		wasCalled := false
+		args := createMockArgsWebServerHandler()
+		args.Facade = &mocks.FacadeStub{
+			DisconnectDispatcherCalled: func(dispatcherID uuid.UUID) error {
+				wasCalled = true
+				return nil
+			},
+		}
+		args.Configs.Flags.PublisherType = common.WSPublisherType
+		args.Configs.MainConfig.ConnectorApi.Host = getFreeAddress(t)
+		args.Configs.MainConfig.ConnectorApi.AllowUnauthenticatedAdmin = allowUnauthenticatedAdmin
+		args.Configs.ApiRoutesConfig = config.APIRoutesConfig{
+			APIPackages: map[string]config.APIPackageConfig{
+				"hub": {
+					Routes: []config.RouteConfig{
+						{Name: "/dispatchers/:id", Open: true, Auth: true},
+					},
+				},
+			},
+		}
+
+		ws, err := gin.NewWebServerHandler(args)
+		require.Nil(t, err)
+
+		err = ws.Run()
+		require.Nil(t, err)
+		defer func() {
+			_ = ws.Close()
+		}()
+
+		url := fmt.Sprintf("http://%s/hub/dispatchers/%s", args.Configs.MainConfig.ConnectorApi.Host, uuid.New().String())
+		req, _ := http.NewRequest(http.MethodDelete, url, nil)
+
+		statusCode := 0
+		require.Eventually(t, func() bool {
+			resp, errDo := http.DefaultClient.Do(req)
+			if errDo != nil {
+				return false
+			}
+			_ = resp.Body.Close()
+			statusCode = resp.StatusCode
+
+			return true
+		}, 5*time.Second, 50*time.Millisecond)
+		require.Equal(t, allowUnauthenticatedAdmin, wasCalled)
+
+		return statusCode
+	}
+
+	t.Run("without auth, admin endpoints should be disabled", func(t *testing.T) {
+		t.Parallel()
+
+		require.Equal(t, http.StatusForbidden, sendDisconnectRequest(t, false))
+	})
+
+	t.Run("unauthenticated admin endpoints allowed, should work", func(t *testing.T) {
+		t.Parallel()
+
+		require.Equal(t, http.StatusNoContent, sendDisconnectRequest(t, true))
+	})
+}
+
+func getFreeAddress(t *testing.T) string {
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.Nil(t, err)
+	defer func() {
+		_ = listener.Close()
+	}()
+
+	return listener.Addr().String()
+}
+
+func createTLSFiles(t *testing.T) (string, string) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err)
+
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, &template, &template, &privateKey.PublicKey, privateKey)
+	require.Nil(t, err)
+
+	keyBytes, err := x509.MarshalECPrivateKey(privateKey)
+	require.Nil(t, err)
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+
+	err = os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certBytes}), 0600)
+	require.Nil(t, err)
+	err = os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes}), 0600)
+	require.Nil(t, err)
+
+	return certFile, keyFile
+}
@@ -1,6 +1,7 @@
 package groups
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -9,9 +10,10 @@ import (
 	"github.com/multiversx/mx-chain-communication-go/websocket"
 	"github.com/multiversx/mx-chain-core-go/core/check"
 	"github.com/multiversx/mx-chain-core-go/data/outport"
-	"github.com/multiversx/mx-chain-notifier-go/api/errors"
+	apiErrors "github.com/multiversx/mx-chain-notifier-go/api/errors"
 	"github.com/multiversx/mx-chain-notifier-go/api/shared"
 	"github.com/multiversx/mx-chain-notifier-go/common"
+	"github.com/multiversx/mx-chain-notifier-go/process"
 )
 
 const (
@@ -20,12 +22,14 @@ const (
 	finalizedEventsEndpoint = "/finalized"
 
 	payloadVersionHeaderKey = "version"
+	correlationIDHeaderKey  = "X-Correlation-ID"
 )
 
 // ArgsEventsGroup defines the arguments needed to create a new events group component
 type ArgsEventsGroup struct {
-	Facade         EventsFacadeHandler
-	PayloadHandler websocket.PayloadHandler
+	Facade                EventsFacadeHandler
+	PayloadHandler        websocket.PayloadHandler
+	AdditionalMiddlewares []gin.HandlerFunc
 }
 
 type eventsGroup struct {
@@ -48,6 +52,7 @@ func NewEventsGroup(args ArgsEventsGroup) (*eventsGroup, error) {
 	}
 
 	h.createMiddlewares()
+	h.additionalMiddlewares = append(h.additionalMiddlewares, args.AdditionalMiddlewares...)
 
 	endpoints := []*shared.EndpointHandlerData{
 		{
@@ -74,10 +79,10 @@ func NewEventsGroup(args ArgsEventsGroup) (*eventsGroup, error) {
 
 func checkEventsGroupArgs(args ArgsEventsGroup) error {
 	if check.IfNil(args.Facade) {
-		return fmt.Errorf("%w for events group", errors.ErrNilFacadeHandler)
+		return fmt.Errorf("%w for events group", apiErrors.ErrNilFacadeHandler)
 	}
 	if check.IfNil(args.PayloadHandler) {
-		return fmt.Errorf("%w for events group", errors.ErrNilPayloadHandler)
+		return fmt.Errorf("%w for events group", apiErrors.ErrNilPayloadHandler)
 	}
 
 	return nil
@@ -95,6 +100,32 @@ func getPayloadVersion(c *gin.Context) uint32 {
 	return uint32(version)
 }
 
+// processPayload passes along the correlation id from the request header, if the payload
+// handler accepts it, so that the observer push can be followed in the notifier logs
+func (h *eventsGroup) processPayload(c *gin.Context, payload []byte, topic string, version uint32) error {
+	correlationID := c.GetHeader(correlationIDHeaderKey)
+	correlatedPayloadHandler, ok := h.payloadHandler.(CorrelatedPayloadHandler)
+	if correlationID == "" || !ok {
+		return h.payloadHandler.ProcessPayload(payload, topic, version)
+	}
+
+	return correlatedPayloadHandler.ProcessPayloadWithCorrelationID(payload, topic, version, correlationID)
+}
+
+// getProcessPayloadStatusCode returns service unavailable for the payloads received while
+// shutting down, so that the observer pushes them again, unprocessable entity for the payloads
+// with an unsupported version or topic, and bad request otherwise
+func getProcessPayloadStatusCode(err error) int {
+	if errors.Is(err, common.ErrPayloadHandlerClosed) {
+		return http.StatusServiceUnavailable
+	}
+	if errors.Is(err, process.ErrInvalidPayloadVersion) || errors.Is(err, process.ErrUnknownPayloadTopic) {
+		return http.StatusUnprocessableEntity
+	}
+
+	return http.StatusBadRequest
+}
+
 func (h *eventsGroup) pushEvents(c *gin.Context) {
 	pushEventsRawData, err := c.GetRawData()
 	if err != nil {
@@ -104,9 +135,9 @@ func (h *eventsGroup) pushEvents(c *gin.Context) {
 
 	payloadVersion := getPayloadVersion(c)
 
-	err = h.payloadHandler.ProcessPayload(pushEventsRawData, outport.TopicSaveBlock, payloadVersion)
+	err = h.processPayload(c, pushEventsRawData, outport.TopicSaveBlock, payloadVersion)
 	if err != nil {
-		shared.JSONResponse(c, http.StatusBadRequest, nil, err.Error())
+		shared.JSONResponse(c, getProcessPayloadStatusCode(err), nil, err.Error())
 		return
 	}
 
@@ -122,9 +153,9 @@ func (h *eventsGroup) revertEvents(c *gin.Context) {
 
 	payloadVersion := getPayloadVersion(c)
 
-	err = h.payloadHandler.ProcessPayload(revertEventsRawData, outport.TopicRevertIndexedBlock, payloadVersion)
+	err = h.processPayload(c, revertEventsRawData, outport.TopicRevertIndexedBlock, payloadVersion)
 	if err != nil {
-		shared.JSONResponse(c, http.StatusBadRequest, nil, err.Error())
+		shared.JSONResponse(c, getProcessPayloadStatusCode(err), nil, err.Error())
 		return
 	}
 
@@ -140,9 +171,9 @@ func (h *eventsGroup) finalizedEvents(c *gin.Context) {
 
 	payloadVersion := getPayloadVersion(c)
 
-	err = h.payloadHandler.ProcessPayload(finalizedRawData, outport.TopicFinalizedBlock, payloadVersion)
+	err = h.processPayload(c, finalizedRawData, outport.TopicFinalizedBlock, payloadVersion)
 	if err != nil {
-		shared.JSONResponse(c, http.StatusBadRequest, nil, err.Error())
+		shared.JSONResponse(c, getProcessPayloadStatusCode(err), nil, err.Error())
 		return
 	}
 
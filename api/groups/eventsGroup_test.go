@@ -4,10 +4,12 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/gin-gonic/gin"
 	"github.com/multiversx/mx-chain-communication-go/testscommon"
 	"github.com/multiversx/mx-chain-core-go/core"
 	"github.com/multiversx/mx-chain-core-go/core/check"
@@ -17,9 +19,13 @@ import (
 	"github.com/multiversx/mx-chain-core-go/data/transaction"
 	apiErrors "github.com/multiversx/mx-chain-notifier-go/api/errors"
 	"github.com/multiversx/mx-chain-notifier-go/api/groups"
+	"github.com/multiversx/mx-chain-notifier-go/api/middleware"
+	"github.com/multiversx/mx-chain-notifier-go/common"
+	"github.com/multiversx/mx-chain-notifier-go/compress"
 	"github.com/multiversx/mx-chain-notifier-go/config"
 	"github.com/multiversx/mx-chain-notifier-go/data"
 	"github.com/multiversx/mx-chain-notifier-go/mocks"
+	"github.com/multiversx/mx-chain-notifier-go/process"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -233,6 +239,73 @@ func TestEventsGroup_PushEvents(t *testing.T) {
 	})
 }
 
+func TestEventsGroup_PushGzipCompressedEvents(t *testing.T) {
+	t.Parallel()
+
+	payload := []byte(`{"hash":"hash1"}`)
+	compressedPayload, err := compress.Compress(payload, compress.GzipAlgorithm)
+	require.Nil(t, err)
+
+	createEventsGroup := func(t *testing.T, receivedPayloads chan []byte) *gin.Engine {
+		payloadHandler, err := process.NewPayloadHandler(process.ArgsPayloadHandler{
+			DataProcessors: map[uint32]process.DataProcessor{
+				common.PayloadV0: &mocks.EventsDataProcessorStub{
+					SaveBlockCalled: func(marshalledData []byte) error {
+						receivedPayloads <- marshalledData
+						return nil
+					},
+				},
+			},
+			StatusMetricsHandler:        &mocks.StatusMetricsStub{},
+			MetricsCollector:            &mocks.MetricsCollectorStub{},
+			MaxDecompressedPayloadBytes: uint64(len(payload)),
+		})
+		require.Nil(t, err)
+
+		args := createMockEventsGroupArgs()
+		args.PayloadHandler = payloadHandler
+		eg, err := groups.NewEventsGroup(args)
+		require.Nil(t, err)
+
+		return startWebServer(eg, eventsPath, getEventsRoutesConfig())
+	}
+
+	t.Run("compressed payload should be decompressed", func(t *testing.T) {
+		t.Parallel()
+
+		receivedPayloads := make(chan []byte, 1)
+		ws := createEventsGroup(t, receivedPayloads)
+
+		req, _ := http.NewRequest("POST", "/events/push", bytes.NewBuffer(compressedPayload))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Content-Encoding", "gzip")
+		resp := httptest.NewRecorder()
+
+		ws.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+		require.Equal(t, payload, <-receivedPayloads)
+	})
+
+	t.Run("corrupted compressed payload should be rejected", func(t *testing.T) {
+		t.Parallel()
+
+		receivedPayloads := make(chan []byte, 1)
+		ws := createEventsGroup(t, receivedPayloads)
+
+		req, _ := http.NewRequest("POST", "/events/push", bytes.NewBuffer(compressedPayload[:len(compressedPayload)-4]))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Content-Encoding", "gzip")
+		resp := httptest.NewRecorder()
+
+		ws.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		assert.Contains(t, resp.Body.String(), process.ErrPayloadDecompression.Error())
+		assert.Equal(t, 0, len(receivedPayloads))
+	})
+}
+
 func TestEventsGroup_RevertEvents(t *testing.T) {
 	t.Parallel()
 
@@ -286,8 +359,9 @@ func TestEventsGroup_RevertEvents(t *testing.T) {
 			},
 		}
 		args.Facade = &mocks.FacadeStub{
-			HandleRevertEventsCalled: func(events data.RevertBlock) {
+			HandleRevertEventsCalled: func(events data.RevertBlock) error {
 				assert.Equal(t, revertBlockEvents, events)
+				return nil
 			},
 		}
 
@@ -335,6 +409,55 @@ func TestEventsGroup_FinalizedEvents(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, resp.Code)
 	})
 
+	t.Run("closed payload handler, service unavailable", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockEventsGroupArgs()
+		args.PayloadHandler = &testscommon.PayloadHandlerStub{
+			ProcessPayloadCalled: func(payload []byte, topic string, version uint32) error {
+				return common.ErrPayloadHandlerClosed
+			},
+		}
+
+		eg, err := groups.NewEventsGroup(args)
+		require.Nil(t, err)
+
+		ws := startWebServer(eg, eventsPath, getEventsRoutesConfig())
+
+		req, _ := http.NewRequest("POST", "/events/finalized", bytes.NewBuffer([]byte(`{"hash":"hash1"}`)))
+		req.Header.Set("Content-Type", "application/json")
+		resp := httptest.NewRecorder()
+
+		ws.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusServiceUnavailable, resp.Code)
+	})
+
+	t.Run("unsupported payload version, unprocessable entity", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockEventsGroupArgs()
+		args.PayloadHandler = &testscommon.PayloadHandlerStub{
+			ProcessPayloadCalled: func(payload []byte, topic string, version uint32) error {
+				return fmt.Errorf("%w: %d", process.ErrInvalidPayloadVersion, version)
+			},
+		}
+
+		eg, err := groups.NewEventsGroup(args)
+		require.Nil(t, err)
+
+		ws := startWebServer(eg, eventsPath, getEventsRoutesConfig())
+
+		req, _ := http.NewRequest("POST", "/events/finalized", bytes.NewBuffer([]byte(`{"hash":"hash1"}`)))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("version", "5")
+		resp := httptest.NewRecorder()
+
+		ws.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+	})
+
 	t.Run("should work", func(t *testing.T) {
 		t.Parallel()
 
@@ -354,9 +477,10 @@ func TestEventsGroup_FinalizedEvents(t *testing.T) {
 			},
 		}
 		args.Facade = &mocks.FacadeStub{
-			HandleFinalizedEventsCalled: func(events data.FinalizedBlock) {
+			HandleFinalizedEventsCalled: func(events data.FinalizedBlock) error {
 				wasCalled = true
 				assert.Equal(t, finalizedBlockEvents, events)
+				return nil
 			},
 		}
 
@@ -377,6 +501,120 @@ func TestEventsGroup_FinalizedEvents(t *testing.T) {
 	})
 }
 
+func TestEventsGroup_CorrelationID(t *testing.T) {
+	t.Parallel()
+
+	t.Run("correlation id header should be passed to the payload handler", func(t *testing.T) {
+		t.Parallel()
+
+		receivedCorrelationID := ""
+		args := createMockEventsGroupArgs()
+		args.PayloadHandler = &mocks.PayloadHandlerStub{
+			ProcessPayloadCalled: func(payload []byte, topic string, version uint32) error {
+				require.Fail(t, "should have not been called")
+				return nil
+			},
+			ProcessPayloadWithCorrelationIDCalled: func(payload []byte, topic string, version uint32, correlationID string) error {
+				require.Equal(t, outport.TopicRevertIndexedBlock, topic)
+				receivedCorrelationID = correlationID
+				return nil
+			},
+		}
+
+		eg, err := groups.NewEventsGroup(args)
+		require.Nil(t, err)
+
+		ws := startWebServer(eg, eventsPath, getEventsRoutesConfig())
+
+		req, _ := http.NewRequest("POST", "/events/revert", bytes.NewBuffer([]byte("{}")))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Correlation-ID", "correlation1")
+		resp := httptest.NewRecorder()
+
+		ws.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, "correlation1", receivedCorrelationID)
+	})
+
+	t.Run("without correlation id header should process the payload", func(t *testing.T) {
+		t.Parallel()
+
+		wasCalled := false
+		args := createMockEventsGroupArgs()
+		args.PayloadHandler = &mocks.PayloadHandlerStub{
+			ProcessPayloadCalled: func(payload []byte, topic string, version uint32) error {
+				wasCalled = true
+				return nil
+			},
+			ProcessPayloadWithCorrelationIDCalled: func(payload []byte, topic string, version uint32, correlationID string) error {
+				require.Fail(t, "should have not been called")
+				return nil
+			},
+		}
+
+		eg, err := groups.NewEventsGroup(args)
+		require.Nil(t, err)
+
+		ws := startWebServer(eg, eventsPath, getEventsRoutesConfig())
+
+		req, _ := http.NewRequest("POST", "/events/finalized", bytes.NewBuffer([]byte("{}")))
+		req.Header.Set("Content-Type", "application/json")
+		resp := httptest.NewRecorder()
+
+		ws.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.True(t, wasCalled)
+	})
+}
+
+func TestEventsGroup_RateLimitedPushEvents(t *testing.T) {
+	t.Parallel()
+
+	burst := 3
+	rateLimiter, err := middleware.NewRateLimiter(middleware.ArgsRateLimiter{
+		RequestsPerSecond: 0.001,
+		Burst:             burst,
+	})
+	require.Nil(t, err)
+
+	args := createMockEventsGroupArgs()
+	args.AdditionalMiddlewares = []gin.HandlerFunc{rateLimiter.MiddlewareHandlerFunc()}
+
+	numProcessed := 0
+	args.PayloadHandler = &testscommon.PayloadHandlerStub{
+		ProcessPayloadCalled: func(payload []byte, topic string, version uint32) error {
+			numProcessed++
+			return nil
+		},
+	}
+
+	eg, err := groups.NewEventsGroup(args)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(eg.GetAdditionalMiddlewares()))
+
+	ws := startWebServer(eg, eventsPath, getEventsRoutesConfig())
+
+	numRequests := 10
+	numTooManyRequests := 0
+	for i := 0; i < numRequests; i++ {
+		req, _ := http.NewRequest("POST", "/events/push", bytes.NewBuffer([]byte("{}")))
+		resp := httptest.NewRecorder()
+		ws.ServeHTTP(resp, req)
+
+		if resp.Code == http.StatusTooManyRequests {
+			response := make(map[string]interface{})
+			loadResponse(resp.Body, &response)
+			assert.Equal(t, middleware.ErrTooManyRequests.Error(), response["error"])
+			numTooManyRequests++
+		}
+	}
+
+	assert.Equal(t, burst, numProcessed)
+	assert.Equal(t, numRequests-burst, numTooManyRequests)
+}
+
 func getEventsRoutesConfig() config.APIRoutesConfig {
 	return config.APIRoutesConfig{
 		APIPackages: map[string]config.APIPackageConfig{
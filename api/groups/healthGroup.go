@@ -0,0 +1,100 @@
+package groups
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-notifier-go/api/errors"
+	"github.com/multiversx/mx-chain-notifier-go/api/shared"
+	"github.com/multiversx/mx-chain-notifier-go/common"
+)
+
+const (
+	// healthEndpoint is empty since the health status is exposed on the group root path
+	healthEndpoint    = ""
+	livenessEndpoint  = "/live"
+	readinessEndpoint = "/ready"
+
+	readinessTimeout = 5 * time.Second
+)
+
+type healthGroup struct {
+	*baseGroup
+	facade shared.FacadeHandler
+}
+
+// NewHealthGroup registers handlers for the /health group
+func NewHealthGroup(facade shared.FacadeHandler) (*healthGroup, error) {
+	if check.IfNil(facade) {
+		return nil, fmt.Errorf("%w for health group", errors.ErrNilFacadeHandler)
+	}
+
+	hg := &healthGroup{
+		facade:    facade,
+		baseGroup: newBaseGroup(),
+	}
+
+	endpoints := []*shared.EndpointHandlerData{
+		{
+			Path:    healthEndpoint,
+			Handler: hg.getHealthStatus,
+			Method:  http.MethodGet,
+		},
+		{
+			Path:    livenessEndpoint,
+			Handler: hg.getLiveness,
+			Method:  http.MethodGet,
+		},
+		{
+			Path:    readinessEndpoint,
+			Handler: hg.getReadiness,
+			Method:  http.MethodGet,
+		},
+	}
+	hg.endpoints = endpoints
+
+	return hg, nil
+}
+
+// getHealthStatus will return the health state of each component, with 503 status
+// code if any of them is down
+func (hg *healthGroup) getHealthStatus(c *gin.Context) {
+	healthStatus := hg.facade.GetHealthStatus()
+
+	statusCode := http.StatusOK
+	if healthStatus.Status == common.HealthStateDown {
+		statusCode = http.StatusServiceUnavailable
+	}
+
+	shared.JSONResponse(c, statusCode, healthStatus, "")
+}
+
+// getLiveness will always return 200, as long as the server is able to handle requests
+func (hg *healthGroup) getLiveness(c *gin.Context) {
+	shared.JSONResponse(c, http.StatusOK, common.HealthStateUp, "")
+}
+
+// getReadiness will ping each component and return 503 status code, together with
+// the failed checks, if any of them failed
+func (hg *healthGroup) getReadiness(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), readinessTimeout)
+	defer cancel()
+
+	readinessStatus := hg.facade.GetReadinessStatus(ctx)
+
+	statusCode := http.StatusOK
+	if readinessStatus.Status == common.HealthStateDown {
+		statusCode = http.StatusServiceUnavailable
+	}
+
+	shared.JSONResponse(c, statusCode, readinessStatus, "")
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (hg *healthGroup) IsInterfaceNil() bool {
+	return hg == nil
+}
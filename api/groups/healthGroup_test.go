@@ -0,0 +1,204 @@
+package groups_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	apiErrors "github.com/multiversx/mx-chain-notifier-go/api/errors"
+	"github.com/multiversx/mx-chain-notifier-go/api/groups"
+	"github.com/multiversx/mx-chain-notifier-go/common"
+	"github.com/multiversx/mx-chain-notifier-go/config"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const healthPath = "/health"
+
+type healthStatusResponse struct {
+	Data  data.HealthStatusResponse `json:"data"`
+	Error string                    `json:"error"`
+}
+
+type readinessStatusResponse struct {
+	Data  data.ReadinessStatusResponse `json:"data"`
+	Error string                       `json:"error"`
+}
+
+func TestNewHealthGroup(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil facade should error", func(t *testing.T) {
+		t.Parallel()
+
+		hg, err := groups.NewHealthGroup(nil)
+
+		require.True(t, errors.Is(err, apiErrors.ErrNilFacadeHandler))
+		require.True(t, check.IfNil(hg))
+	})
+
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		hg, err := groups.NewHealthGroup(&mocks.FacadeStub{})
+
+		assert.False(t, check.IfNil(hg))
+		assert.Nil(t, err)
+	})
+}
+
+func TestHealthGroup_GetHealthStatus(t *testing.T) {
+	t.Parallel()
+
+	t.Run("healthy components should return 200", func(t *testing.T) {
+		t.Parallel()
+
+		expectedStatus := data.HealthStatusResponse{
+			Status: common.HealthStateUp,
+			Components: map[string]string{
+				common.HubHealthComponent:    common.HealthStateUp,
+				common.BrokerHealthComponent: common.HealthStateNotApplicable,
+			},
+		}
+
+		resp := requestHealthStatus(t, expectedStatus)
+
+		var apiResp healthStatusResponse
+		loadResponse(resp.Body, &apiResp)
+
+		require.Equal(t, http.StatusOK, resp.Code)
+		require.Equal(t, expectedStatus, apiResp.Data)
+	})
+
+	t.Run("degraded component should return 503", func(t *testing.T) {
+		t.Parallel()
+
+		expectedStatus := data.HealthStatusResponse{
+			Status: common.HealthStateDown,
+			Components: map[string]string{
+				common.HubHealthComponent:    common.HealthStateUp,
+				common.BrokerHealthComponent: common.HealthStateDown,
+			},
+		}
+
+		resp := requestHealthStatus(t, expectedStatus)
+
+		var apiResp healthStatusResponse
+		loadResponse(resp.Body, &apiResp)
+
+		require.Equal(t, http.StatusServiceUnavailable, resp.Code)
+		require.Equal(t, expectedStatus, apiResp.Data)
+	})
+}
+
+func TestHealthGroup_GetLiveness(t *testing.T) {
+	t.Parallel()
+
+	healthGroup, err := groups.NewHealthGroup(&mocks.FacadeStub{})
+	require.Nil(t, err)
+
+	ws := startWebServer(healthGroup, healthPath, getHealthRoutesConfig())
+
+	req, _ := http.NewRequest("GET", "/health/live", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	require.Equal(t, http.StatusOK, resp.Code)
+}
+
+func TestHealthGroup_GetReadiness(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ready components should return 200", func(t *testing.T) {
+		t.Parallel()
+
+		expectedStatus := data.ReadinessStatusResponse{
+			Status:       common.HealthStateUp,
+			FailedChecks: map[string]string{},
+		}
+
+		resp := requestReadinessStatus(t, expectedStatus)
+
+		var apiResp readinessStatusResponse
+		loadResponse(resp.Body, &apiResp)
+
+		require.Equal(t, http.StatusOK, resp.Code)
+		require.Equal(t, expectedStatus, apiResp.Data)
+	})
+
+	t.Run("failed check should return 503", func(t *testing.T) {
+		t.Parallel()
+
+		expectedStatus := data.ReadinessStatusResponse{
+			Status: common.HealthStateDown,
+			FailedChecks: map[string]string{
+				common.BrokerHealthComponent: "rabbitmq connection failure",
+			},
+		}
+
+		resp := requestReadinessStatus(t, expectedStatus)
+
+		var apiResp readinessStatusResponse
+		loadResponse(resp.Body, &apiResp)
+
+		require.Equal(t, http.StatusServiceUnavailable, resp.Code)
+		require.Equal(t, expectedStatus, apiResp.Data)
+	})
+}
+
+func requestReadinessStatus(t *testing.T, readinessStatus data.ReadinessStatusResponse) *httptest.ResponseRecorder {
+	facade := &mocks.FacadeStub{
+		GetReadinessStatusCalled: func(ctx context.Context) data.ReadinessStatusResponse {
+			return readinessStatus
+		},
+	}
+
+	healthGroup, err := groups.NewHealthGroup(facade)
+	require.Nil(t, err)
+
+	ws := startWebServer(healthGroup, healthPath, getHealthRoutesConfig())
+
+	req, _ := http.NewRequest("GET", "/health/ready", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	return resp
+}
+
+func requestHealthStatus(t *testing.T, healthStatus data.HealthStatusResponse) *httptest.ResponseRecorder {
+	facade := &mocks.FacadeStub{
+		GetHealthStatusCalled: func() data.HealthStatusResponse {
+			return healthStatus
+		},
+	}
+
+	healthGroup, err := groups.NewHealthGroup(facade)
+	require.Nil(t, err)
+
+	ws := startWebServer(healthGroup, healthPath, getHealthRoutesConfig())
+
+	req, _ := http.NewRequest("GET", "/health", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	return resp
+}
+
+func getHealthRoutesConfig() config.APIRoutesConfig {
+	return config.APIRoutesConfig{
+		APIPackages: map[string]config.APIPackageConfig{
+			"health": {
+				Routes: []config.RouteConfig{
+					{Name: "", Open: true},
+					{Name: "/live", Open: true},
+					{Name: "/ready", Open: true},
+				},
+			},
+		},
+	}
+}
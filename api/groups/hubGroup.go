@@ -1,19 +1,42 @@
 package groups
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/multiversx/mx-chain-core-go/core/check"
-	"github.com/multiversx/mx-chain-notifier-go/api/errors"
+	apiErrors "github.com/multiversx/mx-chain-notifier-go/api/errors"
 	"github.com/multiversx/mx-chain-notifier-go/api/shared"
+	"github.com/multiversx/mx-chain-notifier-go/common"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/filters"
 )
 
 const (
-	websocketEndpoint = "/ws"
+	websocketEndpoint          = "/ws"
+	dispatchersEndpoint        = "/dispatchers/:id"
+	webhooksEndpoint           = "/webhooks"
+	webhookEndpoint            = "/webhooks/:id"
+	eventsEndpoint             = "/events"
+	filterEndpoint             = "/filter"
+	subscriptionStatsEndpoint  = "/subscriptions/stats"
+	subscriptionsMatchEndpoint = "/subscriptions/match"
+
+	fromNonceQueryParam = "fromNonce"
+	toNonceQueryParam   = "toNonce"
 )
 
+// ArgsHubGroup defines the arguments needed to create a new hub group component
+type ArgsHubGroup struct {
+	Facade         HubFacadeHandler
+	AuthMiddleware gin.HandlerFunc
+}
+
 type hubGroup struct {
 	*baseGroup
 	facade HubFacadeHandler
@@ -21,22 +44,62 @@ type hubGroup struct {
 
 // NewHubGroup registers handlers for the /hub group
 // It only registers the specified hub implementation and its corresponding dispatchers
-func NewHubGroup(facade HubFacadeHandler) (*hubGroup, error) {
-	if check.IfNil(facade) {
-		return nil, fmt.Errorf("%w for hub group", errors.ErrNilFacadeHandler)
+// If provided, the auth middleware is applied to the endpoints with "Auth" flag enabled
+func NewHubGroup(args ArgsHubGroup) (*hubGroup, error) {
+	if check.IfNil(args.Facade) {
+		return nil, fmt.Errorf("%w for hub group", apiErrors.ErrNilFacadeHandler)
 	}
 
 	h := &hubGroup{
-		facade:    facade,
+		facade:    args.Facade,
 		baseGroup: newBaseGroup(),
 	}
 
+	if args.AuthMiddleware != nil {
+		h.authMiddleware = args.AuthMiddleware
+	}
+
 	endpoints := []*shared.EndpointHandlerData{
 		{
 			Method:  http.MethodGet,
 			Path:    websocketEndpoint,
 			Handler: h.wsHandler,
 		},
+		{
+			Method:  http.MethodDelete,
+			Path:    dispatchersEndpoint,
+			Handler: h.disconnectDispatcher,
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    webhooksEndpoint,
+			Handler: h.registerWebhook,
+		},
+		{
+			Method:  http.MethodDelete,
+			Path:    webhookEndpoint,
+			Handler: h.unregisterWebhook,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    eventsEndpoint,
+			Handler: h.getEventsByNonce,
+		},
+		{
+			Method:  http.MethodPut,
+			Path:    filterEndpoint,
+			Handler: h.updateFilter,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    subscriptionStatsEndpoint,
+			Handler: h.getSubscriptionStats,
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    subscriptionsMatchEndpoint,
+			Handler: h.matchEvent,
+		},
 	}
 
 	h.endpoints = endpoints
@@ -48,6 +111,160 @@ func (h *hubGroup) wsHandler(c *gin.Context) {
 	h.facade.ServeHTTP(c.Writer, c.Request)
 }
 
+// disconnectDispatcher will force close the connection of the dispatcher with the provided id
+func (h *hubGroup) disconnectDispatcher(c *gin.Context) {
+	dispatcherID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		shared.JSONResponse(c, http.StatusBadRequest, nil, err.Error())
+		return
+	}
+
+	err = h.facade.DisconnectDispatcher(dispatcherID)
+	if errors.Is(err, common.ErrDispatcherNotFound) {
+		shared.JSONResponse(c, http.StatusNotFound, nil, err.Error())
+		return
+	}
+	if err != nil {
+		shared.JSONResponse(c, http.StatusInternalServerError, nil, err.Error())
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// registerWebhook will register the webhook from the request body and respond with its id
+func (h *hubGroup) registerWebhook(c *gin.Context) {
+	var registration data.WebhookRegistration
+	err := c.ShouldBindJSON(&registration)
+	if err != nil {
+		shared.JSONResponse(c, http.StatusBadRequest, nil, err.Error())
+		return
+	}
+
+	id, err := h.facade.RegisterWebhook(registration)
+	if err != nil {
+		shared.JSONResponse(c, http.StatusBadRequest, nil, err.Error())
+		return
+	}
+
+	shared.JSONResponse(c, http.StatusCreated, data.WebhookRegistrationResponse{ID: id.String()}, "")
+}
+
+// unregisterWebhook will remove the webhook with the provided id
+func (h *hubGroup) unregisterWebhook(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		shared.JSONResponse(c, http.StatusBadRequest, nil, err.Error())
+		return
+	}
+
+	err = h.facade.UnregisterWebhook(id)
+	if errors.Is(err, common.ErrWebhookNotFound) {
+		shared.JSONResponse(c, http.StatusNotFound, nil, err.Error())
+		return
+	}
+	if err != nil {
+		shared.JSONResponse(c, http.StatusInternalServerError, nil, err.Error())
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// getEventsByNonce will respond with the stored block events with nonces between the
+// fromNonce and toNonce query params, inclusive. If toNonce is missing, there is no upper bound
+func (h *hubGroup) getEventsByNonce(c *gin.Context) {
+	fromNonce, err := strconv.ParseUint(c.Query(fromNonceQueryParam), 10, 64)
+	if err != nil {
+		shared.JSONResponse(c, http.StatusBadRequest, nil, fmt.Sprintf("invalid %s: %s", fromNonceQueryParam, err.Error()))
+		return
+	}
+
+	toNonce := uint64(math.MaxUint64)
+	toNonceParam, ok := c.GetQuery(toNonceQueryParam)
+	if ok {
+		toNonce, err = strconv.ParseUint(toNonceParam, 10, 64)
+		if err != nil {
+			shared.JSONResponse(c, http.StatusBadRequest, nil, fmt.Sprintf("invalid %s: %s", toNonceQueryParam, err.Error()))
+			return
+		}
+	}
+
+	blocks, err := h.facade.GetEventsByNonceRange(fromNonce, toNonce)
+	if errors.Is(err, common.ErrInvalidNonceRange) {
+		shared.JSONResponse(c, http.StatusBadRequest, nil, err.Error())
+		return
+	}
+	if errors.Is(err, common.ErrEventStoreNotEnabled) {
+		shared.JSONResponse(c, http.StatusNotFound, nil, err.Error())
+		return
+	}
+	if err != nil {
+		shared.JSONResponse(c, http.StatusInternalServerError, nil, err.Error())
+		return
+	}
+
+	response := data.EventsByNonceResponse{
+		Blocks: make([]data.StoredBlockEventsResponse, 0, len(blocks)),
+	}
+	for _, block := range blocks {
+		response.Blocks = append(response.Blocks, data.StoredBlockEventsResponse{
+			Nonce:     block.Nonce,
+			Hash:      block.Hash,
+			ShardID:   block.ShardID,
+			TimeStamp: block.TimeStamp,
+			Events:    block.Events,
+		})
+	}
+
+	shared.JSONResponse(c, http.StatusOK, response, "")
+}
+
+// updateFilter will replace the hub events filter with the one described by the request body
+func (h *hubGroup) updateFilter(c *gin.Context) {
+	var cfg filters.FilterConfig
+	err := c.ShouldBindJSON(&cfg)
+	if err != nil {
+		shared.JSONResponse(c, http.StatusBadRequest, nil, err.Error())
+		return
+	}
+
+	err = h.facade.UpdateFilter(cfg)
+	if errors.Is(err, filters.ErrInvalidAddressPrefix) {
+		shared.JSONResponse(c, http.StatusBadRequest, nil, err.Error())
+		return
+	}
+	if errors.Is(err, common.ErrHubNotEnabled) {
+		shared.JSONResponse(c, http.StatusNotFound, nil, err.Error())
+		return
+	}
+	if err != nil {
+		shared.JSONResponse(c, http.StatusInternalServerError, nil, err.Error())
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// getSubscriptionStats will respond with the number of connected dispatchers and subscriptions,
+// together with the number of events matched by each subscription within the stats window
+func (h *hubGroup) getSubscriptionStats(c *gin.Context) {
+	shared.JSONResponse(c, http.StatusOK, h.facade.GetSubscriptionStats(), "")
+}
+
+// matchEvent will respond with the subscriptions which would match the event from the
+// request body, without delivering it
+func (h *hubGroup) matchEvent(c *gin.Context) {
+	var event data.Event
+	err := c.ShouldBindJSON(&event)
+	if err != nil {
+		shared.JSONResponse(c, http.StatusBadRequest, nil, err.Error())
+		return
+	}
+
+	shared.JSONResponse(c, http.StatusOK, h.facade.MatchEvent(event), "")
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (h *hubGroup) IsInterfaceNil() bool {
 	return h == nil
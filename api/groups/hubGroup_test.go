@@ -1,15 +1,23 @@
 package groups_test
 
 import (
+	"bytes"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
 	"github.com/multiversx/mx-chain-core-go/core/check"
 	apiErrors "github.com/multiversx/mx-chain-notifier-go/api/errors"
 	"github.com/multiversx/mx-chain-notifier-go/api/groups"
+	"github.com/multiversx/mx-chain-notifier-go/api/middleware"
+	"github.com/multiversx/mx-chain-notifier-go/common"
 	"github.com/multiversx/mx-chain-notifier-go/config"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/filters"
 	"github.com/multiversx/mx-chain-notifier-go/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -23,7 +31,7 @@ func TestNewHubGroup(t *testing.T) {
 	t.Run("nil facade", func(t *testing.T) {
 		t.Parallel()
 
-		hg, err := groups.NewHubGroup(nil)
+		hg, err := groups.NewHubGroup(groups.ArgsHubGroup{})
 		require.True(t, errors.Is(err, apiErrors.ErrNilFacadeHandler))
 		require.True(t, check.IfNil(hg))
 	})
@@ -38,7 +46,7 @@ func TestNewHubGroup(t *testing.T) {
 			},
 		}
 
-		hg, err := groups.NewHubGroup(facade)
+		hg, err := groups.NewHubGroup(groups.ArgsHubGroup{Facade: facade})
 		require.NoError(t, err)
 		require.NotNil(t, hg)
 
@@ -54,12 +62,615 @@ func TestNewHubGroup(t *testing.T) {
 	})
 }
 
+func TestHubGroup_DisconnectDispatcher(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid dispatcher id, bad request", func(t *testing.T) {
+		t.Parallel()
+
+		wasCalled := false
+		facade := &mocks.FacadeStub{
+			DisconnectDispatcherCalled: func(dispatcherID uuid.UUID) error {
+				wasCalled = true
+				return nil
+			},
+		}
+
+		hg, err := groups.NewHubGroup(groups.ArgsHubGroup{Facade: facade})
+		require.Nil(t, err)
+
+		ws := startWebServer(hg, hubPath, getHubRoutesConfig())
+
+		req, _ := http.NewRequest(http.MethodDelete, "/hub/dispatchers/invalid", nil)
+		resp := httptest.NewRecorder()
+		ws.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		assert.False(t, wasCalled)
+	})
+
+	t.Run("unknown dispatcher, not found", func(t *testing.T) {
+		t.Parallel()
+
+		facade := &mocks.FacadeStub{
+			DisconnectDispatcherCalled: func(dispatcherID uuid.UUID) error {
+				return common.ErrDispatcherNotFound
+			},
+		}
+
+		hg, err := groups.NewHubGroup(groups.ArgsHubGroup{Facade: facade})
+		require.Nil(t, err)
+
+		ws := startWebServer(hg, hubPath, getHubRoutesConfig())
+
+		req, _ := http.NewRequest(http.MethodDelete, "/hub/dispatchers/"+uuid.New().String(), nil)
+		resp := httptest.NewRecorder()
+		ws.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+	})
+
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		dispatcherID := uuid.New()
+		facade := &mocks.FacadeStub{
+			DisconnectDispatcherCalled: func(id uuid.UUID) error {
+				require.Equal(t, dispatcherID, id)
+				return nil
+			},
+		}
+
+		hg, err := groups.NewHubGroup(groups.ArgsHubGroup{Facade: facade})
+		require.Nil(t, err)
+
+		ws := startWebServer(hg, hubPath, getHubRoutesConfig())
+
+		req, _ := http.NewRequest(http.MethodDelete, "/hub/dispatchers/"+dispatcherID.String(), nil)
+		resp := httptest.NewRecorder()
+		ws.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusNoContent, resp.Code)
+	})
+
+	t.Run("with jwt auth, should reject requests without valid token", func(t *testing.T) {
+		t.Parallel()
+
+		secretKey := "secret"
+		numDisconnectCalls := 0
+		facade := &mocks.FacadeStub{
+			DisconnectDispatcherCalled: func(id uuid.UUID) error {
+				numDisconnectCalls++
+				return nil
+			},
+		}
+
+		args := groups.ArgsHubGroup{
+			Facade:         facade,
+			AuthMiddleware: middleware.JWTMiddleware(secretKey),
+		}
+		hg, err := groups.NewHubGroup(args)
+		require.Nil(t, err)
+
+		ws := startWebServer(hg, hubPath, getHubRoutesConfig())
+
+		req, _ := http.NewRequest(http.MethodDelete, "/hub/dispatchers/"+uuid.New().String(), nil)
+		resp := httptest.NewRecorder()
+		ws.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusUnauthorized, resp.Code)
+		assert.Equal(t, 0, numDisconnectCalls)
+
+		token, err := middleware.GenerateToken(jwt.MapClaims{"sub": "admin"}, secretKey)
+		require.Nil(t, err)
+
+		req, _ = http.NewRequest(http.MethodDelete, "/hub/dispatchers/"+uuid.New().String(), nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp = httptest.NewRecorder()
+		ws.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusNoContent, resp.Code)
+		assert.Equal(t, 1, numDisconnectCalls)
+	})
+}
+
+type webhookRegistrationResponse struct {
+	Data  data.WebhookRegistrationResponse `json:"data"`
+	Error string                           `json:"error"`
+}
+
+func TestHubGroup_RegisterWebhook(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid body, bad request", func(t *testing.T) {
+		t.Parallel()
+
+		wasCalled := false
+		facade := &mocks.FacadeStub{
+			RegisterWebhookCalled: func(registration data.WebhookRegistration) (uuid.UUID, error) {
+				wasCalled = true
+				return uuid.New(), nil
+			},
+		}
+
+		hg, err := groups.NewHubGroup(groups.ArgsHubGroup{Facade: facade})
+		require.Nil(t, err)
+
+		ws := startWebServer(hg, hubPath, getHubRoutesConfig())
+
+		req, _ := http.NewRequest(http.MethodPost, "/hub/webhooks", bytes.NewBufferString("invalid"))
+		resp := httptest.NewRecorder()
+		ws.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		assert.False(t, wasCalled)
+	})
+
+	t.Run("facade error, bad request", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		facade := &mocks.FacadeStub{
+			RegisterWebhookCalled: func(registration data.WebhookRegistration) (uuid.UUID, error) {
+				return uuid.UUID{}, expectedErr
+			},
+		}
+
+		hg, err := groups.NewHubGroup(groups.ArgsHubGroup{Facade: facade})
+		require.Nil(t, err)
+
+		ws := startWebServer(hg, hubPath, getHubRoutesConfig())
+
+		req, _ := http.NewRequest(http.MethodPost, "/hub/webhooks", bytes.NewBufferString(`{"url": "invalid"}`))
+		resp := httptest.NewRecorder()
+		ws.ServeHTTP(resp, req)
+
+		response := webhookRegistrationResponse{}
+		loadResponse(resp.Body, &response)
+
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		assert.Equal(t, expectedErr.Error(), response.Error)
+	})
+
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		webhookID := uuid.New()
+		expectedRegistration := data.WebhookRegistration{
+			URL:    "http://localhost:8080/events",
+			Secret: "secret",
+			SubscriptionEntries: []data.SubscriptionEntry{
+				{Address: "erd1"},
+			},
+		}
+		facade := &mocks.FacadeStub{
+			RegisterWebhookCalled: func(registration data.WebhookRegistration) (uuid.UUID, error) {
+				require.Equal(t, expectedRegistration, registration)
+				return webhookID, nil
+			},
+		}
+
+		hg, err := groups.NewHubGroup(groups.ArgsHubGroup{Facade: facade})
+		require.Nil(t, err)
+
+		ws := startWebServer(hg, hubPath, getHubRoutesConfig())
+
+		body := `{"url": "http://localhost:8080/events", "secret": "secret", "subscriptionEntries": [{"address": "erd1"}]}`
+		req, _ := http.NewRequest(http.MethodPost, "/hub/webhooks", bytes.NewBufferString(body))
+		resp := httptest.NewRecorder()
+		ws.ServeHTTP(resp, req)
+
+		response := webhookRegistrationResponse{}
+		loadResponse(resp.Body, &response)
+
+		assert.Equal(t, http.StatusCreated, resp.Code)
+		assert.Equal(t, webhookID.String(), response.Data.ID)
+	})
+}
+
+func TestHubGroup_UnregisterWebhook(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid webhook id, bad request", func(t *testing.T) {
+		t.Parallel()
+
+		wasCalled := false
+		facade := &mocks.FacadeStub{
+			UnregisterWebhookCalled: func(id uuid.UUID) error {
+				wasCalled = true
+				return nil
+			},
+		}
+
+		hg, err := groups.NewHubGroup(groups.ArgsHubGroup{Facade: facade})
+		require.Nil(t, err)
+
+		ws := startWebServer(hg, hubPath, getHubRoutesConfig())
+
+		req, _ := http.NewRequest(http.MethodDelete, "/hub/webhooks/invalid", nil)
+		resp := httptest.NewRecorder()
+		ws.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		assert.False(t, wasCalled)
+	})
+
+	t.Run("unknown webhook, not found", func(t *testing.T) {
+		t.Parallel()
+
+		facade := &mocks.FacadeStub{
+			UnregisterWebhookCalled: func(id uuid.UUID) error {
+				return common.ErrWebhookNotFound
+			},
+		}
+
+		hg, err := groups.NewHubGroup(groups.ArgsHubGroup{Facade: facade})
+		require.Nil(t, err)
+
+		ws := startWebServer(hg, hubPath, getHubRoutesConfig())
+
+		req, _ := http.NewRequest(http.MethodDelete, "/hub/webhooks/"+uuid.New().String(), nil)
+		resp := httptest.NewRecorder()
+		ws.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+	})
+
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		webhookID := uuid.New()
+		facade := &mocks.FacadeStub{
+			UnregisterWebhookCalled: func(id uuid.UUID) error {
+				require.Equal(t, webhookID, id)
+				return nil
+			},
+		}
+
+		hg, err := groups.NewHubGroup(groups.ArgsHubGroup{Facade: facade})
+		require.Nil(t, err)
+
+		ws := startWebServer(hg, hubPath, getHubRoutesConfig())
+
+		req, _ := http.NewRequest(http.MethodDelete, "/hub/webhooks/"+webhookID.String(), nil)
+		resp := httptest.NewRecorder()
+		ws.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusNoContent, resp.Code)
+	})
+}
+
+func TestHubGroup_UpdateFilter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid body, bad request", func(t *testing.T) {
+		t.Parallel()
+
+		wasCalled := false
+		facade := &mocks.FacadeStub{
+			UpdateFilterCalled: func(cfg filters.FilterConfig) error {
+				wasCalled = true
+				return nil
+			},
+		}
+
+		hg, err := groups.NewHubGroup(groups.ArgsHubGroup{Facade: facade})
+		require.Nil(t, err)
+
+		ws := startWebServer(hg, hubPath, getHubRoutesConfig())
+
+		req, _ := http.NewRequest(http.MethodPut, "/hub/filter", bytes.NewBufferString("invalid"))
+		resp := httptest.NewRecorder()
+		ws.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		assert.False(t, wasCalled)
+	})
+
+	t.Run("invalid prefix, bad request", func(t *testing.T) {
+		t.Parallel()
+
+		facade := &mocks.FacadeStub{
+			UpdateFilterCalled: func(cfg filters.FilterConfig) error {
+				return fmt.Errorf("%w: erd", filters.ErrInvalidAddressPrefix)
+			},
+		}
+
+		hg, err := groups.NewHubGroup(groups.ArgsHubGroup{Facade: facade})
+		require.Nil(t, err)
+
+		ws := startWebServer(hg, hubPath, getHubRoutesConfig())
+
+		req, _ := http.NewRequest(http.MethodPut, "/hub/filter", bytes.NewBufferString(`{"prefixes": ["erd"]}`))
+		resp := httptest.NewRecorder()
+		ws.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+	})
+
+	t.Run("hub not enabled, not found", func(t *testing.T) {
+		t.Parallel()
+
+		facade := &mocks.FacadeStub{
+			UpdateFilterCalled: func(cfg filters.FilterConfig) error {
+				return common.ErrHubNotEnabled
+			},
+		}
+
+		hg, err := groups.NewHubGroup(groups.ArgsHubGroup{Facade: facade})
+		require.Nil(t, err)
+
+		ws := startWebServer(hg, hubPath, getHubRoutesConfig())
+
+		req, _ := http.NewRequest(http.MethodPut, "/hub/filter", bytes.NewBufferString(`{}`))
+		resp := httptest.NewRecorder()
+		ws.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+	})
+
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		var receivedConfig filters.FilterConfig
+		facade := &mocks.FacadeStub{
+			UpdateFilterCalled: func(cfg filters.FilterConfig) error {
+				receivedConfig = cfg
+				return nil
+			},
+		}
+
+		hg, err := groups.NewHubGroup(groups.ArgsHubGroup{Facade: facade})
+		require.Nil(t, err)
+
+		ws := startWebServer(hg, hubPath, getHubRoutesConfig())
+
+		body := `{"addresses": ["erd1addr"], "prefixes": ["erd1qqqq"], "identifiers": ["swap"]}`
+		req, _ := http.NewRequest(http.MethodPut, "/hub/filter", bytes.NewBufferString(body))
+		resp := httptest.NewRecorder()
+		ws.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusNoContent, resp.Code)
+		assert.Equal(t, filters.FilterConfig{
+			Addresses:   []string{"erd1addr"},
+			Prefixes:    []string{"erd1qqqq"},
+			Identifiers: []string{"swap"},
+		}, receivedConfig)
+	})
+}
+
+type eventsByNonceResponse struct {
+	Data  data.EventsByNonceResponse `json:"data"`
+	Error string                     `json:"error"`
+}
+
+func TestHubGroup_GetEventsByNonce(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid nonce, bad request", func(t *testing.T) {
+		t.Parallel()
+
+		wasCalled := false
+		facade := &mocks.FacadeStub{
+			GetEventsByNonceRangeCalled: func(fromNonce uint64, toNonce uint64) ([]data.BlockEvents, error) {
+				wasCalled = true
+				return nil, nil
+			},
+		}
+
+		hg, err := groups.NewHubGroup(groups.ArgsHubGroup{Facade: facade})
+		require.Nil(t, err)
+
+		ws := startWebServer(hg, hubPath, getHubRoutesConfig())
+
+		for _, query := range []string{"", "?fromNonce=invalid", "?fromNonce=1&toNonce=-2"} {
+			req, _ := http.NewRequest(http.MethodGet, "/hub/events"+query, nil)
+			resp := httptest.NewRecorder()
+			ws.ServeHTTP(resp, req)
+
+			assert.Equal(t, http.StatusBadRequest, resp.Code)
+		}
+		assert.False(t, wasCalled)
+	})
+
+	t.Run("invalid nonce range, bad request", func(t *testing.T) {
+		t.Parallel()
+
+		facade := &mocks.FacadeStub{
+			GetEventsByNonceRangeCalled: func(fromNonce uint64, toNonce uint64) ([]data.BlockEvents, error) {
+				return nil, common.ErrInvalidNonceRange
+			},
+		}
+
+		hg, err := groups.NewHubGroup(groups.ArgsHubGroup{Facade: facade})
+		require.Nil(t, err)
+
+		ws := startWebServer(hg, hubPath, getHubRoutesConfig())
+
+		req, _ := http.NewRequest(http.MethodGet, "/hub/events?fromNonce=3&toNonce=2", nil)
+		resp := httptest.NewRecorder()
+		ws.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+	})
+
+	t.Run("event store not enabled, not found", func(t *testing.T) {
+		t.Parallel()
+
+		facade := &mocks.FacadeStub{
+			GetEventsByNonceRangeCalled: func(fromNonce uint64, toNonce uint64) ([]data.BlockEvents, error) {
+				return nil, common.ErrEventStoreNotEnabled
+			},
+		}
+
+		hg, err := groups.NewHubGroup(groups.ArgsHubGroup{Facade: facade})
+		require.Nil(t, err)
+
+		ws := startWebServer(hg, hubPath, getHubRoutesConfig())
+
+		req, _ := http.NewRequest(http.MethodGet, "/hub/events?fromNonce=1", nil)
+		resp := httptest.NewRecorder()
+		ws.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+	})
+
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		var requestedRange []uint64
+		facade := &mocks.FacadeStub{
+			GetEventsByNonceRangeCalled: func(fromNonce uint64, toNonce uint64) ([]data.BlockEvents, error) {
+				requestedRange = append(requestedRange, fromNonce, toNonce)
+				return []data.BlockEvents{
+					{Hash: "hash1", Nonce: 1, ShardID: 2, Events: []data.Event{{Identifier: "id1"}}},
+				}, nil
+			},
+		}
+
+		hg, err := groups.NewHubGroup(groups.ArgsHubGroup{Facade: facade})
+		require.Nil(t, err)
+
+		ws := startWebServer(hg, hubPath, getHubRoutesConfig())
+
+		req, _ := http.NewRequest(http.MethodGet, "/hub/events?fromNonce=1&toNonce=5", nil)
+		resp := httptest.NewRecorder()
+		ws.ServeHTTP(resp, req)
+
+		var apiResp eventsByNonceResponse
+		loadResponse(resp.Body, &apiResp)
+
+		require.Equal(t, http.StatusOK, resp.Code)
+		require.Equal(t, []uint64{1, 5}, requestedRange)
+		expectedBlocks := []data.StoredBlockEventsResponse{
+			{Nonce: 1, Hash: "hash1", ShardID: 2, Events: []data.Event{{Identifier: "id1"}}},
+		}
+		require.Equal(t, expectedBlocks, apiResp.Data.Blocks)
+	})
+}
+
+type subscriptionStatsResponse struct {
+	Data  data.SubscriptionStatsResponse `json:"data"`
+	Error string                         `json:"error"`
+}
+
+func TestHubGroup_GetSubscriptionStats(t *testing.T) {
+	t.Parallel()
+
+	dispatcherID := uuid.New()
+	expectedStats := data.SubscriptionStatsResponse{
+		NumDispatchers:   1,
+		NumSubscriptions: 2,
+		WindowInSec:      300,
+		Subscriptions: []data.SubscriptionMatchStats{
+			{ID: 2, DispatcherID: dispatcherID, Address: "erd1", MatchLevel: "match:address", NumMatches: 5},
+			{ID: 1, DispatcherID: dispatcherID, Identifier: "swap", MatchLevel: "match:identifier"},
+		},
+	}
+	facade := &mocks.FacadeStub{
+		GetSubscriptionStatsCalled: func() data.SubscriptionStatsResponse {
+			return expectedStats
+		},
+	}
+
+	hg, err := groups.NewHubGroup(groups.ArgsHubGroup{Facade: facade})
+	require.Nil(t, err)
+
+	ws := startWebServer(hg, hubPath, getHubRoutesConfig())
+
+	req, _ := http.NewRequest(http.MethodGet, "/hub/subscriptions/stats", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	var apiResp subscriptionStatsResponse
+	loadResponse(resp.Body, &apiResp)
+
+	require.Equal(t, http.StatusOK, resp.Code)
+	require.Equal(t, expectedStats, apiResp.Data)
+}
+
+type eventMatchResponse struct {
+	Data  data.EventMatchResponse `json:"data"`
+	Error string                  `json:"error"`
+}
+
+func TestHubGroup_MatchEvent(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid body, bad request", func(t *testing.T) {
+		t.Parallel()
+
+		wasCalled := false
+		facade := &mocks.FacadeStub{
+			MatchEventCalled: func(event data.Event) data.EventMatchResponse {
+				wasCalled = true
+				return data.EventMatchResponse{}
+			},
+		}
+
+		hg, err := groups.NewHubGroup(groups.ArgsHubGroup{Facade: facade})
+		require.Nil(t, err)
+
+		ws := startWebServer(hg, hubPath, getHubRoutesConfig())
+
+		req, _ := http.NewRequest(http.MethodPost, "/hub/subscriptions/match", bytes.NewBufferString("invalid"))
+		resp := httptest.NewRecorder()
+		ws.ServeHTTP(resp, req)
+
+		require.Equal(t, http.StatusBadRequest, resp.Code)
+		require.False(t, wasCalled)
+	})
+
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		dispatcherID := uuid.New()
+		expectedResponse := data.EventMatchResponse{
+			DispatcherIDs: []uuid.UUID{dispatcherID},
+			Subscriptions: []data.Subscription{
+				{ID: 1, DispatcherID: dispatcherID, Address: "erd1", MatchLevel: "match:address", EventType: common.PushLogsAndEvents},
+			},
+		}
+		facade := &mocks.FacadeStub{
+			MatchEventCalled: func(event data.Event) data.EventMatchResponse {
+				require.Equal(t, data.Event{Address: "erd1", Identifier: "swap", Topics: [][]byte{[]byte("topic1")}}, event)
+				return expectedResponse
+			},
+		}
+
+		hg, err := groups.NewHubGroup(groups.ArgsHubGroup{Facade: facade})
+		require.Nil(t, err)
+
+		ws := startWebServer(hg, hubPath, getHubRoutesConfig())
+
+		body := `{"address": "erd1", "identifier": "swap", "topics": ["dG9waWMx"]}`
+		req, _ := http.NewRequest(http.MethodPost, "/hub/subscriptions/match", bytes.NewBufferString(body))
+		resp := httptest.NewRecorder()
+		ws.ServeHTTP(resp, req)
+
+		var apiResp eventMatchResponse
+		loadResponse(resp.Body, &apiResp)
+
+		require.Equal(t, http.StatusOK, resp.Code)
+		require.Equal(t, expectedResponse.DispatcherIDs, apiResp.Data.DispatcherIDs)
+		require.Equal(t, len(expectedResponse.Subscriptions), len(apiResp.Data.Subscriptions))
+		require.Equal(t, expectedResponse.Subscriptions[0].Address, apiResp.Data.Subscriptions[0].Address)
+	})
+}
+
 func getHubRoutesConfig() config.APIRoutesConfig {
 	return config.APIRoutesConfig{
 		APIPackages: map[string]config.APIPackageConfig{
 			"hub": {
 				Routes: []config.RouteConfig{
 					{Name: "/ws", Open: true},
+					{Name: "/dispatchers/:id", Open: true, Auth: true},
+					{Name: "/webhooks", Open: true, Auth: true},
+					{Name: "/webhooks/:id", Open: true, Auth: true},
+					{Name: "/events", Open: true},
+					{Name: "/filter", Open: true, Auth: true},
+					{Name: "/subscriptions/stats", Open: true, Auth: true},
+					{Name: "/subscriptions/match", Open: true, Auth: true},
 				},
 			},
 		},
@@ -3,16 +3,24 @@ package groups
 import (
 	"net/http"
 
+	"github.com/google/uuid"
 	"github.com/multiversx/mx-chain-core-go/core"
 	"github.com/multiversx/mx-chain-core-go/data/block"
 	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/filters"
 )
 
+// CorrelatedPayloadHandler defines the behaviour of a payload handler which accepts the
+// correlation id supplied by the observer
+type CorrelatedPayloadHandler interface {
+	ProcessPayloadWithCorrelationID(payload []byte, topic string, version uint32, correlationID string) error
+}
+
 // EventsFacadeHandler defines the behavior of a facade handler needed for events group
 type EventsFacadeHandler interface {
 	HandlePushEvents(events data.ArgsSaveBlockData) error
-	HandleRevertEvents(revertBlock data.RevertBlock)
-	HandleFinalizedEvents(finalizedBlock data.FinalizedBlock)
+	HandleRevertEvents(revertBlock data.RevertBlock) error
+	HandleFinalizedEvents(finalizedBlock data.FinalizedBlock) error
 	GetConnectorUserAndPass() (string, string)
 	IsInterfaceNil() bool
 }
@@ -20,6 +28,20 @@ type EventsFacadeHandler interface {
 // HubFacadeHandler defines the behavior of a facade handler needed for hub group
 type HubFacadeHandler interface {
 	ServeHTTP(w http.ResponseWriter, r *http.Request)
+	DisconnectDispatcher(dispatcherID uuid.UUID) error
+	GetSubscriptionStats() data.SubscriptionStatsResponse
+	MatchEvent(event data.Event) data.EventMatchResponse
+	RegisterWebhook(registration data.WebhookRegistration) (uuid.UUID, error)
+	UnregisterWebhook(id uuid.UUID) error
+	GetEventsByNonceRange(fromNonce uint64, toNonce uint64) ([]data.BlockEvents, error)
+	UpdateFilter(cfg filters.FilterConfig) error
+	IsInterfaceNil() bool
+}
+
+// RabbitMqFacadeHandler defines the behavior of a facade handler needed for rabbitmq group
+type RabbitMqFacadeHandler interface {
+	RegisterConsumer(subscription data.ConsumerSubscription) error
+	UnregisterConsumer(consumerID string) error
 	IsInterfaceNil() bool
 }
 
@@ -0,0 +1,55 @@
+package groups
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-notifier-go/api/errors"
+	"github.com/multiversx/mx-chain-notifier-go/api/shared"
+)
+
+// prometheusEndpoint is empty since the metrics are exposed on the group root path
+const prometheusEndpoint = ""
+
+type metricsGroup struct {
+	*baseGroup
+	facade shared.FacadeHandler
+}
+
+// NewMetricsGroup registers handlers for the /metrics group
+// It exposes in prometheus format the metrics of the components active for the configured api type
+func NewMetricsGroup(facade shared.FacadeHandler) (*metricsGroup, error) {
+	if check.IfNil(facade) {
+		return nil, fmt.Errorf("%w for metrics group", errors.ErrNilFacadeHandler)
+	}
+
+	mg := &metricsGroup{
+		facade:    facade,
+		baseGroup: newBaseGroup(),
+	}
+
+	endpoints := []*shared.EndpointHandlerData{
+		{
+			Path:    prometheusEndpoint,
+			Handler: mg.getPrometheusMetrics,
+			Method:  http.MethodGet,
+		},
+	}
+	mg.endpoints = endpoints
+
+	return mg, nil
+}
+
+// getPrometheusMetrics will expose notifier's metrics in prometheus text exposition format
+func (mg *metricsGroup) getPrometheusMetrics(c *gin.Context) {
+	metricsResults := mg.facade.GetMetricsForPrometheus()
+
+	c.String(http.StatusOK, metricsResults)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (mg *metricsGroup) IsInterfaceNil() bool {
+	return mg == nil
+}
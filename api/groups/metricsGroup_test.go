@@ -0,0 +1,79 @@
+package groups_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	apiErrors "github.com/multiversx/mx-chain-notifier-go/api/errors"
+	"github.com/multiversx/mx-chain-notifier-go/api/groups"
+	"github.com/multiversx/mx-chain-notifier-go/config"
+	"github.com/multiversx/mx-chain-notifier-go/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const metricsPath = "/metrics"
+
+func TestNewMetricsGroup(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil facade should error", func(t *testing.T) {
+		t.Parallel()
+
+		mg, err := groups.NewMetricsGroup(nil)
+
+		require.True(t, errors.Is(err, apiErrors.ErrNilFacadeHandler))
+		require.True(t, check.IfNil(mg))
+	})
+
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		mg, err := groups.NewMetricsGroup(&mocks.FacadeStub{})
+
+		assert.False(t, check.IfNil(mg))
+		assert.Nil(t, err)
+	})
+}
+
+func TestMetricsGroup_GetPrometheusMetrics(t *testing.T) {
+	t.Parallel()
+
+	expectedMetrics := "# TYPE hub_dispatchers gauge\nhub_dispatchers 2\n\n"
+	facade := &mocks.FacadeStub{
+		GetMetricsForPrometheusCalled: func() string {
+			return expectedMetrics
+		},
+	}
+
+	metricsGroup, err := groups.NewMetricsGroup(facade)
+	require.NoError(t, err)
+
+	ws := startWebServer(metricsGroup, metricsPath, getMetricsRoutesConfig())
+
+	req, _ := http.NewRequest("GET", "/metrics", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	bodyBytes, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	require.Equal(t, http.StatusOK, resp.Code)
+	require.Equal(t, expectedMetrics, string(bodyBytes))
+}
+
+func getMetricsRoutesConfig() config.APIRoutesConfig {
+	return config.APIRoutesConfig{
+		APIPackages: map[string]config.APIPackageConfig{
+			"metrics": {
+				Routes: []config.RouteConfig{
+					{Name: "", Open: true},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,101 @@
+package groups
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	apiErrors "github.com/multiversx/mx-chain-notifier-go/api/errors"
+	"github.com/multiversx/mx-chain-notifier-go/api/shared"
+	"github.com/multiversx/mx-chain-notifier-go/common"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+)
+
+const (
+	subscribeEndpoint         = "/subscribe"
+	consumerSubscribeEndpoint = "/subscribe/:consumerId"
+)
+
+// ArgsRabbitMqGroup defines the arguments needed to create a new rabbitmq group component
+type ArgsRabbitMqGroup struct {
+	Facade         RabbitMqFacadeHandler
+	AuthMiddleware gin.HandlerFunc
+}
+
+type rabbitMqGroup struct {
+	*baseGroup
+	facade RabbitMqFacadeHandler
+}
+
+// NewRabbitMqGroup registers handlers for the /rabbitmq group, which holds the subscriptions
+// of the rabbitMQ consumers. If provided, the auth middleware is applied to the endpoints
+// with "Auth" flag enabled
+func NewRabbitMqGroup(args ArgsRabbitMqGroup) (*rabbitMqGroup, error) {
+	if check.IfNil(args.Facade) {
+		return nil, fmt.Errorf("%w for rabbitmq group", apiErrors.ErrNilFacadeHandler)
+	}
+
+	rg := &rabbitMqGroup{
+		facade:    args.Facade,
+		baseGroup: newBaseGroup(),
+	}
+
+	if args.AuthMiddleware != nil {
+		rg.authMiddleware = args.AuthMiddleware
+	}
+
+	rg.endpoints = []*shared.EndpointHandlerData{
+		{
+			Method:  http.MethodPost,
+			Path:    subscribeEndpoint,
+			Handler: rg.subscribe,
+		},
+		{
+			Method:  http.MethodDelete,
+			Path:    consumerSubscribeEndpoint,
+			Handler: rg.unsubscribe,
+		},
+	}
+
+	return rg, nil
+}
+
+// subscribe will set the subscriptions of the consumer from the request body
+func (rg *rabbitMqGroup) subscribe(c *gin.Context) {
+	var subscription data.ConsumerSubscription
+	err := c.ShouldBindJSON(&subscription)
+	if err != nil {
+		shared.JSONResponse(c, http.StatusBadRequest, nil, err.Error())
+		return
+	}
+
+	err = rg.facade.RegisterConsumer(subscription)
+	if err != nil {
+		shared.JSONResponse(c, http.StatusBadRequest, nil, err.Error())
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// unsubscribe will remove the subscriptions of the consumer with the provided id
+func (rg *rabbitMqGroup) unsubscribe(c *gin.Context) {
+	err := rg.facade.UnregisterConsumer(c.Param("consumerId"))
+	if errors.Is(err, common.ErrConsumerNotFound) {
+		shared.JSONResponse(c, http.StatusNotFound, nil, err.Error())
+		return
+	}
+	if err != nil {
+		shared.JSONResponse(c, http.StatusInternalServerError, nil, err.Error())
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (rg *rabbitMqGroup) IsInterfaceNil() bool {
+	return rg == nil
+}
@@ -0,0 +1,189 @@
+package groups_test
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	apiErrors "github.com/multiversx/mx-chain-notifier-go/api/errors"
+	"github.com/multiversx/mx-chain-notifier-go/api/groups"
+	"github.com/multiversx/mx-chain-notifier-go/common"
+	"github.com/multiversx/mx-chain-notifier-go/config"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const rabbitMqPath = "/rabbitmq"
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func TestNewRabbitMqGroup(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil facade", func(t *testing.T) {
+		t.Parallel()
+
+		rg, err := groups.NewRabbitMqGroup(groups.ArgsRabbitMqGroup{})
+		require.True(t, errors.Is(err, apiErrors.ErrNilFacadeHandler))
+		require.True(t, check.IfNil(rg))
+	})
+
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		rg, err := groups.NewRabbitMqGroup(groups.ArgsRabbitMqGroup{Facade: &mocks.FacadeStub{}})
+		require.Nil(t, err)
+		require.False(t, check.IfNil(rg))
+		require.Equal(t, 0, len(rg.GetAdditionalMiddlewares()))
+	})
+}
+
+func TestRabbitMqGroup_Subscribe(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid body, bad request", func(t *testing.T) {
+		t.Parallel()
+
+		wasCalled := false
+		facade := &mocks.FacadeStub{
+			RegisterConsumerCalled: func(subscription data.ConsumerSubscription) error {
+				wasCalled = true
+				return nil
+			},
+		}
+
+		rg, err := groups.NewRabbitMqGroup(groups.ArgsRabbitMqGroup{Facade: facade})
+		require.Nil(t, err)
+
+		ws := startWebServer(rg, rabbitMqPath, getRabbitMqRoutesConfig())
+
+		req, _ := http.NewRequest(http.MethodPost, "/rabbitmq/subscribe", bytes.NewBufferString("invalid"))
+		resp := httptest.NewRecorder()
+		ws.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		assert.False(t, wasCalled)
+	})
+
+	t.Run("facade error, bad request", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		facade := &mocks.FacadeStub{
+			RegisterConsumerCalled: func(subscription data.ConsumerSubscription) error {
+				return expectedErr
+			},
+		}
+
+		rg, err := groups.NewRabbitMqGroup(groups.ArgsRabbitMqGroup{Facade: facade})
+		require.Nil(t, err)
+
+		ws := startWebServer(rg, rabbitMqPath, getRabbitMqRoutesConfig())
+
+		req, _ := http.NewRequest(http.MethodPost, "/rabbitmq/subscribe", bytes.NewBufferString(`{"consumerId": ""}`))
+		resp := httptest.NewRecorder()
+		ws.ServeHTTP(resp, req)
+
+		response := errorResponse{}
+		loadResponse(resp.Body, &response)
+
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		assert.Equal(t, expectedErr.Error(), response.Error)
+	})
+
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		expectedSubscription := data.ConsumerSubscription{
+			ConsumerID: "consumer1",
+			SubscriptionEntries: []data.SubscriptionEntry{
+				{Address: "erd1", Identifiers: []string{"transfer"}},
+			},
+		}
+		facade := &mocks.FacadeStub{
+			RegisterConsumerCalled: func(subscription data.ConsumerSubscription) error {
+				require.Equal(t, expectedSubscription, subscription)
+				return nil
+			},
+		}
+
+		rg, err := groups.NewRabbitMqGroup(groups.ArgsRabbitMqGroup{Facade: facade})
+		require.Nil(t, err)
+
+		ws := startWebServer(rg, rabbitMqPath, getRabbitMqRoutesConfig())
+
+		body := `{"consumerId": "consumer1", "subscriptionEntries": [{"address": "erd1", "identifiers": ["transfer"]}]}`
+		req, _ := http.NewRequest(http.MethodPost, "/rabbitmq/subscribe", bytes.NewBufferString(body))
+		resp := httptest.NewRecorder()
+		ws.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusNoContent, resp.Code)
+	})
+}
+
+func TestRabbitMqGroup_Unsubscribe(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unknown consumer, not found", func(t *testing.T) {
+		t.Parallel()
+
+		facade := &mocks.FacadeStub{
+			UnregisterConsumerCalled: func(consumerID string) error {
+				return common.ErrConsumerNotFound
+			},
+		}
+
+		rg, err := groups.NewRabbitMqGroup(groups.ArgsRabbitMqGroup{Facade: facade})
+		require.Nil(t, err)
+
+		ws := startWebServer(rg, rabbitMqPath, getRabbitMqRoutesConfig())
+
+		req, _ := http.NewRequest(http.MethodDelete, "/rabbitmq/subscribe/consumer1", nil)
+		resp := httptest.NewRecorder()
+		ws.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+	})
+
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		facade := &mocks.FacadeStub{
+			UnregisterConsumerCalled: func(consumerID string) error {
+				require.Equal(t, "consumer1", consumerID)
+				return nil
+			},
+		}
+
+		rg, err := groups.NewRabbitMqGroup(groups.ArgsRabbitMqGroup{Facade: facade})
+		require.Nil(t, err)
+
+		ws := startWebServer(rg, rabbitMqPath, getRabbitMqRoutesConfig())
+
+		req, _ := http.NewRequest(http.MethodDelete, "/rabbitmq/subscribe/consumer1", nil)
+		resp := httptest.NewRecorder()
+		ws.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusNoContent, resp.Code)
+	})
+}
+
+func getRabbitMqRoutesConfig() config.APIRoutesConfig {
+	return config.APIRoutesConfig{
+		APIPackages: map[string]config.APIPackageConfig{
+			"rabbitmq": {
+				Routes: []config.RouteConfig{
+					{Name: "/subscribe", Open: true, Auth: true},
+					{Name: "/subscribe/:consumerId", Open: true, Auth: true},
+				},
+			},
+		},
+	}
+}
@@ -13,6 +13,8 @@ import (
 const (
 	metricsPath           = "/metrics"
 	prometheusMetricsPath = "/prometheus-metrics"
+	processedBlocksPath   = "/processed-blocks"
+	payloadVersionsPath   = "/payload-versions"
 )
 
 type statusGroup struct {
@@ -42,6 +44,16 @@ func NewStatusGroup(facade shared.FacadeHandler) (*statusGroup, error) {
 			Handler: sg.getPrometheusMetrics,
 			Method:  http.MethodGet,
 		},
+		{
+			Path:    processedBlocksPath,
+			Handler: sg.getProcessedBlocks,
+			Method:  http.MethodGet,
+		},
+		{
+			Path:    payloadVersionsPath,
+			Handler: sg.getPayloadVersions,
+			Method:  http.MethodGet,
+		},
 	}
 	sg.endpoints = endpoints
 
@@ -62,6 +74,20 @@ func (sg *statusGroup) getPrometheusMetrics(c *gin.Context) {
 	c.String(http.StatusOK, metricsResults)
 }
 
+// getProcessedBlocks will expose the last processed block of each shard
+func (sg *statusGroup) getProcessedBlocks(c *gin.Context) {
+	processedBlocks := sg.facade.GetProcessedBlocks()
+
+	shared.JSONResponse(c, http.StatusOK, gin.H{"processedBlocks": processedBlocks}, "")
+}
+
+// getPayloadVersions will expose the supported observer payload versions
+func (sg *statusGroup) getPayloadVersions(c *gin.Context) {
+	payloadVersions := sg.facade.GetPayloadVersions()
+
+	shared.JSONResponse(c, http.StatusOK, gin.H{"payloadVersions": payloadVersions}, "")
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (sg *statusGroup) IsInterfaceNil() bool {
 	return sg == nil
@@ -26,6 +26,20 @@ type statusMetricsResponse struct {
 	Error string `json:"error"`
 }
 
+type processedBlocksResponse struct {
+	Data struct {
+		ProcessedBlocks []data.ProcessedBlock `json:"processedBlocks"`
+	}
+	Error string `json:"error"`
+}
+
+type payloadVersionsResponse struct {
+	Data struct {
+		PayloadVersions data.PayloadVersionsResponse `json:"payloadVersions"`
+	}
+	Error string `json:"error"`
+}
+
 func TestNewStatusGroup(t *testing.T) {
 	t.Parallel()
 
@@ -105,6 +119,64 @@ func TestGetPrometheusMetrics_ShouldWork(t *testing.T) {
 	require.Equal(t, expectedMetrics, string(bodyBytes))
 }
 
+func TestGetProcessedBlocks_ShouldWork(t *testing.T) {
+	t.Parallel()
+
+	expectedBlocks := []data.ProcessedBlock{
+		{ShardID: 0, Nonce: 10, Hash: "hash10", TimeStamp: 60, ProcessedAt: 1000},
+		{ShardID: 1, Nonce: 11, Hash: "hash11", TimeStamp: 66, ProcessedAt: 1001},
+	}
+	facade := &mocks.FacadeStub{
+		GetProcessedBlocksCalled: func() []data.ProcessedBlock {
+			return expectedBlocks
+		},
+	}
+
+	statusGroup, err := groups.NewStatusGroup(facade)
+	require.Nil(t, err)
+
+	ws := startWebServer(statusGroup, statusPath, getStatusRoutesConfig())
+
+	req, _ := http.NewRequest("GET", "/status/processed-blocks", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	var apiResp processedBlocksResponse
+	loadResponse(resp.Body, &apiResp)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	require.Equal(t, expectedBlocks, apiResp.Data.ProcessedBlocks)
+}
+
+func TestGetPayloadVersions(t *testing.T) {
+	t.Parallel()
+
+	expectedVersions := data.PayloadVersionsResponse{
+		SupportedVersions: []uint32{0, 1},
+		VersionFallback:   true,
+	}
+	facade := &mocks.FacadeStub{
+		GetPayloadVersionsCalled: func() data.PayloadVersionsResponse {
+			return expectedVersions
+		},
+	}
+
+	statusGroup, err := groups.NewStatusGroup(facade)
+	require.Nil(t, err)
+
+	ws := startWebServer(statusGroup, "/status", getStatusRoutesConfig())
+
+	req, _ := http.NewRequest("GET", "/status/payload-versions", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	var apiResp payloadVersionsResponse
+	loadResponse(resp.Body, &apiResp)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	require.Equal(t, expectedVersions, apiResp.Data.PayloadVersions)
+}
+
 func TestStatusGroup_IsInterfaceNil(t *testing.T) {
 	t.Parallel()
 
@@ -122,6 +194,8 @@ func getStatusRoutesConfig() config.APIRoutesConfig {
 				Routes: []config.RouteConfig{
 					{Name: "/metrics", Open: true},
 					{Name: "/prometheus-metrics", Open: true},
+					{Name: "/processed-blocks", Open: true},
+					{Name: "/payload-versions", Open: true},
 				},
 			},
 		},
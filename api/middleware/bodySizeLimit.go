@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/multiversx/mx-chain-notifier-go/api/shared"
+)
+
+// DefaultMaxRequestBodyBytes defines the request body size limit used when none is configured
+const DefaultMaxRequestBodyBytes = 4 * 1024 * 1024
+
+// BodySizeLimitMiddleware limits the size of the request body to maxBytes. The body is
+// read before the handler runs, so oversized requests are rejected with 413 status code
+// regardless of how the handler consumes the body
+func BodySizeLimitMiddleware(maxBytes int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.ContentLength > maxBytes {
+			abortWithBodyTooLarge(c)
+			return
+		}
+
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBytes)
+		body, err := ioutil.ReadAll(c.Request.Body)
+		if err != nil {
+			if int64(len(body)) >= maxBytes {
+				abortWithBodyTooLarge(c)
+				return
+			}
+
+			shared.JSONResponse(c, http.StatusBadRequest, nil, err.Error())
+			c.Abort()
+			return
+		}
+
+		c.Request.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+		c.Next()
+	}
+}
+
+func abortWithBodyTooLarge(c *gin.Context) {
+	log.Debug("request body too large", "path", c.FullPath())
+	shared.JSONResponse(c, http.StatusRequestEntityTooLarge, nil, ErrRequestBodyTooLarge.Error())
+	c.Abort()
+}
@@ -0,0 +1,83 @@
+package middleware_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/multiversx/mx-chain-notifier-go/api/middleware"
+	"github.com/stretchr/testify/require"
+)
+
+const maxBodyBytes = 16
+
+func startBodyLimitedServer(handlerReadBody *[]byte) *gin.Engine {
+	ws := gin.New()
+	ws.Use(middleware.BodySizeLimitMiddleware(maxBodyBytes))
+	ws.POST("/push", func(c *gin.Context) {
+		body, err := ioutil.ReadAll(c.Request.Body)
+		if err != nil {
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+
+		*handlerReadBody = body
+		c.Status(http.StatusOK)
+	})
+
+	return ws
+}
+
+func TestBodySizeLimitMiddleware(t *testing.T) {
+	t.Parallel()
+
+	t.Run("payload under the limit should pass", func(t *testing.T) {
+		t.Parallel()
+
+		var handlerReadBody []byte
+		ws := startBodyLimitedServer(&handlerReadBody)
+
+		payload := bytes.Repeat([]byte("a"), maxBodyBytes)
+		req, _ := http.NewRequest("POST", "/push", bytes.NewBuffer(payload))
+		resp := httptest.NewRecorder()
+		ws.ServeHTTP(resp, req)
+
+		require.Equal(t, http.StatusOK, resp.Code)
+		require.Equal(t, payload, handlerReadBody)
+	})
+
+	t.Run("payload over the limit should fail", func(t *testing.T) {
+		t.Parallel()
+
+		var handlerReadBody []byte
+		ws := startBodyLimitedServer(&handlerReadBody)
+
+		payload := bytes.Repeat([]byte("a"), maxBodyBytes+1)
+		req, _ := http.NewRequest("POST", "/push", bytes.NewBuffer(payload))
+		resp := httptest.NewRecorder()
+		ws.ServeHTTP(resp, req)
+
+		require.Equal(t, http.StatusRequestEntityTooLarge, resp.Code)
+		require.Nil(t, handlerReadBody)
+		require.Contains(t, resp.Body.String(), middleware.ErrRequestBodyTooLarge.Error())
+	})
+
+	t.Run("payload over the limit without content length should fail", func(t *testing.T) {
+		t.Parallel()
+
+		var handlerReadBody []byte
+		ws := startBodyLimitedServer(&handlerReadBody)
+
+		payload := bytes.Repeat([]byte("a"), maxBodyBytes+1)
+		req, _ := http.NewRequest("POST", "/push", ioutil.NopCloser(bytes.NewBuffer(payload)))
+		req.ContentLength = -1
+		resp := httptest.NewRecorder()
+		ws.ServeHTTP(resp, req)
+
+		require.Equal(t, http.StatusRequestEntityTooLarge, resp.Code)
+		require.Nil(t, handlerReadBody)
+	})
+}
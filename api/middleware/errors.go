@@ -0,0 +1,30 @@
+package middleware
+
+import "errors"
+
+// ErrTooManyRequests signals that the number of requests exceeded the configured rate limit
+var ErrTooManyRequests = errors.New("too many requests")
+
+// ErrInvalidRequestsPerSecond signals that an invalid number of requests per second has been provided
+var ErrInvalidRequestsPerSecond = errors.New("invalid number of requests per second")
+
+// ErrInvalidBurst signals that an invalid burst value has been provided
+var ErrInvalidBurst = errors.New("invalid burst value")
+
+// ErrMissingAuthToken signals that the bearer token is missing from the request
+var ErrMissingAuthToken = errors.New("missing bearer auth token")
+
+// ErrInvalidAuthToken signals that an invalid auth token has been provided
+var ErrInvalidAuthToken = errors.New("invalid auth token")
+
+// ErrInvalidSigningMethod signals that the token has been signed with an unexpected method
+var ErrInvalidSigningMethod = errors.New("invalid signing method")
+
+// ErrEmptyJWTSecretKey signals that an empty jwt secret key has been provided
+var ErrEmptyJWTSecretKey = errors.New("empty jwt secret key")
+
+// ErrAdminEndpointsDisabled signals that the admin endpoints are disabled, since no authentication is configured
+var ErrAdminEndpointsDisabled = errors.New("admin endpoints are disabled without authentication")
+
+// ErrRequestBodyTooLarge signals that the request body exceeded the configured size limit
+var ErrRequestBodyTooLarge = errors.New("request body too large")
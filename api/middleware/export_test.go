@@ -0,0 +1,8 @@
+package middleware
+
+import "time"
+
+// SetGetTimeHandler -
+func (rl *rateLimiter) SetGetTimeHandler(handler func() time.Time) {
+	rl.getTimeHandler = handler
+}
@@ -0,0 +1,123 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	acceptEncodingHeader  = "Accept-Encoding"
+	contentEncodingHeader = "Content-Encoding"
+	contentLengthHeader   = "Content-Length"
+	varyHeader            = "Vary"
+	upgradeHeader         = "Upgrade"
+	gzipEncoding          = "gzip"
+)
+
+// gzipResponseWriter buffers the response, so that the compression can be decided
+// based on the response size, after the handler has finished
+type gzipResponseWriter struct {
+	gin.ResponseWriter
+	buffer     bytes.Buffer
+	statusCode int
+}
+
+// WriteHeader will keep the status code until the response is flushed
+func (gw *gzipResponseWriter) WriteHeader(code int) {
+	gw.statusCode = code
+}
+
+// WriteHeaderNow does nothing, the header is written when the response is flushed
+func (gw *gzipResponseWriter) WriteHeaderNow() {
+}
+
+// Write will buffer the provided data
+func (gw *gzipResponseWriter) Write(data []byte) (int, error) {
+	return gw.buffer.Write(data)
+}
+
+// WriteString will buffer the provided string
+func (gw *gzipResponseWriter) WriteString(s string) (int, error) {
+	return gw.buffer.WriteString(s)
+}
+
+// Status returns the response status code
+func (gw *gzipResponseWriter) Status() int {
+	return gw.statusCode
+}
+
+// Size returns the number of bytes buffered
+func (gw *gzipResponseWriter) Size() int {
+	return gw.buffer.Len()
+}
+
+// Written returns true if anything has been buffered
+func (gw *gzipResponseWriter) Written() bool {
+	return gw.buffer.Len() > 0
+}
+
+// GzipMiddleware compresses with gzip the responses with a body of at least
+// minCompressBytes, if the client accepts gzip encoding
+func GzipMiddleware(minCompressBytes int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !shouldCompress(c.Request) {
+			c.Next()
+			return
+		}
+
+		writer := c.Writer
+		gw := &gzipResponseWriter{
+			ResponseWriter: writer,
+			statusCode:     http.StatusOK,
+		}
+		c.Writer = gw
+
+		c.Next()
+
+		c.Writer = writer
+		flushResponse(writer, gw, minCompressBytes)
+	}
+}
+
+func shouldCompress(req *http.Request) bool {
+	// websocket upgrade requests take over the connection, they can not be buffered
+	if req.Header.Get(upgradeHeader) != "" {
+		return false
+	}
+
+	return strings.Contains(req.Header.Get(acceptEncodingHeader), gzipEncoding)
+}
+
+func flushResponse(writer gin.ResponseWriter, gw *gzipResponseWriter, minCompressBytes int) {
+	body := gw.buffer.Bytes()
+
+	isAlreadyEncoded := writer.Header().Get(contentEncodingHeader) != ""
+	if len(body) < minCompressBytes || len(body) == 0 || isAlreadyEncoded {
+		writer.WriteHeader(gw.statusCode)
+		_, err := writer.Write(body)
+		if err != nil {
+			log.Debug("failed to write response", "err", err.Error())
+		}
+		return
+	}
+
+	writer.Header().Set(contentEncodingHeader, gzipEncoding)
+	writer.Header().Add(varyHeader, acceptEncodingHeader)
+	writer.Header().Del(contentLengthHeader)
+	writer.WriteHeader(gw.statusCode)
+
+	gz := gzip.NewWriter(writer)
+	_, err := gz.Write(body)
+	if err != nil {
+		log.Debug("failed to write compressed response", "err", err.Error())
+	}
+
+	err = gz.Close()
+	if err != nil {
+		log.Debug("failed to close gzip writer", "err", err.Error())
+	}
+}
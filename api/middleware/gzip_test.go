@@ -0,0 +1,121 @@
+package middleware_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/multiversx/mx-chain-notifier-go/api/middleware"
+	"github.com/stretchr/testify/require"
+)
+
+const minCompressBytes = 100
+
+type testResponse struct {
+	Events []string `json:"events"`
+}
+
+func startGzipServer(response testResponse) *gin.Engine {
+	ws := gin.New()
+	ws.Use(middleware.GzipMiddleware(minCompressBytes))
+	ws.GET("/events", func(c *gin.Context) {
+		c.JSON(http.StatusOK, response)
+	})
+
+	return ws
+}
+
+func TestGzipMiddleware(t *testing.T) {
+	t.Parallel()
+
+	t.Run("response above threshold should be compressed", func(t *testing.T) {
+		t.Parallel()
+
+		response := testResponse{
+			Events: []string{strings.Repeat("event", 100)},
+		}
+		ws := startGzipServer(response)
+
+		req, _ := http.NewRequest("GET", "/events", nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+		resp := httptest.NewRecorder()
+		ws.ServeHTTP(resp, req)
+
+		require.Equal(t, http.StatusOK, resp.Code)
+		require.Equal(t, "gzip", resp.Header().Get("Content-Encoding"))
+		require.Equal(t, "Accept-Encoding", resp.Header().Get("Vary"))
+
+		gz, err := gzip.NewReader(resp.Body)
+		require.Nil(t, err)
+		body, err := ioutil.ReadAll(gz)
+		require.Nil(t, err)
+
+		var decodedResponse testResponse
+		err = json.Unmarshal(body, &decodedResponse)
+		require.Nil(t, err)
+		require.Equal(t, response, decodedResponse)
+	})
+
+	t.Run("response below threshold should not be compressed", func(t *testing.T) {
+		t.Parallel()
+
+		response := testResponse{
+			Events: []string{"event"},
+		}
+		ws := startGzipServer(response)
+
+		req, _ := http.NewRequest("GET", "/events", nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+		resp := httptest.NewRecorder()
+		ws.ServeHTTP(resp, req)
+
+		require.Equal(t, http.StatusOK, resp.Code)
+		require.Empty(t, resp.Header().Get("Content-Encoding"))
+
+		expBody, _ := json.Marshal(response)
+		require.Equal(t, expBody, resp.Body.Bytes())
+	})
+
+	t.Run("client not accepting gzip should receive plain response", func(t *testing.T) {
+		t.Parallel()
+
+		response := testResponse{
+			Events: []string{strings.Repeat("event", 100)},
+		}
+		ws := startGzipServer(response)
+
+		req, _ := http.NewRequest("GET", "/events", nil)
+		resp := httptest.NewRecorder()
+		ws.ServeHTTP(resp, req)
+
+		require.Equal(t, http.StatusOK, resp.Code)
+		require.Empty(t, resp.Header().Get("Content-Encoding"))
+
+		expBody, _ := json.Marshal(response)
+		require.Equal(t, expBody, resp.Body.Bytes())
+	})
+
+	t.Run("status code should be kept", func(t *testing.T) {
+		t.Parallel()
+
+		ws := gin.New()
+		ws.Use(middleware.GzipMiddleware(0))
+		ws.POST("/push", func(c *gin.Context) {
+			c.JSON(http.StatusBadRequest, testResponse{Events: []string{"event"}})
+		})
+
+		req, _ := http.NewRequest("POST", "/push", bytes.NewBuffer([]byte("{}")))
+		req.Header.Set("Accept-Encoding", "gzip")
+		resp := httptest.NewRecorder()
+		ws.ServeHTTP(resp, req)
+
+		require.Equal(t, http.StatusBadRequest, resp.Code)
+		require.Equal(t, "gzip", resp.Header().Get("Content-Encoding"))
+	})
+}
@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/multiversx/mx-chain-notifier-go/api/shared"
+)
+
+const (
+	authorizationHeaderKey = "Authorization"
+	bearerPrefix           = "Bearer "
+
+	// JWTClaimsContextKey defines the gin context key under which the validated token claims are stored
+	JWTClaimsContextKey = "jwtClaims"
+)
+
+// JWTMiddleware returns a middleware which validates HMAC-SHA256 signed bearer tokens
+// On success, the token claims are set in the gin context under JWTClaimsContextKey
+func JWTMiddleware(secretKey string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authHeader := c.GetHeader(authorizationHeaderKey)
+		if !strings.HasPrefix(authHeader, bearerPrefix) {
+			shared.JSONResponse(c, http.StatusUnauthorized, nil, ErrMissingAuthToken.Error())
+			c.Abort()
+			return
+		}
+
+		claims, err := parseToken(strings.TrimPrefix(authHeader, bearerPrefix), secretKey)
+		if err != nil {
+			log.Debug("jwt middleware: invalid token", "path", c.FullPath(), "error", err.Error())
+			shared.JSONResponse(c, http.StatusUnauthorized, nil, err.Error())
+			c.Abort()
+			return
+		}
+
+		c.Set(JWTClaimsContextKey, claims)
+		c.Next()
+	}
+}
+
+// DisabledAuthMiddleware returns a middleware which rejects all the requests, used for the
+// admin endpoints if no authentication is configured
+func DisabledAuthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		shared.JSONResponse(c, http.StatusForbidden, nil, ErrAdminEndpointsDisabled.Error())
+		c.Abort()
+	}
+}
+
+func parseToken(tokenString string, secretKey string) (jwt.MapClaims, error) {
+	claims := jwt.MapClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if token.Method != jwt.SigningMethodHS256 {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidSigningMethod, token.Header["alg"])
+		}
+
+		return []byte(secretKey), nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidAuthToken, err.Error())
+	}
+	if !token.Valid {
+		return nil, ErrInvalidAuthToken
+	}
+
+	return claims, nil
+}
+
+// GenerateToken creates a HMAC-SHA256 signed token with the provided claims
+func GenerateToken(claims jwt.MapClaims, secret string) (string, error) {
+	if len(secret) == 0 {
+		return "", ErrEmptyJWTSecretKey
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+
+	return token.SignedString([]byte(secret))
+}
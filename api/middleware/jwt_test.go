@@ -0,0 +1,177 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/multiversx/mx-chain-notifier-go/api/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecretKey = "secret"
+
+func startJWTProtectedServer(secretKey string, claimsHandler func(claims jwt.MapClaims)) *gin.Engine {
+	ws := gin.New()
+	ws.Use(middleware.JWTMiddleware(secretKey))
+	ws.DELETE("/dispatchers/:id", func(c *gin.Context) {
+		claims, _ := c.Get(middleware.JWTClaimsContextKey)
+		claimsHandler(claims.(jwt.MapClaims))
+		c.Status(http.StatusNoContent)
+	})
+
+	return ws
+}
+
+func sendRequestWithAuthHeader(ws *gin.Engine, authHeader string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(http.MethodDelete, "/dispatchers/id", nil)
+	if authHeader != "" {
+		req.Header.Set("Authorization", authHeader)
+	}
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	return resp
+}
+
+func TestGenerateToken(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty secret key", func(t *testing.T) {
+		t.Parallel()
+
+		token, err := middleware.GenerateToken(jwt.MapClaims{}, "")
+		require.Equal(t, middleware.ErrEmptyJWTSecretKey, err)
+		require.Empty(t, token)
+	})
+
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		token, err := middleware.GenerateToken(jwt.MapClaims{"sub": "admin"}, testSecretKey)
+		require.Nil(t, err)
+		require.NotEmpty(t, token)
+	})
+}
+
+func TestJWTMiddleware(t *testing.T) {
+	t.Parallel()
+
+	t.Run("missing token should fail", func(t *testing.T) {
+		t.Parallel()
+
+		wasCalled := false
+		ws := startJWTProtectedServer(testSecretKey, func(claims jwt.MapClaims) {
+			wasCalled = true
+		})
+
+		resp := sendRequestWithAuthHeader(ws, "")
+		require.Equal(t, http.StatusUnauthorized, resp.Code)
+		assert.Contains(t, resp.Body.String(), middleware.ErrMissingAuthToken.Error())
+		assert.False(t, wasCalled)
+
+		resp = sendRequestWithAuthHeader(ws, "Basic dXNlcjpwYXNz")
+		require.Equal(t, http.StatusUnauthorized, resp.Code)
+		assert.False(t, wasCalled)
+	})
+
+	t.Run("expired token should fail", func(t *testing.T) {
+		t.Parallel()
+
+		wasCalled := false
+		ws := startJWTProtectedServer(testSecretKey, func(claims jwt.MapClaims) {
+			wasCalled = true
+		})
+
+		token, err := middleware.GenerateToken(jwt.MapClaims{
+			"sub": "admin",
+			"exp": time.Now().Add(-time.Minute).Unix(),
+		}, testSecretKey)
+		require.Nil(t, err)
+
+		resp := sendRequestWithAuthHeader(ws, "Bearer "+token)
+		require.Equal(t, http.StatusUnauthorized, resp.Code)
+		assert.Contains(t, resp.Body.String(), middleware.ErrInvalidAuthToken.Error())
+		assert.False(t, wasCalled)
+	})
+
+	t.Run("tampered token should fail", func(t *testing.T) {
+		t.Parallel()
+
+		wasCalled := false
+		ws := startJWTProtectedServer(testSecretKey, func(claims jwt.MapClaims) {
+			wasCalled = true
+		})
+
+		token, err := middleware.GenerateToken(jwt.MapClaims{"sub": "admin"}, "other secret")
+		require.Nil(t, err)
+
+		resp := sendRequestWithAuthHeader(ws, "Bearer "+token)
+		require.Equal(t, http.StatusUnauthorized, resp.Code)
+		assert.False(t, wasCalled)
+
+		token, err = middleware.GenerateToken(jwt.MapClaims{"sub": "admin"}, testSecretKey)
+		require.Nil(t, err)
+
+		resp = sendRequestWithAuthHeader(ws, "Bearer "+token+"a")
+		require.Equal(t, http.StatusUnauthorized, resp.Code)
+		assert.False(t, wasCalled)
+	})
+
+	t.Run("token with different signing method should fail", func(t *testing.T) {
+		t.Parallel()
+
+		wasCalled := false
+		ws := startJWTProtectedServer(testSecretKey, func(claims jwt.MapClaims) {
+			wasCalled = true
+		})
+
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS512, jwt.MapClaims{"sub": "admin"}).SignedString([]byte(testSecretKey))
+		require.Nil(t, err)
+
+		resp := sendRequestWithAuthHeader(ws, "Bearer "+token)
+		require.Equal(t, http.StatusUnauthorized, resp.Code)
+		assert.Contains(t, resp.Body.String(), middleware.ErrInvalidSigningMethod.Error())
+		assert.False(t, wasCalled)
+	})
+
+	t.Run("valid token should work", func(t *testing.T) {
+		t.Parallel()
+
+		var receivedClaims jwt.MapClaims
+		ws := startJWTProtectedServer(testSecretKey, func(claims jwt.MapClaims) {
+			receivedClaims = claims
+		})
+
+		token, err := middleware.GenerateToken(jwt.MapClaims{
+			"sub": "admin",
+			"exp": time.Now().Add(time.Minute).Unix(),
+		}, testSecretKey)
+		require.Nil(t, err)
+
+		resp := sendRequestWithAuthHeader(ws, "Bearer "+token)
+		require.Equal(t, http.StatusNoContent, resp.Code)
+		assert.Equal(t, "admin", receivedClaims["sub"])
+	})
+}
+
+func TestDisabledAuthMiddleware(t *testing.T) {
+	t.Parallel()
+
+	wasCalled := false
+	ws := gin.New()
+	ws.Use(middleware.DisabledAuthMiddleware())
+	ws.DELETE("/dispatchers/:id", func(c *gin.Context) {
+		wasCalled = true
+		c.Status(http.StatusNoContent)
+	})
+
+	resp := sendRequestWithAuthHeader(ws, "")
+	require.Equal(t, http.StatusForbidden, resp.Code)
+	assert.Contains(t, resp.Body.String(), middleware.ErrAdminEndpointsDisabled.Error())
+	assert.False(t, wasCalled)
+}
@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	logger "github.com/multiversx/mx-chain-logger-go"
+	"github.com/multiversx/mx-chain-notifier-go/api/shared"
+)
+
+var log = logger.GetOrCreate("api/middleware")
+
+// ArgsRateLimiter defines the arguments needed to create a new rate limiter
+type ArgsRateLimiter struct {
+	RequestsPerSecond float64
+	Burst             int
+}
+
+type tokenBucket struct {
+	tokens     float64
+	lastUpdate time.Time
+}
+
+type rateLimiter struct {
+	requestsPerSecond float64
+	burst             float64
+	mutBuckets        sync.Mutex
+	buckets           map[string]*tokenBucket
+	getTimeHandler    func() time.Time
+}
+
+// NewRateLimiter creates a token bucket rate limiter, which keeps a separate bucket for each route
+func NewRateLimiter(args ArgsRateLimiter) (*rateLimiter, error) {
+	if args.RequestsPerSecond <= 0 {
+		return nil, ErrInvalidRequestsPerSecond
+	}
+	if args.Burst <= 0 {
+		return nil, ErrInvalidBurst
+	}
+
+	return &rateLimiter{
+		requestsPerSecond: args.RequestsPerSecond,
+		burst:             float64(args.Burst),
+		buckets:           make(map[string]*tokenBucket),
+		getTimeHandler:    time.Now,
+	}, nil
+}
+
+// MiddlewareHandlerFunc returns the handler func used by the gin server when processing requests
+func (rl *rateLimiter) MiddlewareHandlerFunc() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !rl.allow(c.FullPath()) {
+			log.Debug("rate limit exceeded", "path", c.FullPath())
+			shared.JSONResponse(c, http.StatusTooManyRequests, nil, ErrTooManyRequests.Error())
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func (rl *rateLimiter) allow(route string) bool {
+	rl.mutBuckets.Lock()
+	defer rl.mutBuckets.Unlock()
+
+	now := rl.getTimeHandler()
+
+	bucket, ok := rl.buckets[route]
+	if !ok {
+		bucket = &tokenBucket{
+			tokens:     rl.burst,
+			lastUpdate: now,
+		}
+		rl.buckets[route] = bucket
+	}
+
+	elapsed := now.Sub(bucket.lastUpdate).Seconds()
+	bucket.tokens += elapsed * rl.requestsPerSecond
+	if bucket.tokens > rl.burst {
+		bucket.tokens = rl.burst
+	}
+	bucket.lastUpdate = now
+
+	if bucket.tokens < 1 {
+		return false
+	}
+
+	bucket.tokens--
+
+	return true
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (rl *rateLimiter) IsInterfaceNil() bool {
+	return rl == nil
+}
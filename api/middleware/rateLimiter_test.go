@@ -0,0 +1,161 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-notifier-go/api/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createMockArgsRateLimiter() middleware.ArgsRateLimiter {
+	return middleware.ArgsRateLimiter{
+		RequestsPerSecond: 1,
+		Burst:             5,
+	}
+}
+
+func startRateLimitedServer(rl gin.HandlerFunc) *gin.Engine {
+	ws := gin.New()
+	ws.Use(rl)
+	ws.POST("/push", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	ws.POST("/revert", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	return ws
+}
+
+func TestNewRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid requests per second", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsRateLimiter()
+		args.RequestsPerSecond = 0
+
+		rl, err := middleware.NewRateLimiter(args)
+		require.True(t, check.IfNil(rl))
+		require.Equal(t, middleware.ErrInvalidRequestsPerSecond, err)
+	})
+
+	t.Run("invalid burst", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsRateLimiter()
+		args.Burst = 0
+
+		rl, err := middleware.NewRateLimiter(args)
+		require.True(t, check.IfNil(rl))
+		require.Equal(t, middleware.ErrInvalidBurst, err)
+	})
+
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		rl, err := middleware.NewRateLimiter(createMockArgsRateLimiter())
+		require.Nil(t, err)
+		require.False(t, check.IfNil(rl))
+	})
+}
+
+func TestRateLimiter_MiddlewareHandlerFunc(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should reject requests past burst", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsRateLimiter()
+		rl, err := middleware.NewRateLimiter(args)
+		require.Nil(t, err)
+
+		currentTime := time.Now()
+		rl.SetGetTimeHandler(func() time.Time {
+			return currentTime
+		})
+
+		ws := startRateLimitedServer(rl.MiddlewareHandlerFunc())
+
+		numRequests := 20
+		numTooManyRequests := 0
+		for i := 0; i < numRequests; i++ {
+			req, _ := http.NewRequest(http.MethodPost, "/push", nil)
+			resp := httptest.NewRecorder()
+			ws.ServeHTTP(resp, req)
+
+			if i < args.Burst {
+				require.Equal(t, http.StatusOK, resp.Code)
+				continue
+			}
+
+			require.Equal(t, http.StatusTooManyRequests, resp.Code)
+			numTooManyRequests++
+		}
+		assert.Equal(t, numRequests-args.Burst, numTooManyRequests)
+
+		// other routes have their own bucket
+		req, _ := http.NewRequest(http.MethodPost, "/revert", nil)
+		resp := httptest.NewRecorder()
+		ws.ServeHTTP(resp, req)
+		assert.Equal(t, http.StatusOK, resp.Code)
+
+		// tokens are refilled over time
+		currentTime = currentTime.Add(time.Second)
+		req, _ = http.NewRequest(http.MethodPost, "/push", nil)
+		resp = httptest.NewRecorder()
+		ws.ServeHTTP(resp, req)
+		assert.Equal(t, http.StatusOK, resp.Code)
+	})
+
+	t.Run("concurrent requests should not exceed burst", func(t *testing.T) {
+		t.Parallel()
+
+		args := middleware.ArgsRateLimiter{
+			RequestsPerSecond: 0.001,
+			Burst:             10,
+		}
+		rl, err := middleware.NewRateLimiter(args)
+		require.Nil(t, err)
+
+		ws := startRateLimitedServer(rl.MiddlewareHandlerFunc())
+
+		mutCounters := sync.Mutex{}
+		numOk := 0
+		numTooManyRequests := 0
+
+		wg := sync.WaitGroup{}
+		numRequests := 100
+		wg.Add(numRequests)
+		for i := 0; i < numRequests; i++ {
+			go func() {
+				defer wg.Done()
+
+				req, _ := http.NewRequest(http.MethodPost, "/push", nil)
+				resp := httptest.NewRecorder()
+				ws.ServeHTTP(resp, req)
+
+				mutCounters.Lock()
+				if resp.Code == http.StatusOK {
+					numOk++
+				}
+				if resp.Code == http.StatusTooManyRequests {
+					numTooManyRequests++
+				}
+				mutCounters.Unlock()
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, args.Burst, numOk)
+		assert.Equal(t, numRequests-args.Burst, numTooManyRequests)
+	})
+}
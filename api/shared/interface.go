@@ -1,16 +1,20 @@
 package shared
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/multiversx/mx-chain-notifier-go/config"
 	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/filters"
 )
 
 // HTTPServerCloser defines the basic actions of starting and closing that a web server should be able to do
 type HTTPServerCloser interface {
 	Start()
+	StartTLS(certFile, keyFile string)
 	Close() error
 	IsInterfaceNil() bool
 }
@@ -25,12 +29,28 @@ type GroupHandler interface {
 // FacadeHandler defines the behavior of a notifier base facade handler
 type FacadeHandler interface {
 	HandlePushEvents(events data.ArgsSaveBlockData) error
-	HandleRevertEvents(revertBlock data.RevertBlock)
-	HandleFinalizedEvents(finalizedBlock data.FinalizedBlock)
+	HandleRevertEvents(revertBlock data.RevertBlock) error
+	HandleFinalizedEvents(finalizedBlock data.FinalizedBlock) error
+	HandleRoundEvents(roundEvents data.RoundEvents) error
+	HandleValidatorsRatingEvent(validatorsRating data.ValidatorsRatingEvent) error
+	HandleAccountsEvents(accountsEvents data.AccountsEvents) error
 	GetConnectorUserAndPass() (string, string)
 	ServeHTTP(w http.ResponseWriter, r *http.Request)
+	DisconnectDispatcher(dispatcherID uuid.UUID) error
+	GetSubscriptionStats() data.SubscriptionStatsResponse
+	MatchEvent(event data.Event) data.EventMatchResponse
+	RegisterWebhook(registration data.WebhookRegistration) (uuid.UUID, error)
+	UnregisterWebhook(id uuid.UUID) error
+	RegisterConsumer(subscription data.ConsumerSubscription) error
+	UnregisterConsumer(consumerID string) error
+	GetEventsByNonceRange(fromNonce uint64, toNonce uint64) ([]data.BlockEvents, error)
+	UpdateFilter(cfg filters.FilterConfig) error
 	GetMetrics() map[string]*data.EndpointMetricsResponse
 	GetMetricsForPrometheus() string
+	GetProcessedBlocks() []data.ProcessedBlock
+	GetPayloadVersions() data.PayloadVersionsResponse
+	GetHealthStatus() data.HealthStatusResponse
+	GetReadinessStatus(ctx context.Context) data.ReadinessStatusResponse
 	IsInterfaceNil() bool
 }
 
@@ -80,7 +80,7 @@ VERSION:
 
 	publisherType = cli.StringFlag{
 		Name:  "publisher-type",
-		Usage: "This flag specifies the publisher type, it defines the way in which it will expose the events. Options: " + common.MessageQueuePublisherType + " | " + common.WSPublisherType,
+		Usage: "This flag specifies the publisher type, it defines the way in which it will expose the events. Options: " + common.MessageQueuePublisherType + " | " + common.WSPublisherType + " | " + common.RedisPublisherType + " | " + common.WebhookPublisherType + " | " + common.KafkaPublisherType + " | " + common.NATSPublisherType + " | " + common.FilePublisherType + ". Multiple publisher types can be enabled as a comma separated list, e.g. " + common.MessageQueuePublisherType + "," + common.WSPublisherType,
 		Value: common.MessageQueuePublisherType,
 	}
 )
@@ -173,6 +173,11 @@ func readConfigs(ctx *cli.Context) (*config.Configs, error) {
 		return nil, err
 	}
 
+	err = config.LoadConfigFromEnv(mainConfig)
+	if err != nil {
+		return nil, err
+	}
+
 	apiConfig, err := config.LoadAPIConfig(flagsConfig.APIConfigPath)
 	if err != nil {
 		return nil, err
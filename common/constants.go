@@ -6,6 +6,21 @@ const (
 
 	// MessageQueuePublisherType defines a webserver api type using a message queueing service
 	MessageQueuePublisherType string = "rabbitmq"
+
+	// RedisPublisherType defines a publisher type using redis pubsub channels
+	RedisPublisherType string = "redis"
+
+	// WebhookPublisherType defines a publisher type posting the events to http endpoints
+	WebhookPublisherType string = "webhook"
+
+	// KafkaPublisherType defines a publisher type using kafka topics
+	KafkaPublisherType string = "kafka"
+
+	// NATSPublisherType defines a publisher type using NATS subjects
+	NATSPublisherType string = "nats"
+
+	// FilePublisherType defines a publisher type appending the events to a local file
+	FilePublisherType string = "file"
 )
 
 const (
@@ -34,6 +49,89 @@ const (
 
 	// BlockScrs defines the subscription event type for block scrs
 	BlockScrs string = "block_scrs"
+
+	// TxEvents defines the subscription event type for per transaction notifications
+	TxEvents string = "tx_events"
+
+	// RoundEvents defines the subscription event type for the rounds information
+	RoundEvents string = "round_events"
+
+	// ValidatorsRatingEvents defines the subscription event type for the validators rating
+	ValidatorsRatingEvents string = "validators_rating_events"
+
+	// AccountEvents defines the subscription event type for the accounts altered in a block
+	AccountEvents string = "account_events"
+
+	// ReplayUnavailable defines the event type sent when the requested replay is not available
+	ReplayUnavailable string = "replay_unavailable"
+
+	// ErrorFrameType defines the type of the error frames sent to websocket clients
+	ErrorFrameType string = "error"
+
+	// SubscribeAckFrameType defines the type of the frames acknowledging the websocket subscriptions
+	SubscribeAckFrameType string = "subscribed"
+)
+
+const (
+	// InvalidSubscribeErrorCode defines the error frame code of a subscribe message which
+	// could not be decoded
+	InvalidSubscribeErrorCode string = "invalid_subscribe"
+
+	// UnsupportedEncodingErrorCode defines the error frame code of a subscribe message which
+	// requested an encoding not supported for its subscriptions
+	UnsupportedEncodingErrorCode string = "unsupported_encoding"
+
+	// SubscriptionLimitErrorCode defines the error frame code of a subscription rejected for
+	// exceeding the maximum number of subscriptions per dispatcher
+	SubscriptionLimitErrorCode string = "subscription_limit_exceeded"
+
+	// SubscriptionRejectedErrorCode defines the error frame code of a subscription rejected
+	// for any other reason, such as an invalid address pattern
+	SubscriptionRejectedErrorCode string = "subscription_rejected"
+
+	// NoSubscriptionCriteriaErrorCode defines the error frame code of a subscribe message
+	// which would match all the logs and events without setting the subscribe to all flag
+	NoSubscriptionCriteriaErrorCode string = "no_subscription_criteria"
+)
+
+const (
+	// JSONEncoding defines the json encoding of the events pushed to websocket clients
+	JSONEncoding string = "json"
+
+	// MessagePackEncoding defines the MessagePack encoding of the events pushed to websocket clients
+	MessagePackEncoding string = "msgpack"
+
+	// ProtobufEncoding defines the protobuf encoding of the events pushed to websocket clients
+	ProtobufEncoding string = "protobuf"
+)
+
+const (
+	// TxStatusSuccess defines the status of a transaction executed successfully
+	TxStatusSuccess string = "success"
+
+	// TxStatusFail defines the status of a transaction which generated a signalError log event
+	TxStatusFail string = "fail"
+
+	// TxStatusInvalid defines the status of a transaction included in the block as invalid
+	TxStatusInvalid string = "invalid"
+)
+
+const (
+	// HealthStateUp defines the health state of a component which works as expected
+	HealthStateUp string = "up"
+
+	// HealthStateDown defines the health state of a component which is not working
+	HealthStateDown string = "down"
+
+	// HealthStateNotApplicable defines the health state of a component which is not used
+	// for the configured api type
+	HealthStateNotApplicable string = "n/a"
+
+	// HubHealthComponent defines the health component name for the events publishing loop
+	HubHealthComponent string = "hub"
+
+	// BrokerHealthComponent defines the health component name for the message broker connection
+	BrokerHealthComponent string = "broker"
 )
 
 const (
@@ -42,6 +140,9 @@ const (
 
 	// HTTPConnectorType defines the http observer connector type
 	HTTPConnectorType string = "http"
+
+	// GRPCConnectorType defines the gRPC observer connector type
+	GRPCConnectorType string = "grpc"
 )
 
 const (
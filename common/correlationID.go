@@ -0,0 +1,53 @@
+package common
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// MaxCorrelationIDLength is the maximum length of a correlation id supplied by an observer
+const MaxCorrelationIDLength = 128
+
+type correlationIDKey struct{}
+
+// ContextWithCorrelationID returns a copy of the context which holds the correlation id
+func ContextWithCorrelationID(ctx context.Context, correlationID string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, correlationID)
+}
+
+// GetCorrelationID returns the correlation id from the context. If the context does
+// not hold one, a new correlation id is generated
+func GetCorrelationID(ctx context.Context) string {
+	correlationID, ok := ctx.Value(correlationIDKey{}).(string)
+	if ok && correlationID != "" {
+		return correlationID
+	}
+
+	return NewCorrelationID()
+}
+
+// NewCorrelationID generates a new correlation id, used to identify a received payload
+// in the log lines of all the components which handle it
+func NewCorrelationID() string {
+	return uuid.New().String()
+}
+
+// IsValidCorrelationID returns true if the correlation id supplied by an observer can be
+// used as such. Only short ids made of letters, digits and "-", "_", ".", ":" are accepted,
+// since they are written in the log lines and in the message headers
+func IsValidCorrelationID(correlationID string) bool {
+	if len(correlationID) == 0 || len(correlationID) > MaxCorrelationIDLength {
+		return false
+	}
+
+	for _, c := range correlationID {
+		isLetter := (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+		isDigit := c >= '0' && c <= '9'
+		if !isLetter && !isDigit && c != '-' && c != '_' && c != '.' && c != ':' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,23 @@
+package common_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/multiversx/mx-chain-notifier-go/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsValidCorrelationID(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, common.IsValidCorrelationID(common.NewCorrelationID()))
+	require.True(t, common.IsValidCorrelationID("observer-0:push_42.1"))
+	require.True(t, common.IsValidCorrelationID(strings.Repeat("a", common.MaxCorrelationIDLength)))
+
+	require.False(t, common.IsValidCorrelationID(""))
+	require.False(t, common.IsValidCorrelationID(strings.Repeat("a", common.MaxCorrelationIDLength+1)))
+	require.False(t, common.IsValidCorrelationID("id with spaces"))
+	require.False(t, common.IsValidCorrelationID("id\nfake log line"))
+	require.False(t, common.IsValidCorrelationID("id\"quoted\""))
+}
@@ -23,6 +23,9 @@ var ErrReceivedEmptyEvents = errors.New("received empty events")
 // ErrNilMarshaller signals that a nil marshaller has been provided
 var ErrNilMarshaller = errors.New("nil marshaller provided")
 
+// ErrInvalidMarshallerType signals that an invalid marshaller type has been provided
+var ErrInvalidMarshallerType = errors.New("invalid marshaller type")
+
 // ErrNilInternalMarshaller signals that a nil internal marshaller has been provided
 var ErrNilInternalMarshaller = errors.New("nil external marshaller provided")
 
@@ -32,8 +35,42 @@ var ErrNilFacadeHandler = errors.New("nil facade handler")
 // ErrNilStatusMetricsHandler signals that a nil status metrics handler has been provided
 var ErrNilStatusMetricsHandler = errors.New("nil status metrics handler")
 
+// ErrNilProcessedBlocksTracker signals that a nil processed blocks tracker has been provided
+var ErrNilProcessedBlocksTracker = errors.New("nil processed blocks tracker")
+
 // ErrWrongTypeAssertion signals a wrong type assertion
 var ErrWrongTypeAssertion = errors.New("wrong type assertion")
 
+// ErrDispatcherNotFound signals that the dispatcher could not be found
+var ErrDispatcherNotFound = errors.New("dispatcher not found")
+
+// ErrWebhookNotFound signals that the webhook could not be found
+var ErrWebhookNotFound = errors.New("webhook not found")
+
+// ErrConsumerNotFound signals that the rabbitMQ consumer could not be found
+var ErrConsumerNotFound = errors.New("consumer not found")
+
 // ErrLoopAlreadyStarted signals that a loop has already been started
 var ErrLoopAlreadyStarted = errors.New("loop already started")
+
+// ErrNilMetricsCollector signals that a nil metrics collector has been provided
+var ErrNilMetricsCollector = errors.New("nil metrics collector")
+
+// ErrEventStoreNotEnabled signals that the event store is not enabled
+var ErrEventStoreNotEnabled = errors.New("event store not enabled")
+
+// ErrInvalidNonceRange signals that an invalid nonce range has been provided
+var ErrInvalidNonceRange = errors.New("invalid nonce range")
+
+// ErrNilEventStore signals that a nil event store has been provided
+var ErrNilEventStore = errors.New("nil event store")
+
+// ErrGRPCSubscriptionWithoutHub signals that the gRPC subscription server has been enabled
+// without the websocket publisher, which enables the hub
+var ErrGRPCSubscriptionWithoutHub = errors.New("gRPC subscription server requires the websocket publisher")
+
+// ErrHubNotEnabled signals that the hub is not enabled for the configured publisher type
+var ErrHubNotEnabled = errors.New("hub not enabled")
+
+// ErrPayloadHandlerClosed signals that a payload was received after the payload handler was closed
+var ErrPayloadHandlerClosed = errors.New("payload handler is closed, no new payloads are accepted")
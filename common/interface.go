@@ -1,6 +1,7 @@
 package common
 
 import (
+	"context"
 	"time"
 
 	"github.com/multiversx/mx-chain-notifier-go/data"
@@ -13,3 +14,43 @@ type StatusMetricsHandler interface {
 	GetMetricsForPrometheus() string
 	IsInterfaceNil() bool
 }
+
+// ProcessedBlocksTracker defines the behavior of a component that holds the last processed
+// block of each shard
+type ProcessedBlocksTracker interface {
+	SetProcessedBlock(block data.ProcessedBlock)
+	GetProcessedBlocks() []data.ProcessedBlock
+	IsInterfaceNil() bool
+}
+
+// PrometheusMetricsHandler defines the behavior of a component that exposes its metrics in prometheus format
+type PrometheusMetricsHandler interface {
+	GetMetricsForPrometheus() string
+	IsInterfaceNil() bool
+}
+
+// MetricsCollector defines the behavior of a component which collects the notifier runtime metrics
+type MetricsCollector interface {
+	AddBroadcast(status string)
+	SetActiveDispatchers(numDispatchers uint64)
+	AddRabbitMQPublish(exchange string, status string)
+	AddRabbitMQPublishDuration(exchange string, duration time.Duration)
+	SetRabbitMQBufferedEvents(numEvents uint64)
+	AddDuplicatedBlock()
+	AddPayloadHandlerDuration(topic string, duration time.Duration)
+	AddInvalidPayload(reason string)
+	AddFilteredEvents(identifier string, numEvents uint64)
+	AddProcessedBlock(shardID uint32, numEvents uint64)
+	AddPayloadVersionFallback(version uint32)
+	SetBlockProcessingLag(shardID uint32, lag time.Duration)
+	GetMetricsForPrometheus() string
+	IsInterfaceNil() bool
+}
+
+// HealthChecker defines the behavior of a component that is able to report its health
+// state and to be probed for readiness
+type HealthChecker interface {
+	GetHealthState() string
+	Ping(ctx context.Context) error
+	IsInterfaceNil() bool
+}
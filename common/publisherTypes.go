@@ -0,0 +1,51 @@
+package common
+
+import "strings"
+
+const publisherTypesSeparator = ","
+
+// GetPublisherTypes returns the publisher types from a comma separated list, such as
+// "rabbitmq,ws". It returns ErrInvalidAPIType if the list is empty or if it contains
+// an unknown publisher type
+func GetPublisherTypes(publisherType string) ([]string, error) {
+	publisherTypes := make([]string, 0)
+	for _, value := range strings.Split(publisherType, publisherTypesSeparator) {
+		value = strings.TrimSpace(value)
+
+		switch value {
+		case WSPublisherType, MessageQueuePublisherType, RedisPublisherType, WebhookPublisherType, KafkaPublisherType, NATSPublisherType, FilePublisherType:
+		default:
+			return nil, ErrInvalidAPIType
+		}
+
+		if ContainsPublisherType(publisherTypes, value) {
+			continue
+		}
+
+		publisherTypes = append(publisherTypes, value)
+	}
+
+	return publisherTypes, nil
+}
+
+// IsPublisherTypeEnabled returns true if the provided publisher type is part of the
+// comma separated publisher types list
+func IsPublisherTypeEnabled(publisherTypes string, publisherType string) bool {
+	enabledPublisherTypes, err := GetPublisherTypes(publisherTypes)
+	if err != nil {
+		return false
+	}
+
+	return ContainsPublisherType(enabledPublisherTypes, publisherType)
+}
+
+// ContainsPublisherType returns true if the provided publisher type is part of the list
+func ContainsPublisherType(publisherTypes []string, publisherType string) bool {
+	for _, value := range publisherTypes {
+		if value == publisherType {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,53 @@
+package common_test
+
+import (
+	"testing"
+
+	"github.com/multiversx/mx-chain-notifier-go/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPublisherTypes(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty publisher type should error", func(t *testing.T) {
+		t.Parallel()
+
+		publisherTypes, err := common.GetPublisherTypes("")
+		require.Equal(t, common.ErrInvalidAPIType, err)
+		require.Nil(t, publisherTypes)
+	})
+
+	t.Run("unknown publisher type should error", func(t *testing.T) {
+		t.Parallel()
+
+		publisherTypes, err := common.GetPublisherTypes("rabbitmq,mqtt")
+		require.Equal(t, common.ErrInvalidAPIType, err)
+		require.Nil(t, publisherTypes)
+	})
+
+	t.Run("single publisher type should work", func(t *testing.T) {
+		t.Parallel()
+
+		publisherTypes, err := common.GetPublisherTypes(common.WSPublisherType)
+		require.Nil(t, err)
+		require.Equal(t, []string{common.WSPublisherType}, publisherTypes)
+	})
+
+	t.Run("multiple publisher types should work", func(t *testing.T) {
+		t.Parallel()
+
+		publisherTypes, err := common.GetPublisherTypes("rabbitmq, ws,rabbitmq,redis,kafka,nats,file")
+		require.Nil(t, err)
+		require.Equal(t, []string{common.MessageQueuePublisherType, common.WSPublisherType, common.RedisPublisherType, common.KafkaPublisherType, common.NATSPublisherType, common.FilePublisherType}, publisherTypes)
+	})
+}
+
+func TestIsPublisherTypeEnabled(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, common.IsPublisherTypeEnabled("rabbitmq,ws", common.WSPublisherType))
+	require.True(t, common.IsPublisherTypeEnabled("rabbitmq,ws", common.MessageQueuePublisherType))
+	require.False(t, common.IsPublisherTypeEnabled(common.MessageQueuePublisherType, common.WSPublisherType))
+	require.False(t, common.IsPublisherTypeEnabled("invalid", common.WSPublisherType))
+}
@@ -0,0 +1,18 @@
+package common
+
+import (
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName defines the name of the tracer used for the notifier spans
+const TracerName = "github.com/multiversx/mx-chain-notifier-go"
+
+// GetTracer returns the notifier tracer from the provided tracer provider. If the
+// provider is nil, a no-op tracer is returned, so that no spans are recorded
+func GetTracer(tracerProvider trace.TracerProvider) trace.Tracer {
+	if tracerProvider == nil {
+		tracerProvider = trace.NewNoopTracerProvider()
+	}
+
+	return tracerProvider.Tracer(TracerName)
+}
@@ -0,0 +1,123 @@
+package compress
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	// GzipAlgorithm defines the gzip compression, set as "gzip" content encoding
+	GzipAlgorithm = "gzip"
+
+	// ZstdAlgorithm defines the zstandard compression, set as "zstd" content encoding
+	ZstdAlgorithm = "zstd"
+)
+
+// gzipMagicBytes are the first bytes of a gzip stream, which are not a valid start for
+// the json or protobuf payloads
+var gzipMagicBytes = []byte{0x1f, 0x8b}
+
+// the zstd encoder and decoder are safe for concurrent use with EncodeAll and DecodeAll.
+// They are created without options, which can not fail
+var (
+	zstdEncoder, _ = zstd.NewWriter(nil)
+	zstdDecoder, _ = zstd.NewReader(nil)
+)
+
+// IsSupportedAlgorithm returns true for the known compression algorithms
+func IsSupportedAlgorithm(algorithm string) bool {
+	switch algorithm {
+	case GzipAlgorithm, ZstdAlgorithm:
+		return true
+	default:
+		return false
+	}
+}
+
+// Compress compresses the payload with the provided algorithm
+func Compress(payload []byte, algorithm string) ([]byte, error) {
+	switch algorithm {
+	case GzipAlgorithm:
+		return compressGzip(payload)
+	case ZstdAlgorithm:
+		return zstdEncoder.EncodeAll(payload, make([]byte, 0, len(payload))), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedAlgorithm, algorithm)
+	}
+}
+
+func compressGzip(payload []byte) ([]byte, error) {
+	buff := bytes.Buffer{}
+	writer := gzip.NewWriter(&buff)
+
+	_, err := writer.Write(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	err = writer.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return buff.Bytes(), nil
+}
+
+// Decompress decompresses the payload with the algorithm set as its content encoding. A
+// payload without content encoding is returned as it is
+func Decompress(payload []byte, encoding string) ([]byte, error) {
+	switch encoding {
+	case "":
+		return payload, nil
+	case GzipAlgorithm:
+		return decompressGzip(payload)
+	case ZstdAlgorithm:
+		return zstdDecoder.DecodeAll(payload, nil)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedAlgorithm, encoding)
+	}
+}
+
+// IsGzipCompressed returns true if the payload starts with the gzip magic bytes
+func IsGzipCompressed(payload []byte) bool {
+	return bytes.HasPrefix(payload, gzipMagicBytes)
+}
+
+// DecompressGzipWithLimit decompresses the gzip payload, failing with ErrDecompressedSizeLimit
+// as soon as the decompressed data exceeds maxSize bytes, so that a small payload can not
+// expand to an unbounded size
+func DecompressGzipWithLimit(payload []byte, maxSize uint64) ([]byte, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	decompressed, err := io.ReadAll(io.LimitReader(reader, int64(maxSize)+1))
+	if err != nil {
+		return nil, err
+	}
+	if uint64(len(decompressed)) > maxSize {
+		return nil, fmt.Errorf("%w of %d bytes", ErrDecompressedSizeLimit, maxSize)
+	}
+
+	return decompressed, nil
+}
+
+func decompressGzip(payload []byte) ([]byte, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	return io.ReadAll(reader)
+}
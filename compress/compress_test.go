@@ -0,0 +1,133 @@
+package compress_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/multiversx/mx-chain-notifier-go/compress"
+	"github.com/stretchr/testify/require"
+)
+
+func createPayload() []byte {
+	event := `{"address":"erd1qqqqqqqqqqqqqpgqak8zt22wl2ph4tswtyc39namqx6ysa2sd8ss4xmlj3","identifier":"swapTokensFixedInput","topics":["c3dhcA==","V0VHTEQtYmQ0ZDc5"],"txHash":"7aa7a6b0e3c0c4b5"}`
+
+	return []byte("[" + strings.Repeat(event+",", 99) + event + "]")
+}
+
+func TestIsSupportedAlgorithm(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, compress.IsSupportedAlgorithm(compress.GzipAlgorithm))
+	require.True(t, compress.IsSupportedAlgorithm(compress.ZstdAlgorithm))
+	require.False(t, compress.IsSupportedAlgorithm(""))
+	require.False(t, compress.IsSupportedAlgorithm("brotli"))
+}
+
+func TestCompress(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unsupported algorithm should error", func(t *testing.T) {
+		t.Parallel()
+
+		compressed, err := compress.Compress(createPayload(), "brotli")
+		require.Nil(t, compressed)
+		require.True(t, errors.Is(err, compress.ErrUnsupportedAlgorithm))
+	})
+
+	for _, algorithm := range []string{compress.GzipAlgorithm, compress.ZstdAlgorithm} {
+		algorithm := algorithm
+		t.Run(algorithm+" should be smaller and decompress to the payload", func(t *testing.T) {
+			t.Parallel()
+
+			payload := createPayload()
+			compressed, err := compress.Compress(payload, algorithm)
+			require.Nil(t, err)
+			require.Less(t, len(compressed), len(payload))
+
+			decompressed, err := compress.Decompress(compressed, algorithm)
+			require.Nil(t, err)
+			require.Equal(t, payload, decompressed)
+		})
+	}
+}
+
+func TestDecompress(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no encoding should return the payload", func(t *testing.T) {
+		t.Parallel()
+
+		payload := createPayload()
+		decompressed, err := compress.Decompress(payload, "")
+		require.Nil(t, err)
+		require.Equal(t, payload, decompressed)
+	})
+
+	t.Run("unsupported encoding should error", func(t *testing.T) {
+		t.Parallel()
+
+		decompressed, err := compress.Decompress(createPayload(), "brotli")
+		require.Nil(t, decompressed)
+		require.True(t, errors.Is(err, compress.ErrUnsupportedAlgorithm))
+	})
+
+	t.Run("corrupted payload should error", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := compress.Decompress([]byte("not compressed"), compress.GzipAlgorithm)
+		require.NotNil(t, err)
+
+		_, err = compress.Decompress([]byte("not compressed"), compress.ZstdAlgorithm)
+		require.NotNil(t, err)
+	})
+}
+
+func TestIsGzipCompressed(t *testing.T) {
+	t.Parallel()
+
+	compressed, err := compress.Compress(createPayload(), compress.GzipAlgorithm)
+	require.Nil(t, err)
+	require.True(t, compress.IsGzipCompressed(compressed))
+
+	zstdCompressed, err := compress.Compress(createPayload(), compress.ZstdAlgorithm)
+	require.Nil(t, err)
+	require.False(t, compress.IsGzipCompressed(zstdCompressed))
+
+	require.False(t, compress.IsGzipCompressed(createPayload()))
+	require.False(t, compress.IsGzipCompressed([]byte{0x1f}))
+	require.False(t, compress.IsGzipCompressed(nil))
+}
+
+func TestDecompressGzipWithLimit(t *testing.T) {
+	t.Parallel()
+
+	payload := createPayload()
+	compressed, err := compress.Compress(payload, compress.GzipAlgorithm)
+	require.Nil(t, err)
+
+	t.Run("payload within the limit should work", func(t *testing.T) {
+		t.Parallel()
+
+		decompressed, err := compress.DecompressGzipWithLimit(compressed, uint64(len(payload)))
+		require.Nil(t, err)
+		require.Equal(t, payload, decompressed)
+	})
+
+	t.Run("payload over the limit should error", func(t *testing.T) {
+		t.Parallel()
+
+		decompressed, err := compress.DecompressGzipWithLimit(compressed, uint64(len(payload)-1))
+		require.Nil(t, decompressed)
+		require.True(t, errors.Is(err, compress.ErrDecompressedSizeLimit))
+	})
+
+	t.Run("corrupted payload should error", func(t *testing.T) {
+		t.Parallel()
+
+		decompressed, err := compress.DecompressGzipWithLimit(compressed[:len(compressed)/2], uint64(len(payload)))
+		require.Nil(t, decompressed)
+		require.NotNil(t, err)
+		require.False(t, errors.Is(err, compress.ErrDecompressedSizeLimit))
+	})
+}
@@ -0,0 +1,9 @@
+package compress
+
+import "errors"
+
+// ErrUnsupportedAlgorithm signals that an unknown compression algorithm has been provided
+var ErrUnsupportedAlgorithm = errors.New("unsupported compression algorithm")
+
+// ErrDecompressedSizeLimit signals that a payload exceeds the maximum size once decompressed
+var ErrDecompressedSizeLimit = errors.New("decompressed payload exceeds the size limit")
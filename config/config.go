@@ -13,16 +13,82 @@ type Configs struct {
 type MainConfig struct {
 	General            GeneralConfig
 	WebSocketConnector WebSocketConfig
+	GRPCConnector      GRPCConfig
+	GRPCSubscription   GRPCSubscriptionConfig
 	ConnectorApi       ConnectorApiConfig
 	Redis              RedisConfig
+	RedisPubSub        RedisPubSubConfig
+	Webhook            WebhookConfig
+	Kafka              KafkaConfig
+	NATS               NATSConfig
+	File               FilePublisherConfig
 	RabbitMQ           RabbitMQConfig
+	EventStore         EventStoreConfig
+	Backplane          BackplaneConfig
+	Multicast          MulticastConfig
+
+	SubscriptionsPersistence PersistenceConfig
 }
 
 // GeneralConfig maps the general config section
 type GeneralConfig struct {
 	ExternalMarshaller MarshallerConfig
+
+	// InternalMarshaller decodes the outport data carried by the websocket observer connector
+	// payloads, which can use a different encoding than the payloads themselves
+	InternalMarshaller MarshallerConfig
 	AddressConverter   AddressConverterConfig
 	CheckDuplicates    bool
+
+	// ProcessedBlocksCacheSize is the number of recently processed block hashes kept
+	// in memory to drop the blocks resent by the observer. 0 disables the check
+	ProcessedBlocksCacheSize uint32
+
+	// RevertDetailsCacheDepth is the number of recently processed blocks of each shard kept
+	// in memory with their transaction hashes and events, which are added to the revert
+	// events of these blocks. 0 disables it
+	RevertDetailsCacheDepth uint32
+
+	// FinalizedDetailsCacheDepth is the number of recently processed blocks of each shard kept
+	// in memory with their shard, nonce, round and timestamp, which are added to the finalized
+	// events of these blocks. 0 disables it
+	FinalizedDetailsCacheDepth uint32
+
+	// FinalizedDetailsWithTxHashes adds the transaction hashes of the blocks to the finalized
+	// events details, if FinalizedDetailsCacheDepth is set
+	FinalizedDetailsWithTxHashes bool
+
+	// AckAfterPublish returns the payloads processing result only after all their events are
+	// published, so that a payload whose events could not be published is not acknowledged
+	// to the observer
+	AckAfterPublish bool
+
+	// StrictPayloadValidation rejects the observer payloads with an unknown topic or an
+	// unsupported version, instead of dropping them
+	StrictPayloadValidation bool
+
+	// PayloadVersionFallback processes the observer payloads with a version newer than the
+	// supported ones with the highest supported version, instead of rejecting them
+	PayloadVersionFallback bool
+
+	// MaxDecompressedPayloadBytes limits the size of the gzip compressed observer payloads
+	// once decompressed. 0 uses the default limit
+	MaxDecompressedPayloadBytes uint64
+
+	// ShutdownTimeoutInSec is the overall deadline of the shutdown, after which the notifier
+	// exits without waiting for the components still closing. 0 disables the deadline
+	ShutdownTimeoutInSec uint32
+
+	// IncludeTxStatusAndFee adds the status and the fee of the originating transaction to
+	// each pushed log event
+	IncludeTxStatusAndFee bool
+
+	// DecodeTokenTransfers adds the decoded token, nonce, value and receiver to the pushed
+	// ESDTTransfer, ESDTNFTTransfer and MultiESDTNFTTransfer log events
+	DecodeTokenTransfers bool
+
+	// EventsBlacklist holds the identifiers of the log events which are never published
+	EventsBlacklist []string
 }
 
 // MarshallerConfig maps the marshaller configuration
@@ -39,10 +105,47 @@ type AddressConverterConfig struct {
 
 // ConnectorApiConfig maps the connector configuration
 type ConnectorApiConfig struct {
-	Enabled  bool
-	Host     string
-	Username string
-	Password string
+	Enabled                    bool
+	Host                       string
+	Username                   string
+	Password                   string
+	RateLimitRequestsPerSecond float64
+	RateLimitBurst             int
+	AuthRequired               bool
+	JWTSecretKey               string
+	MaxRequestBodyBytes        int64
+	GzipCompression            bool
+	MinCompressBytes           int
+	CertFile                   string
+	KeyFile                    string
+
+	ReadinessMaxPendingBroadcasts uint32
+	BroadcastBufferSize           uint32
+	DrainTimeoutInMs              uint32
+	HubReplayBufferSize           uint32
+	MaxSubscriptionsPerDispatcher int
+	SubscriptionTTLInSec          uint32
+
+	// AllowUnauthenticatedAdmin enables the admin endpoints with "Auth" flag enabled even
+	// if AuthRequired is not set. Otherwise, they respond with 403 status code
+	AllowUnauthenticatedAdmin bool
+
+	// WSBreakOnErrorCount is the number of consecutive write errors after which a websocket
+	// client is unregistered from the hub. 0 stops writing to the client on the first error
+	WSBreakOnErrorCount int
+
+	// SubscriptionMatchStatsWindowInSec is the sliding window over which the hub counts the
+	// events matched by each subscription. 0 disables the counting
+	SubscriptionMatchStatsWindowInSec uint32
+
+	// SubscriptionAddressPrefixes holds the bech32 partial addresses which can be used as
+	// subscription addresses, in order to match all the events of the addresses starting with them
+	SubscriptionAddressPrefixes []string
+
+	// MinBlockNonce and MaxBlockNonce restrict the events delivered by the hub to the blocks
+	// within the nonce range, inclusive. A zero MaxBlockNonce means no upper bound
+	MinBlockNonce uint64
+	MaxBlockNonce uint64
 }
 
 // APIRoutesConfig holds the configuration related to Rest API routes
@@ -71,21 +174,291 @@ type RedisConfig struct {
 	TTL            uint32
 }
 
+// RedisPubSubConfig maps the redis pubsub publisher configuration
+type RedisPubSubConfig struct {
+	Url string
+
+	// Address, Password and DB select the redis server instead of Url, if Address is set
+	Address  string
+	Password string
+	DB       int
+
+	ChannelPrefix          string
+	EventsChannel          string
+	RevertEventsChannel    string
+	FinalizedEventsChannel string
+	PublishTimeoutInMs     uint32
+	PublishMaxAttempts     uint32
+	RetryIntervalInMs      uint32
+	MaxBufferedEvents      uint32
+}
+
+// BackplaneConfig maps the configuration of the backplane which shares the websocket hub
+// broadcasts between multiple notifier instances
+type BackplaneConfig struct {
+	Enabled bool
+	Url     string
+	Channel string
+}
+
+// MulticastConfig maps the configuration of the multicast mode, in which the observer events
+// are also broadcast on the backplane channel of each hub shard, so that the subscribers can
+// be sharded across the notifier instances listening on these channels
+type MulticastConfig struct {
+	Enabled     bool
+	Url         string
+	HubChannels []string
+}
+
+// EventStoreConfig maps the persistent event store configuration
+type EventStoreConfig struct {
+	Enabled           bool
+	Path              string
+	RetainedNonces    uint64
+	MaxBlocksPerQuery uint32
+}
+
+// PersistenceConfig maps the hub subscriptions persistence configuration
+type PersistenceConfig struct {
+	Enabled  bool
+	FilePath string
+}
+
+// WebhookConfig maps the webhook publisher configuration
+type WebhookConfig struct {
+	URLs                []string
+	EventsURLs          []string
+	RevertEventsURLs    []string
+	FinalizedEventsURLs []string
+	RawPayload          bool
+	HMACSecret          string
+	SignatureHeader     string
+	RequestTimeoutInMs  uint32
+	MaxAttempts         uint32
+	RetryIntervalInMs   uint32
+	QueueSize           uint32
+}
+
+// KafkaConfig maps the kafka publisher configuration
+type KafkaConfig struct {
+	Brokers              []string
+	BlockEventsTopic     string
+	RevertEventsTopic    string
+	FinalizedEventsTopic string
+
+	// The topics for the other event types are optional, the events are not published if empty
+	BlockTxsTopic             string
+	BlockScrsTopic            string
+	BlockEventsWithOrderTopic string
+	TxEventsTopic             string
+
+	// RequiredAcks is the number of acknowledgements needed for a write: "all" (default),
+	// "one" or "none"
+	RequiredAcks     string
+	WriteTimeoutInMs uint32
+	TLS              KafkaTLSConfig
+	SASL             KafkaSASLConfig
+}
+
+// KafkaSASLConfig holds the SASL authentication configuration for the kafka brokers connection
+type KafkaSASLConfig struct {
+	// Mechanism can be "plain", "scram-sha-256" or "scram-sha-512". Disabled if empty
+	Mechanism string
+	Username  string
+	Password  string
+
+	// PasswordEnvVar holds the name of the environment variable with the password,
+	// which replaces the password set in the config
+	PasswordEnvVar string
+}
+
+// KafkaTLSConfig holds the TLS configuration for the kafka brokers connection
+type KafkaTLSConfig struct {
+	Enabled  bool
+	CAFile   string
+	CertFile string
+	KeyFile  string
+
+	// InsecureSkipVerify disables the brokers certificate verification, it should
+	// only be used for development
+	InsecureSkipVerify bool
+}
+
+// FilePublisherConfig maps the file publisher configuration
+type FilePublisherConfig struct {
+	// Path is the file the records are appended to, or stdout to write them to the
+	// standard output
+	Path string
+
+	// MaxFileSizeBytes is the size after which the file is rotated. 0 disables the rotation
+	MaxFileSizeBytes uint64
+}
+
+// NATSConfig maps the NATS publisher configuration
+type NATSConfig struct {
+	URL                    string
+	BlockEventsSubject     string
+	RevertEventsSubject    string
+	FinalizedEventsSubject string
+	CredsFile              string
+
+	// UseJetStream publishes on JetStream and waits for the stream acknowledgement.
+	// The streams capturing the subjects have to be created beforehand, unless
+	// CreateStream is set
+	UseJetStream bool
+
+	// CreateStream creates the StreamName stream capturing the subjects above, or
+	// adds them to the stream if it already exists. Only used with JetStream
+	CreateStream bool
+	StreamName   string
+
+	PublishMaxAttempts       uint32
+	PublishRetryIntervalInMs uint32
+	MaxBufferedEvents        uint32
+}
+
 // RabbitMQConfig maps the rabbitMQ configuration
 type RabbitMQConfig struct {
-	Url                     string
+	Url string
+
+	// URLs holds the urls of the other nodes of the rabbitMQ cluster, tried in order after
+	// Url when connecting, and round-robin when reconnecting
+	URLs []string
+
+	// UsernameEnvVar and PasswordEnvVar hold the names of the environment variables
+	// with the credentials, which replace the credentials set in the url
+	UsernameEnvVar string
+	PasswordEnvVar string
+	TLS            RabbitMQTLSConfig
+
 	EventsExchange          RabbitMQExchangeConfig
 	RevertEventsExchange    RabbitMQExchangeConfig
 	FinalizedEventsExchange RabbitMQExchangeConfig
 	BlockTxsExchange        RabbitMQExchangeConfig
 	BlockScrsExchange       RabbitMQExchangeConfig
 	BlockEventsExchange     RabbitMQExchangeConfig
+	TxEventsExchange        RabbitMQExchangeConfig
+
+	// RoundsExchange receives the rounds info pushed by the observers. Disabled if the name is empty
+	RoundsExchange RabbitMQExchangeConfig
+
+	// ValidatorsRatingExchange receives the validators rating pushed by the observers. Disabled
+	// if the name is empty
+	ValidatorsRatingExchange RabbitMQExchangeConfig
+
+	// AccountsExchange receives the accounts altered in the blocks pushed by the observers.
+	// Disabled if the name is empty
+	AccountsExchange RabbitMQExchangeConfig
+
+	// CrossShardEventsExchange receives only the events of the cross shard transactions
+	// completed in the block, on the destination shard. Disabled if the name is empty
+	CrossShardEventsExchange RabbitMQExchangeConfig
+
+	// ConsumerEventsExchange receives the logs and events matched by the subscriptions of each
+	// consumer registered via the REST api, with the consumer id as routing key. It has to be
+	// a direct or topic exchange. Disabled if the name is empty
+	ConsumerEventsExchange RabbitMQExchangeConfig
+
+	// DeadLetterExchange receives the events which could not be published after all the
+	// attempts, wrapped with the failure details. Disabled if the name is empty
+	DeadLetterExchange RabbitMQExchangeConfig
+
+	// DeadLetterSpillFile is the local file where the failed events are appended, if they
+	// could not be published to the dead letter exchange either. Disabled if empty
+	DeadLetterSpillFile string
+
+	PublishConfirmTimeoutInMs uint32
+	PublishMaxAttempts        uint32
+	PublishRetryIntervalInMs  uint32
+	MaxBufferedEvents         uint32
+
+	// PersistentMessages publishes the messages with persistent delivery mode, so they
+	// are not lost if the broker restarts, as long as they are routed to durable queues
+	PersistentMessages bool
+
+	// SkipExchangeDeclare only checks that the exchanges exist, for brokers where
+	// the notifier user does not have configure permissions
+	SkipExchangeDeclare bool
+
+	// PublishMode defines how the logs and events are published: "per-block", as one
+	// message for each block, or "per-event", as one message for each event
+	PublishMode string
+
+	// MarshallerType defines the encoding of the published payloads: "json" or "gogo protobuf".
+	// If empty, the external marshaller is used
+	MarshallerType string
+
+	// CompressionAlgorithm defines the compression of the published payloads: "gzip" or
+	// "zstd", set as the message content encoding. If empty, the payloads are not compressed
+	CompressionAlgorithm string
+
+	// SkipEmptyBlocks does not publish the logs and events of the blocks without events,
+	// after the identifiers filtering. If not set, a message is published for each block
+	SkipEmptyBlocks bool
+
+	// IdentifiersAllowlist and IdentifiersDenylist filter the published logs and events by
+	// identifier. If the allowlist is not empty, only its identifiers are published, and the
+	// denylisted identifiers are never published
+	IdentifiersAllowlist []string
+	IdentifiersDenylist  []string
+
+	// MaxEventsPerMessage splits the logs and events of a block in multiple messages, if the
+	// block has more events, in per-block publish mode. 0 means no limit
+	MaxEventsPerMessage uint32
+
+	Batching       RabbitMQBatchingConfig
+	CircuitBreaker RabbitMQCircuitBreakerConfig
+}
+
+// GetURLs returns the configured rabbitMQ urls, Url being the first one, if set
+func (rc RabbitMQConfig) GetURLs() []string {
+	urls := make([]string, 0, len(rc.URLs)+1)
+	if rc.Url != "" {
+		urls = append(urls, rc.Url)
+	}
+	for _, rawURL := range rc.URLs {
+		if rawURL != "" {
+			urls = append(urls, rawURL)
+		}
+	}
+
+	return urls
+}
+
+// RabbitMQBatchingConfig holds the configuration for publishing the logs and events of
+// multiple blocks as a single message. A batch is published when it reaches MaxBlocks
+// blocks or when WindowInMs passed since its first block, whichever comes first
+type RabbitMQBatchingConfig struct {
+	Enabled    bool
+	MaxBlocks  uint32
+	WindowInMs uint32
+}
+
+// RabbitMQCircuitBreakerConfig holds the configuration for stopping the publishing after
+// FailureThreshold consecutive failed publishes, for OpenDurationInSec seconds, after which
+// one publish is attempted. A zero FailureThreshold disables it
+type RabbitMQCircuitBreakerConfig struct {
+	FailureThreshold  uint32
+	OpenDurationInSec uint32
+}
+
+// RabbitMQTLSConfig holds the TLS configuration for amqps connections
+type RabbitMQTLSConfig struct {
+	CAFile   string
+	CertFile string
+	KeyFile  string
+
+	// InsecureSkipVerify disables the broker certificate verification, it should
+	// only be used for development
+	InsecureSkipVerify bool
 }
 
 // RabbitMQExchangeConfig holds the configuration for a rabbitMQ exchange
 type RabbitMQExchangeConfig struct {
-	Name string
-	Type string
+	Name               string
+	Type               string
+	Durable            bool
+	RoutingKeyTemplate string
 }
 
 // WebSocketConfig holds the configuration for websocket observer interaction config
@@ -102,6 +475,21 @@ type WebSocketConfig struct {
 	DataMarshallerType string
 }
 
+// GRPCConfig holds the configuration for the gRPC observer connector
+type GRPCConfig struct {
+	Enabled bool
+	URL     string
+
+	DataMarshallerType string
+}
+
+// GRPCSubscriptionConfig holds the configuration for the gRPC events subscription server
+type GRPCSubscriptionConfig struct {
+	Enabled         bool
+	URL             string
+	SendTimeoutInMs uint32
+}
+
 // FlagsConfig holds the values for CLI flags
 type FlagsConfig struct {
 	LogLevel          string
@@ -0,0 +1,173 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// envVarPrefix is the prefix of the environment variables which override the config fields
+const envVarPrefix = "NOTIFIER_"
+
+// envVarOverride sets a config field from the value of an environment variable
+type envVarOverride struct {
+	name  string
+	apply func(cfg *MainConfig, value string) error
+}
+
+// envVarOverrides holds the supported environment variables, as described in the README
+var envVarOverrides = []envVarOverride{
+	{name: "CHECK_DUPLICATES", apply: func(cfg *MainConfig, value string) error {
+		return setBool(&cfg.General.CheckDuplicates, value)
+	}},
+	{name: "API_HOST", apply: func(cfg *MainConfig, value string) error {
+		cfg.ConnectorApi.Host = value
+		return nil
+	}},
+	{name: "API_PORT", apply: func(cfg *MainConfig, value string) error {
+		cfg.ConnectorApi.Host = replacePort(cfg.ConnectorApi.Host, value)
+		return nil
+	}},
+	{name: "API_USERNAME", apply: func(cfg *MainConfig, value string) error {
+		cfg.ConnectorApi.Username = value
+		return nil
+	}},
+	{name: "API_PASSWORD", apply: func(cfg *MainConfig, value string) error {
+		cfg.ConnectorApi.Password = value
+		return nil
+	}},
+	{name: "API_JWT_SECRET_KEY", apply: func(cfg *MainConfig, value string) error {
+		cfg.ConnectorApi.JWTSecretKey = value
+		return nil
+	}},
+	{name: "WS_ENABLED", apply: func(cfg *MainConfig, value string) error {
+		return setBool(&cfg.WebSocketConnector.Enabled, value)
+	}},
+	{name: "WS_URL", apply: func(cfg *MainConfig, value string) error {
+		cfg.WebSocketConnector.URL = value
+		return nil
+	}},
+	{name: "GRPC_ENABLED", apply: func(cfg *MainConfig, value string) error {
+		return setBool(&cfg.GRPCConnector.Enabled, value)
+	}},
+	{name: "GRPC_URL", apply: func(cfg *MainConfig, value string) error {
+		cfg.GRPCConnector.URL = value
+		return nil
+	}},
+	{name: "RABBITMQ_URL", apply: func(cfg *MainConfig, value string) error {
+		cfg.RabbitMQ.Url = value
+		return nil
+	}},
+	{name: "RABBITMQ_URLS", apply: func(cfg *MainConfig, value string) error {
+		cfg.RabbitMQ.URLs = splitList(value)
+		return nil
+	}},
+	{name: "REDIS_URL", apply: func(cfg *MainConfig, value string) error {
+		cfg.Redis.Url = value
+		return nil
+	}},
+	{name: "REDIS_SENTINEL_URL", apply: func(cfg *MainConfig, value string) error {
+		cfg.Redis.SentinelUrl = value
+		return nil
+	}},
+	{name: "REDIS_PUBSUB_URL", apply: func(cfg *MainConfig, value string) error {
+		cfg.RedisPubSub.Url = value
+		return nil
+	}},
+	{name: "REDIS_PUBSUB_ADDRESS", apply: func(cfg *MainConfig, value string) error {
+		cfg.RedisPubSub.Address = value
+		return nil
+	}},
+	{name: "REDIS_PUBSUB_PASSWORD", apply: func(cfg *MainConfig, value string) error {
+		cfg.RedisPubSub.Password = value
+		return nil
+	}},
+	{name: "BACKPLANE_URL", apply: func(cfg *MainConfig, value string) error {
+		cfg.Backplane.Url = value
+		return nil
+	}},
+	{name: "KAFKA_BROKERS", apply: func(cfg *MainConfig, value string) error {
+		cfg.Kafka.Brokers = splitList(value)
+		return nil
+	}},
+	{name: "KAFKA_SASL_USERNAME", apply: func(cfg *MainConfig, value string) error {
+		cfg.Kafka.SASL.Username = value
+		return nil
+	}},
+	{name: "KAFKA_SASL_PASSWORD", apply: func(cfg *MainConfig, value string) error {
+		cfg.Kafka.SASL.Password = value
+		return nil
+	}},
+	{name: "NATS_URL", apply: func(cfg *MainConfig, value string) error {
+		cfg.NATS.URL = value
+		return nil
+	}},
+	{name: "WEBHOOK_URLS", apply: func(cfg *MainConfig, value string) error {
+		cfg.Webhook.URLs = splitList(value)
+		return nil
+	}},
+	{name: "WEBHOOK_HMAC_SECRET", apply: func(cfg *MainConfig, value string) error {
+		cfg.Webhook.HMACSecret = value
+		return nil
+	}},
+}
+
+// LoadConfigFromEnv overrides the config fields with the values of the NOTIFIER_ prefixed
+// environment variables, so that the secrets can be injected in containerised deployments.
+// It should be called after loading the config file, so that the environment variables
+// take precedence. The variables which are not set or are empty are ignored
+func LoadConfigFromEnv(cfg *MainConfig) error {
+	if cfg == nil {
+		return ErrNilMainConfig
+	}
+
+	for _, override := range envVarOverrides {
+		name := envVarPrefix + override.name
+		value, ok := os.LookupEnv(name)
+		if !ok || value == "" {
+			continue
+		}
+
+		err := override.apply(cfg, value)
+		if err != nil {
+			return fmt.Errorf("%w %s: %s", ErrInvalidEnvVarValue, name, err.Error())
+		}
+	}
+
+	return nil
+}
+
+func setBool(field *bool, value string) error {
+	boolValue, err := strconv.ParseBool(value)
+	if err != nil {
+		return err
+	}
+
+	*field = boolValue
+	return nil
+}
+
+// replacePort keeps the host of the address, if any, since the address can also be
+// specified only as the port
+func replacePort(address string, port string) string {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return port
+	}
+
+	return net.JoinHostPort(host, port)
+}
+
+func splitList(value string) []string {
+	values := make([]string, 0)
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			values = append(values, item)
+		}
+	}
+
+	return values
+}
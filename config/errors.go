@@ -0,0 +1,51 @@
+package config
+
+import "errors"
+
+// ErrInvalidPort signals that a port outside the allowed range has been provided
+var ErrInvalidPort = errors.New("invalid port")
+
+// ErrInvalidURL signals that an url which cannot be parsed has been provided
+var ErrInvalidURL = errors.New("invalid url")
+
+// ErrEmptyExchangeName signals that an empty name has been provided for a required exchange
+var ErrEmptyExchangeName = errors.New("empty exchange name")
+
+// ErrInvalidRetryDuration signals that a non positive retry duration has been provided
+var ErrInvalidRetryDuration = errors.New("invalid retry duration")
+
+// ErrEmptyMarshallerType signals that an empty marshaller type has been provided
+var ErrEmptyMarshallerType = errors.New("empty marshaller type")
+
+// ErrEmptyInternalMarshallerType signals that an empty internal marshaller type has been provided
+var ErrEmptyInternalMarshallerType = errors.New("empty internal marshaller type")
+
+// ErrInvalidAddressConverter signals that an invalid address converter config has been provided
+var ErrInvalidAddressConverter = errors.New("invalid address converter config")
+
+// ErrIncompleteTLSConfig signals that only one of the certificate and key files has been provided
+var ErrIncompleteTLSConfig = errors.New("both the certificate and the key files have to be provided")
+
+// ErrEmptyJWTSecretKey signals that the JWT authentication is required without a secret key
+var ErrEmptyJWTSecretKey = errors.New("empty JWT secret key")
+
+// ErrNilMainConfig signals that a nil main config has been provided
+var ErrNilMainConfig = errors.New("nil main config")
+
+// ErrInvalidEnvVarValue signals that an environment variable holds an invalid value
+var ErrInvalidEnvVarValue = errors.New("invalid value for environment variable")
+
+// ErrEmptyBackplaneChannel signals that the backplane is enabled without a channel name
+var ErrEmptyBackplaneChannel = errors.New("empty backplane channel")
+
+// ErrEmptyPersistenceFilePath signals that the subscriptions persistence is enabled without a file path
+var ErrEmptyPersistenceFilePath = errors.New("empty persistence file path")
+
+// ErrInvalidRedisDB signals that a negative redis database index has been provided
+var ErrInvalidRedisDB = errors.New("invalid redis database index")
+
+// ErrEmptyMulticastHubChannels signals that the multicast mode is enabled without hub channels
+var ErrEmptyMulticastHubChannels = errors.New("empty multicast hub channels")
+
+// ErrEmptyMulticastHubChannel signals that an empty multicast hub channel has been provided
+var ErrEmptyMulticastHubChannel = errors.New("empty multicast hub channel")
@@ -45,6 +45,9 @@ func TestMainConfig(t *testing.T) {
 			ExternalMarshaller: config.MarshallerConfig{
 				Type: generalMarshallerType,
 			},
+			InternalMarshaller: config.MarshallerConfig{
+				Type: generalMarshallerType,
+			},
 			AddressConverter: config.AddressConverterConfig{
 				Type:   adrConverterType,
 				Prefix: adrConverterPrefix,
@@ -0,0 +1,211 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/multiversx/mx-chain-notifier-go/common"
+)
+
+const (
+	minPort = 1024
+	maxPort = 65535
+)
+
+// Validate checks the configs of the components started by the notifier. The rabbitMQ config
+// is only checked if the rabbitMQ publisher type is enabled
+func (cfg *Configs) Validate() error {
+	err := cfg.MainConfig.General.Validate()
+	if err != nil {
+		return fmt.Errorf("%w in General config", err)
+	}
+	err = cfg.MainConfig.ConnectorApi.Validate()
+	if err != nil {
+		return fmt.Errorf("%w in ConnectorApi config", err)
+	}
+	err = cfg.MainConfig.WebSocketConnector.Validate()
+	if err != nil {
+		return fmt.Errorf("%w in WebSocketConnector config", err)
+	}
+
+	err = cfg.MainConfig.Backplane.Validate()
+	if err != nil {
+		return fmt.Errorf("%w in Backplane config", err)
+	}
+	err = cfg.MainConfig.Multicast.Validate()
+	if err != nil {
+		return fmt.Errorf("%w in Multicast config", err)
+	}
+	err = cfg.MainConfig.SubscriptionsPersistence.Validate()
+	if err != nil {
+		return fmt.Errorf("%w in SubscriptionsPersistence config", err)
+	}
+
+	if common.IsPublisherTypeEnabled(cfg.Flags.PublisherType, common.RedisPublisherType) {
+		err = cfg.MainConfig.RedisPubSub.Validate()
+		if err != nil {
+			return fmt.Errorf("%w in RedisPubSub config", err)
+		}
+	}
+
+	if common.IsPublisherTypeEnabled(cfg.Flags.PublisherType, common.MessageQueuePublisherType) {
+		err = cfg.MainConfig.RabbitMQ.Validate()
+		if err != nil {
+			return fmt.Errorf("%w in RabbitMQ config", err)
+		}
+	}
+
+	return nil
+}
+
+// Validate checks the backplane config, if it is enabled
+func (bc BackplaneConfig) Validate() error {
+	if bc.Enabled && bc.Channel == "" {
+		return ErrEmptyBackplaneChannel
+	}
+
+	return nil
+}
+
+// Validate checks the redis pubsub config
+func (rpc RedisPubSubConfig) Validate() error {
+	if rpc.DB < 0 {
+		return fmt.Errorf("%w: %d", ErrInvalidRedisDB, rpc.DB)
+	}
+
+	return nil
+}
+
+// Validate checks the multicast config, if it is enabled
+func (mc MulticastConfig) Validate() error {
+	if !mc.Enabled {
+		return nil
+	}
+	if len(mc.HubChannels) == 0 {
+		return ErrEmptyMulticastHubChannels
+	}
+	for index, channel := range mc.HubChannels {
+		if channel == "" {
+			return fmt.Errorf("%w at index %d", ErrEmptyMulticastHubChannel, index)
+		}
+	}
+
+	return nil
+}
+
+// Validate checks the persistence config, if it is enabled
+func (pc PersistenceConfig) Validate() error {
+	if pc.Enabled && pc.FilePath == "" {
+		return ErrEmptyPersistenceFilePath
+	}
+
+	return nil
+}
+
+// Validate checks the general config
+func (gc GeneralConfig) Validate() error {
+	if gc.ExternalMarshaller.Type == "" {
+		return ErrEmptyMarshallerType
+	}
+	if gc.InternalMarshaller.Type == "" {
+		return ErrEmptyInternalMarshallerType
+	}
+	if gc.AddressConverter.Length <= 0 || gc.AddressConverter.Prefix == "" {
+		return ErrInvalidAddressConverter
+	}
+
+	return nil
+}
+
+// Validate checks the connector api config. The web server is started even if the http
+// connector is disabled, so the host is always checked
+func (cac ConnectorApiConfig) Validate() error {
+	err := checkPort(cac.Host)
+	if err != nil {
+		return err
+	}
+	if (cac.CertFile == "") != (cac.KeyFile == "") {
+		return ErrIncompleteTLSConfig
+	}
+	if cac.AuthRequired && cac.JWTSecretKey == "" {
+		return ErrEmptyJWTSecretKey
+	}
+
+	return nil
+}
+
+// Validate checks the websocket connector config, if enabled
+func (wsc WebSocketConfig) Validate() error {
+	if !wsc.Enabled {
+		return nil
+	}
+
+	err := checkPort(wsc.URL)
+	if err != nil {
+		return err
+	}
+	if wsc.RetryDurationInSec <= 0 {
+		return fmt.Errorf("%w: %d", ErrInvalidRetryDuration, wsc.RetryDurationInSec)
+	}
+
+	return nil
+}
+
+// Validate checks the rabbitMQ config, including the names of the exchanges which are
+// always published on
+func (rc RabbitMQConfig) Validate() error {
+	urls := rc.GetURLs()
+	if len(urls) == 0 {
+		return fmt.Errorf("%w: no url provided", ErrInvalidURL)
+	}
+	for _, rawURL := range urls {
+		parsedURL, err := url.Parse(rawURL)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidURL, err.Error())
+		}
+		if parsedURL.Scheme != "amqp" && parsedURL.Scheme != "amqps" {
+			return fmt.Errorf("%w: unsupported scheme %q", ErrInvalidURL, parsedURL.Scheme)
+		}
+	}
+
+	requiredExchanges := []struct {
+		section  string
+		exchange RabbitMQExchangeConfig
+	}{
+		{section: "EventsExchange", exchange: rc.EventsExchange},
+		{section: "RevertEventsExchange", exchange: rc.RevertEventsExchange},
+		{section: "FinalizedEventsExchange", exchange: rc.FinalizedEventsExchange},
+		{section: "BlockTxsExchange", exchange: rc.BlockTxsExchange},
+		{section: "BlockScrsExchange", exchange: rc.BlockScrsExchange},
+		{section: "BlockEventsExchange", exchange: rc.BlockEventsExchange},
+	}
+	for _, requiredExchange := range requiredExchanges {
+		if requiredExchange.exchange.Name == "" {
+			return fmt.Errorf("%w for %s", ErrEmptyExchangeName, requiredExchange.section)
+		}
+	}
+
+	return nil
+}
+
+// checkPort checks the port of an address, which can also be specified only as the port
+func checkPort(address string) error {
+	port := address
+	if strings.Contains(address, ":") {
+		var err error
+		_, port, err = net.SplitHostPort(address)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidURL, err.Error())
+		}
+	}
+
+	portValue, err := strconv.Atoi(port)
+	if err != nil || portValue < minPort || portValue > maxPort {
+		return fmt.Errorf("%w: %q, should be between %d and %d", ErrInvalidPort, port, minPort, maxPort)
+	}
+
+	return nil
+}
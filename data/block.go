@@ -10,6 +10,7 @@ import (
 	"github.com/multiversx/mx-chain-core-go/data/rewardTx"
 	"github.com/multiversx/mx-chain-core-go/data/smartContractResult"
 	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // SaveBlockData holds the filtered block data that will be received on push events
@@ -30,6 +31,7 @@ type InterceptorBlockData struct {
 	Scrs          map[string]*smartContractResult.SmartContractResult
 	ScrsWithOrder map[string]*outport.SCRInfo
 	LogEvents     []Event
+	TxEvents      []TxEvent
 }
 
 // ArgsSaveBlockData holds the block data that will be received on push events
@@ -43,6 +45,11 @@ type ArgsSaveBlockData struct {
 	TransactionsPool       *outport.TransactionPool
 	AlteredAccounts        map[string]*alteredAccount.AlteredAccount
 	NumberOfShards         uint32
+	CorrelationID          string
+	SpanContext            trace.SpanContext
+
+	// Timestamp is the time the payload was received by the notifier, in unix nanoseconds
+	Timestamp int64
 }
 
 // OutportBlockDataOld holds the block data that will be received on push events
@@ -8,6 +8,7 @@ import (
 	"github.com/multiversx/mx-chain-core-go/data/rewardTx"
 	"github.com/multiversx/mx-chain-core-go/data/smartContractResult"
 	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // WebSocketEvent defines a websocket event
@@ -23,6 +24,56 @@ type Event struct {
 	Topics     [][]byte `json:"topics"`
 	Data       []byte   `json:"data"`
 	TxHash     string   `json:"txHash"`
+
+	// EventIndex is the position of the event in the log of its transaction or smart contract result
+	EventIndex uint32 `json:"eventIndex"`
+
+	// OriginalTxHash is set for the events of the smart contract results, TxHash being the
+	// smart contract result hash
+	OriginalTxHash string `json:"originalTxHash,omitempty"`
+
+	// TxStatus and TxFee are the status and the fee of the transaction which originated the
+	// event. They are only set if enabled and if the transaction is in the block
+	TxStatus string `json:"txStatus,omitempty"`
+	TxFee    string `json:"txFee,omitempty"`
+
+	// Transfers holds the decoded token transfers of the ESDT/NFT transfer events. They are
+	// only set if enabled and if the topics of the event have the expected layout
+	Transfers []TokenTransfer `json:"transfers,omitempty"`
+
+	// CrossShard is set for the events of the cross shard transactions completed in the
+	// block, on the destination shard. It is only used for routing, it is not published
+	CrossShard bool `json:"-"`
+
+	// BlockNonce is the nonce of the block of the event. It is only used for filtering,
+	// it is not published
+	BlockNonce uint64 `json:"-"`
+}
+
+// TokenTransfer holds a token transfer decoded from the topics of an ESDT/NFT transfer
+// event. The sender is the address of the event
+type TokenTransfer struct {
+	Token    string `json:"token"`
+	Nonce    uint64 `json:"nonce"`
+	Value    string `json:"value"`
+	Receiver string `json:"receiver"`
+}
+
+// BlockEvent holds a single event, together with the details of its block. Index is
+// the position of the event in the block
+type BlockEvent struct {
+	Hash      string `json:"hash"`
+	ShardID   uint32 `json:"shardId"`
+	TimeStamp uint64 `json:"timestamp"`
+	Index     uint32 `json:"index"`
+	Event     Event  `json:"event"`
+}
+
+// BlockEventsBatch holds the events of multiple blocks published as a single message.
+// Hashes lists the hashes of the blocks, in the same order as the blocks
+type BlockEventsBatch struct {
+	Hashes []string      `json:"hashes"`
+	Blocks []BlockEvents `json:"blocks"`
 }
 
 // BlockEvents holds events data for a block
@@ -31,6 +82,33 @@ type BlockEvents struct {
 	ShardID   uint32  `json:"shardId"`
 	TimeStamp uint64  `json:"timestamp"`
 	Events    []Event `json:"events"`
+
+	// Nonce is used by the hub to replay the recent blocks, it is not published
+	Nonce uint64 `json:"-"`
+
+	// Timestamp is the time the payload was received by the notifier, in unix nanoseconds,
+	// unlike TimeStamp, which is the block timestamp
+	Timestamp int64 `json:"notifierTimestamp"`
+
+	// Sequence is incremented by the hub for each broadcast, it is 0 if published without the hub
+	Sequence uint64 `json:"sequence,omitempty"`
+
+	// CorrelationID identifies the received payload in the log lines, it is only published
+	// as the correlation_id header of the rabbitMQ messages
+	CorrelationID string `json:"-"`
+
+	// SpanContext holds the trace context of the received payload, it is not published
+	SpanContext trace.SpanContext `json:"-"`
+
+	// Chunk is set only if the events of the block are published as multiple messages
+	Chunk *EventsChunk `json:"chunk,omitempty"`
+}
+
+// EventsChunk identifies a part of the logs and events of a block, so that the consumers can
+// reassemble the block from the messages with the same hash
+type EventsChunk struct {
+	Index uint32 `json:"index"`
+	Total uint32 `json:"total"`
 }
 
 // RevertBlock holds revert event data
@@ -39,11 +117,136 @@ type RevertBlock struct {
 	Nonce uint64 `json:"nonce"`
 	Round uint64 `json:"round"`
 	Epoch uint32 `json:"epoch"`
+
+	// Timestamp is the time the payload was received by the notifier, in unix nanoseconds
+	Timestamp int64 `json:"notifierTimestamp"`
+
+	// Sequence is incremented by the hub for each broadcast, it is 0 if published without the hub
+	Sequence uint64 `json:"sequence,omitempty"`
+
+	// TxHashes and Events hold the transaction hashes and the events of the reverted block,
+	// if the block details are kept. DetailsUnavailable is set if they are kept, but the
+	// block is no longer among the recently processed blocks
+	TxHashes           []string        `json:"txHashes,omitempty"`
+	Events             []RevertedEvent `json:"events,omitempty"`
+	DetailsUnavailable bool            `json:"detailsUnavailable,omitempty"`
+
+	// CorrelationID identifies the received payload in the log lines, it is only published
+	// as the correlation_id header of the rabbitMQ messages
+	CorrelationID string `json:"-"`
+
+	// SpanContext holds the trace context of the received payload, it is not published
+	SpanContext trace.SpanContext `json:"-"`
+}
+
+// RevertedEvent holds the summary of an event of a reverted block
+type RevertedEvent struct {
+	Address    string `json:"address"`
+	Identifier string `json:"identifier"`
+	TxHash     string `json:"txHash"`
 }
 
 // FinalizedBlock holds finalized block data
 type FinalizedBlock struct {
 	Hash string `json:"hash"`
+
+	// ShardID, Nonce, Round, BlockTimeStamp and TxHashes hold the details of the finalized block,
+	// if they are kept. Enriched is set only if the details are kept, to false if the block is no
+	// longer among the recently processed blocks
+	ShardID        *uint32  `json:"shardId,omitempty"`
+	Nonce          uint64   `json:"nonce,omitempty"`
+	Round          uint64   `json:"round,omitempty"`
+	BlockTimeStamp uint64   `json:"timestamp,omitempty"`
+	TxHashes       []string `json:"txHashes,omitempty"`
+	Enriched       *bool    `json:"enriched,omitempty"`
+
+	// Timestamp is the time the payload was received by the notifier, in unix nanoseconds
+	Timestamp int64 `json:"notifierTimestamp"`
+
+	// Sequence is incremented by the hub for each broadcast, it is 0 if published without the hub
+	Sequence uint64 `json:"sequence,omitempty"`
+
+	// CorrelationID identifies the received payload in the log lines, it is only published
+	// as the correlation_id header of the rabbitMQ messages
+	CorrelationID string `json:"-"`
+
+	// SpanContext holds the trace context of the received payload, it is not published
+	SpanContext trace.SpanContext `json:"-"`
+}
+
+// RoundInfo holds the information of a round: if a block was proposed or the round was
+// missed, and the consensus group indexes of the validators which signed the block. The
+// observer payload does not hold the public key of the proposer
+type RoundInfo struct {
+	Round            uint64   `json:"round"`
+	BlockWasProposed bool     `json:"blockWasProposed"`
+	SignersIndexes   []uint64 `json:"signersIndexes"`
+	ShardID          uint32   `json:"shardId"`
+	Epoch            uint32   `json:"epoch"`
+	Timestamp        uint64   `json:"timestamp"`
+}
+
+// RoundEvents holds the rounds information pushed by the observer of a shard
+type RoundEvents struct {
+	ShardID uint32      `json:"shardId"`
+	Rounds  []RoundInfo `json:"rounds"`
+
+	// CorrelationID identifies the received payload in the log lines, it is only published
+	// as the correlation_id header of the rabbitMQ messages
+	CorrelationID string `json:"-"`
+
+	// SpanContext holds the trace context of the received payload, it is not published
+	SpanContext trace.SpanContext `json:"-"`
+}
+
+// ValidatorRating holds the rating of a validator, identified by its hex encoded public key
+type ValidatorRating struct {
+	PublicKey string  `json:"publicKey"`
+	Rating    float32 `json:"rating"`
+}
+
+// ValidatorsRatingEvent holds the validators rating pushed by the observer of a shard
+type ValidatorsRatingEvent struct {
+	ShardID          uint32            `json:"shardId"`
+	Epoch            uint32            `json:"epoch"`
+	ValidatorsRating []ValidatorRating `json:"validatorsRating"`
+
+	// CorrelationID identifies the received payload in the log lines, it is only published
+	// as the correlation_id header of the rabbitMQ messages
+	CorrelationID string `json:"-"`
+
+	// SpanContext holds the trace context of the received payload, it is not published
+	SpanContext trace.SpanContext `json:"-"`
+}
+
+// AccountTokenBalance holds the balance of a token owned by an altered account
+type AccountTokenBalance struct {
+	Identifier string `json:"identifier"`
+	Nonce      uint64 `json:"nonce"`
+	Balance    string `json:"balance"`
+}
+
+// AccountEvent holds the state of an account after it was altered in a block. The
+// tokens are set only for the accounts with altered token balances
+type AccountEvent struct {
+	Address string                `json:"address"`
+	Nonce   uint64                `json:"nonce"`
+	Balance string                `json:"balance"`
+	Tokens  []AccountTokenBalance `json:"tokens,omitempty"`
+}
+
+// AccountsEvents holds the accounts altered in a block of a shard, sorted by address
+type AccountsEvents struct {
+	ShardID        uint32         `json:"shardId"`
+	BlockTimestamp uint64         `json:"blockTimestamp"`
+	Accounts       []AccountEvent `json:"accounts"`
+
+	// CorrelationID identifies the received payload in the log lines, it is only published
+	// as the correlation_id header of the rabbitMQ messages
+	CorrelationID string `json:"-"`
+
+	// SpanContext holds the trace context of the received payload, it is not published
+	SpanContext trace.SpanContext `json:"-"`
 }
 
 // BlockTxs holds the block transactions
@@ -58,6 +261,23 @@ type BlockScrs struct {
 	Scrs map[string]*smartContractResult.SmartContractResult `json:"scrs"`
 }
 
+// TxEvent holds the notification data for a transaction
+type TxEvent struct {
+	Hash     string `json:"hash"`
+	Sender   string `json:"sender"`
+	Receiver string `json:"receiver"`
+	Value    string `json:"value"`
+	Status   string `json:"status"`
+}
+
+// BlockTxEvents holds the transaction notifications for a block
+type BlockTxEvents struct {
+	Hash      string    `json:"hash"`
+	ShardID   uint32    `json:"shardId"`
+	TimeStamp uint64    `json:"timestamp"`
+	TxEvents  []TxEvent `json:"txEvents"`
+}
+
 // BlockEventsWithOrder holds the block transactions with order
 type BlockEventsWithOrder struct {
 	Hash      string                      `json:"hash"`
@@ -0,0 +1,6 @@
+package payload
+
+import "errors"
+
+// ErrUnsupportedPayloadType signals that the payload type has no protobuf message defined
+var ErrUnsupportedPayloadType = errors.New("unsupported protobuf payload type")
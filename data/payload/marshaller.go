@@ -0,0 +1,227 @@
+//go:generate protoc -I=. -I=$GOPATH/src -I=$GOPATH/src/github.com/multiversx/protobuf/protobuf --gogoslick_out=$GOPATH/src payload.proto
+package payload
+
+import (
+	"fmt"
+
+	"github.com/multiversx/mx-chain-core-go/marshal"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+)
+
+type protoPayloadMarshaller struct {
+	marshaller *marshal.GogoProtoMarshalizer
+}
+
+// NewProtoPayloadMarshaller creates a marshaller which encodes the published block events,
+// block event, revert and finalized events with the equivalent gogo protobuf messages, as
+// well as the events, replay unavailable and wrapper messages pushed to websocket clients.
+// The other payload types are not supported
+func NewProtoPayloadMarshaller() *protoPayloadMarshaller {
+	return &protoPayloadMarshaller{
+		marshaller: &marshal.GogoProtoMarshalizer{},
+	}
+}
+
+// Marshal encodes the payload as its protobuf message
+func (ppm *protoPayloadMarshaller) Marshal(obj interface{}) ([]byte, error) {
+	switch payload := obj.(type) {
+	case data.BlockEvents:
+		return ppm.marshaller.Marshal(blockEventsToProto(payload))
+	case *data.BlockEvents:
+		return ppm.marshaller.Marshal(blockEventsToProto(*payload))
+	case data.BlockEvent:
+		return ppm.marshaller.Marshal(blockEventToProto(payload))
+	case *data.BlockEvent:
+		return ppm.marshaller.Marshal(blockEventToProto(*payload))
+	case data.RevertBlock:
+		return ppm.marshaller.Marshal(revertBlockToProto(payload))
+	case *data.RevertBlock:
+		return ppm.marshaller.Marshal(revertBlockToProto(*payload))
+	case data.FinalizedBlock:
+		return ppm.marshaller.Marshal(&FinalizedBlock{Hash: payload.Hash})
+	case *data.FinalizedBlock:
+		return ppm.marshaller.Marshal(&FinalizedBlock{Hash: payload.Hash})
+	case []data.Event:
+		return ppm.marshaller.Marshal(&Events{Events: eventsToProto(payload)})
+	case data.ReplayUnavailable:
+		return ppm.marshaller.Marshal(replayUnavailableToProto(payload))
+	case *data.ReplayUnavailable:
+		return ppm.marshaller.Marshal(replayUnavailableToProto(*payload))
+	case data.WebSocketEvent:
+		return ppm.marshaller.Marshal(&WebSocketEvent{Type: payload.Type, Data: payload.Data})
+	case *data.WebSocketEvent:
+		return ppm.marshaller.Marshal(&WebSocketEvent{Type: payload.Type, Data: payload.Data})
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedPayloadType, obj)
+	}
+}
+
+// Unmarshal decodes the protobuf message into the payload
+func (ppm *protoPayloadMarshaller) Unmarshal(obj interface{}, buff []byte) error {
+	switch payload := obj.(type) {
+	case *data.BlockEvents:
+		msg := &BlockEvents{}
+		err := ppm.marshaller.Unmarshal(msg, buff)
+		if err != nil {
+			return err
+		}
+		*payload = blockEventsFromProto(msg)
+	case *data.BlockEvent:
+		msg := &BlockEvent{}
+		err := ppm.marshaller.Unmarshal(msg, buff)
+		if err != nil {
+			return err
+		}
+		*payload = blockEventFromProto(msg)
+	case *data.RevertBlock:
+		msg := &RevertBlock{}
+		err := ppm.marshaller.Unmarshal(msg, buff)
+		if err != nil {
+			return err
+		}
+		*payload = data.RevertBlock{
+			Hash:  msg.Hash,
+			Nonce: msg.Nonce,
+			Round: msg.Round,
+			Epoch: msg.Epoch,
+		}
+	case *data.FinalizedBlock:
+		msg := &FinalizedBlock{}
+		err := ppm.marshaller.Unmarshal(msg, buff)
+		if err != nil {
+			return err
+		}
+		*payload = data.FinalizedBlock{Hash: msg.Hash}
+	case *[]data.Event:
+		msg := &Events{}
+		err := ppm.marshaller.Unmarshal(msg, buff)
+		if err != nil {
+			return err
+		}
+		*payload = eventsFromProto(msg.Events)
+	case *data.ReplayUnavailable:
+		msg := &ReplayUnavailable{}
+		err := ppm.marshaller.Unmarshal(msg, buff)
+		if err != nil {
+			return err
+		}
+		*payload = data.ReplayUnavailable{
+			FromBlockNonce:   msg.FromBlockNonce,
+			FromHash:         msg.FromHash,
+			OldestBlockNonce: msg.OldestBlockNonce,
+			OldestHash:       msg.OldestHash,
+		}
+	case *data.WebSocketEvent:
+		msg := &WebSocketEvent{}
+		err := ppm.marshaller.Unmarshal(msg, buff)
+		if err != nil {
+			return err
+		}
+		*payload = data.WebSocketEvent{Type: msg.Type, Data: msg.Data}
+	default:
+		return fmt.Errorf("%w: %T", ErrUnsupportedPayloadType, obj)
+	}
+
+	return nil
+}
+
+func blockEventsToProto(blockEvents data.BlockEvents) *BlockEvents {
+	return &BlockEvents{
+		Hash:      blockEvents.Hash,
+		ShardID:   blockEvents.ShardID,
+		TimeStamp: blockEvents.TimeStamp,
+		Events:    eventsToProto(blockEvents.Events),
+	}
+}
+
+func blockEventsFromProto(msg *BlockEvents) data.BlockEvents {
+	return data.BlockEvents{
+		Hash:      msg.Hash,
+		ShardID:   msg.ShardID,
+		TimeStamp: msg.TimeStamp,
+		Events:    eventsFromProto(msg.Events),
+	}
+}
+
+func eventsToProto(events []data.Event) []Event {
+	protoEvents := make([]Event, 0, len(events))
+	for _, event := range events {
+		protoEvents = append(protoEvents, eventToProto(event))
+	}
+
+	return protoEvents
+}
+
+// eventsFromProto keeps the events nil if there is none, as the json decoding of a block
+// without events
+func eventsFromProto(protoEvents []Event) []data.Event {
+	var events []data.Event
+	for _, event := range protoEvents {
+		events = append(events, eventFromProto(event))
+	}
+
+	return events
+}
+
+func replayUnavailableToProto(replayUnavailable data.ReplayUnavailable) *ReplayUnavailable {
+	return &ReplayUnavailable{
+		FromBlockNonce:   replayUnavailable.FromBlockNonce,
+		FromHash:         replayUnavailable.FromHash,
+		OldestBlockNonce: replayUnavailable.OldestBlockNonce,
+		OldestHash:       replayUnavailable.OldestHash,
+	}
+}
+
+func blockEventToProto(blockEvent data.BlockEvent) *BlockEvent {
+	return &BlockEvent{
+		Hash:      blockEvent.Hash,
+		ShardID:   blockEvent.ShardID,
+		TimeStamp: blockEvent.TimeStamp,
+		Index:     blockEvent.Index,
+		Event:     eventToProto(blockEvent.Event),
+	}
+}
+
+func blockEventFromProto(msg *BlockEvent) data.BlockEvent {
+	return data.BlockEvent{
+		Hash:      msg.Hash,
+		ShardID:   msg.ShardID,
+		TimeStamp: msg.TimeStamp,
+		Index:     msg.Index,
+		Event:     eventFromProto(msg.Event),
+	}
+}
+
+func revertBlockToProto(revertBlock data.RevertBlock) *RevertBlock {
+	return &RevertBlock{
+		Hash:  revertBlock.Hash,
+		Nonce: revertBlock.Nonce,
+		Round: revertBlock.Round,
+		Epoch: revertBlock.Epoch,
+	}
+}
+
+func eventToProto(event data.Event) Event {
+	return Event{
+		Address:    event.Address,
+		Identifier: event.Identifier,
+		Topics:     event.Topics,
+		Data:       event.Data,
+		TxHash:     event.TxHash,
+	}
+}
+
+func eventFromProto(event Event) data.Event {
+	return data.Event{
+		Address:    event.Address,
+		Identifier: event.Identifier,
+		Topics:     event.Topics,
+		Data:       event.Data,
+		TxHash:     event.TxHash,
+	}
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (ppm *protoPayloadMarshaller) IsInterfaceNil() bool {
+	return ppm == nil
+}
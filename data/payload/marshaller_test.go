@@ -0,0 +1,201 @@
+package payload_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/data/payload"
+	"github.com/stretchr/testify/require"
+)
+
+func createBlockEvents() data.BlockEvents {
+	return data.BlockEvents{
+		Hash:      "blockHash1",
+		ShardID:   1,
+		TimeStamp: 1234,
+		Events: []data.Event{
+			{
+				Address:    "erd1addr1",
+				Identifier: "ESDTTransfer",
+				Topics:     [][]byte{[]byte("topic1"), []byte("topic2")},
+				Data:       []byte("data1"),
+				TxHash:     "txHash1",
+			},
+			{
+				Address:    "erd1addr2",
+				Identifier: "writeLog",
+				Topics:     [][]byte{[]byte("topic3")},
+				Data:       []byte("data2"),
+				TxHash:     "txHash2",
+			},
+		},
+	}
+}
+
+func TestNewProtoPayloadMarshaller(t *testing.T) {
+	t.Parallel()
+
+	marshaller := payload.NewProtoPayloadMarshaller()
+	require.False(t, check.IfNil(marshaller))
+}
+
+func TestProtoPayloadMarshaller_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	t.Run("block events should decode as the json payload", func(t *testing.T) {
+		t.Parallel()
+
+		blockEvents := createBlockEvents()
+		blockEvents.CorrelationID = "correlationID"
+
+		var protoEvents, jsonEvents data.BlockEvents
+		requireRoundTrip(t, blockEvents, &protoEvents, &jsonEvents)
+		require.Equal(t, jsonEvents, protoEvents)
+		require.Equal(t, createBlockEvents(), protoEvents)
+	})
+
+	t.Run("block without events should decode as the json payload", func(t *testing.T) {
+		t.Parallel()
+
+		blockEvents := data.BlockEvents{Hash: "blockHash1"}
+
+		var protoEvents, jsonEvents data.BlockEvents
+		requireRoundTrip(t, &blockEvents, &protoEvents, &jsonEvents)
+		require.Equal(t, jsonEvents, protoEvents)
+	})
+
+	t.Run("block event should decode as the json payload", func(t *testing.T) {
+		t.Parallel()
+
+		blockEvents := createBlockEvents()
+		blockEvent := data.BlockEvent{
+			Hash:      blockEvents.Hash,
+			ShardID:   blockEvents.ShardID,
+			TimeStamp: blockEvents.TimeStamp,
+			Index:     1,
+			Event:     blockEvents.Events[1],
+		}
+
+		var protoEvent, jsonEvent data.BlockEvent
+		requireRoundTrip(t, blockEvent, &protoEvent, &jsonEvent)
+		require.Equal(t, jsonEvent, protoEvent)
+		require.Equal(t, blockEvent, protoEvent)
+	})
+
+	t.Run("revert block should decode as the json payload", func(t *testing.T) {
+		t.Parallel()
+
+		revertBlock := data.RevertBlock{
+			Hash:  "blockHash1",
+			Nonce: 10,
+			Round: 11,
+			Epoch: 2,
+		}
+
+		var protoRevert, jsonRevert data.RevertBlock
+		requireRoundTrip(t, revertBlock, &protoRevert, &jsonRevert)
+		require.Equal(t, jsonRevert, protoRevert)
+		require.Equal(t, revertBlock, protoRevert)
+	})
+
+	t.Run("finalized block should decode as the json payload", func(t *testing.T) {
+		t.Parallel()
+
+		finalizedBlock := data.FinalizedBlock{Hash: "blockHash1"}
+
+		var protoFinalized, jsonFinalized data.FinalizedBlock
+		requireRoundTrip(t, &finalizedBlock, &protoFinalized, &jsonFinalized)
+		require.Equal(t, jsonFinalized, protoFinalized)
+		require.Equal(t, finalizedBlock, protoFinalized)
+	})
+
+	t.Run("events should decode as the json payload", func(t *testing.T) {
+		t.Parallel()
+
+		events := createBlockEvents().Events
+
+		var protoEvents, jsonEvents []data.Event
+		requireRoundTrip(t, events, &protoEvents, &jsonEvents)
+		require.Equal(t, jsonEvents, protoEvents)
+		require.Equal(t, events, protoEvents)
+	})
+
+	t.Run("replay unavailable should decode as the json payload", func(t *testing.T) {
+		t.Parallel()
+
+		replayUnavailable := data.ReplayUnavailable{
+			FromBlockNonce:   1,
+			FromHash:         "blockHash1",
+			OldestBlockNonce: 5,
+			OldestHash:       "blockHash5",
+		}
+
+		var protoReplay, jsonReplay data.ReplayUnavailable
+		requireRoundTrip(t, &replayUnavailable, &protoReplay, &jsonReplay)
+		require.Equal(t, jsonReplay, protoReplay)
+		require.Equal(t, replayUnavailable, protoReplay)
+	})
+
+	t.Run("websocket event should keep the encoded data", func(t *testing.T) {
+		t.Parallel()
+
+		marshaller := payload.NewProtoPayloadMarshaller()
+		finalizedBytes, err := marshaller.Marshal(data.FinalizedBlock{Hash: "blockHash1"})
+		require.Nil(t, err)
+
+		wsEvent := data.WebSocketEvent{Type: "finalized_events", Data: finalizedBytes}
+		wsEventBytes, err := marshaller.Marshal(&wsEvent)
+		require.Nil(t, err)
+
+		var decoded data.WebSocketEvent
+		err = marshaller.Unmarshal(&decoded, wsEventBytes)
+		require.Nil(t, err)
+		require.Equal(t, wsEvent, decoded)
+
+		var finalizedBlock data.FinalizedBlock
+		err = marshaller.Unmarshal(&finalizedBlock, decoded.Data)
+		require.Nil(t, err)
+		require.Equal(t, data.FinalizedBlock{Hash: "blockHash1"}, finalizedBlock)
+	})
+}
+
+func requireRoundTrip(t *testing.T, obj interface{}, protoDecoded interface{}, jsonDecoded interface{}) {
+	marshaller := payload.NewProtoPayloadMarshaller()
+
+	protoBytes, err := marshaller.Marshal(obj)
+	require.Nil(t, err)
+	err = marshaller.Unmarshal(protoDecoded, protoBytes)
+	require.Nil(t, err)
+
+	jsonBytes, err := json.Marshal(obj)
+	require.Nil(t, err)
+	err = json.Unmarshal(jsonBytes, jsonDecoded)
+	require.Nil(t, err)
+
+	require.NotEqual(t, jsonBytes, protoBytes)
+}
+
+func TestProtoPayloadMarshaller_UnsupportedPayloads(t *testing.T) {
+	t.Parallel()
+
+	marshaller := payload.NewProtoPayloadMarshaller()
+
+	buff, err := marshaller.Marshal(data.BlockTxs{Hash: "blockHash1"})
+	require.Nil(t, buff)
+	require.True(t, errors.Is(err, payload.ErrUnsupportedPayloadType))
+
+	err = marshaller.Unmarshal(&data.BlockScrs{}, []byte("payload"))
+	require.True(t, errors.Is(err, payload.ErrUnsupportedPayloadType))
+}
+
+func TestProtoPayloadMarshaller_UnmarshalInvalidPayload(t *testing.T) {
+	t.Parallel()
+
+	marshaller := payload.NewProtoPayloadMarshaller()
+
+	err := marshaller.Unmarshal(&data.BlockEvents{}, []byte{0xff, 0xff})
+	require.NotNil(t, err)
+}
@@ -0,0 +1,46 @@
+package payload
+
+import (
+	"bytes"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+const jsonStructTag = "json"
+
+type msgpackMarshaller struct {
+}
+
+// NewMessagePackMarshaller creates a marshaller which encodes the payloads with MessagePack.
+// The struct fields are named by their json tags, so the decoded payloads have the same
+// structure as the json ones
+func NewMessagePackMarshaller() *msgpackMarshaller {
+	return &msgpackMarshaller{}
+}
+
+// Marshal encodes the payload with MessagePack
+func (mm *msgpackMarshaller) Marshal(obj interface{}) ([]byte, error) {
+	buff := &bytes.Buffer{}
+	encoder := msgpack.NewEncoder(buff)
+	encoder.SetCustomStructTag(jsonStructTag)
+
+	err := encoder.Encode(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return buff.Bytes(), nil
+}
+
+// Unmarshal decodes the MessagePack buffer into the provided object
+func (mm *msgpackMarshaller) Unmarshal(obj interface{}, buff []byte) error {
+	decoder := msgpack.NewDecoder(bytes.NewReader(buff))
+	decoder.SetCustomStructTag(jsonStructTag)
+
+	return decoder.Decode(obj)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (mm *msgpackMarshaller) IsInterfaceNil() bool {
+	return mm == nil
+}
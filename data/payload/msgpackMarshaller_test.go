@@ -0,0 +1,62 @@
+package payload_test
+
+import (
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/data/payload"
+	"github.com/stretchr/testify/require"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func TestNewMessagePackMarshaller(t *testing.T) {
+	t.Parallel()
+
+	marshaller := payload.NewMessagePackMarshaller()
+	require.False(t, check.IfNil(marshaller))
+}
+
+func TestMessagePackMarshaller_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	t.Run("block events should round trip", func(t *testing.T) {
+		t.Parallel()
+
+		blockEvents := createBlockEvents()
+		marshaller := payload.NewMessagePackMarshaller()
+
+		buff, err := marshaller.Marshal(&blockEvents)
+		require.Nil(t, err)
+
+		var decoded data.BlockEvents
+		err = marshaller.Unmarshal(&decoded, buff)
+		require.Nil(t, err)
+		require.Equal(t, blockEvents, decoded)
+	})
+
+	t.Run("fields should be named by their json tags", func(t *testing.T) {
+		t.Parallel()
+
+		marshaller := payload.NewMessagePackMarshaller()
+		buff, err := marshaller.Marshal(data.RevertBlock{Hash: "blockHash1", Nonce: 10, Round: 11, Epoch: 2})
+		require.Nil(t, err)
+
+		decoded := make(map[string]interface{})
+		err = msgpack.Unmarshal(buff, &decoded)
+		require.Nil(t, err)
+		require.Equal(t, "blockHash1", decoded["hash"])
+		require.EqualValues(t, 10, decoded["nonce"])
+		require.EqualValues(t, 11, decoded["round"])
+		require.EqualValues(t, 2, decoded["epoch"])
+	})
+}
+
+func TestMessagePackMarshaller_UnmarshalInvalidPayload(t *testing.T) {
+	t.Parallel()
+
+	marshaller := payload.NewMessagePackMarshaller()
+
+	err := marshaller.Unmarshal(&data.BlockEvents{}, []byte{0xc1})
+	require.NotNil(t, err)
+}
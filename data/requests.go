@@ -2,6 +2,8 @@ package data
 
 import (
 	"time"
+
+	"github.com/google/uuid"
 )
 
 // EndpointMetricsResponse defines the response for status metrics endpoint
@@ -9,3 +11,76 @@ type EndpointMetricsResponse struct {
 	NumRequests       uint64        `json:"num_requests"`
 	TotalResponseTime time.Duration `json:"total_response_time"`
 }
+
+// HealthStatusResponse defines the response for health endpoint
+type HealthStatusResponse struct {
+	Status     string            `json:"status"`
+	Components map[string]string `json:"components"`
+}
+
+// ProcessedBlock defines the last processed block of a shard, as exposed by the status endpoint
+type ProcessedBlock struct {
+	ShardID   uint32 `json:"shardId"`
+	Nonce     uint64 `json:"nonce"`
+	Hash      string `json:"hash"`
+	TimeStamp uint64 `json:"timestamp"`
+
+	// ProcessedAt is the time the block was processed by the notifier, in unix nanoseconds
+	ProcessedAt int64 `json:"processedAt"`
+}
+
+// PayloadVersionsResponse defines the observer payload versions supported by the notifier
+type PayloadVersionsResponse struct {
+	SupportedVersions []uint32 `json:"supportedVersions"`
+	VersionFallback   bool     `json:"versionFallback"`
+}
+
+// SubscriptionStatsResponse defines the response for the hub subscriptions stats endpoint
+type SubscriptionStatsResponse struct {
+	NumDispatchers   int                      `json:"numDispatchers"`
+	NumSubscriptions int                      `json:"numSubscriptions"`
+	WindowInSec      uint64                   `json:"windowInSec"`
+	Subscriptions    []SubscriptionMatchStats `json:"subscriptions"`
+}
+
+// EventMatchResponse defines the response for the hub subscriptions match endpoint
+type EventMatchResponse struct {
+	DispatcherIDs []uuid.UUID    `json:"dispatcherIds"`
+	Subscriptions []Subscription `json:"subscriptions"`
+}
+
+// SubscriptionMatchStats holds the number of events matched by a logs and events subscription
+// within the stats window
+type SubscriptionMatchStats struct {
+	ID           uint64    `json:"id"`
+	DispatcherID uuid.UUID `json:"dispatcherId"`
+	Address      string    `json:"address"`
+	Identifier   string    `json:"identifier"`
+	MatchLevel   string    `json:"matchLevel"`
+	NumMatches   uint64    `json:"numMatches"`
+}
+
+// ReadinessStatusResponse defines the response for readiness endpoint
+type ReadinessStatusResponse struct {
+	Status       string            `json:"status"`
+	FailedChecks map[string]string `json:"failed_checks"`
+}
+
+// WebhookRegistrationResponse defines the response for webhook registration endpoint
+type WebhookRegistrationResponse struct {
+	ID string `json:"id"`
+}
+
+// StoredBlockEventsResponse defines a block of the events by nonce endpoint response
+type StoredBlockEventsResponse struct {
+	Nonce     uint64  `json:"nonce"`
+	Hash      string  `json:"hash"`
+	ShardID   uint32  `json:"shardId"`
+	TimeStamp uint64  `json:"timestamp"`
+	Events    []Event `json:"events"`
+}
+
+// EventsByNonceResponse defines the response for events by nonce endpoint
+type EventsByNonceResponse struct {
+	Blocks []StoredBlockEventsResponse `json:"blocks"`
+}
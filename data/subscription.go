@@ -1,27 +1,119 @@
 package data
 
-import "github.com/google/uuid"
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
 
 // SubscribeEvent defines a subscription event
+// If FromHash or FromBlockNonce is set, the recent broadcasts starting with that block
+// are replayed to the dispatcher before the live ones. FromHash takes precedence
+// Otherwise, if SinceSequence is set, the recent broadcasts done after the one with that
+// sequence number are replayed
 type SubscribeEvent struct {
 	DispatcherID        uuid.UUID
 	SubscriptionEntries []SubscriptionEntry `json:"subscriptionEntries"`
+	FromBlockNonce      uint64              `json:"fromBlockNonce"`
+	FromHash            string              `json:"fromHash"`
+	SinceSequence       uint64              `json:"sinceSequence"`
+	Encoding            string              `json:"encoding"`
+
+	// SubscribeToAll has to be set by the websocket clients subscribing to all the logs and
+	// events, with no entries or with entries without any address, identifier or topics
+	SubscribeToAll bool `json:"subscribeToAll"`
+
+	// Resumable is set by the hub for the dispatchers whose id was supplied by the client. Only
+	// their subscriptions are persisted, since the others can not be matched after a restart
+	Resumable bool `json:"-"`
+}
+
+// ReplayUnavailable is sent to a dispatcher which requested a replay starting with a block
+// older than the oldest block kept in memory. The oldest block is empty if none is kept
+type ReplayUnavailable struct {
+	FromBlockNonce   uint64 `json:"fromBlockNonce"`
+	FromHash         string `json:"fromHash"`
+	OldestBlockNonce uint64 `json:"oldestBlockNonce"`
+	OldestHash       string `json:"oldestHash"`
+}
+
+// ErrorFrame is sent to a websocket client when its subscribe message can not be processed.
+// It is always sent as a json text message, whatever the requested encoding, and it can be
+// told apart from the pushed events by its "error" type:
+//
+//	{"type": "error", "code": "invalid_subscribe", "message": "invalid character 'x' ..."}
+//
+// The code is one of the common.*ErrorCode values and the message holds the error details
+type ErrorFrame struct {
+	Type    string `json:"type"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// SubscribeAckFrame is sent to a websocket client when its subscribe message was accepted.
+// Like the error frames, it is always sent as a json text message, with the "subscribed"
+// type, the id of the dispatcher serving the connection and all the subscriptions stored
+// for it, as normalized by the hub:
+//
+//	{"type": "subscribed", "dispatcherId": "6f1c...", "subscriptions": [{"eventType": "all_events", "matchLevel": "*", ...}]}
+type SubscribeAckFrame struct {
+	Type          string         `json:"type"`
+	DispatcherID  string         `json:"dispatcherId"`
+	Subscriptions []Subscription `json:"subscriptions"`
 }
 
 // SubscriptionEntry holds the subscription entry data
 type SubscriptionEntry struct {
-	EventType  string   `json:"eventType"`
-	Address    string   `json:"address"`
-	Identifier string   `json:"identifier"`
-	Topics     []string `json:"topics"`
+	EventType   string   `json:"eventType"`
+	Address     string   `json:"address"`
+	Identifier  string   `json:"identifier"`
+	Identifiers []string `json:"identifiers"`
+	Topics      []string `json:"topics"`
 }
 
 // Subscription holds subscription data
 type Subscription struct {
-	Address      string
-	Identifier   string
-	Topics       []string
-	MatchLevel   string
-	EventType    string
-	DispatcherID uuid.UUID
+	// ID is assigned by the subscription mapper, unique among its subscriptions
+	ID         uint64 `json:"id"`
+	Address    string `json:"address"`
+	Identifier string `json:"identifier"`
+
+	// Identifiers holds the other identifiers matched by the subscription, if any
+	Identifiers  []string  `json:"identifiers"`
+	Topics       []string  `json:"topics"`
+	MatchLevel   string    `json:"matchLevel"`
+	EventType    string    `json:"eventType"`
+	DispatcherID uuid.UUID `json:"dispatcherId"`
+	CreatedAt    time.Time `json:"createdAt"`
+
+	// IsGlob is set if the address is a pattern with "*" wildcards, matched against
+	// the event addresses instead of being compared to them
+	IsGlob bool `json:"isGlob"`
+}
+
+// WebhookRegistration holds the data of a webhook registered via the REST api. The
+// matched events are posted to the url, signed with the secret if it is set
+type WebhookRegistration struct {
+	URL                 string              `json:"url"`
+	Secret              string              `json:"secret"`
+	SubscriptionEntries []SubscriptionEntry `json:"subscriptionEntries"`
+}
+
+// ConsumerSubscription holds the subscription entries registered via the REST api by a
+// rabbitMQ consumer. The matched logs and events are published to the consumer events
+// exchange with the consumer id as routing key
+type ConsumerSubscription struct {
+	ConsumerID          string              `json:"consumerId"`
+	SubscriptionEntries []SubscriptionEntry `json:"subscriptionEntries"`
+}
+
+// BackplaneMessage holds a hub broadcast shared with the other notifier instances. The
+// instance id is used to skip the broadcasts published by the same instance. The nonce
+// of the logs and events broadcasts is set separately, since it is not published
+type BackplaneMessage struct {
+	InstanceID string          `json:"instanceId"`
+	Type       string          `json:"type"`
+	Nonce      uint64          `json:"nonce,omitempty"`
+	Data       json.RawMessage `json:"data"`
 }
@@ -0,0 +1,29 @@
+package disabled
+
+import (
+	"github.com/multiversx/mx-chain-notifier-go/common"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+)
+
+// EventStore defines a disabled event store component
+type EventStore struct{}
+
+// Save does nothing
+func (es *EventStore) Save(_ data.BlockEvents) error {
+	return nil
+}
+
+// GetFromNonce returns ErrEventStoreNotEnabled
+func (es *EventStore) GetFromNonce(_ uint64) ([]data.BlockEvents, error) {
+	return nil, common.ErrEventStoreNotEnabled
+}
+
+// Close returns nil
+func (es *EventStore) Close() error {
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (es *EventStore) IsInterfaceNil() bool {
+	return es == nil
+}
@@ -1,6 +1,10 @@
 package disabled
 
 import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/multiversx/mx-chain-notifier-go/common"
 	"github.com/multiversx/mx-chain-notifier-go/data"
 	"github.com/multiversx/mx-chain-notifier-go/dispatcher"
 )
@@ -9,28 +13,74 @@ import (
 type Hub struct {
 }
 
+// Run does nothing
+func (h *Hub) Run() error {
+	return nil
+}
+
 // Publish does nothing
-func (h *Hub) Publish(events data.BlockEvents) {
+func (h *Hub) Publish(_ context.Context, events data.BlockEvents) error {
+	return nil
 }
 
 // PublishRevert does nothing
-func (h *Hub) PublishRevert(revertBlock data.RevertBlock) {
+func (h *Hub) PublishRevert(_ context.Context, revertBlock data.RevertBlock) error {
+	return nil
 }
 
 // PublishFinalized does nothing
-func (h *Hub) PublishFinalized(finalizedBlock data.FinalizedBlock) {
+func (h *Hub) PublishFinalized(_ context.Context, finalizedBlock data.FinalizedBlock) error {
+	return nil
 }
 
 // PublishTxs does nothing
-func (h *Hub) PublishTxs(blockTxs data.BlockTxs) {
+func (h *Hub) PublishTxs(_ context.Context, blockTxs data.BlockTxs) error {
+	return nil
 }
 
 // PublishScrs does nothing
-func (h *Hub) PublishScrs(blockScrs data.BlockScrs) {
+func (h *Hub) PublishScrs(_ context.Context, blockScrs data.BlockScrs) error {
+	return nil
 }
 
 // PublishBlockEventsWithOrder does nothing
-func (h *Hub) PublishBlockEventsWithOrder(blockTxs data.BlockEventsWithOrder) {
+func (h *Hub) PublishBlockEventsWithOrder(_ context.Context, blockTxs data.BlockEventsWithOrder) error {
+	return nil
+}
+
+// PublishTxEvents does nothing
+func (h *Hub) PublishTxEvents(_ context.Context, blockTxEvents data.BlockTxEvents) error {
+	return nil
+}
+
+// PublishRounds does nothing
+func (h *Hub) PublishRounds(_ context.Context, roundEvents data.RoundEvents) error {
+	return nil
+}
+
+// PublishValidatorsRating does nothing
+func (h *Hub) PublishValidatorsRating(_ context.Context, validatorsRating data.ValidatorsRatingEvent) error {
+	return nil
+}
+
+// PublishAccounts does nothing
+func (h *Hub) PublishAccounts(_ context.Context, accountsEvents data.AccountsEvents) error {
+	return nil
+}
+
+// GetMetricsForPrometheus returns an empty string
+func (h *Hub) GetMetricsForPrometheus() string {
+	return ""
+}
+
+// GetHealthState returns not applicable
+func (h *Hub) GetHealthState() string {
+	return common.HealthStateNotApplicable
+}
+
+// Ping returns nil
+func (h *Hub) Ping(_ context.Context) error {
+	return nil
 }
 
 // RegisterEvent does nothing
@@ -41,8 +91,39 @@ func (h *Hub) RegisterEvent(_ dispatcher.EventDispatcher) {
 func (h *Hub) UnregisterEvent(_ dispatcher.EventDispatcher) {
 }
 
-// Subscribe does nothing
-func (h *Hub) Subscribe(_ data.SubscribeEvent) {
+// Subscribe returns nil
+func (h *Hub) Subscribe(_ data.SubscribeEvent) error {
+	return nil
+}
+
+// GetSubscriptions returns an empty slice
+func (h *Hub) GetSubscriptions(_ uuid.UUID) []data.Subscription {
+	return make([]data.Subscription, 0)
+}
+
+// DisconnectDispatcher returns dispatcher not found error
+func (h *Hub) DisconnectDispatcher(_ uuid.UUID) error {
+	return common.ErrDispatcherNotFound
+}
+
+// GetSubscriptionStats returns empty stats
+func (h *Hub) GetSubscriptionStats() data.SubscriptionStatsResponse {
+	return data.SubscriptionStatsResponse{
+		Subscriptions: make([]data.SubscriptionMatchStats, 0),
+	}
+}
+
+// MatchEvent returns an empty response
+func (h *Hub) MatchEvent(_ data.Event) data.EventMatchResponse {
+	return data.EventMatchResponse{
+		DispatcherIDs: make([]uuid.UUID, 0),
+		Subscriptions: make([]data.Subscription, 0),
+	}
+}
+
+// UpdateFilter returns hub not enabled error
+func (h *Hub) UpdateFilter(_ dispatcher.EventFilter) error {
+	return common.ErrHubNotEnabled
 }
 
 // Close returns nil
@@ -1,6 +1,8 @@
 package disabled
 
 import (
+	"context"
+
 	"github.com/multiversx/mx-chain-notifier-go/data"
 )
 
@@ -23,6 +25,21 @@ func (dp *Publisher) BroadcastRevert(_ data.RevertBlock) {
 func (dp *Publisher) BroadcastFinalized(_ data.FinalizedBlock) {
 }
 
+// BroadcastWithContext does nothing
+func (dp *Publisher) BroadcastWithContext(_ context.Context, _ data.BlockEvents) error {
+	return nil
+}
+
+// BroadcastRevertWithContext does nothing
+func (dp *Publisher) BroadcastRevertWithContext(_ context.Context, _ data.RevertBlock) error {
+	return nil
+}
+
+// BroadcastFinalizedWithContext does nothing
+func (dp *Publisher) BroadcastFinalizedWithContext(_ context.Context, _ data.FinalizedBlock) error {
+	return nil
+}
+
 // BroadcastTxs does nothing
 func (dp *Publisher) BroadcastTxs(_ data.BlockTxs) {
 }
@@ -35,6 +52,62 @@ func (dp *Publisher) BroadcastScrs(_ data.BlockScrs) {
 func (dp *Publisher) BroadcastBlockEventsWithOrder(_ data.BlockEventsWithOrder) {
 }
 
+// BroadcastTxEvents does nothing
+func (dp *Publisher) BroadcastTxEvents(_ data.BlockTxEvents) {
+}
+
+// BroadcastRounds does nothing
+func (dp *Publisher) BroadcastRounds(_ data.RoundEvents) {
+}
+
+// BroadcastValidatorsRating does nothing
+func (dp *Publisher) BroadcastValidatorsRating(_ data.ValidatorsRatingEvent) {
+}
+
+// BroadcastAccounts does nothing
+func (dp *Publisher) BroadcastAccounts(_ data.AccountsEvents) {
+}
+
+// BroadcastTxsWithContext does nothing
+func (dp *Publisher) BroadcastTxsWithContext(_ context.Context, _ data.BlockTxs) error {
+	return nil
+}
+
+// BroadcastScrsWithContext does nothing
+func (dp *Publisher) BroadcastScrsWithContext(_ context.Context, _ data.BlockScrs) error {
+	return nil
+}
+
+// BroadcastBlockEventsWithOrderWithContext does nothing
+func (dp *Publisher) BroadcastBlockEventsWithOrderWithContext(_ context.Context, _ data.BlockEventsWithOrder) error {
+	return nil
+}
+
+// BroadcastTxEventsWithContext does nothing
+func (dp *Publisher) BroadcastTxEventsWithContext(_ context.Context, _ data.BlockTxEvents) error {
+	return nil
+}
+
+// BroadcastRoundsWithContext does nothing
+func (dp *Publisher) BroadcastRoundsWithContext(_ context.Context, _ data.RoundEvents) error {
+	return nil
+}
+
+// BroadcastValidatorsRatingWithContext does nothing
+func (dp *Publisher) BroadcastValidatorsRatingWithContext(_ context.Context, _ data.ValidatorsRatingEvent) error {
+	return nil
+}
+
+// BroadcastAccountsWithContext does nothing
+func (dp *Publisher) BroadcastAccountsWithContext(_ context.Context, _ data.AccountsEvents) error {
+	return nil
+}
+
+// Errors returns nil, no error is ever notified
+func (dp *Publisher) Errors() <-chan error {
+	return nil
+}
+
 // Close returns nil
 func (dp *Publisher) Close() error {
 	return nil
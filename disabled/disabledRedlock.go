@@ -15,6 +15,11 @@ func (drw *disabledRedlockWrapper) IsEventProcessed(_ context.Context, _ string)
 	return true, nil
 }
 
+// RemoveEvent returns nil
+func (drw *disabledRedlockWrapper) RemoveEvent(_ context.Context, _ string) error {
+	return nil
+}
+
 // HasConnection returns true
 func (drw *disabledRedlockWrapper) HasConnection(_ context.Context) bool {
 	return true
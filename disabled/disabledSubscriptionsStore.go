@@ -0,0 +1,34 @@
+package disabled
+
+import (
+	"github.com/google/uuid"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+)
+
+// SubscriptionsStore defines a disabled subscriptions store component
+type SubscriptionsStore struct{}
+
+// SaveSubscriptions does nothing
+func (ss *SubscriptionsStore) SaveSubscriptions(_ uuid.UUID, _ []data.Subscription) error {
+	return nil
+}
+
+// RemoveSubscriptions does nothing
+func (ss *SubscriptionsStore) RemoveSubscriptions(_ uuid.UUID) error {
+	return nil
+}
+
+// LoadSubscriptions returns no subscriptions
+func (ss *SubscriptionsStore) LoadSubscriptions() (map[uuid.UUID][]data.Subscription, error) {
+	return make(map[uuid.UUID][]data.Subscription), nil
+}
+
+// Close returns nil
+func (ss *SubscriptionsStore) Close() error {
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (ss *SubscriptionsStore) IsInterfaceNil() bool {
+	return ss == nil
+}
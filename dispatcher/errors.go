@@ -0,0 +1,21 @@
+package dispatcher
+
+import "errors"
+
+// ErrMaxSubscriptionsReached signals that the dispatcher already has the maximum number of subscriptions
+var ErrMaxSubscriptionsReached = errors.New("maximum number of subscriptions per dispatcher reached")
+
+// ErrInvalidMaxSubscriptionsPerDispatcher signals that an invalid maximum number of subscriptions has been provided
+var ErrInvalidMaxSubscriptionsPerDispatcher = errors.New("invalid maximum number of subscriptions per dispatcher")
+
+// ErrInvalidGlobPattern signals that a subscription address with an invalid glob pattern has been provided
+var ErrInvalidGlobPattern = errors.New("invalid glob pattern")
+
+// ErrInvalidSubscriptionAddress signals that a subscription address which can not be decoded has been provided
+var ErrInvalidSubscriptionAddress = errors.New("invalid subscription address")
+
+// ErrInvalidSubscriptionTTL signals that an invalid subscription ttl has been provided
+var ErrInvalidSubscriptionTTL = errors.New("invalid subscription ttl")
+
+// ErrNilSubscriptionsStore signals that a nil subscriptions store has been provided
+var ErrNilSubscriptionsStore = errors.New("nil subscriptions store")
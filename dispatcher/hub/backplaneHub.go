@@ -0,0 +1,354 @@
+package hub
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/marshal"
+	"github.com/multiversx/mx-chain-notifier-go/common"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/dispatcher"
+)
+
+const (
+	backplanePublishTimeout = time.Second
+	backplaneRetryInterval  = time.Second
+)
+
+// ArgsBackplaneHub defines the arguments needed for backplane hub creation
+type ArgsBackplaneHub struct {
+	Hub        dispatcher.Hub
+	Backplane  dispatcher.Backplane
+	Marshaller marshal.Marshalizer
+
+	// InstanceID identifies the broadcasts published by this instance on the backplane
+	InstanceID string
+}
+
+// backplaneHub shares the broadcasts of the local hub with the other notifier instances,
+// and delivers the broadcasts received from them to the locally connected dispatchers. The
+// subscriptions and the dispatchers are handled by the local hub
+type backplaneHub struct {
+	dispatcher.Hub
+	backplane  dispatcher.Backplane
+	marshaller marshal.Marshalizer
+	instanceID string
+
+	mutState   sync.Mutex
+	cancelFunc func()
+}
+
+// NewBackplaneHub creates a hub which shares the broadcasts of the provided hub through the backplane
+func NewBackplaneHub(args ArgsBackplaneHub) (*backplaneHub, error) {
+	err := checkBackplaneHubArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return &backplaneHub{
+		Hub:        args.Hub,
+		backplane:  args.Backplane,
+		marshaller: args.Marshaller,
+		instanceID: args.InstanceID,
+	}, nil
+}
+
+func checkBackplaneHubArgs(args ArgsBackplaneHub) error {
+	if check.IfNil(args.Hub) {
+		return ErrNilHub
+	}
+	if check.IfNil(args.Backplane) {
+		return ErrNilBackplane
+	}
+	if check.IfNil(args.Marshaller) {
+		return common.ErrNilMarshaller
+	}
+	if args.InstanceID == "" {
+		return ErrEmptyInstanceID
+	}
+
+	return nil
+}
+
+// Run starts the local hub and the subscription to the broadcasts of the other instances
+func (bh *backplaneHub) Run() error {
+	err := bh.Hub.Run()
+	if err != nil {
+		return err
+	}
+
+	bh.mutState.Lock()
+	defer bh.mutState.Unlock()
+
+	var ctx context.Context
+	ctx, bh.cancelFunc = context.WithCancel(context.Background())
+
+	go bh.listen(ctx)
+
+	return nil
+}
+
+// listen subscribes to the backplane until the context is done, subscribing again if the
+// subscription fails. The broadcasts received in the meantime are not delivered
+func (bh *backplaneHub) listen(ctx context.Context) {
+	for {
+		err := bh.backplane.Subscribe(ctx, bh.handlePayload)
+		if ctx.Err() != nil {
+			return
+		}
+		if err == nil {
+			err = ErrBackplaneSubscriptionClosed
+		}
+
+		log.Warn("backplane subscription failed, will retry",
+			"retry interval", backplaneRetryInterval,
+			"err", err.Error(),
+		)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backplaneRetryInterval):
+		}
+	}
+}
+
+func (bh *backplaneHub) handlePayload(payload []byte) {
+	var message data.BackplaneMessage
+	err := bh.marshaller.Unmarshal(&message, payload)
+	if err != nil {
+		log.Error("could not unmarshal backplane message", "err", err.Error())
+		return
+	}
+	if message.InstanceID == bh.instanceID {
+		return
+	}
+
+	err = bh.deliver(message)
+	if err != nil {
+		log.Error("could not deliver backplane message",
+			"type", message.Type,
+			"instance id", message.InstanceID,
+			"err", err.Error(),
+		)
+	}
+}
+
+// deliver broadcasts the message received from another instance with the local hub only
+func (bh *backplaneHub) deliver(message data.BackplaneMessage) error {
+	ctx := context.Background()
+
+	switch message.Type {
+	case common.PushLogsAndEvents:
+		var blockEvents data.BlockEvents
+		err := bh.marshaller.Unmarshal(&blockEvents, message.Data)
+		if err != nil {
+			return err
+		}
+		blockEvents.Nonce = message.Nonce
+		for i := range blockEvents.Events {
+			blockEvents.Events[i].BlockNonce = message.Nonce
+		}
+		bh.Hub.Publish(ctx, blockEvents)
+	case common.RevertBlockEvents:
+		var revertBlock data.RevertBlock
+		err := bh.marshaller.Unmarshal(&revertBlock, message.Data)
+		if err != nil {
+			return err
+		}
+		bh.Hub.PublishRevert(ctx, revertBlock)
+	case common.FinalizedBlockEvents:
+		var finalizedBlock data.FinalizedBlock
+		err := bh.marshaller.Unmarshal(&finalizedBlock, message.Data)
+		if err != nil {
+			return err
+		}
+		bh.Hub.PublishFinalized(ctx, finalizedBlock)
+	case common.BlockTxs:
+		var blockTxs data.BlockTxs
+		err := bh.marshaller.Unmarshal(&blockTxs, message.Data)
+		if err != nil {
+			return err
+		}
+		bh.Hub.PublishTxs(ctx, blockTxs)
+	case common.BlockScrs:
+		var blockScrs data.BlockScrs
+		err := bh.marshaller.Unmarshal(&blockScrs, message.Data)
+		if err != nil {
+			return err
+		}
+		bh.Hub.PublishScrs(ctx, blockScrs)
+	case common.BlockEvents:
+		var blockEvents data.BlockEventsWithOrder
+		err := bh.marshaller.Unmarshal(&blockEvents, message.Data)
+		if err != nil {
+			return err
+		}
+		bh.Hub.PublishBlockEventsWithOrder(ctx, blockEvents)
+	case common.TxEvents:
+		var blockTxEvents data.BlockTxEvents
+		err := bh.marshaller.Unmarshal(&blockTxEvents, message.Data)
+		if err != nil {
+			return err
+		}
+		bh.Hub.PublishTxEvents(ctx, blockTxEvents)
+	case common.RoundEvents:
+		var roundEvents data.RoundEvents
+		err := bh.marshaller.Unmarshal(&roundEvents, message.Data)
+		if err != nil {
+			return err
+		}
+		bh.Hub.PublishRounds(ctx, roundEvents)
+	case common.ValidatorsRatingEvents:
+		var validatorsRating data.ValidatorsRatingEvent
+		err := bh.marshaller.Unmarshal(&validatorsRating, message.Data)
+		if err != nil {
+			return err
+		}
+		bh.Hub.PublishValidatorsRating(ctx, validatorsRating)
+	case common.AccountEvents:
+		var accountsEvents data.AccountsEvents
+		err := bh.marshaller.Unmarshal(&accountsEvents, message.Data)
+		if err != nil {
+			return err
+		}
+		bh.Hub.PublishAccounts(ctx, accountsEvents)
+	default:
+		return ErrUnknownBackplaneMessageType
+	}
+
+	return nil
+}
+
+// publishToBackplane publishes the broadcast to the other instances. A failed publish is
+// only logged and not returned by the publish methods, the broadcast is still delivered to
+// the locally connected dispatchers
+func (bh *backplaneHub) publishToBackplane(ctx context.Context, eventType string, nonce uint64, event interface{}) {
+	eventBytes, err := bh.marshaller.Marshal(event)
+	if err != nil {
+		log.Error("could not marshal event for the backplane", "event", eventType, "err", err.Error())
+		return
+	}
+
+	payload, err := bh.marshaller.Marshal(data.BackplaneMessage{
+		InstanceID: bh.instanceID,
+		Type:       eventType,
+		Nonce:      nonce,
+		Data:       eventBytes,
+	})
+	if err != nil {
+		log.Error("could not marshal backplane message", "event", eventType, "err", err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, backplanePublishTimeout)
+	defer cancel()
+
+	err = bh.backplane.Publish(ctx, payload)
+	if err != nil {
+		log.Warn("failed to publish to the backplane", "event", eventType, "err", err.Error())
+	}
+}
+
+// Publish will broadcast the block events locally and to the other instances
+func (bh *backplaneHub) Publish(ctx context.Context, blockEvents data.BlockEvents) error {
+	err := bh.Hub.Publish(ctx, blockEvents)
+	bh.publishToBackplane(ctx, common.PushLogsAndEvents, blockEvents.Nonce, blockEvents)
+
+	return err
+}
+
+// PublishRevert will broadcast the revert event locally and to the other instances
+func (bh *backplaneHub) PublishRevert(ctx context.Context, revertBlock data.RevertBlock) error {
+	err := bh.Hub.PublishRevert(ctx, revertBlock)
+	bh.publishToBackplane(ctx, common.RevertBlockEvents, 0, revertBlock)
+
+	return err
+}
+
+// PublishFinalized will broadcast the finalized event locally and to the other instances
+func (bh *backplaneHub) PublishFinalized(ctx context.Context, finalizedBlock data.FinalizedBlock) error {
+	err := bh.Hub.PublishFinalized(ctx, finalizedBlock)
+	bh.publishToBackplane(ctx, common.FinalizedBlockEvents, 0, finalizedBlock)
+
+	return err
+}
+
+// PublishTxs will broadcast the txs event locally and to the other instances
+func (bh *backplaneHub) PublishTxs(ctx context.Context, blockTxs data.BlockTxs) error {
+	err := bh.Hub.PublishTxs(ctx, blockTxs)
+	bh.publishToBackplane(ctx, common.BlockTxs, 0, blockTxs)
+
+	return err
+}
+
+// PublishScrs will broadcast the scrs event locally and to the other instances
+func (bh *backplaneHub) PublishScrs(ctx context.Context, blockScrs data.BlockScrs) error {
+	err := bh.Hub.PublishScrs(ctx, blockScrs)
+	bh.publishToBackplane(ctx, common.BlockScrs, 0, blockScrs)
+
+	return err
+}
+
+// PublishBlockEventsWithOrder will broadcast the full block events locally and to the other instances
+func (bh *backplaneHub) PublishBlockEventsWithOrder(ctx context.Context, blockTxs data.BlockEventsWithOrder) error {
+	err := bh.Hub.PublishBlockEventsWithOrder(ctx, blockTxs)
+	bh.publishToBackplane(ctx, common.BlockEvents, 0, blockTxs)
+
+	return err
+}
+
+// PublishTxEvents will broadcast the transaction notifications locally and to the other instances
+func (bh *backplaneHub) PublishTxEvents(ctx context.Context, blockTxEvents data.BlockTxEvents) error {
+	err := bh.Hub.PublishTxEvents(ctx, blockTxEvents)
+	bh.publishToBackplane(ctx, common.TxEvents, 0, blockTxEvents)
+
+	return err
+}
+
+// PublishRounds will broadcast the rounds info locally and to the other instances
+func (bh *backplaneHub) PublishRounds(ctx context.Context, roundEvents data.RoundEvents) error {
+	err := bh.Hub.PublishRounds(ctx, roundEvents)
+	bh.publishToBackplane(ctx, common.RoundEvents, 0, roundEvents)
+
+	return err
+}
+
+// PublishValidatorsRating will broadcast the validators rating locally and to the other instances
+func (bh *backplaneHub) PublishValidatorsRating(ctx context.Context, validatorsRating data.ValidatorsRatingEvent) error {
+	err := bh.Hub.PublishValidatorsRating(ctx, validatorsRating)
+	bh.publishToBackplane(ctx, common.ValidatorsRatingEvents, 0, validatorsRating)
+
+	return err
+}
+
+// PublishAccounts will broadcast the altered accounts locally and to the other instances
+func (bh *backplaneHub) PublishAccounts(ctx context.Context, accountsEvents data.AccountsEvents) error {
+	err := bh.Hub.PublishAccounts(ctx, accountsEvents)
+	bh.publishToBackplane(ctx, common.AccountEvents, 0, accountsEvents)
+
+	return err
+}
+
+// Close will stop the backplane subscription, close the backplane and the local hub
+func (bh *backplaneHub) Close() error {
+	bh.mutState.Lock()
+	if bh.cancelFunc != nil {
+		bh.cancelFunc()
+	}
+	bh.mutState.Unlock()
+
+	err := bh.backplane.Close()
+	if err != nil {
+		log.Warn("failed to close the backplane", "err", err.Error())
+	}
+
+	return bh.Hub.Close()
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (bh *backplaneHub) IsInterfaceNil() bool {
+	return bh == nil
+}
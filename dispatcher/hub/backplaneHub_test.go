@@ -0,0 +1,304 @@
+package hub
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/marshal"
+	"github.com/multiversx/mx-chain-notifier-go/common"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+// inMemoryBackplane delivers each published payload to all the subscribers
+type inMemoryBackplane struct {
+	mut      sync.RWMutex
+	handlers []func(payload []byte)
+}
+
+func (imb *inMemoryBackplane) Publish(_ context.Context, payload []byte) error {
+	imb.mut.RLock()
+	defer imb.mut.RUnlock()
+
+	for _, handler := range imb.handlers {
+		handler(payload)
+	}
+
+	return nil
+}
+
+func (imb *inMemoryBackplane) Subscribe(ctx context.Context, handler func(payload []byte)) error {
+	imb.mut.Lock()
+	imb.handlers = append(imb.handlers, handler)
+	imb.mut.Unlock()
+
+	<-ctx.Done()
+
+	return nil
+}
+
+func (imb *inMemoryBackplane) numSubscribers() int {
+	imb.mut.RLock()
+	defer imb.mut.RUnlock()
+
+	return len(imb.handlers)
+}
+
+func (imb *inMemoryBackplane) Close() error {
+	return nil
+}
+
+func (imb *inMemoryBackplane) IsInterfaceNil() bool {
+	return imb == nil
+}
+
+func createMockBackplaneHubArgs() ArgsBackplaneHub {
+	return ArgsBackplaneHub{
+		Hub:        &mocks.HubStub{},
+		Backplane:  &mocks.BackplaneStub{},
+		Marshaller: &marshal.JsonMarshalizer{},
+		InstanceID: "instance1",
+	}
+}
+
+func TestNewBackplaneHub(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil hub", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockBackplaneHubArgs()
+		args.Hub = nil
+
+		bh, err := NewBackplaneHub(args)
+		require.True(t, check.IfNil(bh))
+		require.Equal(t, ErrNilHub, err)
+	})
+
+	t.Run("nil backplane", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockBackplaneHubArgs()
+		args.Backplane = nil
+
+		bh, err := NewBackplaneHub(args)
+		require.True(t, check.IfNil(bh))
+		require.Equal(t, ErrNilBackplane, err)
+	})
+
+	t.Run("nil marshaller", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockBackplaneHubArgs()
+		args.Marshaller = nil
+
+		bh, err := NewBackplaneHub(args)
+		require.True(t, check.IfNil(bh))
+		require.Equal(t, common.ErrNilMarshaller, err)
+	})
+
+	t.Run("empty instance id", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockBackplaneHubArgs()
+		args.InstanceID = ""
+
+		bh, err := NewBackplaneHub(args)
+		require.True(t, check.IfNil(bh))
+		require.Equal(t, ErrEmptyInstanceID, err)
+	})
+
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		bh, err := NewBackplaneHub(createMockBackplaneHubArgs())
+		require.Nil(t, err)
+		require.False(t, check.IfNil(bh))
+	})
+}
+
+func TestBackplaneHub_Publish(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should broadcast locally and publish to the backplane", func(t *testing.T) {
+		t.Parallel()
+
+		blockEvents := data.BlockEvents{
+			Hash:   "hash1",
+			Nonce:  10,
+			Events: []data.Event{{Address: "erd1alice", TxHash: "txHash1"}},
+		}
+
+		args := createMockBackplaneHubArgs()
+		localBroadcasts := make([]data.BlockEvents, 0)
+		args.Hub = &mocks.HubStub{
+			PublishCalled: func(_ context.Context, events data.BlockEvents) error {
+				localBroadcasts = append(localBroadcasts, events)
+				return nil
+			},
+		}
+		var message data.BackplaneMessage
+		args.Backplane = &mocks.BackplaneStub{
+			PublishCalled: func(_ context.Context, payload []byte) error {
+				return args.Marshaller.Unmarshal(&message, payload)
+			},
+		}
+		bh, _ := NewBackplaneHub(args)
+
+		bh.Publish(context.Background(), blockEvents)
+
+		require.Equal(t, []data.BlockEvents{blockEvents}, localBroadcasts)
+		require.Equal(t, "instance1", message.InstanceID)
+		require.Equal(t, common.PushLogsAndEvents, message.Type)
+		require.Equal(t, uint64(10), message.Nonce)
+	})
+
+	t.Run("failed backplane publish should still broadcast locally", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockBackplaneHubArgs()
+		numLocalBroadcasts := 0
+		args.Hub = &mocks.HubStub{
+			PublishRevertCalled: func(_ context.Context, revertBlock data.RevertBlock) error {
+				numLocalBroadcasts++
+				return nil
+			},
+		}
+		args.Backplane = &mocks.BackplaneStub{
+			PublishCalled: func(_ context.Context, _ []byte) error {
+				return errors.New("expected error")
+			},
+		}
+		bh, _ := NewBackplaneHub(args)
+
+		bh.PublishRevert(context.Background(), data.RevertBlock{Hash: "hash1"})
+
+		require.Equal(t, 1, numLocalBroadcasts)
+	})
+}
+
+func TestBackplaneHub_ReceivedBroadcasts(t *testing.T) {
+	t.Parallel()
+
+	backplane := &inMemoryBackplane{}
+
+	mutReceived := sync.Mutex{}
+	receivedByInstance2 := make([]interface{}, 0)
+	numReceivedByInstance1 := 0
+
+	args := createMockBackplaneHubArgs()
+	args.Backplane = backplane
+	args.Hub = &mocks.HubStub{
+		PublishCalled: func(_ context.Context, events data.BlockEvents) error {
+			mutReceived.Lock()
+			numReceivedByInstance1++
+			mutReceived.Unlock()
+			return nil
+		},
+	}
+	instance1, _ := NewBackplaneHub(args)
+
+	args = createMockBackplaneHubArgs()
+	args.Backplane = backplane
+	args.InstanceID = "instance2"
+	appendReceived := func(event interface{}) {
+		mutReceived.Lock()
+		receivedByInstance2 = append(receivedByInstance2, event)
+		mutReceived.Unlock()
+	}
+	args.Hub = &mocks.HubStub{
+		PublishCalled: func(_ context.Context, events data.BlockEvents) error {
+			appendReceived(events)
+			return nil
+		},
+		PublishFinalizedCalled: func(_ context.Context, finalizedBlock data.FinalizedBlock) error {
+			appendReceived(finalizedBlock)
+			return nil
+		},
+		PublishRoundsCalled: func(_ context.Context, roundEvents data.RoundEvents) error {
+			appendReceived(roundEvents)
+			return nil
+		},
+	}
+	instance2, _ := NewBackplaneHub(args)
+
+	require.Nil(t, instance1.Run())
+	require.Nil(t, instance2.Run())
+	defer func() {
+		_ = instance1.Close()
+		_ = instance2.Close()
+	}()
+	require.Eventually(t, func() bool {
+		return backplane.numSubscribers() == 2
+	}, time.Second, 5*time.Millisecond)
+
+	instance1.Publish(context.Background(), data.BlockEvents{
+		Hash:   "hash1",
+		Nonce:  10,
+		Events: []data.Event{{Address: "erd1alice", TxHash: "txHash1"}},
+	})
+	instance1.PublishFinalized(context.Background(), data.FinalizedBlock{Hash: "hash1"})
+	instance1.PublishRounds(context.Background(), data.RoundEvents{Rounds: []data.RoundInfo{{Round: 5}}})
+
+	// malformed and unknown messages should be skipped
+	_ = backplane.Publish(context.Background(), []byte("invalid"))
+	_ = backplane.Publish(context.Background(), []byte(`{"instanceId": "instance3", "type": "unknown"}`))
+
+	mutReceived.Lock()
+	defer mutReceived.Unlock()
+
+	require.Equal(t, 1, numReceivedByInstance1)
+	require.Equal(t, []interface{}{
+		data.BlockEvents{
+			Hash:   "hash1",
+			Nonce:  10,
+			Events: []data.Event{{Address: "erd1alice", TxHash: "txHash1", BlockNonce: 10}},
+		},
+		data.FinalizedBlock{Hash: "hash1"},
+		data.RoundEvents{Rounds: []data.RoundInfo{{Round: 5}}},
+	}, receivedByInstance2)
+}
+
+func TestBackplaneHub_Close(t *testing.T) {
+	t.Parallel()
+
+	subscriptionDone := make(chan struct{})
+	backplaneClosed := false
+	hubClosed := false
+
+	args := createMockBackplaneHubArgs()
+	args.Backplane = &mocks.BackplaneStub{
+		SubscribeCalled: func(ctx context.Context, _ func(payload []byte)) error {
+			<-ctx.Done()
+			close(subscriptionDone)
+			return nil
+		},
+		CloseCalled: func() error {
+			backplaneClosed = true
+			return nil
+		},
+	}
+	args.Hub = &mocks.HubStub{
+		CloseCalled: func() error {
+			hubClosed = true
+			return nil
+		},
+	}
+	bh, _ := NewBackplaneHub(args)
+
+	require.Nil(t, bh.Run())
+	require.Nil(t, bh.Close())
+
+	select {
+	case <-subscriptionDone:
+	case <-time.After(time.Second):
+		require.Fail(t, "the subscription should have been stopped")
+	}
+	require.True(t, backplaneClosed)
+	require.True(t, hubClosed)
+}
@@ -1,7 +1,13 @@
 package hub
 
 import (
+	"context"
+	"path"
+	"sort"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/multiversx/mx-chain-core-go/core/check"
@@ -10,21 +16,72 @@ import (
 	"github.com/multiversx/mx-chain-notifier-go/data"
 	"github.com/multiversx/mx-chain-notifier-go/dispatcher"
 	"github.com/multiversx/mx-chain-notifier-go/filters"
+	"github.com/multiversx/mx-chain-notifier-go/metrics"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var log = logger.GetOrCreate("hub")
 
+const (
+	numDispatchersPromMetric = "hub_dispatchers"
+	numBroadcastsPromMetric  = "hub_broadcasts"
+	eventTypePromLabel       = "event_type"
+
+	broadcastSpanName     = "commonHub.Broadcast"
+	eventTypeAttribute    = "notifier.event_type"
+	blockHashAttribute    = "notifier.block_hash"
+	numDeliveredAttribute = "notifier.num_delivered"
+)
+
 // ArgsCommonHub defines the arguments needed for common hub creation
 type ArgsCommonHub struct {
 	Filter             filters.EventFilter
 	SubscriptionMapper dispatcher.SubscriptionMapperHandler
+	MetricsCollector   common.MetricsCollector
+	EventStore         dispatcher.EventStore
+
+	// BlockNonceFilter is optional, if set only the events it matches are delivered, in
+	// addition to the subscription filter
+	BlockNonceFilter filters.EventFilter
+
+	// TracerProvider is optional, if not set no spans are recorded
+	TracerProvider trace.TracerProvider
+
+	// ReplayBufferSize is the number of recent blocks whose broadcasts are kept in memory,
+	// to be replayed to the dispatchers subscribing with a replay hint. 0 disables the replay
+	ReplayBufferSize uint32
+
+	// MatchStatsWindow is the sliding window over which the matched events of each
+	// subscription are counted. 0 disables the counting
+	MatchStatsWindow time.Duration
+}
+
+// filterHolder wraps the events filter, so that filters of different types can be swapped
+// in the same atomic value
+type filterHolder struct {
+	filter dispatcher.EventFilter
 }
 
 type commonHub struct {
-	filter             filters.EventFilter
+	// sequence is the first field, so that it is 64-bit aligned for the atomic operations
+	sequence           uint64
+	filter             atomic.Value
+	blockNonceFilter   filters.EventFilter
 	subscriptionMapper dispatcher.SubscriptionMapperHandler
+	metricsCollector   common.MetricsCollector
+	eventStore         dispatcher.EventStore
+	tracer             trace.Tracer
 	mutDispatchers     sync.RWMutex
 	dispatchers        map[uuid.UUID]dispatcher.EventDispatcher
+	deliveryQueues     map[uuid.UUID]*orderedDeliveryQueue
+	replayBuffer       *replayBuffer
+	matchStats         *subscriptionMatchStats
+	mutReserve         sync.Mutex
+	mutMetrics         sync.RWMutex
+	numBroadcasts      map[string]uint64
+	mutState           sync.Mutex
+	cancelFunc         func()
 }
 
 // NewCommonHub creates a new commonHub instance
@@ -34,12 +91,22 @@ func NewCommonHub(args ArgsCommonHub) (*commonHub, error) {
 		return nil, err
 	}
 
-	return &commonHub{
+	ch := &commonHub{
 		mutDispatchers:     sync.RWMutex{},
-		filter:             args.Filter,
+		blockNonceFilter:   args.BlockNonceFilter,
 		subscriptionMapper: args.SubscriptionMapper,
+		metricsCollector:   args.MetricsCollector,
+		eventStore:         args.EventStore,
+		tracer:             common.GetTracer(args.TracerProvider),
 		dispatchers:        make(map[uuid.UUID]dispatcher.EventDispatcher),
-	}, nil
+		deliveryQueues:     make(map[uuid.UUID]*orderedDeliveryQueue),
+		replayBuffer:       newReplayBuffer(args.ReplayBufferSize),
+		matchStats:         newSubscriptionMatchStats(args.MatchStatsWindow),
+		numBroadcasts:      make(map[string]uint64),
+	}
+	ch.filter.Store(filterHolder{filter: args.Filter})
+
+	return ch, nil
 }
 
 func checkArgs(args ArgsCommonHub) error {
@@ -49,13 +116,139 @@ func checkArgs(args ArgsCommonHub) error {
 	if check.IfNil(args.SubscriptionMapper) {
 		return ErrNilSubscriptionMapper
 	}
+	if check.IfNil(args.MetricsCollector) {
+		return common.ErrNilMetricsCollector
+	}
+	if check.IfNil(args.EventStore) {
+		return common.ErrNilEventStore
+	}
+
+	return nil
+}
+
+// Run starts the cleanup of the expired subscriptions of the dispatchers which are no
+// longer registered, until the hub is closed
+func (ch *commonHub) Run() error {
+	ch.mutState.Lock()
+	defer ch.mutState.Unlock()
+
+	if ch.cancelFunc != nil {
+		return common.ErrLoopAlreadyStarted
+	}
+
+	var ctx context.Context
+	ctx, ch.cancelFunc = context.WithCancel(context.Background())
+
+	ch.subscriptionMapper.StartCleanup(ctx, ch.isDispatcherActive)
 
 	return nil
 }
 
 // Subscribe is used by a dispatcher to send a dispatcher.SubscribeEvent
-func (ch *commonHub) Subscribe(event data.SubscribeEvent) {
-	ch.subscriptionMapper.MatchSubscribeEvent(event)
+// If the event has a replay hint, the buffered broadcasts matching the subscriptions of
+// the dispatcher are delivered before the live ones
+func (ch *commonHub) Subscribe(event data.SubscribeEvent) error {
+	event.Resumable = ch.isDispatcherResumable(event.DispatcherID)
+	if event.FromHash == "" && event.FromBlockNonce == 0 && event.SinceSequence == 0 {
+		return ch.subscriptionMapper.MatchSubscribeEvent(event)
+	}
+
+	return ch.subscribeWithReplay(event)
+}
+
+// subscribeWithReplay adds the subscriptions and reserves the replayed broadcasts while
+// holding the reservations lock, so that each broadcast is either replayed or delivered
+// live to the dispatcher
+func (ch *commonHub) subscribeWithReplay(event data.SubscribeEvent) error {
+	ch.mutDispatchers.RLock()
+	defer ch.mutDispatchers.RUnlock()
+
+	ch.mutReserve.Lock()
+	err := ch.subscriptionMapper.MatchSubscribeEvent(event)
+	if err != nil {
+		ch.mutReserve.Unlock()
+		return err
+	}
+
+	d, ok := ch.dispatchers[event.DispatcherID]
+	if !ok {
+		ch.mutReserve.Unlock()
+		return nil
+	}
+	queue := ch.deliveryQueues[event.DispatcherID]
+
+	entries, replayUnavailable := ch.getReplayEntries(event)
+	if replayUnavailable != nil {
+		sequence := queue.reserve()
+		ch.mutReserve.Unlock()
+
+		queue.deliver(sequence, func() {
+			d.ReplayUnavailable(*replayUnavailable)
+		})
+		log.Debug("replay not available", "dispatcherID", event.DispatcherID,
+			"from hash", event.FromHash,
+			"from block nonce", event.FromBlockNonce,
+			"since sequence", event.SinceSequence,
+		)
+		return nil
+	}
+
+	subscriptions := getDispatcherSubscriptions(ch.subscriptionMapper.Subscriptions(), event.DispatcherID)
+	replayedEntries := make([]*replayEntry, 0, len(entries))
+	sequences := make([]uint64, 0, len(entries))
+	for _, entry := range entries {
+		if len(subscriptions[entry.eventType]) == 0 {
+			continue
+		}
+
+		replayedEntries = append(replayedEntries, entry)
+		sequences = append(sequences, queue.reserve())
+	}
+	ch.mutReserve.Unlock()
+
+	for index, entry := range replayedEntries {
+		replayed := entry
+		queue.deliver(sequences[index], func() {
+			replayed.replay(subscriptions[replayed.eventType], d)
+		})
+	}
+
+	log.Debug("replayed broadcasts", "dispatcherID", event.DispatcherID,
+		"from hash", event.FromHash,
+		"from block nonce", event.FromBlockNonce,
+		"since sequence", event.SinceSequence,
+		"num replayed", len(replayedEntries),
+	)
+
+	return nil
+}
+
+// getReplayEntries returns the buffered broadcasts starting with the requested block or, if
+// no block is requested, the ones done after the requested sequence number
+func (ch *commonHub) getReplayEntries(event data.SubscribeEvent) ([]*replayEntry, *data.ReplayUnavailable) {
+	if event.FromHash != "" || event.FromBlockNonce > 0 {
+		return ch.replayBuffer.entriesFrom(event.FromHash, event.FromBlockNonce)
+	}
+
+	return ch.replayBuffer.entriesSince(event.SinceSequence)
+}
+
+// GetSubscriptions returns the subscriptions stored for the dispatcher
+func (ch *commonHub) GetSubscriptions(dispatcherID uuid.UUID) []data.Subscription {
+	return ch.subscriptionMapper.DispatcherSubscriptions(dispatcherID)
+}
+
+func getDispatcherSubscriptions(subscriptions map[string][]data.Subscription, dispatcherID uuid.UUID) map[string][]data.Subscription {
+	dispatcherSubscriptions := make(map[string][]data.Subscription)
+	for eventType, subs := range subscriptions {
+		for _, sub := range subs {
+			if sub.DispatcherID == dispatcherID {
+				dispatcherSubscriptions[eventType] = append(dispatcherSubscriptions[eventType], sub)
+			}
+		}
+	}
+
+	return dispatcherSubscriptions
 }
 
 // RegisterEvent will send event to a receive-only channel used to register dispatchers
@@ -69,153 +262,786 @@ func (ch *commonHub) UnregisterEvent(event dispatcher.EventDispatcher) {
 }
 
 // Publish will publish logs and events to dispatcher
-func (ch *commonHub) Publish(blockEvents data.BlockEvents) {
-	subscriptions := ch.subscriptionMapper.Subscriptions()
+// An event matched by multiple subscriptions of the same dispatcher is delivered only once
+// The block events are saved to the event store after being delivered
+func (ch *commonHub) Publish(_ context.Context, blockEvents data.BlockEvents) error {
+	ch.incrementNumBroadcasts(common.PushLogsAndEvents)
 
-	for _, sub := range subscriptions[common.PushLogsAndEvents] {
-		ch.handlePushBlockEvents(blockEvents, sub)
+	span := ch.startBroadcastSpan(common.PushLogsAndEvents, blockEvents.Hash, blockEvents.SpanContext)
+	defer span.End()
+
+	subscriptions, reservations := ch.reserveDeliveries(&replayEntry{
+		eventType:   common.PushLogsAndEvents,
+		startsBlock: true,
+		hash:        blockEvents.Hash,
+		nonce:       blockEvents.Nonce,
+		sequence:    &blockEvents.Sequence,
+		replay: func(subscriptions []data.Subscription, d dispatcher.EventDispatcher) {
+			matchedEvents := ch.matchEvents(subscriptions, blockEvents.Events, nil)[d.GetID()]
+			d.PushEvents(getMatchedEvents(blockEvents.Events, matchedEvents))
+		},
+	})
+	// the replays are not counted, the events were already matched when first broadcast
+	var numMatches []uint64
+	if ch.matchStats.isEnabled() {
+		numMatches = make([]uint64, len(subscriptions))
+	}
+	matchedEventsMap := ch.matchEvents(subscriptions, blockEvents.Events, numMatches)
+	if numMatches != nil {
+		ch.matchStats.add(subscriptions, numMatches)
 	}
+
+	numDelivered := ch.deliver(reservations, func(id uuid.UUID, d dispatcher.EventDispatcher) {
+		d.PushEvents(getMatchedEvents(blockEvents.Events, matchedEventsMap[id]))
+	})
+
+	ch.addBroadcastMetric(numDelivered)
+	log.Debug("broadcast", "event", common.PushLogsAndEvents,
+		"block hash", blockEvents.Hash,
+		"num delivered", numDelivered,
+		"correlation id", blockEvents.CorrelationID,
+	)
+	span.SetAttributes(attribute.Int(numDeliveredAttribute, numDelivered))
+
+	err := ch.eventStore.Save(blockEvents)
+	if err != nil {
+		log.Warn("could not save block events", "block hash", blockEvents.Hash, "error", err)
+	}
+
+	return nil
 }
 
-func (ch *commonHub) handlePushBlockEvents(blockEvents data.BlockEvents, subscription data.Subscription) {
-	events := make([]data.Event, 0)
-	for _, event := range blockEvents.Events {
-		if ch.filter.MatchEvent(subscription, event) {
-			events = append(events, event)
+// matchEvents returns, for each dispatcher, the events matched by its subscriptions
+// Events are tracked by their position in the block, so duplicates are detected per
+// dispatcher without comparing the event contents
+// If numMatches is set, each matched event is counted for the first subscription of the
+// dispatcher which matched it, at the position of the subscription
+func (ch *commonHub) matchEvents(subscriptions []data.Subscription, events []data.Event, numMatches []uint64) map[uuid.UUID][]bool {
+	matchedEventsMap := make(map[uuid.UUID][]bool)
+	filter := ch.getFilter()
+
+	for subIndex, sub := range subscriptions {
+		matchedEvents, ok := matchedEventsMap[sub.DispatcherID]
+		if !ok {
+			matchedEvents = make([]bool, len(events))
+			matchedEventsMap[sub.DispatcherID] = matchedEvents
 		}
-	}
 
-	ch.mutDispatchers.RLock()
-	d, ok := ch.dispatchers[subscription.DispatcherID]
-	if ok {
-		d.PushEvents(events)
+		for index, event := range events {
+			if matchedEvents[index] {
+				continue
+			}
+
+			matchedEvents[index] = ch.matchEvent(filter, sub, event)
+			if matchedEvents[index] && numMatches != nil {
+				numMatches[subIndex]++
+			}
+		}
 	}
-	ch.mutDispatchers.RUnlock()
+
+	return matchedEventsMap
 }
 
-// PublishRevert will publish revert event to dispatcher
-func (ch *commonHub) PublishRevert(revertBlock data.RevertBlock) {
-	subscriptions := ch.subscriptionMapper.Subscriptions()
+func (ch *commonHub) matchEvent(filter dispatcher.EventFilter, subscription data.Subscription, event data.Event) bool {
+	if !check.IfNil(ch.blockNonceFilter) && !ch.blockNonceFilter.MatchEvent(subscription, event) {
+		return false
+	}
 
-	dispatchersMap := make(map[uuid.UUID]data.RevertBlock)
+	return filter.MatchEvent(subscription, event)
+}
 
-	for _, sub := range subscriptions[common.RevertBlockEvents] {
-		dispatchersMap[sub.DispatcherID] = revertBlock
+func (ch *commonHub) getFilter() dispatcher.EventFilter {
+	return ch.filter.Load().(filterHolder).filter
+}
+
+// UpdateFilter replaces the events filter. A broadcast in progress matches all its events
+// with the filter it started with
+func (ch *commonHub) UpdateFilter(filter dispatcher.EventFilter) error {
+	if check.IfNil(filter) {
+		return ErrNilEventFilter
 	}
 
-	ch.mutDispatchers.RLock()
-	defer ch.mutDispatchers.RUnlock()
-	for id, event := range dispatchersMap {
-		if d, ok := ch.dispatchers[id]; ok {
-			d.RevertEvent(event)
+	ch.filter.Store(filterHolder{filter: filter})
+	log.Info("updated hub events filter")
+
+	return nil
+}
+
+func getMatchedEvents(events []data.Event, matchedEvents []bool) []data.Event {
+	filteredEvents := make([]data.Event, 0)
+	for index, isMatched := range matchedEvents {
+		if isMatched {
+			filteredEvents = append(filteredEvents, events[index])
 		}
 	}
+
+	return filteredEvents
+}
+
+// PublishRevert will publish revert event to dispatcher
+func (ch *commonHub) PublishRevert(_ context.Context, revertBlock data.RevertBlock) error {
+	ch.incrementNumBroadcasts(common.RevertBlockEvents)
+
+	span := ch.startBroadcastSpan(common.RevertBlockEvents, revertBlock.Hash, revertBlock.SpanContext)
+	defer span.End()
+
+	_, reservations := ch.reserveDeliveries(&replayEntry{
+		eventType: common.RevertBlockEvents,
+		sequence:  &revertBlock.Sequence,
+		replay: func(_ []data.Subscription, d dispatcher.EventDispatcher) {
+			d.RevertEvent(revertBlock)
+		},
+	})
+
+	numDelivered := ch.deliver(reservations, func(_ uuid.UUID, d dispatcher.EventDispatcher) {
+		d.RevertEvent(revertBlock)
+	})
+
+	ch.addBroadcastMetric(numDelivered)
+	log.Debug("broadcast", "event", common.RevertBlockEvents,
+		"block hash", revertBlock.Hash,
+		"num delivered", numDelivered,
+		"correlation id", revertBlock.CorrelationID,
+	)
+	span.SetAttributes(attribute.Int(numDeliveredAttribute, numDelivered))
+
+	return nil
 }
 
 // PublishFinalized will publish finalized event to dispatcher
-func (ch *commonHub) PublishFinalized(finalizedBlock data.FinalizedBlock) {
-	subscriptions := ch.subscriptionMapper.Subscriptions()
+func (ch *commonHub) PublishFinalized(_ context.Context, finalizedBlock data.FinalizedBlock) error {
+	ch.incrementNumBroadcasts(common.FinalizedBlockEvents)
+
+	span := ch.startBroadcastSpan(common.FinalizedBlockEvents, finalizedBlock.Hash, finalizedBlock.SpanContext)
+	defer span.End()
+
+	_, reservations := ch.reserveDeliveries(&replayEntry{
+		eventType: common.FinalizedBlockEvents,
+		sequence:  &finalizedBlock.Sequence,
+		replay: func(_ []data.Subscription, d dispatcher.EventDispatcher) {
+			d.FinalizedEvent(finalizedBlock)
+		},
+	})
+
+	numDelivered := ch.deliver(reservations, func(_ uuid.UUID, d dispatcher.EventDispatcher) {
+		d.FinalizedEvent(finalizedBlock)
+	})
+
+	ch.addBroadcastMetric(numDelivered)
+	log.Debug("broadcast", "event", common.FinalizedBlockEvents,
+		"block hash", finalizedBlock.Hash,
+		"num delivered", numDelivered,
+		"correlation id", finalizedBlock.CorrelationID,
+	)
+	span.SetAttributes(attribute.Int(numDeliveredAttribute, numDelivered))
+
+	return nil
+}
+
+// PublishTxs will publish txs event to dispatcher
+func (ch *commonHub) PublishTxs(_ context.Context, blockTxs data.BlockTxs) error {
+	ch.incrementNumBroadcasts(common.BlockTxs)
+
+	_, reservations := ch.reserveDeliveries(&replayEntry{
+		eventType: common.BlockTxs,
+		replay: func(_ []data.Subscription, d dispatcher.EventDispatcher) {
+			d.TxsEvent(blockTxs)
+		},
+	})
+
+	numDelivered := ch.deliver(reservations, func(_ uuid.UUID, d dispatcher.EventDispatcher) {
+		d.TxsEvent(blockTxs)
+	})
+
+	ch.addBroadcastMetric(numDelivered)
+
+	return nil
+}
 
-	dispatchersMap := make(map[uuid.UUID]data.FinalizedBlock)
+// PublishBlockEventsWithOrder will publish block events with order to dispatcher
+func (ch *commonHub) PublishBlockEventsWithOrder(_ context.Context, blockTxs data.BlockEventsWithOrder) error {
+	ch.incrementNumBroadcasts(common.BlockEvents)
+
+	_, reservations := ch.reserveDeliveries(&replayEntry{
+		eventType: common.BlockEvents,
+		replay: func(_ []data.Subscription, d dispatcher.EventDispatcher) {
+			d.BlockEvents(blockTxs)
+		},
+	})
+
+	numDelivered := ch.deliver(reservations, func(_ uuid.UUID, d dispatcher.EventDispatcher) {
+		d.BlockEvents(blockTxs)
+	})
+
+	ch.addBroadcastMetric(numDelivered)
+
+	return nil
+}
+
+// PublishScrs will publish scrs events to dispatcher
+func (ch *commonHub) PublishScrs(_ context.Context, blockScrs data.BlockScrs) error {
+	ch.incrementNumBroadcasts(common.BlockScrs)
+
+	_, reservations := ch.reserveDeliveries(&replayEntry{
+		eventType: common.BlockScrs,
+		replay: func(_ []data.Subscription, d dispatcher.EventDispatcher) {
+			d.ScrsEvent(blockScrs)
+		},
+	})
+
+	numDelivered := ch.deliver(reservations, func(_ uuid.UUID, d dispatcher.EventDispatcher) {
+		d.ScrsEvent(blockScrs)
+	})
+
+	ch.addBroadcastMetric(numDelivered)
+
+	return nil
+}
+
+// PublishRounds will publish the rounds info to dispatchers
+func (ch *commonHub) PublishRounds(_ context.Context, roundEvents data.RoundEvents) error {
+	ch.incrementNumBroadcasts(common.RoundEvents)
+
+	_, reservations := ch.reserveDeliveries(&replayEntry{
+		eventType: common.RoundEvents,
+		replay: func(_ []data.Subscription, d dispatcher.EventDispatcher) {
+			d.RoundEvents(roundEvents)
+		},
+	})
+
+	numDelivered := ch.deliver(reservations, func(_ uuid.UUID, d dispatcher.EventDispatcher) {
+		d.RoundEvents(roundEvents)
+	})
 
-	for _, subscription := range subscriptions[common.FinalizedBlockEvents] {
-		dispatchersMap[subscription.DispatcherID] = finalizedBlock
+	ch.addBroadcastMetric(numDelivered)
+
+	return nil
+}
+
+// PublishValidatorsRating will publish the validators rating to dispatchers
+func (ch *commonHub) PublishValidatorsRating(_ context.Context, validatorsRating data.ValidatorsRatingEvent) error {
+	ch.incrementNumBroadcasts(common.ValidatorsRatingEvents)
+
+	_, reservations := ch.reserveDeliveries(&replayEntry{
+		eventType: common.ValidatorsRatingEvents,
+		replay: func(_ []data.Subscription, d dispatcher.EventDispatcher) {
+			d.ValidatorsRatingEvent(validatorsRating)
+		},
+	})
+
+	numDelivered := ch.deliver(reservations, func(_ uuid.UUID, d dispatcher.EventDispatcher) {
+		d.ValidatorsRatingEvent(validatorsRating)
+	})
+
+	ch.addBroadcastMetric(numDelivered)
+
+	return nil
+}
+
+// PublishTxEvents will publish transaction notifications to dispatchers
+// A subscription with an address matches the transactions having that address as sender or receiver
+func (ch *commonHub) PublishTxEvents(_ context.Context, blockTxEvents data.BlockTxEvents) error {
+	ch.incrementNumBroadcasts(common.TxEvents)
+
+	subscriptions, reservations := ch.reserveDeliveries(&replayEntry{
+		eventType: common.TxEvents,
+		replay: func(subscriptions []data.Subscription, d dispatcher.EventDispatcher) {
+			matchedTxEvents := matchTxEvents(subscriptions, blockTxEvents.TxEvents)[d.GetID()]
+			txEvents := getMatchedTxEvents(blockTxEvents.TxEvents, matchedTxEvents)
+			if len(txEvents) > 0 {
+				d.BlockTxEvents(filterBlockTxEvents(blockTxEvents, txEvents))
+			}
+		},
+	})
+	matchedTxEventsMap := matchTxEvents(subscriptions, blockTxEvents.TxEvents)
+
+	// the dispatchers without matched transactions only release their reservation
+	txEventsMap := make(map[uuid.UUID][]data.TxEvent)
+	for id, reservation := range reservations {
+		txEvents := getMatchedTxEvents(blockTxEvents.TxEvents, matchedTxEventsMap[id])
+		if len(txEvents) == 0 {
+			reservation.release()
+			delete(reservations, id)
+			continue
+		}
+
+		txEventsMap[id] = txEvents
 	}
 
-	ch.mutDispatchers.RLock()
-	defer ch.mutDispatchers.RUnlock()
-	for id, event := range dispatchersMap {
-		if d, ok := ch.dispatchers[id]; ok {
-			d.FinalizedEvent(event)
+	numDelivered := ch.deliver(reservations, func(id uuid.UUID, d dispatcher.EventDispatcher) {
+		d.BlockTxEvents(filterBlockTxEvents(blockTxEvents, txEventsMap[id]))
+	})
+
+	ch.addBroadcastMetric(numDelivered)
+
+	return nil
+}
+
+func filterBlockTxEvents(blockTxEvents data.BlockTxEvents, txEvents []data.TxEvent) data.BlockTxEvents {
+	return data.BlockTxEvents{
+		Hash:      blockTxEvents.Hash,
+		ShardID:   blockTxEvents.ShardID,
+		TimeStamp: blockTxEvents.TimeStamp,
+		TxEvents:  txEvents,
+	}
+}
+
+func matchTxEvents(subscriptions []data.Subscription, txEvents []data.TxEvent) map[uuid.UUID][]bool {
+	matchedTxEventsMap := make(map[uuid.UUID][]bool)
+
+	for _, sub := range subscriptions {
+		matchedTxEvents, ok := matchedTxEventsMap[sub.DispatcherID]
+		if !ok {
+			matchedTxEvents = make([]bool, len(txEvents))
+			matchedTxEventsMap[sub.DispatcherID] = matchedTxEvents
+		}
+
+		for index, txEvent := range txEvents {
+			if matchedTxEvents[index] {
+				continue
+			}
+
+			matchedTxEvents[index] = matchTxEvent(sub, txEvent)
 		}
 	}
+
+	return matchedTxEventsMap
 }
 
-// PublishTxs will publish txs event to dispatcher
-func (ch *commonHub) PublishTxs(blockTxs data.BlockTxs) {
-	subscriptions := ch.subscriptionMapper.Subscriptions()
+func matchTxEvent(sub data.Subscription, txEvent data.TxEvent) bool {
+	if sub.Address == "" {
+		return true
+	}
 
-	dispatchersMap := make(map[uuid.UUID]data.BlockTxs)
+	return sub.Address == txEvent.Sender || sub.Address == txEvent.Receiver
+}
 
-	for _, subscription := range subscriptions[common.BlockTxs] {
-		dispatchersMap[subscription.DispatcherID] = blockTxs
+func getMatchedTxEvents(txEvents []data.TxEvent, matchedTxEvents []bool) []data.TxEvent {
+	filteredTxEvents := make([]data.TxEvent, 0)
+	for index, isMatched := range matchedTxEvents {
+		if isMatched {
+			filteredTxEvents = append(filteredTxEvents, txEvents[index])
+		}
 	}
 
-	ch.mutDispatchers.RLock()
-	defer ch.mutDispatchers.RUnlock()
-	for id, event := range dispatchersMap {
-		if d, ok := ch.dispatchers[id]; ok {
-			d.TxsEvent(event)
+	return filteredTxEvents
+}
+
+// PublishAccounts will publish the altered accounts to dispatchers. A subscription with an
+// address matches the account with that address, or the accounts matching the pattern for
+// a glob address, and a subscription without an address matches all the accounts
+func (ch *commonHub) PublishAccounts(_ context.Context, accountsEvents data.AccountsEvents) error {
+	ch.incrementNumBroadcasts(common.AccountEvents)
+
+	subscriptions, reservations := ch.reserveDeliveries(&replayEntry{
+		eventType: common.AccountEvents,
+		replay: func(subscriptions []data.Subscription, d dispatcher.EventDispatcher) {
+			accounts := matchAccounts(subscriptions, accountsEvents.Accounts)[d.GetID()]
+			if len(accounts) > 0 {
+				d.AccountsEvents(filterAccountsEvents(accountsEvents, accounts))
+			}
+		},
+	})
+	matchedAccountsMap := matchAccounts(subscriptions, accountsEvents.Accounts)
+
+	// the dispatchers without matched accounts only release their reservation
+	for id, reservation := range reservations {
+		if len(matchedAccountsMap[id]) == 0 {
+			reservation.release()
+			delete(reservations, id)
 		}
 	}
+
+	numDelivered := ch.deliver(reservations, func(id uuid.UUID, d dispatcher.EventDispatcher) {
+		d.AccountsEvents(filterAccountsEvents(accountsEvents, matchedAccountsMap[id]))
+	})
+
+	ch.addBroadcastMetric(numDelivered)
+
+	return nil
 }
 
-// PublishBlockEventsWithOrder will publish block events with order to dispatcher
-func (ch *commonHub) PublishBlockEventsWithOrder(blockTxs data.BlockEventsWithOrder) {
-	subscriptions := ch.subscriptionMapper.Subscriptions()
+func filterAccountsEvents(accountsEvents data.AccountsEvents, accounts []data.AccountEvent) data.AccountsEvents {
+	return data.AccountsEvents{
+		ShardID:        accountsEvents.ShardID,
+		BlockTimestamp: accountsEvents.BlockTimestamp,
+		Accounts:       accounts,
+	}
+}
 
-	dispatchersMap := make(map[uuid.UUID]data.BlockEventsWithOrder)
+// matchAccounts returns, for each dispatcher, the accounts matched by its subscriptions, in
+// the block order. The subscriptions are indexed by address, so that each account is looked
+// up once instead of being compared with all the subscriptions; only the glob addresses are
+// matched against each account
+func matchAccounts(subscriptions []data.Subscription, accounts []data.AccountEvent) map[uuid.UUID][]data.AccountEvent {
+	dispatchersByAddress := make(map[string][]uuid.UUID)
+	allAccountsDispatchers := make([]uuid.UUID, 0)
+	globSubscriptions := make([]data.Subscription, 0)
+	for _, sub := range subscriptions {
+		switch {
+		case sub.Address == "":
+			allAccountsDispatchers = append(allAccountsDispatchers, sub.DispatcherID)
+		case sub.IsGlob:
+			globSubscriptions = append(globSubscriptions, sub)
+		default:
+			dispatchersByAddress[sub.Address] = append(dispatchersByAddress[sub.Address], sub.DispatcherID)
+		}
+	}
+
+	matchedAccountsMap := make(map[uuid.UUID][]data.AccountEvent)
+	// lastMatchedIndex holds, for each dispatcher, the index of its last matched account plus
+	// one, so that an account matched by more subscriptions of a dispatcher is added once
+	lastMatchedIndex := make(map[uuid.UUID]int)
+	addMatch := func(dispatcherID uuid.UUID, index int) {
+		if lastMatchedIndex[dispatcherID] == index+1 {
+			return
+		}
 
-	for _, subscription := range subscriptions[common.BlockEvents] {
-		dispatchersMap[subscription.DispatcherID] = blockTxs
+		lastMatchedIndex[dispatcherID] = index + 1
+		matchedAccountsMap[dispatcherID] = append(matchedAccountsMap[dispatcherID], accounts[index])
 	}
 
-	ch.mutDispatchers.RLock()
-	defer ch.mutDispatchers.RUnlock()
-	for id, event := range dispatchersMap {
-		if d, ok := ch.dispatchers[id]; ok {
-			d.BlockEvents(event)
+	for index, account := range accounts {
+		for _, dispatcherID := range allAccountsDispatchers {
+			addMatch(dispatcherID, index)
+		}
+		for _, dispatcherID := range dispatchersByAddress[account.Address] {
+			addMatch(dispatcherID, index)
+		}
+		for _, sub := range globSubscriptions {
+			// the patterns are validated on subscribe
+			matched, err := path.Match(sub.Address, account.Address)
+			if err == nil && matched {
+				addMatch(sub.DispatcherID, index)
+			}
 		}
 	}
+
+	return matchedAccountsMap
 }
 
-// PublishScrs will publish scrs events to dispatcher
-func (ch *commonHub) PublishScrs(blockScrs data.BlockScrs) {
-	subscriptions := ch.subscriptionMapper.Subscriptions()
+// DisconnectDispatcher will close the connection of the dispatcher with the provided id
+// and it will remove all its subscriptions
+func (ch *commonHub) DisconnectDispatcher(dispatcherID uuid.UUID) error {
+	ch.mutDispatchers.RLock()
+	d, ok := ch.dispatchers[dispatcherID]
+	ch.mutDispatchers.RUnlock()
+	if !ok {
+		return common.ErrDispatcherNotFound
+	}
 
-	dispatchersMap := make(map[uuid.UUID]data.BlockScrs)
+	err := d.Close()
+	if err != nil {
+		log.Warn("failed to close dispatcher connection", "dispatcherID", dispatcherID, "err", err.Error())
+	}
 
-	for _, subscription := range subscriptions[common.BlockScrs] {
-		dispatchersMap[subscription.DispatcherID] = blockScrs
+	ch.unregisterDispatcher(d)
+	if d.IsResumable() {
+		// the client asked to be disconnected, so the subscriptions are not kept for a reconnect
+		ch.subscriptionMapper.RemoveSubscriptions(dispatcherID)
 	}
 
+	return nil
+}
+
+func (ch *commonHub) isDispatcherActive(dispatcherID uuid.UUID) bool {
 	ch.mutDispatchers.RLock()
 	defer ch.mutDispatchers.RUnlock()
-	for id, event := range dispatchersMap {
-		if d, ok := ch.dispatchers[id]; ok {
-			d.ScrsEvent(event)
-		}
-	}
+
+	_, ok := ch.dispatchers[dispatcherID]
+	return ok
+}
+
+func (ch *commonHub) isDispatcherResumable(dispatcherID uuid.UUID) bool {
+	ch.mutDispatchers.RLock()
+	defer ch.mutDispatchers.RUnlock()
+
+	d, ok := ch.dispatchers[dispatcherID]
+	return ok && d.IsResumable()
 }
 
+// registerDispatcher adds the dispatcher to the hub. A resumable dispatcher replaces the one
+// registered with the same id, which is closed, since the client reconnected before the
+// previous connection was detected as broken
 func (ch *commonHub) registerDispatcher(d dispatcher.EventDispatcher) {
+	replaced := ch.addDispatcher(d)
+	if replaced == nil {
+		return
+	}
+
+	err := replaced.Close()
+	if err != nil {
+		log.Debug("failed to close replaced dispatcher", "dispatcherID", d.GetID(), "err", err.Error())
+	}
+}
+
+func (ch *commonHub) addDispatcher(d dispatcher.EventDispatcher) dispatcher.EventDispatcher {
 	ch.mutDispatchers.Lock()
 	defer ch.mutDispatchers.Unlock()
 
-	if _, ok := ch.dispatchers[d.GetID()]; ok {
-		return
+	registered, ok := ch.dispatchers[d.GetID()]
+	if ok && (registered == d || !d.IsResumable()) {
+		return nil
 	}
 
 	ch.dispatchers[d.GetID()] = d
+	ch.deliveryQueues[d.GetID()] = newOrderedDeliveryQueue()
+	ch.metricsCollector.SetActiveDispatchers(uint64(len(ch.dispatchers)))
+
+	log.Info("registered new dispatcher", "dispatcherID", d.GetID(), "resumable", d.IsResumable(), "replaced", ok)
 
-	log.Info("registered new dispatcher", "dispatcherID", d.GetID())
+	return registered
 }
 
+// unregisterDispatcher removes the dispatcher and its subscriptions, unless it is resumable.
+// A dispatcher replaced in the meantime by a reconnected one does not unregister the latter
 func (ch *commonHub) unregisterDispatcher(d dispatcher.EventDispatcher) {
 	ch.mutDispatchers.Lock()
 	defer ch.mutDispatchers.Unlock()
 
-	if _, ok := ch.dispatchers[d.GetID()]; ok {
+	registered, ok := ch.dispatchers[d.GetID()]
+	if ok && registered != d {
+		return
+	}
+	if ok {
 		delete(ch.dispatchers, d.GetID())
+		delete(ch.deliveryQueues, d.GetID())
 	}
+	ch.metricsCollector.SetActiveDispatchers(uint64(len(ch.dispatchers)))
 
-	log.Info("unregistered dispatcher", "dispatcherID", d.GetID(), "unsubscribing", true)
+	log.Info("unregistered dispatcher", "dispatcherID", d.GetID(), "unsubscribing", !d.IsResumable())
 
-	ch.subscriptionMapper.RemoveSubscriptions(d.GetID())
+	if !d.IsResumable() {
+		ch.subscriptionMapper.RemoveSubscriptions(d.GetID())
+	}
 }
 
-// Close will close the goroutine and channels
+// deliveryReservation holds the sequence number reserved for a broadcast in the
+// delivery queue of a dispatcher
+type deliveryReservation struct {
+	queue    *orderedDeliveryQueue
+	sequence uint64
+}
+
+func (dr *deliveryReservation) release() {
+	dr.queue.deliver(dr.sequence, nil)
+}
+
+// reserveDeliveries reserves a sequence number in the delivery queue of each registered
+// dispatcher with a subscription to the broadcast, and returns the subscriptions to the
+// broadcast event type. The reservations of a broadcast are done at once, so that each
+// dispatcher receives the broadcasts in the order in which the hub received them, even
+// if they are delivered concurrently. The broadcast is also kept by the replay buffer
+// The sequence number of the broadcast is assigned with the reservations, so that the
+// sequences are strictly increasing in the order in which the broadcasts are delivered
+func (ch *commonHub) reserveDeliveries(entry *replayEntry) ([]data.Subscription, map[uuid.UUID]*deliveryReservation) {
+	ch.mutDispatchers.RLock()
+	defer ch.mutDispatchers.RUnlock()
+
+	ch.mutReserve.Lock()
+	defer ch.mutReserve.Unlock()
+
+	if entry.sequence != nil {
+		*entry.sequence = atomic.AddUint64(&ch.sequence, 1)
+	}
+	entry.lastSequence = atomic.LoadUint64(&ch.sequence)
+	ch.replayBuffer.add(entry)
+
+	subscriptions := ch.subscriptionMapper.Subscriptions()[entry.eventType]
+
+	reservations := make(map[uuid.UUID]*deliveryReservation)
+	for _, sub := range subscriptions {
+		if _, ok := reservations[sub.DispatcherID]; ok {
+			continue
+		}
+
+		queue, ok := ch.deliveryQueues[sub.DispatcherID]
+		if !ok {
+			continue
+		}
+
+		reservations[sub.DispatcherID] = &deliveryReservation{
+			queue:    queue,
+			sequence: queue.reserve(),
+		}
+	}
+
+	return subscriptions, reservations
+}
+
+// deliver delivers the broadcast through the delivery queues of the dispatchers with a
+// reservation, and returns the number of dispatchers the broadcast was delivered to
+func (ch *commonHub) deliver(
+	reservations map[uuid.UUID]*deliveryReservation,
+	delivery func(id uuid.UUID, d dispatcher.EventDispatcher),
+) int {
+	ch.mutDispatchers.RLock()
+	defer ch.mutDispatchers.RUnlock()
+
+	numDelivered := 0
+	for id, reservation := range reservations {
+		d, ok := ch.dispatchers[id]
+		if !ok || ch.deliveryQueues[id] != reservation.queue {
+			// the dispatcher was unregistered in the meantime
+			continue
+		}
+
+		dispatcherID := id
+		reservation.queue.deliver(reservation.sequence, func() {
+			delivery(dispatcherID, d)
+		})
+		numDelivered++
+	}
+
+	return numDelivered
+}
+
+// startBroadcastSpan starts a span for the broadcast, as a child of the span which
+// processed the received payload, if any
+func (ch *commonHub) startBroadcastSpan(eventType string, blockHash string, parent trace.SpanContext) trace.Span {
+	ctx := trace.ContextWithSpanContext(context.Background(), parent)
+	_, span := ch.tracer.Start(ctx, broadcastSpanName,
+		trace.WithAttributes(
+			attribute.String(eventTypeAttribute, eventType),
+			attribute.String(blockHashAttribute, blockHash),
+		),
+	)
+
+	return span
+}
+
+func (ch *commonHub) incrementNumBroadcasts(eventType string) {
+	ch.mutMetrics.Lock()
+	ch.numBroadcasts[eventType]++
+	ch.mutMetrics.Unlock()
+}
+
+func (ch *commonHub) addBroadcastMetric(numDelivered int) {
+	if numDelivered == 0 {
+		ch.metricsCollector.AddBroadcast(metrics.BroadcastStatusNoSubscribers)
+		return
+	}
+
+	ch.metricsCollector.AddBroadcast(metrics.BroadcastStatusDelivered)
+}
+
+// GetMetricsForPrometheus returns the number of connected dispatchers and the number
+// of broadcasts for each event type, in prometheus format
+func (ch *commonHub) GetMetricsForPrometheus() string {
+	ch.mutDispatchers.RLock()
+	numDispatchers := len(ch.dispatchers)
+	ch.mutDispatchers.RUnlock()
+
+	stringBuilder := strings.Builder{}
+	stringBuilder.WriteString(metrics.GaugeMetric(numDispatchersPromMetric, uint64(numDispatchers)))
+
+	ch.mutMetrics.RLock()
+	stringBuilder.WriteString(metrics.CounterMetrics(numBroadcastsPromMetric, eventTypePromLabel, ch.numBroadcasts))
+	ch.mutMetrics.RUnlock()
+
+	return stringBuilder.String()
+}
+
+// GetSubscriptionStats returns the number of connected dispatchers and subscriptions, together
+// with the number of events matched by each logs and events subscription within the stats
+// window, sorted by the number of matches
+func (ch *commonHub) GetSubscriptionStats() data.SubscriptionStatsResponse {
+	ch.mutDispatchers.RLock()
+	numDispatchers := len(ch.dispatchers)
+	ch.mutDispatchers.RUnlock()
+
+	subscriptionsMap := ch.subscriptionMapper.Subscriptions()
+	numSubscriptions := 0
+	for _, subs := range subscriptionsMap {
+		numSubscriptions += len(subs)
+	}
+
+	numMatches := make(map[uint64]uint64)
+	if ch.matchStats.isEnabled() {
+		numMatches = ch.matchStats.getNumMatches()
+	}
+
+	subscriptions := subscriptionsMap[common.PushLogsAndEvents]
+	stats := make([]data.SubscriptionMatchStats, 0, len(subscriptions))
+	for _, sub := range subscriptions {
+		stats = append(stats, data.SubscriptionMatchStats{
+			ID:           sub.ID,
+			DispatcherID: sub.DispatcherID,
+			Address:      sub.Address,
+			Identifier:   sub.Identifier,
+			MatchLevel:   sub.MatchLevel,
+			NumMatches:   numMatches[sub.ID],
+		})
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].NumMatches != stats[j].NumMatches {
+			return stats[i].NumMatches > stats[j].NumMatches
+		}
+		return stats[i].ID < stats[j].ID
+	})
+
+	return data.SubscriptionStatsResponse{
+		NumDispatchers:   numDispatchers,
+		NumSubscriptions: numSubscriptions,
+		WindowInSec:      uint64(ch.matchStats.window / time.Second),
+		Subscriptions:    stats,
+	}
+}
+
+// MatchEvent returns the logs and events subscriptions which match the provided event with
+// the current filter, sorted by id, together with the ids of their dispatchers. Nothing is
+// delivered and the match stats are not updated
+func (ch *commonHub) MatchEvent(event data.Event) data.EventMatchResponse {
+	filter := ch.getFilter()
+	subscriptions := ch.subscriptionMapper.Subscriptions()[common.PushLogsAndEvents]
+
+	matched := make([]data.Subscription, 0)
+	for _, sub := range subscriptions {
+		if ch.matchEvent(filter, sub, event) {
+			matched = append(matched, sub)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		return matched[i].ID < matched[j].ID
+	})
+
+	dispatcherIDs := make([]uuid.UUID, 0)
+	seen := make(map[uuid.UUID]struct{})
+	for _, sub := range matched {
+		_, ok := seen[sub.DispatcherID]
+		if ok {
+			continue
+		}
+		seen[sub.DispatcherID] = struct{}{}
+		dispatcherIDs = append(dispatcherIDs, sub.DispatcherID)
+	}
+
+	return data.EventMatchResponse{
+		DispatcherIDs: dispatcherIDs,
+		Subscriptions: matched,
+	}
+}
+
+// GetHealthState returns not applicable, since the hub delivers events directly to
+// the connected dispatchers, without relying on a message broker
+func (ch *commonHub) GetHealthState() string {
+	return common.HealthStateNotApplicable
+}
+
+// Ping returns nil, since the websocket dispatchers are connected directly to the hub
+func (ch *commonHub) Ping(_ context.Context) error {
+	return nil
+}
+
+// Close will stop the subscriptions cleanup
 func (ch *commonHub) Close() error {
+	ch.mutState.Lock()
+	defer ch.mutState.Unlock()
+
+	if ch.cancelFunc != nil {
+		ch.cancelFunc()
+	}
+
 	return nil
 }
 
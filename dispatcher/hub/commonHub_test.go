@@ -1,23 +1,41 @@
 package hub
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
+	logger "github.com/multiversx/mx-chain-logger-go"
 	"github.com/multiversx/mx-chain-notifier-go/common"
 	"github.com/multiversx/mx-chain-notifier-go/data"
 	"github.com/multiversx/mx-chain-notifier-go/dispatcher"
 	"github.com/multiversx/mx-chain-notifier-go/filters"
+	"github.com/multiversx/mx-chain-notifier-go/metrics"
 	"github.com/multiversx/mx-chain-notifier-go/mocks"
+	"github.com/multiversx/mx-chain-notifier-go/storage"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func createMockCommonHubArgs() ArgsCommonHub {
+	subscriptionMapper, _ := dispatcher.NewSubscriptionMapper(dispatcher.ArgsSubscriptionMapper{})
+
 	return ArgsCommonHub{
 		Filter:             filters.NewDefaultFilter(),
-		SubscriptionMapper: dispatcher.NewSubscriptionMapper(),
+		SubscriptionMapper: subscriptionMapper,
+		MetricsCollector:   &mocks.MetricsCollectorStub{},
+		EventStore:         &mocks.EventStoreStub{},
 	}
 }
 
@@ -46,6 +64,28 @@ func TestNewCommonHub(t *testing.T) {
 		assert.Equal(t, ErrNilSubscriptionMapper, err)
 	})
 
+	t.Run("nil metrics collector", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockCommonHubArgs()
+		args.MetricsCollector = nil
+
+		hub, err := NewCommonHub(args)
+		require.Nil(t, hub)
+		assert.Equal(t, common.ErrNilMetricsCollector, err)
+	})
+
+	t.Run("nil event store", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockCommonHubArgs()
+		args.EventStore = nil
+
+		hub, err := NewCommonHub(args)
+		require.Nil(t, hub)
+		assert.Equal(t, common.ErrNilEventStore, err)
+	})
+
 	t.Run("should work", func(t *testing.T) {
 		t.Parallel()
 
@@ -56,6 +96,50 @@ func TestNewCommonHub(t *testing.T) {
 	})
 }
 
+func TestCommonHub_Run(t *testing.T) {
+	t.Parallel()
+
+	t.Run("run twice should error", func(t *testing.T) {
+		t.Parallel()
+
+		hub, err := NewCommonHub(createMockCommonHubArgs())
+		require.Nil(t, err)
+
+		require.Nil(t, hub.Run())
+		require.Equal(t, common.ErrLoopAlreadyStarted, hub.Run())
+		require.Nil(t, hub.Close())
+	})
+
+	t.Run("should remove the expired subscriptions of unregistered dispatchers", func(t *testing.T) {
+		t.Parallel()
+
+		subscriptionTTL := time.Millisecond * 50
+		args := createMockCommonHubArgs()
+		args.SubscriptionMapper, _ = dispatcher.NewSubscriptionMapper(dispatcher.ArgsSubscriptionMapper{
+			SubscriptionTTL: subscriptionTTL,
+		})
+		hub, err := NewCommonHub(args)
+		require.Nil(t, err)
+
+		consumer := mocks.NewConsumerMock()
+		registeredDispatcher := mocks.NewDispatcherMock(consumer, hub)
+		hub.RegisterEvent(registeredDispatcher)
+		_ = hub.Subscribe(data.SubscribeEvent{DispatcherID: registeredDispatcher.GetID()})
+		_ = hub.Subscribe(data.SubscribeEvent{DispatcherID: uuid.New()})
+
+		require.Nil(t, hub.Run())
+		defer func() {
+			_ = hub.Close()
+		}()
+
+		time.Sleep(subscriptionTTL * 4)
+
+		subscriptions := args.SubscriptionMapper.Subscriptions()[common.PushLogsAndEvents]
+		require.Equal(t, 1, len(subscriptions))
+		require.Equal(t, registeredDispatcher.GetID(), subscriptions[0].DispatcherID)
+	})
+}
+
 func TestCommonHub_RegisterDispatcher(t *testing.T) {
 	t.Parallel()
 
@@ -96,6 +180,202 @@ func TestCommonHub_UnregisterDispatcher(t *testing.T) {
 	require.True(t, hub.CheckDispatcherByID(dispatcher2.GetID(), dispatcher2))
 }
 
+func TestCommonHub_DisconnectDispatcher(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unknown dispatcher", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockCommonHubArgs()
+		hub, err := NewCommonHub(args)
+		require.Nil(t, err)
+
+		err = hub.DisconnectDispatcher(uuid.New())
+		require.Equal(t, common.ErrDispatcherNotFound, err)
+	})
+
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockCommonHubArgs()
+		hub, err := NewCommonHub(args)
+		require.Nil(t, err)
+
+		dispatcherID := uuid.New()
+		wasClosed := false
+		dispatcher1 := &mocks.DispatcherStub{
+			GetIDCalled: func() uuid.UUID {
+				return dispatcherID
+			},
+			CloseCalled: func() error {
+				wasClosed = true
+				return nil
+			},
+		}
+
+		hub.RegisterEvent(dispatcher1)
+		hub.Subscribe(data.SubscribeEvent{
+			DispatcherID: dispatcherID,
+		})
+
+		err = hub.DisconnectDispatcher(dispatcherID)
+		require.Nil(t, err)
+
+		require.True(t, wasClosed)
+		require.True(t, hub.CheckDispatcherByID(dispatcherID, nil))
+		require.Equal(t, 0, len(hub.subscriptionMapper.Subscriptions()[common.PushLogsAndEvents]))
+	})
+}
+
+func createResumableDispatcherStub(id uuid.UUID, receivedEvents chan []data.Event) *mocks.DispatcherStub {
+	return &mocks.DispatcherStub{
+		GetIDCalled: func() uuid.UUID {
+			return id
+		},
+		IsResumableCalled: func() bool {
+			return true
+		},
+		PushEventsCalled: func(events []data.Event) {
+			receivedEvents <- events
+		},
+	}
+}
+
+func requireReceivedEvents(t *testing.T, receivedEvents chan []data.Event, expectedEvents []data.Event) {
+	select {
+	case events := <-receivedEvents:
+		require.Equal(t, expectedEvents, events)
+	case <-time.After(time.Second):
+		require.Fail(t, "events were not received")
+	}
+}
+
+func TestCommonHub_ResumableDispatcher(t *testing.T) {
+	t.Parallel()
+
+	blockEvents := getEvents()
+
+	t.Run("unregistered dispatcher should keep its subscriptions", func(t *testing.T) {
+		t.Parallel()
+
+		hub, err := NewCommonHub(createMockCommonHubArgs())
+		require.Nil(t, err)
+
+		dispatcherID := uuid.New()
+		dispatcher1 := createResumableDispatcherStub(dispatcherID, make(chan []data.Event, 1))
+		hub.RegisterEvent(dispatcher1)
+		err = hub.Subscribe(data.SubscribeEvent{DispatcherID: dispatcherID})
+		require.Nil(t, err)
+
+		hub.UnregisterEvent(dispatcher1)
+		require.True(t, hub.CheckDispatcherByID(dispatcherID, nil))
+		require.Equal(t, 1, len(hub.GetSubscriptions(dispatcherID)))
+
+		receivedEvents := make(chan []data.Event, 1)
+		hub.RegisterEvent(createResumableDispatcherStub(dispatcherID, receivedEvents))
+		_ = hub.Publish(context.Background(), blockEvents)
+		requireReceivedEvents(t, receivedEvents, blockEvents.Events)
+	})
+
+	t.Run("reconnected dispatcher should replace the registered one", func(t *testing.T) {
+		t.Parallel()
+
+		hub, err := NewCommonHub(createMockCommonHubArgs())
+		require.Nil(t, err)
+
+		dispatcherID := uuid.New()
+		wasClosed := false
+		dispatcher1 := createResumableDispatcherStub(dispatcherID, make(chan []data.Event, 1))
+		dispatcher1.CloseCalled = func() error {
+			wasClosed = true
+			return nil
+		}
+		hub.RegisterEvent(dispatcher1)
+		err = hub.Subscribe(data.SubscribeEvent{DispatcherID: dispatcherID})
+		require.Nil(t, err)
+
+		receivedEvents := make(chan []data.Event, 1)
+		dispatcher2 := createResumableDispatcherStub(dispatcherID, receivedEvents)
+		hub.RegisterEvent(dispatcher2)
+		require.True(t, wasClosed)
+		require.True(t, hub.CheckDispatcherByID(dispatcherID, dispatcher2))
+
+		// the previous connection unregisters once closed, which should not affect the new one
+		hub.UnregisterEvent(dispatcher1)
+		require.True(t, hub.CheckDispatcherByID(dispatcherID, dispatcher2))
+
+		_ = hub.Publish(context.Background(), blockEvents)
+		requireReceivedEvents(t, receivedEvents, blockEvents.Events)
+	})
+
+	t.Run("disconnect should remove the subscriptions", func(t *testing.T) {
+		t.Parallel()
+
+		hub, err := NewCommonHub(createMockCommonHubArgs())
+		require.Nil(t, err)
+
+		dispatcherID := uuid.New()
+		hub.RegisterEvent(createResumableDispatcherStub(dispatcherID, make(chan []data.Event, 1)))
+		err = hub.Subscribe(data.SubscribeEvent{DispatcherID: dispatcherID})
+		require.Nil(t, err)
+
+		err = hub.DisconnectDispatcher(dispatcherID)
+		require.Nil(t, err)
+		require.Equal(t, 0, len(hub.GetSubscriptions(dispatcherID)))
+	})
+
+	t.Run("dispatcher reconnecting after a restart should receive the events", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "subscriptions")
+		createPersistentHub := func() (*commonHub, dispatcher.SubscriptionsStore) {
+			store, errCreate := storage.NewSubscriptionsStore(storage.ArgsSubscriptionsStore{Path: path})
+			require.Nil(t, errCreate)
+			subscriptionMapper, errCreate := dispatcher.NewPersistentSubscriptionMapper(dispatcher.ArgsSubscriptionMapper{}, store)
+			require.Nil(t, errCreate)
+
+			args := createMockCommonHubArgs()
+			args.SubscriptionMapper = subscriptionMapper
+			hub, errCreate := NewCommonHub(args)
+			require.Nil(t, errCreate)
+
+			return hub, store
+		}
+
+		hub, store := createPersistentHub()
+		resumableID := uuid.New()
+		dispatcher1 := createResumableDispatcherStub(resumableID, make(chan []data.Event, 1))
+		hub.RegisterEvent(dispatcher1)
+		err := hub.Subscribe(data.SubscribeEvent{
+			DispatcherID:        resumableID,
+			SubscriptionEntries: []data.SubscriptionEntry{{Address: "erd2"}},
+		})
+		require.Nil(t, err)
+
+		dispatcher2 := mocks.NewDispatcherMock(mocks.NewConsumerMock(), hub)
+		hub.RegisterEvent(dispatcher2)
+		err = hub.Subscribe(data.SubscribeEvent{DispatcherID: dispatcher2.GetID()})
+		require.Nil(t, err)
+
+		// the connections are closed on shutdown
+		hub.UnregisterEvent(dispatcher1)
+		hub.UnregisterEvent(dispatcher2)
+		require.Nil(t, hub.Close())
+		require.Nil(t, store.Close())
+
+		hub, store = createPersistentHub()
+		defer func() {
+			_ = store.Close()
+		}()
+		require.Equal(t, 0, len(hub.GetSubscriptions(dispatcher2.GetID())))
+
+		receivedEvents := make(chan []data.Event, 1)
+		hub.RegisterEvent(createResumableDispatcherStub(resumableID, receivedEvents))
+		_ = hub.Publish(context.Background(), blockEvents)
+		requireReceivedEvents(t, receivedEvents, blockEvents.Events[1:2])
+	})
+}
+
 func TestCommonHub_HandleBroadcastDispatcherReceivesEvents(t *testing.T) {
 	t.Parallel()
 
@@ -114,7 +394,7 @@ func TestCommonHub_HandleBroadcastDispatcherReceivesEvents(t *testing.T) {
 
 	blockEvents := getEvents()
 
-	hub.Publish(blockEvents)
+	hub.Publish(context.Background(), blockEvents)
 
 	time.Sleep(time.Millisecond * 100)
 
@@ -122,6 +402,107 @@ func TestCommonHub_HandleBroadcastDispatcherReceivesEvents(t *testing.T) {
 	require.True(t, len(consumer.CollectedEvents()) == len(blockEvents.Events))
 }
 
+func TestCommonHub_PublishShouldSaveBlockEvents(t *testing.T) {
+	t.Parallel()
+
+	var savedEvents []data.BlockEvents
+	args := createMockCommonHubArgs()
+	args.EventStore = &mocks.EventStoreStub{
+		SaveCalled: func(events data.BlockEvents) error {
+			savedEvents = append(savedEvents, events)
+			return errors.New("expected error")
+		},
+	}
+	hub, err := NewCommonHub(args)
+	require.Nil(t, err)
+
+	blockEvents := getEvents()
+	hub.Publish(context.Background(), blockEvents)
+	hub.PublishRevert(context.Background(), data.RevertBlock{Hash: blockEvents.Hash})
+
+	blockEvents.Sequence = 1
+	require.Equal(t, []data.BlockEvents{blockEvents}, savedEvents)
+}
+
+func TestCommonHub_BroadcastSequence(t *testing.T) {
+	t.Parallel()
+
+	mutSequences := sync.Mutex{}
+	savedSequences := make([]uint64, 0)
+	args := createMockCommonHubArgs()
+	args.EventStore = &mocks.EventStoreStub{
+		SaveCalled: func(events data.BlockEvents) error {
+			mutSequences.Lock()
+			savedSequences = append(savedSequences, events.Sequence)
+			mutSequences.Unlock()
+			return nil
+		},
+	}
+	hub, err := NewCommonHub(args)
+	require.Nil(t, err)
+
+	dispatcherID := uuid.New()
+	deliveredSequences := make([]uint64, 0)
+	hub.RegisterEvent(&mocks.DispatcherStub{
+		GetIDCalled: func() uuid.UUID {
+			return dispatcherID
+		},
+		RevertEventCalled: func(event data.RevertBlock) {
+			mutSequences.Lock()
+			deliveredSequences = append(deliveredSequences, event.Sequence)
+			mutSequences.Unlock()
+		},
+		FinalizedEventCalled: func(event data.FinalizedBlock) {
+			mutSequences.Lock()
+			deliveredSequences = append(deliveredSequences, event.Sequence)
+			mutSequences.Unlock()
+		},
+	})
+	err = hub.Subscribe(data.SubscribeEvent{
+		DispatcherID: dispatcherID,
+		SubscriptionEntries: []data.SubscriptionEntry{
+			{EventType: common.RevertBlockEvents},
+			{EventType: common.FinalizedBlockEvents},
+		},
+	})
+	require.Nil(t, err)
+
+	numBroadcasts := 100
+	wg := sync.WaitGroup{}
+	wg.Add(3 * numBroadcasts)
+	for i := 0; i < numBroadcasts; i++ {
+		go func() {
+			hub.Publish(context.Background(), data.BlockEvents{Hash: "hash"})
+			wg.Done()
+		}()
+		go func() {
+			hub.PublishRevert(context.Background(), data.RevertBlock{Hash: "hash"})
+			wg.Done()
+		}()
+		go func() {
+			hub.PublishFinalized(context.Background(), data.FinalizedBlock{Hash: "hash"})
+			wg.Done()
+		}()
+	}
+	wg.Wait()
+
+	mutSequences.Lock()
+	defer mutSequences.Unlock()
+
+	require.Equal(t, 2*numBroadcasts, len(deliveredSequences))
+	for i := 1; i < len(deliveredSequences); i++ {
+		require.Greater(t, deliveredSequences[i], deliveredSequences[i-1])
+	}
+
+	allSequences := append(savedSequences, deliveredSequences...)
+	sort.Slice(allSequences, func(i, j int) bool {
+		return allSequences[i] < allSequences[j]
+	})
+	for i, sequence := range allSequences {
+		require.Equal(t, uint64(i+1), sequence)
+	}
+}
+
 func TestCommonHub_HandleBroadcastMultipleDispatchers(t *testing.T) {
 	t.Parallel()
 
@@ -172,7 +553,7 @@ func TestCommonHub_HandleBroadcastMultipleDispatchers(t *testing.T) {
 
 	blockEvents := getEvents()
 
-	hub.Publish(blockEvents)
+	hub.Publish(context.Background(), blockEvents)
 
 	time.Sleep(time.Millisecond * 100)
 
@@ -181,116 +562,342 @@ func TestCommonHub_HandleBroadcastMultipleDispatchers(t *testing.T) {
 	require.True(t, consumer3.HasEvent(blockEvents.Events[2]))
 }
 
-func TestCommonHub_HandleRevertBroadcast(t *testing.T) {
+func TestCommonHub_HandleBroadcastOverlappingSubscriptions(t *testing.T) {
 	t.Parallel()
 
 	args := createMockCommonHubArgs()
 	hub, err := NewCommonHub(args)
 	require.Nil(t, err)
 
+	consumer := mocks.NewConsumerMock()
+	dispatcher1 := mocks.NewDispatcherMock(consumer, hub)
+
 	numCalls := uint32(0)
-	hub.registerDispatcher(&mocks.DispatcherStub{
-		RevertEventCalled: func(event data.RevertBlock) {
+	dispatcher2 := &mocks.DispatcherStub{
+		GetIDCalled: func() uuid.UUID {
+			return dispatcher1.GetID()
+		},
+		PushEventsCalled: func(events []data.Event) {
 			atomic.AddUint32(&numCalls, 1)
+			consumer.Receive(events)
 		},
-	})
+	}
 
+	hub.RegisterEvent(dispatcher2)
 	hub.Subscribe(data.SubscribeEvent{
+		DispatcherID: dispatcher1.GetID(),
 		SubscriptionEntries: []data.SubscriptionEntry{
 			{
-				EventType: common.RevertBlockEvents,
+				Address: "erd1",
+			},
+			{
+				Identifier: "swap",
+			},
+			{
+				Address:    "erd1",
+				Identifier: "swap",
+			},
+			{
+				Identifier: "lock",
 			},
 		},
 	})
 
-	blockEvents := data.RevertBlock{
-		Hash:  "hash1",
-		Nonce: 1,
-	}
+	blockEvents := getEvents()
 
-	hub.PublishRevert(blockEvents)
+	hub.Publish(context.Background(), blockEvents)
 
-	time.Sleep(time.Millisecond * 100)
+	require.Equal(t, uint32(1), atomic.LoadUint32(&numCalls))
+	require.Equal(t, []data.Event{blockEvents.Events[0], blockEvents.Events[1]}, consumer.CollectedEvents())
 
-	assert.Equal(t, uint32(1), atomic.LoadUint32(&numCalls))
+	hub.Publish(context.Background(), blockEvents)
+
+	require.Equal(t, uint32(2), atomic.LoadUint32(&numCalls))
+	require.Equal(t, 4, len(consumer.CollectedEvents()))
 }
 
-func TestCommonHub_HandleFinalizedBroadcast(t *testing.T) {
+func TestCommonHub_BlockNonceFilterShouldRestrictTheDeliveredEvents(t *testing.T) {
 	t.Parallel()
 
+	blockNonceFilter, err := filters.NewBlockNonceRangeFilter(10, 20)
+	require.Nil(t, err)
+
 	args := createMockCommonHubArgs()
+	args.BlockNonceFilter = blockNonceFilter
 	hub, err := NewCommonHub(args)
 	require.Nil(t, err)
 
-	numCalls := uint32(0)
-	hub.registerDispatcher(&mocks.DispatcherStub{
-		FinalizedEventCalled: func(event data.FinalizedBlock) {
-			atomic.AddUint32(&numCalls, 1)
-		},
+	consumer := mocks.NewConsumerMock()
+	dispatcher1 := mocks.NewDispatcherMock(consumer, hub)
+
+	hub.RegisterEvent(dispatcher1)
+	err = hub.Subscribe(data.SubscribeEvent{
+		DispatcherID:        dispatcher1.GetID(),
+		SubscriptionEntries: []data.SubscriptionEntry{{Address: "erd1"}},
 	})
+	require.Nil(t, err)
 
-	hub.Subscribe(data.SubscribeEvent{
-		SubscriptionEntries: []data.SubscriptionEntry{
-			{
-				EventType: common.FinalizedBlockEvents,
-			},
-		},
+	inRangeEvent := data.Event{Address: "erd1", Identifier: "swap", BlockNonce: 20}
+	outOfRangeEvent := data.Event{Address: "erd1", Identifier: "lock", BlockNonce: 21}
+	otherAddressEvent := data.Event{Address: "erd2", Identifier: "swap", BlockNonce: 10}
+
+	hub.Publish(context.Background(), data.BlockEvents{
+		Hash:   "hash1",
+		Events: []data.Event{inRangeEvent, outOfRangeEvent, otherAddressEvent},
 	})
 
-	blockEvents := data.FinalizedBlock{
-		Hash: "hash1",
-	}
+	require.Equal(t, []data.Event{inRangeEvent}, consumer.CollectedEvents())
+}
 
-	hub.PublishFinalized(blockEvents)
+func TestCommonHub_UpdateFilter(t *testing.T) {
+	t.Parallel()
 
-	time.Sleep(time.Millisecond * 100)
+	t.Run("nil filter should error", func(t *testing.T) {
+		t.Parallel()
 
-	assert.Equal(t, uint32(1), atomic.LoadUint32(&numCalls))
+		hub, _ := NewCommonHub(createMockCommonHubArgs())
+
+		err := hub.UpdateFilter(nil)
+		require.Equal(t, ErrNilEventFilter, err)
+	})
+
+	t.Run("events should be matched by the filter set at broadcast", func(t *testing.T) {
+		t.Parallel()
+
+		hub, err := NewCommonHub(createMockCommonHubArgs())
+		require.Nil(t, err)
+
+		consumer := mocks.NewConsumerMock()
+		dispatcher1 := mocks.NewDispatcherMock(consumer, hub)
+
+		hub.RegisterEvent(dispatcher1)
+		err = hub.Subscribe(data.SubscribeEvent{
+			DispatcherID:        dispatcher1.GetID(),
+			SubscriptionEntries: []data.SubscriptionEntry{},
+		})
+		require.Nil(t, err)
+
+		swapEvent := data.Event{Address: "erd1", Identifier: "swap"}
+		lockEvent := data.Event{Address: "erd1", Identifier: "lock"}
+		otherAddressEvent := data.Event{Address: "erd2", Identifier: "swap"}
+		events := []data.Event{swapEvent, lockEvent, otherAddressEvent}
+
+		hub.Publish(context.Background(), data.BlockEvents{Hash: "hash1", Events: events})
+		require.Equal(t, events, consumer.CollectedEvents())
+
+		filter, err := filters.NewConfigFilter(filters.FilterConfig{
+			Addresses:   []string{"erd1"},
+			Identifiers: []string{"swap"},
+		})
+		require.Nil(t, err)
+		err = hub.UpdateFilter(filter)
+		require.Nil(t, err)
+
+		hub.Publish(context.Background(), data.BlockEvents{Hash: "hash2", Events: events})
+		require.Equal(t, append(events, swapEvent), consumer.CollectedEvents())
+	})
 }
 
-func TestCommonHub_HandleTxsBroadcast(t *testing.T) {
+func TestCommonHub_BroadcastsShouldBeDeliveredInOrder(t *testing.T) {
 	t.Parallel()
 
+	block1Matching := make(chan struct{})
+	releaseBlock1 := make(chan struct{})
 	args := createMockCommonHubArgs()
+	args.Filter = &mocks.EventFilterStub{
+		MatchEventCalled: func(subscription data.Subscription, event data.Event) bool {
+			if event.TxHash == "txHash1" {
+				close(block1Matching)
+				<-releaseBlock1
+			}
+
+			return true
+		},
+	}
 	hub, err := NewCommonHub(args)
-	require.NoError(t, err)
+	require.Nil(t, err)
 
-	numCalls := uint32(0)
-	hub.registerDispatcher(&mocks.DispatcherStub{
-		TxsEventCalled: func(event data.BlockTxs) {
-			atomic.AddUint32(&numCalls, 1)
+	dispatcherID := uuid.New()
+	mutReceived := sync.Mutex{}
+	receivedTxHashes := make([]string, 0)
+	hub.RegisterEvent(&mocks.DispatcherStub{
+		GetIDCalled: func() uuid.UUID {
+			return dispatcherID
+		},
+		PushEventsCalled: func(events []data.Event) {
+			mutReceived.Lock()
+			receivedTxHashes = append(receivedTxHashes, events[0].TxHash)
+			mutReceived.Unlock()
 		},
 	})
-
 	hub.Subscribe(data.SubscribeEvent{
-		SubscriptionEntries: []data.SubscriptionEntry{
-			{
-				EventType: common.BlockTxs,
-			},
-		},
+		DispatcherID:        dispatcherID,
+		SubscriptionEntries: []data.SubscriptionEntry{},
 	})
 
-	blockEvents := data.BlockTxs{
-		Hash: "hash1",
-	}
+	block1Published := make(chan struct{})
+	go func() {
+		hub.Publish(context.Background(), data.BlockEvents{Hash: "hash1", Events: []data.Event{{TxHash: "txHash1"}}})
+		close(block1Published)
+	}()
 
-	hub.PublishTxs(blockEvents)
+	// block 2 is fully processed while block 1 is still being matched
+	<-block1Matching
+	hub.Publish(context.Background(), data.BlockEvents{Hash: "hash2", Events: []data.Event{{TxHash: "txHash2"}}})
 
-	time.Sleep(time.Millisecond * 100)
+	mutReceived.Lock()
+	require.Empty(t, receivedTxHashes)
+	mutReceived.Unlock()
 
-	assert.Equal(t, uint32(1), atomic.LoadUint32(&numCalls))
+	close(releaseBlock1)
+	<-block1Published
+
+	mutReceived.Lock()
+	require.Equal(t, []string{"txHash1", "txHash2"}, receivedTxHashes)
+	mutReceived.Unlock()
 }
 
-func TestCommonHub_HandleBlockEventsBroadcast(t *testing.T) {
+func TestCommonHub_HandleRevertBroadcastOverlappingSubscriptions(t *testing.T) {
 	t.Parallel()
 
 	args := createMockCommonHubArgs()
 	hub, err := NewCommonHub(args)
-	require.NoError(t, err)
+	require.Nil(t, err)
 
 	numCalls := uint32(0)
 	hub.registerDispatcher(&mocks.DispatcherStub{
-		BlockEventsCalled: func(event data.BlockEventsWithOrder) {
+		RevertEventCalled: func(event data.RevertBlock) {
+			atomic.AddUint32(&numCalls, 1)
+		},
+	})
+
+	hub.Subscribe(data.SubscribeEvent{
+		SubscriptionEntries: []data.SubscriptionEntry{
+			{
+				EventType: common.RevertBlockEvents,
+			},
+			{
+				EventType: common.RevertBlockEvents,
+				Address:   "erd1",
+			},
+		},
+	})
+
+	hub.PublishRevert(context.Background(), data.RevertBlock{Hash: "hash1"})
+
+	assert.Equal(t, uint32(1), atomic.LoadUint32(&numCalls))
+}
+
+func TestCommonHub_HandleRevertBroadcast(t *testing.T) {
+	t.Parallel()
+
+	args := createMockCommonHubArgs()
+	hub, err := NewCommonHub(args)
+	require.Nil(t, err)
+
+	numCalls := uint32(0)
+	hub.registerDispatcher(&mocks.DispatcherStub{
+		RevertEventCalled: func(event data.RevertBlock) {
+			atomic.AddUint32(&numCalls, 1)
+		},
+	})
+
+	hub.Subscribe(data.SubscribeEvent{
+		SubscriptionEntries: []data.SubscriptionEntry{
+			{
+				EventType: common.RevertBlockEvents,
+			},
+		},
+	})
+
+	blockEvents := data.RevertBlock{
+		Hash:  "hash1",
+		Nonce: 1,
+	}
+
+	hub.PublishRevert(context.Background(), blockEvents)
+
+	time.Sleep(time.Millisecond * 100)
+
+	assert.Equal(t, uint32(1), atomic.LoadUint32(&numCalls))
+}
+
+func TestCommonHub_HandleFinalizedBroadcast(t *testing.T) {
+	t.Parallel()
+
+	args := createMockCommonHubArgs()
+	hub, err := NewCommonHub(args)
+	require.Nil(t, err)
+
+	numCalls := uint32(0)
+	hub.registerDispatcher(&mocks.DispatcherStub{
+		FinalizedEventCalled: func(event data.FinalizedBlock) {
+			atomic.AddUint32(&numCalls, 1)
+		},
+	})
+
+	hub.Subscribe(data.SubscribeEvent{
+		SubscriptionEntries: []data.SubscriptionEntry{
+			{
+				EventType: common.FinalizedBlockEvents,
+			},
+		},
+	})
+
+	blockEvents := data.FinalizedBlock{
+		Hash: "hash1",
+	}
+
+	hub.PublishFinalized(context.Background(), blockEvents)
+
+	time.Sleep(time.Millisecond * 100)
+
+	assert.Equal(t, uint32(1), atomic.LoadUint32(&numCalls))
+}
+
+func TestCommonHub_HandleTxsBroadcast(t *testing.T) {
+	t.Parallel()
+
+	args := createMockCommonHubArgs()
+	hub, err := NewCommonHub(args)
+	require.NoError(t, err)
+
+	numCalls := uint32(0)
+	hub.registerDispatcher(&mocks.DispatcherStub{
+		TxsEventCalled: func(event data.BlockTxs) {
+			atomic.AddUint32(&numCalls, 1)
+		},
+	})
+
+	hub.Subscribe(data.SubscribeEvent{
+		SubscriptionEntries: []data.SubscriptionEntry{
+			{
+				EventType: common.BlockTxs,
+			},
+		},
+	})
+
+	blockEvents := data.BlockTxs{
+		Hash: "hash1",
+	}
+
+	hub.PublishTxs(context.Background(), blockEvents)
+
+	time.Sleep(time.Millisecond * 100)
+
+	assert.Equal(t, uint32(1), atomic.LoadUint32(&numCalls))
+}
+
+func TestCommonHub_HandleBlockEventsBroadcast(t *testing.T) {
+	t.Parallel()
+
+	args := createMockCommonHubArgs()
+	hub, err := NewCommonHub(args)
+	require.NoError(t, err)
+
+	numCalls := uint32(0)
+	hub.registerDispatcher(&mocks.DispatcherStub{
+		BlockEventsCalled: func(event data.BlockEventsWithOrder) {
 			atomic.AddUint32(&numCalls, 1)
 		},
 	})
@@ -307,7 +914,7 @@ func TestCommonHub_HandleBlockEventsBroadcast(t *testing.T) {
 		Hash: "hash1",
 	}
 
-	hub.PublishBlockEventsWithOrder(blockEvents)
+	hub.PublishBlockEventsWithOrder(context.Background(), blockEvents)
 
 	time.Sleep(time.Millisecond * 100)
 
@@ -340,13 +947,508 @@ func TestCommonHub_HandleScrsBroadcast(t *testing.T) {
 		Hash: "hash1",
 	}
 
-	hub.PublishScrs(blockEvents)
+	hub.PublishScrs(context.Background(), blockEvents)
 
 	time.Sleep(time.Millisecond * 100)
 
 	assert.Equal(t, uint32(1), atomic.LoadUint32(&numCalls))
 }
 
+func TestCommonHub_HandleRoundsBroadcast(t *testing.T) {
+	t.Parallel()
+
+	args := createMockCommonHubArgs()
+	hub, err := NewCommonHub(args)
+	require.Nil(t, err)
+
+	roundEvents := data.RoundEvents{
+		ShardID: 1,
+		Rounds:  []data.RoundInfo{{Round: 10, BlockWasProposed: true}},
+	}
+
+	roundsDispatcherID := uuid.New()
+	numCalls := uint32(0)
+	hub.registerDispatcher(&mocks.DispatcherStub{
+		GetIDCalled: func() uuid.UUID {
+			return roundsDispatcherID
+		},
+		RoundEventsCalled: func(event data.RoundEvents) {
+			assert.Equal(t, roundEvents, event)
+			atomic.AddUint32(&numCalls, 1)
+		},
+	})
+
+	// a dispatcher subscribed to all the logs and events does not receive the rounds
+	eventsDispatcherID := uuid.New()
+	numEventsDispatcherCalls := uint32(0)
+	hub.registerDispatcher(&mocks.DispatcherStub{
+		GetIDCalled: func() uuid.UUID {
+			return eventsDispatcherID
+		},
+		RoundEventsCalled: func(event data.RoundEvents) {
+			atomic.AddUint32(&numEventsDispatcherCalls, 1)
+		},
+	})
+
+	hub.Subscribe(data.SubscribeEvent{
+		DispatcherID: roundsDispatcherID,
+		SubscriptionEntries: []data.SubscriptionEntry{
+			{
+				EventType: common.RoundEvents,
+			},
+		},
+	})
+	hub.Subscribe(data.SubscribeEvent{
+		DispatcherID:        eventsDispatcherID,
+		SubscriptionEntries: []data.SubscriptionEntry{},
+	})
+
+	hub.PublishRounds(context.Background(), roundEvents)
+
+	time.Sleep(time.Millisecond * 100)
+
+	assert.Equal(t, uint32(1), atomic.LoadUint32(&numCalls))
+	assert.Equal(t, uint32(0), atomic.LoadUint32(&numEventsDispatcherCalls))
+}
+
+func TestCommonHub_HandleTxEventsBroadcast(t *testing.T) {
+	t.Parallel()
+
+	args := createMockCommonHubArgs()
+	hub, err := NewCommonHub(args)
+	require.Nil(t, err)
+
+	blockTxEvents := data.BlockTxEvents{
+		Hash: "hash1",
+		TxEvents: []data.TxEvent{
+			{Hash: "txHash1", Sender: "erd1alice", Receiver: "erd1bob"},
+			{Hash: "txHash2", Sender: "erd1bob", Receiver: "erd1dan"},
+			{Hash: "txHash3", Sender: "erd1dan", Receiver: "erd1alice"},
+		},
+	}
+
+	subscribeTxEvents := func(addresses ...string) map[uuid.UUID][]data.BlockTxEvents {
+		id := uuid.New()
+		received := make(map[uuid.UUID][]data.BlockTxEvents)
+		hub.registerDispatcher(&mocks.DispatcherStub{
+			GetIDCalled: func() uuid.UUID {
+				return id
+			},
+			BlockTxEventsCalled: func(event data.BlockTxEvents) {
+				received[id] = append(received[id], event)
+			},
+		})
+
+		entries := make([]data.SubscriptionEntry, 0, len(addresses))
+		for _, address := range addresses {
+			entries = append(entries, data.SubscriptionEntry{
+				EventType: common.TxEvents,
+				Address:   address,
+			})
+		}
+		hub.Subscribe(data.SubscribeEvent{
+			DispatcherID:        id,
+			SubscriptionEntries: entries,
+		})
+
+		return received
+	}
+
+	aliceEvents := subscribeTxEvents("erd1alice")
+	bobAndAllEvents := subscribeTxEvents("erd1bob", "")
+	carolEvents := subscribeTxEvents("erd1carol")
+
+	hub.PublishTxEvents(context.Background(), blockTxEvents)
+
+	for _, events := range aliceEvents {
+		require.Equal(t, 1, len(events))
+		require.Equal(t, []data.TxEvent{blockTxEvents.TxEvents[0], blockTxEvents.TxEvents[2]}, events[0].TxEvents)
+	}
+	require.Equal(t, 1, len(aliceEvents))
+
+	for _, events := range bobAndAllEvents {
+		require.Equal(t, 1, len(events))
+		require.Equal(t, blockTxEvents, events[0])
+	}
+	require.Equal(t, 1, len(bobAndAllEvents))
+
+	require.Equal(t, 0, len(carolEvents))
+}
+
+func TestCommonHub_HandleAccountsBroadcast(t *testing.T) {
+	t.Parallel()
+
+	args := createMockCommonHubArgs()
+	hub, err := NewCommonHub(args)
+	require.Nil(t, err)
+
+	accountsEvents := data.AccountsEvents{
+		ShardID:        1,
+		BlockTimestamp: 1234,
+		Accounts: []data.AccountEvent{
+			{Address: "erd1alice", Nonce: 1, Balance: "10"},
+			{Address: "erd1bob", Nonce: 2, Balance: "20"},
+			{Address: "erd1dan", Nonce: 3, Balance: "30"},
+		},
+	}
+
+	subscribeAccounts := func(addresses ...string) *[]data.AccountsEvents {
+		id := uuid.New()
+		received := make([]data.AccountsEvents, 0)
+		hub.registerDispatcher(&mocks.DispatcherStub{
+			GetIDCalled: func() uuid.UUID {
+				return id
+			},
+			AccountsEventsCalled: func(event data.AccountsEvents) {
+				received = append(received, event)
+			},
+		})
+
+		entries := make([]data.SubscriptionEntry, 0, len(addresses))
+		for _, address := range addresses {
+			entries = append(entries, data.SubscriptionEntry{
+				EventType: common.AccountEvents,
+				Address:   address,
+			})
+		}
+		err = hub.Subscribe(data.SubscribeEvent{
+			DispatcherID:        id,
+			SubscriptionEntries: entries,
+		})
+		require.Nil(t, err)
+
+		return &received
+	}
+
+	aliceAndDanEvents := subscribeAccounts("erd1dan", "erd1alice", "erd1alice")
+	globEvents := subscribeAccounts("erd1b*", "erd1bob")
+	allEvents := subscribeAccounts("")
+	carolEvents := subscribeAccounts("erd1carol")
+
+	hub.PublishAccounts(context.Background(), accountsEvents)
+
+	require.Equal(t, []data.AccountsEvents{{
+		ShardID:        1,
+		BlockTimestamp: 1234,
+		Accounts:       []data.AccountEvent{accountsEvents.Accounts[0], accountsEvents.Accounts[2]},
+	}}, *aliceAndDanEvents)
+	require.Equal(t, []data.AccountsEvents{{
+		ShardID:        1,
+		BlockTimestamp: 1234,
+		Accounts:       []data.AccountEvent{accountsEvents.Accounts[1]},
+	}}, *globEvents)
+	require.Equal(t, []data.AccountsEvents{accountsEvents}, *allEvents)
+	require.Equal(t, 0, len(*carolEvents))
+}
+
+func TestCommonHub_SubscribeMaxSubscriptionsReached(t *testing.T) {
+	t.Parallel()
+
+	subscriptionMapper, _ := dispatcher.NewSubscriptionMapper(dispatcher.ArgsSubscriptionMapper{MaxSubscriptionsPerDispatcher: 1})
+	args := createMockCommonHubArgs()
+	args.SubscriptionMapper = subscriptionMapper
+	args.ReplayBufferSize = 1
+	hub, err := NewCommonHub(args)
+	require.Nil(t, err)
+
+	id := uuid.New()
+	replayUnavailableCalled := false
+	hub.registerDispatcher(&mocks.DispatcherStub{
+		GetIDCalled: func() uuid.UUID {
+			return id
+		},
+		ReplayUnavailableCalled: func(event data.ReplayUnavailable) {
+			replayUnavailableCalled = true
+		},
+	})
+
+	err = hub.Subscribe(data.SubscribeEvent{DispatcherID: id})
+	require.Nil(t, err)
+
+	err = hub.Subscribe(data.SubscribeEvent{DispatcherID: id})
+	require.True(t, errors.Is(err, dispatcher.ErrMaxSubscriptionsReached))
+
+	err = hub.Subscribe(data.SubscribeEvent{DispatcherID: id, FromHash: "hash1"})
+	require.True(t, errors.Is(err, dispatcher.ErrMaxSubscriptionsReached))
+	require.False(t, replayUnavailableCalled)
+}
+
+func TestCommonHub_SubscribeWithReplay(t *testing.T) {
+	t.Parallel()
+
+	publishBlocks := func(hub *commonHub, numBlocks int) {
+		for i := 1; i <= numBlocks; i++ {
+			hash := fmt.Sprintf("hash%d", i)
+			hub.Publish(context.Background(), data.BlockEvents{
+				Hash:   hash,
+				Nonce:  uint64(i),
+				Events: []data.Event{{Address: "erd1alice", TxHash: hash}},
+			})
+			hub.PublishRevert(context.Background(), data.RevertBlock{Hash: hash})
+		}
+	}
+
+	registerDispatcher := func(hub *commonHub) (uuid.UUID, *[]string, *[]data.ReplayUnavailable) {
+		id := uuid.New()
+		received := make([]string, 0)
+		replayUnavailable := make([]data.ReplayUnavailable, 0)
+		hub.registerDispatcher(&mocks.DispatcherStub{
+			GetIDCalled: func() uuid.UUID {
+				return id
+			},
+			PushEventsCalled: func(events []data.Event) {
+				for _, event := range events {
+					received = append(received, event.TxHash)
+				}
+			},
+			ReplayUnavailableCalled: func(event data.ReplayUnavailable) {
+				replayUnavailable = append(replayUnavailable, event)
+			},
+		})
+
+		return id, &received, &replayUnavailable
+	}
+
+	t.Run("should replay the buffered events starting with the hash, before the live ones", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockCommonHubArgs()
+		args.ReplayBufferSize = 3
+		hub, err := NewCommonHub(args)
+		require.Nil(t, err)
+
+		publishBlocks(hub, 4)
+
+		id, received, _ := registerDispatcher(hub)
+		hub.Subscribe(data.SubscribeEvent{
+			DispatcherID: id,
+			FromHash:     "hash3",
+		})
+		hub.Publish(context.Background(), data.BlockEvents{Hash: "hash5", Events: []data.Event{{TxHash: "hash5"}}})
+
+		require.Equal(t, []string{"hash3", "hash4", "hash5"}, *received)
+	})
+
+	t.Run("should replay only the matching events starting with the nonce", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockCommonHubArgs()
+		args.ReplayBufferSize = 3
+		hub, err := NewCommonHub(args)
+		require.Nil(t, err)
+
+		publishBlocks(hub, 3)
+
+		numReverts := 0
+		id := uuid.New()
+		hub.registerDispatcher(&mocks.DispatcherStub{
+			GetIDCalled: func() uuid.UUID {
+				return id
+			},
+			RevertEventCalled: func(event data.RevertBlock) {
+				numReverts++
+			},
+			PushEventsCalled: func(events []data.Event) {
+				require.Fail(t, "should not have been called")
+			},
+		})
+		hub.Subscribe(data.SubscribeEvent{
+			DispatcherID: id,
+			SubscriptionEntries: []data.SubscriptionEntry{
+				{EventType: common.RevertBlockEvents},
+			},
+			FromBlockNonce: 2,
+		})
+
+		require.Equal(t, 2, numReverts)
+	})
+
+	t.Run("should replay the buffered events after the sequence, before the live ones", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockCommonHubArgs()
+		args.ReplayBufferSize = 3
+		hub, err := NewCommonHub(args)
+		require.Nil(t, err)
+
+		// each block gets the sequence of its events, followed by the one of its revert
+		publishBlocks(hub, 4)
+
+		id, received, replayUnavailable := registerDispatcher(hub)
+		hub.Subscribe(data.SubscribeEvent{
+			DispatcherID:  id,
+			SinceSequence: 4,
+		})
+		hub.Publish(context.Background(), data.BlockEvents{Hash: "hash5", Events: []data.Event{{TxHash: "hash5"}}})
+
+		require.Empty(t, *replayUnavailable)
+		require.Equal(t, []string{"hash3", "hash4", "hash5"}, *received)
+	})
+
+	t.Run("evicted sequence should signal replay unavailable", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockCommonHubArgs()
+		args.ReplayBufferSize = 2
+		hub, err := NewCommonHub(args)
+		require.Nil(t, err)
+
+		publishBlocks(hub, 3)
+
+		id, received, replayUnavailable := registerDispatcher(hub)
+		hub.Subscribe(data.SubscribeEvent{
+			DispatcherID:  id,
+			SinceSequence: 1,
+		})
+
+		require.Equal(t, []data.ReplayUnavailable{
+			{OldestBlockNonce: 2, OldestHash: "hash2"},
+		}, *replayUnavailable)
+		require.Empty(t, *received)
+	})
+
+	t.Run("block older than the buffered ones should signal replay unavailable", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockCommonHubArgs()
+		args.ReplayBufferSize = 2
+		hub, err := NewCommonHub(args)
+		require.Nil(t, err)
+
+		publishBlocks(hub, 3)
+
+		id, received, replayUnavailable := registerDispatcher(hub)
+		hub.Subscribe(data.SubscribeEvent{
+			DispatcherID:   id,
+			FromBlockNonce: 1,
+		})
+		hub.Publish(context.Background(), data.BlockEvents{Hash: "hash4", Events: []data.Event{{TxHash: "hash4"}}})
+
+		require.Equal(t, []data.ReplayUnavailable{
+			{FromBlockNonce: 1, OldestBlockNonce: 2, OldestHash: "hash2"},
+		}, *replayUnavailable)
+		require.Equal(t, []string{"hash4"}, *received)
+	})
+
+	t.Run("disabled replay should signal replay unavailable", func(t *testing.T) {
+		t.Parallel()
+
+		hub, err := NewCommonHub(createMockCommonHubArgs())
+		require.Nil(t, err)
+
+		publishBlocks(hub, 1)
+
+		id, received, replayUnavailable := registerDispatcher(hub)
+		hub.Subscribe(data.SubscribeEvent{
+			DispatcherID: id,
+			FromHash:     "hash1",
+		})
+
+		require.Equal(t, []data.ReplayUnavailable{{FromHash: "hash1"}}, *replayUnavailable)
+		require.Empty(t, *received)
+	})
+}
+
+func TestCommonHub_GetSubscriptionStats(t *testing.T) {
+	t.Parallel()
+
+	t.Run("disabled match stats should only count the subscriptions", func(t *testing.T) {
+		t.Parallel()
+
+		hub, err := NewCommonHub(createMockCommonHubArgs())
+		require.Nil(t, err)
+
+		dispatcher1 := mocks.NewDispatcherMock(mocks.NewConsumerMock(), hub)
+		hub.RegisterEvent(dispatcher1)
+		_ = hub.Subscribe(data.SubscribeEvent{
+			DispatcherID: dispatcher1.GetID(),
+			SubscriptionEntries: []data.SubscriptionEntry{
+				{Address: "erd1"},
+				{EventType: common.FinalizedBlockEvents},
+			},
+		})
+		hub.Publish(context.Background(), getEvents())
+
+		stats := hub.GetSubscriptionStats()
+		require.Equal(t, 1, stats.NumDispatchers)
+		require.Equal(t, 2, stats.NumSubscriptions)
+		require.Equal(t, uint64(0), stats.WindowInSec)
+		require.Equal(t, 1, len(stats.Subscriptions))
+		require.Equal(t, uint64(0), stats.Subscriptions[0].NumMatches)
+	})
+
+	t.Run("should count each matched event once for a dispatcher", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockCommonHubArgs()
+		args.MatchStatsWindow = time.Minute
+		hub, err := NewCommonHub(args)
+		require.Nil(t, err)
+
+		dispatcher1 := mocks.NewDispatcherMock(mocks.NewConsumerMock(), hub)
+		hub.RegisterEvent(dispatcher1)
+		_ = hub.Subscribe(data.SubscribeEvent{
+			DispatcherID: dispatcher1.GetID(),
+			SubscriptionEntries: []data.SubscriptionEntry{
+				{Address: "erd1"},
+				{Identifier: "swap"},
+				{Identifier: "lock"},
+			},
+		})
+
+		hub.Publish(context.Background(), getEvents())
+		hub.Publish(context.Background(), getEvents())
+
+		stats := hub.GetSubscriptionStats()
+		require.Equal(t, uint64(60), stats.WindowInSec)
+		require.Equal(t, []data.SubscriptionMatchStats{
+			{ID: 1, DispatcherID: dispatcher1.GetID(), Address: "erd1", MatchLevel: dispatcher.MatchAddress, NumMatches: 2},
+			{ID: 3, DispatcherID: dispatcher1.GetID(), Identifier: "lock", MatchLevel: dispatcher.MatchIdentifier, NumMatches: 2},
+			{ID: 2, DispatcherID: dispatcher1.GetID(), Identifier: "swap", MatchLevel: dispatcher.MatchIdentifier},
+		}, stats.Subscriptions)
+	})
+}
+
+func TestCommonHub_MatchEvent(t *testing.T) {
+	t.Parallel()
+
+	hub, err := NewCommonHub(createMockCommonHubArgs())
+	require.Nil(t, err)
+
+	consumer1 := mocks.NewConsumerMock()
+	dispatcher1 := mocks.NewDispatcherMock(consumer1, hub)
+	hub.RegisterEvent(dispatcher1)
+	_ = hub.Subscribe(data.SubscribeEvent{
+		DispatcherID: dispatcher1.GetID(),
+		SubscriptionEntries: []data.SubscriptionEntry{
+			{Identifier: "swap"},
+			{Address: "erd1"},
+			{Address: "erd2"},
+		},
+	})
+	consumer2 := mocks.NewConsumerMock()
+	dispatcher2 := mocks.NewDispatcherMock(consumer2, hub)
+	hub.RegisterEvent(dispatcher2)
+	_ = hub.Subscribe(data.SubscribeEvent{
+		DispatcherID:        dispatcher2.GetID(),
+		SubscriptionEntries: []data.SubscriptionEntry{{Address: "erd1", Identifier: "swap"}},
+	})
+
+	response := hub.MatchEvent(data.Event{Address: "erd1", Identifier: "swap"})
+	require.Equal(t, []uuid.UUID{dispatcher1.GetID(), dispatcher2.GetID()}, response.DispatcherIDs)
+	require.Equal(t, 3, len(response.Subscriptions))
+	require.Equal(t, uint64(1), response.Subscriptions[0].ID)
+	require.Equal(t, uint64(2), response.Subscriptions[1].ID)
+	require.Equal(t, uint64(4), response.Subscriptions[2].ID)
+
+	response = hub.MatchEvent(data.Event{Address: "erd3", Identifier: "transfer"})
+	require.Equal(t, 0, len(response.DispatcherIDs))
+	require.Equal(t, 0, len(response.Subscriptions))
+
+	require.Equal(t, 0, len(consumer1.CollectedEvents()))
+	require.Equal(t, 0, len(consumer2.CollectedEvents()))
+}
+
 func getEvents() data.BlockEvents {
 	return data.BlockEvents{
 		Hash: "374d75573060d840257045add9cd104b70180065f2406808ebabe02a1a3cb5f8",
@@ -372,3 +1474,177 @@ func getEvents() data.BlockEvents {
 		},
 	}
 }
+
+func BenchmarkCommonHub_Publish(b *testing.B) {
+	hub, _ := NewCommonHub(createMockCommonHubArgs())
+
+	numDispatchers := 100
+	for i := 0; i < numDispatchers; i++ {
+		dispatcherID := uuid.New()
+		hub.RegisterEvent(&mocks.DispatcherStub{
+			GetIDCalled: func() uuid.UUID {
+				return dispatcherID
+			},
+		})
+		hub.Subscribe(data.SubscribeEvent{
+			DispatcherID: dispatcherID,
+			SubscriptionEntries: []data.SubscriptionEntry{
+				{
+					Address: "erd1",
+				},
+				{
+					Identifier: "swap",
+				},
+			},
+		})
+	}
+
+	blockEvents := data.BlockEvents{
+		Hash:   "hash1",
+		Events: make([]data.Event, 0, 100),
+	}
+	for i := 0; i < 100; i++ {
+		blockEvents.Events = append(blockEvents.Events, getEvents().Events...)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		hub.Publish(context.Background(), blockEvents)
+	}
+}
+
+func TestCommonHub_GetMetricsForPrometheus(t *testing.T) {
+	t.Parallel()
+
+	args := createMockCommonHubArgs()
+	hub, err := NewCommonHub(args)
+	require.Nil(t, err)
+
+	dispatcher1 := mocks.NewDispatcherMock(nil, hub)
+	hub.RegisterEvent(dispatcher1)
+
+	hub.Publish(context.Background(), data.BlockEvents{})
+	hub.Publish(context.Background(), data.BlockEvents{})
+	hub.PublishRevert(context.Background(), data.RevertBlock{})
+
+	res := hub.GetMetricsForPrometheus()
+	require.Contains(t, res, "hub_dispatchers 1\n")
+	require.Contains(t, res, "hub_broadcasts{event_type=\"all_events\"} 2\n")
+	require.Contains(t, res, "hub_broadcasts{event_type=\"revert_events\"} 1\n")
+}
+
+func TestCommonHub_MetricsCollector(t *testing.T) {
+	t.Parallel()
+
+	broadcastStatuses := make([]string, 0)
+	numActiveDispatchers := make([]uint64, 0)
+
+	args := createMockCommonHubArgs()
+	args.MetricsCollector = &mocks.MetricsCollectorStub{
+		AddBroadcastCalled: func(status string) {
+			broadcastStatuses = append(broadcastStatuses, status)
+		},
+		SetActiveDispatchersCalled: func(numDispatchers uint64) {
+			numActiveDispatchers = append(numActiveDispatchers, numDispatchers)
+		},
+	}
+	hub, err := NewCommonHub(args)
+	require.Nil(t, err)
+
+	dispatcherID := uuid.New()
+	dispatcher1 := &mocks.DispatcherStub{
+		GetIDCalled: func() uuid.UUID {
+			return dispatcherID
+		},
+	}
+	hub.registerDispatcher(dispatcher1)
+
+	hub.PublishFinalized(context.Background(), data.FinalizedBlock{Hash: "hash1"})
+
+	hub.Subscribe(data.SubscribeEvent{
+		DispatcherID: dispatcherID,
+		SubscriptionEntries: []data.SubscriptionEntry{
+			{
+				EventType: common.FinalizedBlockEvents,
+			},
+		},
+	})
+	hub.PublishFinalized(context.Background(), data.FinalizedBlock{Hash: "hash2"})
+
+	hub.unregisterDispatcher(dispatcher1)
+
+	expBroadcastStatuses := []string{metrics.BroadcastStatusNoSubscribers, metrics.BroadcastStatusDelivered}
+	assert.Equal(t, expBroadcastStatuses, broadcastStatuses)
+	assert.Equal(t, []uint64{1, 0}, numActiveDispatchers)
+}
+
+func TestCommonHub_BroadcastSpans(t *testing.T) {
+	t.Parallel()
+
+	parentSpanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1, 2, 3},
+		SpanID:     trace.SpanID{4, 5, 6},
+		TraceFlags: trace.FlagsSampled,
+	})
+
+	exporter := tracetest.NewInMemoryExporter()
+	args := createMockCommonHubArgs()
+	args.TracerProvider = sdkTrace.NewTracerProvider(sdkTrace.WithSyncer(exporter))
+	hub, err := NewCommonHub(args)
+	require.Nil(t, err)
+
+	hub.registerDispatcher(&mocks.DispatcherStub{})
+	hub.Subscribe(data.SubscribeEvent{
+		SubscriptionEntries: []data.SubscriptionEntry{
+			{
+				EventType: common.RevertBlockEvents,
+			},
+		},
+	})
+
+	hub.PublishRevert(context.Background(), data.RevertBlock{Hash: "hash1", SpanContext: parentSpanContext})
+	hub.PublishFinalized(context.Background(), data.FinalizedBlock{Hash: "hash2"})
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+
+	revertSpan := spans[0]
+	require.Equal(t, "commonHub.Broadcast", revertSpan.Name)
+	require.Equal(t, parentSpanContext.TraceID(), revertSpan.SpanContext.TraceID())
+	require.Equal(t, parentSpanContext.SpanID(), revertSpan.Parent.SpanID())
+	require.Contains(t, revertSpan.Attributes, attribute.String("notifier.event_type", common.RevertBlockEvents))
+	require.Contains(t, revertSpan.Attributes, attribute.String("notifier.block_hash", "hash1"))
+	require.Contains(t, revertSpan.Attributes, attribute.Int("notifier.num_delivered", 1))
+
+	// events without trace context start a new trace
+	finalizedSpan := spans[1]
+	require.False(t, finalizedSpan.Parent.IsValid())
+	require.NotEqual(t, parentSpanContext.TraceID(), finalizedSpan.SpanContext.TraceID())
+	require.Contains(t, finalizedSpan.Attributes, attribute.Int("notifier.num_delivered", 0))
+}
+
+func TestCommonHub_BroadcastShouldLogCorrelationID(t *testing.T) {
+	logPattern := logger.GetLogLevelPattern()
+	_ = logger.SetLogLevel("*:INFO,hub:DEBUG")
+	defer func() {
+		_ = logger.SetLogLevel(logPattern)
+	}()
+
+	logOutput := &mocks.LogOutputStub{}
+	err := logger.AddLogObserver(logOutput, &logger.PlainFormatter{})
+	require.Nil(t, err)
+	defer func() {
+		_ = logger.RemoveLogObserver(logOutput)
+	}()
+
+	hub, err := NewCommonHub(createMockCommonHubArgs())
+	require.Nil(t, err)
+
+	hub.Publish(context.Background(), data.BlockEvents{Hash: "hash1", CorrelationID: "hub-correlation-id-1"})
+	hub.PublishRevert(context.Background(), data.RevertBlock{Hash: "hash2", CorrelationID: "hub-correlation-id-2"})
+	hub.PublishFinalized(context.Background(), data.FinalizedBlock{Hash: "hash3", CorrelationID: "hub-correlation-id-3"})
+
+	require.Contains(t, logOutput.String(), "hub-correlation-id-1")
+	require.Contains(t, logOutput.String(), "hub-correlation-id-2")
+	require.Contains(t, logOutput.String(), "hub-correlation-id-3")
+}
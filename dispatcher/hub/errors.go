@@ -7,3 +7,18 @@ var ErrNilEventFilter = errors.New("nil events filter")
 
 // ErrNilSubscriptionMapper signals that a nil subscription mapper has been provided
 var ErrNilSubscriptionMapper = errors.New("nil subscription mapper")
+
+// ErrNilHub signals that a nil hub has been provided
+var ErrNilHub = errors.New("nil hub")
+
+// ErrNilBackplane signals that a nil backplane has been provided
+var ErrNilBackplane = errors.New("nil backplane")
+
+// ErrEmptyInstanceID signals that an empty instance id has been provided
+var ErrEmptyInstanceID = errors.New("empty instance id")
+
+// ErrBackplaneSubscriptionClosed signals that the backplane subscription was closed
+var ErrBackplaneSubscriptionClosed = errors.New("backplane subscription closed")
+
+// ErrUnknownBackplaneMessageType signals that a backplane message with an unknown type has been received
+var ErrUnknownBackplaneMessageType = errors.New("unknown backplane message type")
@@ -0,0 +1,53 @@
+package hub
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// orderedDeliveryQueue delivers the broadcasts to a dispatcher in the order of their
+// sequence numbers. A sequence number is reserved for each broadcast when the hub
+// receives it, and a delivery completed out of order is kept until all the previous
+// ones are delivered or skipped
+type orderedDeliveryQueue struct {
+	nextReserved uint64
+
+	mut          sync.Mutex
+	nextDelivery uint64
+	pending      map[uint64]func()
+}
+
+func newOrderedDeliveryQueue() *orderedDeliveryQueue {
+	return &orderedDeliveryQueue{
+		pending: make(map[uint64]func()),
+	}
+}
+
+// reserve returns the sequence number of the next broadcast
+func (q *orderedDeliveryQueue) reserve() uint64 {
+	return atomic.AddUint64(&q.nextReserved, 1) - 1
+}
+
+// deliver runs the delivery with the provided sequence number, after the ones with smaller
+// sequence numbers. A nil delivery only releases the sequence number. The deliveries are
+// run while holding the queue lock, so that they are never run concurrently
+func (q *orderedDeliveryQueue) deliver(sequence uint64, delivery func()) {
+	q.mut.Lock()
+	defer q.mut.Unlock()
+
+	if delivery == nil {
+		delivery = func() {}
+	}
+	q.pending[sequence] = delivery
+
+	for {
+		nextDelivery, ok := q.pending[q.nextDelivery]
+		if !ok {
+			return
+		}
+
+		delete(q.pending, q.nextDelivery)
+		q.nextDelivery++
+		nextDelivery()
+	}
+}
@@ -0,0 +1,84 @@
+package hub
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderedDeliveryQueue_Deliver(t *testing.T) {
+	t.Parallel()
+
+	t.Run("deliveries should run in sequence order", func(t *testing.T) {
+		t.Parallel()
+
+		queue := newOrderedDeliveryQueue()
+		delivered := make([]uint64, 0)
+		createDelivery := func(sequence uint64) func() {
+			return func() {
+				delivered = append(delivered, sequence)
+			}
+		}
+
+		seq0 := queue.reserve()
+		seq1 := queue.reserve()
+		seq2 := queue.reserve()
+
+		queue.deliver(seq2, createDelivery(seq2))
+		queue.deliver(seq1, createDelivery(seq1))
+		require.Empty(t, delivered)
+
+		queue.deliver(seq0, createDelivery(seq0))
+		require.Equal(t, []uint64{0, 1, 2}, delivered)
+	})
+
+	t.Run("released sequence should not block the next deliveries", func(t *testing.T) {
+		t.Parallel()
+
+		queue := newOrderedDeliveryQueue()
+		numDelivered := 0
+
+		seq0 := queue.reserve()
+		seq1 := queue.reserve()
+
+		queue.deliver(seq1, func() {
+			numDelivered++
+		})
+		require.Equal(t, 0, numDelivered)
+
+		queue.deliver(seq0, nil)
+		require.Equal(t, 1, numDelivered)
+	})
+
+	t.Run("concurrent deliveries should keep the order", func(t *testing.T) {
+		t.Parallel()
+
+		queue := newOrderedDeliveryQueue()
+		numDeliveries := 100
+		delivered := make([]uint64, 0, numDeliveries)
+
+		sequences := make([]uint64, 0, numDeliveries)
+		for i := 0; i < numDeliveries; i++ {
+			sequences = append(sequences, queue.reserve())
+		}
+
+		wg := sync.WaitGroup{}
+		wg.Add(numDeliveries)
+		for i := numDeliveries - 1; i >= 0; i-- {
+			go func(sequence uint64) {
+				defer wg.Done()
+
+				queue.deliver(sequence, func() {
+					delivered = append(delivered, sequence)
+				})
+			}(sequences[i])
+		}
+		wg.Wait()
+
+		require.Len(t, delivered, numDeliveries)
+		for i, sequence := range delivered {
+			require.Equal(t, uint64(i), sequence)
+		}
+	})
+}
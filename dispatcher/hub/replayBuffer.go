@@ -0,0 +1,179 @@
+package hub
+
+import (
+	"sync"
+
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/dispatcher"
+)
+
+// replayEntry holds a broadcast kept by the replay buffer. The replay delivers the
+// broadcast to a single dispatcher, matched against its subscriptions to the event type
+// The logs and events broadcast of a block starts a new block in the buffer
+type replayEntry struct {
+	eventType   string
+	startsBlock bool
+	hash        string
+	nonce       uint64
+	replay      func(subscriptions []data.Subscription, d dispatcher.EventDispatcher)
+
+	// sequence is optional, if set it receives the sequence number of the broadcast
+	sequence *uint64
+
+	// lastSequence is the sequence number of the broadcast or, for the event types without
+	// one, the sequence number of the previous broadcast
+	lastSequence uint64
+}
+
+// replayBlock holds the broadcasts done since the logs and events of a block were received
+type replayBlock struct {
+	hash    string
+	nonce   uint64
+	entries []*replayEntry
+}
+
+// replayBuffer is a bounded ring of the most recent blocks, used to replay the missed
+// broadcasts to the dispatchers which subscribe with a replay hint. A zero size disables it
+type replayBuffer struct {
+	mut          sync.RWMutex
+	size         int
+	blocks       []*replayBlock
+	lastSequence uint64
+}
+
+func newReplayBuffer(size uint32) *replayBuffer {
+	return &replayBuffer{
+		size:   int(size),
+		blocks: make([]*replayBlock, 0, size),
+	}
+}
+
+// add appends the broadcast to the latest block or, if it starts a block, adds a new block
+// and evicts the oldest one when full. Broadcasts received before the first block are not kept
+func (rb *replayBuffer) add(entry *replayEntry) {
+	rb.mut.Lock()
+	defer rb.mut.Unlock()
+
+	rb.lastSequence = entry.lastSequence
+	if rb.size == 0 {
+		return
+	}
+
+	if entry.startsBlock {
+		rb.addBlock(entry)
+		return
+	}
+	if len(rb.blocks) == 0 {
+		return
+	}
+
+	latestBlock := rb.blocks[len(rb.blocks)-1]
+	latestBlock.entries = append(latestBlock.entries, entry)
+}
+
+func (rb *replayBuffer) addBlock(entry *replayEntry) {
+	if len(rb.blocks) == rb.size {
+		rb.blocks[0] = nil
+		rb.blocks = rb.blocks[1:]
+	}
+
+	rb.blocks = append(rb.blocks, &replayBlock{
+		hash:    entry.hash,
+		nonce:   entry.nonce,
+		entries: []*replayEntry{entry},
+	})
+}
+
+// entriesFrom returns the broadcasts done starting with the block with the provided hash or,
+// if the hash is empty, with the first block with a nonce greater or equal to the provided
+// one. If the requested block is older than the buffered ones, it returns the details
+// to be sent to the dispatcher instead
+func (rb *replayBuffer) entriesFrom(hash string, nonce uint64) ([]*replayEntry, *data.ReplayUnavailable) {
+	rb.mut.RLock()
+	defer rb.mut.RUnlock()
+
+	startIndex, ok := rb.getStartIndex(hash, nonce)
+	if !ok {
+		replayUnavailable := &data.ReplayUnavailable{
+			FromBlockNonce: nonce,
+			FromHash:       hash,
+		}
+		if len(rb.blocks) > 0 {
+			replayUnavailable.OldestBlockNonce = rb.blocks[0].nonce
+			replayUnavailable.OldestHash = rb.blocks[0].hash
+		}
+
+		return nil, replayUnavailable
+	}
+
+	entries := make([]*replayEntry, 0)
+	for _, block := range rb.blocks[startIndex:] {
+		entries = append(entries, block.entries...)
+	}
+
+	return entries, nil
+}
+
+// entriesSince returns the broadcasts done after the one with the provided sequence number,
+// including the broadcasts of the event types without a sequence number done right after it.
+// If the broadcasts following it are older than the buffered ones, or if the sequence number
+// was not assigned yet, it returns the details to be sent to the dispatcher instead
+func (rb *replayBuffer) entriesSince(sequence uint64) ([]*replayEntry, *data.ReplayUnavailable) {
+	rb.mut.RLock()
+	defer rb.mut.RUnlock()
+
+	entries := make([]*replayEntry, 0)
+	isUpToDate := sequence == rb.lastSequence && len(rb.blocks) == 0
+	if isUpToDate {
+		return entries, nil
+	}
+
+	// the sequence numbers start again from 1 when the notifier is restarted
+	isRestarted := sequence > rb.lastSequence
+	isEvicted := len(rb.blocks) == 0 || sequence+1 < rb.blocks[0].entries[0].lastSequence
+	if isRestarted || isEvicted {
+		replayUnavailable := &data.ReplayUnavailable{}
+		if len(rb.blocks) > 0 {
+			replayUnavailable.OldestBlockNonce = rb.blocks[0].nonce
+			replayUnavailable.OldestHash = rb.blocks[0].hash
+		}
+
+		return nil, replayUnavailable
+	}
+
+	for _, block := range rb.blocks {
+		for _, entry := range block.entries {
+			isAfterSequence := entry.lastSequence > sequence || (entry.sequence == nil && entry.lastSequence == sequence)
+			if isAfterSequence {
+				entries = append(entries, entry)
+			}
+		}
+	}
+
+	return entries, nil
+}
+
+func (rb *replayBuffer) getStartIndex(hash string, nonce uint64) (int, bool) {
+	if hash != "" {
+		for index, block := range rb.blocks {
+			if block.hash == hash {
+				return index, true
+			}
+		}
+
+		return 0, false
+	}
+
+	if len(rb.blocks) == 0 || nonce < rb.blocks[0].nonce {
+		return 0, false
+	}
+
+	for index, block := range rb.blocks {
+		if block.nonce >= nonce {
+			return index, true
+		}
+	}
+
+	// the client is already up to date
+	return len(rb.blocks), true
+}
@@ -0,0 +1,200 @@
+package hub
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/multiversx/mx-chain-notifier-go/common"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/stretchr/testify/require"
+)
+
+func createBlockEntry(hash string, nonce uint64) *replayEntry {
+	return &replayEntry{
+		eventType:   common.PushLogsAndEvents,
+		startsBlock: true,
+		hash:        hash,
+		nonce:       nonce,
+	}
+}
+
+func TestReplayBuffer_EntriesFrom(t *testing.T) {
+	t.Parallel()
+
+	t.Run("disabled buffer should not keep entries", func(t *testing.T) {
+		t.Parallel()
+
+		buffer := newReplayBuffer(0)
+		buffer.add(createBlockEntry("hash1", 1))
+
+		entries, replayUnavailable := buffer.entriesFrom("hash1", 0)
+		require.Nil(t, entries)
+		require.Equal(t, &data.ReplayUnavailable{FromHash: "hash1"}, replayUnavailable)
+	})
+
+	t.Run("entries before the first block should not be kept", func(t *testing.T) {
+		t.Parallel()
+
+		buffer := newReplayBuffer(2)
+		buffer.add(&replayEntry{eventType: common.FinalizedBlockEvents})
+		block1 := createBlockEntry("hash1", 1)
+		buffer.add(block1)
+
+		entries, replayUnavailable := buffer.entriesFrom("", 1)
+		require.Nil(t, replayUnavailable)
+		require.Equal(t, []*replayEntry{block1}, entries)
+	})
+
+	t.Run("oldest block should be evicted when full", func(t *testing.T) {
+		t.Parallel()
+
+		buffer := newReplayBuffer(2)
+		block1 := createBlockEntry("hash1", 1)
+		block2 := createBlockEntry("hash2", 2)
+		finalized2 := &replayEntry{eventType: common.FinalizedBlockEvents}
+		block3 := createBlockEntry("hash3", 3)
+		buffer.add(block1)
+		buffer.add(block2)
+		buffer.add(finalized2)
+		buffer.add(block3)
+
+		entries, replayUnavailable := buffer.entriesFrom("hash1", 0)
+		require.Nil(t, entries)
+		require.Equal(t, &data.ReplayUnavailable{
+			FromHash:         "hash1",
+			OldestBlockNonce: 2,
+			OldestHash:       "hash2",
+		}, replayUnavailable)
+
+		entries, replayUnavailable = buffer.entriesFrom("", 1)
+		require.Nil(t, entries)
+		require.Equal(t, uint64(2), replayUnavailable.OldestBlockNonce)
+
+		entries, replayUnavailable = buffer.entriesFrom("hash2", 0)
+		require.Nil(t, replayUnavailable)
+		require.Equal(t, []*replayEntry{block2, finalized2, block3}, entries)
+
+		entries, replayUnavailable = buffer.entriesFrom("", 3)
+		require.Nil(t, replayUnavailable)
+		require.Equal(t, []*replayEntry{block3}, entries)
+	})
+
+	t.Run("nonce newer than the buffered blocks should return no entries", func(t *testing.T) {
+		t.Parallel()
+
+		buffer := newReplayBuffer(2)
+		buffer.add(createBlockEntry("hash1", 1))
+
+		entries, replayUnavailable := buffer.entriesFrom("", 5)
+		require.Nil(t, replayUnavailable)
+		require.Empty(t, entries)
+	})
+}
+
+func createSequencedBlockEntry(hash string, nonce uint64, sequence uint64) *replayEntry {
+	entry := createBlockEntry(hash, nonce)
+	entry.sequence = &sequence
+	entry.lastSequence = sequence
+
+	return entry
+}
+
+func createSequencedEntry(eventType string, sequence uint64) *replayEntry {
+	return &replayEntry{
+		eventType:    eventType,
+		sequence:     &sequence,
+		lastSequence: sequence,
+	}
+}
+
+func TestReplayBuffer_EntriesSince(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty history should return no entries if up to date", func(t *testing.T) {
+		t.Parallel()
+
+		buffer := newReplayBuffer(2)
+
+		entries, replayUnavailable := buffer.entriesSince(0)
+		require.Nil(t, replayUnavailable)
+		require.Empty(t, entries)
+
+		entries, replayUnavailable = buffer.entriesSince(3)
+		require.Nil(t, entries)
+		require.Equal(t, &data.ReplayUnavailable{}, replayUnavailable)
+	})
+
+	t.Run("disabled buffer should signal replay unavailable", func(t *testing.T) {
+		t.Parallel()
+
+		buffer := newReplayBuffer(0)
+		buffer.add(createSequencedBlockEntry("hash1", 1, 1))
+		buffer.add(createSequencedBlockEntry("hash2", 2, 2))
+
+		entries, replayUnavailable := buffer.entriesSince(1)
+		require.Nil(t, entries)
+		require.Equal(t, &data.ReplayUnavailable{}, replayUnavailable)
+
+		entries, replayUnavailable = buffer.entriesSince(2)
+		require.Nil(t, replayUnavailable)
+		require.Empty(t, entries)
+	})
+
+	t.Run("should return the entries after the sequence", func(t *testing.T) {
+		t.Parallel()
+
+		buffer := newReplayBuffer(3)
+		block1 := createSequencedBlockEntry("hash1", 1, 1)
+		txs1 := &replayEntry{eventType: common.BlockTxs, lastSequence: 1}
+		finalized1 := createSequencedEntry(common.FinalizedBlockEvents, 2)
+		block2 := createSequencedBlockEntry("hash2", 2, 3)
+		txs2 := &replayEntry{eventType: common.BlockTxs, lastSequence: 3}
+		buffer.add(block1)
+		buffer.add(txs1)
+		buffer.add(finalized1)
+		buffer.add(block2)
+		buffer.add(txs2)
+
+		entries, replayUnavailable := buffer.entriesSince(1)
+		require.Nil(t, replayUnavailable)
+		require.Equal(t, []*replayEntry{txs1, finalized1, block2, txs2}, entries)
+
+		entries, replayUnavailable = buffer.entriesSince(2)
+		require.Nil(t, replayUnavailable)
+		require.Equal(t, []*replayEntry{block2, txs2}, entries)
+
+		entries, replayUnavailable = buffer.entriesSince(3)
+		require.Nil(t, replayUnavailable)
+		require.Equal(t, []*replayEntry{txs2}, entries)
+	})
+
+	t.Run("evicted entries should signal replay unavailable after wrap around", func(t *testing.T) {
+		t.Parallel()
+
+		buffer := newReplayBuffer(2)
+		for i := uint64(1); i <= 5; i++ {
+			buffer.add(createSequencedBlockEntry(fmt.Sprintf("hash%d", i), i, i))
+		}
+
+		entries, replayUnavailable := buffer.entriesSince(2)
+		require.Nil(t, entries)
+		require.Equal(t, &data.ReplayUnavailable{OldestBlockNonce: 4, OldestHash: "hash4"}, replayUnavailable)
+
+		entries, replayUnavailable = buffer.entriesSince(3)
+		require.Nil(t, replayUnavailable)
+		require.Equal(t, 2, len(entries))
+		require.Equal(t, "hash4", entries[0].hash)
+		require.Equal(t, "hash5", entries[1].hash)
+	})
+
+	t.Run("sequence not assigned yet should signal replay unavailable", func(t *testing.T) {
+		t.Parallel()
+
+		buffer := newReplayBuffer(2)
+		buffer.add(createSequencedBlockEntry("hash1", 1, 1))
+
+		entries, replayUnavailable := buffer.entriesSince(7)
+		require.Nil(t, entries)
+		require.Equal(t, &data.ReplayUnavailable{OldestBlockNonce: 1, OldestHash: "hash1"}, replayUnavailable)
+	})
+}
@@ -0,0 +1,90 @@
+package hub
+
+import (
+	"sync"
+	"time"
+
+	"github.com/multiversx/mx-chain-notifier-go/data"
+)
+
+const numMatchStatsBuckets = 10
+
+// matchStatsBucket holds the number of matched events of each subscription, by id, counted
+// during one bucket duration
+type matchStatsBucket struct {
+	index      int64
+	numMatches map[uint64]uint64
+}
+
+// subscriptionMatchStats counts the matched events of each subscription over a sliding window,
+// split in buckets, so that the old counts expire without keeping a timestamp for each match.
+// A zero window disables it
+type subscriptionMatchStats struct {
+	mut            sync.Mutex
+	window         time.Duration
+	bucketDuration time.Duration
+	buckets        []matchStatsBucket
+	getTime        func() time.Time
+}
+
+func newSubscriptionMatchStats(window time.Duration) *subscriptionMatchStats {
+	bucketDuration := window / numMatchStatsBuckets
+	if bucketDuration == 0 {
+		bucketDuration = 1
+	}
+
+	return &subscriptionMatchStats{
+		window:         window,
+		bucketDuration: bucketDuration,
+		buckets:        make([]matchStatsBucket, numMatchStatsBuckets),
+		getTime:        time.Now,
+	}
+}
+
+func (sms *subscriptionMatchStats) isEnabled() bool {
+	return sms.window > 0
+}
+
+// add records the matches of a broadcast, counted for each subscription at the same position
+func (sms *subscriptionMatchStats) add(subscriptions []data.Subscription, numMatches []uint64) {
+	sms.mut.Lock()
+	defer sms.mut.Unlock()
+
+	bucket := sms.getCurrentBucket()
+	for index, num := range numMatches {
+		if num > 0 {
+			bucket.numMatches[subscriptions[index].ID] += num
+		}
+	}
+}
+
+func (sms *subscriptionMatchStats) getCurrentBucket() *matchStatsBucket {
+	bucketIndex := sms.getTime().UnixNano() / int64(sms.bucketDuration)
+	bucket := &sms.buckets[bucketIndex%numMatchStatsBuckets]
+	if bucket.index != bucketIndex || bucket.numMatches == nil {
+		bucket.index = bucketIndex
+		bucket.numMatches = make(map[uint64]uint64)
+	}
+
+	return bucket
+}
+
+// getNumMatches returns the number of matched events of each subscription within the window
+func (sms *subscriptionMatchStats) getNumMatches() map[uint64]uint64 {
+	sms.mut.Lock()
+	defer sms.mut.Unlock()
+
+	currentIndex := sms.getTime().UnixNano() / int64(sms.bucketDuration)
+	numMatches := make(map[uint64]uint64)
+	for _, bucket := range sms.buckets {
+		if bucket.index <= currentIndex-numMatchStatsBuckets {
+			continue
+		}
+
+		for id, num := range bucket.numMatches {
+			numMatches[id] += num
+		}
+	}
+
+	return numMatches
+}
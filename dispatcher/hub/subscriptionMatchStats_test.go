@@ -0,0 +1,57 @@
+package hub
+
+import (
+	"testing"
+	"time"
+
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscriptionMatchStats(t *testing.T) {
+	t.Parallel()
+
+	t.Run("zero window should be disabled", func(t *testing.T) {
+		t.Parallel()
+
+		require.False(t, newSubscriptionMatchStats(0).isEnabled())
+		require.True(t, newSubscriptionMatchStats(time.Minute).isEnabled())
+	})
+
+	t.Run("should sum the matches of the same subscription", func(t *testing.T) {
+		t.Parallel()
+
+		stats := newSubscriptionMatchStats(time.Minute)
+		subscriptions := []data.Subscription{{ID: 1}, {ID: 2}, {ID: 3}}
+		stats.add(subscriptions, []uint64{1, 0, 2})
+		stats.add(subscriptions[:2], []uint64{3, 1})
+
+		require.Equal(t, map[uint64]uint64{1: 4, 2: 1, 3: 2}, stats.getNumMatches())
+	})
+
+	t.Run("matches older than the window should expire", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Unix(1000, 0)
+		stats := newSubscriptionMatchStats(10 * time.Second)
+		stats.getTime = func() time.Time {
+			return now
+		}
+		subscriptions := []data.Subscription{{ID: 1}, {ID: 2}}
+
+		stats.add(subscriptions, []uint64{1, 1})
+		now = now.Add(5 * time.Second)
+		stats.add(subscriptions, []uint64{1, 0})
+		require.Equal(t, map[uint64]uint64{1: 2, 2: 1}, stats.getNumMatches())
+
+		now = now.Add(5 * time.Second)
+		require.Equal(t, map[uint64]uint64{1: 1}, stats.getNumMatches())
+
+		// the reused bucket should not keep the expired matches
+		stats.add(subscriptions, []uint64{0, 3})
+		require.Equal(t, map[uint64]uint64{1: 1, 2: 3}, stats.getNumMatches())
+
+		now = now.Add(time.Hour)
+		require.Empty(t, stats.getNumMatches())
+	})
+}
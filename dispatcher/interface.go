@@ -1,6 +1,7 @@
 package dispatcher
 
 import (
+	"context"
 	"io"
 	"net/http"
 	"time"
@@ -11,21 +12,52 @@ import (
 )
 
 // EventDispatcher defines the behaviour of a event dispatcher component
+// IsResumable returns true if the id was supplied by the client, in which case the hub keeps
+// the subscriptions of the dispatcher when it is unregistered, to be matched on reconnect
 type EventDispatcher interface {
 	GetID() uuid.UUID
+	IsResumable() bool
 	PushEvents(events []data.Event)
 	RevertEvent(event data.RevertBlock)
 	FinalizedEvent(event data.FinalizedBlock)
 	TxsEvent(event data.BlockTxs)
 	BlockEvents(event data.BlockEventsWithOrder)
 	ScrsEvent(event data.BlockScrs)
+	BlockTxEvents(event data.BlockTxEvents)
+	RoundEvents(event data.RoundEvents)
+	ValidatorsRatingEvent(event data.ValidatorsRatingEvent)
+	AccountsEvents(event data.AccountsEvents)
+	ReplayUnavailable(event data.ReplayUnavailable)
+	Close() error
 }
 
 // Hub defines the behaviour of a component which should be able to receive events
 // and publish them to subscribers
 type Hub interface {
 	process.PublisherHandler
+	Run() error
 	Dispatcher
+	DisconnectDispatcher(dispatcherID uuid.UUID) error
+	UpdateFilter(filter EventFilter) error
+	GetSubscriptionStats() data.SubscriptionStatsResponse
+	MatchEvent(event data.Event) data.EventMatchResponse
+}
+
+// Backplane defines the behaviour of a component which shares the hub broadcasts between
+// multiple notifier instances. Subscribe calls the handler for each received payload,
+// including the ones published by the same instance, until the context is done
+type Backplane interface {
+	Publish(ctx context.Context, payload []byte) error
+	Subscribe(ctx context.Context, handler func(payload []byte)) error
+	Close() error
+	IsInterfaceNil() bool
+}
+
+// EventFilter defines the behaviour of an events filter which matches the events against
+// the subscriptions of the hub
+type EventFilter interface {
+	MatchEvent(subscription data.Subscription, event data.Event) bool
+	IsInterfaceNil() bool
 }
 
 // Dispatcher defines the behaviour of a dispatcher component which should be able to register
@@ -33,7 +65,8 @@ type Hub interface {
 type Dispatcher interface {
 	RegisterEvent(event EventDispatcher)
 	UnregisterEvent(event EventDispatcher)
-	Subscribe(event data.SubscribeEvent)
+	Subscribe(event data.SubscribeEvent) error
+	GetSubscriptions(dispatcherID uuid.UUID) []data.Subscription
 	IsInterfaceNil() bool
 }
 
@@ -52,6 +85,7 @@ type WSConnection interface {
 	SetReadLimit(limit int64)
 	SetReadDeadline(t time.Time) error
 	SetPongHandler(h func(appData string) error)
+	WriteControl(messageType int, data []byte, deadline time.Time) error
 	Close() error
 }
 
@@ -62,8 +96,29 @@ type WSUpgrader interface {
 
 // SubscriptionMapperHandler defines the behaviour of a subscription mapper
 type SubscriptionMapperHandler interface {
-	MatchSubscribeEvent(event data.SubscribeEvent)
+	MatchSubscribeEvent(event data.SubscribeEvent) error
 	RemoveSubscriptions(dispatcherID uuid.UUID)
 	Subscriptions() map[string][]data.Subscription
+	DispatcherSubscriptions(dispatcherID uuid.UUID) []data.Subscription
+	StartCleanup(ctx context.Context, isDispatcherActive func(dispatcherID uuid.UUID) bool)
+	IsInterfaceNil() bool
+}
+
+// SubscriptionsStore defines the behaviour of a component which persists the subscriptions
+// of the dispatchers, so that they are restored on restart
+type SubscriptionsStore interface {
+	SaveSubscriptions(dispatcherID uuid.UUID, subscriptions []data.Subscription) error
+	RemoveSubscriptions(dispatcherID uuid.UUID) error
+	LoadSubscriptions() (map[uuid.UUID][]data.Subscription, error)
+	Close() error
+	IsInterfaceNil() bool
+}
+
+// EventStore defines the behaviour of a component which persists the broadcast block events,
+// to be fetched by nonce
+type EventStore interface {
+	Save(events data.BlockEvents) error
+	GetFromNonce(nonce uint64) ([]data.BlockEvents, error)
+	Close() error
 	IsInterfaceNil() bool
 }
@@ -0,0 +1,128 @@
+package dispatcher
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/multiversx/mx-chain-notifier-go/common"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/storage"
+	"github.com/stretchr/testify/require"
+)
+
+func createSubscriptionsStore(t *testing.T, path string) SubscriptionsStore {
+	store, err := storage.NewSubscriptionsStore(storage.ArgsSubscriptionsStore{Path: path})
+	require.Nil(t, err)
+	t.Cleanup(func() {
+		_ = store.Close()
+	})
+
+	return store
+}
+
+// requireSameSubscriptions compares the creation times separately, since they lose the
+// monotonic clock reading and the location once stored
+func requireSameSubscriptions(t *testing.T, expected []data.Subscription, actual []data.Subscription) {
+	require.Equal(t, len(expected), len(actual))
+	for i := range expected {
+		require.True(t, expected[i].CreatedAt.Equal(actual[i].CreatedAt))
+
+		expectedSubscription, actualSubscription := expected[i], actual[i]
+		expectedSubscription.CreatedAt, actualSubscription.CreatedAt = actual[i].CreatedAt, actual[i].CreatedAt
+		require.Equal(t, expectedSubscription, actualSubscription)
+	}
+}
+
+func TestNewPersistentSubscriptionMapper(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil store", func(t *testing.T) {
+		t.Parallel()
+
+		subMap, err := NewPersistentSubscriptionMapper(ArgsSubscriptionMapper{}, nil)
+		require.Nil(t, subMap)
+		require.Equal(t, ErrNilSubscriptionsStore, err)
+	})
+
+	t.Run("invalid args", func(t *testing.T) {
+		t.Parallel()
+
+		subMap, err := NewPersistentSubscriptionMapper(ArgsSubscriptionMapper{MaxSubscriptionsPerDispatcher: -1}, createSubscriptionsStore(t, filepath.Join(t.TempDir(), "subscriptions")))
+		require.Nil(t, subMap)
+		require.True(t, errors.Is(err, ErrInvalidMaxSubscriptionsPerDispatcher))
+	})
+
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		subMap, err := NewPersistentSubscriptionMapper(ArgsSubscriptionMapper{}, createSubscriptionsStore(t, filepath.Join(t.TempDir(), "subscriptions")))
+		require.Nil(t, err)
+		require.False(t, subMap.IsInterfaceNil())
+		require.Equal(t, 0, len(subMap.Subscriptions()))
+	})
+}
+
+func TestPersistentSubscriptionMapper_RestoreSubscriptions(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "subscriptions")
+	dispatcherID1, dispatcherID2, dispatcherID3, dispatcherID4 := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	store := createSubscriptionsStore(t, path)
+	subMap, err := NewPersistentSubscriptionMapper(ArgsSubscriptionMapper{}, store)
+	require.Nil(t, err)
+
+	_ = subMap.MatchSubscribeEvent(data.SubscribeEvent{
+		DispatcherID:        dispatcherID1,
+		SubscriptionEntries: []data.SubscriptionEntry{{Address: "erd1", Identifiers: []string{"transfer", "swap"}}},
+		Resumable:           true,
+	})
+	_ = subMap.MatchSubscribeEvent(data.SubscribeEvent{
+		DispatcherID:        dispatcherID1,
+		SubscriptionEntries: []data.SubscriptionEntry{{EventType: common.FinalizedBlockEvents}},
+		Resumable:           true,
+	})
+	_ = subMap.MatchSubscribeEvent(data.SubscribeEvent{
+		DispatcherID:        dispatcherID2,
+		SubscriptionEntries: []data.SubscriptionEntry{{Address: "erd1*"}},
+		Resumable:           true,
+	})
+	_ = subMap.MatchSubscribeEvent(data.SubscribeEvent{DispatcherID: dispatcherID3, Resumable: true})
+	subMap.RemoveSubscriptions(dispatcherID3)
+	// the subscriptions of the dispatchers which are not resumable are not persisted
+	_ = subMap.MatchSubscribeEvent(data.SubscribeEvent{DispatcherID: dispatcherID4})
+	require.Equal(t, 1, len(subMap.DispatcherSubscriptions(dispatcherID4)))
+
+	expectedSubscriptions1 := subMap.DispatcherSubscriptions(dispatcherID1)
+	expectedSubscriptions2 := subMap.DispatcherSubscriptions(dispatcherID2)
+	require.Nil(t, store.Close())
+
+	store = createSubscriptionsStore(t, path)
+	restoredSubMap, err := NewPersistentSubscriptionMapper(ArgsSubscriptionMapper{}, store)
+	require.Nil(t, err)
+
+	requireSameSubscriptions(t, expectedSubscriptions1, restoredSubMap.DispatcherSubscriptions(dispatcherID1))
+	requireSameSubscriptions(t, expectedSubscriptions2, restoredSubMap.DispatcherSubscriptions(dispatcherID2))
+	require.Equal(t, 0, len(restoredSubMap.DispatcherSubscriptions(dispatcherID3)))
+	require.Equal(t, 0, len(restoredSubMap.DispatcherSubscriptions(dispatcherID4)))
+	require.Equal(t, 2, len(restoredSubMap.Subscriptions()[common.PushLogsAndEvents]))
+
+	// the ids of the new subscriptions follow the restored ones
+	_ = restoredSubMap.MatchSubscribeEvent(data.SubscribeEvent{DispatcherID: dispatcherID3})
+	require.Equal(t, uint64(4), restoredSubMap.DispatcherSubscriptions(dispatcherID3)[0].ID)
+}
+
+func TestPersistentSubscriptionMapper_StoreFailure(t *testing.T) {
+	t.Parallel()
+
+	store := createSubscriptionsStore(t, filepath.Join(t.TempDir(), "subscriptions"))
+	subMap, _ := NewPersistentSubscriptionMapper(ArgsSubscriptionMapper{}, store)
+	require.Nil(t, store.Close())
+
+	dispatcherID := uuid.New()
+	err := subMap.MatchSubscribeEvent(data.SubscribeEvent{DispatcherID: dispatcherID, Resumable: true})
+	require.NotNil(t, err)
+	require.Equal(t, 0, len(subMap.DispatcherSubscriptions(dispatcherID)))
+}
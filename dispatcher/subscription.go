@@ -1,10 +1,17 @@
 package dispatcher
 
 import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"path"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
 	logger "github.com/multiversx/mx-chain-logger-go"
 	"github.com/multiversx/mx-chain-notifier-go/common"
 	"github.com/multiversx/mx-chain-notifier-go/data"
@@ -30,59 +37,174 @@ const (
 )
 
 const (
-	erdTag = "erd"
+	erdTag       = "erd"
+	globWildcard = "*"
 )
 
+// ArgsSubscriptionMapper defines the arguments needed for subscription mapper creation
+type ArgsSubscriptionMapper struct {
+	// MaxSubscriptionsPerDispatcher limits the number of subscriptions of a dispatcher,
+	// 0 means no limit
+	MaxSubscriptionsPerDispatcher int
+
+	// SubscriptionTTL is the age after which the subscriptions of a dispatcher which is no
+	// longer active are removed by the cleanup, checked every TTL. 0 disables the cleanup
+	SubscriptionTTL time.Duration
+
+	// PubKeyConverter is optional. If set, the subscription addresses given as hex public keys
+	// are converted to the bech32 form of the pushed events, and the full length bech32
+	// addresses are validated. Otherwise, the addresses are used as provided
+	PubKeyConverter core.PubkeyConverter
+}
+
 // SubscriptionMapper defines a subscriptions manager component
 type SubscriptionMapper struct {
-	rwMut         sync.RWMutex
-	subscriptions map[uuid.UUID][]data.Subscription
+	rwMut                         sync.RWMutex
+	subscriptions                 map[uuid.UUID][]data.Subscription
+	maxSubscriptionsPerDispatcher int
+	subscriptionTTL               time.Duration
+	lastSubscriptionID            uint64
+	store                         SubscriptionsStore
+	persistedDispatchers          map[uuid.UUID]struct{}
+	pubKeyConverter               core.PubkeyConverter
+	bech32Prefix                  string
+	bech32Length                  int
 }
 
 // NewSubscriptionMapper initializes an empty map for subscriptions
-func NewSubscriptionMapper() *SubscriptionMapper {
-	return &SubscriptionMapper{
-		rwMut:         sync.RWMutex{},
-		subscriptions: make(map[uuid.UUID][]data.Subscription),
+func NewSubscriptionMapper(args ArgsSubscriptionMapper) (*SubscriptionMapper, error) {
+	if args.MaxSubscriptionsPerDispatcher < 0 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidMaxSubscriptionsPerDispatcher, args.MaxSubscriptionsPerDispatcher)
+	}
+	if args.SubscriptionTTL < 0 {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSubscriptionTTL, args.SubscriptionTTL)
+	}
+
+	sm := &SubscriptionMapper{
+		rwMut:                         sync.RWMutex{},
+		subscriptions:                 make(map[uuid.UUID][]data.Subscription),
+		persistedDispatchers:          make(map[uuid.UUID]struct{}),
+		maxSubscriptionsPerDispatcher: args.MaxSubscriptionsPerDispatcher,
+		subscriptionTTL:               args.SubscriptionTTL,
+	}
+	if !check.IfNil(args.PubKeyConverter) {
+		// the human readable part and the length are taken from an encoded zero address,
+		// since the converter does not expose them
+		zeroAddress := args.PubKeyConverter.SilentEncode(make([]byte, args.PubKeyConverter.Len()), log)
+		sm.pubKeyConverter = args.PubKeyConverter
+		sm.bech32Prefix = zeroAddress[:strings.LastIndex(zeroAddress, "1")+1]
+		sm.bech32Length = len(zeroAddress)
+	}
+
+	return sm, nil
+}
+
+// NewPersistentSubscriptionMapper creates a subscription mapper which writes the subscriptions
+// of the resumable dispatchers through to the store and restores the stored ones. The restored
+// subscriptions are matched once a dispatcher with the same resume id is registered to the hub
+func NewPersistentSubscriptionMapper(args ArgsSubscriptionMapper, store SubscriptionsStore) (*SubscriptionMapper, error) {
+	if check.IfNil(store) {
+		return nil, ErrNilSubscriptionsStore
+	}
+
+	sm, err := NewSubscriptionMapper(args)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptions, err := store.LoadSubscriptions()
+	if err != nil {
+		return nil, err
 	}
+
+	numSubscriptions := 0
+	for dispatcherID, dispatcherSubscriptions := range subscriptions {
+		sm.subscriptions[dispatcherID] = dispatcherSubscriptions
+		sm.persistedDispatchers[dispatcherID] = struct{}{}
+		numSubscriptions += len(dispatcherSubscriptions)
+		for _, subscription := range dispatcherSubscriptions {
+			if subscription.ID > sm.lastSubscriptionID {
+				sm.lastSubscriptionID = subscription.ID
+			}
+		}
+	}
+	sm.store = store
+
+	log.Info("restored subscriptions", "num dispatchers", len(subscriptions), "num subscriptions", numSubscriptions)
+
+	return sm, nil
 }
 
 // MatchSubscribeEvent creates a subscription entry in the subscriptions map
 // It assigns each SubscribeEvent a match level from the input provided
-func (sm *SubscriptionMapper) MatchSubscribeEvent(event data.SubscribeEvent) {
+// If the new subscriptions would exceed the limit of the dispatcher, none of them is added
+// The addresses containing "*" wildcards are kept as glob patterns, which have to be valid
+func (sm *SubscriptionMapper) MatchSubscribeEvent(event data.SubscribeEvent) error {
+	createdAt := time.Now()
 	if event.SubscriptionEntries == nil || len(event.SubscriptionEntries) == 0 {
-		sm.appendSubscription(data.Subscription{
-			DispatcherID: event.DispatcherID,
-			MatchLevel:   MatchAll,
-			EventType:    common.PushLogsAndEvents,
+		err := sm.appendSubscriptions(event.DispatcherID, event.Resumable, []data.Subscription{
+			{
+				DispatcherID: event.DispatcherID,
+				MatchLevel:   MatchAll,
+				EventType:    common.PushLogsAndEvents,
+				CreatedAt:    createdAt,
+			},
 		})
+		if err != nil {
+			return err
+		}
+
 		log.Info("subscribed dispatcher",
 			"dispatcherID", event.DispatcherID,
 			"match level", MatchAll,
 		)
-		return
+		return nil
 	}
 
+	subscriptions := make([]data.Subscription, 0, len(event.SubscriptionEntries))
 	for _, subEntry := range event.SubscriptionEntries {
-		matchLevel := sm.matchLevelFromInput(subEntry)
-		eventType := getEventType(subEntry)
-		subscription := data.Subscription{
+		isGlob := isGlobPattern(subEntry.Address)
+		if isGlob {
+			_, err := path.Match(subEntry.Address, "")
+			if err != nil {
+				return fmt.Errorf("%w: %s", ErrInvalidGlobPattern, subEntry.Address)
+			}
+		} else {
+			address, err := sm.normalizeAddress(subEntry.Address)
+			if err != nil {
+				return err
+			}
+			subEntry.Address = address
+		}
+
+		subscriptions = append(subscriptions, data.Subscription{
 			Address:      subEntry.Address,
 			Identifier:   subEntry.Identifier,
+			Identifiers:  subEntry.Identifiers,
 			Topics:       subEntry.Topics,
 			DispatcherID: event.DispatcherID,
-			MatchLevel:   matchLevel,
-			EventType:    eventType,
-		}
-		sm.appendSubscription(subscription)
+			MatchLevel:   sm.matchLevelFromInput(subEntry),
+			EventType:    GetEventType(subEntry),
+			CreatedAt:    createdAt,
+			IsGlob:       isGlob,
+		})
+	}
 
+	err := sm.appendSubscriptions(event.DispatcherID, event.Resumable, subscriptions)
+	if err != nil {
+		return err
+	}
+
+	for _, subscription := range subscriptions {
 		log.Info("added new subscription for dispatcher",
 			"dispatcherID", event.DispatcherID,
-			"match level", matchLevel,
+			"match level", subscription.MatchLevel,
 		)
 	}
 
 	log.Info("subscribed dispatcher", "dispatcherID", event.DispatcherID)
+
+	return nil
 }
 
 // RemoveSubscriptions removes all subscriptions registered by a dispatcher
@@ -92,6 +214,7 @@ func (sm *SubscriptionMapper) RemoveSubscriptions(dispatcherID uuid.UUID) {
 
 	if _, ok := sm.subscriptions[dispatcherID]; ok {
 		delete(sm.subscriptions, dispatcherID)
+		sm.removeStoredSubscriptions(dispatcherID)
 	}
 
 	log.Info("unsubscribed dispatcher", "dispatcherID", dispatcherID)
@@ -112,9 +235,125 @@ func (sm *SubscriptionMapper) Subscriptions() map[string][]data.Subscription {
 	return subscriptions
 }
 
+// DispatcherSubscriptions returns a copy of the subscriptions of a dispatcher, in the order
+// they were added
+func (sm *SubscriptionMapper) DispatcherSubscriptions(dispatcherID uuid.UUID) []data.Subscription {
+	sm.rwMut.RLock()
+	defer sm.rwMut.RUnlock()
+
+	subscriptions := make([]data.Subscription, len(sm.subscriptions[dispatcherID]))
+	copy(subscriptions, sm.subscriptions[dispatcherID])
+
+	return subscriptions
+}
+
+// StartCleanup starts the goroutine which periodically removes the subscriptions older than
+// the TTL of the dispatchers reported as not active, until the context is done. It does
+// nothing if the TTL is not set
+func (sm *SubscriptionMapper) StartCleanup(ctx context.Context, isDispatcherActive func(dispatcherID uuid.UUID) bool) {
+	if sm.subscriptionTTL == 0 {
+		return
+	}
+
+	go sm.cleanupLoop(ctx, isDispatcherActive)
+}
+
+func (sm *SubscriptionMapper) cleanupLoop(ctx context.Context, isDispatcherActive func(dispatcherID uuid.UUID) bool) {
+	ticker := time.NewTicker(sm.subscriptionTTL)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			sm.removeExpiredSubscriptions(isDispatcherActive)
+		}
+	}
+}
+
+// removeExpiredSubscriptions checks the dispatchers outside the subscriptions lock, since the
+// hub holds its dispatchers lock while subscribing
+func (sm *SubscriptionMapper) removeExpiredSubscriptions(isDispatcherActive func(dispatcherID uuid.UUID) bool) {
+	expiredBefore := time.Now().Add(-sm.subscriptionTTL)
+
+	inactiveDispatchers := make([]uuid.UUID, 0)
+	for _, dispatcherID := range sm.getDispatchersWithSubscriptionsBefore(expiredBefore) {
+		if !isDispatcherActive(dispatcherID) {
+			inactiveDispatchers = append(inactiveDispatchers, dispatcherID)
+		}
+	}
+	if len(inactiveDispatchers) == 0 {
+		return
+	}
+
+	sm.rwMut.Lock()
+	defer sm.rwMut.Unlock()
+
+	for _, dispatcherID := range inactiveDispatchers {
+		remaining := make([]data.Subscription, 0)
+		for _, subscription := range sm.subscriptions[dispatcherID] {
+			if subscription.CreatedAt.After(expiredBefore) {
+				remaining = append(remaining, subscription)
+			}
+		}
+
+		numRemoved := len(sm.subscriptions[dispatcherID]) - len(remaining)
+		if len(remaining) == 0 {
+			delete(sm.subscriptions, dispatcherID)
+			sm.removeStoredSubscriptions(dispatcherID)
+		} else {
+			sm.subscriptions[dispatcherID] = remaining
+			sm.saveStoredSubscriptions(dispatcherID, remaining)
+		}
+
+		log.Info("removed expired subscriptions", "dispatcherID", dispatcherID, "num removed", numRemoved)
+	}
+}
+
+func (sm *SubscriptionMapper) getDispatchersWithSubscriptionsBefore(expiredBefore time.Time) []uuid.UUID {
+	sm.rwMut.RLock()
+	defer sm.rwMut.RUnlock()
+
+	dispatcherIDs := make([]uuid.UUID, 0)
+	for dispatcherID, subscriptions := range sm.subscriptions {
+		for _, subscription := range subscriptions {
+			if !subscription.CreatedAt.After(expiredBefore) {
+				dispatcherIDs = append(dispatcherIDs, dispatcherID)
+				break
+			}
+		}
+	}
+
+	return dispatcherIDs
+}
+
+// normalizeAddress converts a hex public key to its bech32 form, so that it matches the
+// addresses of the pushed events, and rejects the bech32 addresses with the full length
+// which can not be decoded. The other addresses, such as partial ones, are kept as they are
+func (sm *SubscriptionMapper) normalizeAddress(address string) (string, error) {
+	if check.IfNil(sm.pubKeyConverter) || address == "" {
+		return address, nil
+	}
+
+	pubKey, err := hex.DecodeString(address)
+	if err == nil && len(pubKey) == sm.pubKeyConverter.Len() {
+		return sm.pubKeyConverter.Encode(pubKey)
+	}
+
+	if strings.HasPrefix(address, sm.bech32Prefix) && len(address) == sm.bech32Length {
+		_, err = sm.pubKeyConverter.Decode(address)
+		if err != nil {
+			return "", fmt.Errorf("%w: %s", ErrInvalidSubscriptionAddress, address)
+		}
+	}
+
+	return address, nil
+}
+
 func (sm *SubscriptionMapper) matchLevelFromInput(subEntry data.SubscriptionEntry) string {
-	hasAddress := subEntry.Address != "" && strings.Contains(subEntry.Address, erdTag)
-	hasIdentifier := subEntry.Identifier != ""
+	hasAddress := subEntry.Address != "" && (strings.Contains(subEntry.Address, erdTag) || isGlobPattern(subEntry.Address))
+	hasIdentifier := subEntry.Identifier != "" || len(subEntry.Identifiers) > 0
 	hasTopics := len(subEntry.Topics) > 0
 
 	if hasAddress && hasIdentifier && hasTopics {
@@ -133,18 +372,76 @@ func (sm *SubscriptionMapper) matchLevelFromInput(subEntry data.SubscriptionEntr
 	return MatchAll
 }
 
-func (sm *SubscriptionMapper) appendSubscription(sub data.Subscription) {
+func (sm *SubscriptionMapper) appendSubscriptions(dispatcherID uuid.UUID, isResumable bool, subs []data.Subscription) error {
 	sm.rwMut.Lock()
 	defer sm.rwMut.Unlock()
 
-	sm.subscriptions[sub.DispatcherID] = append(sm.subscriptions[sub.DispatcherID], sub)
+	numSubscriptions := len(sm.subscriptions[dispatcherID]) + len(subs)
+	if sm.maxSubscriptionsPerDispatcher > 0 && numSubscriptions > sm.maxSubscriptionsPerDispatcher {
+		return fmt.Errorf("%w: dispatcher %s, limit %d", ErrMaxSubscriptionsReached, dispatcherID, sm.maxSubscriptionsPerDispatcher)
+	}
+
+	for index := range subs {
+		sm.lastSubscriptionID++
+		subs[index].ID = sm.lastSubscriptionID
+	}
+
+	// the subscriptions are written through before being added, so that they are only
+	// matched if they will be restored as well
+	updated := make([]data.Subscription, 0, numSubscriptions)
+	updated = append(updated, sm.subscriptions[dispatcherID]...)
+	updated = append(updated, subs...)
+	if sm.store != nil && isResumable {
+		err := sm.store.SaveSubscriptions(dispatcherID, updated)
+		if err != nil {
+			return fmt.Errorf("%w while storing the subscriptions of dispatcher %s", err, dispatcherID)
+		}
+		sm.persistedDispatchers[dispatcherID] = struct{}{}
+	}
+	sm.subscriptions[dispatcherID] = updated
+
+	return nil
+}
+
+// saveStoredSubscriptions only logs the errors, the removals are applied in memory anyway
+func (sm *SubscriptionMapper) saveStoredSubscriptions(dispatcherID uuid.UUID, subs []data.Subscription) {
+	if _, ok := sm.persistedDispatchers[dispatcherID]; !ok {
+		return
+	}
+
+	err := sm.store.SaveSubscriptions(dispatcherID, subs)
+	if err != nil {
+		log.Warn("could not store the subscriptions", "dispatcherID", dispatcherID, "err", err.Error())
+	}
+}
+
+func (sm *SubscriptionMapper) removeStoredSubscriptions(dispatcherID uuid.UUID) {
+	if _, ok := sm.persistedDispatchers[dispatcherID]; !ok {
+		return
+	}
+	delete(sm.persistedDispatchers, dispatcherID)
+
+	err := sm.store.RemoveSubscriptions(dispatcherID)
+	if err != nil {
+		log.Warn("could not remove the stored subscriptions", "dispatcherID", dispatcherID, "err", err.Error())
+	}
+}
+
+func isGlobPattern(address string) bool {
+	return strings.Contains(address, globWildcard)
 }
 
-func getEventType(subEntry data.SubscriptionEntry) string {
+// GetEventType returns the event type of the subscription entry, the unknown event types
+// being handled as logs and events
+func GetEventType(subEntry data.SubscriptionEntry) string {
 	if subEntry.EventType == common.FinalizedBlockEvents ||
 		subEntry.EventType == common.RevertBlockEvents ||
 		subEntry.EventType == common.BlockTxs ||
 		subEntry.EventType == common.BlockScrs ||
+		subEntry.EventType == common.TxEvents ||
+		subEntry.EventType == common.RoundEvents ||
+		subEntry.EventType == common.ValidatorsRatingEvents ||
+		subEntry.EventType == common.AccountEvents ||
 		subEntry.EventType == common.BlockEvents {
 		return subEntry.EventType
 	}
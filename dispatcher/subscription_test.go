@@ -1,6 +1,10 @@
 package dispatcher
 
 import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"math/rand"
 	"reflect"
@@ -8,15 +12,44 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/multiversx/mx-chain-core-go/core/pubkeyConverter"
 	"github.com/multiversx/mx-chain-notifier-go/common"
 	"github.com/multiversx/mx-chain-notifier-go/data"
 	"github.com/stretchr/testify/require"
 )
 
+func TestNewSubscriptionMapper(t *testing.T) {
+	t.Parallel()
+
+	t.Run("negative max subscriptions per dispatcher", func(t *testing.T) {
+		t.Parallel()
+
+		subMap, err := NewSubscriptionMapper(ArgsSubscriptionMapper{MaxSubscriptionsPerDispatcher: -1})
+		require.Nil(t, subMap)
+		require.True(t, errors.Is(err, ErrInvalidMaxSubscriptionsPerDispatcher))
+	})
+
+	t.Run("negative subscription ttl", func(t *testing.T) {
+		t.Parallel()
+
+		subMap, err := NewSubscriptionMapper(ArgsSubscriptionMapper{SubscriptionTTL: -time.Second})
+		require.Nil(t, subMap)
+		require.True(t, errors.Is(err, ErrInvalidSubscriptionTTL))
+	})
+
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		subMap, err := NewSubscriptionMapper(ArgsSubscriptionMapper{MaxSubscriptionsPerDispatcher: 10})
+		require.Nil(t, err)
+		require.False(t, subMap.IsInterfaceNil())
+	})
+}
+
 func TestSubscriptionMap_Subscriptions(t *testing.T) {
 	t.Parallel()
 
-	subMap := NewSubscriptionMapper()
+	subMap, _ := NewSubscriptionMapper(ArgsSubscriptionMapper{})
 
 	subEvents := generateSubscribeEvents(10)
 
@@ -34,7 +67,7 @@ func TestSubscriptionsMap_ShouldMatchAllForEmptySubscriptionEntry(t *testing.T)
 
 	entry := data.SubscriptionEntry{}
 
-	subMap := NewSubscriptionMapper()
+	subMap, _ := NewSubscriptionMapper(ArgsSubscriptionMapper{})
 
 	require.True(t, subMap.matchLevelFromInput(entry) == MatchAll)
 }
@@ -44,7 +77,7 @@ func TestSubscriptionMapper_MatchSubscribeEventResultsInCorrectSet(t *testing.T)
 
 	subEvents := generateSubscribeEvents(10)
 
-	subMap := NewSubscriptionMapper()
+	subMap, _ := NewSubscriptionMapper(ArgsSubscriptionMapper{})
 
 	for _, subEvent := range subEvents {
 		subMap.MatchSubscribeEvent(subEvent)
@@ -75,8 +108,15 @@ func TestSubscriptionMapper_MatchSubscribeEventResultsInCorrectSet(t *testing.T)
 
 	require.True(t, len(subsFromMap[common.PushLogsAndEvents]) == len(subsFromEntries))
 
+	ids := make(map[uint64]struct{})
 	for _, subs := range subsFromMap {
 		for _, sub1 := range subs {
+			require.False(t, sub1.CreatedAt.IsZero())
+			sub1.CreatedAt = time.Time{}
+			require.NotZero(t, sub1.ID)
+			ids[sub1.ID] = struct{}{}
+			sub1.ID = 0
+
 			found := false
 			for _, sub2 := range subsFromEntries {
 				if reflect.DeepEqual(sub1, sub2) {
@@ -86,6 +126,7 @@ func TestSubscriptionMapper_MatchSubscribeEventResultsInCorrectSet(t *testing.T)
 			require.True(t, found)
 		}
 	}
+	require.Equal(t, len(subsFromEntries), len(ids))
 }
 
 func TestSubscriptionMap_MatchSubscribeEventCorrectMatchLevel(t *testing.T) {
@@ -120,7 +161,7 @@ func TestSubscriptionMap_MatchSubscribeEventCorrectMatchLevel(t *testing.T) {
 		DispatcherID: dispatcherId,
 	}
 
-	subMap := NewSubscriptionMapper()
+	subMap, _ := NewSubscriptionMapper(ArgsSubscriptionMapper{})
 	subMap.MatchSubscribeEvent(subEvent)
 
 	subs := subMap.Subscriptions()
@@ -138,12 +179,153 @@ func TestSubscriptionMap_MatchSubscribeEventCorrectMatchLevel(t *testing.T) {
 	require.True(t, subs[common.BlockTxs][1].MatchLevel == MatchTopics)
 }
 
+func TestSubscriptionMapper_MatchSubscribeEventIdentifiers(t *testing.T) {
+	t.Parallel()
+
+	subMap, _ := NewSubscriptionMapper(ArgsSubscriptionMapper{})
+	err := subMap.MatchSubscribeEvent(data.SubscribeEvent{
+		DispatcherID: uuid.New(),
+		SubscriptionEntries: []data.SubscriptionEntry{
+			{Address: "erd111", Identifiers: []string{"deposit", "withdraw"}},
+			{Identifiers: []string{"ESDTTransfer"}},
+		},
+	})
+	require.Nil(t, err)
+
+	subs := subMap.Subscriptions()[common.PushLogsAndEvents]
+	require.Len(t, subs, 2)
+
+	require.Equal(t, MatchAddressIdentifier, subs[0].MatchLevel)
+	require.Equal(t, []string{"deposit", "withdraw"}, subs[0].Identifiers)
+
+	require.Equal(t, MatchIdentifier, subs[1].MatchLevel)
+	require.Equal(t, []string{"ESDTTransfer"}, subs[1].Identifiers)
+}
+
+func TestSubscriptionMapper_MatchSubscribeEventGlobAddress(t *testing.T) {
+	t.Parallel()
+
+	t.Run("addresses with wildcards should be kept as glob patterns", func(t *testing.T) {
+		t.Parallel()
+
+		subMap, _ := NewSubscriptionMapper(ArgsSubscriptionMapper{})
+		err := subMap.MatchSubscribeEvent(data.SubscribeEvent{
+			DispatcherID: uuid.New(),
+			SubscriptionEntries: []data.SubscriptionEntry{
+				{Address: "erd1*staking*"},
+				{Address: "*staking*", Identifier: "delegate"},
+				{Address: "erd111"},
+			},
+		})
+		require.Nil(t, err)
+
+		subs := subMap.Subscriptions()[common.PushLogsAndEvents]
+		require.Len(t, subs, 3)
+
+		require.True(t, subs[0].IsGlob)
+		require.Equal(t, MatchAddress, subs[0].MatchLevel)
+
+		require.True(t, subs[1].IsGlob)
+		require.Equal(t, MatchAddressIdentifier, subs[1].MatchLevel)
+
+		require.False(t, subs[2].IsGlob)
+		require.Equal(t, MatchAddress, subs[2].MatchLevel)
+	})
+
+	t.Run("invalid glob pattern should not add any subscription", func(t *testing.T) {
+		t.Parallel()
+
+		subMap, _ := NewSubscriptionMapper(ArgsSubscriptionMapper{})
+		err := subMap.MatchSubscribeEvent(data.SubscribeEvent{
+			DispatcherID: uuid.New(),
+			SubscriptionEntries: []data.SubscriptionEntry{
+				{Address: "erd111"},
+				{Address: "erd1[*"},
+			},
+		})
+		require.True(t, errors.Is(err, ErrInvalidGlobPattern))
+		require.Empty(t, subMap.Subscriptions())
+	})
+}
+
+func TestSubscriptionMapper_MatchSubscribeEventAddressForms(t *testing.T) {
+	t.Parallel()
+
+	pubKey := bytes.Repeat([]byte{1}, 32)
+	createSubscriptionMapper := func() *SubscriptionMapper {
+		converter, _ := pubkeyConverter.NewBech32PubkeyConverter(32, "erd")
+		subMap, _ := NewSubscriptionMapper(ArgsSubscriptionMapper{PubKeyConverter: converter})
+
+		return subMap
+	}
+
+	t.Run("hex and bech32 addresses should be stored in the bech32 form", func(t *testing.T) {
+		t.Parallel()
+
+		subMap := createSubscriptionMapper()
+		bech32Address := subMap.pubKeyConverter.SilentEncode(pubKey, log)
+
+		err := subMap.MatchSubscribeEvent(data.SubscribeEvent{
+			DispatcherID: uuid.New(),
+			SubscriptionEntries: []data.SubscriptionEntry{
+				{Address: hex.EncodeToString(pubKey)},
+				{Address: bech32Address, Identifier: "transfer"},
+				{Address: "erd1qqq"},
+				{Address: "erd1*"},
+			},
+		})
+		require.Nil(t, err)
+
+		subs := subMap.Subscriptions()[common.PushLogsAndEvents]
+		require.Len(t, subs, 4)
+		require.Equal(t, bech32Address, subs[0].Address)
+		require.Equal(t, MatchAddress, subs[0].MatchLevel)
+		require.Equal(t, bech32Address, subs[1].Address)
+		require.Equal(t, MatchAddressIdentifier, subs[1].MatchLevel)
+		require.Equal(t, "erd1qqq", subs[2].Address)
+		require.Equal(t, "erd1*", subs[3].Address)
+	})
+
+	t.Run("invalid bech32 address should not add any subscription", func(t *testing.T) {
+		t.Parallel()
+
+		subMap := createSubscriptionMapper()
+		bech32Address := subMap.pubKeyConverter.SilentEncode(pubKey, log)
+		invalidAddress := bech32Address[:len(bech32Address)-1] + "x"
+		if invalidAddress == bech32Address {
+			invalidAddress = bech32Address[:len(bech32Address)-1] + "y"
+		}
+
+		err := subMap.MatchSubscribeEvent(data.SubscribeEvent{
+			DispatcherID: uuid.New(),
+			SubscriptionEntries: []data.SubscriptionEntry{
+				{Address: bech32Address},
+				{Address: invalidAddress},
+			},
+		})
+		require.True(t, errors.Is(err, ErrInvalidSubscriptionAddress))
+		require.Empty(t, subMap.Subscriptions())
+	})
+
+	t.Run("without converter the addresses should be kept as provided", func(t *testing.T) {
+		t.Parallel()
+
+		subMap, _ := NewSubscriptionMapper(ArgsSubscriptionMapper{})
+		err := subMap.MatchSubscribeEvent(data.SubscribeEvent{
+			DispatcherID:        uuid.New(),
+			SubscriptionEntries: []data.SubscriptionEntry{{Address: hex.EncodeToString(pubKey)}},
+		})
+		require.Nil(t, err)
+		require.Equal(t, hex.EncodeToString(pubKey), subMap.Subscriptions()[common.PushLogsAndEvents][0].Address)
+	})
+}
+
 func TestSubscriptionMapper_RemoveSubscriptions(t *testing.T) {
 	t.Parallel()
 
 	subEvents := generateSubscribeEvents(10)
 
-	subMap := NewSubscriptionMapper()
+	subMap, _ := NewSubscriptionMapper(ArgsSubscriptionMapper{})
 
 	for _, subEvent := range subEvents {
 		subMap.MatchSubscribeEvent(subEvent)
@@ -164,6 +346,201 @@ func TestSubscriptionMapper_RemoveSubscriptions(t *testing.T) {
 	}
 }
 
+func TestSubscriptionMapper_DispatcherSubscriptions(t *testing.T) {
+	t.Parallel()
+
+	subMap, _ := NewSubscriptionMapper(ArgsSubscriptionMapper{})
+
+	dispatcherID := uuid.New()
+	_ = subMap.MatchSubscribeEvent(data.SubscribeEvent{
+		DispatcherID:        dispatcherID,
+		SubscriptionEntries: []data.SubscriptionEntry{{Address: "erd1"}},
+	})
+	_ = subMap.MatchSubscribeEvent(data.SubscribeEvent{
+		DispatcherID:        dispatcherID,
+		SubscriptionEntries: []data.SubscriptionEntry{{EventType: common.FinalizedBlockEvents}},
+	})
+	_ = subMap.MatchSubscribeEvent(data.SubscribeEvent{DispatcherID: uuid.New()})
+
+	subscriptions := subMap.DispatcherSubscriptions(dispatcherID)
+	require.Equal(t, 2, len(subscriptions))
+	require.Equal(t, MatchAddress, subscriptions[0].MatchLevel)
+	require.Equal(t, common.FinalizedBlockEvents, subscriptions[1].EventType)
+
+	// the returned slice is a copy
+	subscriptions[0].Address = "erd2"
+	require.Equal(t, "erd1", subMap.DispatcherSubscriptions(dispatcherID)[0].Address)
+
+	require.Equal(t, 0, len(subMap.DispatcherSubscriptions(uuid.New())))
+}
+
+func TestSubscriptionMapper_StartCleanup(t *testing.T) {
+	t.Parallel()
+
+	subscriptionTTL := time.Millisecond * 50
+
+	t.Run("expired subscriptions of inactive dispatchers should be removed", func(t *testing.T) {
+		t.Parallel()
+
+		subMap, _ := NewSubscriptionMapper(ArgsSubscriptionMapper{SubscriptionTTL: subscriptionTTL})
+		activeDispatcherID := uuid.New()
+		inactiveDispatcherID := uuid.New()
+		_ = subMap.MatchSubscribeEvent(data.SubscribeEvent{DispatcherID: activeDispatcherID})
+		_ = subMap.MatchSubscribeEvent(data.SubscribeEvent{DispatcherID: inactiveDispatcherID})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		subMap.StartCleanup(ctx, func(dispatcherID uuid.UUID) bool {
+			return dispatcherID == activeDispatcherID
+		})
+
+		time.Sleep(subscriptionTTL * 4)
+
+		subscriptions := subMap.Subscriptions()[common.PushLogsAndEvents]
+		require.Equal(t, 1, len(subscriptions))
+		require.Equal(t, activeDispatcherID, subscriptions[0].DispatcherID)
+	})
+
+	t.Run("recent subscriptions of inactive dispatchers should be kept", func(t *testing.T) {
+		t.Parallel()
+
+		subMap, _ := NewSubscriptionMapper(ArgsSubscriptionMapper{SubscriptionTTL: subscriptionTTL})
+		dispatcherID := uuid.New()
+		_ = subMap.MatchSubscribeEvent(data.SubscribeEvent{DispatcherID: dispatcherID})
+		createdAt := subMap.Subscriptions()[common.PushLogsAndEvents][0].CreatedAt
+
+		subMap.rwMut.Lock()
+		subMap.subscriptions[dispatcherID] = append(subMap.subscriptions[dispatcherID], data.Subscription{
+			DispatcherID: dispatcherID,
+			MatchLevel:   MatchAll,
+			EventType:    common.FinalizedBlockEvents,
+			CreatedAt:    createdAt.Add(time.Hour),
+		})
+		subMap.rwMut.Unlock()
+
+		subMap.removeExpiredSubscriptions(func(dispatcherID uuid.UUID) bool {
+			return false
+		})
+		require.Equal(t, 2, len(subMap.Subscriptions()))
+
+		subMap.rwMut.Lock()
+		subMap.subscriptions[dispatcherID][0].CreatedAt = createdAt.Add(-time.Hour)
+		subMap.rwMut.Unlock()
+
+		subMap.removeExpiredSubscriptions(func(dispatcherID uuid.UUID) bool {
+			return false
+		})
+		subscriptions := subMap.Subscriptions()
+		require.Equal(t, 0, len(subscriptions[common.PushLogsAndEvents]))
+		require.Equal(t, 1, len(subscriptions[common.FinalizedBlockEvents]))
+	})
+
+	t.Run("cleanup should stop when the context is done", func(t *testing.T) {
+		t.Parallel()
+
+		subMap, _ := NewSubscriptionMapper(ArgsSubscriptionMapper{SubscriptionTTL: subscriptionTTL})
+		_ = subMap.MatchSubscribeEvent(data.SubscribeEvent{DispatcherID: uuid.New()})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		subMap.StartCleanup(ctx, func(dispatcherID uuid.UUID) bool {
+			return false
+		})
+		cancel()
+
+		time.Sleep(subscriptionTTL * 4)
+
+		require.Equal(t, 1, len(subMap.Subscriptions()[common.PushLogsAndEvents]))
+	})
+}
+
+func TestSubscriptionMapper_MaxSubscriptionsPerDispatcher(t *testing.T) {
+	t.Parallel()
+
+	createSubscribeEvent := func(dispatcherID uuid.UUID, numEntries int) data.SubscribeEvent {
+		entries := make([]data.SubscriptionEntry, 0, numEntries)
+		for i := 0; i < numEntries; i++ {
+			entries = append(entries, data.SubscriptionEntry{
+				Address: fmt.Sprintf("erd%d", i),
+			})
+		}
+
+		return data.SubscribeEvent{
+			DispatcherID:        dispatcherID,
+			SubscriptionEntries: entries,
+		}
+	}
+
+	t.Run("subscriptions up to the limit should be added", func(t *testing.T) {
+		t.Parallel()
+
+		subMap, _ := NewSubscriptionMapper(ArgsSubscriptionMapper{MaxSubscriptionsPerDispatcher: 3})
+		dispatcherID := uuid.New()
+
+		err := subMap.MatchSubscribeEvent(createSubscribeEvent(dispatcherID, 2))
+		require.Nil(t, err)
+		err = subMap.MatchSubscribeEvent(createSubscribeEvent(dispatcherID, 1))
+		require.Nil(t, err)
+
+		require.Equal(t, 3, len(subMap.Subscriptions()[common.PushLogsAndEvents]))
+	})
+
+	t.Run("subscriptions exceeding the limit should not be added", func(t *testing.T) {
+		t.Parallel()
+
+		subMap, _ := NewSubscriptionMapper(ArgsSubscriptionMapper{MaxSubscriptionsPerDispatcher: 3})
+		dispatcherID := uuid.New()
+
+		err := subMap.MatchSubscribeEvent(createSubscribeEvent(dispatcherID, 3))
+		require.Nil(t, err)
+		existingSubscriptions := subMap.Subscriptions()
+
+		err = subMap.MatchSubscribeEvent(createSubscribeEvent(dispatcherID, 1))
+		require.True(t, errors.Is(err, ErrMaxSubscriptionsReached))
+		err = subMap.MatchSubscribeEvent(data.SubscribeEvent{DispatcherID: dispatcherID})
+		require.True(t, errors.Is(err, ErrMaxSubscriptionsReached))
+
+		require.Equal(t, existingSubscriptions, subMap.Subscriptions())
+	})
+
+	t.Run("subscribe event exceeding the limit should not add any subscription", func(t *testing.T) {
+		t.Parallel()
+
+		subMap, _ := NewSubscriptionMapper(ArgsSubscriptionMapper{MaxSubscriptionsPerDispatcher: 3})
+		dispatcherID := uuid.New()
+
+		err := subMap.MatchSubscribeEvent(createSubscribeEvent(dispatcherID, 2))
+		require.Nil(t, err)
+
+		err = subMap.MatchSubscribeEvent(createSubscribeEvent(dispatcherID, 2))
+		require.True(t, errors.Is(err, ErrMaxSubscriptionsReached))
+		require.Equal(t, 2, len(subMap.Subscriptions()[common.PushLogsAndEvents]))
+	})
+
+	t.Run("limit should apply per dispatcher", func(t *testing.T) {
+		t.Parallel()
+
+		subMap, _ := NewSubscriptionMapper(ArgsSubscriptionMapper{MaxSubscriptionsPerDispatcher: 1})
+
+		err := subMap.MatchSubscribeEvent(createSubscribeEvent(uuid.New(), 1))
+		require.Nil(t, err)
+		err = subMap.MatchSubscribeEvent(createSubscribeEvent(uuid.New(), 1))
+		require.Nil(t, err)
+
+		require.Equal(t, 2, len(subMap.Subscriptions()[common.PushLogsAndEvents]))
+	})
+
+	t.Run("zero limit should not limit the subscriptions", func(t *testing.T) {
+		t.Parallel()
+
+		subMap, _ := NewSubscriptionMapper(ArgsSubscriptionMapper{})
+		dispatcherID := uuid.New()
+
+		err := subMap.MatchSubscribeEvent(createSubscribeEvent(dispatcherID, 1000))
+		require.Nil(t, err)
+		require.Equal(t, 1000, len(subMap.Subscriptions()[common.PushLogsAndEvents]))
+	})
+}
+
 func generateSubscribeEvents(num int) []data.SubscribeEvent {
 	var randSeed = rand.New(rand.NewSource(time.Now().UnixNano()))
 
@@ -10,3 +10,20 @@ var ErrNilWSUpgrader = errors.New("nil websocket upgrader")
 
 // ErrNilWSConn signals that a nil websocket connection has been provided
 var ErrNilWSConn = errors.New("nil ws connection")
+
+// ErrInvalidBreakOnErrorCount signals that a negative break on error count has been provided
+var ErrInvalidBreakOnErrorCount = errors.New("invalid break on error count")
+
+// ErrUnsupportedEncoding signals that the encoding requested on subscribe is not supported
+var ErrUnsupportedEncoding = errors.New("unsupported encoding")
+
+// ErrNoSubscriptionCriteria signals that a subscribe message matches all the logs and events
+// without the subscribe to all flag being set
+var ErrNoSubscriptionCriteria = errors.New("no address, identifier or topics set for the logs and events subscription, set subscribeToAll to receive all of them")
+
+// ErrEventTypeNotSupportedByEncoding signals that the events of a subscribed event type can
+// not be encoded with the requested encoding
+var ErrEventTypeNotSupportedByEncoding = errors.New("event type not supported by encoding")
+
+// ErrInvalidResumeID signals that the resume id supplied on connect is not a valid uuid
+var ErrInvalidResumeID = errors.New("invalid resume id")
@@ -1,5 +1,8 @@
 package ws
 
+// SendChannelSize -
+const SendChannelSize = sendChannelSize
+
 // ArgsWSDispatcher -
 type ArgsWSDispatcher struct {
 	argsWebSocketDispatcher
@@ -8,9 +11,11 @@ type ArgsWSDispatcher struct {
 // NewTestWSDispatcher -
 func NewTestWSDispatcher(args ArgsWSDispatcher) (*websocketDispatcher, error) {
 	wsArgs := argsWebSocketDispatcher{
-		Dispatcher: args.Dispatcher,
-		Conn:       args.Conn,
-		Marshaller: args.Marshaller,
+		Dispatcher:        args.Dispatcher,
+		Conn:              args.Conn,
+		Marshaller:        args.Marshaller,
+		BreakOnErrorCount: args.BreakOnErrorCount,
+		ResumeID:          args.ResumeID,
 	}
 
 	return newWebSocketDispatcher(wsArgs)
@@ -29,5 +34,21 @@ func (wd *websocketDispatcher) ReadPump() {
 // ReadSendChannel -
 func (wd *websocketDispatcher) ReadSendChannel() []byte {
 	d := <-wd.send
-	return d
+	return d.data
+}
+
+// ReadSendChannelWithType -
+func (wd *websocketDispatcher) ReadSendChannelWithType() (int, []byte) {
+	d := <-wd.send
+	return d.messageType, d.data
+}
+
+// NumQueuedMessages -
+func (wd *websocketDispatcher) NumQueuedMessages() int {
+	return len(wd.send)
+}
+
+// TrySendSubscribeEvent -
+func (wd *websocketDispatcher) TrySendSubscribeEvent(eventBytes []byte) {
+	wd.trySendSubscribeEvent(eventBytes)
 }
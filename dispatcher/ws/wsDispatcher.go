@@ -2,8 +2,11 @@ package ws
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
 	"net"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
@@ -13,6 +16,7 @@ import (
 	logger "github.com/multiversx/mx-chain-logger-go"
 	"github.com/multiversx/mx-chain-notifier-go/common"
 	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/data/payload"
 	"github.com/multiversx/mx-chain-notifier-go/dispatcher"
 )
 
@@ -23,6 +27,8 @@ const (
 	pongWait   = 60 * time.Second
 	pingPeriod = (pongWait * 9) / 10
 	maxMsgSize = 1024 * 1024
+
+	sendChannelSize = 256
 )
 
 var (
@@ -32,18 +38,51 @@ var (
 
 // argsWebSocketDispatcher defines the arguments needed for ws dispatcher
 type argsWebSocketDispatcher struct {
-	Dispatcher dispatcher.Dispatcher
-	Conn       dispatcher.WSConnection
-	Marshaller marshal.Marshalizer
+	Dispatcher        dispatcher.Dispatcher
+	Conn              dispatcher.WSConnection
+	Marshaller        marshal.Marshalizer
+	BreakOnErrorCount int
+
+	// ResumeID is the id supplied by the client on connect, used as the dispatcher id so that
+	// the subscriptions are kept for a reconnect. If not set, a random id is used
+	ResumeID uuid.UUID
+}
+
+// wsMessage holds an encoded event and the websocket message type it is written with
+type wsMessage struct {
+	messageType int
+	data        []byte
+}
+
+// eventEncoder holds the marshaller used for the events pushed to the client and the
+// websocket message type of the encoded events
+type eventEncoder struct {
+	marshaller  marshal.Marshalizer
+	messageType int
 }
 
 type websocketDispatcher struct {
 	id         uuid.UUID
+	resumable  bool
 	wg         sync.WaitGroup
-	send       chan []byte
+	send       chan *wsMessage
 	conn       dispatcher.WSConnection
 	dispatcher dispatcher.Dispatcher
 	marshaller marshal.Marshalizer
+
+	// breakOnErrorCount is the number of consecutive write errors after which the dispatcher
+	// is broken, 0 meaning that the write pump stops on the first error. The write errors
+	// are only counted by the write pump, while isBroken is also read when pushing events
+	breakOnErrorCount int
+	numWriteErrors    int
+	isBroken          uint32
+
+	// writePumpDone is closed when the write pump stops, so that the messages sent afterwards
+	// are dropped instead of blocking on a full send channel
+	writePumpDone chan struct{}
+
+	mutEncoder sync.RWMutex
+	encoder    eventEncoder
 }
 
 // newWebSocketDispatcher createa a new ws dispatcher instance
@@ -57,13 +96,28 @@ func newWebSocketDispatcher(args argsWebSocketDispatcher) (*websocketDispatcher,
 	if check.IfNil(args.Marshaller) {
 		return nil, common.ErrNilMarshaller
 	}
+	if args.BreakOnErrorCount < 0 {
+		return nil, ErrInvalidBreakOnErrorCount
+	}
+
+	id := args.ResumeID
+	if id == uuid.Nil {
+		id = uuid.New()
+	}
 
 	return &websocketDispatcher{
-		id:         uuid.New(),
-		send:       make(chan []byte, 256),
-		conn:       args.Conn,
-		dispatcher: args.Dispatcher,
-		marshaller: args.Marshaller,
+		id:                id,
+		resumable:         args.ResumeID != uuid.Nil,
+		send:              make(chan *wsMessage, sendChannelSize),
+		conn:              args.Conn,
+		dispatcher:        args.Dispatcher,
+		marshaller:        args.Marshaller,
+		breakOnErrorCount: args.BreakOnErrorCount,
+		writePumpDone:     make(chan struct{}),
+		encoder: eventEncoder{
+			marshaller:  args.Marshaller,
+			messageType: websocket.TextMessage,
+		},
 	}, nil
 }
 
@@ -72,131 +126,126 @@ func (wd *websocketDispatcher) GetID() uuid.UUID {
 	return wd.id
 }
 
+// IsResumable returns true if the dispatcher id was supplied by the client on connect
+func (wd *websocketDispatcher) IsResumable() bool {
+	return wd.resumable
+}
+
 // PushEvents receives an events slice and processes it before pushing to socket
 func (wd *websocketDispatcher) PushEvents(events []data.Event) {
-	eventBytes, err := wd.marshaller.Marshal(events)
-	if err != nil {
-		log.Error("failure marshalling events", "err", err.Error())
-		return
-	}
-
-	wsEvent := &data.WebSocketEvent{
-		Type: common.PushLogsAndEvents,
-		Data: eventBytes,
-	}
-	wsEventBytes, err := wd.marshaller.Marshal(wsEvent)
-	if err != nil {
-		log.Error("failure marshalling events", "err", err.Error())
-		return
-	}
-
-	wd.send <- wsEventBytes
+	wd.pushEvent(common.PushLogsAndEvents, events)
 }
 
 // RevertEvent receives a reverted block event and process it before pushing to socket
 func (wd *websocketDispatcher) RevertEvent(event data.RevertBlock) {
-	eventBytes, err := wd.marshaller.Marshal(event)
-	if err != nil {
-		log.Error("failure marshalling events", "err", err.Error())
-		return
-	}
-	wsEvent := &data.WebSocketEvent{
-		Type: common.RevertBlockEvents,
-		Data: eventBytes,
-	}
-	wsEventBytes, err := wd.marshaller.Marshal(wsEvent)
-	if err != nil {
-		log.Error("failure marshalling events", "err", err.Error())
-		return
-	}
-
-	wd.send <- wsEventBytes
+	wd.pushEvent(common.RevertBlockEvents, event)
 }
 
 // FinalizedEvent receives a finalized block event and process it before pushing to socket
 func (wd *websocketDispatcher) FinalizedEvent(event data.FinalizedBlock) {
-	eventBytes, err := wd.marshaller.Marshal(event)
-	if err != nil {
-		log.Error("failure marshalling events", "err", err.Error())
-		return
-	}
-	wsEvent := &data.WebSocketEvent{
-		Type: common.FinalizedBlockEvents,
-		Data: eventBytes,
-	}
-	wsEventBytes, err := wd.marshaller.Marshal(wsEvent)
-	if err != nil {
-		log.Error("failure marshalling events", "err", err.Error())
-		return
-	}
-
-	wd.send <- wsEventBytes
+	wd.pushEvent(common.FinalizedBlockEvents, event)
 }
 
 // TxsEvent receives a block txs event and process it before pushing to socket
 func (wd *websocketDispatcher) TxsEvent(event data.BlockTxs) {
-	eventBytes, err := wd.marshaller.Marshal(event)
-	if err != nil {
-		log.Error("failure marshalling events", "err", err.Error())
-		return
-	}
-	wsEvent := &data.WebSocketEvent{
-		Type: common.BlockTxs,
-		Data: eventBytes,
-	}
-	wsEventBytes, err := wd.marshaller.Marshal(wsEvent)
-	if err != nil {
-		log.Error("failure marshalling events", "err", err.Error())
-		return
-	}
-
-	wd.send <- wsEventBytes
+	wd.pushEvent(common.BlockTxs, event)
 }
 
 // BlockEvents receives block events with data and processes it before pushing to socket
 func (wd *websocketDispatcher) BlockEvents(event data.BlockEventsWithOrder) {
-	eventBytes, err := wd.marshaller.Marshal(event)
+	wd.pushEvent(common.BlockEvents, event)
+}
+
+// ScrsEvent receives a block scrs event and process it before pushing to socket
+func (wd *websocketDispatcher) ScrsEvent(event data.BlockScrs) {
+	wd.pushEvent(common.BlockScrs, event)
+}
+
+// BlockTxEvents receives a block transaction notifications event and process it before pushing to socket
+func (wd *websocketDispatcher) BlockTxEvents(event data.BlockTxEvents) {
+	wd.pushEvent(common.TxEvents, event)
+}
+
+// RoundEvents receives a rounds info event and process it before pushing to socket
+func (wd *websocketDispatcher) RoundEvents(event data.RoundEvents) {
+	wd.pushEvent(common.RoundEvents, event)
+}
+
+// ValidatorsRatingEvent receives a validators rating event and process it before pushing to socket
+func (wd *websocketDispatcher) ValidatorsRatingEvent(event data.ValidatorsRatingEvent) {
+	wd.pushEvent(common.ValidatorsRatingEvents, event)
+}
+
+// AccountsEvents receives the matched altered accounts and process them before pushing to socket
+func (wd *websocketDispatcher) AccountsEvents(event data.AccountsEvents) {
+	wd.pushEvent(common.AccountEvents, event)
+}
+
+// ReplayUnavailable signals the client that the requested replay is not available
+func (wd *websocketDispatcher) ReplayUnavailable(event data.ReplayUnavailable) {
+	wd.pushEvent(common.ReplayUnavailable, event)
+}
+
+// pushEvent encodes the event and its websocket wrapper with the encoding chosen by the
+// client on subscribe before pushing to socket
+func (wd *websocketDispatcher) pushEvent(eventType string, event interface{}) {
+	if atomic.LoadUint32(&wd.isBroken) == 1 {
+		log.Trace("dropped event for broken websocket dispatcher", "dispatcherID", wd.id, "eventType", eventType)
+		return
+	}
+
+	wd.mutEncoder.RLock()
+	encoder := wd.encoder
+	wd.mutEncoder.RUnlock()
+
+	eventBytes, err := encoder.marshaller.Marshal(event)
 	if err != nil {
 		log.Error("failure marshalling events", "err", err.Error())
 		return
 	}
 	wsEvent := &data.WebSocketEvent{
-		Type: common.BlockEvents,
+		Type: eventType,
 		Data: eventBytes,
 	}
-	wsEventBytes, err := wd.marshaller.Marshal(wsEvent)
+	wsEventBytes, err := encoder.marshaller.Marshal(wsEvent)
 	if err != nil {
 		log.Error("failure marshalling events", "err", err.Error())
 		return
 	}
 
-	wd.send <- wsEventBytes
+	wd.sendMessage(&wsMessage{
+		messageType: encoder.messageType,
+		data:        wsEventBytes,
+	})
 }
 
-// ScrsEvent receives a block scrs event and process it before pushing to socket
-func (wd *websocketDispatcher) ScrsEvent(event data.BlockScrs) {
-	eventBytes, err := wd.marshaller.Marshal(event)
-	if err != nil {
-		log.Error("failure marshalling events", "err", err.Error())
-		return
-	}
-	wsEvent := &data.WebSocketEvent{
-		Type: common.BlockScrs,
-		Data: eventBytes,
+// sendMessage queues the message for the write pump. The message is dropped if the write
+// pump stopped, since nothing reads the send channel anymore; this way, the hub broadcasts
+// and the read pump are not blocked by a connection which can no longer be written
+func (wd *websocketDispatcher) sendMessage(message *wsMessage) {
+	select {
+	case wd.send <- message:
+	case <-wd.writePumpDone:
+		log.Trace("dropped message for stopped websocket write pump", "dispatcherID", wd.id)
 	}
-	wsEventBytes, err := wd.marshaller.Marshal(wsEvent)
+}
+
+// Close sends a close frame to the client and closes the underlying connection
+func (wd *websocketDispatcher) Close() error {
+	closeMessage := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+	err := wd.conn.WriteControl(websocket.CloseMessage, closeMessage, time.Now().Add(writeWait))
 	if err != nil {
-		log.Error("failure marshalling events", "err", err.Error())
-		return
+		log.Debug("failed to write close message", "dispatcherID", wd.id, "err", err.Error())
 	}
 
-	wd.send <- wsEventBytes
+	return wd.conn.Close()
 }
 
 // writePump listens on the send-channel and pushes data on the socket stream
 func (wd *websocketDispatcher) writePump() {
 	ticker := time.NewTicker(pingPeriod)
 	defer func() {
+		close(wd.writePumpDone)
 		ticker.Stop()
 		if err := wd.conn.Close(); err != nil {
 			if _, ok := err.(*net.OpError); ok {
@@ -231,26 +280,58 @@ func (wd *websocketDispatcher) writePump() {
 			if !ok {
 				if err := wd.conn.WriteMessage(websocket.CloseMessage, []byte{}); err != nil {
 					log.Debug("failed to write close message", "err", err.Error())
-					return
 				}
+				return
 			}
 
-			if err := nextWriterWrap(websocket.TextMessage, message); err != nil {
-				log.Error("failed to write text message", "err", err.Error())
-				return
+			if err := nextWriterWrap(message.messageType, message.data); err != nil {
+				log.Error("failed to write message", "dispatcherID", wd.id, "err", err.Error())
+				if wd.handleWriteError() {
+					return
+				}
+				continue
 			}
+			wd.numWriteErrors = 0
 		case <-ticker.C:
 			if err := wd.setSocketWriteLimits(); err != nil {
 				log.Error("ticker: failed to set socket write limits", "err", err.Error())
 			}
 			if err := wd.conn.WriteMessage(websocket.PingMessage, []byte{}); err != nil {
-				log.Error("ticker: failed to write ping message", "err", err.Error())
-				return
+				log.Error("ticker: failed to write ping message", "dispatcherID", wd.id, "err", err.Error())
+				if wd.handleWriteError() {
+					return
+				}
 			}
 		}
 	}
 }
 
+// handleWriteError counts the consecutive write errors and returns true if the write pump
+// has to stop. After breakOnErrorCount errors, the dispatcher is broken: it is unregistered
+// from the hub right away, instead of waiting for the read pump to fail, and the events
+// pushed afterwards are dropped, so that a broken connection does not block the broadcasts.
+// The dispatcher is unregistered asynchronously: the hub might be blocked delivering to this
+// dispatcher, which only returns once the write pump stops
+func (wd *websocketDispatcher) handleWriteError() bool {
+	if wd.breakOnErrorCount == 0 {
+		return true
+	}
+
+	wd.numWriteErrors++
+	if wd.numWriteErrors < wd.breakOnErrorCount {
+		return false
+	}
+
+	atomic.StoreUint32(&wd.isBroken, 1)
+	log.Warn("websocket dispatcher is broken, unregistering it",
+		"dispatcherID", wd.id,
+		"consecutive write errors", wd.numWriteErrors,
+	)
+	go wd.dispatcher.UnregisterEvent(wd)
+
+	return true
+}
+
 // readPump listens for incoming events and reads the content from the socket stream
 func (wd *websocketDispatcher) readPump() {
 	defer func() {
@@ -290,10 +371,151 @@ func (wd *websocketDispatcher) trySendSubscribeEvent(eventBytes []byte) {
 	err := wd.marshaller.Unmarshal(&subscribeEvent, eventBytes)
 	if err != nil {
 		log.Error("failure unmarshalling subscribe event", "err", err.Error())
+		wd.sendErrorFrame(common.InvalidSubscribeErrorCode, err)
+		return
+	}
+	err = checkSubscriptionCriteria(subscribeEvent)
+	if err != nil {
+		log.Debug("rejected subscribe event", "dispatcherID", wd.id, "err", err.Error())
+		wd.sendErrorFrame(common.NoSubscriptionCriteriaErrorCode, err)
 		return
 	}
 	subscribeEvent.DispatcherID = wd.id
-	wd.dispatcher.Subscribe(subscribeEvent)
+
+	encoder, err := wd.createEventEncoder(subscribeEvent)
+	if err != nil {
+		log.Warn("could not subscribe dispatcher", "dispatcherID", wd.id, "encoding", subscribeEvent.Encoding, "err", err.Error())
+		wd.sendErrorFrame(common.UnsupportedEncodingErrorCode, err)
+		return
+	}
+
+	// the encoder is set before subscribing, so that the first matched events are already
+	// encoded as requested, and it is restored if the subscription is rejected
+	wd.mutEncoder.Lock()
+	previousEncoder := wd.encoder
+	wd.encoder = encoder
+	wd.mutEncoder.Unlock()
+
+	err = wd.dispatcher.Subscribe(subscribeEvent)
+	if err != nil {
+		wd.mutEncoder.Lock()
+		wd.encoder = previousEncoder
+		wd.mutEncoder.Unlock()
+
+		log.Warn("could not subscribe dispatcher", "dispatcherID", wd.id, "err", err.Error())
+		wd.sendErrorFrame(getSubscribeErrorCode(err), err)
+		return
+	}
+
+	wd.sendSubscribeAckFrame()
+}
+
+// checkSubscriptionCriteria rejects the subscribe events which would match all the logs and
+// events, unless the client explicitly asked for them. The entries of the other event types
+// are discriminated by their event type
+func checkSubscriptionCriteria(subscribeEvent data.SubscribeEvent) error {
+	if subscribeEvent.SubscribeToAll {
+		return nil
+	}
+	if len(subscribeEvent.SubscriptionEntries) == 0 {
+		return ErrNoSubscriptionCriteria
+	}
+
+	for _, entry := range subscribeEvent.SubscriptionEntries {
+		isLogsAndEvents := dispatcher.GetEventType(entry) == common.PushLogsAndEvents
+		hasCriteria := entry.Address != "" || entry.Identifier != "" || len(entry.Identifiers) > 0 || len(entry.Topics) > 0
+		if isLogsAndEvents && !hasCriteria {
+			return ErrNoSubscriptionCriteria
+		}
+	}
+
+	return nil
+}
+
+func getSubscribeErrorCode(err error) string {
+	if errors.Is(err, dispatcher.ErrMaxSubscriptionsReached) {
+		return common.SubscriptionLimitErrorCode
+	}
+
+	return common.SubscriptionRejectedErrorCode
+}
+
+// sendSubscribeAckFrame pushes a json frame with the subscriptions stored by the hub, as
+// defined by data.SubscribeAckFrame
+func (wd *websocketDispatcher) sendSubscribeAckFrame() {
+	ackFrameBytes, err := wd.marshaller.Marshal(&data.SubscribeAckFrame{
+		Type:          common.SubscribeAckFrameType,
+		DispatcherID:  wd.id.String(),
+		Subscriptions: wd.dispatcher.GetSubscriptions(wd.id),
+	})
+	if err != nil {
+		log.Error("failure marshalling subscribe ack frame", "err", err.Error())
+		return
+	}
+
+	wd.sendMessage(&wsMessage{
+		messageType: websocket.TextMessage,
+		data:        ackFrameBytes,
+	})
+}
+
+// sendErrorFrame pushes a json error frame to the client, as defined by data.ErrorFrame
+func (wd *websocketDispatcher) sendErrorFrame(code string, err error) {
+	errorFrameBytes, errMarshal := wd.marshaller.Marshal(&data.ErrorFrame{
+		Type:    common.ErrorFrameType,
+		Code:    code,
+		Message: err.Error(),
+	})
+	if errMarshal != nil {
+		log.Error("failure marshalling error frame", "err", errMarshal.Error())
+		return
+	}
+
+	wd.sendMessage(&wsMessage{
+		messageType: websocket.TextMessage,
+		data:        errorFrameBytes,
+	})
+}
+
+// createEventEncoder returns the encoder of the subscribe event encoding, json being used
+// if none is specified. The subscribe events are always decoded as json
+func (wd *websocketDispatcher) createEventEncoder(subscribeEvent data.SubscribeEvent) (eventEncoder, error) {
+	switch subscribeEvent.Encoding {
+	case "", common.JSONEncoding:
+		return eventEncoder{
+			marshaller:  wd.marshaller,
+			messageType: websocket.TextMessage,
+		}, nil
+	case common.MessagePackEncoding:
+		return eventEncoder{
+			marshaller:  payload.NewMessagePackMarshaller(),
+			messageType: websocket.BinaryMessage,
+		}, nil
+	case common.ProtobufEncoding:
+		for _, entry := range subscribeEvent.SubscriptionEntries {
+			if !isProtobufEventType(entry.EventType) {
+				return eventEncoder{}, fmt.Errorf("%w: %s", ErrEventTypeNotSupportedByEncoding, entry.EventType)
+			}
+		}
+
+		return eventEncoder{
+			marshaller:  payload.NewProtoPayloadMarshaller(),
+			messageType: websocket.BinaryMessage,
+		}, nil
+	default:
+		return eventEncoder{}, ErrUnsupportedEncoding
+	}
+}
+
+// isProtobufEventType returns true if the events of the subscription event type have a
+// protobuf message defined
+func isProtobufEventType(eventType string) bool {
+	switch eventType {
+	case "", common.PushLogsAndEvents, common.RevertBlockEvents, common.FinalizedBlockEvents:
+		return true
+	default:
+		return false
+	}
 }
 
 func (wd *websocketDispatcher) setSocketWriteLimits() error {
@@ -3,14 +3,21 @@ package ws_test
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
 	"github.com/multiversx/mx-chain-core-go/core/mock"
 	"github.com/multiversx/mx-chain-core-go/data/outport"
 	"github.com/multiversx/mx-chain-core-go/data/transaction"
 	"github.com/multiversx/mx-chain-notifier-go/common"
 	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/data/payload"
+	"github.com/multiversx/mx-chain-notifier-go/dispatcher"
 	"github.com/multiversx/mx-chain-notifier-go/dispatcher/ws"
 	"github.com/multiversx/mx-chain-notifier-go/mocks"
 	"github.com/stretchr/testify/assert"
@@ -75,6 +82,17 @@ func TestNewWebSocketDispatcher(t *testing.T) {
 		assert.Equal(t, common.ErrNilMarshaller, err)
 	})
 
+	t.Run("negative break on error count", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockWSDispatcherArgs()
+		args.BreakOnErrorCount = -1
+
+		wd, err := ws.NewTestWSDispatcher(args)
+		require.Nil(t, wd)
+		assert.Equal(t, ws.ErrInvalidBreakOnErrorCount, err)
+	})
+
 	t.Run("should work", func(t *testing.T) {
 		t.Parallel()
 
@@ -82,6 +100,19 @@ func TestNewWebSocketDispatcher(t *testing.T) {
 		wd, err := ws.NewTestWSDispatcher(args)
 		require.Nil(t, err)
 		require.NotNil(t, wd)
+		require.NotEqual(t, uuid.Nil, wd.GetID())
+		require.False(t, wd.IsResumable())
+	})
+
+	t.Run("resume id should be used as the dispatcher id", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockWSDispatcherArgs()
+		args.ResumeID = uuid.New()
+		wd, err := ws.NewTestWSDispatcher(args)
+		require.Nil(t, err)
+		require.Equal(t, args.ResumeID, wd.GetID())
+		require.True(t, wd.IsResumable())
 	})
 }
 
@@ -123,6 +154,251 @@ func TestWritePump(t *testing.T) {
 	assert.True(t, wasCalled)
 }
 
+func TestWritePumpBreakOnErrors(t *testing.T) {
+	t.Parallel()
+
+	events := []data.Event{{Address: "addr1"}}
+
+	createArgs := func(breakOnErrorCount int, writeErrors []bool, numWrites *int, unregistered chan uuid.UUID) ws.ArgsWSDispatcher {
+		args := createMockWSDispatcherArgs()
+		args.BreakOnErrorCount = breakOnErrorCount
+		args.Conn = &mocks.WSConnStub{
+			NextWriterCalled: func(message int) (io.WriteCloser, error) {
+				shouldFail := writeErrors[*numWrites]
+				*numWrites++
+				if shouldFail {
+					return nil, errors.New("write error")
+				}
+				return &testWriter{}, nil
+			},
+		}
+		args.Dispatcher = &mocks.HubStub{
+			UnregisterEventCalled: func(event dispatcher.EventDispatcher) {
+				unregistered <- event.GetID()
+			},
+		}
+
+		return args
+	}
+
+	requireUnregistered := func(t *testing.T, unregistered chan uuid.UUID, expectedID uuid.UUID) {
+		select {
+		case id := <-unregistered:
+			require.Equal(t, expectedID, id)
+		case <-time.After(time.Second):
+			require.Fail(t, "dispatcher was not unregistered")
+		}
+	}
+
+	t.Run("zero count should stop on the first error without unregistering", func(t *testing.T) {
+		t.Parallel()
+
+		numWrites := 0
+		unregistered := make(chan uuid.UUID, 1)
+		args := createArgs(0, []bool{true, true}, &numWrites, unregistered)
+		wd, err := ws.NewTestWSDispatcher(args)
+		require.Nil(t, err)
+
+		wd.PushEvents(events)
+		wd.PushEvents(events)
+		wd.WritePump()
+
+		require.Equal(t, 1, numWrites)
+		require.Equal(t, 0, len(unregistered))
+	})
+
+	t.Run("consecutive write errors should unregister the dispatcher", func(t *testing.T) {
+		t.Parallel()
+
+		numWrites := 0
+		unregistered := make(chan uuid.UUID, 1)
+		args := createArgs(3, []bool{true, true, true}, &numWrites, unregistered)
+		wd, err := ws.NewTestWSDispatcher(args)
+		require.Nil(t, err)
+
+		for i := 0; i < 3; i++ {
+			wd.PushEvents(events)
+		}
+		wd.WritePump()
+
+		require.Equal(t, 3, numWrites)
+		requireUnregistered(t, unregistered, wd.GetID())
+
+		wd.PushEvents(events)
+		require.Equal(t, 0, wd.NumQueuedMessages())
+	})
+
+	t.Run("successful write should reset the errors count", func(t *testing.T) {
+		t.Parallel()
+
+		numWrites := 0
+		unregistered := make(chan uuid.UUID, 1)
+		args := createArgs(3, []bool{true, true, false, true, true, true}, &numWrites, unregistered)
+		wd, err := ws.NewTestWSDispatcher(args)
+		require.Nil(t, err)
+
+		for i := 0; i < 6; i++ {
+			wd.PushEvents(events)
+		}
+		wd.WritePump()
+
+		require.Equal(t, 6, numWrites)
+		requireUnregistered(t, unregistered, wd.GetID())
+	})
+}
+
+// sendChannelTestDispatcher defines the ws dispatcher methods used to fill the send channel
+type sendChannelTestDispatcher interface {
+	PushEvents(events []data.Event)
+	NumQueuedMessages() int
+}
+
+func TestWritePumpStopped(t *testing.T) {
+	t.Parallel()
+
+	events := []data.Event{{Address: "addr1"}}
+
+	createArgs := func(breakOnErrorCount int) ws.ArgsWSDispatcher {
+		args := createMockWSDispatcherArgs()
+		args.BreakOnErrorCount = breakOnErrorCount
+		args.Conn = &mocks.WSConnStub{
+			NextWriterCalled: func(message int) (io.WriteCloser, error) {
+				return nil, errors.New("write error")
+			},
+		}
+
+		return args
+	}
+
+	fillSendChannel := func(wd sendChannelTestDispatcher) {
+		for wd.NumQueuedMessages() < ws.SendChannelSize {
+			wd.PushEvents(events)
+		}
+	}
+
+	t.Run("broken dispatcher should not deadlock the hub delivering to it", func(t *testing.T) {
+		t.Parallel()
+
+		mutDispatchers := sync.RWMutex{}
+		unregistered := make(chan struct{})
+		args := createArgs(1)
+		args.Dispatcher = &mocks.HubStub{
+			UnregisterEventCalled: func(event dispatcher.EventDispatcher) {
+				mutDispatchers.Lock()
+				mutDispatchers.Unlock()
+				close(unregistered)
+			},
+		}
+		wd, err := ws.NewTestWSDispatcher(args)
+		require.Nil(t, err)
+
+		fillSendChannel(wd)
+
+		// simulates the hub delivering to the dispatcher while holding the dispatchers lock
+		delivered := make(chan struct{})
+		mutDispatchers.RLock()
+		go func() {
+			defer mutDispatchers.RUnlock()
+
+			for i := 0; i < 10; i++ {
+				wd.PushEvents(events)
+			}
+			close(delivered)
+		}()
+
+		wd.WritePump()
+
+		select {
+		case <-delivered:
+		case <-time.After(time.Second):
+			require.Fail(t, "delivery to the broken dispatcher is blocked")
+		}
+		select {
+		case <-unregistered:
+		case <-time.After(time.Second):
+			require.Fail(t, "dispatcher was not unregistered")
+		}
+	})
+
+	t.Run("stopped write pump should not deadlock the hub unregistering the dispatcher", func(t *testing.T) {
+		t.Parallel()
+
+		mutDispatchers := sync.RWMutex{}
+		args := createArgs(0)
+		args.Conn = &mocks.WSConnStub{
+			NextWriterCalled: func(message int) (io.WriteCloser, error) {
+				return nil, errors.New("write error")
+			},
+			ReadMessageCalled: func() (messageType int, p []byte, err error) {
+				return 0, nil, errors.New("read error")
+			},
+		}
+		args.Dispatcher = &mocks.HubStub{
+			UnregisterEventCalled: func(event dispatcher.EventDispatcher) {
+				mutDispatchers.Lock()
+				mutDispatchers.Unlock()
+			},
+		}
+		wd, err := ws.NewTestWSDispatcher(args)
+		require.Nil(t, err)
+
+		fillSendChannel(wd)
+		wd.WritePump()
+
+		// simulates the hub delivering to the dispatcher while holding the dispatchers lock
+		delivered := make(chan struct{})
+		mutDispatchers.RLock()
+		go func() {
+			defer mutDispatchers.RUnlock()
+
+			for i := 0; i < 10; i++ {
+				wd.PushEvents(events)
+			}
+			close(delivered)
+		}()
+
+		readPumpDone := make(chan struct{})
+		go func() {
+			wd.ReadPump()
+			close(readPumpDone)
+		}()
+
+		select {
+		case <-delivered:
+		case <-time.After(time.Second):
+			require.Fail(t, "delivery to the stopped dispatcher is blocked")
+		}
+		select {
+		case <-readPumpDone:
+		case <-time.After(time.Second):
+			require.Fail(t, "dispatcher unregister is blocked")
+		}
+	})
+
+	t.Run("read pump frames should not block after the write pump stopped", func(t *testing.T) {
+		t.Parallel()
+
+		wd, err := ws.NewTestWSDispatcher(createArgs(0))
+		require.Nil(t, err)
+
+		fillSendChannel(wd)
+		wd.WritePump()
+		fillSendChannel(wd)
+
+		sent := make(chan struct{})
+		go func() {
+			wd.TrySendSubscribeEvent([]byte("invalid subscribe event"))
+			close(sent)
+		}()
+
+		select {
+		case <-sent:
+		case <-time.After(time.Second):
+			require.Fail(t, "error frame send is blocked")
+		}
+	})
+}
+
 func TestReadPump(t *testing.T) {
 	t.Parallel()
 
@@ -152,6 +428,34 @@ func TestReadPump(t *testing.T) {
 	assert.True(t, wasCalled)
 }
 
+func TestClose(t *testing.T) {
+	t.Parallel()
+
+	args := createMockWSDispatcherArgs()
+
+	closeFrameWasSent := false
+	connWasClosed := false
+	args.Conn = &mocks.WSConnStub{
+		WriteControlCalled: func(messageType int, data []byte, deadline time.Time) error {
+			closeFrameWasSent = messageType == websocket.CloseMessage
+			return nil
+		},
+		CloseCalled: func() error {
+			connWasClosed = true
+			return nil
+		},
+	}
+
+	wd, err := ws.NewTestWSDispatcher(args)
+	require.Nil(t, err)
+
+	err = wd.Close()
+	require.Nil(t, err)
+
+	assert.True(t, closeFrameWasSent)
+	assert.True(t, connWasClosed)
+}
+
 func TestPushEvents(t *testing.T) {
 	t.Parallel()
 
@@ -216,3 +520,355 @@ func TestBlockEventsWithOrder(t *testing.T) {
 
 	require.Equal(t, expectedEventBytes, eventsData)
 }
+
+func TestReplayUnavailable(t *testing.T) {
+	t.Parallel()
+
+	args := createMockWSDispatcherArgs()
+	wd, err := ws.NewTestWSDispatcher(args)
+	require.Nil(t, err)
+
+	replayUnavailable := data.ReplayUnavailable{
+		FromHash:         "hash1",
+		OldestBlockNonce: 2,
+		OldestHash:       "hash2",
+	}
+	eventBytes, _ := json.Marshal(replayUnavailable)
+
+	wd.ReplayUnavailable(replayUnavailable)
+
+	wsEvent := &data.WebSocketEvent{
+		Type: common.ReplayUnavailable,
+		Data: eventBytes,
+	}
+	expectedEventBytes, _ := json.Marshal(wsEvent)
+
+	require.Equal(t, expectedEventBytes, wd.ReadSendChannel())
+}
+
+// encodingTestDispatcher defines the ws dispatcher methods used by the encoding tests
+type encodingTestDispatcher interface {
+	PushEvents(events []data.Event)
+	FinalizedEvent(event data.FinalizedBlock)
+	ReadSendChannelWithType() (int, []byte)
+}
+
+func TestSubscribeEncoding(t *testing.T) {
+	t.Parallel()
+
+	events := []data.Event{
+		{
+			Address:    "addr1",
+			Identifier: "id1",
+		},
+	}
+
+	subscribe := func(t *testing.T, encoding string, hubStub *mocks.HubStub) encodingTestDispatcher {
+		args := createMockWSDispatcherArgs()
+		args.Dispatcher = hubStub
+		wd, err := ws.NewTestWSDispatcher(args)
+		require.Nil(t, err)
+
+		subscribeEvent := data.SubscribeEvent{
+			SubscriptionEntries: []data.SubscriptionEntry{{EventType: common.PushLogsAndEvents}},
+			Encoding:            encoding,
+			SubscribeToAll:      true,
+		}
+		subscribeEventBytes, _ := json.Marshal(subscribeEvent)
+		wd.TrySendSubscribeEvent(subscribeEventBytes)
+
+		return wd
+	}
+
+	t.Run("no encoding should push json text messages", func(t *testing.T) {
+		t.Parallel()
+
+		wasCalled := false
+		wd := subscribe(t, "", &mocks.HubStub{
+			SubscribeCalled: func(event data.SubscribeEvent) error {
+				wasCalled = true
+				return nil
+			},
+		})
+		require.True(t, wasCalled)
+		requireSubscribeAckFrame(t, wd)
+
+		wd.PushEvents(events)
+
+		eventBytes, _ := json.Marshal(events)
+		expectedEventBytes, _ := json.Marshal(&data.WebSocketEvent{
+			Type: common.PushLogsAndEvents,
+			Data: eventBytes,
+		})
+		messageType, eventsData := wd.ReadSendChannelWithType()
+		require.Equal(t, websocket.TextMessage, messageType)
+		require.Equal(t, expectedEventBytes, eventsData)
+	})
+
+	t.Run("msgpack encoding should push msgpack binary messages", func(t *testing.T) {
+		t.Parallel()
+
+		wd := subscribe(t, common.MessagePackEncoding, &mocks.HubStub{})
+		requireSubscribeAckFrame(t, wd)
+		wd.PushEvents(events)
+
+		messageType, eventsData := wd.ReadSendChannelWithType()
+		require.Equal(t, websocket.BinaryMessage, messageType)
+
+		marshaller := payload.NewMessagePackMarshaller()
+		var wsEvent data.WebSocketEvent
+		err := marshaller.Unmarshal(&wsEvent, eventsData)
+		require.Nil(t, err)
+		require.Equal(t, common.PushLogsAndEvents, wsEvent.Type)
+
+		var decodedEvents []data.Event
+		err = marshaller.Unmarshal(&decodedEvents, wsEvent.Data)
+		require.Nil(t, err)
+		require.Equal(t, events, decodedEvents)
+	})
+
+	t.Run("protobuf encoding should push protobuf binary messages", func(t *testing.T) {
+		t.Parallel()
+
+		wd := subscribe(t, common.ProtobufEncoding, &mocks.HubStub{})
+		requireSubscribeAckFrame(t, wd)
+		wd.FinalizedEvent(data.FinalizedBlock{Hash: "hash1"})
+
+		messageType, eventsData := wd.ReadSendChannelWithType()
+		require.Equal(t, websocket.BinaryMessage, messageType)
+
+		marshaller := payload.NewProtoPayloadMarshaller()
+		var wsEvent data.WebSocketEvent
+		err := marshaller.Unmarshal(&wsEvent, eventsData)
+		require.Nil(t, err)
+		require.Equal(t, common.FinalizedBlockEvents, wsEvent.Type)
+
+		var finalizedBlock data.FinalizedBlock
+		err = marshaller.Unmarshal(&finalizedBlock, wsEvent.Data)
+		require.Nil(t, err)
+		require.Equal(t, data.FinalizedBlock{Hash: "hash1"}, finalizedBlock)
+	})
+
+	t.Run("protobuf encoding with unsupported event type should not subscribe", func(t *testing.T) {
+		t.Parallel()
+
+		wasCalled := false
+		args := createMockWSDispatcherArgs()
+		args.Dispatcher = &mocks.HubStub{
+			SubscribeCalled: func(event data.SubscribeEvent) error {
+				wasCalled = true
+				return nil
+			},
+		}
+		wd, err := ws.NewTestWSDispatcher(args)
+		require.Nil(t, err)
+
+		subscribeEventBytes, _ := json.Marshal(data.SubscribeEvent{
+			SubscriptionEntries: []data.SubscriptionEntry{{EventType: common.BlockTxs}},
+			Encoding:            common.ProtobufEncoding,
+		})
+		wd.TrySendSubscribeEvent(subscribeEventBytes)
+
+		require.False(t, wasCalled)
+		messageType, errorFrameBytes := wd.ReadSendChannelWithType()
+		require.Equal(t, websocket.TextMessage, messageType)
+		require.Contains(t, string(errorFrameBytes), common.UnsupportedEncodingErrorCode)
+	})
+
+	t.Run("unknown encoding should not subscribe", func(t *testing.T) {
+		t.Parallel()
+
+		wasCalled := false
+		wd := subscribe(t, "xml", &mocks.HubStub{
+			SubscribeCalled: func(event data.SubscribeEvent) error {
+				wasCalled = true
+				return nil
+			},
+		})
+		require.False(t, wasCalled)
+		requireErrorFrame(t, wd, common.UnsupportedEncodingErrorCode, ws.ErrUnsupportedEncoding.Error())
+
+		wd.PushEvents(events)
+		messageType, _ := wd.ReadSendChannelWithType()
+		require.Equal(t, websocket.TextMessage, messageType)
+	})
+
+	t.Run("rejected subscription should keep the previous encoding", func(t *testing.T) {
+		t.Parallel()
+
+		wd := subscribe(t, common.MessagePackEncoding, &mocks.HubStub{
+			SubscribeCalled: func(event data.SubscribeEvent) error {
+				return errors.New("subscribe error")
+			},
+		})
+		requireErrorFrame(t, wd, common.SubscriptionRejectedErrorCode, "subscribe error")
+
+		wd.PushEvents(events)
+		messageType, _ := wd.ReadSendChannelWithType()
+		require.Equal(t, websocket.TextMessage, messageType)
+	})
+}
+
+// requireSubscribeAckFrame checks that the subscription was acknowledged, always with a json frame
+func requireSubscribeAckFrame(t *testing.T, wd encodingTestDispatcher) {
+	messageType, ackFrameBytes := wd.ReadSendChannelWithType()
+	require.Equal(t, websocket.TextMessage, messageType)
+
+	var ackFrame data.SubscribeAckFrame
+	err := json.Unmarshal(ackFrameBytes, &ackFrame)
+	require.Nil(t, err)
+	require.Equal(t, common.SubscribeAckFrameType, ackFrame.Type)
+}
+
+func requireErrorFrame(t *testing.T, wd encodingTestDispatcher, expectedCode string, expectedMessage string) {
+	messageType, errorFrameBytes := wd.ReadSendChannelWithType()
+	require.Equal(t, websocket.TextMessage, messageType)
+
+	var errorFrame data.ErrorFrame
+	err := json.Unmarshal(errorFrameBytes, &errorFrame)
+	require.Nil(t, err)
+	require.Equal(t, data.ErrorFrame{
+		Type:    common.ErrorFrameType,
+		Code:    expectedCode,
+		Message: expectedMessage,
+	}, errorFrame)
+}
+
+func TestSubscribeErrorFrames(t *testing.T) {
+	t.Parallel()
+
+	t.Run("malformed subscribe should send an error frame", func(t *testing.T) {
+		t.Parallel()
+
+		wasCalled := false
+		args := createMockWSDispatcherArgs()
+		args.Dispatcher = &mocks.HubStub{
+			SubscribeCalled: func(event data.SubscribeEvent) error {
+				wasCalled = true
+				return nil
+			},
+		}
+		wd, err := ws.NewTestWSDispatcher(args)
+		require.Nil(t, err)
+
+		malformedSubscribe := []byte(`{"subscriptionEntries": [`)
+		wd.TrySendSubscribeEvent(malformedSubscribe)
+		require.False(t, wasCalled)
+
+		expectedErr := json.Unmarshal(malformedSubscribe, &data.SubscribeEvent{})
+		requireErrorFrame(t, wd, common.InvalidSubscribeErrorCode, expectedErr.Error())
+	})
+
+	t.Run("subscriptions limit should send an error frame", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := fmt.Errorf("%w: limit 1", dispatcher.ErrMaxSubscriptionsReached)
+		args := createMockWSDispatcherArgs()
+		args.Dispatcher = &mocks.HubStub{
+			SubscribeCalled: func(event data.SubscribeEvent) error {
+				return expectedErr
+			},
+		}
+		wd, err := ws.NewTestWSDispatcher(args)
+		require.Nil(t, err)
+
+		wd.TrySendSubscribeEvent([]byte(`{"subscriptionEntries": [{"address": "erd1"}]}`))
+
+		requireErrorFrame(t, wd, common.SubscriptionLimitErrorCode, expectedErr.Error())
+	})
+}
+
+func TestSubscribeCriteria(t *testing.T) {
+	t.Parallel()
+
+	trySubscribe := func(t *testing.T, subscribeEvent string) (bool, encodingTestDispatcher) {
+		wasCalled := false
+		args := createMockWSDispatcherArgs()
+		args.Dispatcher = &mocks.HubStub{
+			SubscribeCalled: func(event data.SubscribeEvent) error {
+				wasCalled = true
+				return nil
+			},
+		}
+		wd, err := ws.NewTestWSDispatcher(args)
+		require.Nil(t, err)
+
+		wd.TrySendSubscribeEvent([]byte(subscribeEvent))
+
+		return wasCalled, wd
+	}
+
+	t.Run("subscriptions without criteria should send an error frame", func(t *testing.T) {
+		t.Parallel()
+
+		for _, subscribeEvent := range []string{
+			`{}`,
+			`{"subscriptionEntries": []}`,
+			`{"subscriptionEntries": [{}]}`,
+			`{"subscriptionEntries": [{"eventType": "all_events", "address": "", "identifier": "", "topics": []}]}`,
+			`{"subscriptionEntries": [{"eventType": "unknown"}]}`,
+			`{"subscriptionEntries": [{"address": "erd1"}, {"eventType": "all_events"}]}`,
+		} {
+			wasCalled, wd := trySubscribe(t, subscribeEvent)
+			require.False(t, wasCalled, subscribeEvent)
+			requireErrorFrame(t, wd, common.NoSubscriptionCriteriaErrorCode, ws.ErrNoSubscriptionCriteria.Error())
+		}
+	})
+
+	t.Run("subscriptions with criteria should subscribe", func(t *testing.T) {
+		t.Parallel()
+
+		for _, subscribeEvent := range []string{
+			`{"subscribeToAll": true}`,
+			`{"subscriptionEntries": [{"eventType": "all_events"}], "subscribeToAll": true}`,
+			`{"subscriptionEntries": [{"address": "erd1"}]}`,
+			`{"subscriptionEntries": [{"identifier": "swap"}]}`,
+			`{"subscriptionEntries": [{"identifiers": ["swap", "transfer"]}]}`,
+			`{"subscriptionEntries": [{"address": "erd1", "identifier": "swap", "topics": ["dG9waWM="]}]}`,
+			`{"subscriptionEntries": [{"eventType": "finalized_events"}, {"eventType": "block_txs"}]}`,
+		} {
+			wasCalled, wd := trySubscribe(t, subscribeEvent)
+			require.True(t, wasCalled, subscribeEvent)
+			requireSubscribeAckFrame(t, wd)
+		}
+	})
+}
+
+func TestSubscribeAckFrame(t *testing.T) {
+	t.Parallel()
+
+	subscriptionMapper, err := dispatcher.NewSubscriptionMapper(dispatcher.ArgsSubscriptionMapper{})
+	require.Nil(t, err)
+
+	args := createMockWSDispatcherArgs()
+	args.Dispatcher = &mocks.HubStub{
+		SubscribeCalled:        subscriptionMapper.MatchSubscribeEvent,
+		GetSubscriptionsCalled: subscriptionMapper.DispatcherSubscriptions,
+	}
+	wd, err := ws.NewTestWSDispatcher(args)
+	require.Nil(t, err)
+
+	wd.TrySendSubscribeEvent([]byte(`{"subscriptionEntries": [{"address": "erd1", "identifier": "swap"}, {"eventType": "revert_events"}]}`))
+
+	messageType, ackFrameBytes := wd.ReadSendChannelWithType()
+	require.Equal(t, websocket.TextMessage, messageType)
+
+	var ackFrame data.SubscribeAckFrame
+	err = json.Unmarshal(ackFrameBytes, &ackFrame)
+	require.Nil(t, err)
+
+	require.Equal(t, common.SubscribeAckFrameType, ackFrame.Type)
+	require.Equal(t, wd.GetID().String(), ackFrame.DispatcherID)
+	require.Equal(t, 2, len(ackFrame.Subscriptions))
+
+	for _, subscription := range ackFrame.Subscriptions {
+		require.Equal(t, wd.GetID(), subscription.DispatcherID)
+		require.False(t, subscription.CreatedAt.IsZero())
+	}
+	require.Equal(t, "erd1", ackFrame.Subscriptions[0].Address)
+	require.Equal(t, "swap", ackFrame.Subscriptions[0].Identifier)
+	require.Equal(t, dispatcher.MatchAddressIdentifier, ackFrame.Subscriptions[0].MatchLevel)
+	require.Equal(t, common.PushLogsAndEvents, ackFrame.Subscriptions[0].EventType)
+	require.Equal(t, dispatcher.MatchAll, ackFrame.Subscriptions[1].MatchLevel)
+	require.Equal(t, common.RevertBlockEvents, ackFrame.Subscriptions[1].EventType)
+}
@@ -3,6 +3,7 @@ package ws
 import (
 	"net/http"
 
+	"github.com/google/uuid"
 	"github.com/multiversx/mx-chain-core-go/core/check"
 	"github.com/multiversx/mx-chain-core-go/marshal"
 	"github.com/multiversx/mx-chain-notifier-go/common"
@@ -14,12 +15,20 @@ type ArgsWebSocketProcessor struct {
 	Dispatcher dispatcher.Dispatcher
 	Upgrader   dispatcher.WSUpgrader
 	Marshaller marshal.Marshalizer
+
+	// BreakOnErrorCount is the number of consecutive write errors after which a websocket
+	// dispatcher is unregistered from the hub. If 0, it stops writing on the first error
+	// and it is unregistered once its connection is closed
+	BreakOnErrorCount int
 }
 
+const resumeIDQueryParam = "resumeId"
+
 type websocketProcessor struct {
-	dispatcher dispatcher.Dispatcher
-	upgrader   dispatcher.WSUpgrader
-	marshaller marshal.Marshalizer
+	dispatcher        dispatcher.Dispatcher
+	upgrader          dispatcher.WSUpgrader
+	marshaller        marshal.Marshalizer
+	breakOnErrorCount int
 }
 
 // NewWebSocketProcessor creates a new websocketProcessor component
@@ -30,9 +39,10 @@ func NewWebSocketProcessor(args ArgsWebSocketProcessor) (*websocketProcessor, er
 	}
 
 	return &websocketProcessor{
-		dispatcher: args.Dispatcher,
-		upgrader:   args.Upgrader,
-		marshaller: args.Marshaller,
+		dispatcher:        args.Dispatcher,
+		upgrader:          args.Upgrader,
+		marshaller:        args.Marshaller,
+		breakOnErrorCount: args.BreakOnErrorCount,
 	}, nil
 }
 
@@ -46,12 +56,24 @@ func checkArgs(args ArgsWebSocketProcessor) error {
 	if check.IfNil(args.Marshaller) {
 		return common.ErrNilMarshaller
 	}
+	if args.BreakOnErrorCount < 0 {
+		return ErrInvalidBreakOnErrorCount
+	}
 
 	return nil
 }
 
 // ServeHTTP is the entry point used by a http server to serve the websocket upgrader
+// The client can supply a resume id, as the resumeId query parameter, to get the subscriptions
+// of its previous connection, which are sent in a subscribe ack frame once connected
 func (wh *websocketProcessor) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	resumeID, err := getResumeID(r)
+	if err != nil {
+		log.Debug("rejected websocket connection", "err", err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	conn, err := wh.upgrader.Upgrade(w, r, nil)
 	if err != nil {
 		log.Error("failed upgrading connection", "err", err.Error())
@@ -59,9 +81,11 @@ func (wh *websocketProcessor) ServeHTTP(w http.ResponseWriter, r *http.Request)
 	}
 
 	args := argsWebSocketDispatcher{
-		Dispatcher: wh.dispatcher,
-		Conn:       conn,
-		Marshaller: wh.marshaller,
+		Dispatcher:        wh.dispatcher,
+		Conn:              conn,
+		Marshaller:        wh.marshaller,
+		BreakOnErrorCount: wh.breakOnErrorCount,
+		ResumeID:          resumeID,
 	}
 	wsDispatcher, err := newWebSocketDispatcher(args)
 	if err != nil {
@@ -69,11 +93,28 @@ func (wh *websocketProcessor) ServeHTTP(w http.ResponseWriter, r *http.Request)
 		return
 	}
 	wsDispatcher.dispatcher.RegisterEvent(wsDispatcher)
+	if wsDispatcher.IsResumable() && len(wsDispatcher.dispatcher.GetSubscriptions(resumeID)) > 0 {
+		wsDispatcher.sendSubscribeAckFrame()
+	}
 
 	go wsDispatcher.writePump()
 	go wsDispatcher.readPump()
 }
 
+func getResumeID(r *http.Request) (uuid.UUID, error) {
+	resumeID := r.URL.Query().Get(resumeIDQueryParam)
+	if resumeID == "" {
+		return uuid.Nil, nil
+	}
+
+	id, err := uuid.Parse(resumeID)
+	if err != nil || id == uuid.Nil {
+		return uuid.Nil, ErrInvalidResumeID
+	}
+
+	return id, nil
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (wh *websocketProcessor) IsInterfaceNil() bool {
 	return wh == nil
@@ -1,11 +1,21 @@
 package ws_test
 
 import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/multiversx/mx-chain-core-go/core/check"
 	"github.com/multiversx/mx-chain-core-go/core/mock"
 	"github.com/multiversx/mx-chain-notifier-go/common"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/dispatcher"
 	"github.com/multiversx/mx-chain-notifier-go/dispatcher/ws"
 	"github.com/multiversx/mx-chain-notifier-go/mocks"
 	"github.com/stretchr/testify/assert"
@@ -56,6 +66,17 @@ func TestNewWebSocketHandler(t *testing.T) {
 		assert.Equal(t, common.ErrNilMarshaller, err)
 	})
 
+	t.Run("negative break on error count", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsWSHandler()
+		args.BreakOnErrorCount = -1
+
+		wh, err := ws.NewWebSocketProcessor(args)
+		require.True(t, check.IfNil(wh))
+		assert.Equal(t, ws.ErrInvalidBreakOnErrorCount, err)
+	})
+
 	t.Run("should work", func(t *testing.T) {
 		t.Parallel()
 
@@ -65,3 +86,100 @@ func TestNewWebSocketHandler(t *testing.T) {
 		require.Nil(t, err)
 	})
 }
+
+// recordingWriter implements io.WriteCloser, sending the written messages on a channel
+type recordingWriter struct {
+	buff     bytes.Buffer
+	messages chan []byte
+}
+
+// Write -
+func (rw *recordingWriter) Write(p []byte) (n int, err error) {
+	return rw.buff.Write(p)
+}
+
+// Close -
+func (rw *recordingWriter) Close() error {
+	rw.messages <- rw.buff.Bytes()
+	return nil
+}
+
+func TestServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid resume id should reject the connection", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsWSHandler()
+		args.Upgrader = &mocks.WSUpgraderStub{
+			UpgradeCalled: func(w http.ResponseWriter, r *http.Request, responseHeader http.Header) (dispatcher.WSConnection, error) {
+				require.Fail(t, "should have not been called")
+				return nil, nil
+			},
+		}
+		wh, err := ws.NewWebSocketProcessor(args)
+		require.Nil(t, err)
+
+		recorder := httptest.NewRecorder()
+		wh.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/hub/ws?resumeId=invalid", nil))
+		require.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+
+	t.Run("resume id should restore the subscriptions of the previous connection", func(t *testing.T) {
+		t.Parallel()
+
+		resumeID := uuid.New()
+		messages := make(chan []byte, 1)
+		stopRead := make(chan struct{})
+		defer close(stopRead)
+		conn := &mocks.WSConnStub{
+			NextWriterCalled: func(messageType int) (io.WriteCloser, error) {
+				return &recordingWriter{messages: messages}, nil
+			},
+			ReadMessageCalled: func() (messageType int, p []byte, err error) {
+				<-stopRead
+				return 0, nil, errors.New("connection closed")
+			},
+		}
+
+		mutRegistered := sync.Mutex{}
+		var registered dispatcher.EventDispatcher
+		args := createMockArgsWSHandler()
+		args.Marshaller = &mock.MarshalizerMock{}
+		args.Upgrader = &mocks.WSUpgraderStub{
+			UpgradeCalled: func(w http.ResponseWriter, r *http.Request, responseHeader http.Header) (dispatcher.WSConnection, error) {
+				return conn, nil
+			},
+		}
+		args.Dispatcher = &mocks.HubStub{
+			RegisterEventCalled: func(event dispatcher.EventDispatcher) {
+				mutRegistered.Lock()
+				registered = event
+				mutRegistered.Unlock()
+			},
+			GetSubscriptionsCalled: func(dispatcherID uuid.UUID) []data.Subscription {
+				return []data.Subscription{{ID: 1, DispatcherID: dispatcherID}}
+			},
+		}
+		wh, err := ws.NewWebSocketProcessor(args)
+		require.Nil(t, err)
+
+		wh.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/hub/ws?resumeId="+resumeID.String(), nil))
+
+		mutRegistered.Lock()
+		require.Equal(t, resumeID, registered.GetID())
+		require.True(t, registered.IsResumable())
+		mutRegistered.Unlock()
+
+		select {
+		case message := <-messages:
+			ackFrame := data.SubscribeAckFrame{}
+			require.Nil(t, args.Marshaller.Unmarshal(&ackFrame, message))
+			require.Equal(t, common.SubscribeAckFrameType, ackFrame.Type)
+			require.Equal(t, resumeID.String(), ackFrame.DispatcherID)
+			require.Equal(t, 1, len(ackFrame.Subscriptions))
+		case <-time.After(time.Second):
+			require.Fail(t, "subscribe ack frame was not written")
+		}
+	})
+}
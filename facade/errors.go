@@ -5,5 +5,20 @@ import "errors"
 // ErrNilEventsHandler signals that a nil events handler was provided
 var ErrNilEventsHandler = errors.New("nil events handler")
 
+// ErrNilHub signals that a nil hub was provided
+var ErrNilHub = errors.New("nil hub")
+
+// ErrNilWebhookRegistry signals that a nil webhook registry was provided
+var ErrNilWebhookRegistry = errors.New("nil webhook registry")
+
+// ErrNilConsumerRegistry signals that a nil consumer registry was provided
+var ErrNilConsumerRegistry = errors.New("nil consumer registry")
+
 // ErrNilWSHandler signals that a nil websocket handler was provided
 var ErrNilWSHandler = errors.New("nil websocket handler")
+
+// ErrNilMetricsHandler signals that a nil metrics handler was provided
+var ErrNilMetricsHandler = errors.New("nil metrics handler")
+
+// ErrNilHealthChecker signals that a nil health checker was provided
+var ErrNilHealthChecker = errors.New("nil health checker")
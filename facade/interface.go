@@ -1,6 +1,7 @@
 package facade
 
 import (
+	"github.com/google/uuid"
 	"github.com/multiversx/mx-chain-notifier-go/data"
 	"github.com/multiversx/mx-chain-notifier-go/dispatcher"
 )
@@ -9,8 +10,11 @@ import (
 // This will handle push events from observer node.
 type EventsHandler interface {
 	HandleSaveBlockEvents(allEvents data.ArgsSaveBlockData) error
-	HandleRevertEvents(revertBlock data.RevertBlock)
-	HandleFinalizedEvents(finalizedBlock data.FinalizedBlock)
+	HandleRevertEvents(revertBlock data.RevertBlock) error
+	HandleFinalizedEvents(finalizedBlock data.FinalizedBlock) error
+	HandleRoundEvents(roundEvents data.RoundEvents) error
+	HandleValidatorsRatingEvent(validatorsRating data.ValidatorsRatingEvent) error
+	HandleAccountsEvents(accountsEvents data.AccountsEvents) error
 	IsInterfaceNil() bool
 }
 
@@ -21,7 +25,7 @@ type HubHandler interface {
 	Run()
 	RegisterEvent(event dispatcher.EventDispatcher)
 	UnregisterEvent(event dispatcher.EventDispatcher)
-	Subscribe(event data.SubscribeEvent)
+	Subscribe(event data.SubscribeEvent) error
 	Close() error
 	IsInterfaceNil() bool
 }
@@ -35,6 +39,22 @@ type Publisher interface {
 	IsInterfaceNil() bool
 }
 
+// WebhookRegistry defines the behaviour of a component which holds the webhooks
+// registered via the REST api
+type WebhookRegistry interface {
+	Register(registration data.WebhookRegistration) (uuid.UUID, error)
+	Unregister(id uuid.UUID) error
+	IsInterfaceNil() bool
+}
+
+// ConsumerRegistry defines the behaviour of a component which holds the subscriptions of the
+// rabbitMQ consumers registered via the REST api
+type ConsumerRegistry interface {
+	Register(subscription data.ConsumerSubscription) error
+	Unregister(consumerID string) error
+	IsInterfaceNil() bool
+}
+
 // EventsInterceptor defines the behaviour of an events interceptor component
 type EventsInterceptor interface {
 	ProcessBlockEvents(eventsData *data.ArgsSaveBlockData) (*data.InterceptorBlockData, error)
@@ -1,31 +1,51 @@
 package facade
 
 import (
+	"context"
 	"net/http"
+	"strings"
 
+	"github.com/google/uuid"
 	"github.com/multiversx/mx-chain-core-go/core/check"
 	logger "github.com/multiversx/mx-chain-logger-go"
 	"github.com/multiversx/mx-chain-notifier-go/common"
 	"github.com/multiversx/mx-chain-notifier-go/config"
 	"github.com/multiversx/mx-chain-notifier-go/data"
 	"github.com/multiversx/mx-chain-notifier-go/dispatcher"
+	"github.com/multiversx/mx-chain-notifier-go/filters"
 )
 
 var log = logger.GetOrCreate("facade")
 
 // ArgsNotifierFacade defines the arguments necessary for notifierFacade creation
 type ArgsNotifierFacade struct {
-	APIConfig            config.ConnectorApiConfig
-	EventsHandler        EventsHandler
-	WSHandler            dispatcher.WSHandler
-	StatusMetricsHandler common.StatusMetricsHandler
+	APIConfig              config.ConnectorApiConfig
+	EventsHandler          EventsHandler
+	WSHandler              dispatcher.WSHandler
+	Hub                    dispatcher.Hub
+	WebhookRegistry        WebhookRegistry
+	ConsumerRegistry       ConsumerRegistry
+	EventStore             dispatcher.EventStore
+	StatusMetricsHandler   common.StatusMetricsHandler
+	ProcessedBlocksTracker common.ProcessedBlocksTracker
+	PayloadVersions        data.PayloadVersionsResponse
+	MetricsHandlers        []common.PrometheusMetricsHandler
+	HealthCheckers         map[string]common.HealthChecker
 }
 
 type notifierFacade struct {
-	config        config.ConnectorApiConfig
-	eventsHandler EventsHandler
-	wsHandler     dispatcher.WSHandler
-	statusMetrics common.StatusMetricsHandler
+	config           config.ConnectorApiConfig
+	eventsHandler    EventsHandler
+	wsHandler        dispatcher.WSHandler
+	hub              dispatcher.Hub
+	webhookRegistry  WebhookRegistry
+	consumerRegistry ConsumerRegistry
+	eventStore       dispatcher.EventStore
+	statusMetrics    common.StatusMetricsHandler
+	processedBlocks  common.ProcessedBlocksTracker
+	payloadVersions  data.PayloadVersionsResponse
+	metricsHandlers  []common.PrometheusMetricsHandler
+	healthCheckers   map[string]common.HealthChecker
 }
 
 // NewNotifierFacade creates a new notifier facade instance
@@ -36,10 +56,18 @@ func NewNotifierFacade(args ArgsNotifierFacade) (*notifierFacade, error) {
 	}
 
 	return &notifierFacade{
-		eventsHandler: args.EventsHandler,
-		config:        args.APIConfig,
-		wsHandler:     args.WSHandler,
-		statusMetrics: args.StatusMetricsHandler,
+		eventsHandler:    args.EventsHandler,
+		config:           args.APIConfig,
+		wsHandler:        args.WSHandler,
+		hub:              args.Hub,
+		webhookRegistry:  args.WebhookRegistry,
+		consumerRegistry: args.ConsumerRegistry,
+		eventStore:       args.EventStore,
+		statusMetrics:    args.StatusMetricsHandler,
+		processedBlocks:  args.ProcessedBlocksTracker,
+		payloadVersions:  args.PayloadVersions,
+		metricsHandlers:  args.MetricsHandlers,
+		healthCheckers:   args.HealthCheckers,
 	}, nil
 }
 
@@ -50,9 +78,34 @@ func checkArgs(args ArgsNotifierFacade) error {
 	if check.IfNil(args.WSHandler) {
 		return ErrNilWSHandler
 	}
+	if check.IfNil(args.Hub) {
+		return ErrNilHub
+	}
+	if check.IfNil(args.WebhookRegistry) {
+		return ErrNilWebhookRegistry
+	}
+	if check.IfNil(args.ConsumerRegistry) {
+		return ErrNilConsumerRegistry
+	}
+	if check.IfNil(args.EventStore) {
+		return common.ErrNilEventStore
+	}
 	if check.IfNil(args.StatusMetricsHandler) {
 		return common.ErrNilStatusMetricsHandler
 	}
+	if check.IfNil(args.ProcessedBlocksTracker) {
+		return common.ErrNilProcessedBlocksTracker
+	}
+	for _, metricsHandler := range args.MetricsHandlers {
+		if check.IfNil(metricsHandler) {
+			return ErrNilMetricsHandler
+		}
+	}
+	for _, healthChecker := range args.HealthCheckers {
+		if check.IfNil(healthChecker) {
+			return ErrNilHealthChecker
+		}
+	}
 
 	return nil
 }
@@ -64,13 +117,28 @@ func (nf *notifierFacade) HandlePushEvents(allEvents data.ArgsSaveBlockData) err
 }
 
 // HandleRevertEvents will handle revents events received from observer
-func (nf *notifierFacade) HandleRevertEvents(events data.RevertBlock) {
-	nf.eventsHandler.HandleRevertEvents(events)
+func (nf *notifierFacade) HandleRevertEvents(events data.RevertBlock) error {
+	return nf.eventsHandler.HandleRevertEvents(events)
 }
 
 // HandleFinalizedEvents will handle finalized events received from observer
-func (nf *notifierFacade) HandleFinalizedEvents(events data.FinalizedBlock) {
-	nf.eventsHandler.HandleFinalizedEvents(events)
+func (nf *notifierFacade) HandleFinalizedEvents(events data.FinalizedBlock) error {
+	return nf.eventsHandler.HandleFinalizedEvents(events)
+}
+
+// HandleRoundEvents will handle the rounds info received from observer
+func (nf *notifierFacade) HandleRoundEvents(events data.RoundEvents) error {
+	return nf.eventsHandler.HandleRoundEvents(events)
+}
+
+// HandleValidatorsRatingEvent will handle the validators rating received from observer
+func (nf *notifierFacade) HandleValidatorsRatingEvent(event data.ValidatorsRatingEvent) error {
+	return nf.eventsHandler.HandleValidatorsRatingEvent(event)
+}
+
+// HandleAccountsEvents will handle the altered accounts received from observer
+func (nf *notifierFacade) HandleAccountsEvents(events data.AccountsEvents) error {
+	return nf.eventsHandler.HandleAccountsEvents(events)
 }
 
 // ServeHTTP will handle a websocket request
@@ -78,6 +146,77 @@ func (nf *notifierFacade) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	nf.wsHandler.ServeHTTP(w, r)
 }
 
+// GetSubscriptionStats will return the hub dispatchers and subscriptions stats
+func (nf *notifierFacade) GetSubscriptionStats() data.SubscriptionStatsResponse {
+	return nf.hub.GetSubscriptionStats()
+}
+
+// MatchEvent will return the hub subscriptions matching the provided event, without delivering it
+func (nf *notifierFacade) MatchEvent(event data.Event) data.EventMatchResponse {
+	return nf.hub.MatchEvent(event)
+}
+
+// DisconnectDispatcher will force disconnect the dispatcher with the provided id
+func (nf *notifierFacade) DisconnectDispatcher(dispatcherID uuid.UUID) error {
+	return nf.hub.DisconnectDispatcher(dispatcherID)
+}
+
+// RegisterWebhook will register the webhook and return its id
+func (nf *notifierFacade) RegisterWebhook(registration data.WebhookRegistration) (uuid.UUID, error) {
+	return nf.webhookRegistry.Register(registration)
+}
+
+// UnregisterWebhook will remove the webhook with the provided id
+func (nf *notifierFacade) UnregisterWebhook(id uuid.UUID) error {
+	return nf.webhookRegistry.Unregister(id)
+}
+
+// RegisterConsumer will set the subscriptions of the rabbitMQ consumer, replacing the
+// previous ones, if any
+func (nf *notifierFacade) RegisterConsumer(subscription data.ConsumerSubscription) error {
+	return nf.consumerRegistry.Register(subscription)
+}
+
+// UnregisterConsumer will remove the subscriptions of the rabbitMQ consumer
+func (nf *notifierFacade) UnregisterConsumer(consumerID string) error {
+	return nf.consumerRegistry.Unregister(consumerID)
+}
+
+// GetEventsByNonceRange will return the stored block events with nonces between fromNonce
+// and toNonce, inclusive. The number of returned blocks is limited by the event store
+func (nf *notifierFacade) GetEventsByNonceRange(fromNonce uint64, toNonce uint64) ([]data.BlockEvents, error) {
+	if fromNonce > toNonce {
+		return nil, common.ErrInvalidNonceRange
+	}
+
+	blocks, err := nf.eventStore.GetFromNonce(fromNonce)
+	if err != nil {
+		return nil, err
+	}
+
+	blocksInRange := make([]data.BlockEvents, 0, len(blocks))
+	for _, block := range blocks {
+		if block.Nonce > toNonce {
+			break
+		}
+
+		blocksInRange = append(blocksInRange, block)
+	}
+
+	return blocksInRange, nil
+}
+
+// UpdateFilter will replace the hub events filter with the one created from the provided
+// filter config, without restarting the notifier
+func (nf *notifierFacade) UpdateFilter(cfg filters.FilterConfig) error {
+	filter, err := filters.NewConfigFilter(cfg)
+	if err != nil {
+		return err
+	}
+
+	return nf.hub.UpdateFilter(filter)
+}
+
 // GetConnectorUserAndPass will return username and password (for basic authentication)
 // from config
 func (nf *notifierFacade) GetConnectorUserAndPass() (string, string) {
@@ -90,8 +229,65 @@ func (nf *notifierFacade) GetMetrics() map[string]*data.EndpointMetricsResponse
 }
 
 // GetMetricsForPrometheus will return metrics in prometheus format
+// It includes the metrics of the components active for the configured api type
 func (nf *notifierFacade) GetMetricsForPrometheus() string {
-	return nf.statusMetrics.GetMetricsForPrometheus()
+	stringBuilder := strings.Builder{}
+	stringBuilder.WriteString(nf.statusMetrics.GetMetricsForPrometheus())
+
+	for _, metricsHandler := range nf.metricsHandlers {
+		stringBuilder.WriteString(metricsHandler.GetMetricsForPrometheus())
+	}
+
+	return stringBuilder.String()
+}
+
+// GetProcessedBlocks returns the last processed block of each shard
+func (nf *notifierFacade) GetProcessedBlocks() []data.ProcessedBlock {
+	return nf.processedBlocks.GetProcessedBlocks()
+}
+
+// GetPayloadVersions returns the supported observer payload versions
+func (nf *notifierFacade) GetPayloadVersions() data.PayloadVersionsResponse {
+	return nf.payloadVersions
+}
+
+// GetHealthStatus returns the health state of each component. The overall status is
+// down if any of the components is down; not applicable components are not considered
+func (nf *notifierFacade) GetHealthStatus() data.HealthStatusResponse {
+	healthStatus := data.HealthStatusResponse{
+		Status:     common.HealthStateUp,
+		Components: make(map[string]string),
+	}
+
+	for name, healthChecker := range nf.healthCheckers {
+		state := healthChecker.GetHealthState()
+		healthStatus.Components[name] = state
+
+		if state == common.HealthStateDown {
+			healthStatus.Status = common.HealthStateDown
+		}
+	}
+
+	return healthStatus
+}
+
+// GetReadinessStatus pings each component and returns the failed checks. The overall
+// status is down if any of the checks failed
+func (nf *notifierFacade) GetReadinessStatus(ctx context.Context) data.ReadinessStatusResponse {
+	readinessStatus := data.ReadinessStatusResponse{
+		Status:       common.HealthStateUp,
+		FailedChecks: make(map[string]string),
+	}
+
+	for name, healthChecker := range nf.healthCheckers {
+		err := healthChecker.Ping(ctx)
+		if err != nil {
+			readinessStatus.Status = common.HealthStateDown
+			readinessStatus.FailedChecks[name] = err.Error()
+		}
+	}
+
+	return readinessStatus
 }
 
 // IsInterfaceNil returns true if there is no value under the interface
@@ -1,10 +1,13 @@
 package facade_test
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/google/uuid"
 	"github.com/multiversx/mx-chain-core-go/core/check"
 	"github.com/multiversx/mx-chain-core-go/data/block"
 	"github.com/multiversx/mx-chain-core-go/data/outport"
@@ -12,7 +15,9 @@ import (
 	"github.com/multiversx/mx-chain-notifier-go/common"
 	"github.com/multiversx/mx-chain-notifier-go/config"
 	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/dispatcher"
 	"github.com/multiversx/mx-chain-notifier-go/facade"
+	"github.com/multiversx/mx-chain-notifier-go/filters"
 	"github.com/multiversx/mx-chain-notifier-go/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -20,10 +25,15 @@ import (
 
 func createMockFacadeArgs() facade.ArgsNotifierFacade {
 	return facade.ArgsNotifierFacade{
-		EventsHandler:        &mocks.EventsHandlerStub{},
-		APIConfig:            config.ConnectorApiConfig{},
-		WSHandler:            &mocks.WSHandlerStub{},
-		StatusMetricsHandler: &mocks.StatusMetricsStub{},
+		EventsHandler:          &mocks.EventsHandlerStub{},
+		APIConfig:              config.ConnectorApiConfig{},
+		WSHandler:              &mocks.WSHandlerStub{},
+		Hub:                    &mocks.HubStub{},
+		WebhookRegistry:        &mocks.WebhookRegistryStub{},
+		ConsumerRegistry:       &mocks.ConsumerRegistryStub{},
+		EventStore:             &mocks.EventStoreStub{},
+		StatusMetricsHandler:   &mocks.StatusMetricsStub{},
+		ProcessedBlocksTracker: &mocks.ProcessedBlocksTrackerStub{},
 	}
 }
 
@@ -52,6 +62,50 @@ func TestNewNotifierFacade(t *testing.T) {
 		require.Equal(t, facade.ErrNilWSHandler, err)
 	})
 
+	t.Run("nil hub", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockFacadeArgs()
+		args.Hub = nil
+
+		f, err := facade.NewNotifierFacade(args)
+		require.True(t, check.IfNil(f))
+		require.Equal(t, facade.ErrNilHub, err)
+	})
+
+	t.Run("nil webhook registry", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockFacadeArgs()
+		args.WebhookRegistry = nil
+
+		f, err := facade.NewNotifierFacade(args)
+		require.True(t, check.IfNil(f))
+		require.Equal(t, facade.ErrNilWebhookRegistry, err)
+	})
+
+	t.Run("nil consumer registry", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockFacadeArgs()
+		args.ConsumerRegistry = nil
+
+		f, err := facade.NewNotifierFacade(args)
+		require.True(t, check.IfNil(f))
+		require.Equal(t, facade.ErrNilConsumerRegistry, err)
+	})
+
+	t.Run("nil event store", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockFacadeArgs()
+		args.EventStore = nil
+
+		f, err := facade.NewNotifierFacade(args)
+		require.True(t, check.IfNil(f))
+		require.Equal(t, common.ErrNilEventStore, err)
+	})
+
 	t.Run("nil status metrics handler", func(t *testing.T) {
 		t.Parallel()
 
@@ -63,6 +117,28 @@ func TestNewNotifierFacade(t *testing.T) {
 		require.Equal(t, common.ErrNilStatusMetricsHandler, err)
 	})
 
+	t.Run("nil processed blocks tracker", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockFacadeArgs()
+		args.ProcessedBlocksTracker = nil
+
+		f, err := facade.NewNotifierFacade(args)
+		require.True(t, check.IfNil(f))
+		require.Equal(t, common.ErrNilProcessedBlocksTracker, err)
+	})
+
+	t.Run("nil metrics handler", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockFacadeArgs()
+		args.MetricsHandlers = []common.PrometheusMetricsHandler{nil}
+
+		f, err := facade.NewNotifierFacade(args)
+		require.True(t, check.IfNil(f))
+		require.Equal(t, facade.ErrNilMetricsHandler, err)
+	})
+
 	t.Run("should work", func(t *testing.T) {
 		t.Parallel()
 
@@ -128,9 +204,10 @@ func TestHandleRevertEvents(t *testing.T) {
 
 	revertWasCalled := false
 	args.EventsHandler = &mocks.EventsHandlerStub{
-		HandleRevertEventsCalled: func(revertBlock data.RevertBlock) {
+		HandleRevertEventsCalled: func(revertBlock data.RevertBlock) error {
 			revertWasCalled = true
 			assert.Equal(t, revertData, revertBlock)
+			return nil
 		},
 	}
 	facade, err := facade.NewNotifierFacade(args)
@@ -152,9 +229,10 @@ func TestHandleFinalizedEvents(t *testing.T) {
 
 	finalizedWasCalled := false
 	args.EventsHandler = &mocks.EventsHandlerStub{
-		HandleFinalizedEventsCalled: func(finalizedBlock data.FinalizedBlock) {
+		HandleFinalizedEventsCalled: func(finalizedBlock data.FinalizedBlock) error {
 			finalizedWasCalled = true
 			assert.Equal(t, finalizedData, finalizedBlock)
+			return nil
 		},
 	}
 	facade, err := facade.NewNotifierFacade(args)
@@ -184,6 +262,260 @@ func TestServerHTTP(t *testing.T) {
 	assert.True(t, serveHTTPWasCalled)
 }
 
+func TestDisconnectDispatcher(t *testing.T) {
+	t.Parallel()
+
+	args := createMockFacadeArgs()
+
+	dispatcherID := uuid.New()
+	wasCalled := false
+	args.Hub = &mocks.HubStub{
+		DisconnectDispatcherCalled: func(id uuid.UUID) error {
+			wasCalled = true
+			assert.Equal(t, dispatcherID, id)
+			return nil
+		},
+	}
+
+	facade, err := facade.NewNotifierFacade(args)
+	require.Nil(t, err)
+
+	err = facade.DisconnectDispatcher(dispatcherID)
+	require.Nil(t, err)
+	assert.True(t, wasCalled)
+}
+
+func TestGetSubscriptionStats(t *testing.T) {
+	t.Parallel()
+
+	args := createMockFacadeArgs()
+
+	expectedStats := data.SubscriptionStatsResponse{NumDispatchers: 2, NumSubscriptions: 3}
+	args.Hub = &mocks.HubStub{
+		GetSubscriptionStatsCalled: func() data.SubscriptionStatsResponse {
+			return expectedStats
+		},
+	}
+
+	facade, err := facade.NewNotifierFacade(args)
+	require.Nil(t, err)
+
+	require.Equal(t, expectedStats, facade.GetSubscriptionStats())
+}
+
+func TestMatchEvent(t *testing.T) {
+	t.Parallel()
+
+	args := createMockFacadeArgs()
+
+	event := data.Event{Address: "erd1", Identifier: "swap"}
+	expectedResponse := data.EventMatchResponse{
+		Subscriptions: []data.Subscription{{ID: 1, Address: "erd1"}},
+	}
+	args.Hub = &mocks.HubStub{
+		MatchEventCalled: func(ev data.Event) data.EventMatchResponse {
+			require.Equal(t, event, ev)
+			return expectedResponse
+		},
+	}
+
+	facade, err := facade.NewNotifierFacade(args)
+	require.Nil(t, err)
+
+	require.Equal(t, expectedResponse, facade.MatchEvent(event))
+}
+
+func TestUpdateFilter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid filter config should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockFacadeArgs()
+		wasCalled := false
+		args.Hub = &mocks.HubStub{
+			UpdateFilterCalled: func(filter dispatcher.EventFilter) error {
+				wasCalled = true
+				return nil
+			},
+		}
+
+		facade, err := facade.NewNotifierFacade(args)
+		require.Nil(t, err)
+
+		err = facade.UpdateFilter(filters.FilterConfig{Prefixes: []string{"erd"}})
+		require.True(t, errors.Is(err, filters.ErrInvalidAddressPrefix))
+		assert.False(t, wasCalled)
+	})
+
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockFacadeArgs()
+		var updatedFilter dispatcher.EventFilter
+		args.Hub = &mocks.HubStub{
+			UpdateFilterCalled: func(filter dispatcher.EventFilter) error {
+				updatedFilter = filter
+				return nil
+			},
+		}
+
+		facade, err := facade.NewNotifierFacade(args)
+		require.Nil(t, err)
+
+		err = facade.UpdateFilter(filters.FilterConfig{Addresses: []string{"erd1"}})
+		require.Nil(t, err)
+
+		matchAll := data.Subscription{MatchLevel: dispatcher.MatchAll}
+		assert.True(t, updatedFilter.MatchEvent(matchAll, data.Event{Address: "erd1"}))
+		assert.False(t, updatedFilter.MatchEvent(matchAll, data.Event{Address: "erd2"}))
+	})
+}
+
+func TestRegisterWebhook(t *testing.T) {
+	t.Parallel()
+
+	args := createMockFacadeArgs()
+
+	expectedID := uuid.New()
+	registration := data.WebhookRegistration{URL: "http://localhost:8080/events"}
+	args.WebhookRegistry = &mocks.WebhookRegistryStub{
+		RegisterCalled: func(reg data.WebhookRegistration) (uuid.UUID, error) {
+			assert.Equal(t, registration, reg)
+			return expectedID, nil
+		},
+	}
+
+	f, err := facade.NewNotifierFacade(args)
+	require.Nil(t, err)
+
+	id, err := f.RegisterWebhook(registration)
+	require.Nil(t, err)
+	assert.Equal(t, expectedID, id)
+}
+
+func TestUnregisterWebhook(t *testing.T) {
+	t.Parallel()
+
+	args := createMockFacadeArgs()
+
+	webhookID := uuid.New()
+	wasCalled := false
+	args.WebhookRegistry = &mocks.WebhookRegistryStub{
+		UnregisterCalled: func(id uuid.UUID) error {
+			wasCalled = true
+			assert.Equal(t, webhookID, id)
+			return nil
+		},
+	}
+
+	f, err := facade.NewNotifierFacade(args)
+	require.Nil(t, err)
+
+	err = f.UnregisterWebhook(webhookID)
+	require.Nil(t, err)
+	assert.True(t, wasCalled)
+}
+
+func TestRegisterConsumer(t *testing.T) {
+	t.Parallel()
+
+	args := createMockFacadeArgs()
+
+	subscription := data.ConsumerSubscription{ConsumerID: "consumer1"}
+	wasCalled := false
+	args.ConsumerRegistry = &mocks.ConsumerRegistryStub{
+		RegisterCalled: func(s data.ConsumerSubscription) error {
+			wasCalled = true
+			assert.Equal(t, subscription, s)
+			return nil
+		},
+	}
+
+	f, err := facade.NewNotifierFacade(args)
+	require.Nil(t, err)
+
+	err = f.RegisterConsumer(subscription)
+	require.Nil(t, err)
+	assert.True(t, wasCalled)
+}
+
+func TestUnregisterConsumer(t *testing.T) {
+	t.Parallel()
+
+	args := createMockFacadeArgs()
+
+	args.ConsumerRegistry = &mocks.ConsumerRegistryStub{
+		UnregisterCalled: func(consumerID string) error {
+			assert.Equal(t, "consumer1", consumerID)
+			return common.ErrConsumerNotFound
+		},
+	}
+
+	f, err := facade.NewNotifierFacade(args)
+	require.Nil(t, err)
+
+	err = f.UnregisterConsumer("consumer1")
+	assert.Equal(t, common.ErrConsumerNotFound, err)
+}
+
+func TestGetEventsByNonceRange(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid nonce range should error", func(t *testing.T) {
+		t.Parallel()
+
+		f, err := facade.NewNotifierFacade(createMockFacadeArgs())
+		require.Nil(t, err)
+
+		blocks, err := f.GetEventsByNonceRange(3, 2)
+		require.Nil(t, blocks)
+		require.Equal(t, common.ErrInvalidNonceRange, err)
+	})
+
+	t.Run("event store error should be returned", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		args := createMockFacadeArgs()
+		args.EventStore = &mocks.EventStoreStub{
+			GetFromNonceCalled: func(nonce uint64) ([]data.BlockEvents, error) {
+				return nil, expectedErr
+			},
+		}
+
+		f, err := facade.NewNotifierFacade(args)
+		require.Nil(t, err)
+
+		blocks, err := f.GetEventsByNonceRange(1, 2)
+		require.Nil(t, blocks)
+		require.Equal(t, expectedErr, err)
+	})
+
+	t.Run("should return the blocks up to the last nonce", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockFacadeArgs()
+		args.EventStore = &mocks.EventStoreStub{
+			GetFromNonceCalled: func(nonce uint64) ([]data.BlockEvents, error) {
+				assert.Equal(t, uint64(2), nonce)
+				return []data.BlockEvents{
+					{Hash: "hash2", Nonce: 2},
+					{Hash: "hash3", Nonce: 3},
+					{Hash: "hash4", Nonce: 4},
+				}, nil
+			},
+		}
+
+		f, err := facade.NewNotifierFacade(args)
+		require.Nil(t, err)
+
+		blocks, err := f.GetEventsByNonceRange(2, 3)
+		require.Nil(t, err)
+		require.Equal(t, []data.BlockEvents{{Hash: "hash2", Nonce: 2}, {Hash: "hash3", Nonce: 3}}, blocks)
+	})
+}
+
 func TestGetters(t *testing.T) {
 	t.Parallel()
 
@@ -201,3 +533,201 @@ func TestGetters(t *testing.T) {
 	assert.Equal(t, expuser, user)
 	assert.Equal(t, exppass, pass)
 }
+
+func TestGetMetricsForPrometheus(t *testing.T) {
+	t.Parallel()
+
+	args := createMockFacadeArgs()
+	args.StatusMetricsHandler = &mocks.StatusMetricsStub{
+		GetMetricsForPrometheusCalled: func() string {
+			return "status\n"
+		},
+	}
+	args.MetricsHandlers = []common.PrometheusMetricsHandler{
+		&mocks.HubStub{
+			GetMetricsForPrometheusCalled: func() string {
+				return "hub\n"
+			},
+		},
+	}
+
+	f, err := facade.NewNotifierFacade(args)
+	require.Nil(t, err)
+
+	assert.Equal(t, "status\nhub\n", f.GetMetricsForPrometheus())
+}
+
+func TestGetProcessedBlocks(t *testing.T) {
+	t.Parallel()
+
+	expectedBlocks := []data.ProcessedBlock{{ShardID: 0, Nonce: 10, Hash: "hash10"}}
+	args := createMockFacadeArgs()
+	args.ProcessedBlocksTracker = &mocks.ProcessedBlocksTrackerStub{
+		GetProcessedBlocksCalled: func() []data.ProcessedBlock {
+			return expectedBlocks
+		},
+	}
+
+	f, err := facade.NewNotifierFacade(args)
+	require.Nil(t, err)
+
+	assert.Equal(t, expectedBlocks, f.GetProcessedBlocks())
+}
+
+func TestGetHealthStatus(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil health checker should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockFacadeArgs()
+		args.HealthCheckers = map[string]common.HealthChecker{
+			common.HubHealthComponent: nil,
+		}
+
+		f, err := facade.NewNotifierFacade(args)
+		require.True(t, check.IfNil(f))
+		require.Equal(t, facade.ErrNilHealthChecker, err)
+	})
+
+	t.Run("not applicable components should not degrade status", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockFacadeArgs()
+		args.HealthCheckers = map[string]common.HealthChecker{
+			common.HubHealthComponent:    createHealthChecker(common.HealthStateUp),
+			common.BrokerHealthComponent: createHealthChecker(common.HealthStateNotApplicable),
+		}
+
+		f, err := facade.NewNotifierFacade(args)
+		require.Nil(t, err)
+
+		expStatus := data.HealthStatusResponse{
+			Status: common.HealthStateUp,
+			Components: map[string]string{
+				common.HubHealthComponent:    common.HealthStateUp,
+				common.BrokerHealthComponent: common.HealthStateNotApplicable,
+			},
+		}
+		assert.Equal(t, expStatus, f.GetHealthStatus())
+	})
+
+	t.Run("component down should degrade status", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockFacadeArgs()
+		args.HealthCheckers = map[string]common.HealthChecker{
+			common.HubHealthComponent:    createHealthChecker(common.HealthStateUp),
+			common.BrokerHealthComponent: createHealthChecker(common.HealthStateDown),
+		}
+
+		f, err := facade.NewNotifierFacade(args)
+		require.Nil(t, err)
+
+		healthStatus := f.GetHealthStatus()
+		assert.Equal(t, common.HealthStateDown, healthStatus.Status)
+		assert.Equal(t, common.HealthStateDown, healthStatus.Components[common.BrokerHealthComponent])
+	})
+}
+
+func TestGetReadinessStatus(t *testing.T) {
+	t.Parallel()
+
+	errPublisherNotRunning := errors.New("publisher is not running")
+	errTooManyPendingBroadcasts := errors.New("too many pending broadcasts")
+	errConnectionFailure := errors.New("connection failure")
+
+	t.Run("all checks passed should be ready", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockFacadeArgs()
+		args.HealthCheckers = map[string]common.HealthChecker{
+			common.HubHealthComponent:    createReadinessChecker(nil),
+			common.BrokerHealthComponent: createReadinessChecker(nil),
+		}
+
+		f, err := facade.NewNotifierFacade(args)
+		require.Nil(t, err)
+
+		expStatus := data.ReadinessStatusResponse{
+			Status:       common.HealthStateUp,
+			FailedChecks: map[string]string{},
+		}
+		assert.Equal(t, expStatus, f.GetReadinessStatus(context.Background()))
+	})
+
+	t.Run("hub not running should not be ready", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockFacadeArgs()
+		args.HealthCheckers = map[string]common.HealthChecker{
+			common.HubHealthComponent:    createReadinessChecker(errPublisherNotRunning),
+			common.BrokerHealthComponent: createReadinessChecker(nil),
+		}
+
+		f, err := facade.NewNotifierFacade(args)
+		require.Nil(t, err)
+
+		expStatus := data.ReadinessStatusResponse{
+			Status: common.HealthStateDown,
+			FailedChecks: map[string]string{
+				common.HubHealthComponent: errPublisherNotRunning.Error(),
+			},
+		}
+		assert.Equal(t, expStatus, f.GetReadinessStatus(context.Background()))
+	})
+
+	t.Run("too many pending broadcasts should not be ready", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockFacadeArgs()
+		args.HealthCheckers = map[string]common.HealthChecker{
+			common.HubHealthComponent:    createReadinessChecker(errTooManyPendingBroadcasts),
+			common.BrokerHealthComponent: createReadinessChecker(nil),
+		}
+
+		f, err := facade.NewNotifierFacade(args)
+		require.Nil(t, err)
+
+		readinessStatus := f.GetReadinessStatus(context.Background())
+		assert.Equal(t, common.HealthStateDown, readinessStatus.Status)
+		assert.Equal(t, errTooManyPendingBroadcasts.Error(), readinessStatus.FailedChecks[common.HubHealthComponent])
+	})
+
+	t.Run("broker disconnected should not be ready", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockFacadeArgs()
+		args.HealthCheckers = map[string]common.HealthChecker{
+			common.HubHealthComponent:    createReadinessChecker(nil),
+			common.BrokerHealthComponent: createReadinessChecker(errConnectionFailure),
+		}
+
+		f, err := facade.NewNotifierFacade(args)
+		require.Nil(t, err)
+
+		expStatus := data.ReadinessStatusResponse{
+			Status: common.HealthStateDown,
+			FailedChecks: map[string]string{
+				common.BrokerHealthComponent: errConnectionFailure.Error(),
+			},
+		}
+		assert.Equal(t, expStatus, f.GetReadinessStatus(context.Background()))
+	})
+}
+
+func createReadinessChecker(pingErr error) common.HealthChecker {
+	return &mocks.PublisherStub{
+		PingCalled: func(ctx context.Context) error {
+			return pingErr
+		},
+	}
+}
+
+func createHealthChecker(state string) common.HealthChecker {
+	return &mocks.PublisherStub{
+		GetHealthStateCalled: func() string {
+			return state
+		},
+	}
+}
@@ -0,0 +1,64 @@
+package factory
+
+import (
+	"time"
+
+	marshalFactory "github.com/multiversx/mx-chain-core-go/marshal/factory"
+	"github.com/multiversx/mx-chain-notifier-go/common"
+	"github.com/multiversx/mx-chain-notifier-go/config"
+	"github.com/multiversx/mx-chain-notifier-go/disabled"
+	"github.com/multiversx/mx-chain-notifier-go/dispatcher"
+	"github.com/multiversx/mx-chain-notifier-go/grpc"
+	"github.com/multiversx/mx-chain-notifier-go/process"
+)
+
+// CreateGRPCObserverConnector will create the gRPC connector for observer node communication
+func CreateGRPCObserverConnector(
+	config config.GRPCConfig,
+	facade process.EventsFacadeHandler,
+	statusMetricsHandler common.StatusMetricsHandler,
+	processedBlocksTracker common.ProcessedBlocksTracker,
+	metricsCollector common.MetricsCollector,
+	generalConfig config.GeneralConfig,
+) (process.WSClient, error) {
+	if !config.Enabled {
+		return &disabled.WSHandler{}, nil
+	}
+
+	marshaller, err := marshalFactory.NewMarshalizer(config.DataMarshallerType)
+	if err != nil {
+		return nil, err
+	}
+
+	payloadHandler, err := CreatePayloadHandler(marshaller, facade, statusMetricsHandler, processedBlocksTracker, metricsCollector, generalConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return grpc.NewGRPCObserverConnector(grpc.ArgsGRPCObserverConnector{
+		URL:            config.URL,
+		Marshaller:     marshaller,
+		PayloadHandler: payloadHandler,
+	})
+}
+
+// CreateGRPCSubscriptionServer will create the gRPC server for the events subscription
+// streams, which are registered in the hub enabled by the websocket publisher
+func CreateGRPCSubscriptionServer(
+	publisherTypes []string,
+	config config.GRPCSubscriptionConfig,
+	commonHub dispatcher.Dispatcher,
+) (process.WSClient, error) {
+	if !config.Enabled {
+		return &disabled.WSHandler{}, nil
+	}
+	if !common.ContainsPublisherType(publisherTypes, common.WSPublisherType) {
+		return nil, common.ErrGRPCSubscriptionWithoutHub
+	}
+
+	return grpc.NewGRPCSubscriptionServer(grpc.ArgsGRPCSubscriptionServer{
+		URL:         config.URL,
+		Hub:         commonHub,
+		SendTimeout: time.Duration(config.SendTimeoutInMs) * time.Millisecond,
+	})
+}
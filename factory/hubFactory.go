@@ -1,29 +1,182 @@
 package factory
 
 import (
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/multiversx/mx-chain-core-go/marshal"
 	"github.com/multiversx/mx-chain-notifier-go/common"
+	"github.com/multiversx/mx-chain-notifier-go/config"
 	"github.com/multiversx/mx-chain-notifier-go/disabled"
 	"github.com/multiversx/mx-chain-notifier-go/dispatcher"
 	"github.com/multiversx/mx-chain-notifier-go/dispatcher/hub"
+	"github.com/multiversx/mx-chain-notifier-go/facade"
 	"github.com/multiversx/mx-chain-notifier-go/filters"
+	"github.com/multiversx/mx-chain-notifier-go/redis"
+	"github.com/multiversx/mx-chain-notifier-go/storage"
+	"github.com/multiversx/mx-chain-notifier-go/webhook"
+	"go.opentelemetry.io/otel"
 )
 
-// CreateHub creates a common hub component
-func CreateHub(apiType string) (dispatcher.Hub, error) {
-	switch apiType {
-	case common.MessageQueuePublisherType:
-		return &disabled.Hub{}, nil
-	case common.WSPublisherType:
-		return createHub()
-	default:
+// CreateHub creates a common hub component if the websocket publisher is enabled. If the
+// backplane is enabled, the hub shares its broadcasts with the other notifier instances
+func CreateHub(
+	publisherTypes []string,
+	apiConfig config.ConnectorApiConfig,
+	generalConfig config.GeneralConfig,
+	backplaneConfig config.BackplaneConfig,
+	lockerConfig config.RedisConfig,
+	eventStore dispatcher.EventStore,
+	subscriptionsStore dispatcher.SubscriptionsStore,
+	metricsCollector common.MetricsCollector,
+) (dispatcher.Hub, error) {
+	if len(publisherTypes) == 0 {
 		return nil, common.ErrInvalidAPIType
 	}
+	if !common.ContainsPublisherType(publisherTypes, common.WSPublisherType) {
+		return &disabled.Hub{}, nil
+	}
+
+	commonHub, err := createHub(apiConfig, generalConfig, eventStore, subscriptionsStore, metricsCollector)
+	if err != nil {
+		return nil, err
+	}
+	if !backplaneConfig.Enabled {
+		return commonHub, nil
+	}
+
+	return createBackplaneHub(commonHub, backplaneConfig, lockerConfig)
 }
 
-func createHub() (dispatcher.Hub, error) {
+// createBackplaneHub uses the redis server of the locker service if no url is set. Each
+// instance gets a new id on startup, used to skip its own broadcasts
+func createBackplaneHub(commonHub dispatcher.Hub, backplaneConfig config.BackplaneConfig, lockerConfig config.RedisConfig) (dispatcher.Hub, error) {
+	url := backplaneConfig.Url
+	if url == "" {
+		url = lockerConfig.Url
+	}
+
+	client, err := redis.CreateBackplaneClient(url)
+	if err != nil {
+		return nil, err
+	}
+
+	backplane, err := redis.NewRedisBackplane(redis.ArgsRedisBackplane{
+		Client:  client,
+		Channel: backplaneConfig.Channel,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	instanceID := uuid.New().String()
+	log.Info("created hub backplane", "channel", backplaneConfig.Channel, "instance id", instanceID)
+
+	return hub.NewBackplaneHub(hub.ArgsBackplaneHub{
+		Hub:        commonHub,
+		Backplane:  backplane,
+		Marshaller: &marshal.JsonMarshalizer{},
+		InstanceID: instanceID,
+	})
+}
+
+func createHub(
+	apiConfig config.ConnectorApiConfig,
+	generalConfig config.GeneralConfig,
+	eventStore dispatcher.EventStore,
+	subscriptionsStore dispatcher.SubscriptionsStore,
+	metricsCollector common.MetricsCollector,
+) (dispatcher.Hub, error) {
+	pubKeyConverter, err := getPubKeyConverter(generalConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptionMapper, err := dispatcher.NewPersistentSubscriptionMapper(dispatcher.ArgsSubscriptionMapper{
+		MaxSubscriptionsPerDispatcher: apiConfig.MaxSubscriptionsPerDispatcher,
+		SubscriptionTTL:               time.Duration(apiConfig.SubscriptionTTLInSec) * time.Second,
+		PubKeyConverter:               pubKeyConverter,
+	}, subscriptionsStore)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := filters.NewPrefixFilter(apiConfig.SubscriptionAddressPrefixes)
+	if err != nil {
+		return nil, err
+	}
+
+	blockNonceFilter, err := createBlockNonceFilter(apiConfig)
+	if err != nil {
+		return nil, err
+	}
+
 	args := hub.ArgsCommonHub{
-		Filter:             filters.NewDefaultFilter(),
-		SubscriptionMapper: dispatcher.NewSubscriptionMapper(),
+		Filter:             filter,
+		BlockNonceFilter:   blockNonceFilter,
+		SubscriptionMapper: subscriptionMapper,
+		MetricsCollector:   metricsCollector,
+		EventStore:         eventStore,
+		TracerProvider:     otel.GetTracerProvider(),
+		ReplayBufferSize:   apiConfig.HubReplayBufferSize,
+		MatchStatsWindow:   time.Duration(apiConfig.SubscriptionMatchStatsWindowInSec) * time.Second,
 	}
 	return hub.NewCommonHub(args)
 }
+
+// createBlockNonceFilter returns nil if no block nonce range is configured
+func createBlockNonceFilter(apiConfig config.ConnectorApiConfig) (filters.EventFilter, error) {
+	if apiConfig.MinBlockNonce == 0 && apiConfig.MaxBlockNonce == 0 {
+		return nil, nil
+	}
+
+	maxNonce := apiConfig.MaxBlockNonce
+	if maxNonce == 0 {
+		maxNonce = math.MaxUint64
+	}
+
+	return filters.NewBlockNonceRangeFilter(apiConfig.MinBlockNonce, maxNonce)
+}
+
+// CreateEventStore creates the persistent event store if it is enabled and the websocket
+// publisher is enabled, since the hub saves the broadcast block events
+func CreateEventStore(publisherTypes []string, config config.EventStoreConfig) (dispatcher.EventStore, error) {
+	if !config.Enabled || !common.ContainsPublisherType(publisherTypes, common.WSPublisherType) {
+		return &disabled.EventStore{}, nil
+	}
+
+	args := storage.ArgsEventStore{
+		Path:              config.Path,
+		RetainedNonces:    config.RetainedNonces,
+		MaxBlocksPerQuery: config.MaxBlocksPerQuery,
+	}
+
+	return storage.NewEventStore(args)
+}
+
+// CreateSubscriptionsStore creates the persistent store of the hub subscriptions if it is
+// enabled and the websocket publisher is enabled
+func CreateSubscriptionsStore(publisherTypes []string, config config.PersistenceConfig) (dispatcher.SubscriptionsStore, error) {
+	if !config.Enabled || !common.ContainsPublisherType(publisherTypes, common.WSPublisherType) {
+		return &disabled.SubscriptionsStore{}, nil
+	}
+
+	return storage.NewSubscriptionsStore(storage.ArgsSubscriptionsStore{
+		Path: config.FilePath,
+	})
+}
+
+// CreateWebhookRegistry creates the registry for the webhooks registered via the hub
+// REST api; the events are always posted as json
+func CreateWebhookRegistry(commonHub dispatcher.Hub, config config.WebhookConfig) (facade.WebhookRegistry, error) {
+	args := webhook.ArgsWebhookRegistry{
+		Hub:        commonHub,
+		Client:     &http.Client{},
+		Config:     config,
+		Marshaller: &marshal.JsonMarshalizer{},
+	}
+
+	return webhook.NewWebhookRegistry(args)
+}
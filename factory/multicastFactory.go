@@ -0,0 +1,75 @@
+package factory
+
+import (
+	"github.com/multiversx/mx-chain-notifier-go/common"
+	"github.com/multiversx/mx-chain-notifier-go/config"
+	"github.com/multiversx/mx-chain-notifier-go/disabled"
+	"github.com/multiversx/mx-chain-notifier-go/process"
+)
+
+// CreateObserverFacade creates the events facade used by the observer connectors. If the
+// multicast mode is enabled, the observer events are handled by the notifier facade and by
+// an events facade for each hub channel, which broadcasts them on the channel through the
+// backplane. The publishers of the hub channels are returned, so that they are started and
+// closed together with the notifier publisher
+func CreateObserverFacade(
+	config config.MainConfig,
+	notifierFacade process.EventsFacadeHandler,
+	eventsInterceptor process.EventsInterceptor,
+	statusMetricsHandler common.StatusMetricsHandler,
+) (process.EventsFacadeHandler, []process.Publisher, error) {
+	if !config.Multicast.Enabled {
+		return notifierFacade, nil, nil
+	}
+
+	facades := []process.EventsFacadeHandler{notifierFacade}
+	publishers := make([]process.Publisher, 0, len(config.Multicast.HubChannels))
+	for _, channel := range config.Multicast.HubChannels {
+		publisher, err := createHubChannelPublisher(config, channel)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// the duplicates are not checked on the hub channels, since the lock service would report
+		// the blocks locked by the notifier facade, which handles the same events, as duplicates
+		argsEventsHandler := process.ArgsEventsHandler{
+			Locker:               disabled.NewDisabledRedlockWrapper(),
+			Publisher:            publisher,
+			StatusMetricsHandler: statusMetricsHandler,
+			EventsInterceptor:    eventsInterceptor,
+			AckAfterPublish:      config.General.AckAfterPublish,
+		}
+		facade, err := process.NewEventsHandlerFacade(argsEventsHandler)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		facades = append(facades, facade)
+		publishers = append(publishers, publisher)
+	}
+
+	multicastFacade, err := process.NewMulticastEventsFacade(facades)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	log.Info("created multicast observer facade", "hub channels", config.Multicast.HubChannels)
+
+	return multicastFacade, publishers, nil
+}
+
+// createHubChannelPublisher creates a publisher which broadcasts the events only on the hub
+// channel, since the hub channel has no locally connected dispatchers
+func createHubChannelPublisher(mainConfig config.MainConfig, channel string) (process.Publisher, error) {
+	backplaneConfig := config.BackplaneConfig{
+		Enabled: true,
+		Url:     mainConfig.Multicast.Url,
+		Channel: channel,
+	}
+	hubChannel, err := createBackplaneHub(&disabled.Hub{}, backplaneConfig, mainConfig.Redis)
+	if err != nil {
+		return nil, err
+	}
+
+	return CreatePublisher(hubChannel, mainConfig.ConnectorApi)
+}
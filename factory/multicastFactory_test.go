@@ -0,0 +1,115 @@
+package factory_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/multiversx/mx-chain-notifier-go/config"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/factory"
+	"github.com/multiversx/mx-chain-notifier-go/mocks"
+	"github.com/multiversx/mx-chain-notifier-go/process"
+	"github.com/stretchr/testify/require"
+)
+
+func createMulticastConfig(hubChannels ...string) config.MainConfig {
+	return config.MainConfig{
+		Multicast: config.MulticastConfig{
+			Enabled:     true,
+			Url:         "redis://127.0.0.1:1",
+			HubChannels: hubChannels,
+		},
+	}
+}
+
+func TestCreateObserverFacade(t *testing.T) {
+	t.Parallel()
+
+	t.Run("multicast disabled should return the notifier facade", func(t *testing.T) {
+		t.Parallel()
+
+		notifierFacade := &mocks.FacadeStub{}
+		observerFacade, publishers, err := factory.CreateObserverFacade(config.MainConfig{}, notifierFacade, &mocks.EventsInterceptorStub{}, &mocks.StatusMetricsStub{})
+		require.Nil(t, err)
+		require.True(t, observerFacade == notifierFacade)
+		require.Empty(t, publishers)
+	})
+
+	t.Run("invalid hub channels url should error", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := createMulticastConfig("shard_0")
+		cfg.Multicast.Url = "invalid url"
+
+		observerFacade, publishers, err := factory.CreateObserverFacade(cfg, &mocks.FacadeStub{}, &mocks.EventsInterceptorStub{}, &mocks.StatusMetricsStub{})
+		require.NotNil(t, err)
+		require.Nil(t, observerFacade)
+		require.Nil(t, publishers)
+	})
+
+	t.Run("nil notifier facade should error", func(t *testing.T) {
+		t.Parallel()
+
+		observerFacade, publishers, err := factory.CreateObserverFacade(createMulticastConfig("shard_0"), nil, &mocks.EventsInterceptorStub{}, &mocks.StatusMetricsStub{})
+		require.True(t, errors.Is(err, process.ErrNilEventsFacade))
+		require.Nil(t, observerFacade)
+		require.Nil(t, publishers)
+	})
+
+	t.Run("multicast enabled should handle the events with the notifier facade and the hub channels", func(t *testing.T) {
+		t.Parallel()
+
+		reverted := make(chan data.RevertBlock, 1)
+		notifierFacade := &mocks.FacadeStub{
+			HandleRevertEventsCalled: func(events data.RevertBlock) error {
+				reverted <- events
+				return nil
+			},
+		}
+
+		observerFacade, publishers, err := factory.CreateObserverFacade(createMulticastConfig("shard_0", "shard_1"), notifierFacade, &mocks.EventsInterceptorStub{}, &mocks.StatusMetricsStub{})
+		require.Nil(t, err)
+		require.False(t, observerFacade == notifierFacade)
+		require.Len(t, publishers, 2)
+
+		for _, publisher := range publishers {
+			require.Nil(t, publisher.Run())
+		}
+		defer func() {
+			for _, publisher := range publishers {
+				_ = publisher.Close()
+			}
+		}()
+
+		err = observerFacade.HandleRevertEvents(data.RevertBlock{Hash: "hash1"})
+		require.Nil(t, err)
+		require.Equal(t, data.RevertBlock{Hash: "hash1"}, <-reverted)
+	})
+
+	t.Run("notifier facade failure should be returned", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		notifierFacade := &mocks.FacadeStub{
+			HandleFinalizedEventsCalled: func(events data.FinalizedBlock) error {
+				return expectedErr
+			},
+		}
+
+		observerFacade, publishers, err := factory.CreateObserverFacade(createMulticastConfig("shard_0"), notifierFacade, &mocks.EventsInterceptorStub{}, &mocks.StatusMetricsStub{})
+		require.Nil(t, err)
+		for _, publisher := range publishers {
+			require.Nil(t, publisher.Run())
+		}
+		defer func() {
+			for _, publisher := range publishers {
+				_ = publisher.Close()
+			}
+		}()
+
+		err = observerFacade.HandleFinalizedEvents(data.FinalizedBlock{Hash: "hash1"})
+		multiErr, ok := err.(*process.MultiError)
+		require.True(t, ok)
+		require.Equal(t, []error{expectedErr}, multiErr.Errors)
+	})
+}
@@ -8,8 +8,10 @@ import (
 	logger "github.com/multiversx/mx-chain-logger-go"
 	"github.com/multiversx/mx-chain-notifier-go/common"
 	"github.com/multiversx/mx-chain-notifier-go/config"
+	"github.com/multiversx/mx-chain-notifier-go/data"
 	"github.com/multiversx/mx-chain-notifier-go/process"
 	"github.com/multiversx/mx-chain-notifier-go/process/preprocess"
+	"go.opentelemetry.io/otel"
 )
 
 var log = logger.GetOrCreate("factory")
@@ -24,7 +26,9 @@ func CreateEventsInterceptor(cfg config.GeneralConfig) (process.EventsIntercepto
 	}
 
 	argsEventsInterceptor := process.ArgsEventsInterceptor{
-		PubKeyConverter: pubKeyConverter,
+		PubKeyConverter:    pubKeyConverter,
+		WithTxStatusAndFee: cfg.IncludeTxStatusAndFee,
+		WithTokenTransfers: cfg.DecodeTokenTransfers,
 	}
 
 	return process.NewEventsInterceptor(argsEventsInterceptor)
@@ -40,17 +44,47 @@ func getPubKeyConverter(cfg config.GeneralConfig) (core.PubkeyConverter, error)
 }
 
 // CreatePayloadHandler will create a new instance of payload handler
-func CreatePayloadHandler(marshaller marshal.Marshalizer, facade process.EventsFacadeHandler) (websocket.PayloadHandler, error) {
+func CreatePayloadHandler(
+	marshaller marshal.Marshalizer,
+	facade process.EventsFacadeHandler,
+	statusMetricsHandler common.StatusMetricsHandler,
+	processedBlocksTracker common.ProcessedBlocksTracker,
+	metricsCollector common.MetricsCollector,
+	generalConfig config.GeneralConfig,
+) (websocket.PayloadHandler, error) {
 	dataPreProcessorArgs := preprocess.ArgsEventsPreProcessor{
-		Marshaller: marshaller,
-		Facade:     facade,
+		Marshaller:                   marshaller,
+		Facade:                       facade,
+		MetricsCollector:             metricsCollector,
+		ProcessedBlocksTracker:       processedBlocksTracker,
+		ProcessedBlocksCacheSize:     generalConfig.ProcessedBlocksCacheSize,
+		RevertDetailsCacheDepth:      generalConfig.RevertDetailsCacheDepth,
+		EventsBlacklist:              generalConfig.EventsBlacklist,
+		FinalizedDetailsCacheDepth:   generalConfig.FinalizedDetailsCacheDepth,
+		FinalizedDetailsWithTxHashes: generalConfig.FinalizedDetailsWithTxHashes,
+	}
+	if generalConfig.RevertDetailsCacheDepth > 0 {
+		pubKeyConverter, err := getPubKeyConverter(generalConfig)
+		if err != nil {
+			return nil, err
+		}
+		dataPreProcessorArgs.PubKeyConverter = pubKeyConverter
 	}
 	dataPreProcessors, err := createEventsDataPreProcessors(dataPreProcessorArgs)
 	if err != nil {
 		return nil, err
 	}
 
-	payloadHandler, err := process.NewPayloadHandler(dataPreProcessors)
+	payloadHandlerArgs := process.ArgsPayloadHandler{
+		DataProcessors:              dataPreProcessors,
+		StatusMetricsHandler:        statusMetricsHandler,
+		MetricsCollector:            metricsCollector,
+		StrictMode:                  generalConfig.StrictPayloadValidation,
+		VersionFallback:             generalConfig.PayloadVersionFallback,
+		MaxDecompressedPayloadBytes: generalConfig.MaxDecompressedPayloadBytes,
+		TracerProvider:              otel.GetTracerProvider(),
+	}
+	payloadHandler, err := process.NewPayloadHandler(payloadHandlerArgs)
 	if err != nil {
 		return nil, err
 	}
@@ -58,6 +92,14 @@ func CreatePayloadHandler(marshaller marshal.Marshalizer, facade process.EventsF
 	return payloadHandler, nil
 }
 
+// GetPayloadVersions returns the observer payload versions which have a data processor
+func GetPayloadVersions(generalConfig config.GeneralConfig) data.PayloadVersionsResponse {
+	return data.PayloadVersionsResponse{
+		SupportedVersions: []uint32{common.PayloadV0, common.PayloadV1},
+		VersionFallback:   generalConfig.PayloadVersionFallback,
+	}
+}
+
 func createEventsDataPreProcessors(dataPreProcessorArgs preprocess.ArgsEventsPreProcessor) (map[uint32]process.DataProcessor, error) {
 	eventsProcessors := make(map[uint32]process.DataProcessor)
 
@@ -1,50 +1,270 @@
 package factory
 
 import (
+	"fmt"
+	"net/http"
+	"time"
+
 	"github.com/multiversx/mx-chain-core-go/marshal"
+	marshalFactory "github.com/multiversx/mx-chain-core-go/marshal/factory"
 	"github.com/multiversx/mx-chain-notifier-go/common"
 	"github.com/multiversx/mx-chain-notifier-go/config"
+	"github.com/multiversx/mx-chain-notifier-go/data/payload"
 	"github.com/multiversx/mx-chain-notifier-go/dispatcher"
+	"github.com/multiversx/mx-chain-notifier-go/file"
+	"github.com/multiversx/mx-chain-notifier-go/kafka"
+	"github.com/multiversx/mx-chain-notifier-go/nats"
 	"github.com/multiversx/mx-chain-notifier-go/process"
 	"github.com/multiversx/mx-chain-notifier-go/rabbitmq"
+	"github.com/multiversx/mx-chain-notifier-go/redis"
+	"github.com/multiversx/mx-chain-notifier-go/webhook"
 )
 
-// CreatePublisher creates publisher component
-func CreatePublisher(
-	apiType string,
+const (
+	jsonContentType     = "application/json"
+	protobufContentType = "application/x-protobuf"
+)
+
+// CreatePublisherHandler creates the publisher handler component, based on the enabled
+// publisher types. If multiple publisher types are enabled, the events are published
+// to each of them
+func CreatePublisherHandler(
+	publisherTypes []string,
 	config config.MainConfig,
 	marshaller marshal.Marshalizer,
 	commonHub dispatcher.Hub,
-) (process.Publisher, error) {
-	switch apiType {
+	consumerRegistry rabbitmq.ConsumerRegistry,
+	metricsCollector common.MetricsCollector,
+) (process.PublisherHandler, error) {
+	handlers := make([]process.PublisherHandler, 0, len(publisherTypes))
+	for _, publisherType := range publisherTypes {
+		handler, err := createPublisherHandler(publisherType, config, marshaller, commonHub, consumerRegistry, metricsCollector)
+		if err != nil {
+			return nil, err
+		}
+
+		handlers = append(handlers, handler)
+	}
+
+	if len(handlers) == 1 {
+		return handlers[0], nil
+	}
+
+	return process.NewCompositePublisherHandler(handlers)
+}
+
+func createPublisherHandler(
+	publisherType string,
+	config config.MainConfig,
+	marshaller marshal.Marshalizer,
+	commonHub dispatcher.Hub,
+	consumerRegistry rabbitmq.ConsumerRegistry,
+	metricsCollector common.MetricsCollector,
+) (process.PublisherHandler, error) {
+	switch publisherType {
 	case common.MessageQueuePublisherType:
-		return createRabbitMqPublisher(config.RabbitMQ, marshaller)
+		rabbitMqMarshaller, contentType, err := createRabbitMqMarshaller(config, marshaller)
+		if err != nil {
+			return nil, err
+		}
+		return createRabbitMqPublisher(config.RabbitMQ, rabbitMqMarshaller, contentType, consumerRegistry, metricsCollector, config.General.AckAfterPublish)
 	case common.WSPublisherType:
-		return createWSPublisher(commonHub)
+		return commonHub, nil
+	case common.RedisPublisherType:
+		return createRedisPublisher(config.RedisPubSub, config.Redis, config.General.AckAfterPublish)
+	case common.WebhookPublisherType:
+		return createWebhookPublisher(config.Webhook, config.General.AckAfterPublish)
+	case common.KafkaPublisherType:
+		contentType := getContentType(config.General.ExternalMarshaller.Type)
+		return createKafkaPublisher(config.Kafka, marshaller, contentType)
+	case common.NATSPublisherType:
+		contentType := getContentType(config.General.ExternalMarshaller.Type)
+		return createNatsPublisher(config.NATS, marshaller, contentType, config.General.AckAfterPublish)
+	case common.FilePublisherType:
+		return createFilePublisher(config.File)
 	default:
 		return nil, common.ErrInvalidAPIType
 	}
 }
 
-func createRabbitMqPublisher(config config.RabbitMQConfig, marshaller marshal.Marshalizer) (rabbitmq.PublisherService, error) {
-	rabbitClient, err := rabbitmq.NewRabbitMQClient(config.Url)
+// CreatePublisher creates publisher component
+func CreatePublisher(publisherHandler process.PublisherHandler, apiConfig config.ConnectorApiConfig) (process.Publisher, error) {
+	return process.NewPublisher(process.ArgsPublisher{
+		Handler:              publisherHandler,
+		MaxPendingBroadcasts: apiConfig.ReadinessMaxPendingBroadcasts,
+		BroadcastBufferSize:  apiConfig.BroadcastBufferSize,
+		DrainTimeout:         time.Duration(apiConfig.DrainTimeoutInMs) * time.Millisecond,
+	})
+}
+
+func createRabbitMqPublisher(
+	config config.RabbitMQConfig,
+	marshaller marshal.Marshalizer,
+	contentType string,
+	consumerRegistry rabbitmq.ConsumerRegistry,
+	metricsCollector common.MetricsCollector,
+	ackAfterPublish bool,
+) (process.PublisherHandler, error) {
+	urls, err := rabbitmq.GetConnectionURLs(config)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig, err := rabbitmq.CreateTLSConfig(config.TLS)
+	if err != nil {
+		return nil, err
+	}
+
+	rabbitClient, err := rabbitmq.NewRabbitMQClient(
+		urls,
+		tlsConfig,
+		time.Duration(config.PublishConfirmTimeoutInMs)*time.Millisecond,
+	)
 	if err != nil {
 		return nil, err
 	}
 
 	rabbitMqPublisherArgs := rabbitmq.ArgsRabbitMqPublisher{
-		Client:     rabbitClient,
-		Config:     config,
-		Marshaller: marshaller,
+		Client:           rabbitClient,
+		Config:           config,
+		Marshaller:       marshaller,
+		MetricsCollector: metricsCollector,
+		ConsumerRegistry: consumerRegistry,
+		ContentType:      contentType,
+		AckAfterPublish:  ackAfterPublish,
 	}
-	rabbitPublisher, err := rabbitmq.NewRabbitMqPublisher(rabbitMqPublisherArgs)
+
+	return rabbitmq.NewRabbitMqPublisher(rabbitMqPublisherArgs)
+}
+
+// CreateConsumerRegistry creates the registry for the rabbitMQ consumer subscriptions
+// registered via the REST api, limited to the max subscriptions of a hub dispatcher
+func CreateConsumerRegistry(apiConfig config.ConnectorApiConfig, generalConfig config.GeneralConfig) (rabbitmq.ConsumerRegistry, error) {
+	pubKeyConverter, err := getPubKeyConverter(generalConfig)
 	if err != nil {
 		return nil, err
 	}
 
-	return process.NewPublisher(rabbitPublisher)
+	args := rabbitmq.ArgsConsumerRegistry{
+		MaxSubscriptionsPerConsumer: apiConfig.MaxSubscriptionsPerDispatcher,
+		PubKeyConverter:             pubKeyConverter,
+	}
+
+	return rabbitmq.NewConsumerRegistry(args)
 }
 
-func createWSPublisher(commonHub dispatcher.Hub) (process.Publisher, error) {
-	return process.NewPublisher(commonHub)
+// createRedisPublisher creates the redis pubsub publisher; the events are always
+// published as json, so that lightweight subscribers can decode them easily. If no url
+// or address is set, the redis server of the locker service is used
+func createRedisPublisher(config config.RedisPubSubConfig, lockerConfig config.RedisConfig, ackAfterPublish bool) (process.PublisherHandler, error) {
+	if config.Url == "" && config.Address == "" {
+		config.Url = lockerConfig.Url
+	}
+
+	client, err := redis.CreatePubSubClient(config)
+	if err != nil {
+		return nil, err
+	}
+
+	redisPublisherArgs := redis.ArgsRedisPublisher{
+		Client:          client,
+		Config:          config,
+		Marshaller:      &marshal.JsonMarshalizer{},
+		AckAfterPublish: ackAfterPublish,
+	}
+
+	return redis.NewRedisPublisher(redisPublisherArgs)
+}
+
+// createWebhookPublisher creates the webhook publisher; the events are always posted as json
+func createWebhookPublisher(config config.WebhookConfig, ackAfterPublish bool) (process.PublisherHandler, error) {
+	webhookPublisherArgs := webhook.ArgsWebhookPublisher{
+		Client:          &http.Client{},
+		Config:          config,
+		Marshaller:      &marshal.JsonMarshalizer{},
+		AckAfterPublish: ackAfterPublish,
+	}
+
+	return webhook.NewWebhookPublisher(webhookPublisherArgs)
+}
+
+func createKafkaPublisher(
+	config config.KafkaConfig,
+	marshaller marshal.Marshalizer,
+	contentType string,
+) (process.PublisherHandler, error) {
+	writer, err := kafka.CreateWriter(config)
+	if err != nil {
+		return nil, err
+	}
+
+	kafkaPublisherArgs := kafka.ArgsKafkaPublisher{
+		Writer:      writer,
+		Config:      config,
+		Marshaller:  marshaller,
+		ContentType: contentType,
+	}
+
+	return kafka.NewKafkaPublisher(kafkaPublisherArgs)
+}
+
+func createNatsPublisher(
+	config config.NATSConfig,
+	marshaller marshal.Marshalizer,
+	contentType string,
+	ackAfterPublish bool,
+) (process.PublisherHandler, error) {
+	client, err := nats.CreateClient(config)
+	if err != nil {
+		return nil, err
+	}
+
+	natsPublisherArgs := nats.ArgsNatsPublisher{
+		Client:          client,
+		Config:          config,
+		Marshaller:      marshaller,
+		ContentType:     contentType,
+		AckAfterPublish: ackAfterPublish,
+	}
+
+	return nats.NewNatsPublisher(natsPublisherArgs)
+}
+
+// createRabbitMqMarshaller returns the marshaller of the rabbitMQ payloads, together with
+// their content type. If no marshaller type is configured, the external marshaller is used
+func createRabbitMqMarshaller(
+	config config.MainConfig,
+	externalMarshaller marshal.Marshalizer,
+) (marshal.Marshalizer, string, error) {
+	switch config.RabbitMQ.MarshallerType {
+	case "":
+		return externalMarshaller, getContentType(config.General.ExternalMarshaller.Type), nil
+	case marshalFactory.JsonMarshalizer:
+		return &marshal.JsonMarshalizer{}, jsonContentType, nil
+	case marshalFactory.GogoProtobuf:
+		return payload.NewProtoPayloadMarshaller(), protobufContentType, nil
+	default:
+		return nil, "", fmt.Errorf("%w for rabbitMQ: %s", common.ErrInvalidMarshallerType, config.RabbitMQ.MarshallerType)
+	}
+}
+
+// getContentType returns the content type of the payloads marshalled with the provided marshaller type
+func getContentType(marshallerType string) string {
+	switch marshallerType {
+	case marshalFactory.JsonMarshalizer, marshalFactory.TxJsonMarshalizer:
+		return jsonContentType
+	case marshalFactory.GogoProtobuf:
+		return protobufContentType
+	default:
+		return ""
+	}
+}
+
+// createFilePublisher creates the file publisher; the records are always written as json
+func createFilePublisher(config config.FilePublisherConfig) (process.PublisherHandler, error) {
+	filePublisherArgs := file.ArgsFilePublisher{
+		Config:     config,
+		Marshaller: &marshal.JsonMarshalizer{},
+	}
+
+	return file.NewFilePublisher(filePublisherArgs)
 }
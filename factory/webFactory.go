@@ -1,24 +1,46 @@
 package factory
 
 import (
+	"github.com/multiversx/mx-chain-communication-go/websocket"
 	marshalFactory "github.com/multiversx/mx-chain-core-go/marshal/factory"
 	"github.com/multiversx/mx-chain-notifier-go/api/gin"
 	"github.com/multiversx/mx-chain-notifier-go/api/shared"
+	"github.com/multiversx/mx-chain-notifier-go/common"
 	"github.com/multiversx/mx-chain-notifier-go/config"
+	"github.com/multiversx/mx-chain-notifier-go/process"
 )
 
-// CreateWebServerHandler will create a new web server handler component
-func CreateWebServerHandler(facade shared.FacadeHandler, configs config.Configs) (shared.WebServerHandler, error) {
+// CreateHTTPPayloadHandler will create the payload handler of the payloads pushed by the
+// observers on the events endpoints. It is closed on shutdown, before the publisher, so that
+// no new payloads are accepted while the received events are published
+func CreateHTTPPayloadHandler(
+	facade process.EventsFacadeHandler,
+	configs config.Configs,
+	statusMetricsHandler common.StatusMetricsHandler,
+	processedBlocksTracker common.ProcessedBlocksTracker,
+	metricsCollector common.MetricsCollector,
+) (websocket.PayloadHandler, error) {
 	marshaller, err := marshalFactory.NewMarshalizer(marshalFactory.JsonMarshalizer)
 	if err != nil {
 		return nil, err
 	}
 
-	payloadHandler, err := CreatePayloadHandler(marshaller, facade)
-	if err != nil {
-		return nil, err
-	}
+	return CreatePayloadHandler(
+		marshaller,
+		facade,
+		statusMetricsHandler,
+		processedBlocksTracker,
+		metricsCollector,
+		configs.MainConfig.General,
+	)
+}
 
+// CreateWebServerHandler will create a new web server handler component
+func CreateWebServerHandler(
+	facade shared.FacadeHandler,
+	payloadHandler websocket.PayloadHandler,
+	configs config.Configs,
+) (shared.WebServerHandler, error) {
 	webServerArgs := gin.ArgsWebServerHandler{
 		Facade:         facade,
 		PayloadHandler: payloadHandler,
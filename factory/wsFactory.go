@@ -18,28 +18,34 @@ const (
 	writeBufferSize = 1024
 )
 
-// CreateWSHandler creates websocket handler component based on api type
-func CreateWSHandler(apiType string, wsDispatcher dispatcher.Dispatcher, marshaller marshal.Marshalizer) (dispatcher.WSHandler, error) {
-	switch apiType {
-	case common.MessageQueuePublisherType:
-		return &disabled.WSHandler{}, nil
-	case common.WSPublisherType:
-		return createWSHandler(wsDispatcher, marshaller)
-	default:
+// CreateWSHandler creates websocket handler component if the websocket publisher is enabled
+func CreateWSHandler(
+	publisherTypes []string,
+	wsDispatcher dispatcher.Dispatcher,
+	marshaller marshal.Marshalizer,
+	apiConfig config.ConnectorApiConfig,
+) (dispatcher.WSHandler, error) {
+	if len(publisherTypes) == 0 {
 		return nil, common.ErrInvalidAPIType
 	}
+	if !common.ContainsPublisherType(publisherTypes, common.WSPublisherType) {
+		return &disabled.WSHandler{}, nil
+	}
+
+	return createWSHandler(wsDispatcher, marshaller, apiConfig)
 }
 
-func createWSHandler(wsDispatcher dispatcher.Dispatcher, marshaller marshal.Marshalizer) (dispatcher.WSHandler, error) {
+func createWSHandler(wsDispatcher dispatcher.Dispatcher, marshaller marshal.Marshalizer, apiConfig config.ConnectorApiConfig) (dispatcher.WSHandler, error) {
 	upgrader, err := ws.NewWSUpgraderWrapper(readBufferSize, writeBufferSize)
 	if err != nil {
 		return nil, err
 	}
 
 	args := ws.ArgsWebSocketProcessor{
-		Dispatcher: wsDispatcher,
-		Upgrader:   upgrader,
-		Marshaller: marshaller,
+		Dispatcher:        wsDispatcher,
+		Upgrader:          upgrader,
+		Marshaller:        marshaller,
+		BreakOnErrorCount: apiConfig.WSBreakOnErrorCount,
 	}
 	return ws.NewWebSocketProcessor(args)
 }
@@ -48,9 +54,13 @@ func createWSHandler(wsDispatcher dispatcher.Dispatcher, marshaller marshal.Mars
 func CreateWSObserverConnector(
 	config config.WebSocketConfig,
 	facade process.EventsFacadeHandler,
+	statusMetricsHandler common.StatusMetricsHandler,
+	processedBlocksTracker common.ProcessedBlocksTracker,
+	metricsCollector common.MetricsCollector,
+	generalConfig config.GeneralConfig,
 ) (process.WSClient, error) {
 	if config.Enabled {
-		return createWsObsConnector(config, facade)
+		return createWsObsConnector(config, facade, statusMetricsHandler, processedBlocksTracker, metricsCollector, generalConfig)
 	}
 
 	return &disabled.WSHandler{}, nil
@@ -59,18 +69,31 @@ func CreateWSObserverConnector(
 func createWsObsConnector(
 	config config.WebSocketConfig,
 	facade process.EventsFacadeHandler,
+	statusMetricsHandler common.StatusMetricsHandler,
+	processedBlocksTracker common.ProcessedBlocksTracker,
+	metricsCollector common.MetricsCollector,
+	generalConfig config.GeneralConfig,
 ) (process.WSClient, error) {
+	if generalConfig.AckAfterPublish && !config.BlockingAckOnError {
+		log.Warn("the websocket observer connector acknowledges the payloads which could not be published, since BlockingAckOnError is not set")
+	}
+
 	marshaller, err := marshalFactory.NewMarshalizer(config.DataMarshallerType)
 	if err != nil {
 		return nil, err
 	}
 
+	internalMarshaller, err := marshalFactory.NewMarshalizer(generalConfig.InternalMarshaller.Type)
+	if err != nil {
+		return nil, err
+	}
+
 	host, err := createWsHost(config, marshaller)
 	if err != nil {
 		return nil, err
 	}
 
-	payloadHandler, err := CreatePayloadHandler(marshaller, facade)
+	payloadHandler, err := CreatePayloadHandler(internalMarshaller, facade, statusMetricsHandler, processedBlocksTracker, metricsCollector, generalConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,47 @@
+package factory_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/marshal"
+	"github.com/multiversx/mx-chain-notifier-go/config"
+	"github.com/multiversx/mx-chain-notifier-go/factory"
+	"github.com/multiversx/mx-chain-notifier-go/metrics"
+	"github.com/multiversx/mx-chain-notifier-go/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateWSObserverConnector(t *testing.T) {
+	t.Parallel()
+
+	wsConfig := config.WebSocketConfig{
+		Enabled:            true,
+		URL:                "localhost:22111",
+		Mode:               "server",
+		RetryDurationInSec: 5,
+		DataMarshallerType: "gogo protobuf",
+	}
+
+	t.Run("unknown data marshaller type should error", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := wsConfig
+		cfg.DataMarshallerType = "unknown"
+		generalConfig := config.GeneralConfig{InternalMarshaller: config.MarshallerConfig{Type: "json"}}
+
+		connector, err := factory.CreateWSObserverConnector(cfg, &mocks.FacadeStub{}, metrics.NewStatusMetrics(), metrics.NewProcessedBlocksTracker(), metrics.NewMetricsCollector(), generalConfig)
+		require.True(t, errors.Is(err, marshal.ErrUnknownMarshalizer))
+		require.Nil(t, connector)
+	})
+
+	t.Run("unknown internal marshaller type should error", func(t *testing.T) {
+		t.Parallel()
+
+		generalConfig := config.GeneralConfig{InternalMarshaller: config.MarshallerConfig{Type: "unknown"}}
+
+		connector, err := factory.CreateWSObserverConnector(wsConfig, &mocks.FacadeStub{}, metrics.NewStatusMetrics(), metrics.NewProcessedBlocksTracker(), metrics.NewMetricsCollector(), generalConfig)
+		require.True(t, errors.Is(err, marshal.ErrUnknownMarshalizer))
+		require.Nil(t, connector)
+	})
+}
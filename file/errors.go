@@ -0,0 +1,9 @@
+package file
+
+import "errors"
+
+// ErrEmptyPath signals that an empty file path has been provided
+var ErrEmptyPath = errors.New("empty file publisher path")
+
+// ErrPublisherClosed signals that a record has been published after the publisher was closed
+var ErrPublisherClosed = errors.New("file publisher is closed")
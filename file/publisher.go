@@ -0,0 +1,359 @@
+package file
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/marshal"
+	logger "github.com/multiversx/mx-chain-logger-go"
+	"github.com/multiversx/mx-chain-notifier-go/common"
+	"github.com/multiversx/mx-chain-notifier-go/config"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/metrics"
+)
+
+const (
+	writtenRecordsPromMetric = "file_written_records"
+	writeFailuresPromMetric  = "file_write_failures"
+	typePromLabel            = "type"
+
+	// StdoutPath is the path which writes the records to the standard output
+	StdoutPath = "stdout"
+
+	// EventsRecordType is the type of the logs and events records
+	EventsRecordType = "events"
+
+	// RevertRecordType is the type of the revert records
+	RevertRecordType = "revert"
+
+	// FinalizedRecordType is the type of the finalized records
+	FinalizedRecordType = "finalized"
+)
+
+var log = logger.GetOrCreate("file")
+
+// ArgsFilePublisher defines the arguments needed for file publisher creation
+type ArgsFilePublisher struct {
+	Config     config.FilePublisherConfig
+	Marshaller marshal.Marshalizer
+}
+
+// Record defines the structure of a line appended to the file
+type Record struct {
+	Type      string      `json:"type"`
+	Timestamp int64       `json:"timestamp"`
+	Data      interface{} `json:"data"`
+}
+
+type filePublisher struct {
+	marshaller  marshal.Marshalizer
+	path        string
+	maxFileSize uint64
+
+	// mutWrite serializes the writes, so that a record is never split across files
+	mutWrite        sync.Mutex
+	file            *os.File
+	writer          *bufio.Writer
+	fileSize        uint64
+	nextBackupIndex int
+	lastWriteErr    error
+	closed          bool
+
+	mutMetrics        sync.RWMutex
+	numWrittenRecords map[string]uint64
+	numWriteFailures  map[string]uint64
+}
+
+// NewFilePublisher creates a new file publisher instance, which appends the block events,
+// revert and finalized events to the configured file, one json record per line. The
+// other events are ignored. If a max file size is configured, the file is rotated to
+// <path>.<index> before it would exceed it
+func NewFilePublisher(args ArgsFilePublisher) (*filePublisher, error) {
+	err := checkArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	fp := &filePublisher{
+		marshaller:        args.Marshaller,
+		path:              args.Config.Path,
+		maxFileSize:       args.Config.MaxFileSizeBytes,
+		numWrittenRecords: make(map[string]uint64),
+		numWriteFailures:  make(map[string]uint64),
+	}
+
+	if fp.path == StdoutPath {
+		fp.maxFileSize = 0
+		fp.writer = bufio.NewWriter(os.Stdout)
+		return fp, nil
+	}
+
+	fp.nextBackupIndex, err = getNextBackupIndex(fp.path)
+	if err != nil {
+		return nil, err
+	}
+
+	err = fp.openFile()
+	if err != nil {
+		return nil, err
+	}
+
+	return fp, nil
+}
+
+func checkArgs(args ArgsFilePublisher) error {
+	if args.Config.Path == "" {
+		return ErrEmptyPath
+	}
+	if check.IfNil(args.Marshaller) {
+		return common.ErrNilMarshaller
+	}
+
+	return nil
+}
+
+// getNextBackupIndex returns the index following the highest index of the rotated files,
+// so that the files rotated before a restart are not overwritten
+func getNextBackupIndex(path string) (int, error) {
+	backups, err := filepath.Glob(path + ".*")
+	if err != nil {
+		return 0, err
+	}
+
+	nextIndex := 1
+	for _, backup := range backups {
+		index, errParse := strconv.Atoi(strings.TrimPrefix(backup, path+"."))
+		if errParse != nil {
+			continue
+		}
+		if index >= nextIndex {
+			nextIndex = index + 1
+		}
+	}
+
+	return nextIndex, nil
+}
+
+func (fp *filePublisher) openFile() error {
+	file, err := os.OpenFile(fp.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+
+	fileInfo, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	fp.file = file
+	fp.writer = bufio.NewWriter(file)
+	fp.fileSize = uint64(fileInfo.Size())
+
+	return nil
+}
+
+// Publish will append the logs and events record to the file
+func (fp *filePublisher) Publish(_ context.Context, events data.BlockEvents) error {
+	return fp.publishRecord(EventsRecordType, events.Hash, events)
+}
+
+// PublishRevert will append the revert record to the file
+func (fp *filePublisher) PublishRevert(_ context.Context, revertBlock data.RevertBlock) error {
+	return fp.publishRecord(RevertRecordType, revertBlock.Hash, revertBlock)
+}
+
+// PublishFinalized will append the finalized record to the file
+func (fp *filePublisher) PublishFinalized(_ context.Context, finalizedBlock data.FinalizedBlock) error {
+	return fp.publishRecord(FinalizedRecordType, finalizedBlock.Hash, finalizedBlock)
+}
+
+// PublishTxs does nothing, block txs are not written to the file
+func (fp *filePublisher) PublishTxs(_ context.Context, _ data.BlockTxs) error {
+	return nil
+}
+
+// PublishScrs does nothing, block scrs are not written to the file
+func (fp *filePublisher) PublishScrs(_ context.Context, _ data.BlockScrs) error {
+	return nil
+}
+
+// PublishBlockEventsWithOrder does nothing, full block events are not written to the file
+func (fp *filePublisher) PublishBlockEventsWithOrder(_ context.Context, _ data.BlockEventsWithOrder) error {
+	return nil
+}
+
+// PublishTxEvents does nothing, tx events are not written to the file
+func (fp *filePublisher) PublishTxEvents(_ context.Context, _ data.BlockTxEvents) error {
+	return nil
+}
+
+// PublishRounds does nothing, rounds info is not written to the file
+func (fp *filePublisher) PublishRounds(_ context.Context, _ data.RoundEvents) error {
+	return nil
+}
+
+// PublishValidatorsRating does nothing, validators rating is not written to the file
+func (fp *filePublisher) PublishValidatorsRating(_ context.Context, _ data.ValidatorsRatingEvent) error {
+	return nil
+}
+
+// PublishAccounts does nothing, altered accounts are not written to the file
+func (fp *filePublisher) PublishAccounts(_ context.Context, _ data.AccountsEvents) error {
+	return nil
+}
+
+func (fp *filePublisher) publishRecord(recordType string, hash string, eventData interface{}) error {
+	recordBytes, err := fp.marshaller.Marshal(&Record{
+		Type:      recordType,
+		Timestamp: time.Now().Unix(),
+		Data:      eventData,
+	})
+	if err == nil {
+		err = fp.writeRecord(append(recordBytes, '\n'))
+	}
+
+	fp.mutMetrics.Lock()
+	if err != nil {
+		fp.numWriteFailures[recordType]++
+	} else {
+		fp.numWrittenRecords[recordType]++
+	}
+	fp.mutMetrics.Unlock()
+
+	if err != nil {
+		log.Error("failed to write record to file", "path", fp.path, "type", recordType, "hash", hash, "err", err.Error())
+	}
+
+	return err
+}
+
+func (fp *filePublisher) writeRecord(record []byte) error {
+	fp.mutWrite.Lock()
+	defer fp.mutWrite.Unlock()
+
+	if fp.closed {
+		return ErrPublisherClosed
+	}
+
+	var err error
+	if fp.writer == nil {
+		// the file could not be reopened on the last rotation
+		err = fp.openFile()
+	}
+	if err == nil {
+		err = fp.rotateIfNeeded(uint64(len(record)))
+	}
+	if err == nil {
+		_, err = fp.writer.Write(record)
+	}
+	if err == nil {
+		fp.fileSize += uint64(len(record))
+	}
+
+	fp.lastWriteErr = err
+
+	return err
+}
+
+// rotateIfNeeded rotates the file if the record would make it exceed the max size. A
+// record larger than the max size is written to an empty file. The buffered records are
+// flushed before the file is renamed, so no record is lost on rotation
+func (fp *filePublisher) rotateIfNeeded(recordSize uint64) error {
+	if fp.maxFileSize == 0 || fp.fileSize == 0 || fp.fileSize+recordSize <= fp.maxFileSize {
+		return nil
+	}
+
+	err := fp.closeFile()
+	if err != nil {
+		return err
+	}
+
+	backupPath := fmt.Sprintf("%s.%d", fp.path, fp.nextBackupIndex)
+	err = os.Rename(fp.path, backupPath)
+	if err != nil {
+		// the records are still appended to the current file
+		log.Warn("could not rotate file", "path", fp.path, "backup", backupPath, "err", err.Error())
+	} else {
+		fp.nextBackupIndex++
+		log.Debug("rotated file", "path", fp.path, "backup", backupPath)
+	}
+
+	return fp.openFile()
+}
+
+func (fp *filePublisher) closeFile() error {
+	if fp.writer == nil {
+		return nil
+	}
+
+	err := fp.writer.Flush()
+	if err != nil {
+		return err
+	}
+	if fp.file == nil {
+		return nil
+	}
+
+	err = fp.file.Close()
+	fp.file = nil
+	fp.writer = nil
+
+	return err
+}
+
+// GetMetricsForPrometheus returns the number of written and failed records for each
+// record type, in prometheus format
+func (fp *filePublisher) GetMetricsForPrometheus() string {
+	fp.mutMetrics.RLock()
+	defer fp.mutMetrics.RUnlock()
+
+	stringBuilder := strings.Builder{}
+	stringBuilder.WriteString(metrics.CounterMetrics(writtenRecordsPromMetric, typePromLabel, fp.numWrittenRecords))
+	stringBuilder.WriteString(metrics.CounterMetrics(writeFailuresPromMetric, typePromLabel, fp.numWriteFailures))
+
+	return stringBuilder.String()
+}
+
+// GetHealthState returns down if the last record could not be written
+func (fp *filePublisher) GetHealthState() string {
+	if fp.Ping(context.Background()) != nil {
+		return common.HealthStateDown
+	}
+
+	return common.HealthStateUp
+}
+
+// Ping returns the error of the last write, if any
+func (fp *filePublisher) Ping(_ context.Context) error {
+	fp.mutWrite.Lock()
+	defer fp.mutWrite.Unlock()
+
+	return fp.lastWriteErr
+}
+
+// Close will flush the buffered records and close the file
+func (fp *filePublisher) Close() error {
+	fp.mutWrite.Lock()
+	defer fp.mutWrite.Unlock()
+
+	if fp.closed {
+		return nil
+	}
+	fp.closed = true
+
+	return fp.closeFile()
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (fp *filePublisher) IsInterfaceNil() bool {
+	return fp == nil
+}
@@ -0,0 +1,271 @@
+package file_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/marshal"
+	"github.com/multiversx/mx-chain-notifier-go/common"
+	"github.com/multiversx/mx-chain-notifier-go/config"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/file"
+	"github.com/stretchr/testify/require"
+)
+
+// record is used to decode the file records, keeping the raw event data
+type record struct {
+	Type      string          `json:"type"`
+	Timestamp int64           `json:"timestamp"`
+	Data      json.RawMessage `json:"data"`
+}
+
+func createMockArgsFilePublisher(t *testing.T) file.ArgsFilePublisher {
+	return file.ArgsFilePublisher{
+		Config: config.FilePublisherConfig{
+			Path: filepath.Join(t.TempDir(), "events.jsonl"),
+		},
+		Marshaller: &marshal.JsonMarshalizer{},
+	}
+}
+
+func readRecords(t *testing.T, path string) []record {
+	f, err := os.Open(path)
+	require.Nil(t, err)
+	defer func() {
+		_ = f.Close()
+	}()
+
+	records := make([]record, 0)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		r := record{}
+		err = json.Unmarshal(scanner.Bytes(), &r)
+		require.Nil(t, err)
+
+		records = append(records, r)
+	}
+	require.Nil(t, scanner.Err())
+
+	return records
+}
+
+func TestNewFilePublisher(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty path", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsFilePublisher(t)
+		args.Config.Path = ""
+
+		publisher, err := file.NewFilePublisher(args)
+		require.True(t, check.IfNil(publisher))
+		require.Equal(t, file.ErrEmptyPath, err)
+	})
+
+	t.Run("nil marshaller", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsFilePublisher(t)
+		args.Marshaller = nil
+
+		publisher, err := file.NewFilePublisher(args)
+		require.True(t, check.IfNil(publisher))
+		require.Equal(t, common.ErrNilMarshaller, err)
+	})
+
+	t.Run("invalid path", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsFilePublisher(t)
+		args.Config.Path = filepath.Join(t.TempDir(), "missing", "events.jsonl")
+
+		publisher, err := file.NewFilePublisher(args)
+		require.True(t, check.IfNil(publisher))
+		require.NotNil(t, err)
+	})
+
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		publisher, err := file.NewFilePublisher(createMockArgsFilePublisher(t))
+		require.Nil(t, err)
+		require.False(t, check.IfNil(publisher))
+		require.Nil(t, publisher.Ping(context.Background()))
+		require.Equal(t, common.HealthStateUp, publisher.GetHealthState())
+		require.Nil(t, publisher.Close())
+	})
+
+	t.Run("stdout should work", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsFilePublisher(t)
+		args.Config.Path = file.StdoutPath
+
+		publisher, err := file.NewFilePublisher(args)
+		require.Nil(t, err)
+		require.Nil(t, publisher.Close())
+	})
+}
+
+func TestFilePublisher_Publish(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should write the ndjson records on close", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsFilePublisher(t)
+		publisher, err := file.NewFilePublisher(args)
+		require.Nil(t, err)
+
+		blockEvents := data.BlockEvents{
+			Hash:   "hash1",
+			Events: []data.Event{{Address: "erd1", Identifier: "swap", TxHash: "txHash1"}},
+		}
+		revertBlock := data.RevertBlock{Hash: "hash2", Nonce: 2, Round: 3, Epoch: 4}
+		finalizedBlock := data.FinalizedBlock{Hash: "hash3"}
+
+		startTime := time.Now().Unix()
+		publisher.Publish(context.Background(), blockEvents)
+		publisher.PublishRevert(context.Background(), revertBlock)
+		publisher.PublishFinalized(context.Background(), finalizedBlock)
+		publisher.PublishTxs(context.Background(), data.BlockTxs{Hash: "hash4"})
+		publisher.PublishScrs(context.Background(), data.BlockScrs{Hash: "hash5"})
+		publisher.PublishBlockEventsWithOrder(context.Background(), data.BlockEventsWithOrder{Hash: "hash6"})
+		publisher.PublishTxEvents(context.Background(), data.BlockTxEvents{Hash: "hash7"})
+
+		// the records are buffered until close
+		require.Equal(t, 0, len(readRecords(t, args.Config.Path)))
+		require.Nil(t, publisher.Close())
+
+		records := readRecords(t, args.Config.Path)
+		require.Equal(t, 3, len(records))
+		require.Equal(t, []string{file.EventsRecordType, file.RevertRecordType, file.FinalizedRecordType}, []string{records[0].Type, records[1].Type, records[2].Type})
+
+		expectedData := []interface{}{blockEvents, revertBlock, finalizedBlock}
+		for index, r := range records {
+			require.GreaterOrEqual(t, r.Timestamp, startTime)
+
+			expectedBytes, _ := json.Marshal(expectedData[index])
+			require.JSONEq(t, string(expectedBytes), string(r.Data))
+		}
+	})
+
+	t.Run("should append to the existing file", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsFilePublisher(t)
+		publisher, _ := file.NewFilePublisher(args)
+		publisher.PublishFinalized(context.Background(), data.FinalizedBlock{Hash: "hash1"})
+		require.Nil(t, publisher.Close())
+
+		publisher, _ = file.NewFilePublisher(args)
+		publisher.PublishFinalized(context.Background(), data.FinalizedBlock{Hash: "hash2"})
+		require.Nil(t, publisher.Close())
+
+		require.Equal(t, 2, len(readRecords(t, args.Config.Path)))
+	})
+
+	t.Run("publish after close should fail", func(t *testing.T) {
+		t.Parallel()
+
+		publisher, _ := file.NewFilePublisher(createMockArgsFilePublisher(t))
+		require.Nil(t, publisher.Close())
+		require.Nil(t, publisher.Close())
+
+		publisher.PublishFinalized(context.Background(), data.FinalizedBlock{Hash: "hash1"})
+
+		require.True(t, strings.Contains(publisher.GetMetricsForPrometheus(), `file_write_failures{type="finalized"} 1`))
+	})
+}
+
+func TestFilePublisher_Rotation(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should rotate the file without losing records", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsFilePublisher(t)
+		args.Config.MaxFileSizeBytes = 300
+		publisher, err := file.NewFilePublisher(args)
+		require.Nil(t, err)
+
+		numRecords := 20
+		for i := 0; i < numRecords; i++ {
+			publisher.PublishFinalized(context.Background(), data.FinalizedBlock{Hash: fmt.Sprintf("hash%d", i)})
+		}
+		require.Nil(t, publisher.Close())
+
+		backups, err := filepath.Glob(args.Config.Path + ".*")
+		require.Nil(t, err)
+		require.Greater(t, len(backups), 1)
+
+		hashes := make([]string, 0, numRecords)
+		paths := make([]string, 0, len(backups)+1)
+		for index := 1; index <= len(backups); index++ {
+			paths = append(paths, fmt.Sprintf("%s.%d", args.Config.Path, index))
+		}
+		paths = append(paths, args.Config.Path)
+
+		for _, path := range paths {
+			fileInfo, errStat := os.Stat(path)
+			require.Nil(t, errStat)
+			require.LessOrEqual(t, fileInfo.Size(), int64(args.Config.MaxFileSizeBytes))
+
+			for _, r := range readRecords(t, path) {
+				finalizedBlock := data.FinalizedBlock{}
+				err = json.Unmarshal(r.Data, &finalizedBlock)
+				require.Nil(t, err)
+
+				hashes = append(hashes, finalizedBlock.Hash)
+			}
+		}
+
+		require.Equal(t, numRecords, len(hashes))
+		for i := 0; i < numRecords; i++ {
+			require.Equal(t, fmt.Sprintf("hash%d", i), hashes[i])
+		}
+	})
+
+	t.Run("record larger than the max size should be written to a new file", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsFilePublisher(t)
+		args.Config.MaxFileSizeBytes = 10
+		publisher, _ := file.NewFilePublisher(args)
+
+		publisher.PublishFinalized(context.Background(), data.FinalizedBlock{Hash: "hash1"})
+		publisher.PublishFinalized(context.Background(), data.FinalizedBlock{Hash: "hash2"})
+		require.Nil(t, publisher.Close())
+
+		require.Equal(t, 1, len(readRecords(t, args.Config.Path+".1")))
+		require.Equal(t, 1, len(readRecords(t, args.Config.Path)))
+	})
+
+	t.Run("should not overwrite the files rotated before restart", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsFilePublisher(t)
+		args.Config.MaxFileSizeBytes = 10
+		err := os.WriteFile(args.Config.Path+".3", []byte("rotated\n"), 0600)
+		require.Nil(t, err)
+
+		publisher, _ := file.NewFilePublisher(args)
+		publisher.PublishFinalized(context.Background(), data.FinalizedBlock{Hash: "hash1"})
+		publisher.PublishFinalized(context.Background(), data.FinalizedBlock{Hash: "hash2"})
+		require.Nil(t, publisher.Close())
+
+		rotated, err := os.ReadFile(args.Config.Path + ".3")
+		require.Nil(t, err)
+		require.Equal(t, "rotated\n", string(rotated))
+		require.Equal(t, 1, len(readRecords(t, args.Config.Path+".4")))
+	})
+}
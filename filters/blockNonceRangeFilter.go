@@ -0,0 +1,36 @@
+package filters
+
+import (
+	"github.com/multiversx/mx-chain-notifier-go/common"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+)
+
+// BlockNonceRangeFilter matches the events of the blocks with the nonce between MinNonce and
+// MaxNonce, inclusive. It does not depend on the subscription, so it is used by the hub in
+// addition to the subscription filter
+type BlockNonceRangeFilter struct {
+	MinNonce uint64
+	MaxNonce uint64
+}
+
+// NewBlockNonceRangeFilter creates a new block nonce range filter
+func NewBlockNonceRangeFilter(minNonce uint64, maxNonce uint64) (*BlockNonceRangeFilter, error) {
+	if minNonce > maxNonce {
+		return nil, common.ErrInvalidNonceRange
+	}
+
+	return &BlockNonceRangeFilter{
+		MinNonce: minNonce,
+		MaxNonce: maxNonce,
+	}, nil
+}
+
+// MatchEvent returns true if the block nonce of the event is within the range
+func (bf *BlockNonceRangeFilter) MatchEvent(_ data.Subscription, event data.Event) bool {
+	return event.BlockNonce >= bf.MinNonce && event.BlockNonce <= bf.MaxNonce
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (bf *BlockNonceRangeFilter) IsInterfaceNil() bool {
+	return bf == nil
+}
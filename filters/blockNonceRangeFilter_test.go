@@ -0,0 +1,70 @@
+package filters
+
+import (
+	"math"
+	"testing"
+
+	"github.com/multiversx/mx-chain-notifier-go/common"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBlockNonceRangeFilter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("min nonce greater than max nonce should error", func(t *testing.T) {
+		t.Parallel()
+
+		filter, err := NewBlockNonceRangeFilter(11, 10)
+		require.Nil(t, filter)
+		require.Equal(t, common.ErrInvalidNonceRange, err)
+	})
+
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		filter, err := NewBlockNonceRangeFilter(10, 10)
+		require.Nil(t, err)
+		require.False(t, filter.IsInterfaceNil())
+		require.Equal(t, uint64(10), filter.MinNonce)
+		require.Equal(t, uint64(10), filter.MaxNonce)
+	})
+}
+
+func TestBlockNonceRangeFilter_MatchEvent(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should match the range inclusive on both ends", func(t *testing.T) {
+		t.Parallel()
+
+		filter, _ := NewBlockNonceRangeFilter(10, 20)
+		s := data.Subscription{Address: "erd1"}
+
+		require.False(t, filter.MatchEvent(s, data.Event{BlockNonce: 0}))
+		require.False(t, filter.MatchEvent(s, data.Event{BlockNonce: 9}))
+		require.True(t, filter.MatchEvent(s, data.Event{BlockNonce: 10}))
+		require.True(t, filter.MatchEvent(s, data.Event{BlockNonce: 15}))
+		require.True(t, filter.MatchEvent(s, data.Event{BlockNonce: 20}))
+		require.False(t, filter.MatchEvent(s, data.Event{BlockNonce: 21}))
+		require.False(t, filter.MatchEvent(s, data.Event{BlockNonce: math.MaxUint64}))
+	})
+
+	t.Run("single nonce range should match only that nonce", func(t *testing.T) {
+		t.Parallel()
+
+		filter, _ := NewBlockNonceRangeFilter(7, 7)
+
+		require.False(t, filter.MatchEvent(data.Subscription{}, data.Event{BlockNonce: 6}))
+		require.True(t, filter.MatchEvent(data.Subscription{}, data.Event{BlockNonce: 7}))
+		require.False(t, filter.MatchEvent(data.Subscription{}, data.Event{BlockNonce: 8}))
+	})
+
+	t.Run("full range should match all nonces", func(t *testing.T) {
+		t.Parallel()
+
+		filter, _ := NewBlockNonceRangeFilter(0, math.MaxUint64)
+
+		require.True(t, filter.MatchEvent(data.Subscription{}, data.Event{BlockNonce: 0}))
+		require.True(t, filter.MatchEvent(data.Subscription{}, data.Event{BlockNonce: math.MaxUint64}))
+	})
+}
@@ -0,0 +1,70 @@
+package filters
+
+import (
+	"github.com/multiversx/mx-chain-notifier-go/data"
+)
+
+// FilterConfig defines the serializable configuration of the hub events filter. The
+// subscription address prefixes are the ones described for the prefix filter. If the
+// addresses or the identifiers are set, only the events with one of those addresses and
+// one of those identifiers are matched against the subscriptions
+type FilterConfig struct {
+	Addresses   []string `json:"addresses"`
+	Prefixes    []string `json:"prefixes"`
+	Identifiers []string `json:"identifiers"`
+}
+
+// ConfigFilter is the events filter created from a filter config
+type ConfigFilter struct {
+	prefixFilter *PrefixFilter
+	addresses    map[string]struct{}
+	identifiers  map[string]struct{}
+}
+
+// NewConfigFilter creates a new events filter with the provided filter config
+func NewConfigFilter(cfg FilterConfig) (*ConfigFilter, error) {
+	prefixFilter, err := NewPrefixFilter(cfg.Prefixes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ConfigFilter{
+		prefixFilter: prefixFilter,
+		addresses:    toSet(cfg.Addresses),
+		identifiers:  toSet(cfg.Identifiers),
+	}, nil
+}
+
+func toSet(values []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(values))
+	for _, value := range values {
+		set[value] = struct{}{}
+	}
+
+	return set
+}
+
+// MatchEvent will try to match subscription data with an event, if the event is allowed by
+// the configured addresses and identifiers
+func (cf *ConfigFilter) MatchEvent(subscription data.Subscription, event data.Event) bool {
+	if !isInSet(cf.addresses, event.Address) || !isInSet(cf.identifiers, event.Identifier) {
+		return false
+	}
+
+	return cf.prefixFilter.MatchEvent(subscription, event)
+}
+
+// isInSet returns true if the value is in the set, or if the set is empty
+func isInSet(set map[string]struct{}, value string) bool {
+	if len(set) == 0 {
+		return true
+	}
+
+	_, ok := set[value]
+	return ok
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (cf *ConfigFilter) IsInterfaceNil() bool {
+	return cf == nil
+}
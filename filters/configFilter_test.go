@@ -0,0 +1,79 @@
+package filters
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/dispatcher"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewConfigFilter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid prefix should error", func(t *testing.T) {
+		t.Parallel()
+
+		filter, err := NewConfigFilter(FilterConfig{Prefixes: []string{"erd"}})
+		require.Nil(t, filter)
+		require.True(t, errors.Is(err, ErrInvalidAddressPrefix))
+	})
+
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		filter, err := NewConfigFilter(FilterConfig{})
+		require.Nil(t, err)
+		require.False(t, filter.IsInterfaceNil())
+	})
+}
+
+func TestConfigFilter_MatchEvent(t *testing.T) {
+	t.Parallel()
+
+	matchAll := data.Subscription{MatchLevel: dispatcher.MatchAll}
+
+	t.Run("empty config should match as the prefix filter", func(t *testing.T) {
+		t.Parallel()
+
+		filter, _ := NewConfigFilter(FilterConfig{Prefixes: []string{scPrefix}})
+
+		require.True(t, filter.MatchEvent(matchAll, data.Event{Address: userAddress, Identifier: "transfer"}))
+		require.True(t, filter.MatchEvent(
+			data.Subscription{MatchLevel: dispatcher.MatchAddress, Address: scPrefix},
+			data.Event{Address: scAddress1},
+		))
+		require.False(t, filter.MatchEvent(
+			data.Subscription{MatchLevel: dispatcher.MatchAddress, Address: scPrefix},
+			data.Event{Address: userAddress},
+		))
+	})
+
+	t.Run("addresses should restrict the matched events", func(t *testing.T) {
+		t.Parallel()
+
+		filter, _ := NewConfigFilter(FilterConfig{Addresses: []string{scAddress1, scAddress2}})
+
+		require.True(t, filter.MatchEvent(matchAll, data.Event{Address: scAddress1}))
+		require.True(t, filter.MatchEvent(matchAll, data.Event{Address: scAddress2}))
+		require.False(t, filter.MatchEvent(matchAll, data.Event{Address: userAddress}))
+	})
+
+	t.Run("identifiers should restrict the matched events", func(t *testing.T) {
+		t.Parallel()
+
+		filter, _ := NewConfigFilter(FilterConfig{
+			Addresses:   []string{scAddress1},
+			Identifiers: []string{"swap"},
+		})
+
+		require.True(t, filter.MatchEvent(matchAll, data.Event{Address: scAddress1, Identifier: "swap"}))
+		require.False(t, filter.MatchEvent(matchAll, data.Event{Address: scAddress1, Identifier: "transfer"}))
+		require.False(t, filter.MatchEvent(matchAll, data.Event{Address: scAddress2, Identifier: "swap"}))
+		require.False(t, filter.MatchEvent(
+			data.Subscription{MatchLevel: dispatcher.MatchIdentifier, Identifier: "transfer"},
+			data.Event{Address: scAddress1, Identifier: "swap"},
+		))
+	})
+}
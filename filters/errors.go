@@ -0,0 +1,9 @@
+package filters
+
+import "errors"
+
+// ErrInvalidAddressPrefix signals that an invalid bech32 address prefix has been provided
+var ErrInvalidAddressPrefix = errors.New("invalid bech32 address prefix")
+
+// ErrInvalidRegexPattern signals that an invalid address regular expression has been provided
+var ErrInvalidRegexPattern = errors.New("invalid address regex pattern")
@@ -20,9 +20,9 @@ func (f *defaultFilter) MatchEvent(subscription data.Subscription, event data.Ev
 	case dispatcher.MatchAddress:
 		return event.Address == subscription.Address
 	case dispatcher.MatchAddressIdentifier:
-		return event.Address == subscription.Address && event.Identifier == subscription.Identifier
+		return event.Address == subscription.Address && matchIdentifier(subscription, event.Identifier)
 	case dispatcher.MatchIdentifier:
-		return event.Identifier == subscription.Identifier
+		return matchIdentifier(subscription, event.Identifier)
 	case dispatcher.MatchTopics:
 		return f.matchTopics(subscription, event)
 	default:
@@ -30,6 +30,25 @@ func (f *defaultFilter) MatchEvent(subscription data.Subscription, event data.Ev
 	}
 }
 
+// matchIdentifier returns true if the identifier is the one of the subscription or one of
+// its identifiers list. A subscription without identifiers matches all of them
+func matchIdentifier(subscription data.Subscription, identifier string) bool {
+	if subscription.Identifier == "" && len(subscription.Identifiers) == 0 {
+		return true
+	}
+	if subscription.Identifier != "" && subscription.Identifier == identifier {
+		return true
+	}
+
+	for _, subscriptionIdentifier := range subscription.Identifiers {
+		if subscriptionIdentifier == identifier {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (f *defaultFilter) matchTopics(subscription data.Subscription, event data.Event) bool {
 	return false
 }
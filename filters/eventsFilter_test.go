@@ -1,8 +1,13 @@
 package filters
 
 import (
+	"bytes"
+	"encoding/hex"
 	"testing"
 
+	"github.com/google/uuid"
+	"github.com/multiversx/mx-chain-core-go/core/pubkeyConverter"
+	"github.com/multiversx/mx-chain-notifier-go/common"
 	"github.com/multiversx/mx-chain-notifier-go/data"
 	"github.com/multiversx/mx-chain-notifier-go/dispatcher"
 	"github.com/stretchr/testify/require"
@@ -78,3 +83,89 @@ func TestDefaultFilter_MatchEventMatchIdentifier(t *testing.T) {
 
 	require.True(t, filter.MatchEvent(s, events[2]))
 }
+
+func TestDefaultFilter_MatchEventMatchIdentifiers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("identifiers list should match any of them", func(t *testing.T) {
+		t.Parallel()
+
+		s := data.Subscription{
+			Identifiers: []string{"swap", "setValue"},
+			MatchLevel:  dispatcher.MatchIdentifier,
+		}
+
+		require.True(t, filter.MatchEvent(s, events[0]))
+		require.False(t, filter.MatchEvent(s, events[1]))
+		require.True(t, filter.MatchEvent(s, events[2]))
+		require.False(t, filter.MatchEvent(s, events[3]))
+	})
+
+	t.Run("identifier and identifiers list should match any of them", func(t *testing.T) {
+		t.Parallel()
+
+		s := data.Subscription{
+			Identifier:  "getValue",
+			Identifiers: []string{"addLiquidity"},
+			MatchLevel:  dispatcher.MatchIdentifier,
+		}
+
+		require.False(t, filter.MatchEvent(s, events[0]))
+		require.True(t, filter.MatchEvent(s, events[1]))
+		require.False(t, filter.MatchEvent(s, events[2]))
+		require.True(t, filter.MatchEvent(s, events[3]))
+	})
+
+	t.Run("address and identifiers list should match both", func(t *testing.T) {
+		t.Parallel()
+
+		s := data.Subscription{
+			Address:     "erd1",
+			Identifiers: []string{"swap", "addLiquidity"},
+			MatchLevel:  dispatcher.MatchAddressIdentifier,
+		}
+
+		require.True(t, filter.MatchEvent(s, events[0]))
+		require.False(t, filter.MatchEvent(s, events[1]))
+		require.False(t, filter.MatchEvent(s, data.Event{Address: "erd1", Identifier: "setValue"}))
+	})
+
+	t.Run("empty identifiers should match all of them", func(t *testing.T) {
+		t.Parallel()
+
+		s := data.Subscription{
+			Address:     "erd1",
+			Identifiers: []string{},
+			MatchLevel:  dispatcher.MatchAddressIdentifier,
+		}
+
+		require.True(t, filter.MatchEvent(s, events[0]))
+		require.True(t, filter.MatchEvent(s, data.Event{Address: "erd1", Identifier: "setValue"}))
+		require.False(t, filter.MatchEvent(s, events[1]))
+	})
+}
+
+func TestDefaultFilter_MatchEventAddressForms(t *testing.T) {
+	t.Parallel()
+
+	converter, _ := pubkeyConverter.NewBech32PubkeyConverter(32, "erd")
+	pubKey := bytes.Repeat([]byte{2}, 32)
+	otherPubKey := bytes.Repeat([]byte{3}, 32)
+
+	// the events carry the bech32 form of the raw addresses of the outport log events
+	event := data.Event{Address: converter.SilentEncode(pubKey, nil), Identifier: "swap"}
+	otherEvent := data.Event{Address: converter.SilentEncode(otherPubKey, nil), Identifier: "swap"}
+
+	for _, address := range []string{hex.EncodeToString(pubKey), converter.SilentEncode(pubKey, nil)} {
+		subMap, _ := dispatcher.NewSubscriptionMapper(dispatcher.ArgsSubscriptionMapper{PubKeyConverter: converter})
+		err := subMap.MatchSubscribeEvent(data.SubscribeEvent{
+			DispatcherID:        uuid.New(),
+			SubscriptionEntries: []data.SubscriptionEntry{{Address: address, Identifier: "swap"}},
+		})
+		require.Nil(t, err)
+
+		s := subMap.Subscriptions()[common.PushLogsAndEvents][0]
+		require.True(t, filter.MatchEvent(s, event), address)
+		require.False(t, filter.MatchEvent(s, otherEvent), address)
+	}
+}
@@ -0,0 +1,48 @@
+package filters
+
+import (
+	"path"
+
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/dispatcher"
+)
+
+// GlobFilter matches the subscriptions whose address is a glob pattern, e.g. erd1*staking*,
+// against the event addresses. The other subscriptions are matched by the default filter
+type GlobFilter struct {
+	defaultFilter *defaultFilter
+}
+
+// NewGlobFilter creates a new glob filter
+func NewGlobFilter() *GlobFilter {
+	return &GlobFilter{
+		defaultFilter: NewDefaultFilter(),
+	}
+}
+
+// MatchEvent will try to match subscription data with an event
+func (gf *GlobFilter) MatchEvent(subscription data.Subscription, event data.Event) bool {
+	if !subscription.IsGlob {
+		return gf.defaultFilter.MatchEvent(subscription, event)
+	}
+
+	switch subscription.MatchLevel {
+	case dispatcher.MatchAddress:
+		return matchGlob(subscription.Address, event.Address)
+	case dispatcher.MatchAddressIdentifier:
+		return matchGlob(subscription.Address, event.Address) && matchIdentifier(subscription, event.Identifier)
+	default:
+		return gf.defaultFilter.MatchEvent(subscription, event)
+	}
+}
+
+// matchGlob returns false for an invalid pattern, the patterns are validated on subscribe
+func matchGlob(pattern string, address string) bool {
+	matched, err := path.Match(pattern, address)
+	return err == nil && matched
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (gf *GlobFilter) IsInterfaceNil() bool {
+	return gf == nil
+}
@@ -0,0 +1,72 @@
+package filters
+
+import (
+	"testing"
+
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/dispatcher"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGlobFilter_MatchEvent(t *testing.T) {
+	t.Parallel()
+
+	filter := NewGlobFilter()
+	require.False(t, filter.IsInterfaceNil())
+
+	t.Run("single wildcard should match the addresses with the prefix", func(t *testing.T) {
+		t.Parallel()
+
+		s := data.Subscription{Address: scPrefix + "*", MatchLevel: dispatcher.MatchAddress, IsGlob: true}
+
+		require.True(t, filter.MatchEvent(s, data.Event{Address: scAddress1}))
+		require.True(t, filter.MatchEvent(s, data.Event{Address: scAddress2}))
+		require.True(t, filter.MatchEvent(s, data.Event{Address: scPrefix}))
+		require.False(t, filter.MatchEvent(s, data.Event{Address: userAddress}))
+	})
+
+	t.Run("multiple wildcards should match anywhere in the address", func(t *testing.T) {
+		t.Parallel()
+
+		s := data.Subscription{Address: "erd1*staking*", MatchLevel: dispatcher.MatchAddress, IsGlob: true}
+
+		require.True(t, filter.MatchEvent(s, data.Event{Address: "erd1qqstakingqq"}))
+		require.True(t, filter.MatchEvent(s, data.Event{Address: "erd1staking"}))
+		require.False(t, filter.MatchEvent(s, data.Event{Address: "erd1qqstakqq"}))
+		require.False(t, filter.MatchEvent(s, data.Event{Address: "xerd1qqstakingqq"}))
+
+		s = data.Subscription{Address: "erd1*qpgq*d8ss*", MatchLevel: dispatcher.MatchAddress, IsGlob: true}
+
+		require.True(t, filter.MatchEvent(s, data.Event{Address: scAddress1}))
+		require.False(t, filter.MatchEvent(s, data.Event{Address: scAddress2}))
+		require.False(t, filter.MatchEvent(s, data.Event{Address: userAddress}))
+	})
+
+	t.Run("glob address and identifier should match both", func(t *testing.T) {
+		t.Parallel()
+
+		s := data.Subscription{Address: "*pgq*", Identifier: "swap", MatchLevel: dispatcher.MatchAddressIdentifier, IsGlob: true}
+
+		require.True(t, filter.MatchEvent(s, data.Event{Address: scAddress1, Identifier: "swap"}))
+		require.False(t, filter.MatchEvent(s, data.Event{Address: scAddress1, Identifier: "addLiquidity"}))
+		require.False(t, filter.MatchEvent(s, data.Event{Address: userAddress, Identifier: "swap"}))
+	})
+
+	t.Run("not glob subscriptions should be matched exactly", func(t *testing.T) {
+		t.Parallel()
+
+		s := data.Subscription{Address: scPrefix + "*", MatchLevel: dispatcher.MatchAddress}
+		require.False(t, filter.MatchEvent(s, data.Event{Address: scAddress1}))
+		require.True(t, filter.MatchEvent(s, data.Event{Address: scPrefix + "*"}))
+
+		s = data.Subscription{Identifier: "swap", MatchLevel: dispatcher.MatchIdentifier}
+		require.True(t, filter.MatchEvent(s, data.Event{Address: userAddress, Identifier: "swap"}))
+	})
+
+	t.Run("invalid pattern should not match", func(t *testing.T) {
+		t.Parallel()
+
+		s := data.Subscription{Address: "erd1[*", MatchLevel: dispatcher.MatchAddress, IsGlob: true}
+		require.False(t, filter.MatchEvent(s, data.Event{Address: scAddress1}))
+	})
+}
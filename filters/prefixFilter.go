@@ -0,0 +1,151 @@
+package filters
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/dispatcher"
+)
+
+const (
+	bech32Separator = "1"
+	bech32Charset   = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+	bech32MaxLength = 90
+)
+
+// PrefixFilter matches the subscriptions whose address is one of the registered prefixes
+// against all the events with an address starting with that prefix. The other subscriptions
+// are matched by the glob filter, which also handles the exact addresses
+type PrefixFilter struct {
+	globFilter *GlobFilter
+	prefixes   *prefixTrie
+}
+
+// NewPrefixFilter creates a new prefix filter with the provided bech32 partial addresses,
+// made of the human readable part, the separator and the beginning of the data part
+func NewPrefixFilter(prefixes []string) (*PrefixFilter, error) {
+	trie := newPrefixTrie()
+	for _, prefix := range prefixes {
+		err := checkBech32Prefix(prefix)
+		if err != nil {
+			return nil, err
+		}
+
+		trie.insert(prefix)
+	}
+
+	return &PrefixFilter{
+		globFilter: NewGlobFilter(),
+		prefixes:   trie,
+	}, nil
+}
+
+func checkBech32Prefix(prefix string) error {
+	if len(prefix) > bech32MaxLength {
+		return fmt.Errorf("%w: %s is too long", ErrInvalidAddressPrefix, prefix)
+	}
+
+	separatorIndex := strings.Index(prefix, bech32Separator)
+	if separatorIndex < 1 {
+		return fmt.Errorf("%w: %q has no human readable part", ErrInvalidAddressPrefix, prefix)
+	}
+
+	for _, c := range prefix[:separatorIndex] {
+		if c < 'a' || c > 'z' {
+			return fmt.Errorf("%w: %s has an invalid human readable part", ErrInvalidAddressPrefix, prefix)
+		}
+	}
+	for _, c := range prefix[separatorIndex+1:] {
+		if !strings.ContainsRune(bech32Charset, c) {
+			return fmt.Errorf("%w: %s has an invalid character %q", ErrInvalidAddressPrefix, prefix, c)
+		}
+	}
+
+	return nil
+}
+
+// MatchEvent will try to match subscription data with an event
+func (pf *PrefixFilter) MatchEvent(subscription data.Subscription, event data.Event) bool {
+	switch subscription.MatchLevel {
+	case dispatcher.MatchAddress:
+		if pf.prefixes.contains(subscription.Address) {
+			return pf.prefixes.isPrefixOf(subscription.Address, event.Address)
+		}
+	case dispatcher.MatchAddressIdentifier:
+		if pf.prefixes.contains(subscription.Address) {
+			return pf.prefixes.isPrefixOf(subscription.Address, event.Address) && matchIdentifier(subscription, event.Identifier)
+		}
+	}
+
+	return pf.globFilter.MatchEvent(subscription, event)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (pf *PrefixFilter) IsInterfaceNil() bool {
+	return pf == nil
+}
+
+type trieNode struct {
+	children map[byte]*trieNode
+	terminal bool
+}
+
+// prefixTrie holds the registered prefixes, byte by byte, so that checking a prefix
+// against an address only walks the prefix length
+type prefixTrie struct {
+	root *trieNode
+}
+
+func newPrefixTrie() *prefixTrie {
+	return &prefixTrie{
+		root: newTrieNode(),
+	}
+}
+
+func newTrieNode() *trieNode {
+	return &trieNode{
+		children: make(map[byte]*trieNode),
+	}
+}
+
+func (pt *prefixTrie) insert(prefix string) {
+	node := pt.root
+	for i := 0; i < len(prefix); i++ {
+		child, ok := node.children[prefix[i]]
+		if !ok {
+			child = newTrieNode()
+			node.children[prefix[i]] = child
+		}
+		node = child
+	}
+
+	node.terminal = true
+}
+
+// contains returns true if the prefix has been registered
+func (pt *prefixTrie) contains(prefix string) bool {
+	return pt.isPrefixOf(prefix, prefix)
+}
+
+// isPrefixOf returns true if the prefix has been registered and the address starts with it
+func (pt *prefixTrie) isPrefixOf(prefix string, address string) bool {
+	if prefix == "" || len(prefix) > len(address) {
+		return false
+	}
+
+	node := pt.root
+	for i := 0; i < len(prefix); i++ {
+		if address[i] != prefix[i] {
+			return false
+		}
+
+		child, ok := node.children[prefix[i]]
+		if !ok {
+			return false
+		}
+		node = child
+	}
+
+	return node.terminal
+}
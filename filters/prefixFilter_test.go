@@ -0,0 +1,127 @@
+package filters
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/dispatcher"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	scPrefix    = "erd1qqqqqqqqqqqqqpgq"
+	scAddress1  = "erd1qqqqqqqqqqqqqpgqak8zt22wl2ph4tswtyc39namqx6ysa2sd8ss4xmlj3"
+	scAddress2  = "erd1qqqqqqqqqqqqqpgq7ykazrzd905zvnlr88dpfw06677lxe9w0n4suz00uh"
+	userAddress = "erd1spyavw0956vq68xj8y4tenjpq2wd5a9p2c6j8gsz7ztyrnpxrruqzu66jx"
+)
+
+func TestNewPrefixFilter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid prefixes should error", func(t *testing.T) {
+		t.Parallel()
+
+		invalidPrefixes := []string{
+			"",
+			"1qqqq",
+			"erd",
+			"ERD1qqqq",
+			"erd1qqqb",
+			"erd1qqqq1",
+			"erd1" + strings.Repeat("q", bech32MaxLength),
+		}
+		for _, prefix := range invalidPrefixes {
+			filter, err := NewPrefixFilter([]string{scPrefix, prefix})
+			require.Nil(t, filter)
+			require.True(t, errors.Is(err, ErrInvalidAddressPrefix), prefix)
+		}
+	})
+
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		filter, err := NewPrefixFilter([]string{scPrefix, "erd1"})
+		require.Nil(t, err)
+		require.False(t, filter.IsInterfaceNil())
+
+		filter, err = NewPrefixFilter(nil)
+		require.Nil(t, err)
+		require.False(t, filter.IsInterfaceNil())
+	})
+}
+
+func TestPrefixFilter_MatchEvent(t *testing.T) {
+	t.Parallel()
+
+	filter, err := NewPrefixFilter([]string{scPrefix})
+	require.Nil(t, err)
+
+	t.Run("address prefix should match the addresses starting with it", func(t *testing.T) {
+		t.Parallel()
+
+		s := data.Subscription{Address: scPrefix, MatchLevel: dispatcher.MatchAddress}
+
+		require.True(t, filter.MatchEvent(s, data.Event{Address: scAddress1}))
+		require.True(t, filter.MatchEvent(s, data.Event{Address: scAddress2}))
+		require.True(t, filter.MatchEvent(s, data.Event{Address: scPrefix}))
+		require.False(t, filter.MatchEvent(s, data.Event{Address: userAddress}))
+		require.False(t, filter.MatchEvent(s, data.Event{Address: "erd1qqqq"}))
+		require.False(t, filter.MatchEvent(s, data.Event{Address: ""}))
+	})
+
+	t.Run("address prefix and identifier should match both", func(t *testing.T) {
+		t.Parallel()
+
+		s := data.Subscription{Address: scPrefix, Identifier: "swap", MatchLevel: dispatcher.MatchAddressIdentifier}
+
+		require.True(t, filter.MatchEvent(s, data.Event{Address: scAddress1, Identifier: "swap"}))
+		require.False(t, filter.MatchEvent(s, data.Event{Address: scAddress1, Identifier: "addLiquidity"}))
+		require.False(t, filter.MatchEvent(s, data.Event{Address: userAddress, Identifier: "swap"}))
+	})
+
+	t.Run("not registered prefix should be matched exactly", func(t *testing.T) {
+		t.Parallel()
+
+		s := data.Subscription{Address: "erd1qqqqqqqqqqqqq", MatchLevel: dispatcher.MatchAddress}
+		require.False(t, filter.MatchEvent(s, data.Event{Address: scAddress1}))
+
+		s = data.Subscription{Address: scAddress1, MatchLevel: dispatcher.MatchAddress}
+		require.True(t, filter.MatchEvent(s, data.Event{Address: scAddress1}))
+		require.False(t, filter.MatchEvent(s, data.Event{Address: scAddress2}))
+	})
+
+	t.Run("other match levels should use the default filter", func(t *testing.T) {
+		t.Parallel()
+
+		s := data.Subscription{MatchLevel: dispatcher.MatchAll}
+		require.True(t, filter.MatchEvent(s, data.Event{Address: userAddress}))
+
+		s = data.Subscription{Identifier: "swap", MatchLevel: dispatcher.MatchIdentifier}
+		require.True(t, filter.MatchEvent(s, data.Event{Address: userAddress, Identifier: "swap"}))
+		require.False(t, filter.MatchEvent(s, data.Event{Address: scAddress1, Identifier: "getValue"}))
+	})
+
+	t.Run("glob subscriptions should use the glob filter", func(t *testing.T) {
+		t.Parallel()
+
+		s := data.Subscription{Address: "erd1*pgq*", MatchLevel: dispatcher.MatchAddress, IsGlob: true}
+		require.True(t, filter.MatchEvent(s, data.Event{Address: scAddress1}))
+		require.False(t, filter.MatchEvent(s, data.Event{Address: userAddress}))
+	})
+
+	t.Run("empty prefixes should match exactly", func(t *testing.T) {
+		t.Parallel()
+
+		emptyFilter, err := NewPrefixFilter(make([]string, 0))
+		require.Nil(t, err)
+
+		s := data.Subscription{Address: scPrefix, MatchLevel: dispatcher.MatchAddress}
+		require.False(t, emptyFilter.MatchEvent(s, data.Event{Address: scAddress1}))
+		require.True(t, emptyFilter.MatchEvent(s, data.Event{Address: scPrefix}))
+
+		s = data.Subscription{Address: "", MatchLevel: dispatcher.MatchAddress}
+		require.True(t, emptyFilter.MatchEvent(s, data.Event{Address: ""}))
+	})
+}
@@ -0,0 +1,58 @@
+package filters
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/dispatcher"
+)
+
+// RegexFilter matches the subscriptions whose address is one of the registered regular
+// expressions against all the events with an address matching it. The other subscriptions
+// are matched by the glob filter, which also handles the exact addresses
+type RegexFilter struct {
+	globFilter *GlobFilter
+	patterns   map[string]*regexp.Regexp
+}
+
+// NewRegexFilter creates a new regex filter, compiling each of the provided patterns once
+func NewRegexFilter(patterns []string) (*RegexFilter, error) {
+	compiledPatterns := make(map[string]*regexp.Regexp, len(patterns))
+	for _, pattern := range patterns {
+		compiledPattern, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %s", ErrInvalidRegexPattern, pattern, err.Error())
+		}
+
+		compiledPatterns[pattern] = compiledPattern
+	}
+
+	return &RegexFilter{
+		globFilter: NewGlobFilter(),
+		patterns:   compiledPatterns,
+	}, nil
+}
+
+// MatchEvent will try to match subscription data with an event
+func (rf *RegexFilter) MatchEvent(subscription data.Subscription, event data.Event) bool {
+	switch subscription.MatchLevel {
+	case dispatcher.MatchAddress:
+		pattern, ok := rf.patterns[subscription.Address]
+		if ok {
+			return pattern.MatchString(event.Address)
+		}
+	case dispatcher.MatchAddressIdentifier:
+		pattern, ok := rf.patterns[subscription.Address]
+		if ok {
+			return pattern.MatchString(event.Address) && matchIdentifier(subscription, event.Identifier)
+		}
+	}
+
+	return rf.globFilter.MatchEvent(subscription, event)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (rf *RegexFilter) IsInterfaceNil() bool {
+	return rf == nil
+}
@@ -0,0 +1,39 @@
+//go:build go1.18
+// +build go1.18
+
+package filters
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/dispatcher"
+)
+
+func FuzzRegexFilter_MatchEvent(f *testing.F) {
+	f.Add(scPattern, scAddress1)
+	f.Add("q{4}", userAddress)
+	f.Add("erd1(qq", scAddress2)
+	f.Add("", "")
+
+	f.Fuzz(func(t *testing.T, pattern string, address string) {
+		filter, err := NewRegexFilter([]string{pattern})
+		compiledPattern, errCompile := regexp.Compile(pattern)
+		if errCompile != nil {
+			if err == nil {
+				t.Fatalf("invalid pattern %q should error", pattern)
+			}
+			return
+		}
+		if err != nil {
+			t.Fatalf("valid pattern %q should not error: %v", pattern, err)
+		}
+
+		subscription := data.Subscription{Address: pattern, MatchLevel: dispatcher.MatchAddress}
+		matched := filter.MatchEvent(subscription, data.Event{Address: address})
+		if matched != compiledPattern.MatchString(address) {
+			t.Fatalf("pattern %q on address %q: got %v", pattern, address, matched)
+		}
+	})
+}
@@ -0,0 +1,107 @@
+package filters
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/dispatcher"
+	"github.com/stretchr/testify/require"
+)
+
+const scPattern = "^erd1qqqqqqqqqqqqqpgq[a-z0-9]+$"
+
+func TestNewRegexFilter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid pattern should error", func(t *testing.T) {
+		t.Parallel()
+
+		filter, err := NewRegexFilter([]string{scPattern, "erd1(qq"})
+		require.Nil(t, filter)
+		require.True(t, errors.Is(err, ErrInvalidRegexPattern))
+		require.True(t, strings.Contains(err.Error(), "erd1(qq"))
+	})
+
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		filter, err := NewRegexFilter([]string{scPattern, "staking"})
+		require.Nil(t, err)
+		require.False(t, filter.IsInterfaceNil())
+
+		filter, err = NewRegexFilter(nil)
+		require.Nil(t, err)
+		require.False(t, filter.IsInterfaceNil())
+	})
+}
+
+func TestRegexFilter_MatchEvent(t *testing.T) {
+	t.Parallel()
+
+	filter, err := NewRegexFilter([]string{scPattern, "q{4}"})
+	require.Nil(t, err)
+
+	t.Run("registered pattern should match the addresses matching it", func(t *testing.T) {
+		t.Parallel()
+
+		s := data.Subscription{Address: scPattern, MatchLevel: dispatcher.MatchAddress}
+
+		require.True(t, filter.MatchEvent(s, data.Event{Address: scAddress1}))
+		require.True(t, filter.MatchEvent(s, data.Event{Address: scAddress2}))
+		require.False(t, filter.MatchEvent(s, data.Event{Address: scPrefix}))
+		require.False(t, filter.MatchEvent(s, data.Event{Address: userAddress}))
+
+		s = data.Subscription{Address: "q{4}", MatchLevel: dispatcher.MatchAddress}
+
+		require.True(t, filter.MatchEvent(s, data.Event{Address: scAddress1}))
+		require.False(t, filter.MatchEvent(s, data.Event{Address: userAddress}))
+	})
+
+	t.Run("registered pattern and identifier should match both", func(t *testing.T) {
+		t.Parallel()
+
+		s := data.Subscription{Address: scPattern, Identifier: "swap", MatchLevel: dispatcher.MatchAddressIdentifier}
+
+		require.True(t, filter.MatchEvent(s, data.Event{Address: scAddress1, Identifier: "swap"}))
+		require.False(t, filter.MatchEvent(s, data.Event{Address: scAddress1, Identifier: "addLiquidity"}))
+		require.False(t, filter.MatchEvent(s, data.Event{Address: userAddress, Identifier: "swap"}))
+	})
+
+	t.Run("not registered addresses should be matched exactly", func(t *testing.T) {
+		t.Parallel()
+
+		s := data.Subscription{Address: "^erd1.*$", MatchLevel: dispatcher.MatchAddress}
+		require.False(t, filter.MatchEvent(s, data.Event{Address: scAddress1}))
+
+		s = data.Subscription{Address: scAddress1, MatchLevel: dispatcher.MatchAddress}
+		require.True(t, filter.MatchEvent(s, data.Event{Address: scAddress1}))
+		require.False(t, filter.MatchEvent(s, data.Event{Address: scAddress2}))
+
+		s = data.Subscription{Identifier: "swap", MatchLevel: dispatcher.MatchIdentifier}
+		require.True(t, filter.MatchEvent(s, data.Event{Address: userAddress, Identifier: "swap"}))
+	})
+}
+
+func BenchmarkRegexFilter_MatchEvent(b *testing.B) {
+	filter, _ := NewRegexFilter([]string{scPattern})
+	subscription := data.Subscription{Address: scPattern, MatchLevel: dispatcher.MatchAddress}
+	event := data.Event{Address: scAddress1}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		filter.MatchEvent(subscription, event)
+	}
+}
+
+func BenchmarkDefaultFilter_MatchEvent(b *testing.B) {
+	filter := NewDefaultFilter()
+	subscription := data.Subscription{Address: scAddress1, MatchLevel: dispatcher.MatchAddress}
+	event := data.Event{Address: scAddress1}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		filter.MatchEvent(subscription, event)
+	}
+}
@@ -9,16 +9,30 @@ require (
 	github.com/gorilla/websocket v1.5.0
 	github.com/multiversx/mx-chain-core-go v1.2.13
 	github.com/multiversx/mx-chain-logger-go v1.0.13
+	github.com/nats-io/nats-server/v2 v2.8.4
+	github.com/nats-io/nats.go v1.16.0
+	github.com/segmentio/kafka-go v0.4.38
 	github.com/spaolacci/murmur3 v1.1.0
 	github.com/streadway/amqp v1.0.0
 	github.com/stretchr/testify v1.8.4
 	github.com/urfave/cli v1.22.10
+	go.opentelemetry.io/otel v1.14.0
+	go.opentelemetry.io/otel/sdk v1.14.0
+	go.opentelemetry.io/otel/trace v1.14.0
 )
 
 require (
+	github.com/alicebob/miniredis/v2 v2.14.3
 	github.com/gin-contrib/cors v1.4.0
+	github.com/gogo/protobuf v1.3.2
+	github.com/golang-jwt/jwt/v4 v4.5.0
+	github.com/klauspost/compress v1.16.5
 	github.com/multiversx/mx-chain-communication-go v1.0.7
+	github.com/pelletier/go-toml v1.9.3
 	github.com/prometheus/client_model v0.4.0
 	github.com/prometheus/common v0.44.0
+	github.com/syndtr/goleveldb v1.0.1-0.20210819022825-2ae1ddf74ef7
+	github.com/vmihailenco/msgpack/v5 v5.3.5
+	google.golang.org/grpc v1.53.0
 	google.golang.org/protobuf v1.30.0
 )
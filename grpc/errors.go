@@ -0,0 +1,31 @@
+package grpc
+
+import "errors"
+
+// ErrEmptyURL signals that an empty gRPC server url has been provided
+var ErrEmptyURL = errors.New("empty gRPC url")
+
+// ErrNilPayloadHandler signals that a nil payload handler has been provided
+var ErrNilPayloadHandler = errors.New("nil payload handler")
+
+// ErrInvalidObserverEvent signals that an observer event without a block has been received
+var ErrInvalidObserverEvent = errors.New("invalid observer event")
+
+// ErrNilHub signals that a nil hub has been provided
+var ErrNilHub = errors.New("nil hub")
+
+// ErrInvalidSendTimeout signals that an invalid stream send timeout has been provided
+var ErrInvalidSendTimeout = errors.New("invalid send timeout")
+
+// ErrUnsupportedEventType signals that a subscription entry with an event type which is
+// not streamed has been provided
+var ErrUnsupportedEventType = errors.New("unsupported event type")
+
+// ErrSendTimeout signals that an event could not be sent on the stream in time
+var ErrSendTimeout = errors.New("stream send timeout")
+
+// ErrStreamQueueFull signals that the events queue of a stream is full
+var ErrStreamQueueFull = errors.New("stream queue is full")
+
+// ErrInvalidResumeID signals that the resume id supplied in the request metadata is not a valid uuid
+var ErrInvalidResumeID = errors.New("invalid resume id")
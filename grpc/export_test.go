@@ -0,0 +1,13 @@
+package grpc
+
+import "net"
+
+// NewGRPCSubscriptionServerWithListener -
+func NewGRPCSubscriptionServerWithListener(args ArgsGRPCSubscriptionServer, listener net.Listener) (*grpcSubscriptionServer, error) {
+	err := checkSubscriptionServerArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return newGRPCSubscriptionServer(args, listener), nil
+}
@@ -0,0 +1,1080 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: observer.proto
+
+package grpc
+
+import (
+	context "context"
+	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	outport "github.com/multiversx/mx-chain-core-go/data/outport"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+	reflect "reflect"
+	strings "strings"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// ObserverEvent holds one of the events pushed by the observer node
+type ObserverEvent struct {
+	// Types that are valid to be assigned to Event:
+	//	*ObserverEvent_OutportBlock
+	//	*ObserverEvent_RevertedBlock
+	//	*ObserverEvent_FinalizedBlock
+	Event isObserverEvent_Event `protobuf_oneof:"Event"`
+}
+
+func (m *ObserverEvent) Reset()      { *m = ObserverEvent{} }
+func (*ObserverEvent) ProtoMessage() {}
+func (*ObserverEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f64efb003ca9295a, []int{0}
+}
+func (m *ObserverEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ObserverEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *ObserverEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ObserverEvent.Merge(m, src)
+}
+func (m *ObserverEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *ObserverEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_ObserverEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ObserverEvent proto.InternalMessageInfo
+
+type isObserverEvent_Event interface {
+	isObserverEvent_Event()
+	Equal(interface{}) bool
+	MarshalTo([]byte) (int, error)
+	Size() int
+}
+
+type ObserverEvent_OutportBlock struct {
+	OutportBlock *outport.OutportBlock `protobuf:"bytes,1,opt,name=OutportBlock,proto3,oneof" json:"outportBlock,omitempty"`
+}
+type ObserverEvent_RevertedBlock struct {
+	RevertedBlock *outport.BlockData `protobuf:"bytes,2,opt,name=RevertedBlock,proto3,oneof" json:"revertedBlock,omitempty"`
+}
+type ObserverEvent_FinalizedBlock struct {
+	FinalizedBlock *outport.FinalizedBlock `protobuf:"bytes,3,opt,name=FinalizedBlock,proto3,oneof" json:"finalizedBlock,omitempty"`
+}
+
+func (*ObserverEvent_OutportBlock) isObserverEvent_Event()   {}
+func (*ObserverEvent_RevertedBlock) isObserverEvent_Event()  {}
+func (*ObserverEvent_FinalizedBlock) isObserverEvent_Event() {}
+
+func (m *ObserverEvent) GetEvent() isObserverEvent_Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (m *ObserverEvent) GetOutportBlock() *outport.OutportBlock {
+	if x, ok := m.GetEvent().(*ObserverEvent_OutportBlock); ok {
+		return x.OutportBlock
+	}
+	return nil
+}
+
+func (m *ObserverEvent) GetRevertedBlock() *outport.BlockData {
+	if x, ok := m.GetEvent().(*ObserverEvent_RevertedBlock); ok {
+		return x.RevertedBlock
+	}
+	return nil
+}
+
+func (m *ObserverEvent) GetFinalizedBlock() *outport.FinalizedBlock {
+	if x, ok := m.GetEvent().(*ObserverEvent_FinalizedBlock); ok {
+		return x.FinalizedBlock
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*ObserverEvent) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*ObserverEvent_OutportBlock)(nil),
+		(*ObserverEvent_RevertedBlock)(nil),
+		(*ObserverEvent_FinalizedBlock)(nil),
+	}
+}
+
+// EventAck acknowledges an observer event, in the order the events were received.
+// Error is empty if the event was processed successfully
+type EventAck struct {
+	Error string `protobuf:"bytes,1,opt,name=Error,proto3" json:"error,omitempty"`
+}
+
+func (m *EventAck) Reset()      { *m = EventAck{} }
+func (*EventAck) ProtoMessage() {}
+func (*EventAck) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f64efb003ca9295a, []int{1}
+}
+func (m *EventAck) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *EventAck) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *EventAck) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EventAck.Merge(m, src)
+}
+func (m *EventAck) XXX_Size() int {
+	return m.Size()
+}
+func (m *EventAck) XXX_DiscardUnknown() {
+	xxx_messageInfo_EventAck.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EventAck proto.InternalMessageInfo
+
+func (m *EventAck) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*ObserverEvent)(nil), "proto.ObserverEvent")
+	proto.RegisterType((*EventAck)(nil), "proto.EventAck")
+}
+
+func init() { proto.RegisterFile("observer.proto", fileDescriptor_f64efb003ca9295a) }
+
+var fileDescriptor_f64efb003ca9295a = []byte{
+	// 408 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x92, 0x4f, 0xef, 0xd2, 0x30,
+	0x18, 0xc7, 0xdb, 0x9f, 0xc1, 0x3f, 0x55, 0xc0, 0x0c, 0xff, 0x90, 0x69, 0x3a, 0xc3, 0x09, 0x13,
+	0xc7, 0x0c, 0xea, 0x49, 0x2f, 0x2e, 0x42, 0xbc, 0x18, 0x12, 0x12, 0x2e, 0x26, 0x1e, 0xb6, 0x51,
+	0x46, 0x03, 0x5b, 0x97, 0xd2, 0x2d, 0xe8, 0xc9, 0x97, 0xe0, 0x9b, 0x30, 0xf1, 0xa5, 0x78, 0xe4,
+	0xc8, 0x69, 0x91, 0x72, 0x31, 0x3b, 0xf1, 0x12, 0x0c, 0xdd, 0xa6, 0x1b, 0x17, 0x2f, 0x6b, 0xfb,
+	0xed, 0xf7, 0xf9, 0xec, 0xc9, 0xb7, 0x0f, 0x6a, 0x31, 0x77, 0x43, 0x78, 0x42, 0xf8, 0x20, 0xe2,
+	0x4c, 0x30, 0xad, 0xa1, 0x16, 0xdd, 0xf4, 0xa9, 0x58, 0xc6, 0xee, 0xc0, 0x63, 0x81, 0xe5, 0x33,
+	0x9f, 0x59, 0x4a, 0x76, 0xe3, 0x85, 0x3a, 0xa9, 0x83, 0xda, 0xe5, 0x55, 0xfa, 0xb8, 0x62, 0x0f,
+	0xe2, 0xb5, 0xa0, 0x09, 0xe1, 0x9b, 0xad, 0x15, 0x6c, 0x4d, 0x6f, 0xe9, 0xd0, 0xd0, 0xf4, 0x18,
+	0x27, 0xa6, 0xcf, 0xac, 0xb9, 0x23, 0x1c, 0x8b, 0xc5, 0x22, 0x62, 0x5c, 0x94, 0xab, 0xbd, 0x66,
+	0xde, 0x2a, 0xe7, 0xf4, 0xbe, 0x5f, 0xa1, 0xe6, 0xa4, 0x68, 0x68, 0x94, 0x90, 0x50, 0x68, 0x33,
+	0x74, 0x67, 0x52, 0xf1, 0x75, 0xe1, 0x13, 0xd8, 0xbf, 0x3d, 0xec, 0xe4, 0xfe, 0x41, 0xf5, 0xca,
+	0xd6, 0xb3, 0xd4, 0x78, 0x50, 0x85, 0x3e, 0x63, 0x01, 0x15, 0x24, 0x88, 0xc4, 0xe7, 0xf7, 0x60,
+	0x5a, 0xc3, 0x68, 0x33, 0xd4, 0x9c, 0x92, 0x84, 0x70, 0x41, 0xe6, 0x39, 0xf7, 0x4a, 0x71, 0xef,
+	0x16, 0x5c, 0xa5, 0xbd, 0x73, 0x84, 0x63, 0x3f, 0xca, 0x52, 0xe3, 0x21, 0xaf, 0x5a, 0x6b, 0xd4,
+	0x3a, 0x45, 0xfb, 0x84, 0x5a, 0x63, 0x1a, 0x3a, 0x6b, 0xfa, 0xa5, 0xe4, 0x5e, 0x53, 0xdc, 0xfb,
+	0x05, 0xb7, 0x7e, 0x69, 0x3f, 0xce, 0x52, 0xa3, 0xbb, 0xa8, 0x69, 0x35, 0xfa, 0x05, 0xcc, 0xbe,
+	0x81, 0x1a, 0x2a, 0x95, 0xde, 0x2b, 0x74, 0x53, 0x6d, 0xde, 0x7a, 0x2b, 0xed, 0x29, 0x6a, 0x8c,
+	0x38, 0x67, 0x5c, 0x45, 0x73, 0xcb, 0xee, 0x64, 0xa9, 0xd1, 0x26, 0x67, 0xe1, 0x1f, 0x6a, 0x9a,
+	0x3b, 0x86, 0x1f, 0xfe, 0xa6, 0xab, 0xaa, 0x37, 0xda, 0x9b, 0x4b, 0xe1, 0x5e, 0x19, 0x6c, 0xf5,
+	0x11, 0xf4, 0x76, 0xa1, 0x96, 0xff, 0xec, 0x81, 0x3e, 0x7c, 0x0e, 0xed, 0x70, 0x77, 0xc0, 0x60,
+	0x7f, 0xc0, 0xe0, 0x74, 0xc0, 0xf0, 0xab, 0xc4, 0xf0, 0x87, 0xc4, 0xf0, 0xa7, 0xc4, 0x70, 0x27,
+	0x31, 0xdc, 0x4b, 0x0c, 0x7f, 0x49, 0x0c, 0x7f, 0x4b, 0x0c, 0x4e, 0x12, 0xc3, 0x6f, 0x47, 0x0c,
+	0x76, 0x47, 0x0c, 0xf6, 0x47, 0x0c, 0x3e, 0xbe, 0xfc, 0xcf, 0xbc, 0x84, 0x4c, 0xd0, 0x05, 0x25,
+	0xfc, 0x3c, 0x33, 0x3e, 0x8f, 0xbc, 0xd7, 0xe7, 0x8f, 0x7b, 0x5d, 0x75, 0xf1, 0xe2, 0xcf, 0x00,
+	0x83, 0xd8, 0x2a, 0xdb, 0xb4, 0x02, 0x00, 0x00,
+}
+
+func (this *ObserverEvent) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ObserverEvent)
+	if !ok {
+		that2, ok := that.(ObserverEvent)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if that1.Event == nil {
+		if this.Event != nil {
+			return false
+		}
+	} else if this.Event == nil {
+		return false
+	} else if !this.Event.Equal(that1.Event) {
+		return false
+	}
+	return true
+}
+func (this *ObserverEvent_OutportBlock) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ObserverEvent_OutportBlock)
+	if !ok {
+		that2, ok := that.(ObserverEvent_OutportBlock)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.OutportBlock.Equal(that1.OutportBlock) {
+		return false
+	}
+	return true
+}
+func (this *ObserverEvent_RevertedBlock) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ObserverEvent_RevertedBlock)
+	if !ok {
+		that2, ok := that.(ObserverEvent_RevertedBlock)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.RevertedBlock.Equal(that1.RevertedBlock) {
+		return false
+	}
+	return true
+}
+func (this *ObserverEvent_FinalizedBlock) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ObserverEvent_FinalizedBlock)
+	if !ok {
+		that2, ok := that.(ObserverEvent_FinalizedBlock)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.FinalizedBlock.Equal(that1.FinalizedBlock) {
+		return false
+	}
+	return true
+}
+func (this *EventAck) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*EventAck)
+	if !ok {
+		that2, ok := that.(EventAck)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Error != that1.Error {
+		return false
+	}
+	return true
+}
+func (this *ObserverEvent) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&grpc.ObserverEvent{")
+	if this.Event != nil {
+		s = append(s, "Event: "+fmt.Sprintf("%#v", this.Event)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ObserverEvent_OutportBlock) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&grpc.ObserverEvent_OutportBlock{` +
+		`OutportBlock:` + fmt.Sprintf("%#v", this.OutportBlock) + `}`}, ", ")
+	return s
+}
+func (this *ObserverEvent_RevertedBlock) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&grpc.ObserverEvent_RevertedBlock{` +
+		`RevertedBlock:` + fmt.Sprintf("%#v", this.RevertedBlock) + `}`}, ", ")
+	return s
+}
+func (this *ObserverEvent_FinalizedBlock) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&grpc.ObserverEvent_FinalizedBlock{` +
+		`FinalizedBlock:` + fmt.Sprintf("%#v", this.FinalizedBlock) + `}`}, ", ")
+	return s
+}
+func (this *EventAck) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&grpc.EventAck{")
+	s = append(s, "Error: "+fmt.Sprintf("%#v", this.Error)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func valueToGoStringObserver(v interface{}, typ string) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// ObserveEventsClient is the client API for ObserveEvents service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ObserveEventsClient interface {
+	ObserveEvents(ctx context.Context, opts ...grpc.CallOption) (ObserveEvents_ObserveEventsClient, error)
+}
+
+type observeEventsClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewObserveEventsClient(cc *grpc.ClientConn) ObserveEventsClient {
+	return &observeEventsClient{cc}
+}
+
+func (c *observeEventsClient) ObserveEvents(ctx context.Context, opts ...grpc.CallOption) (ObserveEvents_ObserveEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ObserveEvents_serviceDesc.Streams[0], "/proto.ObserveEvents/ObserveEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &observeEventsObserveEventsClient{stream}
+	return x, nil
+}
+
+type ObserveEvents_ObserveEventsClient interface {
+	Send(*ObserverEvent) error
+	Recv() (*EventAck, error)
+	grpc.ClientStream
+}
+
+type observeEventsObserveEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *observeEventsObserveEventsClient) Send(m *ObserverEvent) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *observeEventsObserveEventsClient) Recv() (*EventAck, error) {
+	m := new(EventAck)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ObserveEventsServer is the server API for ObserveEvents service.
+type ObserveEventsServer interface {
+	ObserveEvents(ObserveEvents_ObserveEventsServer) error
+}
+
+// UnimplementedObserveEventsServer can be embedded to have forward compatible implementations.
+type UnimplementedObserveEventsServer struct {
+}
+
+func (*UnimplementedObserveEventsServer) ObserveEvents(srv ObserveEvents_ObserveEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ObserveEvents not implemented")
+}
+
+func RegisterObserveEventsServer(s *grpc.Server, srv ObserveEventsServer) {
+	s.RegisterService(&_ObserveEvents_serviceDesc, srv)
+}
+
+func _ObserveEvents_ObserveEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ObserveEventsServer).ObserveEvents(&observeEventsObserveEventsServer{stream})
+}
+
+type ObserveEvents_ObserveEventsServer interface {
+	Send(*EventAck) error
+	Recv() (*ObserverEvent, error)
+	grpc.ServerStream
+}
+
+type observeEventsObserveEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *observeEventsObserveEventsServer) Send(m *EventAck) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *observeEventsObserveEventsServer) Recv() (*ObserverEvent, error) {
+	m := new(ObserverEvent)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+var _ObserveEvents_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.ObserveEvents",
+	HandlerType: (*ObserveEventsServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ObserveEvents",
+			Handler:       _ObserveEvents_ObserveEvents_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "observer.proto",
+}
+
+func (m *ObserverEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ObserverEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ObserverEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Event != nil {
+		{
+			size := m.Event.Size()
+			i -= size
+			if _, err := m.Event.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ObserverEvent_OutportBlock) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ObserverEvent_OutportBlock) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.OutportBlock != nil {
+		{
+			size, err := m.OutportBlock.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintObserver(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+func (m *ObserverEvent_RevertedBlock) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ObserverEvent_RevertedBlock) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.RevertedBlock != nil {
+		{
+			size, err := m.RevertedBlock.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintObserver(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	return len(dAtA) - i, nil
+}
+func (m *ObserverEvent_FinalizedBlock) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ObserverEvent_FinalizedBlock) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.FinalizedBlock != nil {
+		{
+			size, err := m.FinalizedBlock.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintObserver(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *EventAck) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *EventAck) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *EventAck) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Error) > 0 {
+		i -= len(m.Error)
+		copy(dAtA[i:], m.Error)
+		i = encodeVarintObserver(dAtA, i, uint64(len(m.Error)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintObserver(dAtA []byte, offset int, v uint64) int {
+	offset -= sovObserver(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *ObserverEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Event != nil {
+		n += m.Event.Size()
+	}
+	return n
+}
+
+func (m *ObserverEvent_OutportBlock) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.OutportBlock != nil {
+		l = m.OutportBlock.Size()
+		n += 1 + l + sovObserver(uint64(l))
+	}
+	return n
+}
+func (m *ObserverEvent_RevertedBlock) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.RevertedBlock != nil {
+		l = m.RevertedBlock.Size()
+		n += 1 + l + sovObserver(uint64(l))
+	}
+	return n
+}
+func (m *ObserverEvent_FinalizedBlock) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.FinalizedBlock != nil {
+		l = m.FinalizedBlock.Size()
+		n += 1 + l + sovObserver(uint64(l))
+	}
+	return n
+}
+func (m *EventAck) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovObserver(uint64(l))
+	}
+	return n
+}
+
+func sovObserver(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozObserver(x uint64) (n int) {
+	return sovObserver(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (this *ObserverEvent) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ObserverEvent{`,
+		`Event:` + fmt.Sprintf("%v", this.Event) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ObserverEvent_OutportBlock) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ObserverEvent_OutportBlock{`,
+		`OutportBlock:` + strings.Replace(fmt.Sprintf("%v", this.OutportBlock), "OutportBlock", "outport.OutportBlock", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ObserverEvent_RevertedBlock) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ObserverEvent_RevertedBlock{`,
+		`RevertedBlock:` + strings.Replace(fmt.Sprintf("%v", this.RevertedBlock), "BlockData", "outport.BlockData", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ObserverEvent_FinalizedBlock) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ObserverEvent_FinalizedBlock{`,
+		`FinalizedBlock:` + strings.Replace(fmt.Sprintf("%v", this.FinalizedBlock), "FinalizedBlock", "outport.FinalizedBlock", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *EventAck) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&EventAck{`,
+		`Error:` + fmt.Sprintf("%v", this.Error) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func valueToStringObserver(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("*%v", pv)
+}
+func (m *ObserverEvent) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowObserver
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ObserverEvent: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ObserverEvent: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OutportBlock", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowObserver
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthObserver
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthObserver
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &outport.OutportBlock{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Event = &ObserverEvent_OutportBlock{v}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RevertedBlock", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowObserver
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthObserver
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthObserver
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &outport.BlockData{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Event = &ObserverEvent_RevertedBlock{v}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FinalizedBlock", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowObserver
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthObserver
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthObserver
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &outport.FinalizedBlock{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Event = &ObserverEvent_FinalizedBlock{v}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipObserver(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthObserver
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *EventAck) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowObserver
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: EventAck: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: EventAck: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowObserver
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthObserver
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthObserver
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Error = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipObserver(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthObserver
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipObserver(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowObserver
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowObserver
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowObserver
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthObserver
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupObserver
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthObserver
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthObserver        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowObserver          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupObserver = fmt.Errorf("proto: unexpected end of group")
+)
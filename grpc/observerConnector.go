@@ -0,0 +1,152 @@
+package grpc
+
+import (
+	"io"
+	"net"
+	"sync"
+
+	"github.com/multiversx/mx-chain-communication-go/websocket"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data/outport"
+	"github.com/multiversx/mx-chain-core-go/marshal"
+	logger "github.com/multiversx/mx-chain-logger-go"
+	"github.com/multiversx/mx-chain-notifier-go/common"
+	grpcLib "google.golang.org/grpc"
+)
+
+//go:generate protoc -I=. -I=$GOPATH/src -I=$GOPATH/src/github.com/multiversx/protobuf/protobuf --gogoslick_out=plugins=grpc:$GOPATH/src observer.proto
+
+var log = logger.GetOrCreate("grpc")
+
+// ArgsGRPCObserverConnector defines the arguments needed for gRPC observer connector creation
+type ArgsGRPCObserverConnector struct {
+	URL            string
+	Marshaller     marshal.Marshalizer
+	PayloadHandler websocket.PayloadHandler
+}
+
+type grpcObserverConnector struct {
+	marshaller     marshal.Marshalizer
+	payloadHandler websocket.PayloadHandler
+	server         *grpcLib.Server
+	closeOnce      sync.Once
+}
+
+// NewGRPCObserverConnector creates a gRPC server which receives the events stream of the
+// observer node on the provided url. The events are marshalled with the provided marshaller
+// and processed by the payload handler, as the events received on the websocket connector
+func NewGRPCObserverConnector(args ArgsGRPCObserverConnector) (*grpcObserverConnector, error) {
+	err := checkArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	listener, err := net.Listen("tcp", args.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	goc := &grpcObserverConnector{
+		marshaller:     args.Marshaller,
+		payloadHandler: args.PayloadHandler,
+		server:         grpcLib.NewServer(),
+	}
+	RegisterObserveEventsServer(goc.server, goc)
+
+	go goc.serve(listener)
+
+	log.Info("started gRPC observer connector", "url", listener.Addr().String())
+
+	return goc, nil
+}
+
+func checkArgs(args ArgsGRPCObserverConnector) error {
+	if args.URL == "" {
+		return ErrEmptyURL
+	}
+	if check.IfNil(args.Marshaller) {
+		return common.ErrNilMarshaller
+	}
+	if check.IfNil(args.PayloadHandler) {
+		return ErrNilPayloadHandler
+	}
+
+	return nil
+}
+
+func (goc *grpcObserverConnector) serve(listener net.Listener) {
+	err := goc.server.Serve(listener)
+	if err != nil {
+		log.Error("gRPC observer connector stopped", "err", err.Error())
+	}
+}
+
+// ObserveEvents handles the events stream of an observer node. Each event is processed
+// before the next one is received and it is acknowledged on the stream, with the
+// processing error, if any
+func (goc *grpcObserverConnector) ObserveEvents(stream ObserveEvents_ObserveEventsServer) error {
+	for {
+		event, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		ack := &EventAck{}
+		err = goc.processEvent(event)
+		if err != nil {
+			log.Error("failed to process gRPC observer event", "err", err.Error())
+			ack.Error = err.Error()
+		}
+
+		err = stream.Send(ack)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (goc *grpcObserverConnector) processEvent(event *ObserverEvent) error {
+	payload, topic, err := getEventPayload(event)
+	if err != nil {
+		return err
+	}
+
+	payloadBytes, err := goc.marshaller.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	return goc.payloadHandler.ProcessPayload(payloadBytes, topic, common.PayloadV1)
+}
+
+func getEventPayload(event *ObserverEvent) (interface{}, string, error) {
+	switch {
+	case event.GetOutportBlock() != nil:
+		return event.GetOutportBlock(), outport.TopicSaveBlock, nil
+	case event.GetRevertedBlock() != nil:
+		return event.GetRevertedBlock(), outport.TopicRevertIndexedBlock, nil
+	case event.GetFinalizedBlock() != nil:
+		return event.GetFinalizedBlock(), outport.TopicFinalizedBlock, nil
+	default:
+		return nil, "", ErrInvalidObserverEvent
+	}
+}
+
+// Close stops the gRPC server, closing the active streams, and the payload handler
+func (goc *grpcObserverConnector) Close() error {
+	var err error
+	goc.closeOnce.Do(func() {
+		goc.server.Stop()
+		err = goc.payloadHandler.Close()
+	})
+
+	return err
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (goc *grpcObserverConnector) IsInterfaceNil() bool {
+	return goc == nil
+}
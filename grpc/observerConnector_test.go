@@ -0,0 +1,264 @@
+package grpc_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data/outport"
+	"github.com/multiversx/mx-chain-core-go/marshal"
+	"github.com/multiversx/mx-chain-notifier-go/common"
+	"github.com/multiversx/mx-chain-notifier-go/grpc"
+	"github.com/multiversx/mx-chain-notifier-go/mocks"
+	"github.com/stretchr/testify/require"
+	grpcLib "google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func createMockArgsGRPCObserverConnector() grpc.ArgsGRPCObserverConnector {
+	return grpc.ArgsGRPCObserverConnector{
+		URL:            getFreeAddress(),
+		Marshaller:     &marshal.GogoProtoMarshalizer{},
+		PayloadHandler: &mocks.PayloadHandlerStub{},
+	}
+}
+
+func getFreeAddress() string {
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		panic(err)
+	}
+	defer func() {
+		_ = listener.Close()
+	}()
+
+	return listener.Addr().String()
+}
+
+func createStream(t *testing.T, url string) grpc.ObserveEvents_ObserveEventsClient {
+	conn, err := grpcLib.Dial(url, grpcLib.WithTransportCredentials(insecure.NewCredentials()))
+	require.Nil(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	stream, err := grpc.NewObserveEventsClient(conn).ObserveEvents(ctx)
+	require.Nil(t, err)
+
+	return stream
+}
+
+func TestNewGRPCObserverConnector(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty url", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsGRPCObserverConnector()
+		args.URL = ""
+
+		connector, err := grpc.NewGRPCObserverConnector(args)
+		require.True(t, check.IfNil(connector))
+		require.Equal(t, grpc.ErrEmptyURL, err)
+	})
+
+	t.Run("nil marshaller", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsGRPCObserverConnector()
+		args.Marshaller = nil
+
+		connector, err := grpc.NewGRPCObserverConnector(args)
+		require.True(t, check.IfNil(connector))
+		require.Equal(t, common.ErrNilMarshaller, err)
+	})
+
+	t.Run("nil payload handler", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsGRPCObserverConnector()
+		args.PayloadHandler = nil
+
+		connector, err := grpc.NewGRPCObserverConnector(args)
+		require.True(t, check.IfNil(connector))
+		require.Equal(t, grpc.ErrNilPayloadHandler, err)
+	})
+
+	t.Run("invalid url", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsGRPCObserverConnector()
+		args.URL = "invalid url"
+
+		connector, err := grpc.NewGRPCObserverConnector(args)
+		require.True(t, check.IfNil(connector))
+		require.NotNil(t, err)
+	})
+
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		connector, err := grpc.NewGRPCObserverConnector(createMockArgsGRPCObserverConnector())
+		require.Nil(t, err)
+		require.False(t, check.IfNil(connector))
+
+		require.Nil(t, connector.Close())
+	})
+}
+
+func TestGRPCObserverConnector_ObserveEvents(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should process the events in order", func(t *testing.T) {
+		t.Parallel()
+
+		marshaller := &marshal.GogoProtoMarshalizer{}
+		mutTopics := sync.Mutex{}
+		topics := make([]string, 0)
+		payloads := make([][]byte, 0)
+
+		args := createMockArgsGRPCObserverConnector()
+		args.Marshaller = marshaller
+		args.PayloadHandler = &mocks.PayloadHandlerStub{
+			ProcessPayloadCalled: func(payload []byte, topic string, version uint32) error {
+				require.Equal(t, common.PayloadV1, version)
+
+				mutTopics.Lock()
+				topics = append(topics, topic)
+				payloads = append(payloads, payload)
+				mutTopics.Unlock()
+
+				return nil
+			},
+		}
+
+		connector, err := grpc.NewGRPCObserverConnector(args)
+		require.Nil(t, err)
+		defer func() {
+			_ = connector.Close()
+		}()
+
+		outportBlock := &outport.OutportBlock{ShardID: 1, BlockData: &outport.BlockData{HeaderHash: []byte("hash1")}}
+		revertedBlock := &outport.BlockData{HeaderHash: []byte("hash2")}
+		finalizedBlock := &outport.FinalizedBlock{HeaderHash: []byte("hash3")}
+		events := []*grpc.ObserverEvent{
+			{Event: &grpc.ObserverEvent_OutportBlock{OutportBlock: outportBlock}},
+			{Event: &grpc.ObserverEvent_RevertedBlock{RevertedBlock: revertedBlock}},
+			{Event: &grpc.ObserverEvent_FinalizedBlock{FinalizedBlock: finalizedBlock}},
+		}
+
+		stream := createStream(t, args.URL)
+		for _, event := range events {
+			err = stream.Send(event)
+			require.Nil(t, err)
+
+			ack, errRecv := stream.Recv()
+			require.Nil(t, errRecv)
+			require.Equal(t, "", ack.Error)
+		}
+
+		mutTopics.Lock()
+		defer mutTopics.Unlock()
+
+		require.Equal(t, []string{outport.TopicSaveBlock, outport.TopicRevertIndexedBlock, outport.TopicFinalizedBlock}, topics)
+
+		receivedOutportBlock := &outport.OutportBlock{}
+		err = marshaller.Unmarshal(receivedOutportBlock, payloads[0])
+		require.Nil(t, err)
+		require.Equal(t, outportBlock, receivedOutportBlock)
+
+		receivedRevertedBlock := &outport.BlockData{}
+		err = marshaller.Unmarshal(receivedRevertedBlock, payloads[1])
+		require.Nil(t, err)
+		require.Equal(t, revertedBlock, receivedRevertedBlock)
+
+		receivedFinalizedBlock := &outport.FinalizedBlock{}
+		err = marshaller.Unmarshal(receivedFinalizedBlock, payloads[2])
+		require.Nil(t, err)
+		require.Equal(t, finalizedBlock, receivedFinalizedBlock)
+	})
+
+	t.Run("processing error should be acknowledged", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		args := createMockArgsGRPCObserverConnector()
+		args.PayloadHandler = &mocks.PayloadHandlerStub{
+			ProcessPayloadCalled: func(payload []byte, topic string, version uint32) error {
+				return expectedErr
+			},
+		}
+
+		connector, err := grpc.NewGRPCObserverConnector(args)
+		require.Nil(t, err)
+		defer func() {
+			_ = connector.Close()
+		}()
+
+		stream := createStream(t, args.URL)
+		err = stream.Send(&grpc.ObserverEvent{Event: &grpc.ObserverEvent_FinalizedBlock{FinalizedBlock: &outport.FinalizedBlock{}}})
+		require.Nil(t, err)
+
+		ack, err := stream.Recv()
+		require.Nil(t, err)
+		require.Equal(t, expectedErr.Error(), ack.Error)
+	})
+
+	t.Run("event without block should be acknowledged with error", func(t *testing.T) {
+		t.Parallel()
+
+		processCalled := false
+		args := createMockArgsGRPCObserverConnector()
+		args.PayloadHandler = &mocks.PayloadHandlerStub{
+			ProcessPayloadCalled: func(payload []byte, topic string, version uint32) error {
+				processCalled = true
+				return nil
+			},
+		}
+
+		connector, err := grpc.NewGRPCObserverConnector(args)
+		require.Nil(t, err)
+		defer func() {
+			_ = connector.Close()
+		}()
+
+		stream := createStream(t, args.URL)
+		err = stream.Send(&grpc.ObserverEvent{})
+		require.Nil(t, err)
+
+		ack, err := stream.Recv()
+		require.Nil(t, err)
+		require.Equal(t, grpc.ErrInvalidObserverEvent.Error(), ack.Error)
+		require.False(t, processCalled)
+	})
+}
+
+func TestGRPCObserverConnector_Close(t *testing.T) {
+	t.Parallel()
+
+	numCloseCalls := 0
+	args := createMockArgsGRPCObserverConnector()
+	args.PayloadHandler = &mocks.PayloadHandlerStub{
+		CloseCalled: func() error {
+			numCloseCalls++
+			return nil
+		},
+	}
+
+	connector, err := grpc.NewGRPCObserverConnector(args)
+	require.Nil(t, err)
+
+	require.Nil(t, connector.Close())
+	require.Nil(t, connector.Close())
+	require.Equal(t, 1, numCloseCalls)
+
+	_, err = net.Dial("tcp", args.URL)
+	require.NotNil(t, err)
+}
@@ -0,0 +1,182 @@
+package grpc
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const streamQueueSize = 256
+
+// streamDispatcher is the hub dispatcher of a subscription stream. The hub delivers the
+// events synchronously, so they are only queued here and sent from the stream handler.
+// If the queue is full, the stream is closed, so that a slow consumer does not block the
+// broadcasts to the other dispatchers
+type streamDispatcher struct {
+	id        uuid.UUID
+	resumable bool
+	send      chan *EventEnvelope
+	closeChan chan struct{}
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// newStreamDispatcher creates a stream dispatcher with the resume id supplied by the client as
+// its id, if set, or with a random one otherwise
+func newStreamDispatcher(resumeID uuid.UUID) *streamDispatcher {
+	id := resumeID
+	if id == uuid.Nil {
+		id = uuid.New()
+	}
+
+	return &streamDispatcher{
+		id:        id,
+		resumable: resumeID != uuid.Nil,
+		send:      make(chan *EventEnvelope, streamQueueSize),
+		closeChan: make(chan struct{}),
+	}
+}
+
+// GetID returns the id corresponding to this dispatcher instance
+func (sd *streamDispatcher) GetID() uuid.UUID {
+	return sd.id
+}
+
+// IsResumable returns true if the dispatcher id was supplied by the client as the resume id
+func (sd *streamDispatcher) IsResumable() bool {
+	return sd.resumable
+}
+
+// PushEvents will send the matched logs and events on the stream
+func (sd *streamDispatcher) PushEvents(events []data.Event) {
+	logEvents := make([]*LogEvent, 0, len(events))
+	for _, event := range events {
+		logEvents = append(logEvents, &LogEvent{
+			Address:    event.Address,
+			Identifier: event.Identifier,
+			Topics:     event.Topics,
+			Data:       event.Data,
+			TxHash:     event.TxHash,
+		})
+	}
+
+	sd.enqueue(&EventEnvelope{Event: &EventEnvelope_Events{Events: &LogEvents{Events: logEvents}}})
+}
+
+// RevertEvent will send the revert event on the stream
+func (sd *streamDispatcher) RevertEvent(event data.RevertBlock) {
+	sd.enqueue(&EventEnvelope{Event: &EventEnvelope_RevertedBlock{RevertedBlock: &RevertedBlockEvent{
+		Hash:  event.Hash,
+		Nonce: event.Nonce,
+		Round: event.Round,
+		Epoch: event.Epoch,
+	}}})
+}
+
+// FinalizedEvent will send the finalized event on the stream
+func (sd *streamDispatcher) FinalizedEvent(event data.FinalizedBlock) {
+	sd.enqueue(&EventEnvelope{Event: &EventEnvelope_FinalizedBlock{FinalizedBlock: &FinalizedBlockEvent{
+		Hash: event.Hash,
+	}}})
+}
+
+// TxsEvent does nothing, the txs events are not streamed
+func (sd *streamDispatcher) TxsEvent(_ data.BlockTxs) {
+}
+
+// BlockEvents does nothing, the full block events are not streamed
+func (sd *streamDispatcher) BlockEvents(_ data.BlockEventsWithOrder) {
+}
+
+// ScrsEvent does nothing, the scrs events are not streamed
+func (sd *streamDispatcher) ScrsEvent(_ data.BlockScrs) {
+}
+
+// BlockTxEvents does nothing, the transaction notifications are not streamed
+func (sd *streamDispatcher) BlockTxEvents(_ data.BlockTxEvents) {
+}
+
+// RoundEvents does nothing, the rounds info is not streamed
+func (sd *streamDispatcher) RoundEvents(_ data.RoundEvents) {
+}
+
+// ValidatorsRatingEvent does nothing, the validators rating is not streamed
+func (sd *streamDispatcher) ValidatorsRatingEvent(_ data.ValidatorsRatingEvent) {
+}
+
+// AccountsEvents does nothing, the altered accounts are not streamed
+func (sd *streamDispatcher) AccountsEvents(_ data.AccountsEvents) {
+}
+
+// ReplayUnavailable does nothing, the streams are subscribed without a replay hint
+func (sd *streamDispatcher) ReplayUnavailable(_ data.ReplayUnavailable) {
+}
+
+func (sd *streamDispatcher) enqueue(envelope *EventEnvelope) {
+	select {
+	case <-sd.closeChan:
+		return
+	default:
+	}
+
+	select {
+	case sd.send <- envelope:
+	default:
+		log.Warn("subscription stream queue is full, closing the stream", "dispatcherID", sd.id)
+		sd.closeWithError(status.Error(codes.ResourceExhausted, ErrStreamQueueFull.Error()))
+	}
+}
+
+// run sends the queued events on the stream until the stream is terminated or the
+// dispatcher is closed. An event which is not sent within the send timeout closes the stream
+func (sd *streamDispatcher) run(stream EventsSubscription_SubscribeServer, sendTimeout time.Duration) error {
+	for {
+		select {
+		case envelope := <-sd.send:
+			err := sendWithTimeout(stream, envelope, sendTimeout)
+			if err != nil {
+				return err
+			}
+		case <-sd.closeChan:
+			return sd.closeErr
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
+
+func sendWithTimeout(stream EventsSubscription_SubscribeServer, envelope *EventEnvelope, sendTimeout time.Duration) error {
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- stream.Send(envelope)
+	}()
+
+	timer := time.NewTimer(sendTimeout)
+	defer timer.Stop()
+
+	select {
+	case err := <-errChan:
+		return err
+	case <-timer.C:
+		// the pending send returns once the stream is terminated by the handler
+		return status.Error(codes.DeadlineExceeded, ErrSendTimeout.Error())
+	}
+}
+
+func (sd *streamDispatcher) closeWithError(err error) {
+	sd.closeOnce.Do(func() {
+		sd.closeErr = err
+		close(sd.closeChan)
+	})
+}
+
+// Close terminates the stream, the queued events are dropped
+func (sd *streamDispatcher) Close() error {
+	sd.closeWithError(nil)
+
+	return nil
+}
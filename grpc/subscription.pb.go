@@ -0,0 +1,2731 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: subscription.proto
+
+package grpc
+
+import (
+	bytes "bytes"
+	context "context"
+	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+	reflect "reflect"
+	strings "strings"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// SubscriptionEntry holds the filters of a subscription, as the websocket subscription
+// entries. An empty event type subscribes to the logs and events
+type SubscriptionEntry struct {
+	EventType   string   `protobuf:"bytes,1,opt,name=EventType,proto3" json:"eventType,omitempty"`
+	Address     string   `protobuf:"bytes,2,opt,name=Address,proto3" json:"address,omitempty"`
+	Identifiers []string `protobuf:"bytes,3,rep,name=Identifiers,proto3" json:"identifiers,omitempty"`
+	Topics      []string `protobuf:"bytes,4,rep,name=Topics,proto3" json:"topics,omitempty"`
+}
+
+func (m *SubscriptionEntry) Reset()      { *m = SubscriptionEntry{} }
+func (*SubscriptionEntry) ProtoMessage() {}
+func (*SubscriptionEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c4f8ad1a64b2bad6, []int{0}
+}
+func (m *SubscriptionEntry) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SubscriptionEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *SubscriptionEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscriptionEntry.Merge(m, src)
+}
+func (m *SubscriptionEntry) XXX_Size() int {
+	return m.Size()
+}
+func (m *SubscriptionEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscriptionEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscriptionEntry proto.InternalMessageInfo
+
+func (m *SubscriptionEntry) GetEventType() string {
+	if m != nil {
+		return m.EventType
+	}
+	return ""
+}
+
+func (m *SubscriptionEntry) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *SubscriptionEntry) GetIdentifiers() []string {
+	if m != nil {
+		return m.Identifiers
+	}
+	return nil
+}
+
+func (m *SubscriptionEntry) GetTopics() []string {
+	if m != nil {
+		return m.Topics
+	}
+	return nil
+}
+
+// SubscribeRequest holds the subscription entries of a stream
+type SubscribeRequest struct {
+	SubscriptionEntries []*SubscriptionEntry `protobuf:"bytes,1,rep,name=SubscriptionEntries,proto3" json:"subscriptionEntries,omitempty"`
+}
+
+func (m *SubscribeRequest) Reset()      { *m = SubscribeRequest{} }
+func (*SubscribeRequest) ProtoMessage() {}
+func (*SubscribeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c4f8ad1a64b2bad6, []int{1}
+}
+func (m *SubscribeRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SubscribeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *SubscribeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeRequest.Merge(m, src)
+}
+func (m *SubscribeRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SubscribeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeRequest proto.InternalMessageInfo
+
+func (m *SubscribeRequest) GetSubscriptionEntries() []*SubscriptionEntry {
+	if m != nil {
+		return m.SubscriptionEntries
+	}
+	return nil
+}
+
+// LogEvent holds a log event matched by the subscriptions of the stream
+type LogEvent struct {
+	Address    string   `protobuf:"bytes,1,opt,name=Address,proto3" json:"address,omitempty"`
+	Identifier string   `protobuf:"bytes,2,opt,name=Identifier,proto3" json:"identifier,omitempty"`
+	Topics     [][]byte `protobuf:"bytes,3,rep,name=Topics,proto3" json:"topics,omitempty"`
+	Data       []byte   `protobuf:"bytes,4,opt,name=Data,proto3" json:"data,omitempty"`
+	TxHash     string   `protobuf:"bytes,5,opt,name=TxHash,proto3" json:"txHash,omitempty"`
+}
+
+func (m *LogEvent) Reset()      { *m = LogEvent{} }
+func (*LogEvent) ProtoMessage() {}
+func (*LogEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c4f8ad1a64b2bad6, []int{2}
+}
+func (m *LogEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LogEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *LogEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogEvent.Merge(m, src)
+}
+func (m *LogEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *LogEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LogEvent proto.InternalMessageInfo
+
+func (m *LogEvent) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *LogEvent) GetIdentifier() string {
+	if m != nil {
+		return m.Identifier
+	}
+	return ""
+}
+
+func (m *LogEvent) GetTopics() [][]byte {
+	if m != nil {
+		return m.Topics
+	}
+	return nil
+}
+
+func (m *LogEvent) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *LogEvent) GetTxHash() string {
+	if m != nil {
+		return m.TxHash
+	}
+	return ""
+}
+
+// LogEvents holds the log events of a block matched by the subscriptions of the stream
+type LogEvents struct {
+	Events []*LogEvent `protobuf:"bytes,1,rep,name=Events,proto3" json:"events,omitempty"`
+}
+
+func (m *LogEvents) Reset()      { *m = LogEvents{} }
+func (*LogEvents) ProtoMessage() {}
+func (*LogEvents) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c4f8ad1a64b2bad6, []int{3}
+}
+func (m *LogEvents) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LogEvents) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *LogEvents) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogEvents.Merge(m, src)
+}
+func (m *LogEvents) XXX_Size() int {
+	return m.Size()
+}
+func (m *LogEvents) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogEvents.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LogEvents proto.InternalMessageInfo
+
+func (m *LogEvents) GetEvents() []*LogEvent {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+// RevertedBlockEvent holds the details of a reverted block
+type RevertedBlockEvent struct {
+	Hash  string `protobuf:"bytes,1,opt,name=Hash,proto3" json:"hash,omitempty"`
+	Nonce uint64 `protobuf:"varint,2,opt,name=Nonce,proto3" json:"nonce,omitempty"`
+	Round uint64 `protobuf:"varint,3,opt,name=Round,proto3" json:"round,omitempty"`
+	Epoch uint32 `protobuf:"varint,4,opt,name=Epoch,proto3" json:"epoch,omitempty"`
+}
+
+func (m *RevertedBlockEvent) Reset()      { *m = RevertedBlockEvent{} }
+func (*RevertedBlockEvent) ProtoMessage() {}
+func (*RevertedBlockEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c4f8ad1a64b2bad6, []int{4}
+}
+func (m *RevertedBlockEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RevertedBlockEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *RevertedBlockEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevertedBlockEvent.Merge(m, src)
+}
+func (m *RevertedBlockEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *RevertedBlockEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevertedBlockEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevertedBlockEvent proto.InternalMessageInfo
+
+func (m *RevertedBlockEvent) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *RevertedBlockEvent) GetNonce() uint64 {
+	if m != nil {
+		return m.Nonce
+	}
+	return 0
+}
+
+func (m *RevertedBlockEvent) GetRound() uint64 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *RevertedBlockEvent) GetEpoch() uint32 {
+	if m != nil {
+		return m.Epoch
+	}
+	return 0
+}
+
+// FinalizedBlockEvent holds the hash of a finalized block
+type FinalizedBlockEvent struct {
+	Hash string `protobuf:"bytes,1,opt,name=Hash,proto3" json:"hash,omitempty"`
+}
+
+func (m *FinalizedBlockEvent) Reset()      { *m = FinalizedBlockEvent{} }
+func (*FinalizedBlockEvent) ProtoMessage() {}
+func (*FinalizedBlockEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c4f8ad1a64b2bad6, []int{5}
+}
+func (m *FinalizedBlockEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FinalizedBlockEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *FinalizedBlockEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FinalizedBlockEvent.Merge(m, src)
+}
+func (m *FinalizedBlockEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *FinalizedBlockEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_FinalizedBlockEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FinalizedBlockEvent proto.InternalMessageInfo
+
+func (m *FinalizedBlockEvent) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+// EventEnvelope holds one of the events pushed on a subscription stream
+type EventEnvelope struct {
+	// Types that are valid to be assigned to Event:
+	//	*EventEnvelope_Events
+	//	*EventEnvelope_RevertedBlock
+	//	*EventEnvelope_FinalizedBlock
+	Event isEventEnvelope_Event `protobuf_oneof:"Event"`
+}
+
+func (m *EventEnvelope) Reset()      { *m = EventEnvelope{} }
+func (*EventEnvelope) ProtoMessage() {}
+func (*EventEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c4f8ad1a64b2bad6, []int{6}
+}
+func (m *EventEnvelope) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *EventEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *EventEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EventEnvelope.Merge(m, src)
+}
+func (m *EventEnvelope) XXX_Size() int {
+	return m.Size()
+}
+func (m *EventEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_EventEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EventEnvelope proto.InternalMessageInfo
+
+type isEventEnvelope_Event interface {
+	isEventEnvelope_Event()
+	Equal(interface{}) bool
+	MarshalTo([]byte) (int, error)
+	Size() int
+}
+
+type EventEnvelope_Events struct {
+	Events *LogEvents `protobuf:"bytes,1,opt,name=Events,proto3,oneof" json:"events,omitempty"`
+}
+type EventEnvelope_RevertedBlock struct {
+	RevertedBlock *RevertedBlockEvent `protobuf:"bytes,2,opt,name=RevertedBlock,proto3,oneof" json:"revertedBlock,omitempty"`
+}
+type EventEnvelope_FinalizedBlock struct {
+	FinalizedBlock *FinalizedBlockEvent `protobuf:"bytes,3,opt,name=FinalizedBlock,proto3,oneof" json:"finalizedBlock,omitempty"`
+}
+
+func (*EventEnvelope_Events) isEventEnvelope_Event()         {}
+func (*EventEnvelope_RevertedBlock) isEventEnvelope_Event()  {}
+func (*EventEnvelope_FinalizedBlock) isEventEnvelope_Event() {}
+
+func (m *EventEnvelope) GetEvent() isEventEnvelope_Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (m *EventEnvelope) GetEvents() *LogEvents {
+	if x, ok := m.GetEvent().(*EventEnvelope_Events); ok {
+		return x.Events
+	}
+	return nil
+}
+
+func (m *EventEnvelope) GetRevertedBlock() *RevertedBlockEvent {
+	if x, ok := m.GetEvent().(*EventEnvelope_RevertedBlock); ok {
+		return x.RevertedBlock
+	}
+	return nil
+}
+
+func (m *EventEnvelope) GetFinalizedBlock() *FinalizedBlockEvent {
+	if x, ok := m.GetEvent().(*EventEnvelope_FinalizedBlock); ok {
+		return x.FinalizedBlock
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*EventEnvelope) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*EventEnvelope_Events)(nil),
+		(*EventEnvelope_RevertedBlock)(nil),
+		(*EventEnvelope_FinalizedBlock)(nil),
+	}
+}
+
+func init() {
+	proto.RegisterType((*SubscriptionEntry)(nil), "proto.SubscriptionEntry")
+	proto.RegisterType((*SubscribeRequest)(nil), "proto.SubscribeRequest")
+	proto.RegisterType((*LogEvent)(nil), "proto.LogEvent")
+	proto.RegisterType((*LogEvents)(nil), "proto.LogEvents")
+	proto.RegisterType((*RevertedBlockEvent)(nil), "proto.RevertedBlockEvent")
+	proto.RegisterType((*FinalizedBlockEvent)(nil), "proto.FinalizedBlockEvent")
+	proto.RegisterType((*EventEnvelope)(nil), "proto.EventEnvelope")
+}
+
+func init() { proto.RegisterFile("subscription.proto", fileDescriptor_c4f8ad1a64b2bad6) }
+
+var fileDescriptor_c4f8ad1a64b2bad6 = []byte{
+	// 697 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x94, 0xcf, 0x6e, 0xd3, 0x4c,
+	0x14, 0xc5, 0x3d, 0x4d, 0xd2, 0x7e, 0x99, 0xf4, 0xef, 0xa4, 0x55, 0xdd, 0x7e, 0x30, 0x0e, 0x5e,
+	0x54, 0x41, 0x6a, 0x1a, 0x14, 0x40, 0x42, 0xaa, 0x8a, 0xc0, 0x22, 0xa8, 0x20, 0xc4, 0x22, 0x74,
+	0xc5, 0x02, 0xc9, 0x71, 0xa6, 0x89, 0x45, 0xe2, 0x31, 0xf6, 0x24, 0x6a, 0x59, 0xc1, 0x1b, 0xf0,
+	0x04, 0xac, 0x79, 0x09, 0xf6, 0x2c, 0xbb, 0xec, 0xca, 0xa2, 0xae, 0x84, 0x90, 0x57, 0xdd, 0xb2,
+	0x43, 0xbe, 0x76, 0x9a, 0x71, 0x1b, 0x21, 0xc4, 0x26, 0xb1, 0xcf, 0x9c, 0x73, 0xe7, 0xde, 0x9f,
+	0x3c, 0x83, 0x89, 0x3f, 0x6c, 0xfb, 0x96, 0x67, 0xbb, 0xc2, 0xe6, 0xce, 0x8e, 0xeb, 0x71, 0xc1,
+	0x49, 0x01, 0xfe, 0x36, 0x6b, 0x5d, 0x5b, 0xf4, 0x86, 0xed, 0x1d, 0x8b, 0x0f, 0xea, 0x5d, 0xde,
+	0xe5, 0x75, 0x90, 0xdb, 0xc3, 0x43, 0x78, 0x83, 0x17, 0x78, 0x4a, 0x52, 0xfa, 0x0f, 0x84, 0x57,
+	0x5e, 0x49, 0xc5, 0x9a, 0x8e, 0xf0, 0x8e, 0xc9, 0x7d, 0x5c, 0x6c, 0x8e, 0x98, 0x23, 0x0e, 0x8e,
+	0x5d, 0xa6, 0xa2, 0x0a, 0xaa, 0x16, 0x8d, 0xf5, 0x28, 0xd0, 0xca, 0x6c, 0x2c, 0x6e, 0xf3, 0x81,
+	0x2d, 0xd8, 0xc0, 0x15, 0xc7, 0xad, 0x89, 0x93, 0xd4, 0xf1, 0xdc, 0xe3, 0x4e, 0xc7, 0x63, 0xbe,
+	0xaf, 0xce, 0x40, 0x68, 0x2d, 0x0a, 0xb4, 0x15, 0x33, 0x91, 0xa4, 0xc8, 0xd8, 0x45, 0x76, 0x71,
+	0xe9, 0x59, 0x87, 0x39, 0xc2, 0x3e, 0xb4, 0x99, 0xe7, 0xab, 0xb9, 0x4a, 0xae, 0x5a, 0x34, 0x36,
+	0xa2, 0x40, 0x5b, 0xb3, 0x27, 0xb2, 0x14, 0x94, 0xdd, 0x64, 0x1b, 0xcf, 0x1e, 0x70, 0xd7, 0xb6,
+	0x7c, 0x35, 0x0f, 0xb9, 0xd5, 0x28, 0xd0, 0x96, 0x05, 0x28, 0x52, 0x24, 0xf5, 0xe8, 0x1f, 0x11,
+	0x5e, 0x4e, 0x07, 0x6d, 0xb3, 0x16, 0x7b, 0x37, 0x64, 0xbe, 0x20, 0x03, 0x5c, 0xbe, 0x3a, 0xbc,
+	0xcd, 0x7c, 0x15, 0x55, 0x72, 0xd5, 0x52, 0x43, 0x4d, 0x10, 0xed, 0x5c, 0xc3, 0x63, 0xdc, 0x8a,
+	0x02, 0xed, 0xa6, 0x7f, 0x3d, 0x28, 0x6d, 0x3b, 0xad, 0xae, 0xfe, 0x0b, 0xe1, 0xff, 0x5e, 0xf0,
+	0x2e, 0x00, 0x93, 0x61, 0xa1, 0xbf, 0x82, 0xf5, 0x00, 0xe3, 0xc9, 0xf8, 0x29, 0x60, 0x35, 0x0a,
+	0xb4, 0xd5, 0x09, 0x2b, 0x29, 0x26, 0x79, 0x25, 0x52, 0x31, 0xe1, 0xf9, 0x3f, 0x93, 0x22, 0x5b,
+	0x38, 0xff, 0xc4, 0x14, 0xa6, 0x9a, 0xaf, 0xa0, 0xea, 0xbc, 0x41, 0xa2, 0x40, 0x5b, 0xec, 0x98,
+	0xc2, 0x94, 0x9c, 0xb0, 0x0e, 0x55, 0x8f, 0xf6, 0x4d, 0xbf, 0xa7, 0x16, 0xa0, 0x97, 0xa4, 0x2a,
+	0x28, 0x99, 0xaa, 0xa0, 0xe8, 0xcf, 0x71, 0x71, 0x3c, 0xba, 0x4f, 0xf6, 0xf0, 0x6c, 0xf2, 0x94,
+	0xa2, 0x5e, 0x4a, 0x51, 0x8f, 0x1d, 0x49, 0x2d, 0xf8, 0xda, 0x32, 0x1d, 0x26, 0x21, 0xfd, 0x2b,
+	0xc2, 0xa4, 0xc5, 0x46, 0xcc, 0x13, 0xac, 0x63, 0xf4, 0xb9, 0xf5, 0x36, 0x21, 0xba, 0x85, 0xf3,
+	0xd0, 0x4e, 0x82, 0x13, 0x1a, 0xef, 0x65, 0x9b, 0x81, 0x75, 0x72, 0x1b, 0x17, 0x5e, 0x72, 0xc7,
+	0x62, 0xc0, 0x30, 0x6f, 0x94, 0xa3, 0x40, 0x5b, 0x72, 0x62, 0x41, 0x72, 0x26, 0x8e, 0xd8, 0xda,
+	0xe2, 0x43, 0xa7, 0xa3, 0xe6, 0x26, 0x56, 0x2f, 0x16, 0x64, 0x2b, 0x38, 0x62, 0x6b, 0xd3, 0xe5,
+	0x56, 0x0f, 0xb8, 0x2d, 0x24, 0x56, 0x16, 0x0b, 0xb2, 0x15, 0x1c, 0xfa, 0x1e, 0x2e, 0x3f, 0xb5,
+	0x1d, 0xb3, 0x6f, 0xbf, 0xff, 0x97, 0xfe, 0xf5, 0xcf, 0x33, 0x78, 0x01, 0x12, 0x4d, 0x67, 0xc4,
+	0xfa, 0xdc, 0x65, 0xe4, 0x91, 0xc4, 0x13, 0x55, 0x4b, 0x8d, 0xe5, 0x2b, 0x3c, 0xfd, 0xe9, 0x40,
+	0xf7, 0x95, 0x31, 0x52, 0xf2, 0x06, 0x2f, 0x64, 0x88, 0x02, 0x9b, 0x52, 0x63, 0x23, 0x2d, 0x74,
+	0x9d, 0xb6, 0xf1, 0x7f, 0x14, 0x68, 0xeb, 0x9e, 0xac, 0x67, 0x0a, 0x67, 0xcb, 0x91, 0x36, 0x5e,
+	0xcc, 0x8e, 0x0c, 0x44, 0x4b, 0x8d, 0xcd, 0x74, 0x83, 0x29, 0x3c, 0x8c, 0x1b, 0x51, 0xa0, 0xa9,
+	0x87, 0x99, 0x85, 0xcc, 0x16, 0x57, 0x2a, 0x1a, 0x73, 0xb8, 0x00, 0xc1, 0xc6, 0x01, 0x26, 0xc9,
+	0x58, 0xf2, 0x21, 0x24, 0x0f, 0x71, 0xf1, 0xf2, 0x02, 0x20, 0xeb, 0xd9, 0xc3, 0x7d, 0x79, 0x25,
+	0x6c, 0xae, 0xa6, 0x0b, 0x19, 0xc0, 0xba, 0x72, 0x07, 0x19, 0xce, 0xc9, 0x19, 0x55, 0x4e, 0xcf,
+	0xa8, 0x72, 0x71, 0x46, 0xd1, 0x87, 0x90, 0xa2, 0x2f, 0x21, 0x45, 0xdf, 0x42, 0x8a, 0x4e, 0x42,
+	0x8a, 0x4e, 0x43, 0x8a, 0xbe, 0x87, 0x14, 0xfd, 0x0c, 0xa9, 0x72, 0x11, 0x52, 0xf4, 0xe9, 0x9c,
+	0x2a, 0x27, 0xe7, 0x54, 0x39, 0x3d, 0xa7, 0xca, 0xeb, 0x7b, 0xd2, 0x9d, 0x3c, 0x18, 0xf6, 0x85,
+	0x3d, 0x62, 0x9e, 0x7f, 0x54, 0x1f, 0x1c, 0xd5, 0xac, 0x9e, 0x69, 0x3b, 0x35, 0x87, 0x27, 0x07,
+	0xb5, 0xd6, 0xe5, 0xf5, 0xae, 0xe7, 0x5a, 0xbb, 0xf1, 0x4f, 0x7b, 0x16, 0x1a, 0xb9, 0xfb, 0x7b,
+	0x00, 0x87, 0xdb, 0x50, 0xcb, 0xed, 0x05, 0x00, 0x00,
+}
+
+func (this *SubscriptionEntry) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*SubscriptionEntry)
+	if !ok {
+		that2, ok := that.(SubscriptionEntry)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.EventType != that1.EventType {
+		return false
+	}
+	if this.Address != that1.Address {
+		return false
+	}
+	if len(this.Identifiers) != len(that1.Identifiers) {
+		return false
+	}
+	for i := range this.Identifiers {
+		if this.Identifiers[i] != that1.Identifiers[i] {
+			return false
+		}
+	}
+	if len(this.Topics) != len(that1.Topics) {
+		return false
+	}
+	for i := range this.Topics {
+		if this.Topics[i] != that1.Topics[i] {
+			return false
+		}
+	}
+	return true
+}
+func (this *SubscribeRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*SubscribeRequest)
+	if !ok {
+		that2, ok := that.(SubscribeRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.SubscriptionEntries) != len(that1.SubscriptionEntries) {
+		return false
+	}
+	for i := range this.SubscriptionEntries {
+		if !this.SubscriptionEntries[i].Equal(that1.SubscriptionEntries[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (this *LogEvent) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*LogEvent)
+	if !ok {
+		that2, ok := that.(LogEvent)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Address != that1.Address {
+		return false
+	}
+	if this.Identifier != that1.Identifier {
+		return false
+	}
+	if len(this.Topics) != len(that1.Topics) {
+		return false
+	}
+	for i := range this.Topics {
+		if !bytes.Equal(this.Topics[i], that1.Topics[i]) {
+			return false
+		}
+	}
+	if !bytes.Equal(this.Data, that1.Data) {
+		return false
+	}
+	if this.TxHash != that1.TxHash {
+		return false
+	}
+	return true
+}
+func (this *LogEvents) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*LogEvents)
+	if !ok {
+		that2, ok := that.(LogEvents)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.Events) != len(that1.Events) {
+		return false
+	}
+	for i := range this.Events {
+		if !this.Events[i].Equal(that1.Events[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (this *RevertedBlockEvent) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*RevertedBlockEvent)
+	if !ok {
+		that2, ok := that.(RevertedBlockEvent)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Hash != that1.Hash {
+		return false
+	}
+	if this.Nonce != that1.Nonce {
+		return false
+	}
+	if this.Round != that1.Round {
+		return false
+	}
+	if this.Epoch != that1.Epoch {
+		return false
+	}
+	return true
+}
+func (this *FinalizedBlockEvent) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*FinalizedBlockEvent)
+	if !ok {
+		that2, ok := that.(FinalizedBlockEvent)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Hash != that1.Hash {
+		return false
+	}
+	return true
+}
+func (this *EventEnvelope) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*EventEnvelope)
+	if !ok {
+		that2, ok := that.(EventEnvelope)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if that1.Event == nil {
+		if this.Event != nil {
+			return false
+		}
+	} else if this.Event == nil {
+		return false
+	} else if !this.Event.Equal(that1.Event) {
+		return false
+	}
+	return true
+}
+func (this *EventEnvelope_Events) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*EventEnvelope_Events)
+	if !ok {
+		that2, ok := that.(EventEnvelope_Events)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.Events.Equal(that1.Events) {
+		return false
+	}
+	return true
+}
+func (this *EventEnvelope_RevertedBlock) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*EventEnvelope_RevertedBlock)
+	if !ok {
+		that2, ok := that.(EventEnvelope_RevertedBlock)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.RevertedBlock.Equal(that1.RevertedBlock) {
+		return false
+	}
+	return true
+}
+func (this *EventEnvelope_FinalizedBlock) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*EventEnvelope_FinalizedBlock)
+	if !ok {
+		that2, ok := that.(EventEnvelope_FinalizedBlock)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.FinalizedBlock.Equal(that1.FinalizedBlock) {
+		return false
+	}
+	return true
+}
+func (this *SubscriptionEntry) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 8)
+	s = append(s, "&grpc.SubscriptionEntry{")
+	s = append(s, "EventType: "+fmt.Sprintf("%#v", this.EventType)+",\n")
+	s = append(s, "Address: "+fmt.Sprintf("%#v", this.Address)+",\n")
+	s = append(s, "Identifiers: "+fmt.Sprintf("%#v", this.Identifiers)+",\n")
+	s = append(s, "Topics: "+fmt.Sprintf("%#v", this.Topics)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *SubscribeRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&grpc.SubscribeRequest{")
+	if this.SubscriptionEntries != nil {
+		s = append(s, "SubscriptionEntries: "+fmt.Sprintf("%#v", this.SubscriptionEntries)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *LogEvent) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 9)
+	s = append(s, "&grpc.LogEvent{")
+	s = append(s, "Address: "+fmt.Sprintf("%#v", this.Address)+",\n")
+	s = append(s, "Identifier: "+fmt.Sprintf("%#v", this.Identifier)+",\n")
+	s = append(s, "Topics: "+fmt.Sprintf("%#v", this.Topics)+",\n")
+	s = append(s, "Data: "+fmt.Sprintf("%#v", this.Data)+",\n")
+	s = append(s, "TxHash: "+fmt.Sprintf("%#v", this.TxHash)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *LogEvents) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&grpc.LogEvents{")
+	if this.Events != nil {
+		s = append(s, "Events: "+fmt.Sprintf("%#v", this.Events)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *RevertedBlockEvent) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 8)
+	s = append(s, "&grpc.RevertedBlockEvent{")
+	s = append(s, "Hash: "+fmt.Sprintf("%#v", this.Hash)+",\n")
+	s = append(s, "Nonce: "+fmt.Sprintf("%#v", this.Nonce)+",\n")
+	s = append(s, "Round: "+fmt.Sprintf("%#v", this.Round)+",\n")
+	s = append(s, "Epoch: "+fmt.Sprintf("%#v", this.Epoch)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *FinalizedBlockEvent) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&grpc.FinalizedBlockEvent{")
+	s = append(s, "Hash: "+fmt.Sprintf("%#v", this.Hash)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *EventEnvelope) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&grpc.EventEnvelope{")
+	if this.Event != nil {
+		s = append(s, "Event: "+fmt.Sprintf("%#v", this.Event)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *EventEnvelope_Events) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&grpc.EventEnvelope_Events{` +
+		`Events:` + fmt.Sprintf("%#v", this.Events) + `}`}, ", ")
+	return s
+}
+func (this *EventEnvelope_RevertedBlock) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&grpc.EventEnvelope_RevertedBlock{` +
+		`RevertedBlock:` + fmt.Sprintf("%#v", this.RevertedBlock) + `}`}, ", ")
+	return s
+}
+func (this *EventEnvelope_FinalizedBlock) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&grpc.EventEnvelope_FinalizedBlock{` +
+		`FinalizedBlock:` + fmt.Sprintf("%#v", this.FinalizedBlock) + `}`}, ", ")
+	return s
+}
+func valueToGoStringSubscription(v interface{}, typ string) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// EventsSubscriptionClient is the client API for EventsSubscription service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type EventsSubscriptionClient interface {
+	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (EventsSubscription_SubscribeClient, error)
+}
+
+type eventsSubscriptionClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewEventsSubscriptionClient(cc *grpc.ClientConn) EventsSubscriptionClient {
+	return &eventsSubscriptionClient{cc}
+}
+
+func (c *eventsSubscriptionClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (EventsSubscription_SubscribeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_EventsSubscription_serviceDesc.Streams[0], "/proto.EventsSubscription/Subscribe", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &eventsSubscriptionSubscribeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type EventsSubscription_SubscribeClient interface {
+	Recv() (*EventEnvelope, error)
+	grpc.ClientStream
+}
+
+type eventsSubscriptionSubscribeClient struct {
+	grpc.ClientStream
+}
+
+func (x *eventsSubscriptionSubscribeClient) Recv() (*EventEnvelope, error) {
+	m := new(EventEnvelope)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// EventsSubscriptionServer is the server API for EventsSubscription service.
+type EventsSubscriptionServer interface {
+	Subscribe(*SubscribeRequest, EventsSubscription_SubscribeServer) error
+}
+
+// UnimplementedEventsSubscriptionServer can be embedded to have forward compatible implementations.
+type UnimplementedEventsSubscriptionServer struct {
+}
+
+func (*UnimplementedEventsSubscriptionServer) Subscribe(req *SubscribeRequest, srv EventsSubscription_SubscribeServer) error {
+	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
+}
+
+func RegisterEventsSubscriptionServer(s *grpc.Server, srv EventsSubscriptionServer) {
+	s.RegisterService(&_EventsSubscription_serviceDesc, srv)
+}
+
+func _EventsSubscription_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EventsSubscriptionServer).Subscribe(m, &eventsSubscriptionSubscribeServer{stream})
+}
+
+type EventsSubscription_SubscribeServer interface {
+	Send(*EventEnvelope) error
+	grpc.ServerStream
+}
+
+type eventsSubscriptionSubscribeServer struct {
+	grpc.ServerStream
+}
+
+func (x *eventsSubscriptionSubscribeServer) Send(m *EventEnvelope) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _EventsSubscription_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.EventsSubscription",
+	HandlerType: (*EventsSubscriptionServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Subscribe",
+			Handler:       _EventsSubscription_Subscribe_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "subscription.proto",
+}
+
+func (m *SubscriptionEntry) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SubscriptionEntry) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SubscriptionEntry) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Topics) > 0 {
+		for iNdEx := len(m.Topics) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Topics[iNdEx])
+			copy(dAtA[i:], m.Topics[iNdEx])
+			i = encodeVarintSubscription(dAtA, i, uint64(len(m.Topics[iNdEx])))
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.Identifiers) > 0 {
+		for iNdEx := len(m.Identifiers) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Identifiers[iNdEx])
+			copy(dAtA[i:], m.Identifiers[iNdEx])
+			i = encodeVarintSubscription(dAtA, i, uint64(len(m.Identifiers[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Address) > 0 {
+		i -= len(m.Address)
+		copy(dAtA[i:], m.Address)
+		i = encodeVarintSubscription(dAtA, i, uint64(len(m.Address)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.EventType) > 0 {
+		i -= len(m.EventType)
+		copy(dAtA[i:], m.EventType)
+		i = encodeVarintSubscription(dAtA, i, uint64(len(m.EventType)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SubscribeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SubscribeRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SubscribeRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.SubscriptionEntries) > 0 {
+		for iNdEx := len(m.SubscriptionEntries) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.SubscriptionEntries[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintSubscription(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LogEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LogEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LogEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.TxHash) > 0 {
+		i -= len(m.TxHash)
+		copy(dAtA[i:], m.TxHash)
+		i = encodeVarintSubscription(dAtA, i, uint64(len(m.TxHash)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Data) > 0 {
+		i -= len(m.Data)
+		copy(dAtA[i:], m.Data)
+		i = encodeVarintSubscription(dAtA, i, uint64(len(m.Data)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Topics) > 0 {
+		for iNdEx := len(m.Topics) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Topics[iNdEx])
+			copy(dAtA[i:], m.Topics[iNdEx])
+			i = encodeVarintSubscription(dAtA, i, uint64(len(m.Topics[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Identifier) > 0 {
+		i -= len(m.Identifier)
+		copy(dAtA[i:], m.Identifier)
+		i = encodeVarintSubscription(dAtA, i, uint64(len(m.Identifier)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Address) > 0 {
+		i -= len(m.Address)
+		copy(dAtA[i:], m.Address)
+		i = encodeVarintSubscription(dAtA, i, uint64(len(m.Address)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LogEvents) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LogEvents) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LogEvents) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Events) > 0 {
+		for iNdEx := len(m.Events) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Events[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintSubscription(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RevertedBlockEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RevertedBlockEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RevertedBlockEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Epoch != 0 {
+		i = encodeVarintSubscription(dAtA, i, uint64(m.Epoch))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Round != 0 {
+		i = encodeVarintSubscription(dAtA, i, uint64(m.Round))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Nonce != 0 {
+		i = encodeVarintSubscription(dAtA, i, uint64(m.Nonce))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Hash) > 0 {
+		i -= len(m.Hash)
+		copy(dAtA[i:], m.Hash)
+		i = encodeVarintSubscription(dAtA, i, uint64(len(m.Hash)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *FinalizedBlockEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FinalizedBlockEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FinalizedBlockEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Hash) > 0 {
+		i -= len(m.Hash)
+		copy(dAtA[i:], m.Hash)
+		i = encodeVarintSubscription(dAtA, i, uint64(len(m.Hash)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *EventEnvelope) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *EventEnvelope) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *EventEnvelope) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Event != nil {
+		{
+			size := m.Event.Size()
+			i -= size
+			if _, err := m.Event.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *EventEnvelope_Events) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *EventEnvelope_Events) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.Events != nil {
+		{
+			size, err := m.Events.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintSubscription(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+func (m *EventEnvelope_RevertedBlock) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *EventEnvelope_RevertedBlock) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.RevertedBlock != nil {
+		{
+			size, err := m.RevertedBlock.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintSubscription(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	return len(dAtA) - i, nil
+}
+func (m *EventEnvelope_FinalizedBlock) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *EventEnvelope_FinalizedBlock) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.FinalizedBlock != nil {
+		{
+			size, err := m.FinalizedBlock.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintSubscription(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	return len(dAtA) - i, nil
+}
+func encodeVarintSubscription(dAtA []byte, offset int, v uint64) int {
+	offset -= sovSubscription(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *SubscriptionEntry) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.EventType)
+	if l > 0 {
+		n += 1 + l + sovSubscription(uint64(l))
+	}
+	l = len(m.Address)
+	if l > 0 {
+		n += 1 + l + sovSubscription(uint64(l))
+	}
+	if len(m.Identifiers) > 0 {
+		for _, s := range m.Identifiers {
+			l = len(s)
+			n += 1 + l + sovSubscription(uint64(l))
+		}
+	}
+	if len(m.Topics) > 0 {
+		for _, s := range m.Topics {
+			l = len(s)
+			n += 1 + l + sovSubscription(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *SubscribeRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.SubscriptionEntries) > 0 {
+		for _, e := range m.SubscriptionEntries {
+			l = e.Size()
+			n += 1 + l + sovSubscription(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *LogEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Address)
+	if l > 0 {
+		n += 1 + l + sovSubscription(uint64(l))
+	}
+	l = len(m.Identifier)
+	if l > 0 {
+		n += 1 + l + sovSubscription(uint64(l))
+	}
+	if len(m.Topics) > 0 {
+		for _, b := range m.Topics {
+			l = len(b)
+			n += 1 + l + sovSubscription(uint64(l))
+		}
+	}
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovSubscription(uint64(l))
+	}
+	l = len(m.TxHash)
+	if l > 0 {
+		n += 1 + l + sovSubscription(uint64(l))
+	}
+	return n
+}
+
+func (m *LogEvents) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Events) > 0 {
+		for _, e := range m.Events {
+			l = e.Size()
+			n += 1 + l + sovSubscription(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *RevertedBlockEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Hash)
+	if l > 0 {
+		n += 1 + l + sovSubscription(uint64(l))
+	}
+	if m.Nonce != 0 {
+		n += 1 + sovSubscription(uint64(m.Nonce))
+	}
+	if m.Round != 0 {
+		n += 1 + sovSubscription(uint64(m.Round))
+	}
+	if m.Epoch != 0 {
+		n += 1 + sovSubscription(uint64(m.Epoch))
+	}
+	return n
+}
+
+func (m *FinalizedBlockEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Hash)
+	if l > 0 {
+		n += 1 + l + sovSubscription(uint64(l))
+	}
+	return n
+}
+
+func (m *EventEnvelope) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Event != nil {
+		n += m.Event.Size()
+	}
+	return n
+}
+
+func (m *EventEnvelope_Events) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Events != nil {
+		l = m.Events.Size()
+		n += 1 + l + sovSubscription(uint64(l))
+	}
+	return n
+}
+func (m *EventEnvelope_RevertedBlock) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.RevertedBlock != nil {
+		l = m.RevertedBlock.Size()
+		n += 1 + l + sovSubscription(uint64(l))
+	}
+	return n
+}
+func (m *EventEnvelope_FinalizedBlock) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.FinalizedBlock != nil {
+		l = m.FinalizedBlock.Size()
+		n += 1 + l + sovSubscription(uint64(l))
+	}
+	return n
+}
+
+func sovSubscription(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozSubscription(x uint64) (n int) {
+	return sovSubscription(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (this *SubscriptionEntry) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&SubscriptionEntry{`,
+		`EventType:` + fmt.Sprintf("%v", this.EventType) + `,`,
+		`Address:` + fmt.Sprintf("%v", this.Address) + `,`,
+		`Identifiers:` + fmt.Sprintf("%v", this.Identifiers) + `,`,
+		`Topics:` + fmt.Sprintf("%v", this.Topics) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *SubscribeRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForSubscriptionEntries := "[]*SubscriptionEntry{"
+	for _, f := range this.SubscriptionEntries {
+		repeatedStringForSubscriptionEntries += strings.Replace(f.String(), "SubscriptionEntry", "SubscriptionEntry", 1) + ","
+	}
+	repeatedStringForSubscriptionEntries += "}"
+	s := strings.Join([]string{`&SubscribeRequest{`,
+		`SubscriptionEntries:` + repeatedStringForSubscriptionEntries + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LogEvent) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LogEvent{`,
+		`Address:` + fmt.Sprintf("%v", this.Address) + `,`,
+		`Identifier:` + fmt.Sprintf("%v", this.Identifier) + `,`,
+		`Topics:` + fmt.Sprintf("%v", this.Topics) + `,`,
+		`Data:` + fmt.Sprintf("%v", this.Data) + `,`,
+		`TxHash:` + fmt.Sprintf("%v", this.TxHash) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LogEvents) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForEvents := "[]*LogEvent{"
+	for _, f := range this.Events {
+		repeatedStringForEvents += strings.Replace(f.String(), "LogEvent", "LogEvent", 1) + ","
+	}
+	repeatedStringForEvents += "}"
+	s := strings.Join([]string{`&LogEvents{`,
+		`Events:` + repeatedStringForEvents + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *RevertedBlockEvent) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&RevertedBlockEvent{`,
+		`Hash:` + fmt.Sprintf("%v", this.Hash) + `,`,
+		`Nonce:` + fmt.Sprintf("%v", this.Nonce) + `,`,
+		`Round:` + fmt.Sprintf("%v", this.Round) + `,`,
+		`Epoch:` + fmt.Sprintf("%v", this.Epoch) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *FinalizedBlockEvent) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&FinalizedBlockEvent{`,
+		`Hash:` + fmt.Sprintf("%v", this.Hash) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *EventEnvelope) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&EventEnvelope{`,
+		`Event:` + fmt.Sprintf("%v", this.Event) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *EventEnvelope_Events) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&EventEnvelope_Events{`,
+		`Events:` + strings.Replace(fmt.Sprintf("%v", this.Events), "LogEvents", "LogEvents", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *EventEnvelope_RevertedBlock) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&EventEnvelope_RevertedBlock{`,
+		`RevertedBlock:` + strings.Replace(fmt.Sprintf("%v", this.RevertedBlock), "RevertedBlockEvent", "RevertedBlockEvent", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *EventEnvelope_FinalizedBlock) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&EventEnvelope_FinalizedBlock{`,
+		`FinalizedBlock:` + strings.Replace(fmt.Sprintf("%v", this.FinalizedBlock), "FinalizedBlockEvent", "FinalizedBlockEvent", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func valueToStringSubscription(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("*%v", pv)
+}
+func (m *SubscriptionEntry) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSubscription
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SubscriptionEntry: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SubscriptionEntry: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EventType", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSubscription
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSubscription
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSubscription
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EventType = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSubscription
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSubscription
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSubscription
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Address = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Identifiers", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSubscription
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSubscription
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSubscription
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Identifiers = append(m.Identifiers, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Topics", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSubscription
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSubscription
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSubscription
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Topics = append(m.Topics, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSubscription(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSubscription
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SubscribeRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSubscription
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SubscribeRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SubscribeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SubscriptionEntries", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSubscription
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSubscription
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSubscription
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SubscriptionEntries = append(m.SubscriptionEntries, &SubscriptionEntry{})
+			if err := m.SubscriptionEntries[len(m.SubscriptionEntries)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSubscription(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSubscription
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LogEvent) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSubscription
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LogEvent: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LogEvent: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSubscription
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSubscription
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSubscription
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Address = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Identifier", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSubscription
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSubscription
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSubscription
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Identifier = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Topics", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSubscription
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthSubscription
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSubscription
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Topics = append(m.Topics, make([]byte, postIndex-iNdEx))
+			copy(m.Topics[len(m.Topics)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSubscription
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthSubscription
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSubscription
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TxHash", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSubscription
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSubscription
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSubscription
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TxHash = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSubscription(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSubscription
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LogEvents) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSubscription
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LogEvents: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LogEvents: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Events", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSubscription
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSubscription
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSubscription
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Events = append(m.Events, &LogEvent{})
+			if err := m.Events[len(m.Events)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSubscription(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSubscription
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RevertedBlockEvent) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSubscription
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RevertedBlockEvent: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RevertedBlockEvent: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSubscription
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSubscription
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSubscription
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hash = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Nonce", wireType)
+			}
+			m.Nonce = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSubscription
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Nonce |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSubscription
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Round |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Epoch", wireType)
+			}
+			m.Epoch = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSubscription
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Epoch |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSubscription(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSubscription
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FinalizedBlockEvent) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSubscription
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FinalizedBlockEvent: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FinalizedBlockEvent: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSubscription
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSubscription
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSubscription
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hash = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSubscription(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSubscription
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *EventEnvelope) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSubscription
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: EventEnvelope: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: EventEnvelope: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Events", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSubscription
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSubscription
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSubscription
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &LogEvents{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Event = &EventEnvelope_Events{v}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RevertedBlock", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSubscription
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSubscription
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSubscription
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &RevertedBlockEvent{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Event = &EventEnvelope_RevertedBlock{v}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FinalizedBlock", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSubscription
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSubscription
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSubscription
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &FinalizedBlockEvent{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Event = &EventEnvelope_FinalizedBlock{v}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSubscription(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSubscription
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipSubscription(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowSubscription
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowSubscription
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowSubscription
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthSubscription
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupSubscription
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthSubscription
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthSubscription        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowSubscription          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupSubscription = fmt.Errorf("proto: unexpected end of group")
+)
@@ -0,0 +1,191 @@
+package grpc
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-notifier-go/common"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/dispatcher"
+	grpcLib "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// resumeIDMetadataKey is the metadata key of the resume id supplied by the client
+const resumeIDMetadataKey = "resume-id"
+
+//go:generate protoc -I=. -I=$GOPATH/src -I=$GOPATH/src/github.com/multiversx/protobuf/protobuf --gogoslick_out=plugins=grpc:$GOPATH/src subscription.proto
+
+// ArgsGRPCSubscriptionServer defines the arguments needed for gRPC subscription server creation
+type ArgsGRPCSubscriptionServer struct {
+	URL         string
+	Hub         dispatcher.Dispatcher
+	SendTimeout time.Duration
+}
+
+type grpcSubscriptionServer struct {
+	hub         dispatcher.Dispatcher
+	sendTimeout time.Duration
+	server      *grpcLib.Server
+	closeOnce   sync.Once
+}
+
+// NewGRPCSubscriptionServer creates a gRPC server which streams the events matched by the
+// subscriptions of each Subscribe call on the provided url. Each stream is registered in
+// the hub as a dispatcher, until the stream is terminated
+func NewGRPCSubscriptionServer(args ArgsGRPCSubscriptionServer) (*grpcSubscriptionServer, error) {
+	err := checkSubscriptionServerArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	listener, err := net.Listen("tcp", args.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	return newGRPCSubscriptionServer(args, listener), nil
+}
+
+func checkSubscriptionServerArgs(args ArgsGRPCSubscriptionServer) error {
+	if args.URL == "" {
+		return ErrEmptyURL
+	}
+	if check.IfNil(args.Hub) {
+		return ErrNilHub
+	}
+	if args.SendTimeout <= 0 {
+		return ErrInvalidSendTimeout
+	}
+
+	return nil
+}
+
+func newGRPCSubscriptionServer(args ArgsGRPCSubscriptionServer, listener net.Listener) *grpcSubscriptionServer {
+	gss := &grpcSubscriptionServer{
+		hub:         args.Hub,
+		sendTimeout: args.SendTimeout,
+		server:      grpcLib.NewServer(),
+	}
+	RegisterEventsSubscriptionServer(gss.server, gss)
+
+	go gss.serve(listener)
+
+	log.Info("started gRPC subscription server", "url", listener.Addr().String())
+
+	return gss
+}
+
+func (gss *grpcSubscriptionServer) serve(listener net.Listener) {
+	err := gss.server.Serve(listener)
+	if err != nil {
+		log.Error("gRPC subscription server stopped", "err", err.Error())
+	}
+}
+
+// Subscribe registers the stream in the hub with the subscription entries of the request
+// and sends the matched events on the stream. The stream is unregistered when it is
+// terminated by the client, or closed by the server if an event is not sent in time
+// If the client supplies a resume id in the request metadata, the subscriptions of its
+// previous stream are kept, and the entries of the request are only subscribed if it has none
+func (gss *grpcSubscriptionServer) Subscribe(request *SubscribeRequest, stream EventsSubscription_SubscribeServer) error {
+	subscriptionEntries, err := getSubscriptionEntries(request)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	resumeID, err := getResumeID(stream)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	sd := newStreamDispatcher(resumeID)
+	gss.hub.RegisterEvent(sd)
+	defer func() {
+		gss.hub.UnregisterEvent(sd)
+		_ = sd.Close()
+	}()
+
+	isResumed := sd.IsResumable() && len(gss.hub.GetSubscriptions(sd.GetID())) > 0
+	if !isResumed {
+		err = gss.hub.Subscribe(data.SubscribeEvent{
+			DispatcherID:        sd.GetID(),
+			SubscriptionEntries: subscriptionEntries,
+		})
+		if err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	log.Info("subscription stream started", "dispatcherID", sd.GetID(), "resumed", isResumed)
+
+	err = sd.run(stream, gss.sendTimeout)
+	if err != nil {
+		log.Warn("subscription stream stopped", "dispatcherID", sd.GetID(), "err", err.Error())
+	}
+
+	return err
+}
+
+func getResumeID(stream EventsSubscription_SubscribeServer) (uuid.UUID, error) {
+	md, ok := metadata.FromIncomingContext(stream.Context())
+	if !ok {
+		return uuid.Nil, nil
+	}
+	values := md.Get(resumeIDMetadataKey)
+	if len(values) == 0 {
+		return uuid.Nil, nil
+	}
+
+	id, err := uuid.Parse(values[0])
+	if err != nil || id == uuid.Nil {
+		return uuid.Nil, ErrInvalidResumeID
+	}
+
+	return id, nil
+}
+
+func getSubscriptionEntries(request *SubscribeRequest) ([]data.SubscriptionEntry, error) {
+	subscriptionEntries := make([]data.SubscriptionEntry, 0, len(request.GetSubscriptionEntries()))
+	for _, entry := range request.GetSubscriptionEntries() {
+		if !isStreamedEventType(entry.GetEventType()) {
+			return nil, ErrUnsupportedEventType
+		}
+
+		subscriptionEntries = append(subscriptionEntries, data.SubscriptionEntry{
+			EventType:   entry.GetEventType(),
+			Address:     entry.GetAddress(),
+			Identifiers: entry.GetIdentifiers(),
+			Topics:      entry.GetTopics(),
+		})
+	}
+
+	return subscriptionEntries, nil
+}
+
+func isStreamedEventType(eventType string) bool {
+	switch eventType {
+	case "", common.PushLogsAndEvents, common.RevertBlockEvents, common.FinalizedBlockEvents:
+		return true
+	default:
+		return false
+	}
+}
+
+// Close stops the gRPC server, closing the active streams
+func (gss *grpcSubscriptionServer) Close() error {
+	gss.closeOnce.Do(func() {
+		gss.server.Stop()
+	})
+
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (gss *grpcSubscriptionServer) IsInterfaceNil() bool {
+	return gss == nil
+}
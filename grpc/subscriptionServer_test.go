@@ -0,0 +1,407 @@
+package grpc_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-notifier-go/common"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/dispatcher"
+	"github.com/multiversx/mx-chain-notifier-go/grpc"
+	"github.com/multiversx/mx-chain-notifier-go/mocks"
+	"github.com/stretchr/testify/require"
+	grpcLib "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const bufconnSize = 1024 * 1024
+
+func createMockArgsGRPCSubscriptionServer() grpc.ArgsGRPCSubscriptionServer {
+	return grpc.ArgsGRPCSubscriptionServer{
+		URL:         getFreeAddress(),
+		Hub:         &mocks.HubStub{},
+		SendTimeout: time.Second,
+	}
+}
+
+// createHubStub returns a hub stub which sends the registered dispatchers on the returned
+// channel and the unregistered dispatchers on the second one
+func createHubStub() (*mocks.HubStub, chan dispatcher.EventDispatcher, chan dispatcher.EventDispatcher) {
+	registered := make(chan dispatcher.EventDispatcher, 1)
+	unregistered := make(chan dispatcher.EventDispatcher, 1)
+	hubStub := &mocks.HubStub{
+		RegisterEventCalled: func(event dispatcher.EventDispatcher) {
+			registered <- event
+		},
+		UnregisterEventCalled: func(event dispatcher.EventDispatcher) {
+			unregistered <- event
+		},
+	}
+
+	return hubStub, registered, unregistered
+}
+
+func startSubscriptionServer(t *testing.T, args grpc.ArgsGRPCSubscriptionServer) grpc.EventsSubscriptionClient {
+	listener := bufconn.Listen(bufconnSize)
+	server, err := grpc.NewGRPCSubscriptionServerWithListener(args, listener)
+	require.Nil(t, err)
+	t.Cleanup(func() {
+		_ = server.Close()
+	})
+
+	conn, err := grpcLib.Dial("bufconn",
+		grpcLib.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpcLib.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.Nil(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	return grpc.NewEventsSubscriptionClient(conn)
+}
+
+func subscribe(t *testing.T, client grpc.EventsSubscriptionClient, request *grpc.SubscribeRequest) (grpc.EventsSubscription_SubscribeClient, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	stream, err := client.Subscribe(ctx, request)
+	require.Nil(t, err)
+
+	return stream, cancel
+}
+
+func subscribeWithResumeID(t *testing.T, client grpc.EventsSubscriptionClient, request *grpc.SubscribeRequest, resumeID string) (grpc.EventsSubscription_SubscribeClient, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	ctx = metadata.AppendToOutgoingContext(ctx, "resume-id", resumeID)
+	stream, err := client.Subscribe(ctx, request)
+	require.Nil(t, err)
+
+	return stream, cancel
+}
+
+func waitForDispatcher(t *testing.T, dispatchers chan dispatcher.EventDispatcher) dispatcher.EventDispatcher {
+	select {
+	case d := <-dispatchers:
+		return d
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "timeout waiting for dispatcher")
+		return nil
+	}
+}
+
+func TestNewGRPCSubscriptionServer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty url", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsGRPCSubscriptionServer()
+		args.URL = ""
+
+		server, err := grpc.NewGRPCSubscriptionServer(args)
+		require.True(t, check.IfNil(server))
+		require.Equal(t, grpc.ErrEmptyURL, err)
+	})
+
+	t.Run("nil hub", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsGRPCSubscriptionServer()
+		args.Hub = nil
+
+		server, err := grpc.NewGRPCSubscriptionServer(args)
+		require.True(t, check.IfNil(server))
+		require.Equal(t, grpc.ErrNilHub, err)
+	})
+
+	t.Run("invalid send timeout", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsGRPCSubscriptionServer()
+		args.SendTimeout = 0
+
+		server, err := grpc.NewGRPCSubscriptionServer(args)
+		require.True(t, check.IfNil(server))
+		require.Equal(t, grpc.ErrInvalidSendTimeout, err)
+	})
+
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		server, err := grpc.NewGRPCSubscriptionServer(createMockArgsGRPCSubscriptionServer())
+		require.Nil(t, err)
+		require.False(t, check.IfNil(server))
+
+		require.Nil(t, server.Close())
+	})
+}
+
+func TestGRPCSubscriptionServer_Subscribe(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should stream the pushed events", func(t *testing.T) {
+		t.Parallel()
+
+		hubStub, registered, unregistered := createHubStub()
+		var subscribeEvent data.SubscribeEvent
+		hubStub.SubscribeCalled = func(event data.SubscribeEvent) error {
+			subscribeEvent = event
+			return nil
+		}
+
+		args := createMockArgsGRPCSubscriptionServer()
+		args.Hub = hubStub
+		client := startSubscriptionServer(t, args)
+
+		stream, cancel := subscribe(t, client, &grpc.SubscribeRequest{
+			SubscriptionEntries: []*grpc.SubscriptionEntry{
+				{Address: "erd1", Identifiers: []string{"transfer", "swap"}},
+				{EventType: common.RevertBlockEvents},
+				{EventType: common.FinalizedBlockEvents},
+			},
+		})
+
+		d := waitForDispatcher(t, registered)
+		d.PushEvents([]data.Event{
+			{Address: "erd1", Identifier: "transfer", Topics: [][]byte{[]byte("topic1")}, Data: []byte("data1"), TxHash: "txHash1"},
+		})
+		d.RevertEvent(data.RevertBlock{Hash: "hash1", Nonce: 1, Round: 2, Epoch: 3})
+		d.FinalizedEvent(data.FinalizedBlock{Hash: "hash2"})
+
+		envelope, err := stream.Recv()
+		require.Nil(t, err)
+		require.Equal(t, []*grpc.LogEvent{
+			{Address: "erd1", Identifier: "transfer", Topics: [][]byte{[]byte("topic1")}, Data: []byte("data1"), TxHash: "txHash1"},
+		}, envelope.GetEvents().GetEvents())
+
+		envelope, err = stream.Recv()
+		require.Nil(t, err)
+		require.Equal(t, &grpc.RevertedBlockEvent{Hash: "hash1", Nonce: 1, Round: 2, Epoch: 3}, envelope.GetRevertedBlock())
+
+		envelope, err = stream.Recv()
+		require.Nil(t, err)
+		require.Equal(t, &grpc.FinalizedBlockEvent{Hash: "hash2"}, envelope.GetFinalizedBlock())
+
+		require.Equal(t, d.GetID(), subscribeEvent.DispatcherID)
+		require.Equal(t, []data.SubscriptionEntry{
+			{Address: "erd1", Identifiers: []string{"transfer", "swap"}},
+			{EventType: common.RevertBlockEvents},
+			{EventType: common.FinalizedBlockEvents},
+		}, subscribeEvent.SubscriptionEntries)
+
+		cancel()
+		require.Equal(t, d, waitForDispatcher(t, unregistered))
+	})
+
+	t.Run("unsupported event type should error", func(t *testing.T) {
+		t.Parallel()
+
+		hubStub, registered, _ := createHubStub()
+		args := createMockArgsGRPCSubscriptionServer()
+		args.Hub = hubStub
+		client := startSubscriptionServer(t, args)
+
+		stream, _ := subscribe(t, client, &grpc.SubscribeRequest{
+			SubscriptionEntries: []*grpc.SubscriptionEntry{{EventType: common.BlockTxs}},
+		})
+
+		_, err := stream.Recv()
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+		require.Equal(t, grpc.ErrUnsupportedEventType.Error(), status.Convert(err).Message())
+		require.Equal(t, 0, len(registered))
+	})
+
+	t.Run("invalid resume id should error", func(t *testing.T) {
+		t.Parallel()
+
+		hubStub, registered, _ := createHubStub()
+		args := createMockArgsGRPCSubscriptionServer()
+		args.Hub = hubStub
+		client := startSubscriptionServer(t, args)
+
+		stream, _ := subscribeWithResumeID(t, client, &grpc.SubscribeRequest{}, "invalid")
+
+		_, err := stream.Recv()
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+		require.Equal(t, grpc.ErrInvalidResumeID.Error(), status.Convert(err).Message())
+		require.Equal(t, 0, len(registered))
+	})
+
+	t.Run("resume id should keep the subscriptions of the previous stream", func(t *testing.T) {
+		t.Parallel()
+
+		resumeID := uuid.New()
+		hubStub, registered, _ := createHubStub()
+		hubStub.GetSubscriptionsCalled = func(dispatcherID uuid.UUID) []data.Subscription {
+			if dispatcherID == resumeID {
+				return []data.Subscription{{DispatcherID: resumeID}}
+			}
+			return nil
+		}
+		hubStub.SubscribeCalled = func(event data.SubscribeEvent) error {
+			require.Fail(t, "should have not been called")
+			return nil
+		}
+
+		args := createMockArgsGRPCSubscriptionServer()
+		args.Hub = hubStub
+		client := startSubscriptionServer(t, args)
+
+		stream, _ := subscribeWithResumeID(t, client, &grpc.SubscribeRequest{}, resumeID.String())
+
+		d := waitForDispatcher(t, registered)
+		require.Equal(t, resumeID, d.GetID())
+		require.True(t, d.IsResumable())
+
+		d.FinalizedEvent(data.FinalizedBlock{Hash: "hash1"})
+		envelope, err := stream.Recv()
+		require.Nil(t, err)
+		require.Equal(t, "hash1", envelope.GetFinalizedBlock().GetHash())
+	})
+
+	t.Run("resume id without subscriptions should subscribe the request entries", func(t *testing.T) {
+		t.Parallel()
+
+		resumeID := uuid.New()
+		subscribed := make(chan data.SubscribeEvent, 1)
+		hubStub, registered, _ := createHubStub()
+		hubStub.SubscribeCalled = func(event data.SubscribeEvent) error {
+			subscribed <- event
+			return nil
+		}
+
+		args := createMockArgsGRPCSubscriptionServer()
+		args.Hub = hubStub
+		client := startSubscriptionServer(t, args)
+
+		_, _ = subscribeWithResumeID(t, client, &grpc.SubscribeRequest{
+			SubscriptionEntries: []*grpc.SubscriptionEntry{{Address: "erd1"}},
+		}, resumeID.String())
+
+		require.Equal(t, resumeID, waitForDispatcher(t, registered).GetID())
+		select {
+		case event := <-subscribed:
+			require.Equal(t, resumeID, event.DispatcherID)
+			require.Equal(t, "erd1", event.SubscriptionEntries[0].Address)
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "request entries were not subscribed")
+		}
+	})
+
+	t.Run("subscribe error should close the stream", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		hubStub, registered, unregistered := createHubStub()
+		hubStub.SubscribeCalled = func(event data.SubscribeEvent) error {
+			return expectedErr
+		}
+
+		args := createMockArgsGRPCSubscriptionServer()
+		args.Hub = hubStub
+		client := startSubscriptionServer(t, args)
+
+		stream, _ := subscribe(t, client, &grpc.SubscribeRequest{})
+
+		_, err := stream.Recv()
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+		require.Equal(t, expectedErr.Error(), status.Convert(err).Message())
+		require.Equal(t, waitForDispatcher(t, registered), waitForDispatcher(t, unregistered))
+	})
+
+	t.Run("closed dispatcher should end the stream", func(t *testing.T) {
+		t.Parallel()
+
+		hubStub, registered, unregistered := createHubStub()
+		args := createMockArgsGRPCSubscriptionServer()
+		args.Hub = hubStub
+		client := startSubscriptionServer(t, args)
+
+		stream, _ := subscribe(t, client, &grpc.SubscribeRequest{})
+
+		d := waitForDispatcher(t, registered)
+		require.Nil(t, d.Close())
+
+		_, err := stream.Recv()
+		require.Equal(t, io.EOF, err)
+		require.Equal(t, d, waitForDispatcher(t, unregistered))
+
+		// events pushed after close are dropped
+		d.PushEvents([]data.Event{{Address: "erd1"}})
+	})
+
+	t.Run("slow consumer should have the stream closed", func(t *testing.T) {
+		t.Parallel()
+
+		hubStub, registered, unregistered := createHubStub()
+		args := createMockArgsGRPCSubscriptionServer()
+		args.Hub = hubStub
+		args.SendTimeout = 100 * time.Millisecond
+		client := startSubscriptionServer(t, args)
+
+		stream, _ := subscribe(t, client, &grpc.SubscribeRequest{})
+
+		// the stream is not read, so the sends block once the flow control window is full
+		d := waitForDispatcher(t, registered)
+		largeData := make([]byte, 1024*1024)
+		for i := 0; i < 20; i++ {
+			d.PushEvents([]data.Event{{Address: "erd1", Data: largeData}})
+		}
+		require.Equal(t, d, waitForDispatcher(t, unregistered))
+
+		var err error
+		for err == nil {
+			_, err = stream.Recv()
+		}
+		require.Equal(t, codes.DeadlineExceeded, status.Code(err))
+		require.Equal(t, grpc.ErrSendTimeout.Error(), status.Convert(err).Message())
+	})
+}
+
+func TestGRPCSubscriptionServer_Close(t *testing.T) {
+	t.Parallel()
+
+	hubStub, registered, unregistered := createHubStub()
+	args := createMockArgsGRPCSubscriptionServer()
+	args.Hub = hubStub
+
+	listener := bufconn.Listen(bufconnSize)
+	server, err := grpc.NewGRPCSubscriptionServerWithListener(args, listener)
+	require.Nil(t, err)
+
+	conn, err := grpcLib.Dial("bufconn",
+		grpcLib.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpcLib.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.Nil(t, err)
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	stream, _ := subscribe(t, grpc.NewEventsSubscriptionClient(conn), &grpc.SubscribeRequest{})
+	d := waitForDispatcher(t, registered)
+
+	require.Nil(t, server.Close())
+	require.Nil(t, server.Close())
+
+	_, err = stream.Recv()
+	require.NotNil(t, err)
+	require.Equal(t, d, waitForDispatcher(t, unregistered))
+}
@@ -0,0 +1,83 @@
+package rabbitmq
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/data/block"
+	"github.com/multiversx/mx-chain-core-go/data/outport"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-core-go/marshal"
+	"github.com/multiversx/mx-chain-notifier-go/common"
+	"github.com/multiversx/mx-chain-notifier-go/config"
+	"github.com/multiversx/mx-chain-notifier-go/factory"
+	"github.com/multiversx/mx-chain-notifier-go/integrationTests"
+	"github.com/multiversx/mx-chain-notifier-go/metrics"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotifierWithRabbitMQ_ProcessingMetrics(t *testing.T) {
+	cfg := integrationTests.GetDefaultConfigs()
+	notifier, err := integrationTests.NewTestNotifierWithRabbitMq(cfg.MainConfig)
+	require.Nil(t, err)
+
+	metricsCollector := metrics.NewMetricsCollector()
+	payloadHandler, err := factory.CreatePayloadHandler(
+		&marshal.JsonMarshalizer{},
+		notifier.Facade,
+		metrics.NewStatusMetrics(),
+		metrics.NewProcessedBlocksTracker(),
+		metricsCollector,
+		config.GeneralConfig{},
+	)
+	require.Nil(t, err)
+	webServer := integrationTests.NewTestWebServer(notifier.Facade, common.MessageQueuePublisherType, payloadHandler, common.PayloadV1)
+
+	_ = notifier.Publisher.Run()
+	defer notifier.Publisher.Close()
+
+	require.NotContains(t, metricsCollector.GetMetricsForPrometheus(), "notifier_processed_blocks_total")
+
+	header := &block.HeaderV2{
+		Header: &block.Header{
+			ShardID:   1,
+			Nonce:     1,
+			TimeStamp: uint64(time.Now().Add(-time.Minute).Unix()),
+		},
+	}
+	headerBytes, _ := json.Marshal(header)
+	saveBlockData := &outport.OutportBlock{
+		BlockData: &outport.BlockData{
+			HeaderBytes: headerBytes,
+			HeaderType:  string(core.ShardHeaderV2),
+			HeaderHash:  []byte("headerHash1"),
+			Body:        &block.Body{},
+		},
+		TransactionPool: &outport.TransactionPool{
+			Logs: []*outport.LogData{
+				{
+					Log: &transaction.Log{
+						Address: []byte("logaddr1"),
+						Events: []*transaction.Event{
+							{Address: []byte("addr1"), Identifier: []byte("transfer")},
+							{Address: []byte("addr2"), Identifier: []byte("swap")},
+						},
+					},
+					TxHash: "logHash1",
+				},
+			},
+		},
+		HeaderGasConsumption: &outport.HeaderGasConsumption{},
+	}
+
+	err = webServer.PushEventsRequest(saveBlockData)
+	require.Nil(t, err)
+
+	res := metricsCollector.GetMetricsForPrometheus()
+	require.Contains(t, res, "notifier_processed_blocks_total{shard=\"1\"} 1\n")
+	require.Contains(t, res, "notifier_processed_events_total{shard=\"1\"} 2\n")
+	require.Contains(t, res, "notifier_block_processing_lag_seconds{shard=\"1\"} ")
+	require.Contains(t, res, "notifier_payload_handler_duration_seconds_count{topic=\"SaveBlock\"} 1\n")
+}
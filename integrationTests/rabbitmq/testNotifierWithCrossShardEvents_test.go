@@ -0,0 +1,113 @@
+package rabbitmq
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/data/block"
+	"github.com/multiversx/mx-chain-core-go/data/outport"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-notifier-go/common"
+	"github.com/multiversx/mx-chain-notifier-go/config"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/integrationTests"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotifierWithRabbitMQ_CrossShardEvents(t *testing.T) {
+	cfg := integrationTests.GetDefaultConfigs()
+	cfg.MainConfig.RabbitMQ.CrossShardEventsExchange = config.RabbitMQExchangeConfig{
+		Name: "crossshardevents",
+		Type: "fanout",
+	}
+	notifier, err := integrationTests.NewTestNotifierWithRabbitMq(cfg.MainConfig)
+	require.Nil(t, err)
+
+	client, err := integrationTests.CreateObserverConnector(notifier.Facade, common.HTTPConnectorType, common.MessageQueuePublisherType, common.PayloadV1)
+	require.Nil(t, err)
+
+	_ = notifier.Publisher.Run()
+	defer notifier.Publisher.Close()
+
+	// with 3 shards, the last byte of the address selects the shard
+	shard0Addr := []byte("shard0Addr")
+	shard0Addr[len(shard0Addr)-1] = 0
+	shard1Addr := []byte("shard1Addr")
+	shard1Addr[len(shard1Addr)-1] = 1
+
+	header := &block.HeaderV2{
+		Header: &block.Header{
+			Nonce:   1,
+			ShardID: 1,
+		},
+	}
+	headerBytes, _ := json.Marshal(header)
+
+	txPool := &outport.TransactionPool{
+		Transactions: map[string]*outport.TxInfo{
+			"intraShardTx": {
+				Transaction: &transaction.Transaction{SndAddr: shard1Addr, RcvAddr: shard1Addr},
+			},
+			"crossShardTx": {
+				Transaction: &transaction.Transaction{SndAddr: shard0Addr, RcvAddr: shard1Addr},
+			},
+		},
+		Logs: []*outport.LogData{
+			{
+				TxHash: "intraShardTx",
+				Log: &transaction.Log{
+					Events: []*transaction.Event{{Address: shard1Addr, Identifier: []byte("intraShard")}},
+				},
+			},
+			{
+				TxHash: "crossShardTx",
+				Log: &transaction.Log{
+					Events: []*transaction.Event{{Address: shard1Addr, Identifier: []byte("crossShard")}},
+				},
+			},
+		},
+	}
+
+	err = client.PushEventsRequest(&outport.OutportBlock{
+		BlockData: &outport.BlockData{
+			HeaderBytes: headerBytes,
+			HeaderType:  string(core.ShardHeaderV2),
+			HeaderHash:  []byte("headerHash1"),
+			Body:        &block.Body{},
+		},
+		TransactionPool:      txPool,
+		HeaderGasConsumption: &outport.HeaderGasConsumption{},
+		NumberOfShards:       3,
+	})
+	require.Nil(t, err)
+
+	require.Eventually(t, func() bool {
+		entries := notifier.RabbitMQClient.GetEntries()
+		_, hasEvents := entries["allevents"]
+		_, hasCrossShardEvents := entries["crossshardevents"]
+
+		return hasEvents && hasCrossShardEvents
+	}, 2*time.Second, 10*time.Millisecond)
+
+	entries := notifier.RabbitMQClient.GetEntries()
+
+	allEvents := data.BlockEvents{}
+	err = json.Unmarshal(entries["allevents"].Body, &allEvents)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(allEvents.Events))
+
+	crossShardEvents := data.BlockEvents{}
+	err = json.Unmarshal(entries["crossshardevents"].Body, &crossShardEvents)
+	require.Nil(t, err)
+	require.Equal(t, hex.EncodeToString([]byte("headerHash1")), crossShardEvents.Hash)
+	require.Equal(t, []data.Event{
+		{
+			Address:    hex.EncodeToString(shard1Addr),
+			Identifier: "crossShard",
+			TxHash:     "crossShardTx",
+		},
+	}, crossShardEvents.Events)
+}
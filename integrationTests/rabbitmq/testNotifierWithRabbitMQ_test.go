@@ -1,7 +1,9 @@
 package rabbitmq
 
 import (
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"sync"
 	"testing"
 	"time"
@@ -13,6 +15,7 @@ import (
 	"github.com/multiversx/mx-chain-core-go/data/transaction"
 	logger "github.com/multiversx/mx-chain-logger-go"
 	"github.com/multiversx/mx-chain-notifier-go/common"
+	"github.com/multiversx/mx-chain-notifier-go/data"
 	"github.com/multiversx/mx-chain-notifier-go/integrationTests"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -28,6 +31,10 @@ func TestNotifierWithRabbitMQ(t *testing.T) {
 	t.Run("with ws observer connnector", func(t *testing.T) {
 		testNotifierWithRabbitMQ(t, common.WSObsConnectorType, common.PayloadV1)
 	})
+
+	t.Run("with grpc observer connnector", func(t *testing.T) {
+		testNotifierWithRabbitMQ(t, common.GRPCConnectorType, common.PayloadV1)
+	})
 }
 
 func testNotifierWithRabbitMQ(t *testing.T, observerType string, payloadVersion uint32) {
@@ -59,6 +66,110 @@ func testNotifierWithRabbitMQ(t *testing.T, observerType string, payloadVersion
 	assert.Equal(t, 6, len(notifier.RabbitMQClient.GetEntries()))
 }
 
+func TestNotifierWithRabbitMQ_AckAfterPublish(t *testing.T) {
+	t.Run("with http observer connnector", func(t *testing.T) {
+		testNotifierWithRabbitMQAckAfterPublish(t, common.HTTPConnectorType)
+	})
+
+	t.Run("with grpc observer connnector", func(t *testing.T) {
+		testNotifierWithRabbitMQAckAfterPublish(t, common.GRPCConnectorType)
+	})
+}
+
+func testNotifierWithRabbitMQAckAfterPublish(t *testing.T, observerType string) {
+	cfg := integrationTests.GetDefaultConfigs()
+	cfg.MainConfig.General.AckAfterPublish = true
+	notifier, err := integrationTests.NewTestNotifierWithRabbitMq(cfg.MainConfig)
+	require.Nil(t, err)
+
+	client, err := integrationTests.CreateObserverConnectorWithConfig(notifier.Facade, observerType, common.MessageQueuePublisherType, common.PayloadV1, cfg.MainConfig.General)
+	require.Nil(t, err)
+
+	_ = notifier.Publisher.Run()
+	defer notifier.Publisher.Close()
+
+	outportBlock := &outport.OutportBlock{
+		BlockData: &outport.BlockData{
+			HeaderBytes: []byte(`{"Header": {"Nonce": 1}}`),
+			HeaderType:  string(core.ShardHeaderV2),
+			HeaderHash:  []byte("headerHash1"),
+			Body:        &block.Body{},
+		},
+		TransactionPool:      &outport.TransactionPool{},
+		HeaderGasConsumption: &outport.HeaderGasConsumption{},
+	}
+
+	notifier.RabbitMQClient.SetPublishError(errors.New("publish failure"))
+	err = client.PushEventsRequest(outportBlock)
+	require.NotNil(t, err)
+	require.Equal(t, 0, len(notifier.RabbitMQClient.GetEntries()))
+
+	// the observer sends the payload again, since it was not acknowledged
+	notifier.RabbitMQClient.SetPublishError(nil)
+	err = client.PushEventsRequest(outportBlock)
+	require.Nil(t, err)
+	require.NotEmpty(t, notifier.RabbitMQClient.GetEntries()["allevents"].Body)
+}
+
+func TestNotifierWithRabbitMQ_EnrichedFinalizedEvents(t *testing.T) {
+	t.Run("with http observer connnector", func(t *testing.T) {
+		testNotifierWithRabbitMQEnrichedFinalizedEvents(t, common.HTTPConnectorType)
+	})
+
+	t.Run("with grpc observer connnector", func(t *testing.T) {
+		testNotifierWithRabbitMQEnrichedFinalizedEvents(t, common.GRPCConnectorType)
+	})
+}
+
+func testNotifierWithRabbitMQEnrichedFinalizedEvents(t *testing.T, observerType string) {
+	cfg := integrationTests.GetDefaultConfigs()
+	cfg.MainConfig.General.FinalizedDetailsCacheDepth = 10
+	notifier, err := integrationTests.NewTestNotifierWithRabbitMq(cfg.MainConfig)
+	require.Nil(t, err)
+
+	client, err := integrationTests.CreateObserverConnectorWithConfig(notifier.Facade, observerType, common.MessageQueuePublisherType, common.PayloadV1, cfg.MainConfig.General)
+	require.Nil(t, err)
+
+	_ = notifier.Publisher.Run()
+	defer notifier.Publisher.Close()
+
+	getFinalizedBlock := func() data.FinalizedBlock {
+		var finalizedBlock data.FinalizedBlock
+		require.Eventually(t, func() bool {
+			return len(notifier.RabbitMQClient.GetEntries()["finalized"].Body) > 0
+		}, time.Second*2, time.Millisecond*10)
+
+		err := json.Unmarshal(notifier.RabbitMQClient.GetEntries()["finalized"].Body, &finalizedBlock)
+		require.Nil(t, err)
+		notifier.RabbitMQClient.Reset()
+
+		return finalizedBlock
+	}
+
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+	pushEventsRequest(wg, client)
+	integrationTests.WaitTimeout(t, wg, time.Second*2)
+
+	err = client.FinalizedEventsRequest(&outport.FinalizedBlock{HeaderHash: []byte("headerHash1")})
+	require.Nil(t, err)
+
+	finalizedBlock := getFinalizedBlock()
+	require.Equal(t, hex.EncodeToString([]byte("headerHash1")), finalizedBlock.Hash)
+	require.True(t, *finalizedBlock.Enriched)
+	require.Equal(t, uint32(0), *finalizedBlock.ShardID)
+	require.Equal(t, uint64(1), finalizedBlock.Nonce)
+	require.Nil(t, finalizedBlock.TxHashes)
+
+	err = client.FinalizedEventsRequest(&outport.FinalizedBlock{HeaderHash: []byte("headerHash3")})
+	require.Nil(t, err)
+
+	finalizedBlock = getFinalizedBlock()
+	require.Equal(t, hex.EncodeToString([]byte("headerHash3")), finalizedBlock.Hash)
+	require.False(t, *finalizedBlock.Enriched)
+	require.Nil(t, finalizedBlock.ShardID)
+}
+
 func pushEventsRequest(wg *sync.WaitGroup, webServer integrationTests.ObserverConnector) {
 	header := &block.HeaderV2{
 		Header: &block.Header{
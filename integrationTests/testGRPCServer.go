@@ -0,0 +1,114 @@
+package integrationTests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/multiversx/mx-chain-core-go/data/outport"
+	"github.com/multiversx/mx-chain-notifier-go/api/shared"
+	"github.com/multiversx/mx-chain-notifier-go/config"
+	"github.com/multiversx/mx-chain-notifier-go/factory"
+	"github.com/multiversx/mx-chain-notifier-go/grpc"
+	"github.com/multiversx/mx-chain-notifier-go/metrics"
+	"github.com/multiversx/mx-chain-notifier-go/process"
+	grpcLib "google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// newTestGRPCServer will create a new gRPC observer connector and a client streaming to it
+func newTestGRPCServer(facade shared.FacadeHandler, generalConfig config.GeneralConfig) (ObserverConnector, error) {
+	conf := config.GRPCConfig{
+		Enabled:            true,
+		URL:                fmt.Sprintf("localhost:%d", getRandomPort()),
+		DataMarshallerType: "json",
+	}
+
+	connector, err := factory.CreateGRPCObserverConnector(conf, facade, metrics.NewStatusMetrics(), metrics.NewProcessedBlocksTracker(), metrics.NewMetricsCollector(), generalConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return newGRPCObsClient(conf.URL, connector)
+}
+
+type grpcObsClient struct {
+	conn      *grpcLib.ClientConn
+	stream    grpc.ObserveEvents_ObserveEventsClient
+	cancel    func()
+	connector process.WSClient
+
+	// mutStream serializes the requests, so that each ack is received by its sender
+	mutStream sync.Mutex
+}
+
+// newGRPCObsClient will create a new instance of observer gRPC client
+func newGRPCObsClient(url string, connector process.WSClient) (*grpcObsClient, error) {
+	conn, err := grpcLib.Dial(url, grpcLib.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := grpc.NewObserveEventsClient(conn).ObserveEvents(ctx)
+	if err != nil {
+		cancel()
+		_ = conn.Close()
+		return nil, err
+	}
+
+	return &grpcObsClient{
+		conn:      conn,
+		stream:    stream,
+		cancel:    cancel,
+		connector: connector,
+	}, nil
+}
+
+// PushEventsRequest will send the outport block on the stream
+func (o *grpcObsClient) PushEventsRequest(outportBlock *outport.OutportBlock) error {
+	return o.send(&grpc.ObserverEvent{Event: &grpc.ObserverEvent_OutportBlock{OutportBlock: outportBlock}})
+}
+
+// RevertEventsRequest will send the reverted block on the stream
+func (o *grpcObsClient) RevertEventsRequest(blockData *outport.BlockData) error {
+	return o.send(&grpc.ObserverEvent{Event: &grpc.ObserverEvent_RevertedBlock{RevertedBlock: blockData}})
+}
+
+// FinalizedEventsRequest will send the finalized block on the stream
+func (o *grpcObsClient) FinalizedEventsRequest(finalizedBlock *outport.FinalizedBlock) error {
+	return o.send(&grpc.ObserverEvent{Event: &grpc.ObserverEvent_FinalizedBlock{FinalizedBlock: finalizedBlock}})
+}
+
+func (o *grpcObsClient) send(event *grpc.ObserverEvent) error {
+	o.mutStream.Lock()
+	defer o.mutStream.Unlock()
+
+	err := o.stream.Send(event)
+	if err != nil {
+		return err
+	}
+
+	ack, err := o.stream.Recv()
+	if err != nil {
+		return err
+	}
+	if ack.Error != "" {
+		return errors.New(ack.Error)
+	}
+
+	return nil
+}
+
+// Close will close the client stream and the gRPC observer connector
+func (o *grpcObsClient) Close() error {
+	o.mutStream.Lock()
+	defer o.mutStream.Unlock()
+
+	_ = o.stream.CloseSend()
+	o.cancel()
+	_ = o.conn.Close()
+
+	return o.connector.Close()
+}
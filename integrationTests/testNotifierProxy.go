@@ -1,6 +1,8 @@
 package integrationTests
 
 import (
+	"net/http"
+
 	"github.com/multiversx/mx-chain-core-go/marshal"
 	"github.com/multiversx/mx-chain-notifier-go/api/shared"
 	"github.com/multiversx/mx-chain-notifier-go/config"
@@ -15,6 +17,7 @@ import (
 	"github.com/multiversx/mx-chain-notifier-go/process"
 	"github.com/multiversx/mx-chain-notifier-go/rabbitmq"
 	"github.com/multiversx/mx-chain-notifier-go/redis"
+	"github.com/multiversx/mx-chain-notifier-go/webhook"
 )
 
 type testNotifier struct {
@@ -28,6 +31,34 @@ type testNotifier struct {
 
 // NewTestNotifierWithWS will create a notifier instance for websockets flow
 func NewTestNotifierWithWS(cfg config.MainConfig) (*testNotifier, error) {
+	return newTestNotifierWithWS(cfg, mocks.NewRabbitClientMock(), nil)
+}
+
+// NewTestNotifierWithWSAndRabbitMq will create a notifier instance which delivers the events
+// to the websocket subscribers and also publishes them to rabbitmq
+func NewTestNotifierWithWSAndRabbitMq(cfg config.MainConfig) (*testNotifier, error) {
+	rabbitmqMock := mocks.NewRabbitClientMock()
+	publisherArgs := rabbitmq.ArgsRabbitMqPublisher{
+		Client:           rabbitmqMock,
+		Config:           cfg.RabbitMQ,
+		Marshaller:       &marshal.JsonMarshalizer{},
+		MetricsCollector: metrics.NewMetricsCollector(),
+	}
+	rabbitPublisherHandler, err := rabbitmq.NewRabbitMqPublisher(publisherArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	return newTestNotifierWithWS(cfg, rabbitmqMock, rabbitPublisherHandler)
+}
+
+// newTestNotifierWithWS creates the websockets flow, publishing also to the additional
+// publisher handler, if provided
+func newTestNotifierWithWS(
+	cfg config.MainConfig,
+	rabbitmqMock *mocks.RabbitClientMock,
+	additionalPublisherHandler process.PublisherHandler,
+) (*testNotifier, error) {
 	marshaller := &marshal.JsonMarshalizer{}
 	redisClient := mocks.NewRedisClientMock()
 	redlockArgs := redis.ArgsRedlockWrapper{
@@ -39,15 +70,29 @@ func NewTestNotifierWithWS(cfg config.MainConfig) (*testNotifier, error) {
 		return nil, err
 	}
 
+	subscriptionMapper, err := dispatcher.NewSubscriptionMapper(dispatcher.ArgsSubscriptionMapper{})
+	if err != nil {
+		return nil, err
+	}
+
 	args := hub.ArgsCommonHub{
 		Filter:             filters.NewDefaultFilter(),
-		SubscriptionMapper: dispatcher.NewSubscriptionMapper(),
+		SubscriptionMapper: subscriptionMapper,
+		MetricsCollector:   metrics.NewMetricsCollector(),
+		EventStore:         &disabled.EventStore{},
 	}
 	commonHub, err := hub.NewCommonHub(args)
 	if err != nil {
 		return nil, err
 	}
-	publisher, err := process.NewPublisher(commonHub)
+	publisherHandler := process.PublisherHandler(commonHub)
+	if additionalPublisherHandler != nil {
+		publisherHandler, err = process.NewCompositePublisherHandler([]process.PublisherHandler{commonHub, additionalPublisherHandler})
+		if err != nil {
+			return nil, err
+		}
+	}
+	publisher, err := process.NewPublisher(process.ArgsPublisher{Handler: publisherHandler})
 	if err != nil {
 		return nil, err
 	}
@@ -88,11 +133,26 @@ func NewTestNotifierWithWS(cfg config.MainConfig) (*testNotifier, error) {
 		return nil, err
 	}
 
+	webhookRegistry, err := webhook.NewWebhookRegistry(webhook.ArgsWebhookRegistry{
+		Hub:        commonHub,
+		Client:     &http.Client{},
+		Config:     cfg.Webhook,
+		Marshaller: &marshal.JsonMarshalizer{},
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	facadeArgs := facade.ArgsNotifierFacade{
-		EventsHandler:        eventsHandler,
-		APIConfig:            cfg.ConnectorApi,
-		WSHandler:            wsHandler,
-		StatusMetricsHandler: statusMetricsHandler,
+		EventsHandler:          eventsHandler,
+		APIConfig:              cfg.ConnectorApi,
+		WSHandler:              wsHandler,
+		Hub:                    commonHub,
+		WebhookRegistry:        webhookRegistry,
+		ConsumerRegistry:       &mocks.ConsumerRegistryStub{},
+		EventStore:             &disabled.EventStore{},
+		StatusMetricsHandler:   statusMetricsHandler,
+		ProcessedBlocksTracker: metrics.NewProcessedBlocksTracker(),
 	}
 	facade, err := facade.NewNotifierFacade(facadeArgs)
 	if err != nil {
@@ -105,7 +165,7 @@ func NewTestNotifierWithWS(cfg config.MainConfig) (*testNotifier, error) {
 		Publisher:      publisher,
 		WSHandler:      wsHandler,
 		RedisClient:    redisClient,
-		RabbitMQClient: mocks.NewRabbitClientMock(),
+		RabbitMQClient: rabbitmqMock,
 	}, nil
 }
 
@@ -126,15 +186,16 @@ func NewTestNotifierWithRabbitMq(cfg config.MainConfig) (*testNotifier, error) {
 
 	rabbitmqMock := mocks.NewRabbitClientMock()
 	publisherArgs := rabbitmq.ArgsRabbitMqPublisher{
-		Client:     rabbitmqMock,
-		Config:     cfg.RabbitMQ,
-		Marshaller: marshaller,
+		Client:           rabbitmqMock,
+		Config:           cfg.RabbitMQ,
+		Marshaller:       marshaller,
+		MetricsCollector: metrics.NewMetricsCollector(),
 	}
 	publisherHandler, err := rabbitmq.NewRabbitMqPublisher(publisherArgs)
 	if err != nil {
 		return nil, err
 	}
-	publisher, err := process.NewPublisher(publisherHandler)
+	publisher, err := process.NewPublisher(process.ArgsPublisher{Handler: publisherHandler})
 	if err != nil {
 		return nil, err
 	}
@@ -153,6 +214,7 @@ func NewTestNotifierWithRabbitMq(cfg config.MainConfig) (*testNotifier, error) {
 		StatusMetricsHandler: statusMetricsHandler,
 		CheckDuplicates:      cfg.General.CheckDuplicates,
 		EventsInterceptor:    eventsInterceptor,
+		AckAfterPublish:      cfg.General.AckAfterPublish,
 	}
 	eventsHandler, err := process.NewEventsHandler(argsEventsHandler)
 	if err != nil {
@@ -161,10 +223,15 @@ func NewTestNotifierWithRabbitMq(cfg config.MainConfig) (*testNotifier, error) {
 
 	wsHandler := &disabled.WSHandler{}
 	facadeArgs := facade.ArgsNotifierFacade{
-		EventsHandler:        eventsHandler,
-		APIConfig:            cfg.ConnectorApi,
-		WSHandler:            wsHandler,
-		StatusMetricsHandler: statusMetricsHandler,
+		EventsHandler:          eventsHandler,
+		APIConfig:              cfg.ConnectorApi,
+		WSHandler:              wsHandler,
+		Hub:                    &disabled.Hub{},
+		WebhookRegistry:        &mocks.WebhookRegistryStub{},
+		ConsumerRegistry:       &mocks.ConsumerRegistryStub{},
+		EventStore:             &disabled.EventStore{},
+		StatusMetricsHandler:   statusMetricsHandler,
+		ProcessedBlocksTracker: metrics.NewProcessedBlocksTracker(),
 	}
 	facade, err := facade.NewNotifierFacade(facadeArgs)
 	if err != nil {
@@ -189,6 +256,9 @@ func GetDefaultConfigs() config.Configs {
 				ExternalMarshaller: config.MarshallerConfig{
 					Type: "json",
 				},
+				InternalMarshaller: config.MarshallerConfig{
+					Type: "json",
+				},
 				AddressConverter: config.AddressConverterConfig{
 					Type:   "bech32",
 					Prefix: "erd",
@@ -234,6 +304,10 @@ func GetDefaultConfigs() config.Configs {
 					Name: "blockevents",
 					Type: "fanout",
 				},
+				PublishConfirmTimeoutInMs: 1000,
+				PublishMaxAttempts:        3,
+				PublishRetryIntervalInMs:  10,
+				MaxBufferedEvents:         100,
 			},
 		},
 		Flags: config.FlagsConfig{
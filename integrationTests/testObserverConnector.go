@@ -10,33 +10,55 @@ import (
 	wsFactory "github.com/multiversx/mx-chain-communication-go/websocket/factory"
 	"github.com/multiversx/mx-chain-core-go/data/outport"
 	"github.com/multiversx/mx-chain-core-go/marshal"
+	marshalFactory "github.com/multiversx/mx-chain-core-go/marshal/factory"
 	logger "github.com/multiversx/mx-chain-logger-go"
 	"github.com/multiversx/mx-chain-notifier-go/api/shared"
 	"github.com/multiversx/mx-chain-notifier-go/common"
 	"github.com/multiversx/mx-chain-notifier-go/config"
 	"github.com/multiversx/mx-chain-notifier-go/factory"
+	"github.com/multiversx/mx-chain-notifier-go/metrics"
 )
 
-// CreateObserverConnector will create observer connector component
+// CreateObserverConnector will create observer connector component, the websocket observer
+// connector payloads and their outport data being json encoded
 func CreateObserverConnector(facade shared.FacadeHandler, connType string, apiType string, payloadVersion uint32) (ObserverConnector, error) {
-	marshaller := &marshal.JsonMarshalizer{}
-	payloadHandler, err := factory.CreatePayloadHandler(marshaller, facade)
-	if err != nil {
-		return nil, err
+	generalConfig := config.GeneralConfig{
+		InternalMarshaller: config.MarshallerConfig{Type: marshalFactory.JsonMarshalizer},
 	}
 
+	return CreateObserverConnectorWithConfig(facade, connType, apiType, payloadVersion, generalConfig)
+}
+
+// CreateObserverConnectorWithConfig will create observer connector component, which processes
+// the payloads with the provided general config
+func CreateObserverConnectorWithConfig(
+	facade shared.FacadeHandler,
+	connType string,
+	apiType string,
+	payloadVersion uint32,
+	generalConfig config.GeneralConfig,
+) (ObserverConnector, error) {
 	switch connType {
 	case common.HTTPConnectorType:
+		configs := config.Configs{MainConfig: config.MainConfig{General: generalConfig}}
+		payloadHandler, err := factory.CreateHTTPPayloadHandler(facade, configs, metrics.NewStatusMetrics(), metrics.NewProcessedBlocksTracker(), metrics.NewMetricsCollector())
+		if err != nil {
+			return nil, err
+		}
 		return NewTestWebServer(facade, apiType, payloadHandler, payloadVersion), nil
 	case common.WSObsConnectorType:
-		return newTestWSServer(facade, marshaller)
+		return CreateWSObserverConnector(facade, marshalFactory.JsonMarshalizer, generalConfig)
+	case common.GRPCConnectorType:
+		return newTestGRPCServer(facade, generalConfig)
 	default:
 		return nil, errors.New("invalid observer connector type")
 	}
 }
 
-// newTestWSServer will create a new test ws server
-func newTestWSServer(facade shared.FacadeHandler, marshaller marshal.Marshalizer) (ObserverConnector, error) {
+// CreateWSObserverConnector will create a websocket observer connector and a client pushing to it. The
+// payloads are encoded with the data marshaller type and their outport data with the internal
+// marshaller of the general config, as the observer node does
+func CreateWSObserverConnector(facade shared.FacadeHandler, dataMarshallerType string, generalConfig config.GeneralConfig) (ObserverConnector, error) {
 	port := getRandomPort()
 	conf := config.WebSocketConfig{
 		Enabled:                 true,
@@ -46,10 +68,19 @@ func newTestWSServer(facade shared.FacadeHandler, marshaller marshal.Marshalizer
 		RetryDurationInSec:      5,
 		BlockingAckOnError:      false,
 		AcknowledgeTimeoutInSec: 60,
-		DataMarshallerType:      "json",
+		DataMarshallerType:      dataMarshallerType,
+	}
+
+	dataMarshaller, err := marshalFactory.NewMarshalizer(dataMarshallerType)
+	if err != nil {
+		return nil, err
+	}
+	internalMarshaller, err := marshalFactory.NewMarshalizer(generalConfig.InternalMarshaller.Type)
+	if err != nil {
+		return nil, err
 	}
 
-	_, err := factory.CreateWSObserverConnector(conf, facade)
+	_, err = factory.CreateWSObserverConnector(conf, facade, metrics.NewStatusMetrics(), metrics.NewProcessedBlocksTracker(), metrics.NewMetricsCollector(), generalConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -57,7 +88,7 @@ func newTestWSServer(facade shared.FacadeHandler, marshaller marshal.Marshalizer
 	// wait for ws server to start
 	time.Sleep(4 * time.Second)
 
-	wsClient, err := newWSObsClient(marshaller, conf.URL)
+	wsClient, err := newWSObsClient(dataMarshaller, internalMarshaller, conf.URL)
 	if err != nil {
 		return nil, err
 	}
@@ -95,8 +126,9 @@ type wsObsClient struct {
 	senderHost senderHost
 }
 
-// newWSObsClient will create a new instance of observer websocket client
-func newWSObsClient(marshaller marshal.Marshalizer, url string) (*wsObsClient, error) {
+// newWSObsClient will create a new instance of observer websocket client, which encodes the
+// payloads with the data marshaller and their outport data with the internal marshaller
+func newWSObsClient(dataMarshaller marshal.Marshalizer, internalMarshaller marshal.Marshalizer, url string) (*wsObsClient, error) {
 	var log = logger.GetOrCreate("hostdriver")
 
 	wsHost, err := wsFactory.CreateWebSocketHost(wsFactory.ArgsWebSocketHost{
@@ -109,7 +141,7 @@ func newWSObsClient(marshaller marshal.Marshalizer, url string) (*wsObsClient, e
 			AcknowledgeTimeoutInSec: 60,
 			Version:                 1,
 		},
-		Marshaller: marshaller,
+		Marshaller: dataMarshaller,
 		Log:        log,
 	})
 	if err != nil {
@@ -117,7 +149,7 @@ func newWSObsClient(marshaller marshal.Marshalizer, url string) (*wsObsClient, e
 	}
 
 	return &wsObsClient{
-		marshaller: marshaller,
+		marshaller: internalMarshaller,
 		senderHost: wsHost,
 	}, nil
 }
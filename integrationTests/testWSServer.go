@@ -2,12 +2,14 @@ package integrationTests
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"sync"
 
 	"github.com/gorilla/websocket"
+	"github.com/multiversx/mx-chain-notifier-go/common"
 	"github.com/multiversx/mx-chain-notifier-go/data"
 	"github.com/multiversx/mx-chain-notifier-go/dispatcher"
 )
@@ -34,7 +36,7 @@ func NewWSClient(h http.Handler) (*wsClient, error) {
 	}, nil
 }
 
-// SendSubscribeMessage will send subscribe message
+// SendSubscribeMessage will send subscribe message and wait for the subscription to be acknowledged
 func (ws *wsClient) SendSubscribeMessage(subscribeEvent *data.SubscribeEvent) error {
 	m, err := json.Marshal(subscribeEvent)
 	if err != nil {
@@ -45,6 +47,20 @@ func (ws *wsClient) SendSubscribeMessage(subscribeEvent *data.SubscribeEvent) er
 		return err
 	}
 
+	m, err = ws.ReadMessage()
+	if err != nil {
+		return err
+	}
+
+	var ackFrame data.SubscribeAckFrame
+	err = json.Unmarshal(m, &ackFrame)
+	if err != nil {
+		return err
+	}
+	if ackFrame.Type != common.SubscribeAckFrameType {
+		return fmt.Errorf("subscription not acknowledged: %s", m)
+	}
+
 	return nil
 }
 
@@ -192,6 +208,28 @@ func (ws *wsClient) ReceiveScrs() (*data.BlockScrs, error) {
 	return &event, nil
 }
 
+// ReceiveTxEvents will try to receive block tx events
+func (ws *wsClient) ReceiveTxEvents() (*data.BlockTxEvents, error) {
+	m, err := ws.ReadMessage()
+	if err != nil {
+		return nil, err
+	}
+
+	var reply data.WebSocketEvent
+	err = json.Unmarshal(m, &reply)
+	if err != nil {
+		return nil, err
+	}
+
+	var event data.BlockTxEvents
+	err = json.Unmarshal(reply.Data, &event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &event, nil
+}
+
 // Close will close connection
 func (ws *wsClient) Close() {
 	ws.httpServer.Close()
@@ -84,7 +84,7 @@ func (w *TestWebServer) createGroups() map[string]shared.GroupHandler {
 	}
 
 	if w.apiType == common.WSPublisherType {
-		hubHandler, err := groups.NewHubGroup(w.facade)
+		hubHandler, err := groups.NewHubGroup(groups.ArgsHubGroup{Facade: w.facade})
 		if err == nil {
 			groupsMap["hub"] = hubHandler
 		}
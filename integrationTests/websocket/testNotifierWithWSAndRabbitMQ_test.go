@@ -0,0 +1,113 @@
+package websocket
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/data/block"
+	"github.com/multiversx/mx-chain-core-go/data/outport"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-notifier-go/common"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/integrationTests"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotifierWithWebsocketsAndRabbitMQ_PushEvents(t *testing.T) {
+	cfg := integrationTests.GetDefaultConfigs()
+	notifier, err := integrationTests.NewTestNotifierWithWSAndRabbitMq(cfg.MainConfig)
+	require.Nil(t, err)
+
+	webServer, err := integrationTests.CreateObserverConnector(notifier.Facade, common.HTTPConnectorType, common.WSPublisherType, common.PayloadV1)
+	require.Nil(t, err)
+
+	_ = notifier.Publisher.Run()
+	defer notifier.Publisher.Close()
+
+	ws, err := integrationTests.NewWSClient(notifier.WSHandler)
+	require.Nil(t, err)
+	defer ws.Close()
+
+	subscribeEvent := &data.SubscribeEvent{
+		SubscriptionEntries: []data.SubscriptionEntry{
+			{
+				EventType: common.PushLogsAndEvents,
+			},
+		},
+		SubscribeToAll: true,
+	}
+
+	ws.SendSubscribeMessage(subscribeEvent)
+
+	addr := []byte("addr1")
+	events := []data.Event{
+		{
+			Address: hex.EncodeToString(addr),
+			TxHash:  "txHash1",
+		},
+	}
+
+	header := &block.HeaderV2{
+		Header: &block.Header{
+			ShardID:   1,
+			TimeStamp: 1234,
+		},
+	}
+	headerBytes, _ := json.Marshal(header)
+	saveBlockData := &outport.OutportBlock{
+		TransactionPool: &outport.TransactionPool{
+			Logs: []*outport.LogData{
+				{
+					Log: &transaction.Log{
+						Events: []*transaction.Event{
+							{
+								Address: addr,
+							},
+						},
+					},
+					TxHash: "txHash1",
+				},
+			},
+		},
+		BlockData: &outport.BlockData{
+			HeaderBytes: headerBytes,
+			HeaderType:  string(core.ShardHeaderV2),
+			HeaderHash:  []byte("headerHash"),
+			Body: &block.Body{
+				MiniBlocks: make([]*block.MiniBlock, 1),
+			},
+		},
+		HeaderGasConsumption: &outport.HeaderGasConsumption{},
+	}
+
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+
+	go func() {
+		reply, err := ws.ReceiveEvents()
+		require.Nil(t, err)
+
+		require.Equal(t, events, reply)
+		wg.Done()
+	}()
+
+	time.Sleep(time.Second)
+
+	err = webServer.PushEventsRequest(saveBlockData)
+	require.Nil(t, err)
+
+	integrationTests.WaitTimeout(t, wg, time.Second*2)
+
+	publishing, ok := notifier.RabbitMQClient.GetEntries()[cfg.MainConfig.RabbitMQ.EventsExchange.Name]
+	require.True(t, ok)
+
+	publishedEvents := data.BlockEvents{}
+	err = json.Unmarshal(publishing.Body, &publishedEvents)
+	require.Nil(t, err)
+	require.Equal(t, hex.EncodeToString([]byte("headerHash")), publishedEvents.Hash)
+	require.Equal(t, events, publishedEvents.Events)
+}
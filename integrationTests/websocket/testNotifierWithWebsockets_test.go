@@ -3,6 +3,7 @@ package websocket
 import (
 	"encoding/hex"
 	"encoding/json"
+	"math/big"
 	"sync"
 	"testing"
 	"time"
@@ -12,6 +13,7 @@ import (
 	"github.com/multiversx/mx-chain-core-go/data/outport"
 	"github.com/multiversx/mx-chain-core-go/data/smartContractResult"
 	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	marshalFactory "github.com/multiversx/mx-chain-core-go/marshal/factory"
 	"github.com/multiversx/mx-chain-notifier-go/common"
 	"github.com/multiversx/mx-chain-notifier-go/data"
 	"github.com/multiversx/mx-chain-notifier-go/integrationTests"
@@ -40,6 +42,7 @@ func TestNotifierWithWebsockets_PushEvents(t *testing.T) {
 				EventType: common.PushLogsAndEvents,
 			},
 		},
+		SubscribeToAll: true,
 	}
 
 	ws.SendSubscribeMessage(subscribeEvent)
@@ -104,6 +107,94 @@ func TestNotifierWithWebsockets_PushEvents(t *testing.T) {
 	integrationTests.WaitTimeout(t, wg, time.Second*2)
 }
 
+func TestNotifierWithWebsockets_ProtobufPayloadsWithJSONData(t *testing.T) {
+	cfg := integrationTests.GetDefaultConfigs()
+	notifier, err := integrationTests.NewTestNotifierWithWS(cfg.MainConfig)
+	require.Nil(t, err)
+
+	// the payloads are protobuf encoded, while the outport data inside them is json encoded
+	client, err := integrationTests.CreateWSObserverConnector(notifier.Facade, marshalFactory.GogoProtobuf, cfg.MainConfig.General)
+	require.Nil(t, err)
+	defer client.Close()
+
+	_ = notifier.Publisher.Run()
+	defer notifier.Publisher.Close()
+
+	ws, err := integrationTests.NewWSClient(notifier.WSHandler)
+	require.Nil(t, err)
+	defer ws.Close()
+
+	subscribeEvent := &data.SubscribeEvent{
+		SubscriptionEntries: []data.SubscriptionEntry{
+			{
+				EventType: common.PushLogsAndEvents,
+			},
+		},
+		SubscribeToAll: true,
+	}
+
+	ws.SendSubscribeMessage(subscribeEvent)
+
+	addr := []byte("addr1")
+	events := []data.Event{
+		{
+			Address: hex.EncodeToString(addr),
+			TxHash:  "txHash1",
+		},
+	}
+
+	header := &block.HeaderV2{
+		Header: &block.Header{
+			ShardID:   1,
+			TimeStamp: 1234,
+		},
+	}
+	headerBytes, _ := json.Marshal(header)
+	saveBlockData := &outport.OutportBlock{
+		TransactionPool: &outport.TransactionPool{
+			Logs: []*outport.LogData{
+				{
+					Log: &transaction.Log{
+						Events: []*transaction.Event{
+							{
+								Address: addr,
+							},
+						},
+					},
+					TxHash: "txHash1",
+				},
+			},
+		},
+		BlockData: &outport.BlockData{
+			HeaderBytes: headerBytes,
+			HeaderType:  string(core.ShardHeaderV2),
+			HeaderHash:  []byte("headerHash"),
+			Body: &block.Body{
+				MiniBlocks: make([]*block.MiniBlock, 1),
+			},
+		},
+		HeaderGasConsumption: &outport.HeaderGasConsumption{},
+	}
+
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+
+	go func() {
+		reply, err := ws.ReceiveEvents()
+		require.Nil(t, err)
+
+		require.Equal(t, events, reply)
+		wg.Done()
+	}()
+
+	time.Sleep(time.Second)
+
+	err = client.PushEventsRequest(saveBlockData)
+	require.Nil(t, err)
+
+	integrationTests.WaitTimeout(t, wg, time.Second*2)
+}
+
 func TestNotifierWithWebsockets_BlockEvents(t *testing.T) {
 	cfg := integrationTests.GetDefaultConfigs()
 	notifier, err := integrationTests.NewTestNotifierWithWS(cfg.MainConfig)
@@ -245,6 +336,9 @@ func TestNotifierWithWebsockets_RevertEvents(t *testing.T) {
 		reply, err := ws.ReceiveRevertBlock()
 		require.Nil(t, err)
 
+		require.NotZero(t, reply.Timestamp)
+		reply.Timestamp = 0
+		expReply.Sequence = 1
 		require.Equal(t, expReply, reply)
 		wg.Done()
 	}()
@@ -297,6 +391,9 @@ func TestNotifierWithWebsockets_FinalizedEvents(t *testing.T) {
 		reply, err := ws.ReceiveFinalized()
 		require.Nil(t, err)
 
+		require.NotZero(t, reply.Timestamp)
+		reply.Timestamp = 0
+		expReply.Sequence = 1
 		require.Equal(t, expReply, reply)
 		wg.Done()
 	}()
@@ -308,6 +405,102 @@ func TestNotifierWithWebsockets_FinalizedEvents(t *testing.T) {
 	integrationTests.WaitTimeout(t, wg, time.Second*2)
 }
 
+func TestNotifierWithWebsockets_EnrichedFinalizedEvents(t *testing.T) {
+	cfg := integrationTests.GetDefaultConfigs()
+	cfg.MainConfig.General.FinalizedDetailsCacheDepth = 10
+	cfg.MainConfig.General.FinalizedDetailsWithTxHashes = true
+	notifier, err := integrationTests.NewTestNotifierWithWS(cfg.MainConfig)
+	require.Nil(t, err)
+
+	webServer, err := integrationTests.CreateObserverConnectorWithConfig(notifier.Facade, common.HTTPConnectorType, common.WSPublisherType, common.PayloadV1, cfg.MainConfig.General)
+	require.Nil(t, err)
+
+	_ = notifier.Publisher.Run()
+	defer notifier.Publisher.Close()
+
+	ws, err := integrationTests.NewWSClient(notifier.WSHandler)
+	require.Nil(t, err)
+	defer ws.Close()
+
+	ws.SendSubscribeMessage(&data.SubscribeEvent{
+		SubscriptionEntries: []data.SubscriptionEntry{
+			{
+				EventType: common.FinalizedBlockEvents,
+			},
+		},
+	})
+
+	header := &block.HeaderV2{
+		Header: &block.Header{
+			ShardID:   1,
+			Nonce:     2,
+			Round:     3,
+			TimeStamp: 4,
+		},
+	}
+	headerBytes, _ := json.Marshal(header)
+	saveBlockData := &outport.OutportBlock{
+		BlockData: &outport.BlockData{
+			HeaderBytes: headerBytes,
+			HeaderType:  string(core.ShardHeaderV2),
+			HeaderHash:  []byte("hash1"),
+			Body:        &block.Body{},
+		},
+		TransactionPool: &outport.TransactionPool{
+			Transactions: map[string]*outport.TxInfo{
+				"txHash1": {Transaction: &transaction.Transaction{}, FeeInfo: &outport.FeeInfo{}},
+			},
+		},
+		HeaderGasConsumption: &outport.HeaderGasConsumption{},
+	}
+
+	shardID := uint32(1)
+	enriched := true
+	expReplies := []*data.FinalizedBlock{
+		{
+			Hash:           hex.EncodeToString([]byte("hash1")),
+			ShardID:        &shardID,
+			Nonce:          2,
+			Round:          3,
+			BlockTimeStamp: 4,
+			TxHashes:       []string{"txHash1"},
+			Enriched:       &enriched,
+			Sequence:       2,
+		},
+		{
+			Hash:     hex.EncodeToString([]byte("hash2")),
+			Enriched: new(bool),
+			Sequence: 3,
+		},
+	}
+
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+
+	go func() {
+		for _, expReply := range expReplies {
+			reply, err := ws.ReceiveFinalized()
+			require.Nil(t, err)
+
+			require.NotZero(t, reply.Timestamp)
+			reply.Timestamp = 0
+			require.Equal(t, expReply, reply)
+		}
+		wg.Done()
+	}()
+
+	time.Sleep(time.Second)
+
+	err = webServer.PushEventsRequest(saveBlockData)
+	require.Nil(t, err)
+	err = webServer.FinalizedEventsRequest(&outport.FinalizedBlock{HeaderHash: []byte("hash1")})
+	require.Nil(t, err)
+	err = webServer.FinalizedEventsRequest(&outport.FinalizedBlock{HeaderHash: []byte("hash2")})
+	require.Nil(t, err)
+
+	integrationTests.WaitTimeout(t, wg, time.Second*2)
+}
+
 func TestNotifierWithWebsockets_TxsEvents(t *testing.T) {
 	cfg := integrationTests.GetDefaultConfigs()
 	notifier, err := integrationTests.NewTestNotifierWithWS(cfg.MainConfig)
@@ -392,6 +585,172 @@ func TestNotifierWithWebsockets_TxsEvents(t *testing.T) {
 	integrationTests.WaitTimeout(t, wg, time.Second*2)
 }
 
+func TestNotifierWithWebsockets_TxEvents(t *testing.T) {
+	cfg := integrationTests.GetDefaultConfigs()
+	notifier, err := integrationTests.NewTestNotifierWithWS(cfg.MainConfig)
+	require.Nil(t, err)
+
+	webServer, err := integrationTests.CreateObserverConnector(notifier.Facade, common.HTTPConnectorType, common.WSPublisherType, common.PayloadV1)
+	require.Nil(t, err)
+
+	_ = notifier.Publisher.Run()
+	defer notifier.Publisher.Close()
+
+	alice := []byte("alice")
+	bob := []byte("bob")
+	carol := []byte("carol")
+
+	wsAlice, err := integrationTests.NewWSClient(notifier.WSHandler)
+	require.Nil(t, err)
+	defer wsAlice.Close()
+
+	err = wsAlice.SendSubscribeMessage(createTxEventsSubscribeEvent(alice))
+	require.Nil(t, err)
+
+	wsCarol, err := integrationTests.NewWSClient(notifier.WSHandler)
+	require.Nil(t, err)
+	defer wsCarol.Close()
+
+	err = wsCarol.SendSubscribeMessage(createTxEventsSubscribeEvent(carol))
+	require.Nil(t, err)
+
+	blockHash := []byte("hash1")
+	txs := map[string]*outport.TxInfo{
+		"txHash1": {
+			Transaction: &transaction.Transaction{
+				SndAddr: alice,
+				RcvAddr: bob,
+				Value:   big.NewInt(10),
+			},
+			ExecutionOrder: 1,
+		},
+		"txHash2": {
+			Transaction: &transaction.Transaction{
+				SndAddr: bob,
+				RcvAddr: alice,
+				Value:   big.NewInt(20),
+			},
+			ExecutionOrder: 2,
+		},
+		"txHash3": {
+			Transaction: &transaction.Transaction{
+				SndAddr: bob,
+				RcvAddr: carol,
+				Value:   big.NewInt(30),
+			},
+			ExecutionOrder: 3,
+		},
+	}
+
+	header := &block.HeaderV2{
+		Header: &block.Header{
+			ShardID:   1,
+			TimeStamp: 1234,
+		},
+	}
+	headerBytes, _ := json.Marshal(header)
+	saveBlockData := &outport.OutportBlock{
+		TransactionPool: &outport.TransactionPool{
+			Transactions: txs,
+			Logs: []*outport.LogData{
+				{
+					Log: &transaction.Log{
+						Events: []*transaction.Event{
+							{
+								Address:    bob,
+								Identifier: []byte(core.SignalErrorOperation),
+							},
+						},
+					},
+					TxHash: "txHash2",
+				},
+			},
+		},
+		BlockData: &outport.BlockData{
+			HeaderBytes: headerBytes,
+			HeaderType:  string(core.ShardHeaderV2),
+			HeaderHash:  blockHash,
+			Body: &block.Body{
+				MiniBlocks: make([]*block.MiniBlock, 1),
+			},
+		},
+		HeaderGasConsumption: &outport.HeaderGasConsumption{},
+	}
+
+	expAliceTxEvents := &data.BlockTxEvents{
+		Hash:      hex.EncodeToString(blockHash),
+		ShardID:   1,
+		TimeStamp: 1234,
+		TxEvents: []data.TxEvent{
+			{
+				Hash:     "txHash1",
+				Sender:   hex.EncodeToString(alice),
+				Receiver: hex.EncodeToString(bob),
+				Value:    "10",
+				Status:   common.TxStatusSuccess,
+			},
+			{
+				Hash:     "txHash2",
+				Sender:   hex.EncodeToString(bob),
+				Receiver: hex.EncodeToString(alice),
+				Value:    "20",
+				Status:   common.TxStatusFail,
+			},
+		},
+	}
+	expCarolTxEvents := &data.BlockTxEvents{
+		Hash:      hex.EncodeToString(blockHash),
+		ShardID:   1,
+		TimeStamp: 1234,
+		TxEvents: []data.TxEvent{
+			{
+				Hash:     "txHash3",
+				Sender:   hex.EncodeToString(bob),
+				Receiver: hex.EncodeToString(carol),
+				Value:    "30",
+				Status:   common.TxStatusSuccess,
+			},
+		},
+	}
+
+	wg := &sync.WaitGroup{}
+	wg.Add(2)
+
+	go func() {
+		reply, err := wsAlice.ReceiveTxEvents()
+		require.Nil(t, err)
+
+		require.Equal(t, expAliceTxEvents, reply)
+		wg.Done()
+	}()
+
+	go func() {
+		reply, err := wsCarol.ReceiveTxEvents()
+		require.Nil(t, err)
+
+		require.Equal(t, expCarolTxEvents, reply)
+		wg.Done()
+	}()
+
+	time.Sleep(time.Second)
+
+	err = webServer.PushEventsRequest(saveBlockData)
+	require.Nil(t, err)
+
+	integrationTests.WaitTimeout(t, wg, time.Second*2)
+}
+
+func createTxEventsSubscribeEvent(address []byte) *data.SubscribeEvent {
+	return &data.SubscribeEvent{
+		SubscriptionEntries: []data.SubscriptionEntry{
+			{
+				EventType: common.TxEvents,
+				Address:   hex.EncodeToString(address),
+			},
+		},
+	}
+}
+
 func TestNotifierWithWebsockets_ScrsEvents(t *testing.T) {
 	cfg := integrationTests.GetDefaultConfigs()
 	notifier, err := integrationTests.NewTestNotifierWithWS(cfg.MainConfig)
@@ -483,6 +842,10 @@ func TestNotifierWithWebsockets_AllEvents(t *testing.T) {
 	t.Run("with ws observer connector", func(t *testing.T) {
 		testNotifierWithWebsockets_AllEvents(t, common.WSObsConnectorType)
 	})
+
+	t.Run("with grpc observer connector", func(t *testing.T) {
+		testNotifierWithWebsockets_AllEvents(t, common.GRPCConnectorType)
+	})
 }
 
 func testNotifierWithWebsockets_AllEvents(t *testing.T, observerType string) {
@@ -522,6 +885,7 @@ func testNotifierWithWebsockets_AllEvents(t *testing.T, observerType string) {
 				EventType: common.BlockScrs,
 			},
 		},
+		SubscribeToAll: true,
 	}
 
 	err = ws.SendSubscribeMessage(subscribeEvent)
@@ -679,6 +1043,9 @@ func testNotifierWithWebsockets_AllEvents(t *testing.T, observerType string) {
 			case common.RevertBlockEvents:
 				var event *data.RevertBlock
 				_ = json.Unmarshal(reply.Data, &event)
+				assert.NotZero(t, event.Timestamp)
+				assert.NotZero(t, event.Sequence)
+				event.Timestamp, event.Sequence = 0, 0
 				assert.Equal(t, expRevertBlock, event)
 				wg.Done()
 			case common.BlockEvents:
@@ -689,6 +1056,9 @@ func testNotifierWithWebsockets_AllEvents(t *testing.T, observerType string) {
 			case common.FinalizedBlockEvents:
 				var event *data.FinalizedBlock
 				_ = json.Unmarshal(reply.Data, &event)
+				assert.NotZero(t, event.Timestamp)
+				assert.NotZero(t, event.Sequence)
+				event.Timestamp, event.Sequence = 0, 0
 				assert.Equal(t, expFinalizedBlock, event)
 				wg.Done()
 			case common.BlockTxs:
@@ -0,0 +1,155 @@
+package kafka
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/multiversx/mx-chain-notifier-go/config"
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
+)
+
+const (
+	defaultWriteTimeout = 5 * time.Second
+
+	requireAllAcks  = "all"
+	requireOneAck   = "one"
+	requireNoneAcks = "none"
+
+	plainMechanism       = "plain"
+	scramSHA256Mechanism = "scram-sha-256"
+	scramSHA512Mechanism = "scram-sha-512"
+)
+
+// CreateWriter creates a kafka writer for the configured brokers. The topic is set on
+// each message, and the messages are partitioned by key, so the events of the same
+// block hash are always written to the same partition
+func CreateWriter(cfg config.KafkaConfig) (*kafka.Writer, error) {
+	if len(cfg.Brokers) == 0 {
+		return nil, ErrEmptyBrokers
+	}
+
+	requiredAcks, err := getRequiredAcks(cfg.RequiredAcks)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig, err := CreateTLSConfig(cfg.TLS)
+	if err != nil {
+		return nil, err
+	}
+
+	saslMechanism, err := CreateSASLMechanism(cfg.SASL)
+	if err != nil {
+		return nil, err
+	}
+
+	writeTimeout := time.Duration(cfg.WriteTimeoutInMs) * time.Millisecond
+	if writeTimeout == 0 {
+		writeTimeout = defaultWriteTimeout
+	}
+
+	return &kafka.Writer{
+		Addr:         kafka.TCP(cfg.Brokers...),
+		Balancer:     &kafka.Hash{},
+		RequiredAcks: requiredAcks,
+		WriteTimeout: writeTimeout,
+		// each event is written synchronously, so there is no batch to wait for
+		BatchSize: 1,
+		Transport: &kafka.Transport{
+			TLS:  tlsConfig,
+			SASL: saslMechanism,
+		},
+	}, nil
+}
+
+// getRequiredAcks returns the kafka required acks for the configured value; an empty value
+// requires all the in-sync replicas to acknowledge the writes
+func getRequiredAcks(requiredAcks string) (kafka.RequiredAcks, error) {
+	switch requiredAcks {
+	case "", requireAllAcks:
+		return kafka.RequireAll, nil
+	case requireOneAck:
+		return kafka.RequireOne, nil
+	case requireNoneAcks:
+		return kafka.RequireNone, nil
+	default:
+		return 0, fmt.Errorf("%w: %s", ErrInvalidRequiredAcks, requiredAcks)
+	}
+}
+
+// CreateSASLMechanism creates the SASL mechanism used to authenticate to the brokers. It
+// returns nil if no mechanism is configured
+func CreateSASLMechanism(cfg config.KafkaSASLConfig) (sasl.Mechanism, error) {
+	if cfg.Mechanism == "" {
+		return nil, nil
+	}
+	if cfg.Username == "" {
+		return nil, fmt.Errorf("%w: empty username", ErrInvalidSASLConfig)
+	}
+
+	password := cfg.Password
+	if cfg.PasswordEnvVar != "" {
+		var ok bool
+		password, ok = os.LookupEnv(cfg.PasswordEnvVar)
+		if !ok {
+			return nil, fmt.Errorf("%w: environment variable %s is not set", ErrInvalidSASLConfig, cfg.PasswordEnvVar)
+		}
+	}
+
+	switch cfg.Mechanism {
+	case plainMechanism:
+		return plain.Mechanism{Username: cfg.Username, Password: password}, nil
+	case scramSHA256Mechanism:
+		return scram.Mechanism(scram.SHA256, cfg.Username, password)
+	case scramSHA512Mechanism:
+		return scram.Mechanism(scram.SHA512, cfg.Username, password)
+	default:
+		return nil, fmt.Errorf("%w: unsupported mechanism %s", ErrInvalidSASLConfig, cfg.Mechanism)
+	}
+}
+
+// CreateTLSConfig creates the TLS config used for the brokers connection. It returns
+// nil if TLS is not enabled
+func CreateTLSConfig(cfg config.KafkaTLSConfig) (*tls.Config, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	if (cfg.CertFile == "") != (cfg.KeyFile == "") {
+		return nil, fmt.Errorf("%w: both client certificate and key files should be provided", ErrInvalidTLSConfig)
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	}
+
+	if cfg.CAFile != "" {
+		caCert, err := ioutil.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("%w: could not read CA file: %s", ErrInvalidTLSConfig, err.Error())
+		}
+
+		caCertPool := x509.NewCertPool()
+		if !caCertPool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("%w: no certificate found in CA file %s", ErrInvalidTLSConfig, cfg.CAFile)
+		}
+		tlsConfig.RootCAs = caCertPool
+	}
+
+	if cfg.CertFile != "" {
+		clientCert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("%w: could not load client certificate: %s", ErrInvalidTLSConfig, err.Error())
+		}
+		tlsConfig.Certificates = []tls.Certificate{clientCert}
+	}
+
+	return tlsConfig, nil
+}
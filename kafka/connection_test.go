@@ -0,0 +1,166 @@
+package kafka_test
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/multiversx/mx-chain-notifier-go/config"
+	notifierKafka "github.com/multiversx/mx-chain-notifier-go/kafka"
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateWriter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty brokers, should fail", func(t *testing.T) {
+		t.Parallel()
+
+		writer, err := notifierKafka.CreateWriter(config.KafkaConfig{})
+		require.Nil(t, writer)
+		require.Equal(t, notifierKafka.ErrEmptyBrokers, err)
+	})
+
+	t.Run("invalid TLS config, should fail", func(t *testing.T) {
+		t.Parallel()
+
+		writer, err := notifierKafka.CreateWriter(config.KafkaConfig{
+			Brokers: []string{"localhost:9092"},
+			TLS: config.KafkaTLSConfig{
+				Enabled:  true,
+				CertFile: "cert.pem",
+			},
+		})
+		require.Nil(t, writer)
+		require.True(t, errors.Is(err, notifierKafka.ErrInvalidTLSConfig))
+	})
+
+	t.Run("invalid required acks, should fail", func(t *testing.T) {
+		t.Parallel()
+
+		writer, err := notifierKafka.CreateWriter(config.KafkaConfig{
+			Brokers:      []string{"localhost:9092"},
+			RequiredAcks: "two",
+		})
+		require.Nil(t, writer)
+		require.True(t, errors.Is(err, notifierKafka.ErrInvalidRequiredAcks))
+	})
+
+	t.Run("invalid SASL config, should fail", func(t *testing.T) {
+		t.Parallel()
+
+		writer, err := notifierKafka.CreateWriter(config.KafkaConfig{
+			Brokers: []string{"localhost:9092"},
+			SASL: config.KafkaSASLConfig{
+				Mechanism: "gssapi",
+				Username:  "user",
+			},
+		})
+		require.Nil(t, writer)
+		require.True(t, errors.Is(err, notifierKafka.ErrInvalidSASLConfig))
+	})
+
+	t.Run("should set the required acks", func(t *testing.T) {
+		t.Parallel()
+
+		expectedAcks := map[string]kafka.RequiredAcks{
+			"":     kafka.RequireAll,
+			"all":  kafka.RequireAll,
+			"one":  kafka.RequireOne,
+			"none": kafka.RequireNone,
+		}
+		for requiredAcks, expected := range expectedAcks {
+			writer, err := notifierKafka.CreateWriter(config.KafkaConfig{
+				Brokers:      []string{"localhost:9092"},
+				RequiredAcks: requiredAcks,
+			})
+			require.Nil(t, err)
+			require.Equal(t, expected, writer.RequiredAcks)
+		}
+	})
+
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		writer, err := notifierKafka.CreateWriter(config.KafkaConfig{
+			Brokers: []string{"localhost:9092", "localhost:9093"},
+			TLS: config.KafkaTLSConfig{
+				Enabled:            true,
+				InsecureSkipVerify: true,
+			},
+		})
+		require.Nil(t, err)
+		require.Equal(t, "localhost:9092,localhost:9093", writer.Addr.String())
+		require.Empty(t, writer.Topic)
+	})
+}
+
+func TestCreateSASLMechanism(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no mechanism, should return nil", func(t *testing.T) {
+		t.Parallel()
+
+		mechanism, err := notifierKafka.CreateSASLMechanism(config.KafkaSASLConfig{Username: "user"})
+		require.Nil(t, err)
+		require.Nil(t, mechanism)
+	})
+
+	t.Run("empty username, should fail", func(t *testing.T) {
+		t.Parallel()
+
+		mechanism, err := notifierKafka.CreateSASLMechanism(config.KafkaSASLConfig{Mechanism: "plain"})
+		require.Nil(t, mechanism)
+		require.True(t, errors.Is(err, notifierKafka.ErrInvalidSASLConfig))
+	})
+
+	t.Run("password env var not set, should fail", func(t *testing.T) {
+		t.Parallel()
+
+		mechanism, err := notifierKafka.CreateSASLMechanism(config.KafkaSASLConfig{
+			Mechanism:      "plain",
+			Username:       "user",
+			PasswordEnvVar: "NOTIFIER_TEST_KAFKA_MISSING_PASSWORD",
+		})
+		require.Nil(t, mechanism)
+		require.True(t, errors.Is(err, notifierKafka.ErrInvalidSASLConfig))
+	})
+
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		expectedNames := map[string]string{
+			"plain":         "PLAIN",
+			"scram-sha-256": "SCRAM-SHA-256",
+			"scram-sha-512": "SCRAM-SHA-512",
+		}
+		for mechanismType, expectedName := range expectedNames {
+			mechanism, err := notifierKafka.CreateSASLMechanism(config.KafkaSASLConfig{
+				Mechanism: mechanismType,
+				Username:  "user",
+				Password:  "pass",
+			})
+			require.Nil(t, err)
+			require.Equal(t, expectedName, mechanism.Name())
+		}
+	})
+
+	t.Run("password from env var should work", func(t *testing.T) {
+		t.Parallel()
+
+		_ = os.Setenv("NOTIFIER_TEST_KAFKA_PASSWORD", "pass")
+		defer func() {
+			_ = os.Unsetenv("NOTIFIER_TEST_KAFKA_PASSWORD")
+		}()
+
+		mechanism, err := notifierKafka.CreateSASLMechanism(config.KafkaSASLConfig{
+			Mechanism:      "plain",
+			Username:       "user",
+			PasswordEnvVar: "NOTIFIER_TEST_KAFKA_PASSWORD",
+		})
+		require.Nil(t, err)
+		require.Equal(t, "pass", mechanism.(plain.Mechanism).Password)
+	})
+}
@@ -0,0 +1,24 @@
+package kafka
+
+import "errors"
+
+// ErrNilWriter signals that a nil kafka writer has been provided
+var ErrNilWriter = errors.New("nil kafka writer")
+
+// ErrEmptyBrokers signals that no kafka broker address has been provided
+var ErrEmptyBrokers = errors.New("empty kafka brokers")
+
+// ErrInvalidKafkaTopicName signals that an empty kafka topic name has been provided
+var ErrInvalidKafkaTopicName = errors.New("invalid kafka topic name")
+
+// ErrInvalidTLSConfig signals that an invalid kafka TLS config has been provided
+var ErrInvalidTLSConfig = errors.New("invalid kafka TLS config")
+
+// ErrKafkaWriteFailed signals that the last write to the kafka brokers failed
+var ErrKafkaWriteFailed = errors.New("kafka write failed")
+
+// ErrInvalidRequiredAcks signals that an invalid kafka required acks value has been provided
+var ErrInvalidRequiredAcks = errors.New("invalid kafka required acks")
+
+// ErrInvalidSASLConfig signals that an invalid kafka SASL config has been provided
+var ErrInvalidSASLConfig = errors.New("invalid kafka SASL config")
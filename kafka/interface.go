@@ -0,0 +1,13 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// Writer defines the behaviour of a kafka writer able to publish messages on topics
+type Writer interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
@@ -0,0 +1,257 @@
+package kafka
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/marshal"
+	logger "github.com/multiversx/mx-chain-logger-go"
+	"github.com/multiversx/mx-chain-notifier-go/common"
+	"github.com/multiversx/mx-chain-notifier-go/config"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/metrics"
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	publishSuccessPromMetric  = "kafka_publish_success"
+	publishFailuresPromMetric = "kafka_publish_failures"
+	topicPromLabel            = "topic"
+
+	contentTypeHeader = "content-type"
+)
+
+var log = logger.GetOrCreate("kafka")
+
+// ArgsKafkaPublisher defines the arguments needed for kafka publisher creation
+type ArgsKafkaPublisher struct {
+	Writer      Writer
+	Config      config.KafkaConfig
+	Marshaller  marshal.Marshalizer
+	ContentType string
+}
+
+type kafkaPublisher struct {
+	writer      Writer
+	marshaller  marshal.Marshalizer
+	cfg         config.KafkaConfig
+	contentType string
+
+	mutMetrics         sync.RWMutex
+	numPublishSuccess  map[string]uint64
+	numPublishFailures map[string]uint64
+	lastWriteFailed    bool
+}
+
+// NewKafkaPublisher creates a new kafka publisher instance. The block events, revert and
+// finalized events are always published, the other events only if their topic is configured
+func NewKafkaPublisher(args ArgsKafkaPublisher) (*kafkaPublisher, error) {
+	err := checkArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return &kafkaPublisher{
+		writer:             args.Writer,
+		marshaller:         args.Marshaller,
+		cfg:                args.Config,
+		contentType:        args.ContentType,
+		numPublishSuccess:  make(map[string]uint64),
+		numPublishFailures: make(map[string]uint64),
+	}, nil
+}
+
+func checkArgs(args ArgsKafkaPublisher) error {
+	if args.Writer == nil {
+		return ErrNilWriter
+	}
+	if check.IfNil(args.Marshaller) {
+		return common.ErrNilMarshaller
+	}
+	if args.Config.BlockEventsTopic == "" {
+		return ErrInvalidKafkaTopicName
+	}
+	if args.Config.RevertEventsTopic == "" {
+		return ErrInvalidKafkaTopicName
+	}
+	if args.Config.FinalizedEventsTopic == "" {
+		return ErrInvalidKafkaTopicName
+	}
+
+	return nil
+}
+
+// Publish will publish logs and events to the kafka block events topic
+func (kp *kafkaPublisher) Publish(ctx context.Context, events data.BlockEvents) error {
+	eventsBytes, err := kp.marshaller.Marshal(events)
+	if err != nil {
+		log.Error("could not marshal events", "err", err.Error())
+		return err
+	}
+
+	err = kp.publishToTopic(ctx, kp.cfg.BlockEventsTopic, events.Hash, eventsBytes)
+	if err != nil {
+		log.Error("failed to publish events to kafka", "hash", events.Hash, "err", err.Error())
+	}
+
+	return err
+}
+
+// PublishRevert will publish revert event to the kafka revert events topic
+func (kp *kafkaPublisher) PublishRevert(ctx context.Context, revertBlock data.RevertBlock) error {
+	revertBlockBytes, err := kp.marshaller.Marshal(revertBlock)
+	if err != nil {
+		log.Error("could not marshal revert event", "err", err.Error())
+		return err
+	}
+
+	err = kp.publishToTopic(ctx, kp.cfg.RevertEventsTopic, revertBlock.Hash, revertBlockBytes)
+	if err != nil {
+		log.Error("failed to publish revert event to kafka", "hash", revertBlock.Hash, "err", err.Error())
+	}
+
+	return err
+}
+
+// PublishFinalized will publish finalized event to the kafka finalized events topic
+func (kp *kafkaPublisher) PublishFinalized(ctx context.Context, finalizedBlock data.FinalizedBlock) error {
+	finalizedBlockBytes, err := kp.marshaller.Marshal(finalizedBlock)
+	if err != nil {
+		log.Error("could not marshal finalized event", "err", err.Error())
+		return err
+	}
+
+	err = kp.publishToTopic(ctx, kp.cfg.FinalizedEventsTopic, finalizedBlock.Hash, finalizedBlockBytes)
+	if err != nil {
+		log.Error("failed to publish finalized event to kafka", "hash", finalizedBlock.Hash, "err", err.Error())
+	}
+
+	return err
+}
+
+// PublishTxs will publish block txs event to the kafka block txs topic, if configured
+func (kp *kafkaPublisher) PublishTxs(ctx context.Context, blockTxs data.BlockTxs) error {
+	return kp.publishOptional(ctx, kp.cfg.BlockTxsTopic, common.BlockTxs, blockTxs.Hash, blockTxs)
+}
+
+// PublishScrs will publish block scrs event to the kafka block scrs topic, if configured
+func (kp *kafkaPublisher) PublishScrs(ctx context.Context, blockScrs data.BlockScrs) error {
+	return kp.publishOptional(ctx, kp.cfg.BlockScrsTopic, common.BlockScrs, blockScrs.Hash, blockScrs)
+}
+
+// PublishBlockEventsWithOrder will publish full block events to the kafka block events with
+// order topic, if configured
+func (kp *kafkaPublisher) PublishBlockEventsWithOrder(ctx context.Context, blockTxs data.BlockEventsWithOrder) error {
+	return kp.publishOptional(ctx, kp.cfg.BlockEventsWithOrderTopic, common.BlockEvents, blockTxs.Hash, blockTxs)
+}
+
+// PublishTxEvents will publish transaction notifications to the kafka tx events topic, if configured
+func (kp *kafkaPublisher) PublishTxEvents(ctx context.Context, blockTxEvents data.BlockTxEvents) error {
+	return kp.publishOptional(ctx, kp.cfg.TxEventsTopic, common.TxEvents, blockTxEvents.Hash, blockTxEvents)
+}
+
+// PublishRounds does nothing, rounds info is not published on kafka
+func (kp *kafkaPublisher) PublishRounds(_ context.Context, _ data.RoundEvents) error {
+	return nil
+}
+
+// PublishValidatorsRating does nothing, validators rating is not published on kafka
+func (kp *kafkaPublisher) PublishValidatorsRating(_ context.Context, _ data.ValidatorsRatingEvent) error {
+	return nil
+}
+
+// PublishAccounts does nothing, altered accounts are not published on kafka
+func (kp *kafkaPublisher) PublishAccounts(_ context.Context, _ data.AccountsEvents) error {
+	return nil
+}
+
+func (kp *kafkaPublisher) publishOptional(ctx context.Context, topic string, eventType string, hash string, event interface{}) error {
+	if topic == "" {
+		return nil
+	}
+
+	eventBytes, err := kp.marshaller.Marshal(event)
+	if err != nil {
+		log.Error("could not marshal event", "event", eventType, "err", err.Error())
+		return err
+	}
+
+	err = kp.publishToTopic(ctx, topic, hash, eventBytes)
+	if err != nil {
+		log.Error("failed to publish event to kafka", "event", eventType, "hash", hash, "err", err.Error())
+	}
+
+	return err
+}
+
+// publishToTopic writes the payload to the kafka topic, keyed by the block hash. The
+// writer retries internally, so a failed write is not retried again
+func (kp *kafkaPublisher) publishToTopic(ctx context.Context, topic string, hash string, payload []byte) error {
+	message := kafka.Message{
+		Topic: topic,
+		Key:   []byte(hash),
+		Value: payload,
+	}
+	if kp.contentType != "" {
+		message.Headers = []kafka.Header{{Key: contentTypeHeader, Value: []byte(kp.contentType)}}
+	}
+
+	err := kp.writer.WriteMessages(ctx, message)
+
+	kp.mutMetrics.Lock()
+	kp.lastWriteFailed = err != nil
+	if err != nil {
+		kp.numPublishFailures[topic]++
+	} else {
+		kp.numPublishSuccess[topic]++
+	}
+	kp.mutMetrics.Unlock()
+
+	return err
+}
+
+// GetMetricsForPrometheus returns the number of successful and failed publish operations
+// for each topic, in prometheus format
+func (kp *kafkaPublisher) GetMetricsForPrometheus() string {
+	kp.mutMetrics.RLock()
+	defer kp.mutMetrics.RUnlock()
+
+	stringBuilder := strings.Builder{}
+	stringBuilder.WriteString(metrics.CounterMetrics(publishSuccessPromMetric, topicPromLabel, kp.numPublishSuccess))
+	stringBuilder.WriteString(metrics.CounterMetrics(publishFailuresPromMetric, topicPromLabel, kp.numPublishFailures))
+
+	return stringBuilder.String()
+}
+
+// GetHealthState returns down if the last write to the kafka brokers failed
+func (kp *kafkaPublisher) GetHealthState() string {
+	kp.mutMetrics.RLock()
+	defer kp.mutMetrics.RUnlock()
+
+	if kp.lastWriteFailed {
+		return common.HealthStateDown
+	}
+
+	return common.HealthStateUp
+}
+
+// Ping returns an error if the last write to the kafka brokers failed
+func (kp *kafkaPublisher) Ping(_ context.Context) error {
+	if kp.GetHealthState() == common.HealthStateDown {
+		return ErrKafkaWriteFailed
+	}
+
+	return nil
+}
+
+// Close will close the kafka writer, flushing the pending messages
+func (kp *kafkaPublisher) Close() error {
+	return kp.writer.Close()
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (kp *kafkaPublisher) IsInterfaceNil() bool {
+	return kp == nil
+}
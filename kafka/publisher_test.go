@@ -0,0 +1,249 @@
+package kafka_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/marshal"
+	"github.com/multiversx/mx-chain-notifier-go/common"
+	"github.com/multiversx/mx-chain-notifier-go/config"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	notifierKafka "github.com/multiversx/mx-chain-notifier-go/kafka"
+	"github.com/multiversx/mx-chain-notifier-go/mocks"
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/require"
+)
+
+func createMockArgsKafkaPublisher() notifierKafka.ArgsKafkaPublisher {
+	return notifierKafka.ArgsKafkaPublisher{
+		Writer: &mocks.KafkaWriterStub{},
+		Config: config.KafkaConfig{
+			Brokers:              []string{"localhost:9092"},
+			BlockEventsTopic:     "block_events",
+			RevertEventsTopic:    "revert_events",
+			FinalizedEventsTopic: "finalized_events",
+		},
+		Marshaller:  &marshal.JsonMarshalizer{},
+		ContentType: "application/json",
+	}
+}
+
+func TestNewKafkaPublisher(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil writer, should fail", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsKafkaPublisher()
+		args.Writer = nil
+
+		publisher, err := notifierKafka.NewKafkaPublisher(args)
+		require.True(t, check.IfNil(publisher))
+		require.Equal(t, notifierKafka.ErrNilWriter, err)
+	})
+
+	t.Run("nil marshaller, should fail", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsKafkaPublisher()
+		args.Marshaller = nil
+
+		publisher, err := notifierKafka.NewKafkaPublisher(args)
+		require.True(t, check.IfNil(publisher))
+		require.Equal(t, common.ErrNilMarshaller, err)
+	})
+
+	t.Run("empty block events topic, should fail", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsKafkaPublisher()
+		args.Config.BlockEventsTopic = ""
+
+		publisher, err := notifierKafka.NewKafkaPublisher(args)
+		require.True(t, check.IfNil(publisher))
+		require.Equal(t, notifierKafka.ErrInvalidKafkaTopicName, err)
+	})
+
+	t.Run("empty revert events topic, should fail", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsKafkaPublisher()
+		args.Config.RevertEventsTopic = ""
+
+		publisher, err := notifierKafka.NewKafkaPublisher(args)
+		require.True(t, check.IfNil(publisher))
+		require.Equal(t, notifierKafka.ErrInvalidKafkaTopicName, err)
+	})
+
+	t.Run("empty finalized events topic, should fail", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsKafkaPublisher()
+		args.Config.FinalizedEventsTopic = ""
+
+		publisher, err := notifierKafka.NewKafkaPublisher(args)
+		require.True(t, check.IfNil(publisher))
+		require.Equal(t, notifierKafka.ErrInvalidKafkaTopicName, err)
+	})
+
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		publisher, err := notifierKafka.NewKafkaPublisher(createMockArgsKafkaPublisher())
+		require.Nil(t, err)
+		require.False(t, check.IfNil(publisher))
+		require.Equal(t, common.HealthStateUp, publisher.GetHealthState())
+	})
+}
+
+func TestKafkaPublisher_Publish(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should write events to topics, keyed by block hash", func(t *testing.T) {
+		t.Parallel()
+
+		writtenMessages := make([]kafka.Message, 0)
+		args := createMockArgsKafkaPublisher()
+		args.Writer = &mocks.KafkaWriterStub{
+			WriteMessagesCalled: func(msgs ...kafka.Message) error {
+				writtenMessages = append(writtenMessages, msgs...)
+				return nil
+			},
+		}
+
+		publisher, err := notifierKafka.NewKafkaPublisher(args)
+		require.Nil(t, err)
+
+		blockEvents := data.BlockEvents{Hash: "hash1", Events: []data.Event{{Address: "erd1"}}}
+		publisher.Publish(context.Background(), blockEvents)
+		publisher.PublishRevert(context.Background(), data.RevertBlock{Hash: "hash2"})
+		publisher.PublishFinalized(context.Background(), data.FinalizedBlock{Hash: "hash3"})
+
+		require.Len(t, writtenMessages, 3)
+		require.Equal(t, "block_events", writtenMessages[0].Topic)
+		require.Equal(t, []byte("hash1"), writtenMessages[0].Key)
+		require.Equal(t, []kafka.Header{{Key: "content-type", Value: []byte("application/json")}}, writtenMessages[0].Headers)
+		require.Equal(t, "revert_events", writtenMessages[1].Topic)
+		require.Equal(t, []byte("hash2"), writtenMessages[1].Key)
+		require.Equal(t, "finalized_events", writtenMessages[2].Topic)
+		require.Equal(t, []byte("hash3"), writtenMessages[2].Key)
+
+		var publishedEvents data.BlockEvents
+		err = json.Unmarshal(writtenMessages[0].Value, &publishedEvents)
+		require.Nil(t, err)
+		require.Equal(t, blockEvents.Events, publishedEvents.Events)
+
+		require.Contains(t, publisher.GetMetricsForPrometheus(), `kafka_publish_success{topic="block_events"} 1`)
+	})
+
+	t.Run("other events with configured topics should be written, keyed by block hash", func(t *testing.T) {
+		t.Parallel()
+
+		writtenMessages := make([]kafka.Message, 0)
+		args := createMockArgsKafkaPublisher()
+		args.Config.BlockTxsTopic = "block_txs"
+		args.Config.BlockScrsTopic = "block_scrs"
+		args.Config.BlockEventsWithOrderTopic = "block_events_with_order"
+		args.Config.TxEventsTopic = "tx_events"
+		args.Writer = &mocks.KafkaWriterStub{
+			WriteMessagesCalled: func(msgs ...kafka.Message) error {
+				writtenMessages = append(writtenMessages, msgs...)
+				return nil
+			},
+		}
+
+		publisher, err := notifierKafka.NewKafkaPublisher(args)
+		require.Nil(t, err)
+
+		publisher.PublishTxs(context.Background(), data.BlockTxs{Hash: "hash1"})
+		publisher.PublishScrs(context.Background(), data.BlockScrs{Hash: "hash2"})
+		publisher.PublishBlockEventsWithOrder(context.Background(), data.BlockEventsWithOrder{Hash: "hash3"})
+		blockTxEvents := data.BlockTxEvents{Hash: "hash4", TxEvents: []data.TxEvent{{Hash: "txHash1"}}}
+		publisher.PublishTxEvents(context.Background(), blockTxEvents)
+
+		require.Len(t, writtenMessages, 4)
+		require.Equal(t, "block_txs", writtenMessages[0].Topic)
+		require.Equal(t, []byte("hash1"), writtenMessages[0].Key)
+		require.Equal(t, "block_scrs", writtenMessages[1].Topic)
+		require.Equal(t, []byte("hash2"), writtenMessages[1].Key)
+		require.Equal(t, "block_events_with_order", writtenMessages[2].Topic)
+		require.Equal(t, []byte("hash3"), writtenMessages[2].Key)
+		require.Equal(t, "tx_events", writtenMessages[3].Topic)
+		require.Equal(t, []byte("hash4"), writtenMessages[3].Key)
+
+		var publishedTxEvents data.BlockTxEvents
+		err = json.Unmarshal(writtenMessages[3].Value, &publishedTxEvents)
+		require.Nil(t, err)
+		require.Equal(t, blockTxEvents, publishedTxEvents)
+	})
+
+	t.Run("other events without configured topics should not be written", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsKafkaPublisher()
+		args.Writer = &mocks.KafkaWriterStub{
+			WriteMessagesCalled: func(msgs ...kafka.Message) error {
+				require.Fail(t, "should not have been called")
+				return nil
+			},
+		}
+
+		publisher, err := notifierKafka.NewKafkaPublisher(args)
+		require.Nil(t, err)
+
+		publisher.PublishTxs(context.Background(), data.BlockTxs{Hash: "hash1"})
+		publisher.PublishScrs(context.Background(), data.BlockScrs{Hash: "hash1"})
+		publisher.PublishBlockEventsWithOrder(context.Background(), data.BlockEventsWithOrder{Hash: "hash1"})
+		publisher.PublishTxEvents(context.Background(), data.BlockTxEvents{Hash: "hash1"})
+	})
+
+	t.Run("failed write should report the publisher as down until the next successful write", func(t *testing.T) {
+		t.Parallel()
+
+		writeErr := errors.New("brokers not reachable")
+		args := createMockArgsKafkaPublisher()
+		args.Writer = &mocks.KafkaWriterStub{
+			WriteMessagesCalled: func(msgs ...kafka.Message) error {
+				return writeErr
+			},
+		}
+
+		publisher, err := notifierKafka.NewKafkaPublisher(args)
+		require.Nil(t, err)
+
+		publisher.Publish(context.Background(), data.BlockEvents{Hash: "hash1"})
+
+		require.Equal(t, common.HealthStateDown, publisher.GetHealthState())
+		require.Equal(t, notifierKafka.ErrKafkaWriteFailed, publisher.Ping(context.Background()))
+		require.Contains(t, publisher.GetMetricsForPrometheus(), `kafka_publish_failures{topic="block_events"} 1`)
+
+		writeErr = nil
+		publisher.Publish(context.Background(), data.BlockEvents{Hash: "hash2"})
+
+		require.Equal(t, common.HealthStateUp, publisher.GetHealthState())
+		require.Nil(t, publisher.Ping(context.Background()))
+	})
+}
+
+func TestKafkaPublisher_Close(t *testing.T) {
+	t.Parallel()
+
+	wasClosed := false
+	args := createMockArgsKafkaPublisher()
+	args.Writer = &mocks.KafkaWriterStub{
+		CloseCalled: func() error {
+			wasClosed = true
+			return nil
+		},
+	}
+
+	publisher, err := notifierKafka.NewKafkaPublisher(args)
+	require.Nil(t, err)
+
+	err = publisher.Close()
+	require.Nil(t, err)
+	require.True(t, wasClosed)
+}
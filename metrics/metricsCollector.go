@@ -0,0 +1,405 @@
+package metrics
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	eventsBroadcastPromMetric         = "notifier_events_broadcast_total"
+	activeDispatchersPromMetric       = "notifier_active_dispatchers"
+	rabbitMQPublishPromMetric         = "notifier_rabbitmq_publish_total"
+	rabbitMQPublishDurationPromMetric = "notifier_rabbitmq_publish_duration_seconds"
+	rabbitMQBufferedEventsPromMetric  = "notifier_rabbitmq_buffered_events"
+	duplicatedBlocksPromMetric        = "notifier_duplicated_blocks_dropped_total"
+	payloadHandlerDurationPromMetric  = "notifier_payload_handler_duration_seconds"
+	invalidPayloadsPromMetric         = "notifier_invalid_payloads_total"
+	filteredEventsPromMetric          = "notifier_events_filtered_total"
+	versionFallbacksPromMetric        = "notifier_payload_version_fallbacks_total"
+
+	// processedBlocksPromMetric counts the blocks processed from each shard, after their events were handled
+	processedBlocksPromMetric = "notifier_processed_blocks_total"
+	// processedEventsPromMetric counts the log events of the blocks processed from each shard
+	processedEventsPromMetric = "notifier_processed_events_total"
+	// blockProcessingLagPromMetric holds, for each shard, the delay between the timestamp of
+	// the last processed block and the time it was processed, the end to end lag
+	blockProcessingLagPromMetric = "notifier_block_processing_lag_seconds"
+	// payloadHandlerBucketsPromMetric holds the same durations as the payload handler summary,
+	// as a histogram, so that the summary scrapes are kept unchanged
+	payloadHandlerBucketsPromMetric = "notifier_payload_handler_duration_seconds_bucketed"
+
+	statusPromLabel   = "status"
+	exchangePromLabel = "exchange"
+	topicPromLabel    = "topic"
+	reasonPromLabel   = "reason"
+
+	identifierPromLabel = "identifier"
+	shardPromLabel      = "shard"
+	versionPromLabel    = "version"
+)
+
+const (
+	// BroadcastStatusDelivered defines the status of a broadcast delivered to at least one dispatcher
+	BroadcastStatusDelivered = "delivered"
+
+	// BroadcastStatusNoSubscribers defines the status of a broadcast with no matching dispatcher
+	BroadcastStatusNoSubscribers = "no_subscribers"
+
+	// PublishStatusSuccess defines the status of a publish acknowledged by the broker
+	PublishStatusSuccess = "success"
+
+	// PublishStatusFailure defines the status of a publish which failed after all the attempts
+	PublishStatusFailure = "failure"
+
+	// PublishStatusDropped defines the status of an event dropped since the publish buffer was full
+	PublishStatusDropped = "dropped"
+
+	// InvalidPayloadUnknownTopic defines the reason of a payload received with an unknown topic
+	InvalidPayloadUnknownTopic = "unknown_topic"
+
+	// InvalidPayloadUnsupportedVersion defines the reason of a payload received with an unsupported version
+	InvalidPayloadUnsupportedVersion = "unsupported_version"
+)
+
+// durationBuckets holds the upper bounds, in seconds, of the duration histograms buckets
+var durationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+type exchangeStatus struct {
+	exchange string
+	status   string
+}
+
+type durationSummary struct {
+	count uint64
+	sum   time.Duration
+}
+
+// durationHistogram holds the number of observations in each bucket, not cumulated
+type durationHistogram struct {
+	durationSummary
+	bucketCounts []uint64
+}
+
+func newDurationHistogram() *durationHistogram {
+	return &durationHistogram{
+		bucketCounts: make([]uint64, len(durationBuckets)),
+	}
+}
+
+func (dh *durationHistogram) observe(duration time.Duration) {
+	dh.count++
+	dh.sum += duration
+
+	for i, upperBound := range durationBuckets {
+		if duration.Seconds() <= upperBound {
+			dh.bucketCounts[i]++
+			return
+		}
+	}
+}
+
+type metricsCollector struct {
+	mut                       sync.RWMutex
+	numBroadcasts             map[string]uint64
+	numActiveDispatchers      uint64
+	numRabbitMQPublishes      map[exchangeStatus]uint64
+	rabbitMQPublishDuration   map[string]*durationHistogram
+	numRabbitMQBufferedEvents *uint64
+	numDuplicatedBlocks       uint64
+	payloadHandlerDuration    map[string]*durationHistogram
+	numInvalidPayloads        map[string]uint64
+	numFilteredEvents         map[string]uint64
+	numVersionFallbacks       map[string]uint64
+	numProcessedBlocks        map[string]uint64
+	numProcessedEvents        map[string]uint64
+	blockProcessingLag        map[string]float64
+}
+
+// NewMetricsCollector creates a collector for the notifier runtime metrics
+func NewMetricsCollector() *metricsCollector {
+	return &metricsCollector{
+		numBroadcasts:           make(map[string]uint64),
+		numRabbitMQPublishes:    make(map[exchangeStatus]uint64),
+		rabbitMQPublishDuration: make(map[string]*durationHistogram),
+		payloadHandlerDuration:  make(map[string]*durationHistogram),
+		numInvalidPayloads:      make(map[string]uint64),
+		numFilteredEvents:       make(map[string]uint64),
+		numVersionFallbacks:     make(map[string]uint64),
+		numProcessedBlocks:      make(map[string]uint64),
+		numProcessedEvents:      make(map[string]uint64),
+		blockProcessingLag:      make(map[string]float64),
+	}
+}
+
+// AddBroadcast increments the number of hub broadcasts with the provided status
+func (mc *metricsCollector) AddBroadcast(status string) {
+	mc.mut.Lock()
+	mc.numBroadcasts[status]++
+	mc.mut.Unlock()
+}
+
+// SetActiveDispatchers sets the number of dispatchers connected to the hub
+func (mc *metricsCollector) SetActiveDispatchers(numDispatchers uint64) {
+	mc.mut.Lock()
+	mc.numActiveDispatchers = numDispatchers
+	mc.mut.Unlock()
+}
+
+// AddRabbitMQPublish increments the number of rabbitMQ publishes on the exchange, with the provided status
+func (mc *metricsCollector) AddRabbitMQPublish(exchange string, status string) {
+	mc.mut.Lock()
+	mc.numRabbitMQPublishes[exchangeStatus{exchange: exchange, status: status}]++
+	mc.mut.Unlock()
+}
+
+// AddRabbitMQPublishDuration adds the duration of publishing a message on the exchange,
+// including the retries
+func (mc *metricsCollector) AddRabbitMQPublishDuration(exchange string, duration time.Duration) {
+	mc.mut.Lock()
+	defer mc.mut.Unlock()
+
+	histogram, ok := mc.rabbitMQPublishDuration[exchange]
+	if !ok {
+		histogram = newDurationHistogram()
+		mc.rabbitMQPublishDuration[exchange] = histogram
+	}
+
+	histogram.observe(duration)
+}
+
+// SetRabbitMQBufferedEvents sets the number of events buffered while disconnected from rabbitMQ
+func (mc *metricsCollector) SetRabbitMQBufferedEvents(numEvents uint64) {
+	mc.mut.Lock()
+	mc.numRabbitMQBufferedEvents = &numEvents
+	mc.mut.Unlock()
+}
+
+// AddDuplicatedBlock increments the number of blocks resent by the observer and dropped
+func (mc *metricsCollector) AddDuplicatedBlock() {
+	mc.mut.Lock()
+	mc.numDuplicatedBlocks++
+	mc.mut.Unlock()
+}
+
+// AddPayloadHandlerDuration adds the duration of processing a payload with the provided topic
+func (mc *metricsCollector) AddPayloadHandlerDuration(topic string, duration time.Duration) {
+	mc.mut.Lock()
+	defer mc.mut.Unlock()
+
+	histogram, ok := mc.payloadHandlerDuration[topic]
+	if !ok {
+		histogram = newDurationHistogram()
+		mc.payloadHandlerDuration[topic] = histogram
+	}
+
+	histogram.observe(duration)
+}
+
+// AddInvalidPayload increments the number of payloads with an unknown topic or an unsupported
+// version, with the provided reason. They are counted both when dropped and when rejected
+func (mc *metricsCollector) AddInvalidPayload(reason string) {
+	mc.mut.Lock()
+	mc.numInvalidPayloads[reason]++
+	mc.mut.Unlock()
+}
+
+// AddFilteredEvents increments the number of log events with the provided identifier which
+// were dropped by the events blacklist
+func (mc *metricsCollector) AddFilteredEvents(identifier string, numEvents uint64) {
+	mc.mut.Lock()
+	mc.numFilteredEvents[identifier] += numEvents
+	mc.mut.Unlock()
+}
+
+// AddPayloadVersionFallback increments the number of payloads with the provided version, which
+// has no data processor, processed with the highest supported version
+func (mc *metricsCollector) AddPayloadVersionFallback(version uint32) {
+	mc.mut.Lock()
+	mc.numVersionFallbacks[strconv.FormatUint(uint64(version), 10)]++
+	mc.mut.Unlock()
+}
+
+// AddProcessedBlock increments the number of processed blocks of the shard, and the number of
+// processed log events with the events of the block
+func (mc *metricsCollector) AddProcessedBlock(shardID uint32, numEvents uint64) {
+	shard := strconv.FormatUint(uint64(shardID), 10)
+
+	mc.mut.Lock()
+	mc.numProcessedBlocks[shard]++
+	mc.numProcessedEvents[shard] += numEvents
+	mc.mut.Unlock()
+}
+
+// SetBlockProcessingLag sets the delay between the timestamp of the last processed block of
+// the shard and the time it was processed
+func (mc *metricsCollector) SetBlockProcessingLag(shardID uint32, lag time.Duration) {
+	shard := strconv.FormatUint(uint64(shardID), 10)
+
+	mc.mut.Lock()
+	mc.blockProcessingLag[shard] = lag.Seconds()
+	mc.mut.Unlock()
+}
+
+// GetMetricsForPrometheus returns the collected metrics in prometheus format
+func (mc *metricsCollector) GetMetricsForPrometheus() string {
+	mc.mut.RLock()
+	defer mc.mut.RUnlock()
+
+	stringBuilder := strings.Builder{}
+	stringBuilder.WriteString(CounterMetrics(eventsBroadcastPromMetric, statusPromLabel, mc.numBroadcasts))
+	stringBuilder.WriteString(GaugeMetric(activeDispatchersPromMetric, mc.numActiveDispatchers))
+	stringBuilder.WriteString(mc.rabbitMQPublishMetrics())
+	stringBuilder.WriteString(durationHistogramMetrics(rabbitMQPublishDurationPromMetric, exchangePromLabel, mc.rabbitMQPublishDuration))
+	if mc.numRabbitMQBufferedEvents != nil {
+		stringBuilder.WriteString(GaugeMetric(rabbitMQBufferedEventsPromMetric, *mc.numRabbitMQBufferedEvents))
+	}
+	stringBuilder.WriteString(mc.payloadHandlerDurationMetrics())
+	stringBuilder.WriteString(durationHistogramMetrics(payloadHandlerBucketsPromMetric, topicPromLabel, mc.payloadHandlerDuration))
+	if mc.numDuplicatedBlocks > 0 {
+		stringBuilder.WriteString(unlabeledCounterMetric(duplicatedBlocksPromMetric, mc.numDuplicatedBlocks))
+	}
+	stringBuilder.WriteString(CounterMetrics(invalidPayloadsPromMetric, reasonPromLabel, mc.numInvalidPayloads))
+	stringBuilder.WriteString(CounterMetrics(filteredEventsPromMetric, identifierPromLabel, mc.numFilteredEvents))
+	stringBuilder.WriteString(CounterMetrics(versionFallbacksPromMetric, versionPromLabel, mc.numVersionFallbacks))
+	stringBuilder.WriteString(CounterMetrics(processedBlocksPromMetric, shardPromLabel, mc.numProcessedBlocks))
+	stringBuilder.WriteString(CounterMetrics(processedEventsPromMetric, shardPromLabel, mc.numProcessedEvents))
+	stringBuilder.WriteString(gaugeMetrics(blockProcessingLagPromMetric, shardPromLabel, mc.blockProcessingLag))
+
+	return stringBuilder.String()
+}
+
+func (mc *metricsCollector) rabbitMQPublishMetrics() string {
+	if len(mc.numRabbitMQPublishes) == 0 {
+		return ""
+	}
+
+	keys := make([]exchangeStatus, 0, len(mc.numRabbitMQPublishes))
+	for key := range mc.numRabbitMQPublishes {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].exchange != keys[j].exchange {
+			return keys[i].exchange < keys[j].exchange
+		}
+		return keys[i].status < keys[j].status
+	})
+
+	metricFamily := &dto.MetricFamily{
+		Name:   proto.String(rabbitMQPublishPromMetric),
+		Type:   dto.MetricType_COUNTER.Enum(),
+		Metric: make([]*dto.Metric, 0, len(keys)),
+	}
+	for _, key := range keys {
+		metricFamily.Metric = append(metricFamily.Metric, &dto.Metric{
+			Label: []*dto.LabelPair{
+				{
+					Name:  proto.String(exchangePromLabel),
+					Value: proto.String(key.exchange),
+				},
+				{
+					Name:  proto.String(statusPromLabel),
+					Value: proto.String(key.status),
+				},
+			},
+			Counter: &dto.Counter{
+				Value: proto.Float64(float64(mc.numRabbitMQPublishes[key])),
+			},
+		})
+	}
+
+	return promMetricAsString(metricFamily)
+}
+
+// durationHistogramMetrics returns the histograms family, with a metric for each label value
+func durationHistogramMetrics(metricName string, labelName string, histograms map[string]*durationHistogram) string {
+	if len(histograms) == 0 {
+		return ""
+	}
+
+	labelValues := make([]string, 0, len(histograms))
+	for labelValue := range histograms {
+		labelValues = append(labelValues, labelValue)
+	}
+	sort.Strings(labelValues)
+
+	metricFamily := &dto.MetricFamily{
+		Name:   proto.String(metricName),
+		Type:   dto.MetricType_HISTOGRAM.Enum(),
+		Metric: make([]*dto.Metric, 0, len(labelValues)),
+	}
+	for _, labelValue := range labelValues {
+		histogram := histograms[labelValue]
+
+		buckets := make([]*dto.Bucket, 0, len(durationBuckets))
+		cumulativeCount := uint64(0)
+		for i, upperBound := range durationBuckets {
+			cumulativeCount += histogram.bucketCounts[i]
+			buckets = append(buckets, &dto.Bucket{
+				CumulativeCount: proto.Uint64(cumulativeCount),
+				UpperBound:      proto.Float64(upperBound),
+			})
+		}
+
+		metricFamily.Metric = append(metricFamily.Metric, &dto.Metric{
+			Label: []*dto.LabelPair{
+				{
+					Name:  proto.String(labelName),
+					Value: proto.String(labelValue),
+				},
+			},
+			Histogram: &dto.Histogram{
+				SampleCount: proto.Uint64(histogram.count),
+				SampleSum:   proto.Float64(histogram.sum.Seconds()),
+				Bucket:      buckets,
+			},
+		})
+	}
+
+	return promMetricAsString(metricFamily)
+}
+
+func (mc *metricsCollector) payloadHandlerDurationMetrics() string {
+	if len(mc.payloadHandlerDuration) == 0 {
+		return ""
+	}
+
+	topics := make([]string, 0, len(mc.payloadHandlerDuration))
+	for topic := range mc.payloadHandlerDuration {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+
+	metricFamily := &dto.MetricFamily{
+		Name:   proto.String(payloadHandlerDurationPromMetric),
+		Type:   dto.MetricType_SUMMARY.Enum(),
+		Metric: make([]*dto.Metric, 0, len(topics)),
+	}
+	for _, topic := range topics {
+		histogram := mc.payloadHandlerDuration[topic]
+		metricFamily.Metric = append(metricFamily.Metric, &dto.Metric{
+			Label: []*dto.LabelPair{
+				{
+					Name:  proto.String(topicPromLabel),
+					Value: proto.String(topic),
+				},
+			},
+			Summary: &dto.Summary{
+				SampleCount: proto.Uint64(histogram.count),
+				SampleSum:   proto.Float64(histogram.sum.Seconds()),
+			},
+		})
+	}
+
+	return promMetricAsString(metricFamily)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (mc *metricsCollector) IsInterfaceNil() bool {
+	return mc == nil
+}
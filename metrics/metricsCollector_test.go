@@ -0,0 +1,118 @@
+package metrics_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-notifier-go/metrics"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMetricsCollector(t *testing.T) {
+	t.Parallel()
+
+	mc := metrics.NewMetricsCollector()
+	require.False(t, check.IfNil(mc))
+}
+
+func TestMetricsCollector_GetMetricsForPrometheus(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no collected metrics should only return the dispatchers gauge", func(t *testing.T) {
+		t.Parallel()
+
+		mc := metrics.NewMetricsCollector()
+
+		expRes := "# TYPE notifier_active_dispatchers gauge\nnotifier_active_dispatchers 0\n\n"
+		require.Equal(t, expRes, mc.GetMetricsForPrometheus())
+	})
+
+	t.Run("should render the collected metrics", func(t *testing.T) {
+		t.Parallel()
+
+		mc := metrics.NewMetricsCollector()
+
+		mc.AddBroadcast(metrics.BroadcastStatusDelivered)
+		mc.AddBroadcast(metrics.BroadcastStatusDelivered)
+		mc.AddBroadcast(metrics.BroadcastStatusNoSubscribers)
+
+		mc.SetActiveDispatchers(3)
+		mc.SetActiveDispatchers(2)
+
+		mc.AddRabbitMQPublish("revert", metrics.PublishStatusSuccess)
+		mc.AddRabbitMQPublish("allevents", metrics.PublishStatusFailure)
+		mc.AddRabbitMQPublish("allevents", metrics.PublishStatusSuccess)
+		mc.AddRabbitMQPublish("allevents", metrics.PublishStatusSuccess)
+		mc.AddRabbitMQPublish("allevents", metrics.PublishStatusDropped)
+
+		mc.AddRabbitMQPublishDuration("allevents", 20*time.Millisecond)
+		mc.AddRabbitMQPublishDuration("allevents", 300*time.Millisecond)
+		mc.AddRabbitMQPublishDuration("allevents", 20*time.Second)
+
+		mc.SetRabbitMQBufferedEvents(4)
+		mc.SetRabbitMQBufferedEvents(1)
+
+		mc.AddDuplicatedBlock()
+		mc.AddDuplicatedBlock()
+
+		mc.AddPayloadHandlerDuration("SaveBlock", 250*time.Millisecond)
+		mc.AddPayloadHandlerDuration("SaveBlock", 750*time.Millisecond)
+		mc.AddPayloadHandlerDuration("FinalizedBlock", 500*time.Millisecond)
+
+		mc.AddInvalidPayload(metrics.InvalidPayloadUnknownTopic)
+		mc.AddInvalidPayload(metrics.InvalidPayloadUnsupportedVersion)
+		mc.AddInvalidPayload(metrics.InvalidPayloadUnsupportedVersion)
+
+		mc.AddFilteredEvents("writeLog", 3)
+		mc.AddFilteredEvents("writeLog", 2)
+		mc.AddFilteredEvents("completedTxEvent", 1)
+
+		mc.AddPayloadVersionFallback(2)
+		mc.AddPayloadVersionFallback(2)
+
+		mc.AddProcessedBlock(0, 3)
+		mc.AddProcessedBlock(0, 2)
+		mc.AddProcessedBlock(4294967295, 0)
+		mc.SetBlockProcessingLag(0, 4*time.Second)
+		mc.SetBlockProcessingLag(0, 1500*time.Millisecond)
+
+		res := mc.GetMetricsForPrometheus()
+
+		require.Contains(t, res, "notifier_events_broadcast_total{status=\"delivered\"} 2\n")
+		require.Contains(t, res, "notifier_events_broadcast_total{status=\"no_subscribers\"} 1\n")
+		require.Contains(t, res, "notifier_active_dispatchers 2\n")
+		require.Contains(t, res, "notifier_rabbitmq_publish_total{exchange=\"allevents\",status=\"failure\"} 1\n")
+		require.Contains(t, res, "notifier_rabbitmq_publish_total{exchange=\"allevents\",status=\"success\"} 2\n")
+		require.Contains(t, res, "notifier_rabbitmq_publish_total{exchange=\"revert\",status=\"success\"} 1\n")
+		require.Contains(t, res, "notifier_rabbitmq_publish_total{exchange=\"allevents\",status=\"dropped\"} 1\n")
+		require.Contains(t, res, "# TYPE notifier_rabbitmq_publish_duration_seconds histogram\n")
+		require.Contains(t, res, "notifier_rabbitmq_publish_duration_seconds_bucket{exchange=\"allevents\",le=\"0.01\"} 0\n")
+		require.Contains(t, res, "notifier_rabbitmq_publish_duration_seconds_bucket{exchange=\"allevents\",le=\"0.025\"} 1\n")
+		require.Contains(t, res, "notifier_rabbitmq_publish_duration_seconds_bucket{exchange=\"allevents\",le=\"0.5\"} 2\n")
+		require.Contains(t, res, "notifier_rabbitmq_publish_duration_seconds_bucket{exchange=\"allevents\",le=\"10\"} 2\n")
+		require.Contains(t, res, "notifier_rabbitmq_publish_duration_seconds_bucket{exchange=\"allevents\",le=\"+Inf\"} 3\n")
+		require.Contains(t, res, "notifier_rabbitmq_publish_duration_seconds_sum{exchange=\"allevents\"} 20.32\n")
+		require.Contains(t, res, "notifier_rabbitmq_publish_duration_seconds_count{exchange=\"allevents\"} 3\n")
+		require.Contains(t, res, "notifier_rabbitmq_buffered_events 1\n")
+		require.Contains(t, res, "# TYPE notifier_duplicated_blocks_dropped_total counter\nnotifier_duplicated_blocks_dropped_total 2\n")
+		require.Contains(t, res, "notifier_payload_handler_duration_seconds_sum{topic=\"SaveBlock\"} 1\n")
+		require.Contains(t, res, "notifier_payload_handler_duration_seconds_count{topic=\"SaveBlock\"} 2\n")
+		require.Contains(t, res, "notifier_payload_handler_duration_seconds_sum{topic=\"FinalizedBlock\"} 0.5\n")
+		require.Contains(t, res, "notifier_payload_handler_duration_seconds_count{topic=\"FinalizedBlock\"} 1\n")
+		require.Contains(t, res, "notifier_invalid_payloads_total{reason=\"unknown_topic\"} 1\n")
+		require.Contains(t, res, "notifier_invalid_payloads_total{reason=\"unsupported_version\"} 2\n")
+		require.Contains(t, res, "notifier_events_filtered_total{identifier=\"completedTxEvent\"} 1\n")
+		require.Contains(t, res, "notifier_events_filtered_total{identifier=\"writeLog\"} 5\n")
+		require.Contains(t, res, "# TYPE notifier_payload_handler_duration_seconds summary\n")
+		require.Contains(t, res, "# TYPE notifier_payload_handler_duration_seconds_bucketed histogram\n")
+		require.Contains(t, res, "notifier_payload_handler_duration_seconds_bucketed_bucket{topic=\"SaveBlock\",le=\"0.5\"} 1\n")
+		require.Contains(t, res, "notifier_payload_handler_duration_seconds_bucketed_count{topic=\"SaveBlock\"} 2\n")
+		require.Contains(t, res, "notifier_payload_version_fallbacks_total{version=\"2\"} 2\n")
+		require.Contains(t, res, "notifier_processed_blocks_total{shard=\"0\"} 2\n")
+		require.Contains(t, res, "notifier_processed_blocks_total{shard=\"4294967295\"} 1\n")
+		require.Contains(t, res, "notifier_processed_events_total{shard=\"0\"} 5\n")
+		require.Contains(t, res, "notifier_processed_events_total{shard=\"4294967295\"} 0\n")
+		require.Contains(t, res, "# TYPE notifier_block_processing_lag_seconds gauge\nnotifier_block_processing_lag_seconds{shard=\"0\"} 1.5\n")
+	})
+}
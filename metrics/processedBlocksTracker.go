@@ -0,0 +1,56 @@
+package metrics
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/multiversx/mx-chain-notifier-go/data"
+)
+
+type processedBlocksTracker struct {
+	mutBlocks sync.RWMutex
+	blocks    map[uint32]data.ProcessedBlock
+}
+
+// NewProcessedBlocksTracker will return an instance of the processed blocks tracker, which
+// holds the last processed block of each shard
+func NewProcessedBlocksTracker() *processedBlocksTracker {
+	return &processedBlocksTracker{
+		blocks: make(map[uint32]data.ProcessedBlock),
+	}
+}
+
+// SetProcessedBlock records the processed block as the last one of its shard. A block with
+// a lower nonce than the recorded one, delivered out of order, does not replace it
+func (pbt *processedBlocksTracker) SetProcessedBlock(block data.ProcessedBlock) {
+	pbt.mutBlocks.Lock()
+	defer pbt.mutBlocks.Unlock()
+
+	lastBlock, ok := pbt.blocks[block.ShardID]
+	if ok && block.Nonce < lastBlock.Nonce {
+		return
+	}
+
+	pbt.blocks[block.ShardID] = block
+}
+
+// GetProcessedBlocks returns the last processed block of each shard, sorted by shard id
+func (pbt *processedBlocksTracker) GetProcessedBlocks() []data.ProcessedBlock {
+	pbt.mutBlocks.RLock()
+	defer pbt.mutBlocks.RUnlock()
+
+	blocks := make([]data.ProcessedBlock, 0, len(pbt.blocks))
+	for _, block := range pbt.blocks {
+		blocks = append(blocks, block)
+	}
+	sort.Slice(blocks, func(i, j int) bool {
+		return blocks[i].ShardID < blocks[j].ShardID
+	})
+
+	return blocks
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (pbt *processedBlocksTracker) IsInterfaceNil() bool {
+	return pbt == nil
+}
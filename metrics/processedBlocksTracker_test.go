@@ -0,0 +1,84 @@
+package metrics_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/metrics"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProcessedBlocksTracker(t *testing.T) {
+	t.Parallel()
+
+	tracker := metrics.NewProcessedBlocksTracker()
+	require.False(t, check.IfNil(tracker))
+	require.Equal(t, 0, len(tracker.GetProcessedBlocks()))
+}
+
+func TestProcessedBlocksTracker_SetProcessedBlock(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should keep the last block of each shard, sorted by shard", func(t *testing.T) {
+		t.Parallel()
+
+		tracker := metrics.NewProcessedBlocksTracker()
+		tracker.SetProcessedBlock(data.ProcessedBlock{ShardID: 4294967295, Nonce: 5, Hash: "metaHash5"})
+		tracker.SetProcessedBlock(data.ProcessedBlock{ShardID: 1, Nonce: 10, Hash: "hash10"})
+		tracker.SetProcessedBlock(data.ProcessedBlock{ShardID: 0, Nonce: 7, Hash: "hash7"})
+		tracker.SetProcessedBlock(data.ProcessedBlock{ShardID: 1, Nonce: 11, Hash: "hash11"})
+
+		expectedBlocks := []data.ProcessedBlock{
+			{ShardID: 0, Nonce: 7, Hash: "hash7"},
+			{ShardID: 1, Nonce: 11, Hash: "hash11"},
+			{ShardID: 4294967295, Nonce: 5, Hash: "metaHash5"},
+		}
+		require.Equal(t, expectedBlocks, tracker.GetProcessedBlocks())
+	})
+
+	t.Run("block delivered out of order should be ignored", func(t *testing.T) {
+		t.Parallel()
+
+		tracker := metrics.NewProcessedBlocksTracker()
+		tracker.SetProcessedBlock(data.ProcessedBlock{ShardID: 0, Nonce: 11, Hash: "hash11"})
+		tracker.SetProcessedBlock(data.ProcessedBlock{ShardID: 0, Nonce: 10, Hash: "hash10"})
+
+		require.Equal(t, []data.ProcessedBlock{{ShardID: 0, Nonce: 11, Hash: "hash11"}}, tracker.GetProcessedBlocks())
+	})
+
+	t.Run("block with the same nonce should replace the last one", func(t *testing.T) {
+		t.Parallel()
+
+		tracker := metrics.NewProcessedBlocksTracker()
+		tracker.SetProcessedBlock(data.ProcessedBlock{ShardID: 0, Nonce: 10, Hash: "hash10"})
+		tracker.SetProcessedBlock(data.ProcessedBlock{ShardID: 0, Nonce: 10, Hash: "forkHash10"})
+
+		require.Equal(t, []data.ProcessedBlock{{ShardID: 0, Nonce: 10, Hash: "forkHash10"}}, tracker.GetProcessedBlocks())
+	})
+
+	t.Run("concurrent operations should work", func(t *testing.T) {
+		t.Parallel()
+
+		tracker := metrics.NewProcessedBlocksTracker()
+
+		numOperations := 100
+		wg := sync.WaitGroup{}
+		wg.Add(numOperations)
+		for i := 0; i < numOperations; i++ {
+			go func(idx int) {
+				defer wg.Done()
+
+				if idx%2 == 0 {
+					tracker.SetProcessedBlock(data.ProcessedBlock{ShardID: uint32(idx % 3), Nonce: uint64(idx)})
+					return
+				}
+				_ = tracker.GetProcessedBlocks()
+			}(i)
+		}
+		wg.Wait()
+
+		require.Equal(t, 3, len(tracker.GetProcessedBlocks()))
+	})
+}
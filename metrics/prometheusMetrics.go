@@ -2,26 +2,83 @@ package metrics
 
 import (
 	"bytes"
+	"sort"
 
 	dto "github.com/prometheus/client_model/go"
 	"github.com/prometheus/common/expfmt"
 	"google.golang.org/protobuf/proto"
 )
 
+const operationLabel = "operation"
+
 func requestsCounterMetric(metricName, endpoint string, count uint64) string {
+	return CounterMetric(metricName, operationLabel, endpoint, count)
+}
+
+// CounterMetric returns the counter metric, with the provided label, in prometheus text format
+func CounterMetric(metricName, labelName, labelValue string, count uint64) string {
+	return CounterMetrics(metricName, labelName, map[string]uint64{labelValue: count})
+}
+
+// CounterMetrics returns the counter metrics family, with a metric for each label value, in prometheus text format
+func CounterMetrics(metricName, labelName string, counters map[string]uint64) string {
+	if len(counters) == 0 {
+		return ""
+	}
+
+	labelValues := make([]string, 0, len(counters))
+	for labelValue := range counters {
+		labelValues = append(labelValues, labelValue)
+	}
+	sort.Strings(labelValues)
+
+	metricFamily := &dto.MetricFamily{
+		Name:   proto.String(metricName),
+		Type:   dto.MetricType_COUNTER.Enum(),
+		Metric: make([]*dto.Metric, 0, len(labelValues)),
+	}
+	for _, labelValue := range labelValues {
+		metricFamily.Metric = append(metricFamily.Metric, &dto.Metric{
+			Label: []*dto.LabelPair{
+				{
+					Name:  proto.String(labelName),
+					Value: proto.String(labelValue),
+				},
+			},
+			Counter: &dto.Counter{
+				Value: proto.Float64(float64(counters[labelValue])),
+			},
+		})
+	}
+
+	return promMetricAsString(metricFamily)
+}
+
+func unlabeledCounterMetric(metricName string, value uint64) string {
 	metricFamily := &dto.MetricFamily{
 		Name: proto.String(metricName),
 		Type: dto.MetricType_COUNTER.Enum(),
 		Metric: []*dto.Metric{
 			{
-				Label: []*dto.LabelPair{
-					{
-						Name:  proto.String("operation"),
-						Value: proto.String(endpoint),
-					},
-				},
 				Counter: &dto.Counter{
-					Value: proto.Float64(float64(count)),
+					Value: proto.Float64(float64(value)),
+				},
+			},
+		},
+	}
+
+	return promMetricAsString(metricFamily)
+}
+
+// GaugeMetric returns the gauge metric in prometheus text format
+func GaugeMetric(metricName string, value uint64) string {
+	metricFamily := &dto.MetricFamily{
+		Name: proto.String(metricName),
+		Type: dto.MetricType_GAUGE.Enum(),
+		Metric: []*dto.Metric{
+			{
+				Gauge: &dto.Gauge{
+					Value: proto.Float64(float64(value)),
 				},
 			},
 		},
@@ -30,6 +87,40 @@ func requestsCounterMetric(metricName, endpoint string, count uint64) string {
 	return promMetricAsString(metricFamily)
 }
 
+// gaugeMetrics returns the gauge metrics family, with a metric for each label value
+func gaugeMetrics(metricName, labelName string, gauges map[string]float64) string {
+	if len(gauges) == 0 {
+		return ""
+	}
+
+	labelValues := make([]string, 0, len(gauges))
+	for labelValue := range gauges {
+		labelValues = append(labelValues, labelValue)
+	}
+	sort.Strings(labelValues)
+
+	metricFamily := &dto.MetricFamily{
+		Name:   proto.String(metricName),
+		Type:   dto.MetricType_GAUGE.Enum(),
+		Metric: make([]*dto.Metric, 0, len(labelValues)),
+	}
+	for _, labelValue := range labelValues {
+		metricFamily.Metric = append(metricFamily.Metric, &dto.Metric{
+			Label: []*dto.LabelPair{
+				{
+					Name:  proto.String(labelName),
+					Value: proto.String(labelValue),
+				},
+			},
+			Gauge: &dto.Gauge{
+				Value: proto.Float64(gauges[labelValue]),
+			},
+		})
+	}
+
+	return promMetricAsString(metricFamily)
+}
+
 func promMetricAsString(metric *dto.MetricFamily) string {
 	out := bytes.NewBuffer(make([]byte, 0))
 	_, err := expfmt.MetricFamilyToText(out, metric)
@@ -0,0 +1,37 @@
+package metrics_test
+
+import (
+	"testing"
+
+	"github.com/multiversx/mx-chain-notifier-go/metrics"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCounterMetrics(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no counters should return empty string", func(t *testing.T) {
+		t.Parallel()
+
+		require.Empty(t, metrics.CounterMetrics("counter", "label", nil))
+	})
+
+	t.Run("should render a single family with sorted metrics", func(t *testing.T) {
+		t.Parallel()
+
+		res := metrics.CounterMetrics("counter", "label", map[string]uint64{
+			"b": 2,
+			"a": 1,
+		})
+
+		expRes := "# TYPE counter counter\ncounter{label=\"a\"} 1\ncounter{label=\"b\"} 2\n\n"
+		require.Equal(t, expRes, res)
+	})
+}
+
+func TestGaugeMetric(t *testing.T) {
+	t.Parallel()
+
+	res := metrics.GaugeMetric("gauge", 10)
+	require.Equal(t, "# TYPE gauge gauge\ngauge 10\n\n", res)
+}
@@ -0,0 +1,46 @@
+package mocks
+
+import (
+	"context"
+)
+
+// BackplaneStub -
+type BackplaneStub struct {
+	PublishCalled   func(ctx context.Context, payload []byte) error
+	SubscribeCalled func(ctx context.Context, handler func(payload []byte)) error
+	CloseCalled     func() error
+}
+
+// Publish -
+func (bs *BackplaneStub) Publish(ctx context.Context, payload []byte) error {
+	if bs.PublishCalled != nil {
+		return bs.PublishCalled(ctx, payload)
+	}
+
+	return nil
+}
+
+// Subscribe -
+func (bs *BackplaneStub) Subscribe(ctx context.Context, handler func(payload []byte)) error {
+	if bs.SubscribeCalled != nil {
+		return bs.SubscribeCalled(ctx, handler)
+	}
+
+	<-ctx.Done()
+
+	return nil
+}
+
+// Close -
+func (bs *BackplaneStub) Close() error {
+	if bs.CloseCalled != nil {
+		return bs.CloseCalled()
+	}
+
+	return nil
+}
+
+// IsInterfaceNil -
+func (bs *BackplaneStub) IsInterfaceNil() bool {
+	return bs == nil
+}
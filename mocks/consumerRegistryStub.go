@@ -0,0 +1,44 @@
+package mocks
+
+import (
+	"github.com/multiversx/mx-chain-notifier-go/data"
+)
+
+// ConsumerRegistryStub implements ConsumerRegistry interface
+type ConsumerRegistryStub struct {
+	RegisterCalled    func(subscription data.ConsumerSubscription) error
+	UnregisterCalled  func(consumerID string) error
+	MatchEventsCalled func(events []data.Event) ([]string, map[string][]data.Event)
+}
+
+// Register -
+func (crs *ConsumerRegistryStub) Register(subscription data.ConsumerSubscription) error {
+	if crs.RegisterCalled != nil {
+		return crs.RegisterCalled(subscription)
+	}
+
+	return nil
+}
+
+// Unregister -
+func (crs *ConsumerRegistryStub) Unregister(consumerID string) error {
+	if crs.UnregisterCalled != nil {
+		return crs.UnregisterCalled(consumerID)
+	}
+
+	return nil
+}
+
+// MatchEvents -
+func (crs *ConsumerRegistryStub) MatchEvents(events []data.Event) ([]string, map[string][]data.Event) {
+	if crs.MatchEventsCalled != nil {
+		return crs.MatchEventsCalled(events)
+	}
+
+	return nil, nil
+}
+
+// IsInterfaceNil -
+func (crs *ConsumerRegistryStub) IsInterfaceNil() bool {
+	return crs == nil
+}
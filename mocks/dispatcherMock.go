@@ -27,6 +27,11 @@ func (d *DispatcherMock) GetID() uuid.UUID {
 	return d.id
 }
 
+// IsResumable -
+func (d *DispatcherMock) IsResumable() bool {
+	return false
+}
+
 // PushEvents -
 func (d *DispatcherMock) PushEvents(events []data.Event) {
 	d.consumer.Receive(events)
@@ -52,9 +57,34 @@ func (d *DispatcherMock) TxsEvent(event data.BlockTxs) {
 func (d *DispatcherMock) ScrsEvent(event data.BlockScrs) {
 }
 
+// BlockTxEvents -
+func (d *DispatcherMock) BlockTxEvents(event data.BlockTxEvents) {
+}
+
+// RoundEvents -
+func (d *DispatcherMock) RoundEvents(event data.RoundEvents) {
+}
+
+// ValidatorsRatingEvent -
+func (d *DispatcherMock) ValidatorsRatingEvent(event data.ValidatorsRatingEvent) {
+}
+
+// AccountsEvents -
+func (d *DispatcherMock) AccountsEvents(event data.AccountsEvents) {
+}
+
+// ReplayUnavailable -
+func (d *DispatcherMock) ReplayUnavailable(event data.ReplayUnavailable) {
+}
+
+// Close -
+func (d *DispatcherMock) Close() error {
+	return nil
+}
+
 // Subscribe -
-func (d *DispatcherMock) Subscribe(event data.SubscribeEvent) {
-	d.hub.Subscribe(event)
+func (d *DispatcherMock) Subscribe(event data.SubscribeEvent) error {
+	return d.hub.Subscribe(event)
 }
 
 // Register -
@@ -7,13 +7,20 @@ import (
 
 // DispatcherStub implements dispatcher EventDispatcher interface
 type DispatcherStub struct {
-	GetIDCalled          func() uuid.UUID
-	PushEventsCalled     func(events []data.Event)
-	BlockEventsCalled    func(event data.BlockEventsWithOrder)
-	RevertEventCalled    func(event data.RevertBlock)
-	FinalizedEventCalled func(event data.FinalizedBlock)
-	TxsEventCalled       func(event data.BlockTxs)
-	ScrsEventCalled      func(event data.BlockScrs)
+	GetIDCalled                 func() uuid.UUID
+	IsResumableCalled           func() bool
+	PushEventsCalled            func(events []data.Event)
+	BlockEventsCalled           func(event data.BlockEventsWithOrder)
+	RevertEventCalled           func(event data.RevertBlock)
+	FinalizedEventCalled        func(event data.FinalizedBlock)
+	TxsEventCalled              func(event data.BlockTxs)
+	ScrsEventCalled             func(event data.BlockScrs)
+	BlockTxEventsCalled         func(event data.BlockTxEvents)
+	RoundEventsCalled           func(event data.RoundEvents)
+	ValidatorsRatingEventCalled func(event data.ValidatorsRatingEvent)
+	AccountsEventsCalled        func(event data.AccountsEvents)
+	ReplayUnavailableCalled     func(event data.ReplayUnavailable)
+	CloseCalled                 func() error
 }
 
 // GetID -
@@ -25,6 +32,15 @@ func (d *DispatcherStub) GetID() uuid.UUID {
 	return uuid.UUID{}
 }
 
+// IsResumable -
+func (d *DispatcherStub) IsResumable() bool {
+	if d.IsResumableCalled != nil {
+		return d.IsResumableCalled()
+	}
+
+	return false
+}
+
 // PushEvents -
 func (d *DispatcherStub) PushEvents(events []data.Event) {
 	if d.PushEventsCalled != nil {
@@ -66,3 +82,47 @@ func (d *DispatcherStub) ScrsEvent(event data.BlockScrs) {
 		d.ScrsEventCalled(event)
 	}
 }
+
+// BlockTxEvents -
+func (d *DispatcherStub) BlockTxEvents(event data.BlockTxEvents) {
+	if d.BlockTxEventsCalled != nil {
+		d.BlockTxEventsCalled(event)
+	}
+}
+
+// RoundEvents -
+func (d *DispatcherStub) RoundEvents(event data.RoundEvents) {
+	if d.RoundEventsCalled != nil {
+		d.RoundEventsCalled(event)
+	}
+}
+
+// ValidatorsRatingEvent -
+func (d *DispatcherStub) ValidatorsRatingEvent(event data.ValidatorsRatingEvent) {
+	if d.ValidatorsRatingEventCalled != nil {
+		d.ValidatorsRatingEventCalled(event)
+	}
+}
+
+// AccountsEvents -
+func (d *DispatcherStub) AccountsEvents(event data.AccountsEvents) {
+	if d.AccountsEventsCalled != nil {
+		d.AccountsEventsCalled(event)
+	}
+}
+
+// ReplayUnavailable -
+func (d *DispatcherStub) ReplayUnavailable(event data.ReplayUnavailable) {
+	if d.ReplayUnavailableCalled != nil {
+		d.ReplayUnavailableCalled(event)
+	}
+}
+
+// Close -
+func (d *DispatcherStub) Close() error {
+	if d.CloseCalled != nil {
+		return d.CloseCalled()
+	}
+
+	return nil
+}
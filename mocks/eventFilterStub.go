@@ -0,0 +1,22 @@
+package mocks
+
+import "github.com/multiversx/mx-chain-notifier-go/data"
+
+// EventFilterStub -
+type EventFilterStub struct {
+	MatchEventCalled func(subscription data.Subscription, event data.Event) bool
+}
+
+// MatchEvent -
+func (ef *EventFilterStub) MatchEvent(subscription data.Subscription, event data.Event) bool {
+	if ef.MatchEventCalled != nil {
+		return ef.MatchEventCalled(subscription, event)
+	}
+
+	return true
+}
+
+// IsInterfaceNil -
+func (ef *EventFilterStub) IsInterfaceNil() bool {
+	return ef == nil
+}
@@ -0,0 +1,44 @@
+package mocks
+
+import (
+	"github.com/multiversx/mx-chain-notifier-go/data"
+)
+
+// EventStoreStub implements EventStore interface
+type EventStoreStub struct {
+	SaveCalled         func(events data.BlockEvents) error
+	GetFromNonceCalled func(nonce uint64) ([]data.BlockEvents, error)
+	CloseCalled        func() error
+}
+
+// Save -
+func (ess *EventStoreStub) Save(events data.BlockEvents) error {
+	if ess.SaveCalled != nil {
+		return ess.SaveCalled(events)
+	}
+
+	return nil
+}
+
+// GetFromNonce -
+func (ess *EventStoreStub) GetFromNonce(nonce uint64) ([]data.BlockEvents, error) {
+	if ess.GetFromNonceCalled != nil {
+		return ess.GetFromNonceCalled(nonce)
+	}
+
+	return make([]data.BlockEvents, 0), nil
+}
+
+// Close -
+func (ess *EventStoreStub) Close() error {
+	if ess.CloseCalled != nil {
+		return ess.CloseCalled()
+	}
+
+	return nil
+}
+
+// IsInterfaceNil -
+func (ess *EventStoreStub) IsInterfaceNil() bool {
+	return ess == nil
+}
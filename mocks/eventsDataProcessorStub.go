@@ -1,14 +1,21 @@
 package mocks
 
+import (
+	"context"
+)
+
 // EventsDataProcessorStub -
 type EventsDataProcessorStub struct {
-	SaveBlockCalled          func(marshalledData []byte) error
-	RevertIndexedBlockCalled func(marshalledData []byte) error
-	FinalizedBlockCalled     func(marshalledData []byte) error
+	SaveBlockCalled            func(marshalledData []byte) error
+	RevertIndexedBlockCalled   func(marshalledData []byte) error
+	FinalizedBlockCalled       func(marshalledData []byte) error
+	SaveRoundsCalled           func(marshalledData []byte) error
+	SaveValidatorsRatingCalled func(marshalledData []byte) error
+	SaveAccountsCalled         func(marshalledData []byte) error
 }
 
 // SaveBlock -
-func (stub *EventsDataProcessorStub) SaveBlock(marshalledData []byte) error {
+func (stub *EventsDataProcessorStub) SaveBlock(_ context.Context, marshalledData []byte) error {
 	if stub.SaveBlockCalled != nil {
 		return stub.SaveBlockCalled(marshalledData)
 	}
@@ -17,7 +24,7 @@ func (stub *EventsDataProcessorStub) SaveBlock(marshalledData []byte) error {
 }
 
 // RevertIndexedBlock -
-func (stub *EventsDataProcessorStub) RevertIndexedBlock(marshalledData []byte) error {
+func (stub *EventsDataProcessorStub) RevertIndexedBlock(_ context.Context, marshalledData []byte) error {
 	if stub.RevertIndexedBlockCalled != nil {
 		return stub.RevertIndexedBlockCalled(marshalledData)
 	}
@@ -26,7 +33,7 @@ func (stub *EventsDataProcessorStub) RevertIndexedBlock(marshalledData []byte) e
 }
 
 // FinalizedBlock -
-func (stub *EventsDataProcessorStub) FinalizedBlock(marshalledData []byte) error {
+func (stub *EventsDataProcessorStub) FinalizedBlock(_ context.Context, marshalledData []byte) error {
 	if stub.FinalizedBlockCalled != nil {
 		return stub.FinalizedBlockCalled(marshalledData)
 	}
@@ -34,6 +41,33 @@ func (stub *EventsDataProcessorStub) FinalizedBlock(marshalledData []byte) error
 	return nil
 }
 
+// SaveRounds -
+func (stub *EventsDataProcessorStub) SaveRounds(_ context.Context, marshalledData []byte) error {
+	if stub.SaveRoundsCalled != nil {
+		return stub.SaveRoundsCalled(marshalledData)
+	}
+
+	return nil
+}
+
+// SaveValidatorsRating -
+func (stub *EventsDataProcessorStub) SaveValidatorsRating(_ context.Context, marshalledData []byte) error {
+	if stub.SaveValidatorsRatingCalled != nil {
+		return stub.SaveValidatorsRatingCalled(marshalledData)
+	}
+
+	return nil
+}
+
+// SaveAccounts -
+func (stub *EventsDataProcessorStub) SaveAccounts(_ context.Context, marshalledData []byte) error {
+	if stub.SaveAccountsCalled != nil {
+		return stub.SaveAccountsCalled(marshalledData)
+	}
+
+	return nil
+}
+
 // Close -
 func (stub *EventsDataProcessorStub) Close() error {
 	return nil
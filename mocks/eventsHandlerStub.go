@@ -4,9 +4,12 @@ import "github.com/multiversx/mx-chain-notifier-go/data"
 
 // EventsHandlerStub implements EventsHandler interface
 type EventsHandlerStub struct {
-	HandleSaveBlockEventsCalled func(allEvents data.ArgsSaveBlockData) error
-	HandleRevertEventsCalled    func(revertBlock data.RevertBlock)
-	HandleFinalizedEventsCalled func(finalizedBlock data.FinalizedBlock)
+	HandleSaveBlockEventsCalled       func(allEvents data.ArgsSaveBlockData) error
+	HandleRevertEventsCalled          func(revertBlock data.RevertBlock) error
+	HandleFinalizedEventsCalled       func(finalizedBlock data.FinalizedBlock) error
+	HandleRoundEventsCalled           func(roundEvents data.RoundEvents) error
+	HandleValidatorsRatingEventCalled func(validatorsRating data.ValidatorsRatingEvent) error
+	HandleAccountsEventsCalled        func(accountsEvents data.AccountsEvents) error
 }
 
 // HandleSaveBlockEvents -
@@ -19,17 +22,48 @@ func (e *EventsHandlerStub) HandleSaveBlockEvents(events data.ArgsSaveBlockData)
 }
 
 // HandleRevertEvents -
-func (e *EventsHandlerStub) HandleRevertEvents(revertBlock data.RevertBlock) {
+func (e *EventsHandlerStub) HandleRevertEvents(revertBlock data.RevertBlock) error {
 	if e.HandleRevertEventsCalled != nil {
-		e.HandleRevertEventsCalled(revertBlock)
+		return e.HandleRevertEventsCalled(revertBlock)
 	}
+
+	return nil
 }
 
 // HandleFinalizedEvents -
-func (e *EventsHandlerStub) HandleFinalizedEvents(finalizedBlock data.FinalizedBlock) {
+func (e *EventsHandlerStub) HandleFinalizedEvents(finalizedBlock data.FinalizedBlock) error {
 	if e.HandleFinalizedEventsCalled != nil {
-		e.HandleFinalizedEventsCalled(finalizedBlock)
+		return e.HandleFinalizedEventsCalled(finalizedBlock)
+	}
+
+	return nil
+}
+
+// HandleRoundEvents -
+func (e *EventsHandlerStub) HandleRoundEvents(roundEvents data.RoundEvents) error {
+	if e.HandleRoundEventsCalled != nil {
+		return e.HandleRoundEventsCalled(roundEvents)
 	}
+
+	return nil
+}
+
+// HandleValidatorsRatingEvent -
+func (e *EventsHandlerStub) HandleValidatorsRatingEvent(validatorsRating data.ValidatorsRatingEvent) error {
+	if e.HandleValidatorsRatingEventCalled != nil {
+		return e.HandleValidatorsRatingEventCalled(validatorsRating)
+	}
+
+	return nil
+}
+
+// HandleAccountsEvents -
+func (e *EventsHandlerStub) HandleAccountsEvents(accountsEvents data.AccountsEvents) error {
+	if e.HandleAccountsEventsCalled != nil {
+		return e.HandleAccountsEventsCalled(accountsEvents)
+	}
+
+	return nil
 }
 
 // IsInterfaceNil -
@@ -1,20 +1,39 @@
 package mocks
 
 import (
+	"context"
 	"net/http"
 
+	"github.com/google/uuid"
 	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/filters"
 )
 
 // FacadeStub implements FacadeHandler interface
 type FacadeStub struct {
-	HandlePushEventsCalled        func(events data.ArgsSaveBlockData) error
-	HandleRevertEventsCalled      func(events data.RevertBlock)
-	HandleFinalizedEventsCalled   func(events data.FinalizedBlock)
-	ServeCalled                   func(w http.ResponseWriter, r *http.Request)
-	GetConnectorUserAndPassCalled func() (string, string)
-	GetMetricsCalled              func() map[string]*data.EndpointMetricsResponse
-	GetMetricsForPrometheusCalled func() string
+	HandlePushEventsCalled            func(events data.ArgsSaveBlockData) error
+	HandleRevertEventsCalled          func(events data.RevertBlock) error
+	HandleFinalizedEventsCalled       func(events data.FinalizedBlock) error
+	HandleRoundEventsCalled           func(events data.RoundEvents) error
+	HandleValidatorsRatingEventCalled func(event data.ValidatorsRatingEvent) error
+	HandleAccountsEventsCalled        func(event data.AccountsEvents) error
+	ServeCalled                       func(w http.ResponseWriter, r *http.Request)
+	DisconnectDispatcherCalled        func(dispatcherID uuid.UUID) error
+	RegisterWebhookCalled             func(registration data.WebhookRegistration) (uuid.UUID, error)
+	UnregisterWebhookCalled           func(id uuid.UUID) error
+	RegisterConsumerCalled            func(subscription data.ConsumerSubscription) error
+	UnregisterConsumerCalled          func(consumerID string) error
+	GetEventsByNonceRangeCalled       func(fromNonce uint64, toNonce uint64) ([]data.BlockEvents, error)
+	UpdateFilterCalled                func(cfg filters.FilterConfig) error
+	GetSubscriptionStatsCalled        func() data.SubscriptionStatsResponse
+	MatchEventCalled                  func(event data.Event) data.EventMatchResponse
+	GetConnectorUserAndPassCalled     func() (string, string)
+	GetMetricsCalled                  func() map[string]*data.EndpointMetricsResponse
+	GetMetricsForPrometheusCalled     func() string
+	GetProcessedBlocksCalled          func() []data.ProcessedBlock
+	GetPayloadVersionsCalled          func() data.PayloadVersionsResponse
+	GetHealthStatusCalled             func() data.HealthStatusResponse
+	GetReadinessStatusCalled          func(ctx context.Context) data.ReadinessStatusResponse
 }
 
 // HandlePushEvents -
@@ -27,17 +46,48 @@ func (fs *FacadeStub) HandlePushEvents(events data.ArgsSaveBlockData) error {
 }
 
 // HandleRevertEvents -
-func (fs *FacadeStub) HandleRevertEvents(events data.RevertBlock) {
+func (fs *FacadeStub) HandleRevertEvents(events data.RevertBlock) error {
 	if fs.HandleRevertEventsCalled != nil {
-		fs.HandleRevertEventsCalled(events)
+		return fs.HandleRevertEventsCalled(events)
 	}
+
+	return nil
 }
 
 // HandleFinalizedEvents -
-func (fs *FacadeStub) HandleFinalizedEvents(events data.FinalizedBlock) {
+func (fs *FacadeStub) HandleFinalizedEvents(events data.FinalizedBlock) error {
 	if fs.HandleFinalizedEventsCalled != nil {
-		fs.HandleFinalizedEventsCalled(events)
+		return fs.HandleFinalizedEventsCalled(events)
+	}
+
+	return nil
+}
+
+// HandleRoundEvents -
+func (fs *FacadeStub) HandleRoundEvents(events data.RoundEvents) error {
+	if fs.HandleRoundEventsCalled != nil {
+		return fs.HandleRoundEventsCalled(events)
+	}
+
+	return nil
+}
+
+// HandleValidatorsRatingEvent -
+func (fs *FacadeStub) HandleValidatorsRatingEvent(event data.ValidatorsRatingEvent) error {
+	if fs.HandleValidatorsRatingEventCalled != nil {
+		return fs.HandleValidatorsRatingEventCalled(event)
+	}
+
+	return nil
+}
+
+// HandleAccountsEvents -
+func (fs *FacadeStub) HandleAccountsEvents(event data.AccountsEvents) error {
+	if fs.HandleAccountsEventsCalled != nil {
+		return fs.HandleAccountsEventsCalled(event)
 	}
+
+	return nil
 }
 
 // ServeHTTP -
@@ -47,6 +97,87 @@ func (fs *FacadeStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// DisconnectDispatcher -
+func (fs *FacadeStub) DisconnectDispatcher(dispatcherID uuid.UUID) error {
+	if fs.DisconnectDispatcherCalled != nil {
+		return fs.DisconnectDispatcherCalled(dispatcherID)
+	}
+
+	return nil
+}
+
+// GetSubscriptionStats -
+func (fs *FacadeStub) GetSubscriptionStats() data.SubscriptionStatsResponse {
+	if fs.GetSubscriptionStatsCalled != nil {
+		return fs.GetSubscriptionStatsCalled()
+	}
+
+	return data.SubscriptionStatsResponse{}
+}
+
+// MatchEvent -
+func (fs *FacadeStub) MatchEvent(event data.Event) data.EventMatchResponse {
+	if fs.MatchEventCalled != nil {
+		return fs.MatchEventCalled(event)
+	}
+
+	return data.EventMatchResponse{}
+}
+
+// RegisterWebhook -
+func (fs *FacadeStub) RegisterWebhook(registration data.WebhookRegistration) (uuid.UUID, error) {
+	if fs.RegisterWebhookCalled != nil {
+		return fs.RegisterWebhookCalled(registration)
+	}
+
+	return uuid.UUID{}, nil
+}
+
+// UnregisterWebhook -
+func (fs *FacadeStub) UnregisterWebhook(id uuid.UUID) error {
+	if fs.UnregisterWebhookCalled != nil {
+		return fs.UnregisterWebhookCalled(id)
+	}
+
+	return nil
+}
+
+// RegisterConsumer -
+func (fs *FacadeStub) RegisterConsumer(subscription data.ConsumerSubscription) error {
+	if fs.RegisterConsumerCalled != nil {
+		return fs.RegisterConsumerCalled(subscription)
+	}
+
+	return nil
+}
+
+// UnregisterConsumer -
+func (fs *FacadeStub) UnregisterConsumer(consumerID string) error {
+	if fs.UnregisterConsumerCalled != nil {
+		return fs.UnregisterConsumerCalled(consumerID)
+	}
+
+	return nil
+}
+
+// GetEventsByNonceRange -
+func (fs *FacadeStub) GetEventsByNonceRange(fromNonce uint64, toNonce uint64) ([]data.BlockEvents, error) {
+	if fs.GetEventsByNonceRangeCalled != nil {
+		return fs.GetEventsByNonceRangeCalled(fromNonce, toNonce)
+	}
+
+	return make([]data.BlockEvents, 0), nil
+}
+
+// UpdateFilter -
+func (fs *FacadeStub) UpdateFilter(cfg filters.FilterConfig) error {
+	if fs.UpdateFilterCalled != nil {
+		return fs.UpdateFilterCalled(cfg)
+	}
+
+	return nil
+}
+
 // GetConnectorUserAndPass -
 func (fs *FacadeStub) GetConnectorUserAndPass() (string, string) {
 	if fs.GetConnectorUserAndPassCalled != nil {
@@ -74,6 +205,42 @@ func (fs *FacadeStub) GetMetricsForPrometheus() string {
 	return ""
 }
 
+// GetPayloadVersions -
+func (fs *FacadeStub) GetPayloadVersions() data.PayloadVersionsResponse {
+	if fs.GetPayloadVersionsCalled != nil {
+		return fs.GetPayloadVersionsCalled()
+	}
+
+	return data.PayloadVersionsResponse{}
+}
+
+// GetProcessedBlocks -
+func (fs *FacadeStub) GetProcessedBlocks() []data.ProcessedBlock {
+	if fs.GetProcessedBlocksCalled != nil {
+		return fs.GetProcessedBlocksCalled()
+	}
+
+	return nil
+}
+
+// GetHealthStatus -
+func (fs *FacadeStub) GetHealthStatus() data.HealthStatusResponse {
+	if fs.GetHealthStatusCalled != nil {
+		return fs.GetHealthStatusCalled()
+	}
+
+	return data.HealthStatusResponse{}
+}
+
+// GetReadinessStatus -
+func (fs *FacadeStub) GetReadinessStatus(ctx context.Context) data.ReadinessStatusResponse {
+	if fs.GetReadinessStatusCalled != nil {
+		return fs.GetReadinessStatusCalled(ctx)
+	}
+
+	return data.ReadinessStatusResponse{}
+}
+
 // IsInterfaceNil -
 func (fs *FacadeStub) IsInterfaceNil() bool {
 	return fs == nil
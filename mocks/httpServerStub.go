@@ -4,8 +4,9 @@ import "context"
 
 // HTTPServerStub defines a stub that implements HTTPServerHandler interface
 type HTTPServerStub struct {
-	ListenAndServeCalled func() error
-	ShutdownCalled       func(ctx context.Context) error
+	ListenAndServeCalled    func() error
+	ListenAndServeTLSCalled func(certFile, keyFile string) error
+	ShutdownCalled          func(ctx context.Context) error
 }
 
 // ListenAndServe -
@@ -17,6 +18,15 @@ func (hss *HTTPServerStub) ListenAndServe() error {
 	return nil
 }
 
+// ListenAndServeTLS -
+func (hss *HTTPServerStub) ListenAndServeTLS(certFile, keyFile string) error {
+	if hss.ListenAndServeTLSCalled != nil {
+		return hss.ListenAndServeTLSCalled(certFile, keyFile)
+	}
+
+	return nil
+}
+
 // Shutdown -
 func (hss *HTTPServerStub) Shutdown(ctx context.Context) error {
 	if hss.ShutdownCalled != nil {
@@ -1,64 +1,163 @@
 package mocks
 
 import (
+	"context"
+
+	"github.com/google/uuid"
 	"github.com/multiversx/mx-chain-notifier-go/data"
 	"github.com/multiversx/mx-chain-notifier-go/dispatcher"
 )
 
 // HubStub implements Hub interface
 type HubStub struct {
-	PublishCalled                     func(events data.BlockEvents)
-	PublishRevertCalled               func(revertBlock data.RevertBlock)
-	PublishFinalizedCalled            func(finalizedBlock data.FinalizedBlock)
-	PublishTxsCalled                  func(blockTxs data.BlockTxs)
-	PublishScrsCalled                 func(blockScrs data.BlockScrs)
-	PublishBlockEventsWithOrderCalled func(blockTxs data.BlockEventsWithOrder)
+	RunCalled                         func() error
+	PublishCalled                     func(ctx context.Context, events data.BlockEvents) error
+	PublishRevertCalled               func(ctx context.Context, revertBlock data.RevertBlock) error
+	PublishFinalizedCalled            func(ctx context.Context, finalizedBlock data.FinalizedBlock) error
+	PublishTxsCalled                  func(ctx context.Context, blockTxs data.BlockTxs) error
+	PublishScrsCalled                 func(ctx context.Context, blockScrs data.BlockScrs) error
+	PublishBlockEventsWithOrderCalled func(ctx context.Context, blockTxs data.BlockEventsWithOrder) error
+	PublishTxEventsCalled             func(ctx context.Context, blockTxEvents data.BlockTxEvents) error
+	PublishRoundsCalled               func(ctx context.Context, roundEvents data.RoundEvents) error
+	PublishValidatorsRatingCalled     func(ctx context.Context, validatorsRating data.ValidatorsRatingEvent) error
+	PublishAccountsCalled             func(ctx context.Context, accountsEvents data.AccountsEvents) error
+	GetMetricsForPrometheusCalled     func() string
+	GetHealthStateCalled              func() string
+	PingCalled                        func(ctx context.Context) error
 	RegisterEventCalled               func(event dispatcher.EventDispatcher)
 	UnregisterEventCalled             func(event dispatcher.EventDispatcher)
-	SubscribeCalled                   func(event data.SubscribeEvent)
+	SubscribeCalled                   func(event data.SubscribeEvent) error
+	GetSubscriptionsCalled            func(dispatcherID uuid.UUID) []data.Subscription
+	DisconnectDispatcherCalled        func(dispatcherID uuid.UUID) error
+	UpdateFilterCalled                func(filter dispatcher.EventFilter) error
+	GetSubscriptionStatsCalled        func() data.SubscriptionStatsResponse
+	MatchEventCalled                  func(event data.Event) data.EventMatchResponse
 	CloseCalled                       func() error
 }
 
+// Run -
+func (h *HubStub) Run() error {
+	if h.RunCalled != nil {
+		return h.RunCalled()
+	}
+
+	return nil
+}
+
 // Publish -
-func (h *HubStub) Publish(events data.BlockEvents) {
+func (h *HubStub) Publish(ctx context.Context, events data.BlockEvents) error {
 	if h.PublishCalled != nil {
-		h.PublishCalled(events)
+		return h.PublishCalled(ctx, events)
 	}
+
+	return nil
 }
 
 // PublishRevert -
-func (h *HubStub) PublishRevert(revertBlock data.RevertBlock) {
+func (h *HubStub) PublishRevert(ctx context.Context, revertBlock data.RevertBlock) error {
 	if h.PublishRevertCalled != nil {
-		h.PublishRevertCalled(revertBlock)
+		return h.PublishRevertCalled(ctx, revertBlock)
 	}
+
+	return nil
 }
 
 // PublishFinalized -
-func (h *HubStub) PublishFinalized(finalizedBlock data.FinalizedBlock) {
+func (h *HubStub) PublishFinalized(ctx context.Context, finalizedBlock data.FinalizedBlock) error {
 	if h.PublishFinalizedCalled != nil {
-		h.PublishFinalizedCalled(finalizedBlock)
+		return h.PublishFinalizedCalled(ctx, finalizedBlock)
 	}
+
+	return nil
 }
 
 // PublishTxs -
-func (h *HubStub) PublishTxs(blockTxs data.BlockTxs) {
+func (h *HubStub) PublishTxs(ctx context.Context, blockTxs data.BlockTxs) error {
 	if h.PublishTxsCalled != nil {
-		h.PublishTxsCalled(blockTxs)
+		return h.PublishTxsCalled(ctx, blockTxs)
 	}
+
+	return nil
 }
 
 // PublishScrs -
-func (h *HubStub) PublishScrs(blockScrs data.BlockScrs) {
+func (h *HubStub) PublishScrs(ctx context.Context, blockScrs data.BlockScrs) error {
 	if h.PublishScrsCalled != nil {
-		h.PublishScrsCalled(blockScrs)
+		return h.PublishScrsCalled(ctx, blockScrs)
 	}
+
+	return nil
 }
 
 // PublishBlockEventsWithOrder -
-func (h *HubStub) PublishBlockEventsWithOrder(blockTxs data.BlockEventsWithOrder) {
+func (h *HubStub) PublishBlockEventsWithOrder(ctx context.Context, blockTxs data.BlockEventsWithOrder) error {
 	if h.PublishBlockEventsWithOrderCalled != nil {
-		h.PublishBlockEventsWithOrderCalled(blockTxs)
+		return h.PublishBlockEventsWithOrderCalled(ctx, blockTxs)
 	}
+
+	return nil
+}
+
+// PublishTxEvents -
+func (h *HubStub) PublishTxEvents(ctx context.Context, blockTxEvents data.BlockTxEvents) error {
+	if h.PublishTxEventsCalled != nil {
+		return h.PublishTxEventsCalled(ctx, blockTxEvents)
+	}
+
+	return nil
+}
+
+// PublishRounds -
+func (h *HubStub) PublishRounds(ctx context.Context, roundEvents data.RoundEvents) error {
+	if h.PublishRoundsCalled != nil {
+		return h.PublishRoundsCalled(ctx, roundEvents)
+	}
+
+	return nil
+}
+
+// PublishValidatorsRating -
+func (h *HubStub) PublishValidatorsRating(ctx context.Context, validatorsRating data.ValidatorsRatingEvent) error {
+	if h.PublishValidatorsRatingCalled != nil {
+		return h.PublishValidatorsRatingCalled(ctx, validatorsRating)
+	}
+
+	return nil
+}
+
+// PublishAccounts -
+func (h *HubStub) PublishAccounts(ctx context.Context, accountsEvents data.AccountsEvents) error {
+	if h.PublishAccountsCalled != nil {
+		return h.PublishAccountsCalled(ctx, accountsEvents)
+	}
+
+	return nil
+}
+
+// GetMetricsForPrometheus -
+func (h *HubStub) GetMetricsForPrometheus() string {
+	if h.GetMetricsForPrometheusCalled != nil {
+		return h.GetMetricsForPrometheusCalled()
+	}
+
+	return ""
+}
+
+// GetHealthState -
+func (h *HubStub) GetHealthState() string {
+	if h.GetHealthStateCalled != nil {
+		return h.GetHealthStateCalled()
+	}
+
+	return ""
+}
+
+// Ping -
+func (h *HubStub) Ping(ctx context.Context) error {
+	if h.PingCalled != nil {
+		return h.PingCalled(ctx)
+	}
+	return nil
 }
 
 // RegisterEvent -
@@ -76,14 +175,65 @@ func (h *HubStub) UnregisterEvent(event dispatcher.EventDispatcher) {
 }
 
 // Subscribe -
-func (h *HubStub) Subscribe(event data.SubscribeEvent) {
+func (h *HubStub) Subscribe(event data.SubscribeEvent) error {
 	if h.SubscribeCalled != nil {
-		h.SubscribeCalled(event)
+		return h.SubscribeCalled(event)
+	}
+
+	return nil
+}
+
+// GetSubscriptions -
+func (h *HubStub) GetSubscriptions(dispatcherID uuid.UUID) []data.Subscription {
+	if h.GetSubscriptionsCalled != nil {
+		return h.GetSubscriptionsCalled(dispatcherID)
+	}
+
+	return nil
+}
+
+// DisconnectDispatcher -
+func (h *HubStub) DisconnectDispatcher(dispatcherID uuid.UUID) error {
+	if h.DisconnectDispatcherCalled != nil {
+		return h.DisconnectDispatcherCalled(dispatcherID)
+	}
+
+	return nil
+}
+
+// GetSubscriptionStats -
+func (h *HubStub) GetSubscriptionStats() data.SubscriptionStatsResponse {
+	if h.GetSubscriptionStatsCalled != nil {
+		return h.GetSubscriptionStatsCalled()
 	}
+
+	return data.SubscriptionStatsResponse{}
+}
+
+// MatchEvent -
+func (h *HubStub) MatchEvent(event data.Event) data.EventMatchResponse {
+	if h.MatchEventCalled != nil {
+		return h.MatchEventCalled(event)
+	}
+
+	return data.EventMatchResponse{}
+}
+
+// UpdateFilter -
+func (h *HubStub) UpdateFilter(filter dispatcher.EventFilter) error {
+	if h.UpdateFilterCalled != nil {
+		return h.UpdateFilterCalled(filter)
+	}
+
+	return nil
 }
 
 // Close -
 func (h *HubStub) Close() error {
+	if h.CloseCalled != nil {
+		return h.CloseCalled()
+	}
+
 	return nil
 }
 
@@ -0,0 +1,31 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// KafkaWriterStub -
+type KafkaWriterStub struct {
+	WriteMessagesCalled func(msgs ...kafka.Message) error
+	CloseCalled         func() error
+}
+
+// WriteMessages -
+func (kw *KafkaWriterStub) WriteMessages(_ context.Context, msgs ...kafka.Message) error {
+	if kw.WriteMessagesCalled != nil {
+		return kw.WriteMessagesCalled(msgs...)
+	}
+
+	return nil
+}
+
+// Close -
+func (kw *KafkaWriterStub) Close() error {
+	if kw.CloseCalled != nil {
+		return kw.CloseCalled()
+	}
+
+	return nil
+}
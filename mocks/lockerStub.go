@@ -5,6 +5,7 @@ import "context"
 // LockerStub implements LockService interface
 type LockerStub struct {
 	IsEventProcessedCalled func(ctx context.Context, blockHash string) (bool, error)
+	RemoveEventCalled      func(ctx context.Context, blockHash string) error
 	HasConnectionCalled    func(ctx context.Context) bool
 }
 
@@ -17,6 +18,15 @@ func (ls *LockerStub) IsEventProcessed(ctx context.Context, blockHash string) (b
 	return false, nil
 }
 
+// RemoveEvent -
+func (ls *LockerStub) RemoveEvent(ctx context.Context, blockHash string) error {
+	if ls.RemoveEventCalled != nil {
+		return ls.RemoveEventCalled(ctx, blockHash)
+	}
+
+	return nil
+}
+
 // HasConnection -
 func (ls *LockerStub) HasConnection(ctx context.Context) bool {
 	if ls.HasConnectionCalled != nil {
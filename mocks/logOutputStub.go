@@ -0,0 +1,28 @@
+package mocks
+
+import (
+	"bytes"
+	"sync"
+)
+
+// LogOutputStub -
+type LogOutputStub struct {
+	mut    sync.Mutex
+	buffer bytes.Buffer
+}
+
+// Write -
+func (stub *LogOutputStub) Write(p []byte) (int, error) {
+	stub.mut.Lock()
+	defer stub.mut.Unlock()
+
+	return stub.buffer.Write(p)
+}
+
+// String -
+func (stub *LogOutputStub) String() string {
+	stub.mut.Lock()
+	defer stub.mut.Unlock()
+
+	return stub.buffer.String()
+}
@@ -0,0 +1,117 @@
+package mocks
+
+import "time"
+
+// MetricsCollectorStub -
+type MetricsCollectorStub struct {
+	AddBroadcastCalled               func(status string)
+	SetActiveDispatchersCalled       func(numDispatchers uint64)
+	AddRabbitMQPublishCalled         func(exchange string, status string)
+	AddRabbitMQPublishDurationCalled func(exchange string, duration time.Duration)
+	SetRabbitMQBufferedEventsCalled  func(numEvents uint64)
+	AddDuplicatedBlockCalled         func()
+	AddPayloadHandlerDurationCalled  func(topic string, duration time.Duration)
+	AddInvalidPayloadCalled          func(reason string)
+	AddFilteredEventsCalled          func(identifier string, numEvents uint64)
+	AddProcessedBlockCalled          func(shardID uint32, numEvents uint64)
+	AddPayloadVersionFallbackCalled  func(version uint32)
+	SetBlockProcessingLagCalled      func(shardID uint32, lag time.Duration)
+	GetMetricsForPrometheusCalled    func() string
+}
+
+// AddBroadcast -
+func (mcs *MetricsCollectorStub) AddBroadcast(status string) {
+	if mcs.AddBroadcastCalled != nil {
+		mcs.AddBroadcastCalled(status)
+	}
+}
+
+// SetActiveDispatchers -
+func (mcs *MetricsCollectorStub) SetActiveDispatchers(numDispatchers uint64) {
+	if mcs.SetActiveDispatchersCalled != nil {
+		mcs.SetActiveDispatchersCalled(numDispatchers)
+	}
+}
+
+// AddRabbitMQPublish -
+func (mcs *MetricsCollectorStub) AddRabbitMQPublish(exchange string, status string) {
+	if mcs.AddRabbitMQPublishCalled != nil {
+		mcs.AddRabbitMQPublishCalled(exchange, status)
+	}
+}
+
+// AddRabbitMQPublishDuration -
+func (mcs *MetricsCollectorStub) AddRabbitMQPublishDuration(exchange string, duration time.Duration) {
+	if mcs.AddRabbitMQPublishDurationCalled != nil {
+		mcs.AddRabbitMQPublishDurationCalled(exchange, duration)
+	}
+}
+
+// SetRabbitMQBufferedEvents -
+func (mcs *MetricsCollectorStub) SetRabbitMQBufferedEvents(numEvents uint64) {
+	if mcs.SetRabbitMQBufferedEventsCalled != nil {
+		mcs.SetRabbitMQBufferedEventsCalled(numEvents)
+	}
+}
+
+// AddDuplicatedBlock -
+func (mcs *MetricsCollectorStub) AddDuplicatedBlock() {
+	if mcs.AddDuplicatedBlockCalled != nil {
+		mcs.AddDuplicatedBlockCalled()
+	}
+}
+
+// AddPayloadHandlerDuration -
+func (mcs *MetricsCollectorStub) AddPayloadHandlerDuration(topic string, duration time.Duration) {
+	if mcs.AddPayloadHandlerDurationCalled != nil {
+		mcs.AddPayloadHandlerDurationCalled(topic, duration)
+	}
+}
+
+// AddInvalidPayload -
+func (mcs *MetricsCollectorStub) AddInvalidPayload(reason string) {
+	if mcs.AddInvalidPayloadCalled != nil {
+		mcs.AddInvalidPayloadCalled(reason)
+	}
+}
+
+// AddFilteredEvents -
+func (mcs *MetricsCollectorStub) AddFilteredEvents(identifier string, numEvents uint64) {
+	if mcs.AddFilteredEventsCalled != nil {
+		mcs.AddFilteredEventsCalled(identifier, numEvents)
+	}
+}
+
+// AddProcessedBlock -
+func (mcs *MetricsCollectorStub) AddProcessedBlock(shardID uint32, numEvents uint64) {
+	if mcs.AddProcessedBlockCalled != nil {
+		mcs.AddProcessedBlockCalled(shardID, numEvents)
+	}
+}
+
+// AddPayloadVersionFallback -
+func (mcs *MetricsCollectorStub) AddPayloadVersionFallback(version uint32) {
+	if mcs.AddPayloadVersionFallbackCalled != nil {
+		mcs.AddPayloadVersionFallbackCalled(version)
+	}
+}
+
+// SetBlockProcessingLag -
+func (mcs *MetricsCollectorStub) SetBlockProcessingLag(shardID uint32, lag time.Duration) {
+	if mcs.SetBlockProcessingLagCalled != nil {
+		mcs.SetBlockProcessingLagCalled(shardID, lag)
+	}
+}
+
+// GetMetricsForPrometheus -
+func (mcs *MetricsCollectorStub) GetMetricsForPrometheus() string {
+	if mcs.GetMetricsForPrometheusCalled != nil {
+		return mcs.GetMetricsForPrometheusCalled()
+	}
+	return ""
+}
+
+// IsInterfaceNil -
+func (mcs *MetricsCollectorStub) IsInterfaceNil() bool {
+	return mcs == nil
+}
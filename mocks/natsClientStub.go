@@ -0,0 +1,50 @@
+package mocks
+
+import "github.com/nats-io/nats.go"
+
+// NatsClientStub -
+type NatsClientStub struct {
+	PublishCalled             func(msg *nats.Msg) error
+	IsConnectedCalled         func() bool
+	SetReconnectHandlerCalled func(handler func())
+	CloseCalled               func() error
+}
+
+// Publish -
+func (ncs *NatsClientStub) Publish(msg *nats.Msg) error {
+	if ncs.PublishCalled != nil {
+		return ncs.PublishCalled(msg)
+	}
+
+	return nil
+}
+
+// IsConnected -
+func (ncs *NatsClientStub) IsConnected() bool {
+	if ncs.IsConnectedCalled != nil {
+		return ncs.IsConnectedCalled()
+	}
+
+	return true
+}
+
+// SetReconnectHandler -
+func (ncs *NatsClientStub) SetReconnectHandler(handler func()) {
+	if ncs.SetReconnectHandlerCalled != nil {
+		ncs.SetReconnectHandlerCalled(handler)
+	}
+}
+
+// Close -
+func (ncs *NatsClientStub) Close() error {
+	if ncs.CloseCalled != nil {
+		return ncs.CloseCalled()
+	}
+
+	return nil
+}
+
+// IsInterfaceNil -
+func (ncs *NatsClientStub) IsInterfaceNil() bool {
+	return ncs == nil
+}
@@ -2,8 +2,9 @@ package mocks
 
 // PayloadHandlerStub -
 type PayloadHandlerStub struct {
-	ProcessPayloadCalled func(payload []byte, topic string, version uint32) error
-	CloseCalled          func() error
+	ProcessPayloadCalled                  func(payload []byte, topic string, version uint32) error
+	ProcessPayloadWithCorrelationIDCalled func(payload []byte, topic string, version uint32, correlationID string) error
+	CloseCalled                           func() error
 }
 
 // ProcessPayload -
@@ -14,6 +15,14 @@ func (ph *PayloadHandlerStub) ProcessPayload(payload []byte, topic string, versi
 	return nil
 }
 
+// ProcessPayloadWithCorrelationID -
+func (ph *PayloadHandlerStub) ProcessPayloadWithCorrelationID(payload []byte, topic string, version uint32, correlationID string) error {
+	if ph.ProcessPayloadWithCorrelationIDCalled != nil {
+		return ph.ProcessPayloadWithCorrelationIDCalled(payload, topic, version, correlationID)
+	}
+	return nil
+}
+
 // Close -
 func (ph *PayloadHandlerStub) Close() error {
 	if ph.CloseCalled != nil {
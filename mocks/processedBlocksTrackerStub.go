@@ -0,0 +1,30 @@
+package mocks
+
+import "github.com/multiversx/mx-chain-notifier-go/data"
+
+// ProcessedBlocksTrackerStub -
+type ProcessedBlocksTrackerStub struct {
+	SetProcessedBlockCalled  func(block data.ProcessedBlock)
+	GetProcessedBlocksCalled func() []data.ProcessedBlock
+}
+
+// SetProcessedBlock -
+func (s *ProcessedBlocksTrackerStub) SetProcessedBlock(block data.ProcessedBlock) {
+	if s.SetProcessedBlockCalled != nil {
+		s.SetProcessedBlockCalled(block)
+	}
+}
+
+// GetProcessedBlocks -
+func (s *ProcessedBlocksTrackerStub) GetProcessedBlocks() []data.ProcessedBlock {
+	if s.GetProcessedBlocksCalled != nil {
+		return s.GetProcessedBlocksCalled()
+	}
+
+	return nil
+}
+
+// IsInterfaceNil -
+func (s *ProcessedBlocksTrackerStub) IsInterfaceNil() bool {
+	return s == nil
+}
@@ -0,0 +1,54 @@
+package mocks
+
+import (
+	"context"
+)
+
+// PubSubClientStub -
+type PubSubClientStub struct {
+	PublishCalled     func(channel string, payload []byte) error
+	IsConnectedCalled func() bool
+	CloseCalled       func() error
+	SubscribeCalled   func(ctx context.Context, channel string, handler func(payload []byte)) error
+}
+
+// Publish -
+func (ps *PubSubClientStub) Publish(_ context.Context, channel string, payload []byte) error {
+	if ps.PublishCalled != nil {
+		return ps.PublishCalled(channel, payload)
+	}
+
+	return nil
+}
+
+// IsConnected -
+func (ps *PubSubClientStub) IsConnected(_ context.Context) bool {
+	if ps.IsConnectedCalled != nil {
+		return ps.IsConnectedCalled()
+	}
+
+	return false
+}
+
+// Subscribe -
+func (ps *PubSubClientStub) Subscribe(ctx context.Context, channel string, handler func(payload []byte)) error {
+	if ps.SubscribeCalled != nil {
+		return ps.SubscribeCalled(ctx, channel, handler)
+	}
+
+	return nil
+}
+
+// Close -
+func (ps *PubSubClientStub) Close() error {
+	if ps.CloseCalled != nil {
+		return ps.CloseCalled()
+	}
+
+	return nil
+}
+
+// IsInterfaceNil -
+func (ps *PubSubClientStub) IsInterfaceNil() bool {
+	return ps == nil
+}
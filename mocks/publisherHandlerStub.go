@@ -1,57 +1,150 @@
 package mocks
 
-import "github.com/multiversx/mx-chain-notifier-go/data"
+import (
+	"context"
+
+	"github.com/multiversx/mx-chain-notifier-go/data"
+)
 
 // PublisherHandlerStub -
 type PublisherHandlerStub struct {
-	PublishCalled                     func(events data.BlockEvents)
-	PublishRevertCalled               func(revertBlock data.RevertBlock)
-	PublishFinalizedCalled            func(finalizedBlock data.FinalizedBlock)
-	PublishTxsCalled                  func(blockTxs data.BlockTxs)
-	PublishScrsCalled                 func(blockScrs data.BlockScrs)
-	PublishBlockEventsWithOrderCalled func(blockTxs data.BlockEventsWithOrder)
+	PublishCalled                     func(ctx context.Context, events data.BlockEvents) error
+	PublishRevertCalled               func(ctx context.Context, revertBlock data.RevertBlock) error
+	PublishFinalizedCalled            func(ctx context.Context, finalizedBlock data.FinalizedBlock) error
+	PublishTxsCalled                  func(ctx context.Context, blockTxs data.BlockTxs) error
+	PublishScrsCalled                 func(ctx context.Context, blockScrs data.BlockScrs) error
+	PublishBlockEventsWithOrderCalled func(ctx context.Context, blockTxs data.BlockEventsWithOrder) error
+	PublishTxEventsCalled             func(ctx context.Context, blockTxEvents data.BlockTxEvents) error
+	PublishRoundsCalled               func(ctx context.Context, roundEvents data.RoundEvents) error
+	PublishValidatorsRatingCalled     func(ctx context.Context, validatorsRating data.ValidatorsRatingEvent) error
+	PublishAccountsCalled             func(ctx context.Context, accountsEvents data.AccountsEvents) error
+	GetMetricsForPrometheusCalled     func() string
+	GetHealthStateCalled              func() string
+	PingCalled                        func(ctx context.Context) error
+	SetPublishErrorHandlerCalled      func(handler func(err error))
 	CloseCalled                       func() error
 }
 
 // Publish -
-func (p *PublisherHandlerStub) Publish(events data.BlockEvents) {
+func (p *PublisherHandlerStub) Publish(ctx context.Context, events data.BlockEvents) error {
 	if p.PublishCalled != nil {
-		p.PublishCalled(events)
+		return p.PublishCalled(ctx, events)
 	}
+
+	return nil
 }
 
 // PublishRevert -
-func (p *PublisherHandlerStub) PublishRevert(revertBlock data.RevertBlock) {
+func (p *PublisherHandlerStub) PublishRevert(ctx context.Context, revertBlock data.RevertBlock) error {
 	if p.PublishRevertCalled != nil {
-		p.PublishRevertCalled(revertBlock)
+		return p.PublishRevertCalled(ctx, revertBlock)
 	}
+
+	return nil
 }
 
 // PublishFinalized -
-func (p *PublisherHandlerStub) PublishFinalized(finalizedBlock data.FinalizedBlock) {
+func (p *PublisherHandlerStub) PublishFinalized(ctx context.Context, finalizedBlock data.FinalizedBlock) error {
 	if p.PublishFinalizedCalled != nil {
-		p.PublishFinalizedCalled(finalizedBlock)
+		return p.PublishFinalizedCalled(ctx, finalizedBlock)
 	}
+
+	return nil
 }
 
 // PublishTxs -
-func (p *PublisherHandlerStub) PublishTxs(blockTxs data.BlockTxs) {
+func (p *PublisherHandlerStub) PublishTxs(ctx context.Context, blockTxs data.BlockTxs) error {
 	if p.PublishTxsCalled != nil {
-		p.PublishTxsCalled(blockTxs)
+		return p.PublishTxsCalled(ctx, blockTxs)
 	}
+
+	return nil
 }
 
 // PublishScrs -
-func (p *PublisherHandlerStub) PublishScrs(blockScrs data.BlockScrs) {
+func (p *PublisherHandlerStub) PublishScrs(ctx context.Context, blockScrs data.BlockScrs) error {
 	if p.PublishScrsCalled != nil {
-		p.PublishScrsCalled(blockScrs)
+		return p.PublishScrsCalled(ctx, blockScrs)
 	}
+
+	return nil
 }
 
 // PublishBlockEventsWithOrder -
-func (p *PublisherHandlerStub) PublishBlockEventsWithOrder(blockTxs data.BlockEventsWithOrder) {
+func (p *PublisherHandlerStub) PublishBlockEventsWithOrder(ctx context.Context, blockTxs data.BlockEventsWithOrder) error {
 	if p.PublishBlockEventsWithOrderCalled != nil {
-		p.PublishBlockEventsWithOrderCalled(blockTxs)
+		return p.PublishBlockEventsWithOrderCalled(ctx, blockTxs)
+	}
+
+	return nil
+}
+
+// PublishTxEvents -
+func (p *PublisherHandlerStub) PublishTxEvents(ctx context.Context, blockTxEvents data.BlockTxEvents) error {
+	if p.PublishTxEventsCalled != nil {
+		return p.PublishTxEventsCalled(ctx, blockTxEvents)
+	}
+
+	return nil
+}
+
+// PublishRounds -
+func (p *PublisherHandlerStub) PublishRounds(ctx context.Context, roundEvents data.RoundEvents) error {
+	if p.PublishRoundsCalled != nil {
+		return p.PublishRoundsCalled(ctx, roundEvents)
+	}
+
+	return nil
+}
+
+// PublishValidatorsRating -
+func (p *PublisherHandlerStub) PublishValidatorsRating(ctx context.Context, validatorsRating data.ValidatorsRatingEvent) error {
+	if p.PublishValidatorsRatingCalled != nil {
+		return p.PublishValidatorsRatingCalled(ctx, validatorsRating)
+	}
+
+	return nil
+}
+
+// PublishAccounts -
+func (p *PublisherHandlerStub) PublishAccounts(ctx context.Context, accountsEvents data.AccountsEvents) error {
+	if p.PublishAccountsCalled != nil {
+		return p.PublishAccountsCalled(ctx, accountsEvents)
+	}
+
+	return nil
+}
+
+// GetMetricsForPrometheus -
+func (p *PublisherHandlerStub) GetMetricsForPrometheus() string {
+	if p.GetMetricsForPrometheusCalled != nil {
+		return p.GetMetricsForPrometheusCalled()
+	}
+
+	return ""
+}
+
+// GetHealthState -
+func (p *PublisherHandlerStub) GetHealthState() string {
+	if p.GetHealthStateCalled != nil {
+		return p.GetHealthStateCalled()
+	}
+
+	return ""
+}
+
+// Ping -
+func (p *PublisherHandlerStub) Ping(ctx context.Context) error {
+	if p.PingCalled != nil {
+		return p.PingCalled(ctx)
+	}
+	return nil
+}
+
+// SetPublishErrorHandler -
+func (p *PublisherHandlerStub) SetPublishErrorHandler(handler func(err error)) {
+	if p.SetPublishErrorHandlerCalled != nil {
+		p.SetPublishErrorHandlerCalled(handler)
 	}
 }
 
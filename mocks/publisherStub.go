@@ -1,17 +1,39 @@
 package mocks
 
-import "github.com/multiversx/mx-chain-notifier-go/data"
+import (
+	"context"
+
+	"github.com/multiversx/mx-chain-notifier-go/data"
+)
 
 // PublisherStub implements PublisherService interface
 type PublisherStub struct {
-	RunCalled                           func() error
-	BroadcastCalled                     func(events data.BlockEvents)
-	BroadcastRevertCalled               func(event data.RevertBlock)
-	BroadcastFinalizedCalled            func(event data.FinalizedBlock)
-	BroadcastTxsCalled                  func(event data.BlockTxs)
-	BroadcastScrsCalled                 func(event data.BlockScrs)
-	BroadcastBlockEventsWithOrderCalled func(event data.BlockEventsWithOrder)
-	CloseCalled                         func() error
+	RunCalled                                      func() error
+	BroadcastCalled                                func(events data.BlockEvents)
+	BroadcastRevertCalled                          func(event data.RevertBlock)
+	BroadcastFinalizedCalled                       func(event data.FinalizedBlock)
+	BroadcastWithContextCalled                     func(ctx context.Context, events data.BlockEvents) error
+	BroadcastRevertWithContextCalled               func(ctx context.Context, event data.RevertBlock) error
+	BroadcastFinalizedWithContextCalled            func(ctx context.Context, event data.FinalizedBlock) error
+	BroadcastTxsCalled                             func(event data.BlockTxs)
+	BroadcastScrsCalled                            func(event data.BlockScrs)
+	BroadcastBlockEventsWithOrderCalled            func(event data.BlockEventsWithOrder)
+	BroadcastTxEventsCalled                        func(event data.BlockTxEvents)
+	BroadcastRoundsCalled                          func(event data.RoundEvents)
+	BroadcastValidatorsRatingCalled                func(event data.ValidatorsRatingEvent)
+	BroadcastAccountsCalled                        func(event data.AccountsEvents)
+	BroadcastTxsWithContextCalled                  func(ctx context.Context, event data.BlockTxs) error
+	BroadcastScrsWithContextCalled                 func(ctx context.Context, event data.BlockScrs) error
+	BroadcastBlockEventsWithOrderWithContextCalled func(ctx context.Context, event data.BlockEventsWithOrder) error
+	BroadcastTxEventsWithContextCalled             func(ctx context.Context, event data.BlockTxEvents) error
+	BroadcastRoundsWithContextCalled               func(ctx context.Context, event data.RoundEvents) error
+	BroadcastValidatorsRatingWithContextCalled     func(ctx context.Context, event data.ValidatorsRatingEvent) error
+	BroadcastAccountsWithContextCalled             func(ctx context.Context, event data.AccountsEvents) error
+	GetHealthStateCalled                           func() string
+	PingCalled                                     func(ctx context.Context) error
+	GetMetricsForPrometheusCalled                  func() string
+	ErrorsCalled                                   func() <-chan error
+	CloseCalled                                    func() error
 }
 
 // Run -
@@ -44,6 +66,33 @@ func (ps *PublisherStub) BroadcastFinalized(event data.FinalizedBlock) {
 	}
 }
 
+// BroadcastWithContext -
+func (ps *PublisherStub) BroadcastWithContext(ctx context.Context, events data.BlockEvents) error {
+	if ps.BroadcastWithContextCalled != nil {
+		return ps.BroadcastWithContextCalled(ctx, events)
+	}
+
+	return nil
+}
+
+// BroadcastRevertWithContext -
+func (ps *PublisherStub) BroadcastRevertWithContext(ctx context.Context, event data.RevertBlock) error {
+	if ps.BroadcastRevertWithContextCalled != nil {
+		return ps.BroadcastRevertWithContextCalled(ctx, event)
+	}
+
+	return nil
+}
+
+// BroadcastFinalizedWithContext -
+func (ps *PublisherStub) BroadcastFinalizedWithContext(ctx context.Context, event data.FinalizedBlock) error {
+	if ps.BroadcastFinalizedWithContextCalled != nil {
+		return ps.BroadcastFinalizedWithContextCalled(ctx, event)
+	}
+
+	return nil
+}
+
 // BroadcastTxs -
 func (ps *PublisherStub) BroadcastTxs(event data.BlockTxs) {
 	if ps.BroadcastTxsCalled != nil {
@@ -65,6 +114,130 @@ func (ps *PublisherStub) BroadcastBlockEventsWithOrder(event data.BlockEventsWit
 	}
 }
 
+// BroadcastTxEvents -
+func (ps *PublisherStub) BroadcastTxEvents(event data.BlockTxEvents) {
+	if ps.BroadcastTxEventsCalled != nil {
+		ps.BroadcastTxEventsCalled(event)
+	}
+}
+
+// BroadcastRounds -
+func (ps *PublisherStub) BroadcastRounds(event data.RoundEvents) {
+	if ps.BroadcastRoundsCalled != nil {
+		ps.BroadcastRoundsCalled(event)
+	}
+}
+
+// BroadcastValidatorsRating -
+func (ps *PublisherStub) BroadcastValidatorsRating(event data.ValidatorsRatingEvent) {
+	if ps.BroadcastValidatorsRatingCalled != nil {
+		ps.BroadcastValidatorsRatingCalled(event)
+	}
+}
+
+// BroadcastAccounts -
+func (ps *PublisherStub) BroadcastAccounts(event data.AccountsEvents) {
+	if ps.BroadcastAccountsCalled != nil {
+		ps.BroadcastAccountsCalled(event)
+	}
+}
+
+// BroadcastTxsWithContext -
+func (ps *PublisherStub) BroadcastTxsWithContext(ctx context.Context, event data.BlockTxs) error {
+	if ps.BroadcastTxsWithContextCalled != nil {
+		return ps.BroadcastTxsWithContextCalled(ctx, event)
+	}
+
+	return nil
+}
+
+// BroadcastScrsWithContext -
+func (ps *PublisherStub) BroadcastScrsWithContext(ctx context.Context, event data.BlockScrs) error {
+	if ps.BroadcastScrsWithContextCalled != nil {
+		return ps.BroadcastScrsWithContextCalled(ctx, event)
+	}
+
+	return nil
+}
+
+// BroadcastBlockEventsWithOrderWithContext -
+func (ps *PublisherStub) BroadcastBlockEventsWithOrderWithContext(ctx context.Context, event data.BlockEventsWithOrder) error {
+	if ps.BroadcastBlockEventsWithOrderWithContextCalled != nil {
+		return ps.BroadcastBlockEventsWithOrderWithContextCalled(ctx, event)
+	}
+
+	return nil
+}
+
+// BroadcastTxEventsWithContext -
+func (ps *PublisherStub) BroadcastTxEventsWithContext(ctx context.Context, event data.BlockTxEvents) error {
+	if ps.BroadcastTxEventsWithContextCalled != nil {
+		return ps.BroadcastTxEventsWithContextCalled(ctx, event)
+	}
+
+	return nil
+}
+
+// BroadcastRoundsWithContext -
+func (ps *PublisherStub) BroadcastRoundsWithContext(ctx context.Context, event data.RoundEvents) error {
+	if ps.BroadcastRoundsWithContextCalled != nil {
+		return ps.BroadcastRoundsWithContextCalled(ctx, event)
+	}
+
+	return nil
+}
+
+// BroadcastValidatorsRatingWithContext -
+func (ps *PublisherStub) BroadcastValidatorsRatingWithContext(ctx context.Context, event data.ValidatorsRatingEvent) error {
+	if ps.BroadcastValidatorsRatingWithContextCalled != nil {
+		return ps.BroadcastValidatorsRatingWithContextCalled(ctx, event)
+	}
+
+	return nil
+}
+
+// BroadcastAccountsWithContext -
+func (ps *PublisherStub) BroadcastAccountsWithContext(ctx context.Context, event data.AccountsEvents) error {
+	if ps.BroadcastAccountsWithContextCalled != nil {
+		return ps.BroadcastAccountsWithContextCalled(ctx, event)
+	}
+
+	return nil
+}
+
+// GetHealthState -
+func (ps *PublisherStub) GetHealthState() string {
+	if ps.GetHealthStateCalled != nil {
+		return ps.GetHealthStateCalled()
+	}
+
+	return ""
+}
+
+// Ping -
+func (ps *PublisherStub) Ping(ctx context.Context) error {
+	if ps.PingCalled != nil {
+		return ps.PingCalled(ctx)
+	}
+	return nil
+}
+
+// GetMetricsForPrometheus -
+func (ps *PublisherStub) GetMetricsForPrometheus() string {
+	if ps.GetMetricsForPrometheusCalled != nil {
+		return ps.GetMetricsForPrometheusCalled()
+	}
+	return ""
+}
+
+// Errors -
+func (ps *PublisherStub) Errors() <-chan error {
+	if ps.ErrorsCalled != nil {
+		return ps.ErrorsCalled()
+	}
+	return nil
+}
+
 // Close -
 func (ps *PublisherStub) Close() error {
 	if ps.CloseCalled != nil {
@@ -1,6 +1,7 @@
 package mocks
 
 import (
+	"context"
 	"sync"
 
 	"github.com/streadway/amqp"
@@ -8,8 +9,9 @@ import (
 
 // RabbitClientMock -
 type RabbitClientMock struct {
-	mut    sync.RWMutex
-	events map[string]amqp.Publishing
+	mut        sync.RWMutex
+	events     map[string]amqp.Publishing
+	publishErr error
 }
 
 // NewRabbitClientMock -
@@ -20,17 +22,26 @@ func NewRabbitClientMock() *RabbitClientMock {
 }
 
 // Publish -
-func (rc *RabbitClientMock) Publish(exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+func (rc *RabbitClientMock) Publish(_ context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
 	rc.mut.Lock()
 	defer rc.mut.Unlock()
 
+	if rc.publishErr != nil {
+		return rc.publishErr
+	}
+
 	rc.events[exchange] = msg
 
 	return nil
 }
 
 // ExchangeDeclare -
-func (rc *RabbitClientMock) ExchangeDeclare(name, kind string) error {
+func (rc *RabbitClientMock) ExchangeDeclare(name, kind string, durable bool) error {
+	return nil
+}
+
+// ExchangeDeclarePassive -
+func (rc *RabbitClientMock) ExchangeDeclarePassive(name, kind string, durable bool) error {
 	return nil
 }
 
@@ -57,7 +68,33 @@ func (rc *RabbitClientMock) GetEntries() map[string]amqp.Publishing {
 	rc.mut.RLock()
 	defer rc.mut.RUnlock()
 
-	return rc.events
+	events := make(map[string]amqp.Publishing, len(rc.events))
+	for exchange, msg := range rc.events {
+		events[exchange] = msg
+	}
+
+	return events
+}
+
+// SetPublishError -
+func (rc *RabbitClientMock) SetPublishError(err error) {
+	rc.mut.Lock()
+	defer rc.mut.Unlock()
+
+	rc.publishErr = err
+}
+
+// Reset -
+func (rc *RabbitClientMock) Reset() {
+	rc.mut.Lock()
+	defer rc.mut.Unlock()
+
+	rc.events = make(map[string]amqp.Publishing)
+}
+
+// IsConnected -
+func (rc *RabbitClientMock) IsConnected() bool {
+	return true
 }
 
 // Close -
@@ -1,30 +1,44 @@
 package mocks
 
-import "github.com/streadway/amqp"
+import (
+	"context"
+
+	"github.com/streadway/amqp"
+)
 
 // RabbitClientStub -
 type RabbitClientStub struct {
-	PublishCalled         func(exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error
-	ExchangeDeclareCalled func(name, kind string) error
-	ConnErrChanCalled     func() chan *amqp.Error
-	CloseErrChanCalled    func() chan *amqp.Error
-	ReconnectCalled       func()
-	ReopenChannelCalled   func()
-	CloseCalled           func()
+	PublishCalled                func(ctx context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error
+	ExchangeDeclareCalled        func(name, kind string, durable bool) error
+	ExchangeDeclarePassiveCalled func(name, kind string, durable bool) error
+	ConnErrChanCalled            func() chan *amqp.Error
+	CloseErrChanCalled           func() chan *amqp.Error
+	ReconnectCalled              func()
+	ReopenChannelCalled          func()
+	IsConnectedCalled            func() bool
+	CloseCalled                  func()
 }
 
 // Publish -
-func (rc *RabbitClientStub) Publish(exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+func (rc *RabbitClientStub) Publish(ctx context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
 	if rc.PublishCalled != nil {
-		return rc.PublishCalled(exchange, key, mandatory, immediate, msg)
+		return rc.PublishCalled(ctx, exchange, key, mandatory, immediate, msg)
 	}
 	return nil
 }
 
 // ExchangeDeclare -
-func (rc *RabbitClientStub) ExchangeDeclare(name, kind string) error {
+func (rc *RabbitClientStub) ExchangeDeclare(name, kind string, durable bool) error {
 	if rc.ExchangeDeclareCalled != nil {
-		return rc.ExchangeDeclareCalled(name, kind)
+		return rc.ExchangeDeclareCalled(name, kind, durable)
+	}
+	return nil
+}
+
+// ExchangeDeclarePassive -
+func (rc *RabbitClientStub) ExchangeDeclarePassive(name, kind string, durable bool) error {
+	if rc.ExchangeDeclarePassiveCalled != nil {
+		return rc.ExchangeDeclarePassiveCalled(name, kind, durable)
 	}
 	return nil
 }
@@ -59,6 +73,14 @@ func (rc *RabbitClientStub) ReopenChannel() {
 	}
 }
 
+// IsConnected -
+func (rc *RabbitClientStub) IsConnected() bool {
+	if rc.IsConnectedCalled != nil {
+		return rc.IsConnectedCalled()
+	}
+	return true
+}
+
 // Close -
 func (rc *RabbitClientStub) Close() {
 	if rc.CloseCalled != nil {
@@ -8,6 +8,7 @@ import (
 // RedisClientStub -
 type RedisClientStub struct {
 	SetEntryCalled    func(key string, value bool, ttl time.Duration) (bool, error)
+	RemoveEntryCalled func(key string) error
 	PingCalled        func() (string, error)
 	IsConnectedCalled func() bool
 }
@@ -21,6 +22,15 @@ func (rc *RedisClientStub) SetEntry(_ context.Context, key string, value bool, t
 	return false, nil
 }
 
+// RemoveEntry -
+func (rc *RedisClientStub) RemoveEntry(_ context.Context, key string) error {
+	if rc.RemoveEntryCalled != nil {
+		return rc.RemoveEntryCalled(key)
+	}
+
+	return nil
+}
+
 // Ping -
 func (rc *RedisClientStub) Ping(_ context.Context) (string, error) {
 	if rc.PingCalled != nil {
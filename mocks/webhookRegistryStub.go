@@ -0,0 +1,35 @@
+package mocks
+
+import (
+	"github.com/google/uuid"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+)
+
+// WebhookRegistryStub implements WebhookRegistry interface
+type WebhookRegistryStub struct {
+	RegisterCalled   func(registration data.WebhookRegistration) (uuid.UUID, error)
+	UnregisterCalled func(id uuid.UUID) error
+}
+
+// Register -
+func (wrs *WebhookRegistryStub) Register(registration data.WebhookRegistration) (uuid.UUID, error) {
+	if wrs.RegisterCalled != nil {
+		return wrs.RegisterCalled(registration)
+	}
+
+	return uuid.UUID{}, nil
+}
+
+// Unregister -
+func (wrs *WebhookRegistryStub) Unregister(id uuid.UUID) error {
+	if wrs.UnregisterCalled != nil {
+		return wrs.UnregisterCalled(id)
+	}
+
+	return nil
+}
+
+// IsInterfaceNil -
+func (wrs *WebhookRegistryStub) IsInterfaceNil() bool {
+	return wrs == nil
+}
@@ -14,6 +14,7 @@ type WSConnStub struct {
 	SetReadLimitCalled     func(limit int64)
 	SetReadDeadlineCalled  func(t time.Time) error
 	SetPongHandlerCalled   func(h func(appData string) error)
+	WriteControlCalled     func(messageType int, data []byte, deadline time.Time) error
 	CloseCalled            func() error
 }
 
@@ -76,6 +77,15 @@ func (w *WSConnStub) SetPongHandler(h func(appData string) error) {
 	}
 }
 
+// WriteControl -
+func (w *WSConnStub) WriteControl(messageType int, data []byte, deadline time.Time) error {
+	if w.WriteControlCalled != nil {
+		return w.WriteControlCalled(messageType, data, deadline)
+	}
+
+	return nil
+}
+
 // Close -
 func (w *WSConnStub) Close() error {
 	if w.CloseCalled != nil {
@@ -0,0 +1,180 @@
+package nats
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/multiversx/mx-chain-notifier-go/config"
+	"github.com/nats-io/nats.go"
+)
+
+const (
+	clientName = "mx-chain-notifier"
+
+	// reconnect forever, the events published while disconnected are buffered by the
+	// publisher and resent after the connection is recovered
+	infiniteReconnects = -1
+)
+
+type natsClient struct {
+	conn      *nats.Conn
+	jetStream nats.JetStreamContext
+
+	mutReconnectHandler sync.RWMutex
+	reconnectHandler    func()
+}
+
+// CreateClient connects to the configured NATS server. If JetStream is enabled, the
+// messages are published on JetStream and each publish waits for the stream acknowledgement
+func CreateClient(cfg config.NATSConfig) (*natsClient, error) {
+	if cfg.URL == "" {
+		return nil, ErrEmptyURL
+	}
+	if cfg.UseJetStream && cfg.CreateStream && cfg.StreamName == "" {
+		return nil, ErrEmptyStreamName
+	}
+
+	client := &natsClient{}
+	options := []nats.Option{
+		nats.Name(clientName),
+		nats.MaxReconnects(infiniteReconnects),
+		nats.DisconnectErrHandler(func(_ *nats.Conn, err error) {
+			log.Warn("disconnected from NATS server", "err", err)
+		}),
+		nats.ReconnectHandler(func(conn *nats.Conn) {
+			log.Info("reconnected to NATS server", "url", conn.ConnectedUrl())
+			client.notifyReconnect()
+		}),
+	}
+	if cfg.CredsFile != "" {
+		options = append(options, nats.UserCredentials(cfg.CredsFile))
+	}
+
+	conn, err := nats.Connect(cfg.URL, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.conn = conn
+	if !cfg.UseJetStream {
+		return client, nil
+	}
+
+	client.jetStream, err = conn.JetStream()
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	if cfg.CreateStream {
+		err = ensureStream(client.jetStream, cfg)
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	return client, nil
+}
+
+// ensureStream creates the configured stream with the publisher subjects. If the stream
+// already exists, the missing subjects are added to it
+func ensureStream(jetStream nats.JetStreamContext, cfg config.NATSConfig) error {
+	subjects := []string{cfg.BlockEventsSubject, cfg.RevertEventsSubject, cfg.FinalizedEventsSubject}
+
+	streamInfo, err := jetStream.StreamInfo(cfg.StreamName)
+	if errors.Is(err, nats.ErrStreamNotFound) {
+		_, err = jetStream.AddStream(&nats.StreamConfig{
+			Name:     cfg.StreamName,
+			Subjects: subjects,
+		})
+		if err == nil {
+			log.Info("created JetStream stream", "stream", cfg.StreamName, "subjects", subjects)
+		}
+
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	streamConfig := streamInfo.Config
+	missingSubjects := getMissingSubjects(streamConfig.Subjects, subjects)
+	if len(missingSubjects) == 0 {
+		return nil
+	}
+
+	streamConfig.Subjects = append(streamConfig.Subjects, missingSubjects...)
+	_, err = jetStream.UpdateStream(&streamConfig)
+	if err == nil {
+		log.Info("added subjects to JetStream stream", "stream", cfg.StreamName, "subjects", missingSubjects)
+	}
+
+	return err
+}
+
+func getMissingSubjects(streamSubjects []string, subjects []string) []string {
+	existingSubjects := make(map[string]struct{}, len(streamSubjects))
+	for _, subject := range streamSubjects {
+		existingSubjects[subject] = struct{}{}
+	}
+
+	missingSubjects := make([]string, 0)
+	for _, subject := range subjects {
+		if _, ok := existingSubjects[subject]; !ok {
+			missingSubjects = append(missingSubjects, subject)
+			existingSubjects[subject] = struct{}{}
+		}
+	}
+
+	return missingSubjects
+}
+
+// Publish publishes the message on core NATS, or on JetStream if enabled
+func (nc *natsClient) Publish(msg *nats.Msg) error {
+	if nc.jetStream == nil {
+		return nc.conn.PublishMsg(msg)
+	}
+
+	_, err := nc.jetStream.PublishMsg(msg)
+	return err
+}
+
+// SetReconnectHandler sets the handler called each time the connection to the NATS
+// server is recovered
+func (nc *natsClient) SetReconnectHandler(handler func()) {
+	nc.mutReconnectHandler.Lock()
+	nc.reconnectHandler = handler
+	nc.mutReconnectHandler.Unlock()
+}
+
+func (nc *natsClient) notifyReconnect() {
+	nc.mutReconnectHandler.RLock()
+	handler := nc.reconnectHandler
+	nc.mutReconnectHandler.RUnlock()
+
+	if handler != nil {
+		handler()
+	}
+}
+
+// IsConnected returns true if the client is connected to the NATS server
+func (nc *natsClient) IsConnected() bool {
+	return nc.conn.IsConnected()
+}
+
+// Close flushes the pending messages and closes the connection
+func (nc *natsClient) Close() error {
+	defer nc.conn.Close()
+
+	if !nc.conn.IsConnected() {
+		return nil
+	}
+
+	return nc.conn.Flush()
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (nc *natsClient) IsInterfaceNil() bool {
+	return nc == nil
+}
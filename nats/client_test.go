@@ -0,0 +1,318 @@
+package nats_test
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-notifier-go/config"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	notifierNats "github.com/multiversx/mx-chain-notifier-go/nats"
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/require"
+)
+
+const receiveTimeout = 5 * time.Second
+
+func runEmbeddedServer(t *testing.T) *server.Server {
+	opts := &server.Options{
+		Host:      "127.0.0.1",
+		Port:      -1,
+		JetStream: true,
+		StoreDir:  t.TempDir(),
+		NoLog:     true,
+		NoSigs:    true,
+	}
+
+	natsServer, err := server.NewServer(opts)
+	require.Nil(t, err)
+
+	go natsServer.Start()
+	require.True(t, natsServer.ReadyForConnections(receiveTimeout))
+	t.Cleanup(natsServer.Shutdown)
+
+	return natsServer
+}
+
+func createNatsConfig(url string) config.NATSConfig {
+	return config.NATSConfig{
+		URL:                    url,
+		BlockEventsSubject:     "notifier.block_events",
+		RevertEventsSubject:    "notifier.revert_events",
+		FinalizedEventsSubject: "notifier.finalized_events",
+		PublishMaxAttempts:     1,
+		MaxBufferedEvents:      10,
+	}
+}
+
+func TestCreateClient(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty url, should fail", func(t *testing.T) {
+		t.Parallel()
+
+		client, err := notifierNats.CreateClient(config.NATSConfig{})
+		require.True(t, check.IfNil(client))
+		require.Equal(t, notifierNats.ErrEmptyURL, err)
+	})
+
+	t.Run("missing creds file, should fail", func(t *testing.T) {
+		t.Parallel()
+
+		natsServer := runEmbeddedServer(t)
+
+		cfg := createNatsConfig(natsServer.ClientURL())
+		cfg.CredsFile = "missing.creds"
+
+		client, err := notifierNats.CreateClient(cfg)
+		require.True(t, check.IfNil(client))
+		require.NotNil(t, err)
+	})
+
+	t.Run("create stream without stream name, should fail", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := createNatsConfig("nats://localhost:4222")
+		cfg.UseJetStream = true
+		cfg.CreateStream = true
+
+		client, err := notifierNats.CreateClient(cfg)
+		require.True(t, check.IfNil(client))
+		require.Equal(t, notifierNats.ErrEmptyStreamName, err)
+	})
+
+	t.Run("core NATS, subscribers should receive the events", func(t *testing.T) {
+		t.Parallel()
+
+		natsServer := runEmbeddedServer(t)
+		cfg := createNatsConfig(natsServer.ClientURL())
+
+		subscriber, err := nats.Connect(natsServer.ClientURL())
+		require.Nil(t, err)
+		defer subscriber.Close()
+
+		subscription, err := subscriber.SubscribeSync("notifier.>")
+		require.Nil(t, err)
+		require.Nil(t, subscriber.Flush())
+
+		client, err := notifierNats.CreateClient(cfg)
+		require.Nil(t, err)
+		require.True(t, client.IsConnected())
+
+		publisher, err := notifierNats.NewNatsPublisher(createArgsWithClient(cfg, client))
+		require.Nil(t, err)
+
+		publisher.Publish(context.Background(), data.BlockEvents{Hash: "hash1"})
+		publisher.PublishRevert(context.Background(), data.RevertBlock{Hash: "hash2"})
+
+		msg, err := subscription.NextMsg(receiveTimeout)
+		require.Nil(t, err)
+		require.Equal(t, "notifier.block_events", msg.Subject)
+		require.Equal(t, "hash1", msg.Header.Get("Block-Hash"))
+
+		msg, err = subscription.NextMsg(receiveTimeout)
+		require.Nil(t, err)
+		require.Equal(t, "notifier.revert_events", msg.Subject)
+		require.Equal(t, "hash2", msg.Header.Get("Block-Hash"))
+
+		require.Nil(t, publisher.Close())
+		require.False(t, client.IsConnected())
+	})
+
+	t.Run("JetStream, events should be stored on the stream", func(t *testing.T) {
+		t.Parallel()
+
+		natsServer := runEmbeddedServer(t)
+		cfg := createNatsConfig(natsServer.ClientURL())
+		cfg.UseJetStream = true
+
+		admin, err := nats.Connect(natsServer.ClientURL())
+		require.Nil(t, err)
+		defer admin.Close()
+
+		jetStream, err := admin.JetStream()
+		require.Nil(t, err)
+		_, err = jetStream.AddStream(&nats.StreamConfig{
+			Name:     "notifier",
+			Subjects: []string{"notifier.>"},
+		})
+		require.Nil(t, err)
+
+		client, err := notifierNats.CreateClient(cfg)
+		require.Nil(t, err)
+
+		publisher, err := notifierNats.NewNatsPublisher(createArgsWithClient(cfg, client))
+		require.Nil(t, err)
+		defer func() {
+			_ = publisher.Close()
+		}()
+
+		publisher.Publish(context.Background(), data.BlockEvents{Hash: "hash1"})
+		publisher.PublishFinalized(context.Background(), data.FinalizedBlock{Hash: "hash2"})
+
+		streamInfo, err := jetStream.StreamInfo("notifier")
+		require.Nil(t, err)
+		require.Equal(t, uint64(2), streamInfo.State.Msgs)
+		require.Contains(t, publisher.GetMetricsForPrometheus(), `nats_publish_success{subject="notifier.finalized_events"} 1`)
+	})
+
+	t.Run("JetStream without stream, publish should fail", func(t *testing.T) {
+		t.Parallel()
+
+		natsServer := runEmbeddedServer(t)
+		cfg := createNatsConfig(natsServer.ClientURL())
+		cfg.UseJetStream = true
+
+		client, err := notifierNats.CreateClient(cfg)
+		require.Nil(t, err)
+
+		publisher, err := notifierNats.NewNatsPublisher(createArgsWithClient(cfg, client))
+		require.Nil(t, err)
+		defer func() {
+			_ = publisher.Close()
+		}()
+
+		publisher.Publish(context.Background(), data.BlockEvents{Hash: "hash1"})
+
+		require.Contains(t, publisher.GetMetricsForPrometheus(), `nats_publish_failures{subject="notifier.block_events"} 1`)
+	})
+}
+
+func createArgsWithClient(cfg config.NATSConfig, client notifierNats.Client) notifierNats.ArgsNatsPublisher {
+	args := createMockArgsNatsPublisher()
+	args.Config = cfg
+	args.Client = client
+
+	return args
+}
+
+func TestCreateClient_CreateStream(t *testing.T) {
+	t.Parallel()
+
+	t.Run("missing stream should be created", func(t *testing.T) {
+		t.Parallel()
+
+		natsServer := runEmbeddedServer(t)
+		cfg := createNatsConfig(natsServer.ClientURL())
+		cfg.UseJetStream = true
+		cfg.CreateStream = true
+		cfg.StreamName = "notifier"
+
+		client, err := notifierNats.CreateClient(cfg)
+		require.Nil(t, err)
+		publisher, err := notifierNats.NewNatsPublisher(createArgsWithClient(cfg, client))
+		require.Nil(t, err)
+		defer func() {
+			_ = publisher.Close()
+		}()
+
+		publisher.Publish(context.Background(), data.BlockEvents{Hash: "hash1"})
+
+		jetStream := createJetStreamContext(t, natsServer.ClientURL())
+		streamInfo, err := jetStream.StreamInfo("notifier")
+		require.Nil(t, err)
+		require.Equal(t, []string{cfg.BlockEventsSubject, cfg.RevertEventsSubject, cfg.FinalizedEventsSubject}, streamInfo.Config.Subjects)
+		require.Equal(t, uint64(1), streamInfo.State.Msgs)
+	})
+
+	t.Run("existing stream should get the missing subjects", func(t *testing.T) {
+		t.Parallel()
+
+		natsServer := runEmbeddedServer(t)
+		cfg := createNatsConfig(natsServer.ClientURL())
+		cfg.UseJetStream = true
+		cfg.CreateStream = true
+		cfg.StreamName = "notifier"
+
+		jetStream := createJetStreamContext(t, natsServer.ClientURL())
+		_, err := jetStream.AddStream(&nats.StreamConfig{
+			Name:     "notifier",
+			Subjects: []string{"other.subject", cfg.BlockEventsSubject},
+		})
+		require.Nil(t, err)
+
+		client, err := notifierNats.CreateClient(cfg)
+		require.Nil(t, err)
+		defer func() {
+			_ = client.Close()
+		}()
+
+		streamInfo, err := jetStream.StreamInfo("notifier")
+		require.Nil(t, err)
+		require.Equal(t, []string{"other.subject", cfg.BlockEventsSubject, cfg.RevertEventsSubject, cfg.FinalizedEventsSubject}, streamInfo.Config.Subjects)
+	})
+}
+
+func TestNatsPublisher_ResendAfterReconnect(t *testing.T) {
+	t.Parallel()
+
+	storeDir := t.TempDir()
+	opts := &server.Options{
+		Host:      "127.0.0.1",
+		Port:      -1,
+		JetStream: true,
+		StoreDir:  storeDir,
+		NoLog:     true,
+		NoSigs:    true,
+	}
+	natsServer, err := server.NewServer(opts)
+	require.Nil(t, err)
+	go natsServer.Start()
+	require.True(t, natsServer.ReadyForConnections(receiveTimeout))
+
+	cfg := createNatsConfig(natsServer.ClientURL())
+	cfg.UseJetStream = true
+	cfg.CreateStream = true
+	cfg.StreamName = "notifier"
+
+	client, err := notifierNats.CreateClient(cfg)
+	require.Nil(t, err)
+	publisher, err := notifierNats.NewNatsPublisher(createArgsWithClient(cfg, client))
+	require.Nil(t, err)
+	defer func() {
+		_ = publisher.Close()
+	}()
+
+	publisher.Publish(context.Background(), data.BlockEvents{Hash: "hash1"})
+
+	// restart the server on the same port, the stream is kept in the store dir
+	opts.Port = natsServer.Addr().(*net.TCPAddr).Port
+	natsServer.Shutdown()
+	natsServer.WaitForShutdown()
+	require.Eventually(t, func() bool {
+		return !client.IsConnected()
+	}, receiveTimeout, 10*time.Millisecond)
+
+	publisher.PublishRevert(context.Background(), data.RevertBlock{Hash: "hash2"})
+	require.Contains(t, publisher.GetMetricsForPrometheus(), "nats_buffered_events 1")
+
+	natsServer, err = server.NewServer(opts)
+	require.Nil(t, err)
+	go natsServer.Start()
+	require.True(t, natsServer.ReadyForConnections(receiveTimeout))
+	t.Cleanup(natsServer.Shutdown)
+
+	require.Eventually(t, func() bool {
+		return strings.Contains(publisher.GetMetricsForPrometheus(), "nats_buffered_events 0")
+	}, 2*receiveTimeout, 50*time.Millisecond)
+
+	jetStream := createJetStreamContext(t, natsServer.ClientURL())
+	streamInfo, err := jetStream.StreamInfo("notifier")
+	require.Nil(t, err)
+	require.Equal(t, uint64(2), streamInfo.State.Msgs)
+}
+
+func createJetStreamContext(t *testing.T, url string) nats.JetStreamContext {
+	conn, err := nats.Connect(url)
+	require.Nil(t, err)
+	t.Cleanup(conn.Close)
+
+	jetStream, err := conn.JetStream()
+	require.Nil(t, err)
+
+	return jetStream
+}
@@ -0,0 +1,24 @@
+package nats
+
+import "errors"
+
+// ErrNilClient signals that a nil NATS client has been provided
+var ErrNilClient = errors.New("nil NATS client")
+
+// ErrEmptyURL signals that an empty NATS server url has been provided
+var ErrEmptyURL = errors.New("empty NATS url")
+
+// ErrInvalidSubjectName signals that an empty NATS subject name has been provided
+var ErrInvalidSubjectName = errors.New("invalid NATS subject name")
+
+// ErrEmptyStreamName signals that an empty JetStream stream name has been provided
+var ErrEmptyStreamName = errors.New("empty JetStream stream name")
+
+// ErrInvalidPublishMaxAttempts signals that an invalid number of publish attempts has been provided
+var ErrInvalidPublishMaxAttempts = errors.New("invalid number of NATS publish attempts")
+
+// ErrPublishBufferFull signals that the event could not be buffered while disconnected
+var ErrPublishBufferFull = errors.New("NATS publish buffer is full")
+
+// ErrNATSNotConnected signals that the NATS client is not connected to the server
+var ErrNATSNotConnected = errors.New("NATS client not connected")
@@ -0,0 +1,12 @@
+package nats
+
+import "github.com/nats-io/nats.go"
+
+// Client defines the behaviour of a NATS client able to publish messages on subjects
+type Client interface {
+	Publish(msg *nats.Msg) error
+	IsConnected() bool
+	SetReconnectHandler(handler func())
+	Close() error
+	IsInterfaceNil() bool
+}
@@ -0,0 +1,415 @@
+package nats
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/marshal"
+	logger "github.com/multiversx/mx-chain-logger-go"
+	"github.com/multiversx/mx-chain-notifier-go/common"
+	"github.com/multiversx/mx-chain-notifier-go/config"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/metrics"
+	"github.com/nats-io/nats.go"
+)
+
+const (
+	publishSuccessPromMetric  = "nats_publish_success"
+	publishFailuresPromMetric = "nats_publish_failures"
+	droppedEventsPromMetric   = "nats_dropped_events"
+	bufferedEventsPromMetric  = "nats_buffered_events"
+	subjectPromLabel          = "subject"
+
+	maxPublishRetryInterval = 10 * time.Second
+
+	contentTypeHeader = "Content-Type"
+	hashHeader        = "Block-Hash"
+)
+
+var log = logger.GetOrCreate("nats")
+
+// ArgsNatsPublisher defines the arguments needed for NATS publisher creation
+type ArgsNatsPublisher struct {
+	Client      Client
+	Config      config.NATSConfig
+	Marshaller  marshal.Marshalizer
+	ContentType string
+
+	// AckAfterPublish returns the publish errors instead of buffering the events while
+	// disconnected, since a buffered event is not yet published
+	AckAfterPublish bool
+}
+
+type natsPublisher struct {
+	client          Client
+	marshaller      marshal.Marshalizer
+	cfg             config.NATSConfig
+	contentType     string
+	retryInterval   time.Duration
+	ackAfterPublish bool
+
+	// mutPublish serializes publishing, so that buffered events are resent in order
+	mutPublish sync.Mutex
+	buffer     []*bufferedEvent
+
+	mutMetrics         sync.RWMutex
+	numPublishSuccess  map[string]uint64
+	numPublishFailures map[string]uint64
+	numDroppedEvents   map[string]uint64
+	numBufferedEvents  uint64
+}
+
+// bufferedEvent holds an event received while disconnected from the NATS server
+type bufferedEvent struct {
+	subject string
+	hash    string
+	payload []byte
+}
+
+// NewNatsPublisher creates a new NATS publisher instance. Only the block events,
+// revert and finalized events are published, the other events are ignored
+// The events received while disconnected are buffered and resent, in order, as soon
+// as the client reconnects
+func NewNatsPublisher(args ArgsNatsPublisher) (*natsPublisher, error) {
+	err := checkArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	np := &natsPublisher{
+		client:             args.Client,
+		marshaller:         args.Marshaller,
+		cfg:                args.Config,
+		contentType:        args.ContentType,
+		retryInterval:      time.Duration(args.Config.PublishRetryIntervalInMs) * time.Millisecond,
+		ackAfterPublish:    args.AckAfterPublish,
+		buffer:             make([]*bufferedEvent, 0),
+		numPublishSuccess:  make(map[string]uint64),
+		numPublishFailures: make(map[string]uint64),
+		numDroppedEvents:   make(map[string]uint64),
+	}
+	np.client.SetReconnectHandler(np.onReconnect)
+
+	return np, nil
+}
+
+func checkArgs(args ArgsNatsPublisher) error {
+	if check.IfNil(args.Client) {
+		return ErrNilClient
+	}
+	if check.IfNil(args.Marshaller) {
+		return common.ErrNilMarshaller
+	}
+	if args.Config.BlockEventsSubject == "" {
+		return ErrInvalidSubjectName
+	}
+	if args.Config.RevertEventsSubject == "" {
+		return ErrInvalidSubjectName
+	}
+	if args.Config.FinalizedEventsSubject == "" {
+		return ErrInvalidSubjectName
+	}
+	if args.Config.PublishMaxAttempts == 0 {
+		return ErrInvalidPublishMaxAttempts
+	}
+
+	return nil
+}
+
+// Publish will publish logs and events on the NATS block events subject
+func (np *natsPublisher) Publish(_ context.Context, events data.BlockEvents) error {
+	eventsBytes, err := np.marshaller.Marshal(events)
+	if err != nil {
+		log.Error("could not marshal events", "err", err.Error())
+		return err
+	}
+
+	err = np.publishToSubject(np.cfg.BlockEventsSubject, events.Hash, eventsBytes)
+	if err != nil {
+		log.Error("failed to publish events to NATS", "hash", events.Hash, "err", err.Error())
+	}
+
+	return err
+}
+
+// PublishRevert will publish revert event on the NATS revert events subject
+func (np *natsPublisher) PublishRevert(_ context.Context, revertBlock data.RevertBlock) error {
+	revertBlockBytes, err := np.marshaller.Marshal(revertBlock)
+	if err != nil {
+		log.Error("could not marshal revert event", "err", err.Error())
+		return err
+	}
+
+	err = np.publishToSubject(np.cfg.RevertEventsSubject, revertBlock.Hash, revertBlockBytes)
+	if err != nil {
+		log.Error("failed to publish revert event to NATS", "hash", revertBlock.Hash, "err", err.Error())
+	}
+
+	return err
+}
+
+// PublishFinalized will publish finalized event on the NATS finalized events subject
+func (np *natsPublisher) PublishFinalized(_ context.Context, finalizedBlock data.FinalizedBlock) error {
+	finalizedBlockBytes, err := np.marshaller.Marshal(finalizedBlock)
+	if err != nil {
+		log.Error("could not marshal finalized event", "err", err.Error())
+		return err
+	}
+
+	err = np.publishToSubject(np.cfg.FinalizedEventsSubject, finalizedBlock.Hash, finalizedBlockBytes)
+	if err != nil {
+		log.Error("failed to publish finalized event to NATS", "hash", finalizedBlock.Hash, "err", err.Error())
+	}
+
+	return err
+}
+
+// PublishTxs does nothing, block txs are not published on NATS
+func (np *natsPublisher) PublishTxs(_ context.Context, _ data.BlockTxs) error {
+	return nil
+}
+
+// PublishScrs does nothing, block scrs are not published on NATS
+func (np *natsPublisher) PublishScrs(_ context.Context, _ data.BlockScrs) error {
+	return nil
+}
+
+// PublishBlockEventsWithOrder does nothing, full block events are not published on NATS
+func (np *natsPublisher) PublishBlockEventsWithOrder(_ context.Context, _ data.BlockEventsWithOrder) error {
+	return nil
+}
+
+// PublishTxEvents does nothing, tx events are not published on NATS
+func (np *natsPublisher) PublishTxEvents(_ context.Context, _ data.BlockTxEvents) error {
+	return nil
+}
+
+// PublishRounds does nothing, rounds info is not published on nats
+func (np *natsPublisher) PublishRounds(_ context.Context, _ data.RoundEvents) error {
+	return nil
+}
+
+// PublishValidatorsRating does nothing, validators rating is not published on nats
+func (np *natsPublisher) PublishValidatorsRating(_ context.Context, _ data.ValidatorsRatingEvent) error {
+	return nil
+}
+
+// PublishAccounts does nothing, altered accounts are not published on nats
+func (np *natsPublisher) PublishAccounts(_ context.Context, _ data.AccountsEvents) error {
+	return nil
+}
+
+// publishToSubject publishes the payload on the subject. While disconnected from the NATS
+// server, the events are buffered and they are resent in the same order after the
+// connection is recovered, before any new event, unless the ack is sent after publish
+func (np *natsPublisher) publishToSubject(subject string, hash string, payload []byte) error {
+	np.mutPublish.Lock()
+	defer np.mutPublish.Unlock()
+
+	np.flushBuffer()
+
+	event := &bufferedEvent{
+		subject: subject,
+		hash:    hash,
+		payload: payload,
+	}
+
+	if np.ackAfterPublish {
+		return np.publishWithoutBuffering(event)
+	}
+
+	if len(np.buffer) > 0 || !np.client.IsConnected() {
+		return np.bufferEvent(event)
+	}
+
+	err := np.publishWithRetries(event)
+	if err != nil && !np.client.IsConnected() {
+		return np.bufferEvent(event)
+	}
+
+	return err
+}
+
+// publishWithoutBuffering returns the publish error, or not connected if disconnected from
+// the NATS server, instead of buffering the event
+func (np *natsPublisher) publishWithoutBuffering(event *bufferedEvent) error {
+	if !np.client.IsConnected() {
+		return ErrNATSNotConnected
+	}
+
+	return np.publishWithRetries(event)
+}
+
+// onReconnect resends the buffered events, it is called by the client after reconnecting
+func (np *natsPublisher) onReconnect() {
+	np.mutPublish.Lock()
+	defer np.mutPublish.Unlock()
+
+	numBuffered := len(np.buffer)
+	np.flushBuffer()
+
+	log.Info("resent the buffered events after reconnecting to NATS",
+		"num resent", numBuffered-len(np.buffer),
+		"num buffered", len(np.buffer),
+	)
+}
+
+// flushBuffer publishes the buffered events, in order, while connected
+func (np *natsPublisher) flushBuffer() {
+	for len(np.buffer) > 0 && np.client.IsConnected() {
+		event := np.buffer[0]
+
+		err := np.publishWithRetries(event)
+		if err != nil && !np.client.IsConnected() {
+			return
+		}
+		if err != nil {
+			log.Error("failed to publish buffered event to NATS",
+				"subject", event.subject,
+				"hash", event.hash,
+				"err", err.Error(),
+			)
+		}
+
+		np.buffer = np.buffer[1:]
+		np.setNumBufferedEvents()
+	}
+}
+
+// bufferEvent adds the event to the buffer. If the buffer is full, the new event is
+// dropped, in order to keep the buffered events contiguous
+func (np *natsPublisher) bufferEvent(event *bufferedEvent) error {
+	if uint32(len(np.buffer)) >= np.cfg.MaxBufferedEvents {
+		np.mutMetrics.Lock()
+		np.numDroppedEvents[event.subject]++
+		np.mutMetrics.Unlock()
+
+		return ErrPublishBufferFull
+	}
+
+	np.buffer = append(np.buffer, event)
+	np.setNumBufferedEvents()
+
+	log.Debug("NATS not connected, buffered event",
+		"subject", event.subject,
+		"hash", event.hash,
+		"num buffered", len(np.buffer),
+	)
+
+	return nil
+}
+
+func (np *natsPublisher) setNumBufferedEvents() {
+	np.mutMetrics.Lock()
+	np.numBufferedEvents = uint64(len(np.buffer))
+	np.mutMetrics.Unlock()
+}
+
+// publishWithRetries publishes the event, retrying with exponential backoff until it is
+// acknowledged or the max number of attempts is reached. The message id is derived from
+// the subject and the block hash, so that JetStream drops the duplicates of a resent event
+func (np *natsPublisher) publishWithRetries(event *bufferedEvent) error {
+	msg := nats.NewMsg(event.subject)
+	msg.Data = event.payload
+	msg.Header.Set(hashHeader, event.hash)
+	msg.Header.Set(nats.MsgIdHdr, event.subject+"/"+event.hash)
+	if np.contentType != "" {
+		msg.Header.Set(contentTypeHeader, np.contentType)
+	}
+
+	var err error
+	retryInterval := np.retryInterval
+	for attempt := uint32(1); attempt <= np.cfg.PublishMaxAttempts; attempt++ {
+		err = np.client.Publish(msg)
+		if err == nil {
+			break
+		}
+		if attempt == np.cfg.PublishMaxAttempts || !np.client.IsConnected() {
+			break
+		}
+
+		log.Warn("failed to publish to NATS, will retry",
+			"subject", event.subject,
+			"hash", event.hash,
+			"attempt", attempt,
+			"retry interval", retryInterval,
+			"err", err.Error(),
+		)
+
+		time.Sleep(retryInterval)
+		retryInterval = nextRetryInterval(retryInterval)
+	}
+
+	np.mutMetrics.Lock()
+	if err != nil {
+		np.numPublishFailures[event.subject]++
+	} else {
+		np.numPublishSuccess[event.subject]++
+	}
+	np.mutMetrics.Unlock()
+
+	return err
+}
+
+func nextRetryInterval(retryInterval time.Duration) time.Duration {
+	retryInterval *= 2
+	if retryInterval > maxPublishRetryInterval {
+		return maxPublishRetryInterval
+	}
+
+	return retryInterval
+}
+
+// GetMetricsForPrometheus returns the number of successful, failed and dropped publish
+// operations for each subject, together with the number of buffered events, in prometheus format
+func (np *natsPublisher) GetMetricsForPrometheus() string {
+	np.mutMetrics.RLock()
+	defer np.mutMetrics.RUnlock()
+
+	stringBuilder := strings.Builder{}
+	stringBuilder.WriteString(metrics.CounterMetrics(publishSuccessPromMetric, subjectPromLabel, np.numPublishSuccess))
+	stringBuilder.WriteString(metrics.CounterMetrics(publishFailuresPromMetric, subjectPromLabel, np.numPublishFailures))
+	stringBuilder.WriteString(metrics.CounterMetrics(droppedEventsPromMetric, subjectPromLabel, np.numDroppedEvents))
+	stringBuilder.WriteString(metrics.GaugeMetric(bufferedEventsPromMetric, np.numBufferedEvents))
+
+	return stringBuilder.String()
+}
+
+// GetHealthState returns down if the client is not connected to the NATS server
+func (np *natsPublisher) GetHealthState() string {
+	if !np.client.IsConnected() {
+		return common.HealthStateDown
+	}
+
+	return common.HealthStateUp
+}
+
+// Ping returns an error if the client is not connected to the NATS server
+func (np *natsPublisher) Ping(_ context.Context) error {
+	if !np.client.IsConnected() {
+		return ErrNATSNotConnected
+	}
+
+	return nil
+}
+
+// Close will resend the buffered events, if connected, flush the pending messages and
+// close the NATS connection. The events still buffered are dropped
+func (np *natsPublisher) Close() error {
+	np.mutPublish.Lock()
+	np.flushBuffer()
+	if len(np.buffer) > 0 {
+		log.Warn("dropped the buffered NATS events on close", "num buffered", len(np.buffer))
+	}
+	np.mutPublish.Unlock()
+
+	return np.client.Close()
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (np *natsPublisher) IsInterfaceNil() bool {
+	return np == nil
+}
@@ -0,0 +1,387 @@
+package nats_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/marshal"
+	"github.com/multiversx/mx-chain-notifier-go/common"
+	"github.com/multiversx/mx-chain-notifier-go/config"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/mocks"
+	notifierNats "github.com/multiversx/mx-chain-notifier-go/nats"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/require"
+)
+
+func createMockArgsNatsPublisher() notifierNats.ArgsNatsPublisher {
+	return notifierNats.ArgsNatsPublisher{
+		Client: &mocks.NatsClientStub{},
+		Config: config.NATSConfig{
+			URL:                      "nats://localhost:4222",
+			BlockEventsSubject:       "notifier.block_events",
+			RevertEventsSubject:      "notifier.revert_events",
+			FinalizedEventsSubject:   "notifier.finalized_events",
+			PublishMaxAttempts:       3,
+			PublishRetryIntervalInMs: 1,
+			MaxBufferedEvents:        2,
+		},
+		Marshaller:  &marshal.JsonMarshalizer{},
+		ContentType: "application/json",
+	}
+}
+
+func TestNewNatsPublisher(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil client, should fail", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsNatsPublisher()
+		args.Client = nil
+
+		publisher, err := notifierNats.NewNatsPublisher(args)
+		require.True(t, check.IfNil(publisher))
+		require.Equal(t, notifierNats.ErrNilClient, err)
+	})
+
+	t.Run("nil marshaller, should fail", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsNatsPublisher()
+		args.Marshaller = nil
+
+		publisher, err := notifierNats.NewNatsPublisher(args)
+		require.True(t, check.IfNil(publisher))
+		require.Equal(t, common.ErrNilMarshaller, err)
+	})
+
+	t.Run("empty block events subject, should fail", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsNatsPublisher()
+		args.Config.BlockEventsSubject = ""
+
+		publisher, err := notifierNats.NewNatsPublisher(args)
+		require.True(t, check.IfNil(publisher))
+		require.Equal(t, notifierNats.ErrInvalidSubjectName, err)
+	})
+
+	t.Run("empty revert events subject, should fail", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsNatsPublisher()
+		args.Config.RevertEventsSubject = ""
+
+		publisher, err := notifierNats.NewNatsPublisher(args)
+		require.True(t, check.IfNil(publisher))
+		require.Equal(t, notifierNats.ErrInvalidSubjectName, err)
+	})
+
+	t.Run("empty finalized events subject, should fail", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsNatsPublisher()
+		args.Config.FinalizedEventsSubject = ""
+
+		publisher, err := notifierNats.NewNatsPublisher(args)
+		require.True(t, check.IfNil(publisher))
+		require.Equal(t, notifierNats.ErrInvalidSubjectName, err)
+	})
+
+	t.Run("invalid publish max attempts, should fail", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsNatsPublisher()
+		args.Config.PublishMaxAttempts = 0
+
+		publisher, err := notifierNats.NewNatsPublisher(args)
+		require.True(t, check.IfNil(publisher))
+		require.Equal(t, notifierNats.ErrInvalidPublishMaxAttempts, err)
+	})
+
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		publisher, err := notifierNats.NewNatsPublisher(createMockArgsNatsPublisher())
+		require.Nil(t, err)
+		require.False(t, check.IfNil(publisher))
+		require.Equal(t, common.HealthStateUp, publisher.GetHealthState())
+	})
+}
+
+func TestNatsPublisher_Publish(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should publish events on subjects, with the block hash header", func(t *testing.T) {
+		t.Parallel()
+
+		publishedMessages := make([]*nats.Msg, 0)
+		args := createMockArgsNatsPublisher()
+		args.Client = &mocks.NatsClientStub{
+			PublishCalled: func(msg *nats.Msg) error {
+				publishedMessages = append(publishedMessages, msg)
+				return nil
+			},
+		}
+
+		publisher, err := notifierNats.NewNatsPublisher(args)
+		require.Nil(t, err)
+
+		blockEvents := data.BlockEvents{Hash: "hash1", Events: []data.Event{{Address: "erd1"}}}
+		publisher.Publish(context.Background(), blockEvents)
+		publisher.PublishRevert(context.Background(), data.RevertBlock{Hash: "hash2"})
+		publisher.PublishFinalized(context.Background(), data.FinalizedBlock{Hash: "hash3"})
+
+		require.Len(t, publishedMessages, 3)
+		require.Equal(t, "notifier.block_events", publishedMessages[0].Subject)
+		require.Equal(t, "hash1", publishedMessages[0].Header.Get("Block-Hash"))
+		require.Equal(t, "application/json", publishedMessages[0].Header.Get("Content-Type"))
+		require.Equal(t, "notifier.revert_events", publishedMessages[1].Subject)
+		require.Equal(t, "hash2", publishedMessages[1].Header.Get("Block-Hash"))
+		require.Equal(t, "notifier.finalized_events", publishedMessages[2].Subject)
+		require.Equal(t, "hash3", publishedMessages[2].Header.Get("Block-Hash"))
+		require.Equal(t, "notifier.finalized_events/hash3", publishedMessages[2].Header.Get(nats.MsgIdHdr))
+
+		var publishedEvents data.BlockEvents
+		err = json.Unmarshal(publishedMessages[0].Data, &publishedEvents)
+		require.Nil(t, err)
+		require.Equal(t, blockEvents.Events, publishedEvents.Events)
+
+		require.Contains(t, publisher.GetMetricsForPrometheus(), `nats_publish_success{subject="notifier.block_events"} 1`)
+	})
+
+	t.Run("other events should not be published", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsNatsPublisher()
+		args.Client = &mocks.NatsClientStub{
+			PublishCalled: func(msg *nats.Msg) error {
+				require.Fail(t, "should not have been called")
+				return nil
+			},
+		}
+
+		publisher, err := notifierNats.NewNatsPublisher(args)
+		require.Nil(t, err)
+
+		publisher.PublishTxs(context.Background(), data.BlockTxs{Hash: "hash1"})
+		publisher.PublishScrs(context.Background(), data.BlockScrs{Hash: "hash1"})
+		publisher.PublishBlockEventsWithOrder(context.Background(), data.BlockEventsWithOrder{Hash: "hash1"})
+		publisher.PublishTxEvents(context.Background(), data.BlockTxEvents{Hash: "hash1"})
+	})
+
+	t.Run("failed publish should be retried and counted", func(t *testing.T) {
+		t.Parallel()
+
+		numAttempts := 0
+		args := createMockArgsNatsPublisher()
+		args.Client = &mocks.NatsClientStub{
+			PublishCalled: func(msg *nats.Msg) error {
+				numAttempts++
+				return errors.New("no responders")
+			},
+		}
+
+		publisher, err := notifierNats.NewNatsPublisher(args)
+		require.Nil(t, err)
+
+		publisher.PublishRevert(context.Background(), data.RevertBlock{Hash: "hash1"})
+
+		require.Equal(t, 3, numAttempts)
+		require.Contains(t, publisher.GetMetricsForPrometheus(), `nats_publish_failures{subject="notifier.revert_events"} 1`)
+	})
+
+	t.Run("publish acknowledged after retries should be counted as success", func(t *testing.T) {
+		t.Parallel()
+
+		numAttempts := 0
+		args := createMockArgsNatsPublisher()
+		args.Client = &mocks.NatsClientStub{
+			PublishCalled: func(msg *nats.Msg) error {
+				numAttempts++
+				if numAttempts < 2 {
+					return nats.ErrTimeout
+				}
+
+				return nil
+			},
+		}
+
+		publisher, err := notifierNats.NewNatsPublisher(args)
+		require.Nil(t, err)
+
+		publisher.PublishFinalized(context.Background(), data.FinalizedBlock{Hash: "hash1"})
+
+		require.Equal(t, 2, numAttempts)
+		metrics := publisher.GetMetricsForPrometheus()
+		require.Contains(t, metrics, `nats_publish_success{subject="notifier.finalized_events"} 1`)
+		require.NotContains(t, metrics, `nats_publish_failures{subject="notifier.finalized_events"}`)
+	})
+}
+
+func TestNatsPublisher_BufferWhileDisconnected(t *testing.T) {
+	t.Parallel()
+
+	t.Run("events should be resent in order after reconnecting", func(t *testing.T) {
+		t.Parallel()
+
+		isConnected := false
+		var reconnectHandler func()
+		publishedHashes := make([]string, 0)
+		args := createMockArgsNatsPublisher()
+		args.Client = &mocks.NatsClientStub{
+			PublishCalled: func(msg *nats.Msg) error {
+				publishedHashes = append(publishedHashes, msg.Header.Get("Block-Hash"))
+				return nil
+			},
+			IsConnectedCalled: func() bool {
+				return isConnected
+			},
+			SetReconnectHandlerCalled: func(handler func()) {
+				reconnectHandler = handler
+			},
+		}
+
+		publisher, err := notifierNats.NewNatsPublisher(args)
+		require.Nil(t, err)
+		require.NotNil(t, reconnectHandler)
+
+		publisher.Publish(context.Background(), data.BlockEvents{Hash: "hash1"})
+		publisher.PublishRevert(context.Background(), data.RevertBlock{Hash: "hash2"})
+		require.Empty(t, publishedHashes)
+		require.Contains(t, publisher.GetMetricsForPrometheus(), "nats_buffered_events 2")
+
+		isConnected = true
+		reconnectHandler()
+		require.Equal(t, []string{"hash1", "hash2"}, publishedHashes)
+		require.Contains(t, publisher.GetMetricsForPrometheus(), "nats_buffered_events 0")
+
+		publisher.PublishFinalized(context.Background(), data.FinalizedBlock{Hash: "hash3"})
+		require.Equal(t, []string{"hash1", "hash2", "hash3"}, publishedHashes)
+	})
+
+	t.Run("events should be dropped when the buffer is full", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsNatsPublisher()
+		args.Client = &mocks.NatsClientStub{
+			IsConnectedCalled: func() bool {
+				return false
+			},
+		}
+
+		publisher, err := notifierNats.NewNatsPublisher(args)
+		require.Nil(t, err)
+
+		publisher.Publish(context.Background(), data.BlockEvents{Hash: "hash1"})
+		publisher.Publish(context.Background(), data.BlockEvents{Hash: "hash2"})
+		publisher.Publish(context.Background(), data.BlockEvents{Hash: "hash3"})
+
+		metrics := publisher.GetMetricsForPrometheus()
+		require.Contains(t, metrics, "nats_buffered_events 2")
+		require.Contains(t, metrics, `nats_dropped_events{subject="notifier.block_events"} 1`)
+	})
+
+	t.Run("publish failing because of a disconnect should be buffered", func(t *testing.T) {
+		t.Parallel()
+
+		isConnected := true
+		numAttempts := 0
+		args := createMockArgsNatsPublisher()
+		args.Client = &mocks.NatsClientStub{
+			PublishCalled: func(msg *nats.Msg) error {
+				numAttempts++
+				isConnected = false
+				return nats.ErrConnectionClosed
+			},
+			IsConnectedCalled: func() bool {
+				return isConnected
+			},
+		}
+
+		publisher, err := notifierNats.NewNatsPublisher(args)
+		require.Nil(t, err)
+
+		publisher.PublishRevert(context.Background(), data.RevertBlock{Hash: "hash1"})
+
+		require.Equal(t, 1, numAttempts)
+		require.Contains(t, publisher.GetMetricsForPrometheus(), "nats_buffered_events 1")
+	})
+
+	t.Run("ack after publish should return the error and not buffer the events", func(t *testing.T) {
+		t.Parallel()
+
+		isConnected := false
+		numAttempts := 0
+		args := createMockArgsNatsPublisher()
+		args.AckAfterPublish = true
+		args.Client = &mocks.NatsClientStub{
+			PublishCalled: func(msg *nats.Msg) error {
+				numAttempts++
+				return nil
+			},
+			IsConnectedCalled: func() bool {
+				return isConnected
+			},
+		}
+
+		publisher, err := notifierNats.NewNatsPublisher(args)
+		require.Nil(t, err)
+
+		err = publisher.PublishRevert(context.Background(), data.RevertBlock{Hash: "hash1"})
+		require.Equal(t, notifierNats.ErrNATSNotConnected, err)
+		require.Equal(t, 0, numAttempts)
+		require.Contains(t, publisher.GetMetricsForPrometheus(), "nats_buffered_events 0")
+
+		isConnected = true
+		err = publisher.PublishRevert(context.Background(), data.RevertBlock{Hash: "hash2"})
+		require.Nil(t, err)
+		require.Equal(t, 1, numAttempts)
+	})
+}
+
+func TestNatsPublisher_HealthState(t *testing.T) {
+	t.Parallel()
+
+	isConnected := false
+	args := createMockArgsNatsPublisher()
+	args.Client = &mocks.NatsClientStub{
+		IsConnectedCalled: func() bool {
+			return isConnected
+		},
+	}
+
+	publisher, err := notifierNats.NewNatsPublisher(args)
+	require.Nil(t, err)
+
+	require.Equal(t, common.HealthStateDown, publisher.GetHealthState())
+	require.Equal(t, notifierNats.ErrNATSNotConnected, publisher.Ping(context.Background()))
+
+	isConnected = true
+	require.Equal(t, common.HealthStateUp, publisher.GetHealthState())
+	require.Nil(t, publisher.Ping(context.Background()))
+}
+
+func TestNatsPublisher_Close(t *testing.T) {
+	t.Parallel()
+
+	wasClosed := false
+	args := createMockArgsNatsPublisher()
+	args.Client = &mocks.NatsClientStub{
+		CloseCalled: func() error {
+			wasClosed = true
+			return nil
+		},
+	}
+
+	publisher, err := notifierNats.NewNatsPublisher(args)
+	require.Nil(t, err)
+
+	err = publisher.Close()
+	require.Nil(t, err)
+	require.True(t, wasClosed)
+}
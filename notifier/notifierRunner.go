@@ -3,16 +3,18 @@ package notifier
 import (
 	"os"
 	"os/signal"
+	"syscall"
+	"time"
 
 	marshalFactory "github.com/multiversx/mx-chain-core-go/marshal/factory"
 	logger "github.com/multiversx/mx-chain-logger-go"
-	"github.com/multiversx/mx-chain-notifier-go/api/shared"
+	"github.com/multiversx/mx-chain-notifier-go/common"
 	"github.com/multiversx/mx-chain-notifier-go/config"
 	"github.com/multiversx/mx-chain-notifier-go/facade"
 	"github.com/multiversx/mx-chain-notifier-go/factory"
 	"github.com/multiversx/mx-chain-notifier-go/metrics"
 	"github.com/multiversx/mx-chain-notifier-go/process"
-	"github.com/multiversx/mx-chain-notifier-go/rabbitmq"
+	"github.com/multiversx/mx-chain-notifier-go/shutdown"
 )
 
 var log = logger.GetOrCreate("notifierRunner")
@@ -26,6 +28,10 @@ func NewNotifierRunner(cfgs *config.Configs) (*notifierRunner, error) {
 	if cfgs == nil {
 		return nil, ErrNilConfigs
 	}
+	err := cfgs.Validate()
+	if err != nil {
+		return nil, err
+	}
 
 	return &notifierRunner{
 		configs: *cfgs,
@@ -34,7 +40,10 @@ func NewNotifierRunner(cfgs *config.Configs) (*notifierRunner, error) {
 
 // Start will trigger the notifier service
 func (nr *notifierRunner) Start() error {
-	publisherType := nr.configs.Flags.PublisherType
+	publisherTypes, err := common.GetPublisherTypes(nr.configs.Flags.PublisherType)
+	if err != nil {
+		return err
+	}
 
 	externalMarshaller, err := marshalFactory.NewMarshalizer(nr.configs.MainConfig.General.ExternalMarshaller.Type)
 	if err != nil {
@@ -46,22 +55,59 @@ func (nr *notifierRunner) Start() error {
 		return err
 	}
 
-	commonHub, err := factory.CreateHub(publisherType)
+	metricsCollector := metrics.NewMetricsCollector()
+
+	eventStore, err := factory.CreateEventStore(publisherTypes, nr.configs.MainConfig.EventStore)
+	if err != nil {
+		return err
+	}
+
+	subscriptionsStore, err := factory.CreateSubscriptionsStore(publisherTypes, nr.configs.MainConfig.SubscriptionsPersistence)
+	if err != nil {
+		return err
+	}
+
+	commonHub, err := factory.CreateHub(
+		publisherTypes,
+		nr.configs.MainConfig.ConnectorApi,
+		nr.configs.MainConfig.General,
+		nr.configs.MainConfig.Backplane,
+		nr.configs.MainConfig.Redis,
+		eventStore,
+		subscriptionsStore,
+		metricsCollector,
+	)
 	if err != nil {
 		return err
 	}
 
-	publisher, err := factory.CreatePublisher(publisherType, nr.configs.MainConfig, externalMarshaller, commonHub)
+	consumerRegistry, err := factory.CreateConsumerRegistry(nr.configs.MainConfig.ConnectorApi, nr.configs.MainConfig.General)
 	if err != nil {
 		return err
 	}
 
-	wsHandler, err := factory.CreateWSHandler(publisherType, commonHub, externalMarshaller)
+	publisherHandler, err := factory.CreatePublisherHandler(publisherTypes, nr.configs.MainConfig, externalMarshaller, commonHub, consumerRegistry, metricsCollector)
+	if err != nil {
+		return err
+	}
+
+	publisher, err := factory.CreatePublisher(publisherHandler, nr.configs.MainConfig.ConnectorApi)
+	if err != nil {
+		return err
+	}
+
+	webhookRegistry, err := factory.CreateWebhookRegistry(commonHub, nr.configs.MainConfig.Webhook)
+	if err != nil {
+		return err
+	}
+
+	wsHandler, err := factory.CreateWSHandler(publisherTypes, commonHub, externalMarshaller, nr.configs.MainConfig.ConnectorApi)
 	if err != nil {
 		return err
 	}
 
 	statusMetricsHandler := metrics.NewStatusMetrics()
+	processedBlocksTracker := metrics.NewProcessedBlocksTracker()
 
 	eventsInterceptor, err := factory.CreateEventsInterceptor(nr.configs.MainConfig.General)
 	if err != nil {
@@ -70,6 +116,7 @@ func (nr *notifierRunner) Start() error {
 
 	argsEventsHandler := process.ArgsEventsHandler{
 		CheckDuplicates:      nr.configs.MainConfig.General.CheckDuplicates,
+		AckAfterPublish:      nr.configs.MainConfig.General.AckAfterPublish,
 		Locker:               lockService,
 		Publisher:            publisher,
 		StatusMetricsHandler: statusMetricsHandler,
@@ -81,68 +128,144 @@ func (nr *notifierRunner) Start() error {
 	}
 
 	facadeArgs := facade.ArgsNotifierFacade{
-		EventsHandler:        eventsHandler,
-		APIConfig:            nr.configs.MainConfig.ConnectorApi,
-		WSHandler:            wsHandler,
-		StatusMetricsHandler: statusMetricsHandler,
+		EventsHandler:          eventsHandler,
+		APIConfig:              nr.configs.MainConfig.ConnectorApi,
+		WSHandler:              wsHandler,
+		Hub:                    commonHub,
+		WebhookRegistry:        webhookRegistry,
+		ConsumerRegistry:       consumerRegistry,
+		EventStore:             eventStore,
+		StatusMetricsHandler:   statusMetricsHandler,
+		ProcessedBlocksTracker: processedBlocksTracker,
+		PayloadVersions:        factory.GetPayloadVersions(nr.configs.MainConfig.General),
+		MetricsHandlers:        []common.PrometheusMetricsHandler{publisherHandler, publisher, metricsCollector},
+		HealthCheckers: map[string]common.HealthChecker{
+			common.HubHealthComponent:    publisher,
+			common.BrokerHealthComponent: publisherHandler,
+		},
 	}
 	facade, err := facade.NewNotifierFacade(facadeArgs)
 	if err != nil {
 		return err
 	}
 
-	webServer, err := factory.CreateWebServerHandler(facade, nr.configs)
+	observerFacade, hubChannelPublishers, err := factory.CreateObserverFacade(nr.configs.MainConfig, facade, eventsInterceptor, statusMetricsHandler)
 	if err != nil {
 		return err
 	}
 
-	wsConnector, err := factory.CreateWSObserverConnector(nr.configs.MainConfig.WebSocketConnector, facade)
+	httpPayloadHandler, err := factory.CreateHTTPPayloadHandler(observerFacade, nr.configs, statusMetricsHandler, processedBlocksTracker, metricsCollector)
 	if err != nil {
 		return err
 	}
 
-	err = publisher.Run()
+	webServer, err := factory.CreateWebServerHandler(facade, httpPayloadHandler, nr.configs)
 	if err != nil {
 		return err
 	}
 
-	err = webServer.Run()
+	wsConnector, err := factory.CreateWSObserverConnector(
+		nr.configs.MainConfig.WebSocketConnector,
+		observerFacade,
+		statusMetricsHandler,
+		processedBlocksTracker,
+		metricsCollector,
+		nr.configs.MainConfig.General,
+	)
 	if err != nil {
 		return err
 	}
 
-	err = waitForGracefulShutdown(webServer, publisher, wsConnector)
+	grpcConnector, err := factory.CreateGRPCObserverConnector(
+		nr.configs.MainConfig.GRPCConnector,
+		observerFacade,
+		statusMetricsHandler,
+		processedBlocksTracker,
+		metricsCollector,
+		nr.configs.MainConfig.General,
+	)
 	if err != nil {
 		return err
 	}
-	log.Debug("closing eventNotifier proxy...")
 
-	return nil
-}
+	grpcSubscriptionServer, err := factory.CreateGRPCSubscriptionServer(
+		publisherTypes,
+		nr.configs.MainConfig.GRPCSubscription,
+		commonHub,
+	)
+	if err != nil {
+		return err
+	}
 
-func waitForGracefulShutdown(
-	server shared.WebServerHandler,
-	publisher rabbitmq.PublisherService,
-	wsConnector process.WSClient,
-) error {
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, os.Interrupt, os.Kill)
-	<-quit
+	err = commonHub.Run()
+	if err != nil {
+		return err
+	}
 
-	err := server.Close()
+	err = publisher.Run()
 	if err != nil {
 		return err
 	}
 
-	err = wsConnector.Close()
+	for _, hubChannelPublisher := range hubChannelPublishers {
+		err = hubChannelPublisher.Run()
+		if err != nil {
+			return err
+		}
+	}
+
+	err = webServer.Run()
 	if err != nil {
 		return err
 	}
 
-	err = publisher.Close()
+	publisherClosers := []shutdown.Closer{publisher}
+	for _, hubChannelPublisher := range hubChannelPublishers {
+		publisherClosers = append(publisherClosers, hubChannelPublisher)
+	}
+
+	shutdownManager, err := shutdown.NewShutdownManager(shutdown.ArgsShutdownManager{
+		Stages: []shutdown.Stage{
+			{
+				// the observers are no longer accepted, the payloads in progress are processed
+				Name:    "observer intake",
+				Closers: []shutdown.Closer{wsConnector, grpcConnector, httpPayloadHandler},
+			},
+			{
+				// the received events are delivered to the hub and published to the brokers
+				Name:    "publisher",
+				Closers: publisherClosers,
+			},
+			{
+				Name:    "subscribers",
+				Closers: []shutdown.Closer{grpcSubscriptionServer, webServer},
+			},
+			{
+				Name:    "storage",
+				Closers: []shutdown.Closer{eventStore, subscriptionsStore},
+			},
+		},
+		Timeout: time.Duration(nr.configs.MainConfig.General.ShutdownTimeoutInSec) * time.Second,
+	})
 	if err != nil {
 		return err
 	}
 
+	waitForShutdownSignal()
+
+	err = shutdownManager.Shutdown()
+	if err != nil {
+		return err
+	}
+	log.Debug("closing eventNotifier proxy...")
+
 	return nil
 }
+
+func waitForShutdownSignal() {
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+	sig := <-quit
+
+	log.Info("received shutdown signal", "signal", sig.String())
+}
@@ -0,0 +1,197 @@
+package process
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-notifier-go/common"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+)
+
+type compositePublisherHandler struct {
+	handlers []PublisherHandler
+}
+
+// NewCompositePublisherHandler creates a publisher handler which fans out the events
+// to each of the provided publisher handlers, in the provided order
+func NewCompositePublisherHandler(handlers []PublisherHandler) (*compositePublisherHandler, error) {
+	if len(handlers) == 0 {
+		return nil, ErrEmptyPublisherHandlers
+	}
+	for index, handler := range handlers {
+		if check.IfNil(handler) {
+			return nil, fmt.Errorf("%w at index %d", ErrNilPublisherHandler, index)
+		}
+	}
+
+	return &compositePublisherHandler{
+		handlers: handlers,
+	}, nil
+}
+
+// Publish will publish the block events to each publisher handler
+func (cph *compositePublisherHandler) Publish(ctx context.Context, events data.BlockEvents) error {
+	return cph.forEachHandler(func(handler PublisherHandler) error {
+		return handler.Publish(ctx, events)
+	})
+}
+
+// PublishRevert will publish the revert event to each publisher handler
+func (cph *compositePublisherHandler) PublishRevert(ctx context.Context, revertBlock data.RevertBlock) error {
+	return cph.forEachHandler(func(handler PublisherHandler) error {
+		return handler.PublishRevert(ctx, revertBlock)
+	})
+}
+
+// PublishFinalized will publish the finalized event to each publisher handler
+func (cph *compositePublisherHandler) PublishFinalized(ctx context.Context, finalizedBlock data.FinalizedBlock) error {
+	return cph.forEachHandler(func(handler PublisherHandler) error {
+		return handler.PublishFinalized(ctx, finalizedBlock)
+	})
+}
+
+// PublishTxs will publish the txs event to each publisher handler
+func (cph *compositePublisherHandler) PublishTxs(ctx context.Context, blockTxs data.BlockTxs) error {
+	return cph.forEachHandler(func(handler PublisherHandler) error {
+		return handler.PublishTxs(ctx, blockTxs)
+	})
+}
+
+// PublishScrs will publish the scrs event to each publisher handler
+func (cph *compositePublisherHandler) PublishScrs(ctx context.Context, blockScrs data.BlockScrs) error {
+	return cph.forEachHandler(func(handler PublisherHandler) error {
+		return handler.PublishScrs(ctx, blockScrs)
+	})
+}
+
+// PublishBlockEventsWithOrder will publish the block events with order to each publisher handler
+func (cph *compositePublisherHandler) PublishBlockEventsWithOrder(ctx context.Context, blockTxs data.BlockEventsWithOrder) error {
+	return cph.forEachHandler(func(handler PublisherHandler) error {
+		return handler.PublishBlockEventsWithOrder(ctx, blockTxs)
+	})
+}
+
+// PublishTxEvents will publish the transaction notifications to each publisher handler
+func (cph *compositePublisherHandler) PublishTxEvents(ctx context.Context, blockTxEvents data.BlockTxEvents) error {
+	return cph.forEachHandler(func(handler PublisherHandler) error {
+		return handler.PublishTxEvents(ctx, blockTxEvents)
+	})
+}
+
+// PublishRounds will publish the rounds info to each publisher handler
+func (cph *compositePublisherHandler) PublishRounds(ctx context.Context, roundEvents data.RoundEvents) error {
+	return cph.forEachHandler(func(handler PublisherHandler) error {
+		return handler.PublishRounds(ctx, roundEvents)
+	})
+}
+
+// PublishValidatorsRating will publish the validators rating to each publisher handler
+func (cph *compositePublisherHandler) PublishValidatorsRating(ctx context.Context, validatorsRating data.ValidatorsRatingEvent) error {
+	return cph.forEachHandler(func(handler PublisherHandler) error {
+		return handler.PublishValidatorsRating(ctx, validatorsRating)
+	})
+}
+
+// PublishAccounts will publish the altered accounts to each publisher handler
+func (cph *compositePublisherHandler) PublishAccounts(ctx context.Context, accountsEvents data.AccountsEvents) error {
+	return cph.forEachHandler(func(handler PublisherHandler) error {
+		return handler.PublishAccounts(ctx, accountsEvents)
+	})
+}
+
+// forEachHandler calls the provided function for each publisher handler; a panic
+// in one of the handlers is logged, so that the other handlers still get the event.
+// It returns a MultiError with the errors of the handlers which failed, if any
+func (cph *compositePublisherHandler) forEachHandler(publish func(handler PublisherHandler) error) error {
+	errs := make([]error, 0, len(cph.handlers))
+	for _, handler := range cph.handlers {
+		errs = append(errs, publishWithRecover(handler, publish))
+	}
+
+	return combineErrors(errs)
+}
+
+func publishWithRecover(handler PublisherHandler, publish func(handler PublisherHandler) error) (err error) {
+	defer func() {
+		r := recover()
+		if r != nil {
+			log.Error("publisher handler failed", "handler", fmt.Sprintf("%T", handler), "error", r)
+			err = fmt.Errorf("%w: %T: %v", ErrPublisherHandlerPanic, handler, r)
+		}
+	}()
+
+	return publish(handler)
+}
+
+// GetMetricsForPrometheus returns the metrics of each publisher handler
+func (cph *compositePublisherHandler) GetMetricsForPrometheus() string {
+	stringBuilder := strings.Builder{}
+	for _, handler := range cph.handlers {
+		stringBuilder.WriteString(handler.GetMetricsForPrometheus())
+	}
+
+	return stringBuilder.String()
+}
+
+// GetHealthState returns down if any of the publisher handlers is down, and not
+// applicable if none of them is applicable
+func (cph *compositePublisherHandler) GetHealthState() string {
+	healthState := common.HealthStateNotApplicable
+	for _, handler := range cph.handlers {
+		switch handler.GetHealthState() {
+		case common.HealthStateDown:
+			return common.HealthStateDown
+		case common.HealthStateUp:
+			healthState = common.HealthStateUp
+		}
+	}
+
+	return healthState
+}
+
+// Ping returns the first error returned by the publisher handlers
+func (cph *compositePublisherHandler) Ping(ctx context.Context) error {
+	for _, handler := range cph.handlers {
+		err := handler.Ping(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SetPublishErrorHandler sets the publish error handler on each publisher handler
+// which notifies its publish errors
+func (cph *compositePublisherHandler) SetPublishErrorHandler(handler func(err error)) {
+	for _, publisherHandler := range cph.handlers {
+		errorsNotifier, ok := publisherHandler.(PublishErrorsNotifier)
+		if ok {
+			errorsNotifier.SetPublishErrorHandler(handler)
+		}
+	}
+}
+
+// Close will close each publisher handler, even if some of them fail, and returns
+// the first error
+func (cph *compositePublisherHandler) Close() error {
+	var firstErr error
+	for _, handler := range cph.handlers {
+		err := handler.Close()
+		if err != nil {
+			log.Error("failed to close publisher handler", "handler", fmt.Sprintf("%T", handler), "error", err.Error())
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+
+	return firstErr
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (cph *compositePublisherHandler) IsInterfaceNil() bool {
+	return cph == nil
+}
@@ -0,0 +1,236 @@
+package process_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-notifier-go/common"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/mocks"
+	"github.com/multiversx/mx-chain-notifier-go/process"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCompositePublisherHandler(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty handlers should error", func(t *testing.T) {
+		t.Parallel()
+
+		cph, err := process.NewCompositePublisherHandler(nil)
+		require.True(t, check.IfNil(cph))
+		require.Equal(t, process.ErrEmptyPublisherHandlers, err)
+	})
+
+	t.Run("nil handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		handlers := []process.PublisherHandler{&mocks.PublisherHandlerStub{}, nil}
+		cph, err := process.NewCompositePublisherHandler(handlers)
+		require.True(t, check.IfNil(cph))
+		require.True(t, errors.Is(err, process.ErrNilPublisherHandler))
+	})
+
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		handlers := []process.PublisherHandler{&mocks.PublisherHandlerStub{}, &mocks.PublisherHandlerStub{}}
+		cph, err := process.NewCompositePublisherHandler(handlers)
+		require.False(t, check.IfNil(cph))
+		require.Nil(t, err)
+	})
+}
+
+func TestCompositePublisherHandler_Publish(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should publish to all handlers", func(t *testing.T) {
+		t.Parallel()
+
+		events := data.BlockEvents{Hash: "hash1"}
+		publishedHashes := make([]string, 0)
+		createHandler := func() process.PublisherHandler {
+			return &mocks.PublisherHandlerStub{
+				PublishCalled: func(_ context.Context, blockEvents data.BlockEvents) error {
+					publishedHashes = append(publishedHashes, blockEvents.Hash)
+					return nil
+				},
+				PublishRevertCalled: func(_ context.Context, revertBlock data.RevertBlock) error {
+					publishedHashes = append(publishedHashes, revertBlock.Hash)
+					return nil
+				},
+				PublishFinalizedCalled: func(_ context.Context, finalizedBlock data.FinalizedBlock) error {
+					publishedHashes = append(publishedHashes, finalizedBlock.Hash)
+					return nil
+				},
+			}
+		}
+
+		cph, err := process.NewCompositePublisherHandler([]process.PublisherHandler{createHandler(), createHandler()})
+		require.Nil(t, err)
+
+		cph.Publish(context.Background(), events)
+		cph.PublishRevert(context.Background(), data.RevertBlock{Hash: "hash2"})
+		cph.PublishFinalized(context.Background(), data.FinalizedBlock{Hash: "hash3"})
+
+		require.Equal(t, []string{"hash1", "hash1", "hash2", "hash2", "hash3", "hash3"}, publishedHashes)
+	})
+
+	t.Run("failing handler should not prevent the others from publishing", func(t *testing.T) {
+		t.Parallel()
+
+		failingHandler := &mocks.PublisherHandlerStub{
+			PublishCalled: func(_ context.Context, events data.BlockEvents) error {
+				panic("publish failure")
+			},
+		}
+
+		wasCalled := false
+		handler := &mocks.PublisherHandlerStub{
+			PublishCalled: func(_ context.Context, events data.BlockEvents) error {
+				wasCalled = true
+				return nil
+			},
+		}
+
+		cph, err := process.NewCompositePublisherHandler([]process.PublisherHandler{failingHandler, handler})
+		require.Nil(t, err)
+
+		require.NotPanics(t, func() {
+			err = cph.Publish(context.Background(), data.BlockEvents{})
+		})
+		require.True(t, wasCalled)
+		multiErr, ok := err.(*process.MultiError)
+		require.True(t, ok)
+		require.Equal(t, 1, len(multiErr.Errors))
+		require.True(t, errors.Is(multiErr.Errors[0], process.ErrPublisherHandlerPanic))
+	})
+
+	t.Run("should return the errors of the failing handlers", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		failingHandler := &mocks.PublisherHandlerStub{
+			PublishRevertCalled: func(_ context.Context, revertBlock data.RevertBlock) error {
+				return expectedErr
+			},
+		}
+
+		cph, err := process.NewCompositePublisherHandler([]process.PublisherHandler{&mocks.PublisherHandlerStub{}, failingHandler})
+		require.Nil(t, err)
+
+		err = cph.PublishRevert(context.Background(), data.RevertBlock{})
+		require.Equal(t, &process.MultiError{Errors: []error{expectedErr}}, err)
+
+		err = cph.Publish(context.Background(), data.BlockEvents{})
+		require.Nil(t, err)
+	})
+}
+
+func TestCompositePublisherHandler_GetHealthState(t *testing.T) {
+	t.Parallel()
+
+	createHandler := func(state string) process.PublisherHandler {
+		return &mocks.PublisherHandlerStub{
+			GetHealthStateCalled: func() string {
+				return state
+			},
+		}
+	}
+
+	cph, _ := process.NewCompositePublisherHandler([]process.PublisherHandler{
+		createHandler(common.HealthStateNotApplicable),
+		createHandler(common.HealthStateNotApplicable),
+	})
+	require.Equal(t, common.HealthStateNotApplicable, cph.GetHealthState())
+
+	cph, _ = process.NewCompositePublisherHandler([]process.PublisherHandler{
+		createHandler(common.HealthStateNotApplicable),
+		createHandler(common.HealthStateUp),
+	})
+	require.Equal(t, common.HealthStateUp, cph.GetHealthState())
+
+	cph, _ = process.NewCompositePublisherHandler([]process.PublisherHandler{
+		createHandler(common.HealthStateDown),
+		createHandler(common.HealthStateUp),
+	})
+	require.Equal(t, common.HealthStateDown, cph.GetHealthState())
+}
+
+func TestCompositePublisherHandler_Ping(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("expected error")
+	cph, _ := process.NewCompositePublisherHandler([]process.PublisherHandler{
+		&mocks.PublisherHandlerStub{},
+		&mocks.PublisherHandlerStub{
+			PingCalled: func(ctx context.Context) error {
+				return expectedErr
+			},
+		},
+	})
+
+	require.Equal(t, expectedErr, cph.Ping(context.Background()))
+}
+
+func TestCompositePublisherHandler_Close(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("expected error")
+	numCloseCalls := 0
+	cph, _ := process.NewCompositePublisherHandler([]process.PublisherHandler{
+		&mocks.PublisherHandlerStub{
+			CloseCalled: func() error {
+				numCloseCalls++
+				return expectedErr
+			},
+		},
+		&mocks.PublisherHandlerStub{
+			CloseCalled: func() error {
+				numCloseCalls++
+				return nil
+			},
+		},
+	})
+
+	err := cph.Close()
+	require.Equal(t, expectedErr, err)
+	require.Equal(t, 2, numCloseCalls)
+}
+
+func TestCompositePublisherHandler_SetPublishErrorHandler(t *testing.T) {
+	t.Parallel()
+
+	numSetCalls := 0
+	handler := &mocks.PublisherHandlerStub{
+		SetPublishErrorHandlerCalled: func(handler func(err error)) {
+			require.NotNil(t, handler)
+			numSetCalls++
+		},
+	}
+	cph, _ := process.NewCompositePublisherHandler([]process.PublisherHandler{handler, handler})
+
+	cph.SetPublishErrorHandler(func(err error) {})
+	require.Equal(t, 2, numSetCalls)
+}
+
+func TestCompositePublisherHandler_GetMetricsForPrometheus(t *testing.T) {
+	t.Parallel()
+
+	cph, _ := process.NewCompositePublisherHandler([]process.PublisherHandler{
+		&mocks.PublisherHandlerStub{
+			GetMetricsForPrometheusCalled: func() string {
+				return "metric_a 1\n"
+			},
+		},
+		&mocks.PublisherHandlerStub{
+			GetMetricsForPrometheusCalled: func() string {
+				return "metric_b 2\n"
+			},
+		},
+	})
+
+	require.Equal(t, "metric_a 1\nmetric_b 2\n", cph.GetMetricsForPrometheus())
+}
@@ -31,3 +31,30 @@ var ErrNilPublisherHandler = errors.New("nil publisher handler provided")
 
 // ErrNilEventsInterceptor signals that a nil events interceptor was provided
 var ErrNilEventsInterceptor = errors.New("nil events interceptor")
+
+// ErrPublisherNotRunning signals that the publishing loop is not running
+var ErrPublisherNotRunning = errors.New("publisher is not running")
+
+// ErrPublishFailed signals that the events were not published
+var ErrPublishFailed = errors.New("failed to publish the events")
+
+// ErrPublisherClosed signals that the publisher is closed and no longer accepts broadcasts
+var ErrPublisherClosed = errors.New("publisher is closed")
+
+// ErrPublisherCloseTimeout signals that the publisher handler did not finish publishing and closing in time
+var ErrPublisherCloseTimeout = errors.New("publisher close timeout")
+
+// ErrTooManyPendingBroadcasts signals that the number of events waiting to be published is above the threshold
+var ErrTooManyPendingBroadcasts = errors.New("too many pending broadcasts")
+
+// ErrEmptyPublisherHandlers signals that no publisher handler has been provided
+var ErrEmptyPublisherHandlers = errors.New("empty publisher handlers provided")
+
+// ErrEmptyEventsFacades signals that no events facade has been provided
+var ErrEmptyEventsFacades = errors.New("empty events facades provided")
+
+// ErrNilEventsFacade signals that a nil events facade has been provided
+var ErrNilEventsFacade = errors.New("nil events facade provided")
+
+// ErrPublisherHandlerPanic signals that a publisher handler panicked while publishing
+var ErrPublisherHandlerPanic = errors.New("publisher handler panic")
@@ -32,6 +32,10 @@ type ArgsEventsHandler struct {
 	StatusMetricsHandler common.StatusMetricsHandler
 	EventsInterceptor    EventsInterceptor
 	CheckDuplicates      bool
+
+	// AckAfterPublish returns the events only after they are published, so that the
+	// payload is not acknowledged to the observer if the publish fails
+	AckAfterPublish bool
 }
 
 type eventsHandler struct {
@@ -40,6 +44,7 @@ type eventsHandler struct {
 	metricsHandler    common.StatusMetricsHandler
 	eventsInterceptor EventsInterceptor
 	checkDuplicates   bool
+	ackAfterPublish   bool
 }
 
 // NewEventsHandler creates a new events handler component
@@ -55,6 +60,7 @@ func NewEventsHandler(args ArgsEventsHandler) (*eventsHandler, error) {
 		metricsHandler:    args.StatusMetricsHandler,
 		eventsInterceptor: args.EventsInterceptor,
 		checkDuplicates:   args.CheckDuplicates,
+		ackAfterPublish:   args.AckAfterPublish,
 	}, nil
 }
 
@@ -83,16 +89,29 @@ func (eh *eventsHandler) HandleSaveBlockEvents(allEvents data.ArgsSaveBlockData)
 		return nil
 	}
 
+	err := eh.handleSaveBlockEvents(allEvents)
+	if err != nil {
+		eh.removeProcessedEvent(common.PushLogsAndEvents, blockHash)
+	}
+
+	return err
+}
+
+func (eh *eventsHandler) handleSaveBlockEvents(allEvents data.ArgsSaveBlockData) error {
 	eventsData, err := eh.eventsInterceptor.ProcessBlockEvents(&allEvents)
 	if err != nil {
 		return err
 	}
 
 	pushEvents := data.BlockEvents{
-		Hash:      eventsData.Hash,
-		ShardID:   eventsData.Header.GetShardID(),
-		TimeStamp: eventsData.Header.GetTimeStamp(),
-		Events:    eventsData.LogEvents,
+		Hash:          eventsData.Hash,
+		ShardID:       eventsData.Header.GetShardID(),
+		TimeStamp:     eventsData.Header.GetTimeStamp(),
+		Events:        eventsData.LogEvents,
+		Nonce:         eventsData.Header.GetNonce(),
+		Timestamp:     allEvents.Timestamp,
+		CorrelationID: allEvents.CorrelationID,
+		SpanContext:   allEvents.SpanContext,
 	}
 	err = eh.handlePushEvents(pushEvents)
 	if err != nil {
@@ -103,13 +122,19 @@ func (eh *eventsHandler) HandleSaveBlockEvents(allEvents data.ArgsSaveBlockData)
 		Hash: eventsData.Hash,
 		Txs:  eventsData.Txs,
 	}
-	eh.handleBlockTxs(txs)
+	err = eh.handleBlockTxs(txs)
+	if err != nil {
+		return err
+	}
 
 	scrs := data.BlockScrs{
 		Hash: eventsData.Hash,
 		Scrs: eventsData.Scrs,
 	}
-	eh.handleBlockScrs(scrs)
+	err = eh.handleBlockScrs(scrs)
+	if err != nil {
+		return err
+	}
 
 	txsWithOrder := data.BlockEventsWithOrder{
 		Hash:      eventsData.Hash,
@@ -119,9 +144,18 @@ func (eh *eventsHandler) HandleSaveBlockEvents(allEvents data.ArgsSaveBlockData)
 		Scrs:      eventsData.ScrsWithOrder,
 		Events:    eventsData.LogEvents,
 	}
-	eh.handleBlockEventsWithOrder(txsWithOrder)
+	err = eh.handleBlockEventsWithOrder(txsWithOrder)
+	if err != nil {
+		return err
+	}
 
-	return nil
+	txEvents := data.BlockTxEvents{
+		Hash:      eventsData.Hash,
+		ShardID:   eventsData.Header.GetShardID(),
+		TimeStamp: eventsData.Header.GetTimeStamp(),
+		TxEvents:  eventsData.TxEvents,
+	}
+	return eh.handleBlockTxEvents(txEvents)
 }
 
 // HandlePushEvents will handle push events received from observer
@@ -136,18 +170,53 @@ func (eh *eventsHandler) handlePushEvents(events data.BlockEvents) error {
 	if len(events.Events) == 0 {
 		log.Warn("received empty events", "event", common.PushLogsAndEvents,
 			"block hash", events.Hash,
+			"correlation id", events.CorrelationID,
 		)
 		events.Events = make([]data.Event, 0)
 	} else {
 		log.Info("received", "event", common.PushLogsAndEvents,
 			"block hash", events.Hash,
+			"correlation id", events.CorrelationID,
 		)
 	}
 
 	t := time.Now()
-	eh.publisher.Broadcast(events)
+	var err error
+	if eh.ackAfterPublish {
+		err = eh.publisher.BroadcastWithContext(context.Background(), events)
+	} else {
+		eh.publisher.Broadcast(events)
+	}
 	eh.metricsHandler.AddRequest(getRabbitOpID(common.PushLogsAndEvents), time.Since(t))
-	return nil
+
+	return eh.checkPublishError(common.PushLogsAndEvents, err)
+}
+
+// checkPublishError wraps the error of the events waited until published, which is returned,
+// if the ack is sent after publish, so that the observer sends the payload again
+func (eh *eventsHandler) checkPublishError(eventType string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	log.Warn("failed to publish events", "event", eventType, "err", err.Error())
+
+	return fmt.Errorf("%w: %s", ErrPublishFailed, err.Error())
+}
+
+// removeProcessedEvent removes the event marked in the locker service, if duplicates are
+// checked, after it failed to be handled, so that the event sent again by the observer is
+// not dropped as duplicated
+func (eh *eventsHandler) removeProcessedEvent(id string, blockHash string) {
+	if !eh.checkDuplicates {
+		return
+	}
+
+	key := getPrefixLockerKey(id) + blockHash
+	err := eh.locker.RemoveEvent(context.Background(), key)
+	if err != nil {
+		log.Warn("failed to remove event from locker", "event", id, "block hash", blockHash, "err", err.Error())
+	}
 }
 
 func (eh *eventsHandler) shouldProcessSaveBlockEvents(blockHash string) bool {
@@ -169,12 +238,12 @@ func (eh *eventsHandler) shouldProcessSaveBlockEvents(blockHash string) bool {
 }
 
 // HandleRevertEvents will handle revents events received from observer
-func (eh *eventsHandler) HandleRevertEvents(revertBlock data.RevertBlock) {
+func (eh *eventsHandler) HandleRevertEvents(revertBlock data.RevertBlock) error {
 	if revertBlock.Hash == "" {
 		log.Warn("received empty hash", "event", common.RevertBlockEvents,
 			"will process", false,
 		)
-		return
+		return nil
 	}
 
 	shouldProcessRevert := true
@@ -185,28 +254,42 @@ func (eh *eventsHandler) HandleRevertEvents(revertBlock data.RevertBlock) {
 	if !shouldProcessRevert {
 		log.Info("received duplicated events", "event", common.RevertBlockEvents,
 			"block hash", revertBlock.Hash,
+			"correlation id", revertBlock.CorrelationID,
 			"will process", false,
 		)
-		return
+		return nil
 	}
 
 	log.Info("received", "event", common.RevertBlockEvents,
 		"block hash", revertBlock.Hash,
+		"correlation id", revertBlock.CorrelationID,
 		"will process", shouldProcessRevert,
 	)
 
 	t := time.Now()
-	eh.publisher.BroadcastRevert(revertBlock)
+	var err error
+	if eh.ackAfterPublish {
+		err = eh.publisher.BroadcastRevertWithContext(context.Background(), revertBlock)
+	} else {
+		eh.publisher.BroadcastRevert(revertBlock)
+	}
 	eh.metricsHandler.AddRequest(getRabbitOpID(common.RevertBlockEvents), time.Since(t))
+
+	err = eh.checkPublishError(common.RevertBlockEvents, err)
+	if err != nil {
+		eh.removeProcessedEvent(common.RevertBlockEvents, revertBlock.Hash)
+	}
+
+	return err
 }
 
 // HandleFinalizedEvents will handle finalized events received from observer
-func (eh *eventsHandler) HandleFinalizedEvents(finalizedBlock data.FinalizedBlock) {
+func (eh *eventsHandler) HandleFinalizedEvents(finalizedBlock data.FinalizedBlock) error {
 	if finalizedBlock.Hash == "" {
 		log.Warn("received empty hash", "event", common.FinalizedBlockEvents,
 			"will process", false,
 		)
-		return
+		return nil
 	}
 	shouldProcessFinalized := true
 	if eh.checkDuplicates {
@@ -216,28 +299,125 @@ func (eh *eventsHandler) HandleFinalizedEvents(finalizedBlock data.FinalizedBloc
 	if !shouldProcessFinalized {
 		log.Info("received duplicated events", "event", common.FinalizedBlockEvents,
 			"block hash", finalizedBlock.Hash,
+			"correlation id", finalizedBlock.CorrelationID,
 			"will process", false,
 		)
-		return
+		return nil
 	}
 
 	log.Info("received", "event", common.FinalizedBlockEvents,
 		"block hash", finalizedBlock.Hash,
+		"correlation id", finalizedBlock.CorrelationID,
 		"will process", shouldProcessFinalized,
 	)
 
 	t := time.Now()
-	eh.publisher.BroadcastFinalized(finalizedBlock)
+	var err error
+	if eh.ackAfterPublish {
+		err = eh.publisher.BroadcastFinalizedWithContext(context.Background(), finalizedBlock)
+	} else {
+		eh.publisher.BroadcastFinalized(finalizedBlock)
+	}
 	eh.metricsHandler.AddRequest(getRabbitOpID(common.FinalizedBlockEvents), time.Since(t))
+
+	err = eh.checkPublishError(common.FinalizedBlockEvents, err)
+	if err != nil {
+		eh.removeProcessedEvent(common.FinalizedBlockEvents, finalizedBlock.Hash)
+	}
+
+	return err
+}
+
+// HandleRoundEvents will handle the rounds info received from observer
+func (eh *eventsHandler) HandleRoundEvents(roundEvents data.RoundEvents) error {
+	if len(roundEvents.Rounds) == 0 {
+		log.Warn("received no rounds", "event", common.RoundEvents,
+			"will process", false,
+		)
+		return nil
+	}
+
+	log.Info("received", "event", common.RoundEvents,
+		"shard id", roundEvents.ShardID,
+		"num rounds", len(roundEvents.Rounds),
+		"correlation id", roundEvents.CorrelationID,
+	)
+
+	t := time.Now()
+	var err error
+	if eh.ackAfterPublish {
+		err = eh.publisher.BroadcastRoundsWithContext(context.Background(), roundEvents)
+	} else {
+		eh.publisher.BroadcastRounds(roundEvents)
+	}
+	eh.metricsHandler.AddRequest(getRabbitOpID(common.RoundEvents), time.Since(t))
+
+	return eh.checkPublishError(common.RoundEvents, err)
+}
+
+// HandleValidatorsRatingEvent will handle the validators rating received from observer
+func (eh *eventsHandler) HandleValidatorsRatingEvent(validatorsRating data.ValidatorsRatingEvent) error {
+	if len(validatorsRating.ValidatorsRating) == 0 {
+		log.Warn("received no validators rating", "event", common.ValidatorsRatingEvents,
+			"will process", false,
+		)
+		return nil
+	}
+
+	log.Info("received", "event", common.ValidatorsRatingEvents,
+		"shard id", validatorsRating.ShardID,
+		"epoch", validatorsRating.Epoch,
+		"num validators", len(validatorsRating.ValidatorsRating),
+		"correlation id", validatorsRating.CorrelationID,
+	)
+
+	t := time.Now()
+	var err error
+	if eh.ackAfterPublish {
+		err = eh.publisher.BroadcastValidatorsRatingWithContext(context.Background(), validatorsRating)
+	} else {
+		eh.publisher.BroadcastValidatorsRating(validatorsRating)
+	}
+	eh.metricsHandler.AddRequest(getRabbitOpID(common.ValidatorsRatingEvents), time.Since(t))
+
+	return eh.checkPublishError(common.ValidatorsRatingEvents, err)
+}
+
+// HandleAccountsEvents will handle the altered accounts received from observer
+func (eh *eventsHandler) HandleAccountsEvents(accountsEvents data.AccountsEvents) error {
+	if len(accountsEvents.Accounts) == 0 {
+		log.Warn("received no altered accounts", "event", common.AccountEvents,
+			"will process", false,
+		)
+		return nil
+	}
+
+	log.Info("received", "event", common.AccountEvents,
+		"shard id", accountsEvents.ShardID,
+		"block timestamp", accountsEvents.BlockTimestamp,
+		"num accounts", len(accountsEvents.Accounts),
+		"correlation id", accountsEvents.CorrelationID,
+	)
+
+	t := time.Now()
+	var err error
+	if eh.ackAfterPublish {
+		err = eh.publisher.BroadcastAccountsWithContext(context.Background(), accountsEvents)
+	} else {
+		eh.publisher.BroadcastAccounts(accountsEvents)
+	}
+	eh.metricsHandler.AddRequest(getRabbitOpID(common.AccountEvents), time.Since(t))
+
+	return eh.checkPublishError(common.AccountEvents, err)
 }
 
 // handleBlockTxs will handle txs events received from observer
-func (eh *eventsHandler) handleBlockTxs(blockTxs data.BlockTxs) {
+func (eh *eventsHandler) handleBlockTxs(blockTxs data.BlockTxs) error {
 	if blockTxs.Hash == "" {
 		log.Warn("received empty hash", "event", common.BlockTxs,
 			"will process", false,
 		)
-		return
+		return nil
 	}
 
 	if len(blockTxs.Txs) == 0 {
@@ -251,17 +431,24 @@ func (eh *eventsHandler) handleBlockTxs(blockTxs data.BlockTxs) {
 	}
 
 	t := time.Now()
-	eh.publisher.BroadcastTxs(blockTxs)
+	var err error
+	if eh.ackAfterPublish {
+		err = eh.publisher.BroadcastTxsWithContext(context.Background(), blockTxs)
+	} else {
+		eh.publisher.BroadcastTxs(blockTxs)
+	}
 	eh.metricsHandler.AddRequest(getRabbitOpID(common.BlockTxs), time.Since(t))
+
+	return eh.checkPublishError(common.BlockTxs, err)
 }
 
 // handleBlockScrs will handle scrs events received from observer
-func (eh *eventsHandler) handleBlockScrs(blockScrs data.BlockScrs) {
+func (eh *eventsHandler) handleBlockScrs(blockScrs data.BlockScrs) error {
 	if blockScrs.Hash == "" {
 		log.Warn("received empty hash", "event", common.BlockScrs,
 			"will process", false,
 		)
-		return
+		return nil
 	}
 
 	if len(blockScrs.Scrs) == 0 {
@@ -275,17 +462,24 @@ func (eh *eventsHandler) handleBlockScrs(blockScrs data.BlockScrs) {
 	}
 
 	t := time.Now()
-	eh.publisher.BroadcastScrs(blockScrs)
+	var err error
+	if eh.ackAfterPublish {
+		err = eh.publisher.BroadcastScrsWithContext(context.Background(), blockScrs)
+	} else {
+		eh.publisher.BroadcastScrs(blockScrs)
+	}
 	eh.metricsHandler.AddRequest(getRabbitOpID(common.BlockScrs), time.Since(t))
+
+	return eh.checkPublishError(common.BlockScrs, err)
 }
 
 // handleBlockEventsWithOrder will handle full block events received from observer
-func (eh *eventsHandler) handleBlockEventsWithOrder(blockTxs data.BlockEventsWithOrder) {
+func (eh *eventsHandler) handleBlockEventsWithOrder(blockTxs data.BlockEventsWithOrder) error {
 	if blockTxs.Hash == "" {
 		log.Warn("received empty hash", "event", common.BlockEvents,
 			"will process", false,
 		)
-		return
+		return nil
 	}
 
 	log.Info("received", "event", common.BlockEvents,
@@ -293,8 +487,47 @@ func (eh *eventsHandler) handleBlockEventsWithOrder(blockTxs data.BlockEventsWit
 	)
 
 	t := time.Now()
-	eh.publisher.BroadcastBlockEventsWithOrder(blockTxs)
+	var err error
+	if eh.ackAfterPublish {
+		err = eh.publisher.BroadcastBlockEventsWithOrderWithContext(context.Background(), blockTxs)
+	} else {
+		eh.publisher.BroadcastBlockEventsWithOrder(blockTxs)
+	}
 	eh.metricsHandler.AddRequest(getRabbitOpID(common.BlockEvents), time.Since(t))
+
+	return eh.checkPublishError(common.BlockEvents, err)
+}
+
+// handleBlockTxEvents will handle the transaction notifications created from the block received from observer
+func (eh *eventsHandler) handleBlockTxEvents(blockTxEvents data.BlockTxEvents) error {
+	if blockTxEvents.Hash == "" {
+		log.Warn("received empty hash", "event", common.TxEvents,
+			"will process", false,
+		)
+		return nil
+	}
+
+	if len(blockTxEvents.TxEvents) == 0 {
+		log.Debug("received empty events", "event", common.TxEvents,
+			"block hash", blockTxEvents.Hash,
+		)
+		return nil
+	}
+
+	log.Info("received", "event", common.TxEvents,
+		"block hash", blockTxEvents.Hash,
+	)
+
+	t := time.Now()
+	var err error
+	if eh.ackAfterPublish {
+		err = eh.publisher.BroadcastTxEventsWithContext(context.Background(), blockTxEvents)
+	} else {
+		eh.publisher.BroadcastTxEvents(blockTxEvents)
+	}
+	eh.metricsHandler.AddRequest(getRabbitOpID(common.TxEvents), time.Since(t))
+
+	return eh.checkPublishError(common.TxEvents, err)
 }
 
 func (eh *eventsHandler) tryCheckProcessedWithRetry(id, blockHash string) bool {
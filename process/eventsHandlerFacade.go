@@ -0,0 +1,34 @@
+package process
+
+import (
+	"github.com/multiversx/mx-chain-notifier-go/data"
+)
+
+// eventsHandlerFacade exposes an events handler as an events facade, so that it can be
+// wrapped by the multicast events facade without the api components of the notifier facade
+type eventsHandlerFacade struct {
+	*eventsHandler
+}
+
+// NewEventsHandlerFacade creates an events facade which handles the observer events with a
+// new events handler
+func NewEventsHandlerFacade(args ArgsEventsHandler) (*eventsHandlerFacade, error) {
+	handler, err := NewEventsHandler(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return &eventsHandlerFacade{
+		eventsHandler: handler,
+	}, nil
+}
+
+// HandlePushEvents will handle the save block events with the events handler
+func (ehf *eventsHandlerFacade) HandlePushEvents(events data.ArgsSaveBlockData) error {
+	return ehf.HandleSaveBlockEvents(events)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (ehf *eventsHandlerFacade) IsInterfaceNil() bool {
+	return ehf == nil
+}
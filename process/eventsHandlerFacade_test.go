@@ -0,0 +1,84 @@
+package process_test
+
+import (
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data/block"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/mocks"
+	"github.com/multiversx/mx-chain-notifier-go/process"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEventsHandlerFacade(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil publisher should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockEventsHandlerArgs()
+		args.Publisher = nil
+
+		ehf, err := process.NewEventsHandlerFacade(args)
+		require.Equal(t, process.ErrNilPublisherService, err)
+		require.True(t, check.IfNil(ehf))
+	})
+
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		ehf, err := process.NewEventsHandlerFacade(createMockEventsHandlerArgs())
+		require.Nil(t, err)
+		require.False(t, check.IfNil(ehf))
+	})
+}
+
+func TestEventsHandlerFacade_HandlePushEvents(t *testing.T) {
+	t.Parallel()
+
+	blockHash := []byte("blockHash")
+	args := createMockEventsHandlerArgs()
+	args.EventsInterceptor = &mocks.EventsInterceptorStub{
+		ProcessBlockEventsCalled: func(eventsData *data.ArgsSaveBlockData) (*data.InterceptorBlockData, error) {
+			return &data.InterceptorBlockData{
+				Hash:      string(blockHash),
+				Header:    &block.HeaderV2{Header: &block.Header{}},
+				LogEvents: []data.Event{{Address: "addr1"}},
+			}, nil
+		},
+	}
+	pushed := make([]data.BlockEvents, 0)
+	args.Publisher = &mocks.PublisherStub{
+		BroadcastCalled: func(events data.BlockEvents) {
+			pushed = append(pushed, events)
+		},
+	}
+
+	ehf, err := process.NewEventsHandlerFacade(args)
+	require.Nil(t, err)
+
+	err = ehf.HandlePushEvents(data.ArgsSaveBlockData{HeaderHash: blockHash})
+	require.Nil(t, err)
+	require.Len(t, pushed, 1)
+	require.Equal(t, string(blockHash), pushed[0].Hash)
+}
+
+func TestEventsHandlerFacade_HandleRevertEvents(t *testing.T) {
+	t.Parallel()
+
+	args := createMockEventsHandlerArgs()
+	reverted := make([]data.RevertBlock, 0)
+	args.Publisher = &mocks.PublisherStub{
+		BroadcastRevertCalled: func(event data.RevertBlock) {
+			reverted = append(reverted, event)
+		},
+	}
+
+	ehf, err := process.NewEventsHandlerFacade(args)
+	require.Nil(t, err)
+
+	err = ehf.HandleRevertEvents(data.RevertBlock{Hash: "hash1"})
+	require.Nil(t, err)
+	require.Equal(t, []data.RevertBlock{{Hash: "hash1"}}, reverted)
+}
@@ -2,7 +2,9 @@ package process_test
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/multiversx/mx-chain-core-go/core/check"
@@ -295,6 +297,113 @@ func TestHandleSaveBlockEvents(t *testing.T) {
 		assert.True(t, scrsWasCalled)
 		assert.True(t, blockEventsWithOrderWasCalled)
 	})
+
+	t.Run("ack after publish should wait for the publish of all the topics", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockEventsHandlerArgs()
+		args.AckAfterPublish = true
+		args.EventsInterceptor = &mocks.EventsInterceptorStub{
+			ProcessBlockEventsCalled: func(eventsData *data.ArgsSaveBlockData) (*data.InterceptorBlockData, error) {
+				return &data.InterceptorBlockData{
+					Hash:     "blockHash1",
+					Header:   &block.HeaderV2{Header: &block.Header{}},
+					TxEvents: []data.TxEvent{{}},
+				}, nil
+			},
+		}
+
+		numCalls := 0
+		args.Publisher = &mocks.PublisherStub{
+			BroadcastCalled: func(events data.BlockEvents) {
+				require.Fail(t, "should have not been called")
+			},
+			BroadcastTxsCalled: func(event data.BlockTxs) {
+				require.Fail(t, "should have not been called")
+			},
+			BroadcastScrsCalled: func(event data.BlockScrs) {
+				require.Fail(t, "should have not been called")
+			},
+			BroadcastBlockEventsWithOrderCalled: func(event data.BlockEventsWithOrder) {
+				require.Fail(t, "should have not been called")
+			},
+			BroadcastTxEventsCalled: func(event data.BlockTxEvents) {
+				require.Fail(t, "should have not been called")
+			},
+			BroadcastWithContextCalled: func(ctx context.Context, events data.BlockEvents) error {
+				numCalls++
+				return nil
+			},
+			BroadcastTxsWithContextCalled: func(ctx context.Context, event data.BlockTxs) error {
+				numCalls++
+				return nil
+			},
+			BroadcastScrsWithContextCalled: func(ctx context.Context, event data.BlockScrs) error {
+				numCalls++
+				return nil
+			},
+			BroadcastBlockEventsWithOrderWithContextCalled: func(ctx context.Context, event data.BlockEventsWithOrder) error {
+				numCalls++
+				return nil
+			},
+			BroadcastTxEventsWithContextCalled: func(ctx context.Context, event data.BlockTxEvents) error {
+				numCalls++
+				return nil
+			},
+		}
+
+		eventsHandler, err := process.NewEventsHandler(args)
+		require.Nil(t, err)
+
+		err = eventsHandler.HandleSaveBlockEvents(data.ArgsSaveBlockData{HeaderHash: []byte("blockHash1")})
+		require.Nil(t, err)
+		require.Equal(t, 5, numCalls)
+	})
+
+	t.Run("ack after publish, failed publish should release the locker key", func(t *testing.T) {
+		t.Parallel()
+
+		headerHash := []byte("blockHash1")
+		expectedErr := errors.New("expected error")
+		args := createMockEventsHandlerArgs()
+		args.AckAfterPublish = true
+		args.CheckDuplicates = true
+		args.EventsInterceptor = &mocks.EventsInterceptorStub{
+			ProcessBlockEventsCalled: func(eventsData *data.ArgsSaveBlockData) (*data.InterceptorBlockData, error) {
+				return &data.InterceptorBlockData{
+					Hash:   "blockHash1",
+					Header: &block.HeaderV2{Header: &block.Header{}},
+				}, nil
+			},
+		}
+		args.Publisher = &mocks.PublisherStub{
+			BroadcastScrsWithContextCalled: func(ctx context.Context, event data.BlockScrs) error {
+				return expectedErr
+			},
+			BroadcastBlockEventsWithOrderWithContextCalled: func(ctx context.Context, event data.BlockEventsWithOrder) error {
+				require.Fail(t, "should have not been called")
+				return nil
+			},
+		}
+
+		removedKey := ""
+		args.Locker = &mocks.LockerStub{
+			IsEventProcessedCalled: func(ctx context.Context, blockHash string) (bool, error) {
+				return true, nil
+			},
+			RemoveEventCalled: func(ctx context.Context, blockHash string) error {
+				removedKey = blockHash
+				return nil
+			},
+		}
+
+		eventsHandler, err := process.NewEventsHandler(args)
+		require.Nil(t, err)
+
+		err = eventsHandler.HandleSaveBlockEvents(data.ArgsSaveBlockData{HeaderHash: headerHash})
+		require.True(t, errors.Is(err, process.ErrPublishFailed))
+		require.Equal(t, hex.EncodeToString(headerHash), removedKey)
+	})
 }
 
 func TestShouldProcessSaveBlockEvents(t *testing.T) {
@@ -386,6 +495,58 @@ func TestHandlePushEvents(t *testing.T) {
 		require.Nil(t, err)
 		require.True(t, wasCalled)
 	})
+
+	t.Run("ack after publish should wait for the publish", func(t *testing.T) {
+		t.Parallel()
+
+		events := data.BlockEvents{
+			Hash:      "hash1",
+			ShardID:   1,
+			TimeStamp: 1234,
+			Events:    []data.Event{},
+		}
+
+		wasCalled := false
+		args := createMockEventsHandlerArgs()
+		args.AckAfterPublish = true
+		args.Publisher = &mocks.PublisherStub{
+			BroadcastCalled: func(evs data.BlockEvents) {
+				require.Fail(t, "should have not been called")
+			},
+			BroadcastWithContextCalled: func(ctx context.Context, evs data.BlockEvents) error {
+				require.Equal(t, events, evs)
+				wasCalled = true
+				return nil
+			},
+		}
+
+		eventsHandler, err := process.NewEventsHandler(args)
+		require.Nil(t, err)
+
+		err = eventsHandler.HandlePushEvents(events)
+		require.Nil(t, err)
+		require.True(t, wasCalled)
+	})
+
+	t.Run("ack after publish, failed publish should error", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		args := createMockEventsHandlerArgs()
+		args.AckAfterPublish = true
+		args.Publisher = &mocks.PublisherStub{
+			BroadcastWithContextCalled: func(ctx context.Context, evs data.BlockEvents) error {
+				return expectedErr
+			},
+		}
+
+		eventsHandler, err := process.NewEventsHandler(args)
+		require.Nil(t, err)
+
+		err = eventsHandler.HandlePushEvents(data.BlockEvents{Hash: "hash1"})
+		require.True(t, errors.Is(err, process.ErrPublishFailed))
+		require.True(t, strings.Contains(err.Error(), expectedErr.Error()))
+	})
 }
 
 func TestHandleRevertEvents(t *testing.T) {
@@ -443,6 +604,66 @@ func TestHandleRevertEvents(t *testing.T) {
 		eventsHandler.HandleRevertEvents(revertEvents)
 		require.False(t, wasCalled)
 	})
+
+	t.Run("ack after publish, failed publish should release the locker key", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		args := createMockEventsHandlerArgs()
+		args.AckAfterPublish = true
+		args.CheckDuplicates = true
+		args.Publisher = &mocks.PublisherStub{
+			BroadcastRevertWithContextCalled: func(ctx context.Context, event data.RevertBlock) error {
+				return expectedErr
+			},
+		}
+
+		removedKey := ""
+		args.Locker = &mocks.LockerStub{
+			IsEventProcessedCalled: func(ctx context.Context, blockHash string) (bool, error) {
+				return true, nil
+			},
+			RemoveEventCalled: func(ctx context.Context, blockHash string) error {
+				removedKey = blockHash
+				return nil
+			},
+		}
+
+		eventsHandler, err := process.NewEventsHandler(args)
+		require.Nil(t, err)
+
+		err = eventsHandler.HandleRevertEvents(data.RevertBlock{Hash: "hash1", Nonce: 1})
+		require.True(t, errors.Is(err, process.ErrPublishFailed))
+		require.Equal(t, "revert_hash1", removedKey)
+	})
+
+	t.Run("ack after publish, successful publish should keep the locker key", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockEventsHandlerArgs()
+		args.AckAfterPublish = true
+		args.CheckDuplicates = true
+		args.Publisher = &mocks.PublisherStub{
+			BroadcastRevertWithContextCalled: func(ctx context.Context, event data.RevertBlock) error {
+				return nil
+			},
+		}
+		args.Locker = &mocks.LockerStub{
+			IsEventProcessedCalled: func(ctx context.Context, blockHash string) (bool, error) {
+				return true, nil
+			},
+			RemoveEventCalled: func(ctx context.Context, blockHash string) error {
+				require.Fail(t, "should have not been called")
+				return nil
+			},
+		}
+
+		eventsHandler, err := process.NewEventsHandler(args)
+		require.Nil(t, err)
+
+		err = eventsHandler.HandleRevertEvents(data.RevertBlock{Hash: "hash1", Nonce: 1})
+		require.Nil(t, err)
+	})
 }
 
 func TestHandleFinalizedEvents(t *testing.T) {
@@ -498,6 +719,66 @@ func TestHandleFinalizedEvents(t *testing.T) {
 		eventsHandler.HandleFinalizedEvents(events)
 		require.False(t, wasCalled)
 	})
+
+	t.Run("ack after publish, failed publish should release the locker key", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		args := createMockEventsHandlerArgs()
+		args.AckAfterPublish = true
+		args.CheckDuplicates = true
+		args.Publisher = &mocks.PublisherStub{
+			BroadcastFinalizedWithContextCalled: func(ctx context.Context, event data.FinalizedBlock) error {
+				return expectedErr
+			},
+		}
+
+		removedKey := ""
+		args.Locker = &mocks.LockerStub{
+			IsEventProcessedCalled: func(ctx context.Context, blockHash string) (bool, error) {
+				return true, nil
+			},
+			RemoveEventCalled: func(ctx context.Context, blockHash string) error {
+				removedKey = blockHash
+				return nil
+			},
+		}
+
+		eventsHandler, err := process.NewEventsHandler(args)
+		require.Nil(t, err)
+
+		err = eventsHandler.HandleFinalizedEvents(data.FinalizedBlock{Hash: "hash1"})
+		require.True(t, errors.Is(err, process.ErrPublishFailed))
+		require.Equal(t, "finalized_hash1", removedKey)
+	})
+
+	t.Run("ack after publish, successful publish should keep the locker key", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockEventsHandlerArgs()
+		args.AckAfterPublish = true
+		args.CheckDuplicates = true
+		args.Publisher = &mocks.PublisherStub{
+			BroadcastFinalizedWithContextCalled: func(ctx context.Context, event data.FinalizedBlock) error {
+				return nil
+			},
+		}
+		args.Locker = &mocks.LockerStub{
+			IsEventProcessedCalled: func(ctx context.Context, blockHash string) (bool, error) {
+				return true, nil
+			},
+			RemoveEventCalled: func(ctx context.Context, blockHash string) error {
+				require.Fail(t, "should have not been called")
+				return nil
+			},
+		}
+
+		eventsHandler, err := process.NewEventsHandler(args)
+		require.Nil(t, err)
+
+		err = eventsHandler.HandleFinalizedEvents(data.FinalizedBlock{Hash: "hash1"})
+		require.Nil(t, err)
+	})
 }
 
 func TestHandleTxsEvents(t *testing.T) {
@@ -599,6 +880,189 @@ func TestHandleBlockEventsWithOrderEvents(t *testing.T) {
 	})
 }
 
+func TestHandleBlockTxEvents(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty tx events, should not broadcast", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockEventsHandlerArgs()
+		args.Publisher = &mocks.PublisherStub{
+			BroadcastTxEventsCalled: func(event data.BlockTxEvents) {
+				require.Fail(t, "should not have been called")
+			},
+		}
+
+		eventsHandler, err := process.NewEventsHandler(args)
+		require.Nil(t, err)
+
+		eventsHandler.HandleBlockTxEvents(data.BlockTxEvents{Hash: "hash1"})
+	})
+
+	t.Run("broadcast tx events was called", func(t *testing.T) {
+		t.Parallel()
+
+		blockTxEvents := data.BlockTxEvents{
+			Hash: "hash1",
+			TxEvents: []data.TxEvent{
+				{
+					Hash:   "txHash1",
+					Status: common.TxStatusSuccess,
+				},
+			},
+		}
+
+		wasCalled := false
+		args := createMockEventsHandlerArgs()
+		args.Publisher = &mocks.PublisherStub{
+			BroadcastTxEventsCalled: func(event data.BlockTxEvents) {
+				require.Equal(t, blockTxEvents, event)
+				wasCalled = true
+			},
+		}
+
+		eventsHandler, err := process.NewEventsHandler(args)
+		require.Nil(t, err)
+
+		eventsHandler.HandleBlockTxEvents(blockTxEvents)
+		require.True(t, wasCalled)
+	})
+}
+
+func TestHandleRoundEvents(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no rounds, should not broadcast", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockEventsHandlerArgs()
+		args.Publisher = &mocks.PublisherStub{
+			BroadcastRoundsCalled: func(event data.RoundEvents) {
+				require.Fail(t, "should not have been called")
+			},
+		}
+
+		eventsHandler, err := process.NewEventsHandler(args)
+		require.Nil(t, err)
+
+		eventsHandler.HandleRoundEvents(data.RoundEvents{ShardID: 1})
+	})
+
+	t.Run("broadcast rounds was called", func(t *testing.T) {
+		t.Parallel()
+
+		roundEvents := data.RoundEvents{
+			ShardID: 1,
+			Rounds:  []data.RoundInfo{{Round: 10, BlockWasProposed: true}, {Round: 11}},
+		}
+
+		wasCalled := false
+		args := createMockEventsHandlerArgs()
+		args.Publisher = &mocks.PublisherStub{
+			BroadcastRoundsCalled: func(event data.RoundEvents) {
+				require.Equal(t, roundEvents, event)
+				wasCalled = true
+			},
+		}
+
+		eventsHandler, err := process.NewEventsHandler(args)
+		require.Nil(t, err)
+
+		eventsHandler.HandleRoundEvents(roundEvents)
+		require.True(t, wasCalled)
+	})
+}
+
+func TestHandleValidatorsRatingEvent(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty validators rating, should not broadcast", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockEventsHandlerArgs()
+		args.Publisher = &mocks.PublisherStub{
+			BroadcastValidatorsRatingCalled: func(event data.ValidatorsRatingEvent) {
+				require.Fail(t, "should not have been called")
+			},
+		}
+
+		eventsHandler, err := process.NewEventsHandler(args)
+		require.Nil(t, err)
+
+		eventsHandler.HandleValidatorsRatingEvent(data.ValidatorsRatingEvent{ShardID: 1, Epoch: 5})
+	})
+
+	t.Run("broadcast validators rating was called", func(t *testing.T) {
+		t.Parallel()
+
+		validatorsRating := data.ValidatorsRatingEvent{
+			ShardID:          1,
+			Epoch:            5,
+			ValidatorsRating: []data.ValidatorRating{{PublicKey: "pubKey1", Rating: 50.5}},
+		}
+
+		wasCalled := false
+		args := createMockEventsHandlerArgs()
+		args.Publisher = &mocks.PublisherStub{
+			BroadcastValidatorsRatingCalled: func(event data.ValidatorsRatingEvent) {
+				require.Equal(t, validatorsRating, event)
+				wasCalled = true
+			},
+		}
+
+		eventsHandler, err := process.NewEventsHandler(args)
+		require.Nil(t, err)
+
+		eventsHandler.HandleValidatorsRatingEvent(validatorsRating)
+		require.True(t, wasCalled)
+	})
+}
+
+func TestHandleAccountsEvents(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no altered accounts, should not broadcast", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockEventsHandlerArgs()
+		args.Publisher = &mocks.PublisherStub{
+			BroadcastAccountsCalled: func(event data.AccountsEvents) {
+				require.Fail(t, "should not have been called")
+			},
+		}
+
+		eventsHandler, err := process.NewEventsHandler(args)
+		require.Nil(t, err)
+
+		eventsHandler.HandleAccountsEvents(data.AccountsEvents{ShardID: 1, BlockTimestamp: 1234})
+	})
+
+	t.Run("broadcast accounts was called", func(t *testing.T) {
+		t.Parallel()
+
+		accountsEvents := data.AccountsEvents{
+			ShardID:        1,
+			BlockTimestamp: 1234,
+			Accounts:       []data.AccountEvent{{Address: "erd1alice", Nonce: 3, Balance: "1000"}},
+		}
+
+		wasCalled := false
+		args := createMockEventsHandlerArgs()
+		args.Publisher = &mocks.PublisherStub{
+			BroadcastAccountsCalled: func(event data.AccountsEvents) {
+				require.Equal(t, accountsEvents, event)
+				wasCalled = true
+			},
+		}
+
+		eventsHandler, err := process.NewEventsHandler(args)
+		require.Nil(t, err)
+
+		eventsHandler.HandleAccountsEvents(accountsEvents)
+		require.True(t, wasCalled)
+	})
+}
+
 func TestTryCheckProcessedWithRetry(t *testing.T) {
 	t.Parallel()
 
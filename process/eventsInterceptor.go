@@ -2,13 +2,16 @@ package process
 
 import (
 	"encoding/hex"
+	"sort"
 
 	"github.com/multiversx/mx-chain-core-go/core"
 	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/core/sharding"
 	nodeData "github.com/multiversx/mx-chain-core-go/data"
 	"github.com/multiversx/mx-chain-core-go/data/outport"
 	"github.com/multiversx/mx-chain-core-go/data/smartContractResult"
 	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-notifier-go/common"
 	"github.com/multiversx/mx-chain-notifier-go/data"
 )
 
@@ -16,15 +19,31 @@ import (
 type logEvent struct {
 	EventHandler nodeData.EventHandler
 	TxHash       string
+	Index        uint32
+}
+
+// txEventInfo defines a tx event associated with the transaction execution order
+type txEventInfo struct {
+	txEvent        data.TxEvent
+	executionOrder uint32
 }
 
 // ArgsEventsInterceptor defines the arguments needed for creating an events interceptor instance
 type ArgsEventsInterceptor struct {
 	PubKeyConverter core.PubkeyConverter
+
+	// WithTxStatusAndFee adds the status and the fee of the originating transaction to
+	// each log event, increasing the size of the pushed events
+	WithTxStatusAndFee bool
+
+	// WithTokenTransfers adds the decoded token transfers to the ESDT/NFT transfer events
+	WithTokenTransfers bool
 }
 
 type eventsInterceptor struct {
-	pubKeyConverter core.PubkeyConverter
+	pubKeyConverter    core.PubkeyConverter
+	withTxStatusAndFee bool
+	withTokenTransfers bool
 }
 
 // NewEventsInterceptor creates a new eventsInterceptor instance
@@ -34,7 +53,9 @@ func NewEventsInterceptor(args ArgsEventsInterceptor) (*eventsInterceptor, error
 	}
 
 	return &eventsInterceptor{
-		pubKeyConverter: args.PubKeyConverter,
+		pubKeyConverter:    args.PubKeyConverter,
+		withTxStatusAndFee: args.WithTxStatusAndFee,
+		withTokenTransfers: args.WithTokenTransfers,
 	}, nil
 }
 
@@ -54,6 +75,12 @@ func (ei *eventsInterceptor) ProcessBlockEvents(eventsData *data.ArgsSaveBlockDa
 	}
 
 	events := ei.getLogEventsFromTransactionsPool(eventsData.TransactionsPool.Logs)
+	tagCrossShardEvents(events, eventsData.TransactionsPool, eventsData.Header.GetShardID(), eventsData.NumberOfShards)
+	ei.setTxContext(events, eventsData.TransactionsPool)
+	ei.setTokenTransfers(events)
+	for i := range events {
+		events[i].BlockNonce = eventsData.Header.GetNonce()
+	}
 
 	txs := make(map[string]*transaction.Transaction)
 	for hash, tx := range eventsData.TransactionsPool.Transactions {
@@ -67,6 +94,8 @@ func (ei *eventsInterceptor) ProcessBlockEvents(eventsData *data.ArgsSaveBlockDa
 	}
 	scrsWithOrder := eventsData.TransactionsPool.SmartContractResults
 
+	txEvents := ei.getTxEventsFromTransactionsPool(eventsData.TransactionsPool, events)
+
 	return &data.InterceptorBlockData{
 		Hash:          hex.EncodeToString(eventsData.HeaderHash),
 		Body:          eventsData.Body,
@@ -76,9 +105,80 @@ func (ei *eventsInterceptor) ProcessBlockEvents(eventsData *data.ArgsSaveBlockDa
 		Scrs:          scrs,
 		ScrsWithOrder: scrsWithOrder,
 		LogEvents:     events,
+		TxEvents:      txEvents,
 	}, nil
 }
 
+// getTxEventsFromTransactionsPool creates the per transaction notifications, ordered by execution order
+// Smart contract results are not included, they are only available through block scrs events
+func (ei *eventsInterceptor) getTxEventsFromTransactionsPool(pool *outport.TransactionPool, events []data.Event) []data.TxEvent {
+	failedTxs := make(map[string]struct{})
+	for _, event := range events {
+		if event.Identifier == core.SignalErrorOperation {
+			failedTxs[event.TxHash] = struct{}{}
+		}
+	}
+
+	txsInfo := make([]*txEventInfo, 0, len(pool.Transactions)+len(pool.InvalidTxs))
+	for hash, tx := range pool.Transactions {
+		status := common.TxStatusSuccess
+		if _, isFailed := failedTxs[hash]; isFailed {
+			status = common.TxStatusFail
+		}
+		txsInfo = ei.appendTxEventInfo(txsInfo, hash, tx, status)
+	}
+	for hash, tx := range pool.InvalidTxs {
+		txsInfo = ei.appendTxEventInfo(txsInfo, hash, tx, common.TxStatusInvalid)
+	}
+
+	sort.Slice(txsInfo, func(i, j int) bool {
+		if txsInfo[i].executionOrder == txsInfo[j].executionOrder {
+			return txsInfo[i].txEvent.Hash < txsInfo[j].txEvent.Hash
+		}
+		return txsInfo[i].executionOrder < txsInfo[j].executionOrder
+	})
+
+	txEvents := make([]data.TxEvent, 0, len(txsInfo))
+	for _, txInfo := range txsInfo {
+		txEvents = append(txEvents, txInfo.txEvent)
+	}
+
+	return txEvents
+}
+
+func (ei *eventsInterceptor) appendTxEventInfo(txsInfo []*txEventInfo, hash string, tx *outport.TxInfo, status string) []*txEventInfo {
+	if tx == nil || tx.Transaction == nil {
+		return txsInfo
+	}
+
+	sender, err := ei.pubKeyConverter.Encode(tx.Transaction.GetSndAddr())
+	if err != nil {
+		log.Error("eventsInterceptor: failed to encode tx sender address", "txHash", hash, "error", err)
+		return txsInfo
+	}
+	receiver, err := ei.pubKeyConverter.Encode(tx.Transaction.GetRcvAddr())
+	if err != nil {
+		log.Error("eventsInterceptor: failed to encode tx receiver address", "txHash", hash, "error", err)
+		return txsInfo
+	}
+
+	value := "0"
+	if tx.Transaction.GetValue() != nil {
+		value = tx.Transaction.GetValue().String()
+	}
+
+	return append(txsInfo, &txEventInfo{
+		txEvent: data.TxEvent{
+			Hash:     hash,
+			Sender:   sender,
+			Receiver: receiver,
+			Value:    value,
+			Status:   status,
+		},
+		executionOrder: tx.ExecutionOrder,
+	})
+}
+
 func (ei *eventsInterceptor) getLogEventsFromTransactionsPool(logs []*outport.LogData) []data.Event {
 	var logEvents []*logEvent
 	for _, logData := range logs {
@@ -89,11 +189,11 @@ func (ei *eventsInterceptor) getLogEventsFromTransactionsPool(logs []*outport.Lo
 			continue
 		}
 
-		for _, event := range logData.Log.Events {
-
+		for index, event := range logData.Log.Events {
 			le := &logEvent{
 				EventHandler: event,
 				TxHash:       logData.TxHash,
+				Index:        uint32(index),
 			}
 
 			logEvents = append(logEvents, le)
@@ -128,12 +228,134 @@ func (ei *eventsInterceptor) getLogEventsFromTransactionsPool(logs []*outport.Lo
 			Topics:     event.EventHandler.GetTopics(),
 			Data:       event.EventHandler.GetData(),
 			TxHash:     event.TxHash,
+			EventIndex: event.Index,
 		})
 	}
 
 	return events
 }
 
+// setTxContext sets the original transaction hash on the events of the smart contract results
+// and, if enabled, the status and the fee of the transaction which originated each event
+func (ei *eventsInterceptor) setTxContext(events []data.Event, pool *outport.TransactionPool) {
+	for i := range events {
+		scr, ok := pool.SmartContractResults[events[i].TxHash]
+		if ok && scr != nil && scr.SmartContractResult != nil && len(scr.SmartContractResult.OriginalTxHash) > 0 {
+			events[i].OriginalTxHash = hex.EncodeToString(scr.SmartContractResult.OriginalTxHash)
+		}
+	}
+
+	if !ei.withTxStatusAndFee {
+		return
+	}
+
+	failedTxs := make(map[string]struct{})
+	for _, event := range events {
+		if event.Identifier == core.SignalErrorOperation {
+			failedTxs[getOriginatingTxHash(event)] = struct{}{}
+		}
+	}
+
+	for i := range events {
+		events[i].TxStatus, events[i].TxFee = getTxStatusAndFee(pool, getOriginatingTxHash(events[i]), failedTxs)
+	}
+}
+
+// setTokenTransfers decodes the token transfers of the ESDT/NFT transfer events, if enabled. The
+// events with unexpected topics are published without the decoded transfers
+func (ei *eventsInterceptor) setTokenTransfers(events []data.Event) {
+	if !ei.withTokenTransfers {
+		return
+	}
+
+	for i := range events {
+		if _, isTransfer := tokenTransferIdentifiers[events[i].Identifier]; !isTransfer {
+			continue
+		}
+
+		transfers, ok := decodeTokenTransfers(events[i], ei.pubKeyConverter)
+		if !ok {
+			log.Debug("eventsInterceptor: could not decode token transfer event",
+				"identifier", events[i].Identifier,
+				"txHash", events[i].TxHash,
+				"num topics", len(events[i].Topics),
+			)
+			continue
+		}
+		events[i].Transfers = transfers
+	}
+}
+
+func getOriginatingTxHash(event data.Event) string {
+	if event.OriginalTxHash != "" {
+		return event.OriginalTxHash
+	}
+
+	return event.TxHash
+}
+
+// getTxStatusAndFee returns empty values if the transaction is not in the block, e.g. for the
+// events of the smart contract results of a transaction executed in a previous block
+func getTxStatusAndFee(pool *outport.TransactionPool, txHash string, failedTxs map[string]struct{}) (string, string) {
+	tx, ok := pool.Transactions[txHash]
+	if ok && tx != nil {
+		status := common.TxStatusSuccess
+		if _, isFailed := failedTxs[txHash]; isFailed {
+			status = common.TxStatusFail
+		}
+		return status, getTxFee(tx.FeeInfo)
+	}
+
+	tx, ok = pool.InvalidTxs[txHash]
+	if ok && tx != nil {
+		return common.TxStatusInvalid, getTxFee(tx.FeeInfo)
+	}
+
+	return "", ""
+}
+
+func getTxFee(feeInfo *outport.FeeInfo) string {
+	if feeInfo == nil || feeInfo.Fee == nil {
+		return "0"
+	}
+
+	return feeInfo.Fee.String()
+}
+
+// tagCrossShardEvents marks the events generated by cross shard transactions or smart contract
+// results which completed in this block, on their destination shard. The shards are computed
+// from the sender and receiver addresses, so no event is marked if the number of shards is not known
+func tagCrossShardEvents(events []data.Event, pool *outport.TransactionPool, selfShardID uint32, numberOfShards uint32) {
+	if numberOfShards == 0 {
+		return
+	}
+
+	for i := range events {
+		sender, receiver, found := getSourceTxAddresses(pool, events[i].TxHash)
+		if !found {
+			continue
+		}
+
+		senderShardID := sharding.ComputeShardID(sender, numberOfShards)
+		receiverShardID := sharding.ComputeShardID(receiver, numberOfShards)
+		events[i].CrossShard = senderShardID != receiverShardID && receiverShardID == selfShardID
+	}
+}
+
+func getSourceTxAddresses(pool *outport.TransactionPool, txHash string) ([]byte, []byte, bool) {
+	tx, ok := pool.Transactions[txHash]
+	if ok && tx != nil && tx.Transaction != nil {
+		return tx.Transaction.GetSndAddr(), tx.Transaction.GetRcvAddr(), true
+	}
+
+	scr, ok := pool.SmartContractResults[txHash]
+	if ok && scr != nil && scr.SmartContractResult != nil {
+		return scr.SmartContractResult.GetSndAddr(), scr.SmartContractResult.GetRcvAddr(), true
+	}
+
+	return nil, nil, false
+}
+
 // IsInterfaceNil returns whether the interface is nil
 func (ei *eventsInterceptor) IsInterfaceNil() bool {
 	return ei == nil
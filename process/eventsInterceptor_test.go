@@ -2,13 +2,17 @@ package process_test
 
 import (
 	"encoding/hex"
+	"math/big"
 	"testing"
 
+	"github.com/multiversx/mx-chain-core-go/core"
 	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/core/pubkeyConverter"
 	"github.com/multiversx/mx-chain-core-go/data/block"
 	"github.com/multiversx/mx-chain-core-go/data/outport"
 	"github.com/multiversx/mx-chain-core-go/data/smartContractResult"
 	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-notifier-go/common"
 	"github.com/multiversx/mx-chain-notifier-go/data"
 	"github.com/multiversx/mx-chain-notifier-go/mocks"
 	"github.com/multiversx/mx-chain-notifier-go/process"
@@ -129,6 +133,7 @@ func TestProcessBlockEvents(t *testing.T) {
 		}
 		blockHeader := &block.HeaderV2{
 			Header: &block.Header{
+				Nonce:     5,
 				ShardID:   1,
 				TimeStamp: 1234,
 			},
@@ -196,7 +201,15 @@ func TestProcessBlockEvents(t *testing.T) {
 			ScrsWithOrder: expScrsWithOrder,
 			LogEvents: []data.Event{
 				{
-					Address: hex.EncodeToString(addr),
+					Address:    hex.EncodeToString(addr),
+					BlockNonce: 5,
+				},
+			},
+			TxEvents: []data.TxEvent{
+				{
+					Hash:   "hash2",
+					Value:  "0",
+					Status: common.TxStatusSuccess,
 				},
 			},
 		}
@@ -207,6 +220,228 @@ func TestProcessBlockEvents(t *testing.T) {
 	})
 }
 
+func TestProcessBlockEvents_CrossShardEvents(t *testing.T) {
+	t.Parallel()
+
+	// with 3 shards, the last byte of the address selects the shard
+	shard0Addr := []byte("addr0")
+	shard0Addr[len(shard0Addr)-1] = 0
+	shard1Addr := []byte("addr1")
+	shard1Addr[len(shard1Addr)-1] = 1
+	shard2Addr := []byte("addr2")
+	shard2Addr[len(shard2Addr)-1] = 2
+
+	createLog := func(txHash string) *outport.LogData {
+		return &outport.LogData{
+			TxHash: txHash,
+			Log: &transaction.Log{
+				Events: []*transaction.Event{{Address: shard1Addr, Identifier: []byte(txHash)}},
+			},
+		}
+	}
+
+	createBlockData := func(numberOfShards uint32) *data.ArgsSaveBlockData {
+		return &data.ArgsSaveBlockData{
+			HeaderHash: []byte("blockHash"),
+			Body:       &block.Body{},
+			Header: &block.HeaderV2{
+				Header: &block.Header{ShardID: 1},
+			},
+			NumberOfShards: numberOfShards,
+			TransactionsPool: &outport.TransactionPool{
+				Transactions: map[string]*outport.TxInfo{
+					"intraShardTx": {Transaction: &transaction.Transaction{SndAddr: shard1Addr, RcvAddr: shard1Addr}},
+					"crossShardTx": {Transaction: &transaction.Transaction{SndAddr: shard0Addr, RcvAddr: shard1Addr}},
+					"outgoingTx":   {Transaction: &transaction.Transaction{SndAddr: shard1Addr, RcvAddr: shard2Addr}},
+				},
+				SmartContractResults: map[string]*outport.SCRInfo{
+					"crossShardScr": {SmartContractResult: &smartContractResult.SmartContractResult{SndAddr: shard2Addr, RcvAddr: shard1Addr}},
+				},
+				Logs: []*outport.LogData{
+					createLog("intraShardTx"),
+					createLog("crossShardTx"),
+					createLog("outgoingTx"),
+					createLog("crossShardScr"),
+					createLog("unknownTx"),
+				},
+			},
+		}
+	}
+
+	t.Run("should tag the events of the cross shard txs completed in the block", func(t *testing.T) {
+		t.Parallel()
+
+		eventsInterceptor, _ := process.NewEventsInterceptor(createMockEventsInterceptorArgs())
+
+		events, err := eventsInterceptor.ProcessBlockEvents(createBlockData(3))
+		require.Nil(t, err)
+
+		crossShardEvents := make(map[string]bool)
+		for _, event := range events.LogEvents {
+			crossShardEvents[event.TxHash] = event.CrossShard
+		}
+
+		expectedCrossShardEvents := map[string]bool{
+			"intraShardTx":  false,
+			"crossShardTx":  true,
+			"outgoingTx":    false,
+			"crossShardScr": true,
+			"unknownTx":     false,
+		}
+		require.Equal(t, expectedCrossShardEvents, crossShardEvents)
+	})
+
+	t.Run("unknown number of shards, should not tag events", func(t *testing.T) {
+		t.Parallel()
+
+		eventsInterceptor, _ := process.NewEventsInterceptor(createMockEventsInterceptorArgs())
+
+		events, err := eventsInterceptor.ProcessBlockEvents(createBlockData(0))
+		require.Nil(t, err)
+		require.Equal(t, 5, len(events.LogEvents))
+		for _, event := range events.LogEvents {
+			require.False(t, event.CrossShard)
+		}
+	})
+}
+
+func TestProcessBlockEvents_TxContext(t *testing.T) {
+	t.Parallel()
+
+	createLog := func(txHash string, identifiers ...string) *outport.LogData {
+		logData := &outport.LogData{TxHash: txHash, Log: &transaction.Log{}}
+		for _, identifier := range identifiers {
+			logData.Log.Events = append(logData.Log.Events, &transaction.Event{Identifier: []byte(identifier)})
+		}
+		return logData
+	}
+
+	blockData := &data.ArgsSaveBlockData{
+		HeaderHash: []byte("blockHash"),
+		Body:       &block.Body{},
+		Header:     &block.HeaderV2{Header: &block.Header{}},
+		TransactionsPool: &outport.TransactionPool{
+			Transactions: map[string]*outport.TxInfo{
+				"aa01": {Transaction: &transaction.Transaction{}, FeeInfo: &outport.FeeInfo{Fee: big.NewInt(100)}},
+				"bb02": {Transaction: &transaction.Transaction{}, FeeInfo: &outport.FeeInfo{Fee: big.NewInt(50)}},
+			},
+			InvalidTxs: map[string]*outport.TxInfo{
+				"cc03": {Transaction: &transaction.Transaction{}, FeeInfo: &outport.FeeInfo{Fee: big.NewInt(10)}},
+			},
+			SmartContractResults: map[string]*outport.SCRInfo{
+				"dd04": {SmartContractResult: &smartContractResult.SmartContractResult{OriginalTxHash: []byte{0xaa, 0x01}}},
+				"ee05": {SmartContractResult: &smartContractResult.SmartContractResult{OriginalTxHash: []byte{0xff, 0x06}}},
+			},
+			Logs: []*outport.LogData{
+				createLog("aa01", "transfer", "completedTxEvent"),
+				createLog("bb02", core.SignalErrorOperation),
+				createLog("cc03", "transfer"),
+				createLog("dd04", "transfer"),
+				createLog("ee05", "transfer"),
+			},
+		},
+	}
+
+	t.Run("should set the index and the original tx hash", func(t *testing.T) {
+		t.Parallel()
+
+		eventsInterceptor, _ := process.NewEventsInterceptor(createMockEventsInterceptorArgs())
+
+		events, err := eventsInterceptor.ProcessBlockEvents(blockData)
+		require.Nil(t, err)
+
+		expectedEvents := []data.Event{
+			{Identifier: "transfer", TxHash: "aa01"},
+			{Identifier: "completedTxEvent", TxHash: "aa01", EventIndex: 1},
+			{Identifier: core.SignalErrorOperation, TxHash: "bb02"},
+			{Identifier: "transfer", TxHash: "cc03"},
+			{Identifier: "transfer", TxHash: "dd04", OriginalTxHash: "aa01"},
+			{Identifier: "transfer", TxHash: "ee05", OriginalTxHash: "ff06"},
+		}
+		require.Equal(t, expectedEvents, events.LogEvents)
+	})
+
+	t.Run("should set the status and the fee of the originating tx", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockEventsInterceptorArgs()
+		args.WithTxStatusAndFee = true
+		eventsInterceptor, _ := process.NewEventsInterceptor(args)
+
+		events, err := eventsInterceptor.ProcessBlockEvents(blockData)
+		require.Nil(t, err)
+
+		expectedEvents := []data.Event{
+			{Identifier: "transfer", TxHash: "aa01", TxStatus: common.TxStatusSuccess, TxFee: "100"},
+			{Identifier: "completedTxEvent", TxHash: "aa01", EventIndex: 1, TxStatus: common.TxStatusSuccess, TxFee: "100"},
+			{Identifier: core.SignalErrorOperation, TxHash: "bb02", TxStatus: common.TxStatusFail, TxFee: "50"},
+			{Identifier: "transfer", TxHash: "cc03", TxStatus: common.TxStatusInvalid, TxFee: "10"},
+			{Identifier: "transfer", TxHash: "dd04", OriginalTxHash: "aa01", TxStatus: common.TxStatusSuccess, TxFee: "100"},
+			{Identifier: "transfer", TxHash: "ee05", OriginalTxHash: "ff06"},
+		}
+		require.Equal(t, expectedEvents, events.LogEvents)
+	})
+}
+
+func TestProcessBlockEvents_TokenTransfers(t *testing.T) {
+	t.Parallel()
+
+	converter, _ := pubkeyConverter.NewBech32PubkeyConverter(32, "erd")
+	receiver, _ := converter.Decode(bobAddress)
+	sender, _ := converter.Decode(aliceAddress)
+
+	transferEvent := &transaction.Event{
+		Address:    sender,
+		Identifier: []byte(core.BuiltInFunctionESDTTransfer),
+		Topics:     [][]byte{[]byte("WEGLD-bd4d79"), {}, big.NewInt(1000).Bytes(), receiver},
+	}
+	malformedEvent := &transaction.Event{
+		Address:    sender,
+		Identifier: []byte(core.BuiltInFunctionMultiESDTNFTTransfer),
+		Topics:     [][]byte{[]byte("WEGLD-bd4d79"), {}, receiver},
+	}
+	blockData := &data.ArgsSaveBlockData{
+		HeaderHash: []byte("blockHash"),
+		Body:       &block.Body{},
+		Header:     &block.HeaderV2{Header: &block.Header{}},
+		TransactionsPool: &outport.TransactionPool{
+			Logs: []*outport.LogData{
+				{TxHash: "aa01", Log: &transaction.Log{Events: []*transaction.Event{transferEvent, malformedEvent}}},
+			},
+		},
+	}
+
+	t.Run("disabled should not decode the transfers", func(t *testing.T) {
+		t.Parallel()
+
+		eventsInterceptor, _ := process.NewEventsInterceptor(process.ArgsEventsInterceptor{PubKeyConverter: converter})
+
+		events, err := eventsInterceptor.ProcessBlockEvents(blockData)
+		require.Nil(t, err)
+		require.Equal(t, 2, len(events.LogEvents))
+		require.Nil(t, events.LogEvents[0].Transfers)
+		require.Nil(t, events.LogEvents[1].Transfers)
+	})
+
+	t.Run("should decode the transfers and keep the malformed events", func(t *testing.T) {
+		t.Parallel()
+
+		eventsInterceptor, _ := process.NewEventsInterceptor(process.ArgsEventsInterceptor{
+			PubKeyConverter:    converter,
+			WithTokenTransfers: true,
+		})
+
+		events, err := eventsInterceptor.ProcessBlockEvents(blockData)
+		require.Nil(t, err)
+		require.Equal(t, 2, len(events.LogEvents))
+		require.Equal(t, []data.TokenTransfer{
+			{Token: "WEGLD-bd4d79", Nonce: 0, Value: "1000", Receiver: bobAddress},
+		}, events.LogEvents[0].Transfers)
+		require.Equal(t, malformedEvent.Topics, events.LogEvents[1].Topics)
+		require.Nil(t, events.LogEvents[1].Transfers)
+	})
+}
+
 func TestGetLogEventsFromTransactionsPool(t *testing.T) {
 	t.Parallel()
 
@@ -263,3 +498,105 @@ func TestGetLogEventsFromTransactionsPool(t *testing.T) {
 	require.Equal(t, txHash1, receivedEvents[1].TxHash)
 	require.Equal(t, txHash2, receivedEvents[2].TxHash)
 }
+
+func TestGetLogEventsFromTransactionsPool_FilteredEvents(t *testing.T) {
+	t.Parallel()
+
+	// the events dropped by the blacklist are set to nil, so that the others keep their index
+	logs := []*outport.LogData{
+		{
+			Log: &transaction.Log{
+				Events: []*transaction.Event{
+					nil,
+					{Address: []byte("addr1"), Identifier: []byte("transfer")},
+				},
+			},
+			TxHash: "txHash1",
+		},
+		{
+			Log:    &transaction.Log{Events: []*transaction.Event{nil}},
+			TxHash: "txHash2",
+		},
+	}
+
+	en, _ := process.NewEventsInterceptor(createMockEventsInterceptorArgs())
+
+	receivedEvents := en.GetLogEventsFromTransactionsPool(logs)
+	require.Equal(t, 1, len(receivedEvents))
+	require.Equal(t, "transfer", receivedEvents[0].Identifier)
+	require.Equal(t, uint32(1), receivedEvents[0].EventIndex)
+}
+
+func TestGetTxEventsFromTransactionsPool(t *testing.T) {
+	t.Parallel()
+
+	sender := []byte("sender")
+	receiver := []byte("receiver")
+
+	pool := &outport.TransactionPool{
+		Transactions: map[string]*outport.TxInfo{
+			"txHash1": {
+				Transaction: &transaction.Transaction{
+					SndAddr: sender,
+					RcvAddr: receiver,
+					Value:   big.NewInt(10),
+				},
+				ExecutionOrder: 2,
+			},
+			"txHash2": {
+				Transaction: &transaction.Transaction{
+					SndAddr: receiver,
+					RcvAddr: sender,
+					Value:   big.NewInt(20),
+				},
+				ExecutionOrder: 1,
+			},
+			"txHash3": nil,
+		},
+		InvalidTxs: map[string]*outport.TxInfo{
+			"txHash4": {
+				Transaction: &transaction.Transaction{
+					SndAddr: sender,
+					RcvAddr: sender,
+				},
+				ExecutionOrder: 3,
+			},
+		},
+	}
+	logEvents := []data.Event{
+		{
+			Identifier: core.SignalErrorOperation,
+			TxHash:     "txHash1",
+		},
+	}
+
+	args := createMockEventsInterceptorArgs()
+	en, _ := process.NewEventsInterceptor(args)
+
+	expTxEvents := []data.TxEvent{
+		{
+			Hash:     "txHash2",
+			Sender:   hex.EncodeToString(receiver),
+			Receiver: hex.EncodeToString(sender),
+			Value:    "20",
+			Status:   common.TxStatusSuccess,
+		},
+		{
+			Hash:     "txHash1",
+			Sender:   hex.EncodeToString(sender),
+			Receiver: hex.EncodeToString(receiver),
+			Value:    "10",
+			Status:   common.TxStatusFail,
+		},
+		{
+			Hash:     "txHash4",
+			Sender:   hex.EncodeToString(sender),
+			Receiver: hex.EncodeToString(sender),
+			Value:    "0",
+			Status:   common.TxStatusInvalid,
+		},
+	}
+
+	txEvents := en.GetTxEventsFromTransactionsPool(pool, logEvents)
+	require.Equal(t, expTxEvents, txEvents)
+}
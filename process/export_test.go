@@ -1,6 +1,9 @@
 package process
 
 import (
+	"context"
+
+	"github.com/multiversx/mx-chain-core-go/core"
 	"github.com/multiversx/mx-chain-core-go/data/outport"
 	"github.com/multiversx/mx-chain-notifier-go/data"
 )
@@ -30,6 +33,11 @@ func (eh *eventsHandler) HandleBlockEventsWithOrder(blockTxs data.BlockEventsWit
 	eh.handleBlockEventsWithOrder(blockTxs)
 }
 
+// HandleBlockTxEvents -
+func (eh *eventsHandler) HandleBlockTxEvents(blockTxEvents data.BlockTxEvents) {
+	eh.handleBlockTxEvents(blockTxEvents)
+}
+
 // ShouldProcessSaveBlockEvents -
 func (eh *eventsHandler) ShouldProcessSaveBlockEvents(blockHash string) bool {
 	return eh.shouldProcessSaveBlockEvents(blockHash)
@@ -39,3 +47,21 @@ func (eh *eventsHandler) ShouldProcessSaveBlockEvents(blockHash string) bool {
 func (ei *eventsInterceptor) GetLogEventsFromTransactionsPool(logs []*outport.LogData) []data.Event {
 	return ei.getLogEventsFromTransactionsPool(logs)
 }
+
+// GetTxEventsFromTransactionsPool exports internal method for testing
+func (ei *eventsInterceptor) GetTxEventsFromTransactionsPool(pool *outport.TransactionPool, events []data.Event) []data.TxEvent {
+	return ei.getTxEventsFromTransactionsPool(pool, events)
+}
+
+// DecodeTokenTransfers -
+func DecodeTokenTransfers(event data.Event, pubKeyConverter core.PubkeyConverter) ([]data.TokenTransfer, bool) {
+	return decodeTokenTransfers(event, pubKeyConverter)
+}
+
+// EnqueueWithContext -
+func (p *publisher) EnqueueWithContext(ctx context.Context, publish func()) error {
+	return p.enqueueWithContext(ctx, func(_ context.Context, _ PublisherHandler) error {
+		publish()
+		return nil
+	})
+}
@@ -10,20 +10,40 @@ import (
 // It makes sure that a duplicated entry is not processed multiple times.
 type LockService interface {
 	IsEventProcessed(ctx context.Context, blockHash string) (bool, error)
+	RemoveEvent(ctx context.Context, blockHash string) error
 	HasConnection(ctx context.Context) bool
 	IsInterfaceNil() bool
 }
 
 // Publisher defines the behaviour of a publisher component which should be
-// able to publish received events and broadcast them to channels
+// able to publish received events and broadcast them to channels. The broadcasts with
+// context wait until the events are published and return the publish error
 type Publisher interface {
 	Run() error
 	Broadcast(events data.BlockEvents)
 	BroadcastRevert(event data.RevertBlock)
 	BroadcastFinalized(event data.FinalizedBlock)
+	BroadcastWithContext(ctx context.Context, events data.BlockEvents) error
+	BroadcastRevertWithContext(ctx context.Context, event data.RevertBlock) error
+	BroadcastFinalizedWithContext(ctx context.Context, event data.FinalizedBlock) error
 	BroadcastTxs(event data.BlockTxs)
 	BroadcastBlockEventsWithOrder(event data.BlockEventsWithOrder)
 	BroadcastScrs(event data.BlockScrs)
+	BroadcastTxEvents(event data.BlockTxEvents)
+	BroadcastRounds(event data.RoundEvents)
+	BroadcastValidatorsRating(event data.ValidatorsRatingEvent)
+	BroadcastAccounts(event data.AccountsEvents)
+	BroadcastTxsWithContext(ctx context.Context, event data.BlockTxs) error
+	BroadcastBlockEventsWithOrderWithContext(ctx context.Context, event data.BlockEventsWithOrder) error
+	BroadcastScrsWithContext(ctx context.Context, event data.BlockScrs) error
+	BroadcastTxEventsWithContext(ctx context.Context, event data.BlockTxEvents) error
+	BroadcastRoundsWithContext(ctx context.Context, event data.RoundEvents) error
+	BroadcastValidatorsRatingWithContext(ctx context.Context, event data.ValidatorsRatingEvent) error
+	BroadcastAccountsWithContext(ctx context.Context, event data.AccountsEvents) error
+	GetHealthState() string
+	Ping(ctx context.Context) error
+	GetMetricsForPrometheus() string
+	Errors() <-chan error
 	Close() error
 	IsInterfaceNil() bool
 }
@@ -31,8 +51,11 @@ type Publisher interface {
 // EventsHandler defines the behaviour of an events handler component
 type EventsHandler interface {
 	HandleSaveBlockEvents(allEvents data.ArgsSaveBlockData) error
-	HandleRevertEvents(revertBlock data.RevertBlock)
-	HandleFinalizedEvents(finalizedBlock data.FinalizedBlock)
+	HandleRevertEvents(revertBlock data.RevertBlock) error
+	HandleFinalizedEvents(finalizedBlock data.FinalizedBlock) error
+	HandleRoundEvents(roundEvents data.RoundEvents) error
+	HandleValidatorsRatingEvent(validatorsRating data.ValidatorsRatingEvent) error
+	HandleAccountsEvents(accountsEvents data.AccountsEvents) error
 	IsInterfaceNil() bool
 }
 
@@ -45,32 +68,57 @@ type EventsInterceptor interface {
 // WSClient defines what a websocket client should do
 type WSClient interface {
 	Close() error
+	IsInterfaceNil() bool
 }
 
-// DataProcessor dines what a data indexer should do
+// DataProcessor dines what a data indexer should do. The provided context holds
+// the trace context of the received payload
 type DataProcessor interface {
-	SaveBlock(marshalledData []byte) error
-	RevertIndexedBlock(marshalledData []byte) error
-	FinalizedBlock(marshalledData []byte) error
+	SaveBlock(ctx context.Context, marshalledData []byte) error
+	RevertIndexedBlock(ctx context.Context, marshalledData []byte) error
+	FinalizedBlock(ctx context.Context, marshalledData []byte) error
+	SaveRounds(ctx context.Context, marshalledData []byte) error
+	SaveValidatorsRating(ctx context.Context, marshalledData []byte) error
+	SaveAccounts(ctx context.Context, marshalledData []byte) error
 	IsInterfaceNil() bool
 }
 
 // EventsFacadeHandler defines the behavior of a facade handler needed for events group
 type EventsFacadeHandler interface {
 	HandlePushEvents(events data.ArgsSaveBlockData) error
-	HandleRevertEvents(revertBlock data.RevertBlock)
-	HandleFinalizedEvents(finalizedBlock data.FinalizedBlock)
+	HandleRevertEvents(revertBlock data.RevertBlock) error
+	HandleFinalizedEvents(finalizedBlock data.FinalizedBlock) error
+	HandleRoundEvents(roundEvents data.RoundEvents) error
+	HandleValidatorsRatingEvent(validatorsRating data.ValidatorsRatingEvent) error
+	HandleAccountsEvents(accountsEvents data.AccountsEvents) error
 	IsInterfaceNil() bool
 }
 
-// PublisherHandler defines the behavior of a publisher component
+// PublishErrorsNotifier defines the behavior of a publisher handler which notifies the
+// events which could not be published
+type PublishErrorsNotifier interface {
+	SetPublishErrorHandler(handler func(err error))
+}
+
+// PublisherHandler defines the behavior of a publisher component. The publish context is done
+// when the in-flight publish has to be aborted, such as on the shutdown drain timeout. The
+// publish methods return nil only if the events were delivered, e.g. confirmed by the broker,
+// or handed over to a buffer or queue, which the publishers do not use if the ack is sent
+// after publish
 type PublisherHandler interface {
-	Publish(events data.BlockEvents)
-	PublishRevert(revertBlock data.RevertBlock)
-	PublishFinalized(finalizedBlock data.FinalizedBlock)
-	PublishTxs(blockTxs data.BlockTxs)
-	PublishScrs(blockScrs data.BlockScrs)
-	PublishBlockEventsWithOrder(blockTxs data.BlockEventsWithOrder)
+	Publish(ctx context.Context, events data.BlockEvents) error
+	PublishRevert(ctx context.Context, revertBlock data.RevertBlock) error
+	PublishFinalized(ctx context.Context, finalizedBlock data.FinalizedBlock) error
+	PublishTxs(ctx context.Context, blockTxs data.BlockTxs) error
+	PublishScrs(ctx context.Context, blockScrs data.BlockScrs) error
+	PublishBlockEventsWithOrder(ctx context.Context, blockTxs data.BlockEventsWithOrder) error
+	PublishTxEvents(ctx context.Context, blockTxEvents data.BlockTxEvents) error
+	PublishRounds(ctx context.Context, roundEvents data.RoundEvents) error
+	PublishValidatorsRating(ctx context.Context, validatorsRating data.ValidatorsRatingEvent) error
+	PublishAccounts(ctx context.Context, accountsEvents data.AccountsEvents) error
+	GetMetricsForPrometheus() string
+	GetHealthState() string
+	Ping(ctx context.Context) error
 	Close() error
 	IsInterfaceNil() bool
 }
@@ -0,0 +1,33 @@
+package process
+
+import "strings"
+
+// MultiError holds the errors returned by multiple components handling the same event
+type MultiError struct {
+	Errors []error
+}
+
+// Error returns the messages of all the errors, in the order of the components
+func (me *MultiError) Error() string {
+	messages := make([]string, 0, len(me.Errors))
+	for _, err := range me.Errors {
+		messages = append(messages, err.Error())
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// combineErrors returns a MultiError with the non nil errors, or nil if all of them are nil
+func combineErrors(errs []error) error {
+	failed := make([]error, 0)
+	for _, err := range errs {
+		if err != nil {
+			failed = append(failed, err)
+		}
+	}
+	if len(failed) > 0 {
+		return &MultiError{Errors: failed}
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package process
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+)
+
+type multicastEventsFacade struct {
+	facades []EventsFacadeHandler
+}
+
+// NewMulticastEventsFacade creates an events facade which fans out the observer events to
+// each of the provided facades, concurrently, so that a deployment with the subscribers
+// sharded across multiple hubs can be fed by a single observer connector. The facades
+// receive the same event data, so they must not modify it
+func NewMulticastEventsFacade(facades []EventsFacadeHandler) (*multicastEventsFacade, error) {
+	if len(facades) == 0 {
+		return nil, ErrEmptyEventsFacades
+	}
+	for index, facade := range facades {
+		if check.IfNil(facade) {
+			return nil, fmt.Errorf("%w at index %d", ErrNilEventsFacade, index)
+		}
+	}
+
+	return &multicastEventsFacade{
+		facades: facades,
+	}, nil
+}
+
+// HandlePushEvents will handle the save block events in each facade and returns a
+// MultiError with the errors of the facades which failed, if any
+func (mef *multicastEventsFacade) HandlePushEvents(events data.ArgsSaveBlockData) error {
+	return mef.forEachFacade(func(facade EventsFacadeHandler) error {
+		return facade.HandlePushEvents(events)
+	})
+}
+
+// HandleRevertEvents will handle the revert event in each facade and returns a
+// MultiError with the errors of the facades which failed, if any
+func (mef *multicastEventsFacade) HandleRevertEvents(revertBlock data.RevertBlock) error {
+	return mef.forEachFacade(func(facade EventsFacadeHandler) error {
+		return facade.HandleRevertEvents(revertBlock)
+	})
+}
+
+// HandleFinalizedEvents will handle the finalized event in each facade and returns a
+// MultiError with the errors of the facades which failed, if any
+func (mef *multicastEventsFacade) HandleFinalizedEvents(finalizedBlock data.FinalizedBlock) error {
+	return mef.forEachFacade(func(facade EventsFacadeHandler) error {
+		return facade.HandleFinalizedEvents(finalizedBlock)
+	})
+}
+
+// HandleRoundEvents will handle the rounds info in each facade and returns a
+// MultiError with the errors of the facades which failed, if any
+func (mef *multicastEventsFacade) HandleRoundEvents(roundEvents data.RoundEvents) error {
+	return mef.forEachFacade(func(facade EventsFacadeHandler) error {
+		return facade.HandleRoundEvents(roundEvents)
+	})
+}
+
+// HandleValidatorsRatingEvent will handle the validators rating in each facade and returns a
+// MultiError with the errors of the facades which failed, if any
+func (mef *multicastEventsFacade) HandleValidatorsRatingEvent(validatorsRating data.ValidatorsRatingEvent) error {
+	return mef.forEachFacade(func(facade EventsFacadeHandler) error {
+		return facade.HandleValidatorsRatingEvent(validatorsRating)
+	})
+}
+
+// HandleAccountsEvents will handle the altered accounts in each facade and returns a
+// MultiError with the errors of the facades which failed, if any
+func (mef *multicastEventsFacade) HandleAccountsEvents(accountsEvents data.AccountsEvents) error {
+	return mef.forEachFacade(func(facade EventsFacadeHandler) error {
+		return facade.HandleAccountsEvents(accountsEvents)
+	})
+}
+
+// forEachFacade calls the provided function for each facade, concurrently, and returns
+// the combined errors once all of them are done
+func (mef *multicastEventsFacade) forEachFacade(handle func(facade EventsFacadeHandler) error) error {
+	errs := make([]error, len(mef.facades))
+	wg := sync.WaitGroup{}
+	wg.Add(len(mef.facades))
+	for index, facade := range mef.facades {
+		go func(index int, facade EventsFacadeHandler) {
+			defer wg.Done()
+			errs[index] = handle(facade)
+		}(index, facade)
+	}
+	wg.Wait()
+
+	return combineErrors(errs)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (mef *multicastEventsFacade) IsInterfaceNil() bool {
+	return mef == nil
+}
@@ -0,0 +1,119 @@
+package process_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/mocks"
+	"github.com/multiversx/mx-chain-notifier-go/process"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMulticastEventsFacade(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty facades should error", func(t *testing.T) {
+		t.Parallel()
+
+		mef, err := process.NewMulticastEventsFacade(nil)
+		require.True(t, check.IfNil(mef))
+		require.Equal(t, process.ErrEmptyEventsFacades, err)
+	})
+
+	t.Run("nil facade should error", func(t *testing.T) {
+		t.Parallel()
+
+		mef, err := process.NewMulticastEventsFacade([]process.EventsFacadeHandler{&mocks.FacadeStub{}, nil})
+		require.True(t, check.IfNil(mef))
+		require.True(t, errors.Is(err, process.ErrNilEventsFacade))
+	})
+
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		mef, err := process.NewMulticastEventsFacade([]process.EventsFacadeHandler{&mocks.FacadeStub{}, &mocks.FacadeStub{}})
+		require.False(t, check.IfNil(mef))
+		require.Nil(t, err)
+	})
+}
+
+func TestMulticastEventsFacade_Handle(t *testing.T) {
+	t.Parallel()
+
+	t.Run("all facades should receive the events", func(t *testing.T) {
+		t.Parallel()
+
+		mut := sync.Mutex{}
+		receivedHashes := make(map[int][]string)
+		createFacade := func(index int) process.EventsFacadeHandler {
+			record := func(hash string) {
+				mut.Lock()
+				receivedHashes[index] = append(receivedHashes[index], hash)
+				mut.Unlock()
+			}
+
+			return &mocks.FacadeStub{
+				HandlePushEventsCalled: func(events data.ArgsSaveBlockData) error {
+					record(string(events.HeaderHash))
+					return nil
+				},
+				HandleRevertEventsCalled: func(events data.RevertBlock) error {
+					record(events.Hash)
+					return nil
+				},
+				HandleFinalizedEventsCalled: func(events data.FinalizedBlock) error {
+					record(events.Hash)
+					return nil
+				},
+			}
+		}
+
+		mef, _ := process.NewMulticastEventsFacade([]process.EventsFacadeHandler{createFacade(0), createFacade(1), createFacade(2)})
+
+		err := mef.HandlePushEvents(data.ArgsSaveBlockData{HeaderHash: []byte("hash1")})
+		require.Nil(t, err)
+		mef.HandleRevertEvents(data.RevertBlock{Hash: "hash2"})
+		mef.HandleFinalizedEvents(data.FinalizedBlock{Hash: "hash3"})
+
+		require.Equal(t, 3, len(receivedHashes))
+		for index := 0; index < 3; index++ {
+			require.Equal(t, []string{"hash1", "hash2", "hash3"}, receivedHashes[index])
+		}
+	})
+
+	t.Run("partial failure should return the errors and still deliver to the others", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr1 := errors.New("expected error 1")
+		expectedErr2 := errors.New("expected error 2")
+		mut := sync.Mutex{}
+		numHandled := 0
+		createFacade := func(err error) process.EventsFacadeHandler {
+			return &mocks.FacadeStub{
+				HandlePushEventsCalled: func(events data.ArgsSaveBlockData) error {
+					mut.Lock()
+					numHandled++
+					mut.Unlock()
+					return err
+				},
+			}
+		}
+
+		mef, _ := process.NewMulticastEventsFacade([]process.EventsFacadeHandler{
+			createFacade(expectedErr1),
+			createFacade(nil),
+			createFacade(expectedErr2),
+		})
+
+		err := mef.HandlePushEvents(data.ArgsSaveBlockData{HeaderHash: []byte("hash1")})
+		require.Equal(t, 3, numHandled)
+
+		multiErr, ok := err.(*process.MultiError)
+		require.True(t, ok)
+		require.Equal(t, []error{expectedErr1, expectedErr2}, multiErr.Errors)
+		require.Equal(t, "expected error 1; expected error 2", err.Error())
+	})
+}
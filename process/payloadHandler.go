@@ -1,11 +1,32 @@
 package process
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"sync"
+	"time"
 
+	"github.com/multiversx/mx-chain-core-go/core/check"
 	"github.com/multiversx/mx-chain-core-go/data/outport"
+	"github.com/multiversx/mx-chain-notifier-go/common"
+	"github.com/multiversx/mx-chain-notifier-go/compress"
+	"github.com/multiversx/mx-chain-notifier-go/metrics"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
+const (
+	payloadHandlerMetricPrefix = "PayloadHandler"
+	processPayloadSpanName     = "payloadHandler.ProcessPayload"
+	topicAttribute             = "notifier.topic"
+	versionAttribute           = "notifier.payload_version"
+)
+
+// DefaultMaxDecompressedPayloadBytes defines the decompressed payload size limit used when none is configured
+const DefaultMaxDecompressedPayloadBytes = 256 * 1024 * 1024
+
 // ErrNilDataProcessor signals that a nil data processor has been provided
 var ErrNilDataProcessor = errors.New("nil data processor")
 
@@ -15,19 +36,79 @@ var ErrInvalidPayloadType = errors.New("invalid payload type")
 // ErrInvalidPayloadVersion signals that an invalid payload version has been provided
 var ErrInvalidPayloadVersion = errors.New("invalid payload version")
 
+// ErrUnknownPayloadTopic signals that a payload with an unknown topic has been provided
+var ErrUnknownPayloadTopic = errors.New("unknown payload topic")
+
+// ErrPayloadDecompression signals that a compressed payload could not be decompressed
+var ErrPayloadDecompression = errors.New("failed to decompress payload")
+
+// ArgsPayloadHandler defines the arguments needed for a payload handler
+type ArgsPayloadHandler struct {
+	DataProcessors       map[uint32]DataProcessor
+	StatusMetricsHandler common.StatusMetricsHandler
+	MetricsCollector     common.MetricsCollector
+
+	// StrictMode rejects the payloads with an unknown topic or an unsupported version, instead
+	// of dropping them, so that they are not acknowledged to the observer
+	StrictMode bool
+
+	// MaxDecompressedPayloadBytes limits the size of the gzip compressed payloads once
+	// decompressed. If 0, DefaultMaxDecompressedPayloadBytes is used
+	MaxDecompressedPayloadBytes uint64
+
+	// VersionFallback routes the payloads with a version newer than the registered ones to
+	// the data processor of the highest registered version, instead of rejecting them
+	VersionFallback bool
+
+	// TracerProvider is optional, if not set no spans are recorded
+	TracerProvider trace.TracerProvider
+}
+
 type payloadHandler struct {
-	dataProcessors map[uint32]DataProcessor
-	actions        map[string]func(marshalledData []byte, version uint32) error
+	dataProcessors   map[uint32]DataProcessor
+	metricsHandler   common.StatusMetricsHandler
+	metricsCollector common.MetricsCollector
+	strictMode       bool
+	maxPayloadSize   uint64
+	versionFallback  bool
+	highestVersion   uint32
+	tracer           trace.Tracer
+	actions          map[string]func(ctx context.Context, marshalledData []byte, version uint32) error
+
+	// mutClose is held for reading while a payload is processed, so that Close waits for the
+	// payloads in progress before the publisher is drained
+	mutClose sync.RWMutex
+	closed   bool
 }
 
 // NewPayloadHandler will create a new instance of events indexer
-func NewPayloadHandler(dataProcessors map[uint32]DataProcessor) (*payloadHandler, error) {
-	if len(dataProcessors) == 0 {
+func NewPayloadHandler(args ArgsPayloadHandler) (*payloadHandler, error) {
+	if len(args.DataProcessors) == 0 {
 		return nil, ErrNilDataProcessor
 	}
+	if check.IfNil(args.StatusMetricsHandler) {
+		return nil, common.ErrNilStatusMetricsHandler
+	}
+	if check.IfNil(args.MetricsCollector) {
+		return nil, common.ErrNilMetricsCollector
+	}
 
 	payloadIndexer := &payloadHandler{
-		dataProcessors: dataProcessors,
+		dataProcessors:   args.DataProcessors,
+		metricsHandler:   args.StatusMetricsHandler,
+		metricsCollector: args.MetricsCollector,
+		strictMode:       args.StrictMode,
+		maxPayloadSize:   args.MaxDecompressedPayloadBytes,
+		versionFallback:  args.VersionFallback,
+		tracer:           common.GetTracer(args.TracerProvider),
+	}
+	for version := range args.DataProcessors {
+		if version > payloadIndexer.highestVersion {
+			payloadIndexer.highestVersion = version
+		}
+	}
+	if payloadIndexer.maxPayloadSize == 0 {
+		payloadIndexer.maxPayloadSize = DefaultMaxDecompressedPayloadBytes
 	}
 	payloadIndexer.initActionsMap()
 
@@ -36,7 +117,7 @@ func NewPayloadHandler(dataProcessors map[uint32]DataProcessor) (*payloadHandler
 
 // GetOperationsMap returns the map with all the operations that will index data
 func (ph *payloadHandler) initActionsMap() {
-	ph.actions = map[string]func(d []byte, v uint32) error{
+	ph.actions = map[string]func(ctx context.Context, d []byte, v uint32) error{
 		outport.TopicSaveBlock:             ph.saveBlock,
 		outport.TopicRevertIndexedBlock:    ph.revertIndexedBlock,
 		outport.TopicSaveRoundsInfo:        ph.saveRounds,
@@ -47,65 +128,185 @@ func (ph *payloadHandler) initActionsMap() {
 	}
 }
 
-// ProcessPayload will proces the provided payload based on the topic
+// ProcessPayload will proces the provided payload based on the topic. A span is started
+// for each processed payload, and its trace context is passed along with the events
 func (ph *payloadHandler) ProcessPayload(payload []byte, topic string, version uint32) error {
+	return ph.ProcessPayloadWithCorrelationID(payload, topic, version, "")
+}
+
+// ProcessPayloadWithCorrelationID will process the provided payload, identified by the
+// correlation id supplied by the observer. If it is empty or not valid, a new one is generated
+func (ph *payloadHandler) ProcessPayloadWithCorrelationID(payload []byte, topic string, version uint32, correlationID string) error {
+	ph.mutClose.RLock()
+	defer ph.mutClose.RUnlock()
+
+	if ph.closed {
+		return common.ErrPayloadHandlerClosed
+	}
+
 	payloadTypeAction, ok := ph.actions[topic]
 	if !ok {
+		ph.metricsCollector.AddInvalidPayload(metrics.InvalidPayloadUnknownTopic)
+		if ph.strictMode {
+			log.Warn("rejected payload with unknown topic", "topic", topic, "version", version)
+			return fmt.Errorf("%w: %s", ErrUnknownPayloadTopic, topic)
+		}
+
 		log.Warn("invalid payload type", "topic", topic)
 		return nil
 	}
 
-	return payloadTypeAction(payload, version)
+	if !common.IsValidCorrelationID(correlationID) {
+		if correlationID != "" {
+			log.Debug("invalid correlation id received, a new one will be generated", "topic", topic)
+		}
+		correlationID = common.NewCorrelationID()
+	}
+	log.Debug("processing payload", "topic", topic, "version", version, "correlation id", correlationID)
+
+	payload, err := ph.decompressPayload(payload)
+	if err != nil {
+		log.Warn("rejected payload", "topic", topic, "correlation id", correlationID, "err", err.Error())
+		return err
+	}
+
+	ctx := common.ContextWithCorrelationID(context.Background(), correlationID)
+	ctx, span := ph.tracer.Start(ctx, processPayloadSpanName,
+		trace.WithAttributes(
+			attribute.String(topicAttribute, topic),
+			attribute.Int64(versionAttribute, int64(version)),
+		),
+	)
+	defer span.End()
+
+	t := time.Now()
+	err = payloadTypeAction(ctx, payload, version)
+	duration := time.Since(t)
+	ph.metricsHandler.AddRequest(getPayloadHandlerOpID(topic), duration)
+	ph.metricsCollector.AddPayloadHandlerDuration(topic, duration)
+
+	if err != nil {
+		log.Debug("failed to process payload", "topic", topic, "correlation id", correlationID, "err", err.Error())
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	return err
 }
 
-func (ph *payloadHandler) saveBlock(marshalledData []byte, version uint32) error {
-	dataProcessor, ok := ph.dataProcessors[version]
-	if !ok {
-		log.Warn("invalid provided version", "version", version)
-		return ErrInvalidPayloadType
+// decompressPayload decompresses the gzip payloads, detected by the gzip magic bytes, so that
+// the observers can compress the payloads on any connector. Other payloads are returned as they are
+func (ph *payloadHandler) decompressPayload(payload []byte) ([]byte, error) {
+	if !compress.IsGzipCompressed(payload) {
+		return payload, nil
 	}
 
-	return dataProcessor.SaveBlock(marshalledData)
+	decompressed, err := compress.DecompressGzipWithLimit(payload, ph.maxPayloadSize)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrPayloadDecompression, err.Error())
+	}
+
+	return decompressed, nil
+}
+
+func getPayloadHandlerOpID(topic string) string {
+	return fmt.Sprintf("%s-%s", payloadHandlerMetricPrefix, topic)
 }
 
-func (ph *payloadHandler) revertIndexedBlock(marshalledData []byte, version uint32) error {
+// getDataProcessor returns the data processor of the payload version. A payload with an
+// unsupported version is rejected in both modes, since its data cannot be decoded, unless
+// the version fallback is enabled and the version is newer than the registered ones, in
+// which case the payload is expected to be compatible with the highest registered version
+func (ph *payloadHandler) getDataProcessor(topic string, version uint32) (DataProcessor, error) {
 	dataProcessor, ok := ph.dataProcessors[version]
-	if !ok {
-		log.Warn("invalid provided version", "version", version)
-		return ErrInvalidPayloadType
+	if ok {
+		return dataProcessor, nil
+	}
+
+	if ph.versionFallback && version > ph.highestVersion {
+		ph.metricsCollector.AddPayloadVersionFallback(version)
+		log.Warn("unsupported payload version, processing it with the highest supported version",
+			"topic", topic, "version", version, "fallback version", ph.highestVersion)
+		return ph.dataProcessors[ph.highestVersion], nil
 	}
 
-	return dataProcessor.RevertIndexedBlock(marshalledData)
+	ph.metricsCollector.AddInvalidPayload(metrics.InvalidPayloadUnsupportedVersion)
+	log.Warn("invalid provided version", "topic", topic, "version", version)
+	return nil, fmt.Errorf("%w: %d", ErrInvalidPayloadVersion, version)
 }
 
-func (ph *payloadHandler) finalizedBlock(marshalledData []byte, version uint32) error {
-	dataProcessor, ok := ph.dataProcessors[version]
-	if !ok {
-		log.Warn("invalid provided version", "version", version)
-		return ErrInvalidPayloadType
+func (ph *payloadHandler) saveBlock(ctx context.Context, marshalledData []byte, version uint32) error {
+	dataProcessor, err := ph.getDataProcessor(outport.TopicSaveBlock, version)
+	if err != nil {
+		return err
 	}
 
-	return dataProcessor.FinalizedBlock(marshalledData)
+	return dataProcessor.SaveBlock(ctx, marshalledData)
 }
 
-func (ph *payloadHandler) saveRounds(marshalledData []byte, version uint32) error {
-	return nil
+func (ph *payloadHandler) revertIndexedBlock(ctx context.Context, marshalledData []byte, version uint32) error {
+	dataProcessor, err := ph.getDataProcessor(outport.TopicRevertIndexedBlock, version)
+	if err != nil {
+		return err
+	}
+
+	return dataProcessor.RevertIndexedBlock(ctx, marshalledData)
 }
 
-func (ph *payloadHandler) saveValidatorsRating(marshalledData []byte, version uint32) error {
-	return nil
+func (ph *payloadHandler) finalizedBlock(ctx context.Context, marshalledData []byte, version uint32) error {
+	dataProcessor, err := ph.getDataProcessor(outport.TopicFinalizedBlock, version)
+	if err != nil {
+		return err
+	}
+
+	return dataProcessor.FinalizedBlock(ctx, marshalledData)
 }
 
-func (ph *payloadHandler) saveValidatorsPubKeys(marshalledData []byte, version uint32) error {
-	return nil
+func (ph *payloadHandler) saveRounds(ctx context.Context, marshalledData []byte, version uint32) error {
+	dataProcessor, err := ph.getDataProcessor(outport.TopicSaveRoundsInfo, version)
+	if err != nil {
+		return err
+	}
+
+	return dataProcessor.SaveRounds(ctx, marshalledData)
 }
 
-func (ph *payloadHandler) saveAccounts(marshalledData []byte, version uint32) error {
-	return nil
+func (ph *payloadHandler) saveValidatorsRating(ctx context.Context, marshalledData []byte, version uint32) error {
+	dataProcessor, err := ph.getDataProcessor(outport.TopicSaveValidatorsRating, version)
+	if err != nil {
+		return err
+	}
+
+	return dataProcessor.SaveValidatorsRating(ctx, marshalledData)
 }
 
-// Close will close the indexer
+// saveValidatorsPubKeys drops the validators public keys, which are not used. In strict mode,
+// the payload version is still checked
+func (ph *payloadHandler) saveValidatorsPubKeys(_ context.Context, _ []byte, version uint32) error {
+	if !ph.strictMode {
+		return nil
+	}
+
+	_, err := ph.getDataProcessor(outport.TopicSaveValidatorsPubKeys, version)
+	return err
+}
+
+func (ph *payloadHandler) saveAccounts(ctx context.Context, marshalledData []byte, version uint32) error {
+	dataProcessor, err := ph.getDataProcessor(outport.TopicSaveAccounts, version)
+	if err != nil {
+		return err
+	}
+
+	return dataProcessor.SaveAccounts(ctx, marshalledData)
+}
+
+// Close stops accepting new payloads, which are rejected with ErrPayloadHandlerClosed, and
+// returns after the payloads in progress are processed
 func (ph *payloadHandler) Close() error {
+	ph.mutClose.Lock()
+	ph.closed = true
+	ph.mutClose.Unlock()
+
 	return nil
 }
 
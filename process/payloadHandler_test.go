@@ -1,26 +1,43 @@
 package process_test
 
 import (
+	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/multiversx/mx-chain-communication-go/testscommon"
+	"github.com/multiversx/mx-chain-communication-go/websocket"
 	"github.com/multiversx/mx-chain-core-go/core/mock"
 	"github.com/multiversx/mx-chain-core-go/data/block"
 	"github.com/multiversx/mx-chain-core-go/data/outport"
+	"github.com/multiversx/mx-chain-core-go/marshal"
+	logger "github.com/multiversx/mx-chain-logger-go"
+	"github.com/multiversx/mx-chain-notifier-go/api/groups"
 	"github.com/multiversx/mx-chain-notifier-go/common"
+	"github.com/multiversx/mx-chain-notifier-go/compress"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/metrics"
 	"github.com/multiversx/mx-chain-notifier-go/mocks"
 	"github.com/multiversx/mx-chain-notifier-go/process"
 	"github.com/multiversx/mx-chain-notifier-go/process/preprocess"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func createDefaultDataProcessors() map[uint32]process.DataProcessor {
 	eventsProcessors := make(map[uint32]process.DataProcessor)
 
 	dataPreProcessorArgs := preprocess.ArgsEventsPreProcessor{
-		Marshaller: &mock.MarshalizerMock{},
-		Facade:     &mocks.FacadeStub{},
+		Marshaller:             &mock.MarshalizerMock{},
+		Facade:                 &mocks.FacadeStub{},
+		MetricsCollector:       &mocks.MetricsCollectorStub{},
+		ProcessedBlocksTracker: &mocks.ProcessedBlocksTrackerStub{},
 	}
 
 	eventsProcessorV0, _ := preprocess.NewEventsPreProcessorV0(dataPreProcessorArgs)
@@ -32,21 +49,51 @@ func createDefaultDataProcessors() map[uint32]process.DataProcessor {
 	return eventsProcessors
 }
 
+func createMockArgsPayloadHandler(dataProcessors map[uint32]process.DataProcessor) process.ArgsPayloadHandler {
+	return process.ArgsPayloadHandler{
+		DataProcessors:       dataProcessors,
+		StatusMetricsHandler: &mocks.StatusMetricsStub{},
+		MetricsCollector:     &mocks.MetricsCollectorStub{},
+	}
+}
+
 func TestNewPayloadHandler(t *testing.T) {
 	t.Parallel()
 
 	t.Run("nil data processor", func(t *testing.T) {
 		t.Parallel()
 
-		ei, err := process.NewPayloadHandler(nil)
+		ei, err := process.NewPayloadHandler(process.ArgsPayloadHandler{StatusMetricsHandler: &mocks.StatusMetricsStub{}})
 		require.Nil(t, ei)
 		require.Equal(t, process.ErrNilDataProcessor, err)
 	})
 
+	t.Run("nil status metrics handler", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsPayloadHandler(createDefaultDataProcessors())
+		args.StatusMetricsHandler = nil
+
+		ei, err := process.NewPayloadHandler(args)
+		require.Nil(t, ei)
+		require.Equal(t, common.ErrNilStatusMetricsHandler, err)
+	})
+
+	t.Run("nil metrics collector", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsPayloadHandler(createDefaultDataProcessors())
+		args.MetricsCollector = nil
+
+		ei, err := process.NewPayloadHandler(args)
+		require.Nil(t, ei)
+		require.Equal(t, common.ErrNilMetricsCollector, err)
+	})
+
 	t.Run("should work", func(t *testing.T) {
 		t.Parallel()
 
-		ei, err := process.NewPayloadHandler(createDefaultDataProcessors())
+		ei, err := process.NewPayloadHandler(createMockArgsPayloadHandler(createDefaultDataProcessors()))
 		require.Nil(t, err)
 		require.NotNil(t, ei)
 		require.False(t, ei.IsInterfaceNil())
@@ -61,7 +108,7 @@ func TestProcessPayload(t *testing.T) {
 	t.Run("invalid topic, should return nil", func(t *testing.T) {
 		t.Parallel()
 
-		ei, err := process.NewPayloadHandler(createDefaultDataProcessors())
+		ei, err := process.NewPayloadHandler(createMockArgsPayloadHandler(createDefaultDataProcessors()))
 		err = ei.ProcessPayload([]byte("payload"), "invalid topic", 1)
 		require.Nil(t, err)
 	})
@@ -80,7 +127,7 @@ func TestProcessPayload(t *testing.T) {
 		}
 		eventsProcessors[common.PayloadV1] = dp
 
-		ei, err := process.NewPayloadHandler(eventsProcessors)
+		ei, err := process.NewPayloadHandler(createMockArgsPayloadHandler(eventsProcessors))
 		require.Nil(t, err)
 
 		err = ei.ProcessPayload([]byte("payload"), outport.TopicSaveBlock, common.PayloadV0)
@@ -109,7 +156,7 @@ func TestProcessPayload(t *testing.T) {
 		}
 		eventsProcessors[common.PayloadV1] = dp
 
-		ei, err := process.NewPayloadHandler(eventsProcessors)
+		ei, err := process.NewPayloadHandler(createMockArgsPayloadHandler(eventsProcessors))
 		require.Nil(t, err)
 
 		err = ei.ProcessPayload([]byte("payload"), outport.TopicRevertIndexedBlock, common.PayloadV0)
@@ -145,7 +192,7 @@ func TestProcessPayload(t *testing.T) {
 		}
 		eventsProcessors[common.PayloadV1] = dp
 
-		ei, err := process.NewPayloadHandler(eventsProcessors)
+		ei, err := process.NewPayloadHandler(createMockArgsPayloadHandler(eventsProcessors))
 		require.Nil(t, err)
 
 		err = ei.ProcessPayload([]byte("payload"), outport.TopicFinalizedBlock, common.PayloadV0)
@@ -162,3 +209,668 @@ func TestProcessPayload(t *testing.T) {
 		require.True(t, wasCalled)
 	})
 }
+
+func TestProcessPayload_SaveRounds(t *testing.T) {
+	t.Parallel()
+
+	roundsInfo := &outport.RoundsInfo{
+		ShardID: 1,
+		RoundsInfo: []*outport.RoundInfo{
+			{Round: 10, SignersIndexes: []uint64{0, 2}, BlockWasProposed: true, ShardId: 1, Epoch: 2, Timestamp: 100},
+			{Round: 11, ShardId: 1, Epoch: 2, Timestamp: 106},
+		},
+	}
+	expectedRounds := []data.RoundInfo{
+		{Round: 10, SignersIndexes: []uint64{0, 2}, BlockWasProposed: true, ShardID: 1, Epoch: 2, Timestamp: 100},
+		{Round: 11, ShardID: 1, Epoch: 2, Timestamp: 106},
+	}
+
+	// the rounds payload is driven through the preprocessor and the events handler up to the publisher
+	createPayloadHandler := func(t *testing.T, version uint32, marshaller marshal.Marshalizer, published chan data.RoundEvents) websocket.PayloadHandler {
+		eventsHandlerArgs := process.ArgsEventsHandler{
+			Locker:               &mocks.LockerStub{},
+			StatusMetricsHandler: &mocks.StatusMetricsStub{},
+			EventsInterceptor:    &mocks.EventsInterceptorStub{},
+			Publisher: &mocks.PublisherStub{
+				BroadcastRoundsCalled: func(event data.RoundEvents) {
+					published <- event
+				},
+			},
+		}
+		eventsHandler, err := process.NewEventsHandler(eventsHandlerArgs)
+		require.Nil(t, err)
+
+		facade := &mocks.FacadeStub{
+			HandleRoundEventsCalled: eventsHandler.HandleRoundEvents,
+		}
+		dataProcessorArgs := preprocess.ArgsEventsPreProcessor{
+			Marshaller:             marshaller,
+			Facade:                 facade,
+			MetricsCollector:       &mocks.MetricsCollectorStub{},
+			ProcessedBlocksTracker: &mocks.ProcessedBlocksTrackerStub{},
+		}
+
+		var dataProcessor process.DataProcessor
+		if version == common.PayloadV0 {
+			dataProcessor, err = preprocess.NewEventsPreProcessorV0(dataProcessorArgs)
+		} else {
+			dataProcessor, err = preprocess.NewEventsPreProcessorV1(dataProcessorArgs)
+		}
+		require.Nil(t, err)
+
+		ph, err := process.NewPayloadHandler(createMockArgsPayloadHandler(map[uint32]process.DataProcessor{version: dataProcessor}))
+		require.Nil(t, err)
+
+		return ph
+	}
+
+	testVersion := func(version uint32, marshaller marshal.Marshalizer) func(t *testing.T) {
+		return func(t *testing.T) {
+			t.Parallel()
+
+			published := make(chan data.RoundEvents, 1)
+			ph := createPayloadHandler(t, version, marshaller, published)
+
+			roundsInfoBytes, err := marshaller.Marshal(roundsInfo)
+			require.Nil(t, err)
+
+			err = ph.ProcessPayload(roundsInfoBytes, outport.TopicSaveRoundsInfo, version)
+			require.Nil(t, err)
+
+			roundEvents := <-published
+			require.Equal(t, uint32(1), roundEvents.ShardID)
+			require.Equal(t, expectedRounds, roundEvents.Rounds)
+			require.NotEmpty(t, roundEvents.CorrelationID)
+		}
+	}
+
+	t.Run("payload v0", testVersion(common.PayloadV0, &marshal.JsonMarshalizer{}))
+	t.Run("payload v1", testVersion(common.PayloadV1, &marshal.GogoProtoMarshalizer{}))
+
+	t.Run("invalid payload should error", func(t *testing.T) {
+		t.Parallel()
+
+		published := make(chan data.RoundEvents, 1)
+		ph := createPayloadHandler(t, common.PayloadV1, &marshal.GogoProtoMarshalizer{}, published)
+
+		err := ph.ProcessPayload([]byte("invalid"), outport.TopicSaveRoundsInfo, common.PayloadV1)
+		require.NotNil(t, err)
+		require.Equal(t, 0, len(published))
+	})
+
+	t.Run("unknown version should error", func(t *testing.T) {
+		t.Parallel()
+
+		ph, err := process.NewPayloadHandler(createMockArgsPayloadHandler(createDefaultDataProcessors()))
+		require.Nil(t, err)
+
+		err = ph.ProcessPayload([]byte("payload"), outport.TopicSaveRoundsInfo, 5)
+		require.True(t, errors.Is(err, process.ErrInvalidPayloadVersion))
+	})
+}
+
+func TestProcessPayload_StrictMode(t *testing.T) {
+	t.Parallel()
+
+	const unsupportedVersion = uint32(5)
+	topicsWithPayloads := []string{
+		outport.TopicSaveBlock,
+		outport.TopicRevertIndexedBlock,
+		outport.TopicFinalizedBlock,
+		outport.TopicSaveRoundsInfo,
+		outport.TopicSaveValidatorsRating,
+		outport.TopicSaveAccounts,
+	}
+
+	createPayloadHandler := func(strictMode bool, invalidPayloads *[]string) websocket.PayloadHandler {
+		args := createMockArgsPayloadHandler(map[uint32]process.DataProcessor{common.PayloadV1: &mocks.EventsDataProcessorStub{}})
+		args.StrictMode = strictMode
+		args.MetricsCollector = &mocks.MetricsCollectorStub{
+			AddInvalidPayloadCalled: func(reason string) {
+				*invalidPayloads = append(*invalidPayloads, reason)
+			},
+		}
+		ph, _ := process.NewPayloadHandler(args)
+
+		return ph
+	}
+
+	for _, strictMode := range []bool{false, true} {
+		strictMode := strictMode
+		testName := fmt.Sprintf("strict mode %v", strictMode)
+
+		t.Run(testName+", supported version should work", func(t *testing.T) {
+			t.Parallel()
+
+			invalidPayloads := make([]string, 0)
+			ph := createPayloadHandler(strictMode, &invalidPayloads)
+
+			for _, topic := range append(topicsWithPayloads, outport.TopicSaveValidatorsPubKeys) {
+				err := ph.ProcessPayload([]byte("payload"), topic, common.PayloadV1)
+				require.Nil(t, err, topic)
+			}
+			require.Equal(t, 0, len(invalidPayloads))
+		})
+
+		t.Run(testName+", unsupported version should error", func(t *testing.T) {
+			t.Parallel()
+
+			invalidPayloads := make([]string, 0)
+			ph := createPayloadHandler(strictMode, &invalidPayloads)
+
+			for _, topic := range topicsWithPayloads {
+				err := ph.ProcessPayload([]byte("payload"), topic, unsupportedVersion)
+				require.True(t, errors.Is(err, process.ErrInvalidPayloadVersion), topic)
+			}
+			require.Equal(t, len(topicsWithPayloads), len(invalidPayloads))
+			for _, reason := range invalidPayloads {
+				require.Equal(t, metrics.InvalidPayloadUnsupportedVersion, reason)
+			}
+		})
+	}
+
+	t.Run("validators pub keys with unsupported version", func(t *testing.T) {
+		t.Parallel()
+
+		invalidPayloads := make([]string, 0)
+		ph := createPayloadHandler(false, &invalidPayloads)
+		err := ph.ProcessPayload([]byte("payload"), outport.TopicSaveValidatorsPubKeys, unsupportedVersion)
+		require.Nil(t, err)
+		require.Equal(t, 0, len(invalidPayloads))
+
+		ph = createPayloadHandler(true, &invalidPayloads)
+		err = ph.ProcessPayload([]byte("payload"), outport.TopicSaveValidatorsPubKeys, unsupportedVersion)
+		require.True(t, errors.Is(err, process.ErrInvalidPayloadVersion))
+		require.Equal(t, []string{metrics.InvalidPayloadUnsupportedVersion}, invalidPayloads)
+	})
+
+	t.Run("unknown topic should be dropped if not in strict mode", func(t *testing.T) {
+		t.Parallel()
+
+		invalidPayloads := make([]string, 0)
+		ph := createPayloadHandler(false, &invalidPayloads)
+
+		err := ph.ProcessPayload([]byte("payload"), "unknown topic", common.PayloadV1)
+		require.Nil(t, err)
+		require.Equal(t, []string{metrics.InvalidPayloadUnknownTopic}, invalidPayloads)
+	})
+
+	t.Run("unknown topic should error in strict mode", func(t *testing.T) {
+		t.Parallel()
+
+		invalidPayloads := make([]string, 0)
+		ph := createPayloadHandler(true, &invalidPayloads)
+
+		err := ph.ProcessPayload([]byte("payload"), "unknown topic", common.PayloadV1)
+		require.True(t, errors.Is(err, process.ErrUnknownPayloadTopic))
+		require.Equal(t, []string{metrics.InvalidPayloadUnknownTopic}, invalidPayloads)
+	})
+}
+
+func TestProcessPayload_VersionFallback(t *testing.T) {
+	t.Parallel()
+
+	createPayloadHandler := func(versionFallback bool, processedVersions *[]uint32, fallbacks *[]uint32) websocket.PayloadHandler {
+		createDataProcessor := func(version uint32) process.DataProcessor {
+			return &mocks.EventsDataProcessorStub{
+				SaveBlockCalled: func(marshalledData []byte) error {
+					*processedVersions = append(*processedVersions, version)
+					return nil
+				},
+			}
+		}
+
+		args := createMockArgsPayloadHandler(map[uint32]process.DataProcessor{
+			common.PayloadV1: createDataProcessor(common.PayloadV1),
+			3:                createDataProcessor(3),
+		})
+		args.VersionFallback = versionFallback
+		args.MetricsCollector = &mocks.MetricsCollectorStub{
+			AddPayloadVersionFallbackCalled: func(version uint32) {
+				*fallbacks = append(*fallbacks, version)
+			},
+		}
+		ph, _ := process.NewPayloadHandler(args)
+
+		return ph
+	}
+
+	t.Run("exact version should be processed by its data processor", func(t *testing.T) {
+		t.Parallel()
+
+		processedVersions := make([]uint32, 0)
+		fallbacks := make([]uint32, 0)
+		ph := createPayloadHandler(true, &processedVersions, &fallbacks)
+
+		err := ph.ProcessPayload([]byte("payload"), outport.TopicSaveBlock, common.PayloadV1)
+		require.Nil(t, err)
+		err = ph.ProcessPayload([]byte("payload"), outport.TopicSaveBlock, 3)
+		require.Nil(t, err)
+
+		require.Equal(t, []uint32{common.PayloadV1, 3}, processedVersions)
+		require.Equal(t, 0, len(fallbacks))
+	})
+
+	t.Run("fallback allowed should route newer versions to the highest version", func(t *testing.T) {
+		t.Parallel()
+
+		processedVersions := make([]uint32, 0)
+		fallbacks := make([]uint32, 0)
+		ph := createPayloadHandler(true, &processedVersions, &fallbacks)
+
+		err := ph.ProcessPayload([]byte("payload"), outport.TopicSaveBlock, 4)
+		require.Nil(t, err)
+		err = ph.ProcessPayload([]byte("payload"), outport.TopicSaveBlock, 2)
+		require.True(t, errors.Is(err, process.ErrInvalidPayloadVersion))
+
+		require.Equal(t, []uint32{3}, processedVersions)
+		require.Equal(t, []uint32{4}, fallbacks)
+	})
+
+	t.Run("fallback disabled should reject newer versions", func(t *testing.T) {
+		t.Parallel()
+
+		processedVersions := make([]uint32, 0)
+		fallbacks := make([]uint32, 0)
+		ph := createPayloadHandler(false, &processedVersions, &fallbacks)
+
+		err := ph.ProcessPayload([]byte("payload"), outport.TopicSaveBlock, 4)
+		require.True(t, errors.Is(err, process.ErrInvalidPayloadVersion))
+
+		require.Equal(t, 0, len(processedVersions))
+		require.Equal(t, 0, len(fallbacks))
+	})
+}
+
+func TestProcessPayload_GzipCompressedPayloads(t *testing.T) {
+	t.Parallel()
+
+	marshaller := &mock.MarshalizerMock{}
+	headerBytes, _ := json.Marshal(&block.Header{Nonce: 7, Round: 8, Epoch: 1})
+
+	outportBlockBytes, _ := marshaller.Marshal(&outport.OutportBlock{
+		BlockData: &outport.BlockData{
+			HeaderHash:  []byte("hash1"),
+			HeaderBytes: headerBytes,
+			HeaderType:  "Header",
+		},
+		TransactionPool:      &outport.TransactionPool{},
+		HeaderGasConsumption: &outport.HeaderGasConsumption{},
+	})
+	revertBlockBytes, _ := marshaller.Marshal(&outport.BlockData{
+		HeaderHash:  []byte("hash2"),
+		HeaderBytes: headerBytes,
+		HeaderType:  "Header",
+	})
+	finalizedBlockBytes, _ := marshaller.Marshal(&outport.FinalizedBlock{HeaderHash: []byte("hash3")})
+
+	type handledEvents struct {
+		saveBlock *data.ArgsSaveBlockData
+		revert    *data.RevertBlock
+		finalized *data.FinalizedBlock
+	}
+	createPayloadHandler := func(t *testing.T, maxDecompressedPayloadBytes uint64, handled *handledEvents) websocket.PayloadHandler {
+		dataPreProcessorArgs := preprocess.ArgsEventsPreProcessor{
+			Marshaller:             marshaller,
+			MetricsCollector:       &mocks.MetricsCollectorStub{},
+			ProcessedBlocksTracker: &mocks.ProcessedBlocksTrackerStub{},
+			Facade: &mocks.FacadeStub{
+				HandlePushEventsCalled: func(events data.ArgsSaveBlockData) error {
+					handled.saveBlock = &events
+					return nil
+				},
+				HandleRevertEventsCalled: func(events data.RevertBlock) error {
+					handled.revert = &events
+					return nil
+				},
+				HandleFinalizedEventsCalled: func(events data.FinalizedBlock) error {
+					handled.finalized = &events
+					return nil
+				},
+			},
+		}
+		eventsProcessorV1, err := preprocess.NewEventsPreProcessorV1(dataPreProcessorArgs)
+		require.Nil(t, err)
+
+		args := createMockArgsPayloadHandler(map[uint32]process.DataProcessor{common.PayloadV1: eventsProcessorV1})
+		args.MaxDecompressedPayloadBytes = maxDecompressedPayloadBytes
+		ph, err := process.NewPayloadHandler(args)
+		require.Nil(t, err)
+
+		return ph
+	}
+	gzipPayload := func(t *testing.T, payload []byte) []byte {
+		compressed, err := compress.Compress(payload, compress.GzipAlgorithm)
+		require.Nil(t, err)
+
+		return compressed
+	}
+
+	t.Run("save block", func(t *testing.T) {
+		t.Parallel()
+
+		handled := &handledEvents{}
+		ph := createPayloadHandler(t, 0, handled)
+
+		err := ph.ProcessPayload(gzipPayload(t, outportBlockBytes), outport.TopicSaveBlock, common.PayloadV1)
+		require.Nil(t, err)
+		require.NotNil(t, handled.saveBlock)
+		require.Equal(t, []byte("hash1"), handled.saveBlock.HeaderHash)
+	})
+
+	t.Run("revert indexed block", func(t *testing.T) {
+		t.Parallel()
+
+		handled := &handledEvents{}
+		ph := createPayloadHandler(t, 0, handled)
+
+		err := ph.ProcessPayload(gzipPayload(t, revertBlockBytes), outport.TopicRevertIndexedBlock, common.PayloadV1)
+		require.Nil(t, err)
+		require.NotNil(t, handled.revert)
+		require.Equal(t, hex.EncodeToString([]byte("hash2")), handled.revert.Hash)
+		require.Equal(t, uint64(7), handled.revert.Nonce)
+		require.Equal(t, uint64(8), handled.revert.Round)
+	})
+
+	t.Run("finalized block", func(t *testing.T) {
+		t.Parallel()
+
+		handled := &handledEvents{}
+		ph := createPayloadHandler(t, 0, handled)
+
+		err := ph.ProcessPayload(gzipPayload(t, finalizedBlockBytes), outport.TopicFinalizedBlock, common.PayloadV1)
+		require.Nil(t, err)
+		require.NotNil(t, handled.finalized)
+		require.Equal(t, hex.EncodeToString([]byte("hash3")), handled.finalized.Hash)
+	})
+
+	t.Run("uncompressed payload should still work", func(t *testing.T) {
+		t.Parallel()
+
+		handled := &handledEvents{}
+		ph := createPayloadHandler(t, 0, handled)
+
+		err := ph.ProcessPayload(finalizedBlockBytes, outport.TopicFinalizedBlock, common.PayloadV1)
+		require.Nil(t, err)
+		require.NotNil(t, handled.finalized)
+	})
+
+	t.Run("corrupted payload should error", func(t *testing.T) {
+		t.Parallel()
+
+		handled := &handledEvents{}
+		ph := createPayloadHandler(t, 0, handled)
+
+		compressed := gzipPayload(t, outportBlockBytes)
+		err := ph.ProcessPayload(compressed[:len(compressed)/2], outport.TopicSaveBlock, common.PayloadV1)
+		require.True(t, errors.Is(err, process.ErrPayloadDecompression))
+		require.Nil(t, handled.saveBlock)
+	})
+
+	t.Run("payload over the decompressed size limit should error", func(t *testing.T) {
+		t.Parallel()
+
+		handled := &handledEvents{}
+		ph := createPayloadHandler(t, uint64(len(outportBlockBytes)-1), handled)
+
+		err := ph.ProcessPayload(gzipPayload(t, outportBlockBytes), outport.TopicSaveBlock, common.PayloadV1)
+		require.True(t, errors.Is(err, process.ErrPayloadDecompression))
+		require.Contains(t, err.Error(), compress.ErrDecompressedSizeLimit.Error())
+		require.Nil(t, handled.saveBlock)
+
+		ph = createPayloadHandler(t, uint64(len(outportBlockBytes)), handled)
+		err = ph.ProcessPayload(gzipPayload(t, outportBlockBytes), outport.TopicSaveBlock, common.PayloadV1)
+		require.Nil(t, err)
+		require.NotNil(t, handled.saveBlock)
+	})
+}
+
+func TestProcessPayload_ShouldAddTopicMetrics(t *testing.T) {
+	t.Parallel()
+
+	eventsProcessors := make(map[uint32]process.DataProcessor)
+	eventsProcessors[common.PayloadV1] = &mocks.EventsDataProcessorStub{}
+
+	recordedOperations := make([]string, 0)
+	recordedTopics := make([]string, 0)
+	args := createMockArgsPayloadHandler(eventsProcessors)
+	args.StatusMetricsHandler = &mocks.StatusMetricsStub{
+		AddRequestCalled: func(path string, duration time.Duration) {
+			recordedOperations = append(recordedOperations, path)
+		},
+	}
+	args.MetricsCollector = &mocks.MetricsCollectorStub{
+		AddPayloadHandlerDurationCalled: func(topic string, duration time.Duration) {
+			recordedTopics = append(recordedTopics, topic)
+		},
+	}
+
+	ei, err := process.NewPayloadHandler(args)
+	require.Nil(t, err)
+
+	_ = ei.ProcessPayload([]byte("payload"), "invalid topic", common.PayloadV1)
+	_ = ei.ProcessPayload([]byte("payload"), outport.TopicSaveBlock, common.PayloadV1)
+	_ = ei.ProcessPayload([]byte("payload"), outport.TopicFinalizedBlock, common.PayloadV1)
+
+	expOperations := []string{
+		"PayloadHandler-" + outport.TopicSaveBlock,
+		"PayloadHandler-" + outport.TopicFinalizedBlock,
+	}
+	require.Equal(t, expOperations, recordedOperations)
+	require.Equal(t, []string{outport.TopicSaveBlock, outport.TopicFinalizedBlock}, recordedTopics)
+}
+
+func TestProcessPayload_ShouldPropagateTraceContext(t *testing.T) {
+	t.Parallel()
+
+	var finalizedEvent data.FinalizedBlock
+	dataPreProcessorArgs := preprocess.ArgsEventsPreProcessor{
+		Marshaller:             &mock.MarshalizerMock{},
+		MetricsCollector:       &mocks.MetricsCollectorStub{},
+		ProcessedBlocksTracker: &mocks.ProcessedBlocksTrackerStub{},
+		Facade: &mocks.FacadeStub{
+			HandleFinalizedEventsCalled: func(event data.FinalizedBlock) error {
+				finalizedEvent = event
+				return nil
+			},
+		},
+	}
+	eventsProcessorV1, err := preprocess.NewEventsPreProcessorV1(dataPreProcessorArgs)
+	require.Nil(t, err)
+
+	exporter := tracetest.NewInMemoryExporter()
+	args := createMockArgsPayloadHandler(map[uint32]process.DataProcessor{common.PayloadV1: eventsProcessorV1})
+	args.TracerProvider = sdkTrace.NewTracerProvider(sdkTrace.WithSyncer(exporter))
+
+	ph, err := process.NewPayloadHandler(args)
+	require.Nil(t, err)
+
+	finalizedBlockBytes, err := json.Marshal(&outport.FinalizedBlock{HeaderHash: []byte("headerHash1")})
+	require.Nil(t, err)
+
+	err = ph.ProcessPayload(finalizedBlockBytes, outport.TopicFinalizedBlock, common.PayloadV1)
+	require.Nil(t, err)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	require.Equal(t, "payloadHandler.ProcessPayload", spans[0].Name)
+	require.Contains(t, spans[0].Attributes, attribute.String("notifier.topic", outport.TopicFinalizedBlock))
+
+	// the events passed along hold the trace context of the payload span
+	require.True(t, finalizedEvent.SpanContext.IsValid())
+	require.Equal(t, spans[0].SpanContext.TraceID(), finalizedEvent.SpanContext.TraceID())
+	require.Equal(t, spans[0].SpanContext.SpanID(), finalizedEvent.SpanContext.SpanID())
+}
+
+func TestProcessPayload_NilTracerProviderShouldNotPropagateTraceContext(t *testing.T) {
+	t.Parallel()
+
+	var spanContext trace.SpanContext
+	dataPreProcessorArgs := preprocess.ArgsEventsPreProcessor{
+		Marshaller:             &mock.MarshalizerMock{},
+		MetricsCollector:       &mocks.MetricsCollectorStub{},
+		ProcessedBlocksTracker: &mocks.ProcessedBlocksTrackerStub{},
+		Facade: &mocks.FacadeStub{
+			HandleFinalizedEventsCalled: func(event data.FinalizedBlock) error {
+				spanContext = event.SpanContext
+				return nil
+			},
+		},
+	}
+	eventsProcessorV1, err := preprocess.NewEventsPreProcessorV1(dataPreProcessorArgs)
+	require.Nil(t, err)
+
+	ph, err := process.NewPayloadHandler(createMockArgsPayloadHandler(map[uint32]process.DataProcessor{common.PayloadV1: eventsProcessorV1}))
+	require.Nil(t, err)
+
+	finalizedBlockBytes, err := json.Marshal(&outport.FinalizedBlock{HeaderHash: []byte("headerHash1")})
+	require.Nil(t, err)
+
+	err = ph.ProcessPayload(finalizedBlockBytes, outport.TopicFinalizedBlock, common.PayloadV1)
+	require.Nil(t, err)
+	require.False(t, spanContext.IsValid())
+}
+
+func TestProcessPayload_ShouldLogCorrelationID(t *testing.T) {
+	logPattern := logger.GetLogLevelPattern()
+	_ = logger.SetLogLevel("*:INFO,process:DEBUG")
+	defer func() {
+		_ = logger.SetLogLevel(logPattern)
+	}()
+
+	logOutput := &mocks.LogOutputStub{}
+	err := logger.AddLogObserver(logOutput, &logger.PlainFormatter{})
+	require.Nil(t, err)
+	defer func() {
+		_ = logger.RemoveLogObserver(logOutput)
+	}()
+
+	var finalizedEvent data.FinalizedBlock
+	dataPreProcessorArgs := preprocess.ArgsEventsPreProcessor{
+		Marshaller:             &mock.MarshalizerMock{},
+		MetricsCollector:       &mocks.MetricsCollectorStub{},
+		ProcessedBlocksTracker: &mocks.ProcessedBlocksTrackerStub{},
+		Facade: &mocks.FacadeStub{
+			HandleFinalizedEventsCalled: func(event data.FinalizedBlock) error {
+				finalizedEvent = event
+				return nil
+			},
+		},
+	}
+	eventsProcessorV1, err := preprocess.NewEventsPreProcessorV1(dataPreProcessorArgs)
+	require.Nil(t, err)
+
+	ph, err := process.NewPayloadHandler(createMockArgsPayloadHandler(map[uint32]process.DataProcessor{common.PayloadV1: eventsProcessorV1}))
+	require.Nil(t, err)
+
+	finalizedBlockBytes, err := json.Marshal(&outport.FinalizedBlock{HeaderHash: []byte("headerHash1")})
+	require.Nil(t, err)
+
+	err = ph.ProcessPayload(finalizedBlockBytes, outport.TopicFinalizedBlock, common.PayloadV1)
+	require.Nil(t, err)
+
+	// the correlation id generated for the payload is passed along with the event
+	require.NotEmpty(t, finalizedEvent.CorrelationID)
+	require.Contains(t, logOutput.String(), "processing payload")
+	require.Contains(t, logOutput.String(), finalizedEvent.CorrelationID)
+}
+
+func TestProcessPayloadWithCorrelationID(t *testing.T) {
+	t.Parallel()
+
+	createPayloadHandler := func(finalizedEvent *data.FinalizedBlock) groups.CorrelatedPayloadHandler {
+		dataPreProcessorArgs := preprocess.ArgsEventsPreProcessor{
+			Marshaller:             &mock.MarshalizerMock{},
+			MetricsCollector:       &mocks.MetricsCollectorStub{},
+			ProcessedBlocksTracker: &mocks.ProcessedBlocksTrackerStub{},
+			Facade: &mocks.FacadeStub{
+				HandleFinalizedEventsCalled: func(event data.FinalizedBlock) error {
+					*finalizedEvent = event
+					return nil
+				},
+			},
+		}
+		eventsProcessorV1, err := preprocess.NewEventsPreProcessorV1(dataPreProcessorArgs)
+		require.Nil(t, err)
+
+		ph, err := process.NewPayloadHandler(createMockArgsPayloadHandler(map[uint32]process.DataProcessor{common.PayloadV1: eventsProcessorV1}))
+		require.Nil(t, err)
+
+		return ph
+	}
+
+	finalizedBlockBytes, err := json.Marshal(&outport.FinalizedBlock{HeaderHash: []byte("headerHash1")})
+	require.Nil(t, err)
+
+	t.Run("supplied correlation id should be passed along with the event", func(t *testing.T) {
+		t.Parallel()
+
+		var finalizedEvent data.FinalizedBlock
+		ph := createPayloadHandler(&finalizedEvent)
+
+		err := ph.ProcessPayloadWithCorrelationID(finalizedBlockBytes, outport.TopicFinalizedBlock, common.PayloadV1, "observer-0:push-42")
+		require.Nil(t, err)
+		require.Equal(t, "observer-0:push-42", finalizedEvent.CorrelationID)
+	})
+
+	t.Run("invalid correlation id should be replaced", func(t *testing.T) {
+		t.Parallel()
+
+		var finalizedEvent data.FinalizedBlock
+		ph := createPayloadHandler(&finalizedEvent)
+
+		err := ph.ProcessPayloadWithCorrelationID(finalizedBlockBytes, outport.TopicFinalizedBlock, common.PayloadV1, "id\nfake log line")
+		require.Nil(t, err)
+		require.NotEmpty(t, finalizedEvent.CorrelationID)
+		require.NotContains(t, finalizedEvent.CorrelationID, "fake")
+	})
+}
+
+func TestPayloadHandler_Close(t *testing.T) {
+	t.Parallel()
+
+	processing := make(chan struct{})
+	release := make(chan struct{})
+	numProcessed := 0
+	eventsProcessors := map[uint32]process.DataProcessor{
+		common.PayloadV1: &mocks.EventsDataProcessorStub{
+			FinalizedBlockCalled: func(marshalledData []byte) error {
+				close(processing)
+				<-release
+				numProcessed++
+				return nil
+			},
+		},
+	}
+
+	ph, err := process.NewPayloadHandler(createMockArgsPayloadHandler(eventsProcessors))
+	require.Nil(t, err)
+
+	errProcess := make(chan error, 1)
+	go func() {
+		errProcess <- ph.ProcessPayload([]byte("payload"), outport.TopicFinalizedBlock, common.PayloadV1)
+	}()
+	<-processing
+
+	closed := make(chan struct{})
+	go func() {
+		_ = ph.Close()
+		close(closed)
+	}()
+
+	select {
+	case <-closed:
+		require.Fail(t, "close should wait for the payload in progress")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	require.Nil(t, <-errProcess)
+	<-closed
+	require.Equal(t, 1, numProcessed)
+
+	err = ph.ProcessPayload([]byte("payload"), outport.TopicFinalizedBlock, common.PayloadV1)
+	require.Equal(t, common.ErrPayloadHandlerClosed, err)
+	require.Equal(t, 1, numProcessed)
+	require.Nil(t, ph.Close())
+}
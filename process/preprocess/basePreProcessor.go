@@ -1,28 +1,73 @@
 package preprocess
 
 import (
+	"context"
+	"encoding/hex"
+	"sort"
+	"time"
+
 	"github.com/multiversx/mx-chain-core-go/core"
 	"github.com/multiversx/mx-chain-core-go/core/check"
 	coreData "github.com/multiversx/mx-chain-core-go/data"
+	"github.com/multiversx/mx-chain-core-go/data/alteredAccount"
 	"github.com/multiversx/mx-chain-core-go/data/block"
+	"github.com/multiversx/mx-chain-core-go/data/outport"
 	"github.com/multiversx/mx-chain-core-go/marshal"
 	logger "github.com/multiversx/mx-chain-logger-go"
 	"github.com/multiversx/mx-chain-notifier-go/common"
+	"github.com/multiversx/mx-chain-notifier-go/data"
 	"github.com/multiversx/mx-chain-notifier-go/process"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var log = logger.GetOrCreate("preprocess")
 
 // ArgsEventsPreProcessor defines the arguments needed to create a new events data preprocessor
 type ArgsEventsPreProcessor struct {
-	Marshaller marshal.Marshalizer
-	Facade     process.EventsFacadeHandler
+	Marshaller             marshal.Marshalizer
+	Facade                 process.EventsFacadeHandler
+	MetricsCollector       common.MetricsCollector
+	ProcessedBlocksTracker common.ProcessedBlocksTracker
+
+	// ProcessedBlocksCacheSize is the number of recently processed block hashes kept to
+	// drop the blocks resent by the observer. If 0, the duplicated blocks are processed
+	ProcessedBlocksCacheSize uint32
+
+	// RevertDetailsCacheDepth is the number of recently processed blocks of each shard kept
+	// with their transaction hashes and events, added to their revert events. If 0, the
+	// revert events only hold the block details
+	RevertDetailsCacheDepth uint32
+
+	// PubKeyConverter encodes the events addresses of the revert details, it is needed only
+	// if RevertDetailsCacheDepth is set
+	PubKeyConverter core.PubkeyConverter
+
+	// FinalizedDetailsCacheDepth is the number of recently processed blocks of each shard kept
+	// with their shard, nonce, round and timestamp, added to their finalized events. If 0, the
+	// finalized events only hold the block hash
+	FinalizedDetailsCacheDepth uint32
+
+	// FinalizedDetailsWithTxHashes adds the transaction hashes of the block to the finalized
+	// events details as well
+	FinalizedDetailsWithTxHashes bool
+
+	// EventsBlacklist holds the identifiers of the log events dropped before being handled
+	// by the facade, so that they are not published at all
+	EventsBlacklist []string
 }
 
 type baseEventsPreProcessor struct {
-	marshaller        marshal.Marshalizer
-	emptyBlockCreator EmptyBlockCreatorContainer
-	facade            process.EventsFacadeHandler
+	marshaller             marshal.Marshalizer
+	emptyBlockCreator      EmptyBlockCreatorContainer
+	facade                 process.EventsFacadeHandler
+	metricsCollector       common.MetricsCollector
+	processedBlocksTracker common.ProcessedBlocksTracker
+	processedBlocks        *processedBlocksCache
+	revertDetails          *blockDetailsCache
+	finalizedDetails       *blockDetailsCache
+	finalizedTxHashes      bool
+	pubKeyConverter        core.PubkeyConverter
+	eventsBlacklist        map[string]struct{}
 }
 
 // newBaseEventsPreProcessor will create a new base events data preprocessor instance
@@ -33,8 +78,19 @@ func newBaseEventsPreProcessor(args ArgsEventsPreProcessor) (*baseEventsPreProce
 	}
 
 	dp := &baseEventsPreProcessor{
-		marshaller: args.Marshaller,
-		facade:     args.Facade,
+		marshaller:             args.Marshaller,
+		facade:                 args.Facade,
+		metricsCollector:       args.MetricsCollector,
+		processedBlocksTracker: args.ProcessedBlocksTracker,
+		processedBlocks:        newProcessedBlocksCache(args.ProcessedBlocksCacheSize),
+		revertDetails:          newBlockDetailsCache(args.RevertDetailsCacheDepth),
+		finalizedDetails:       newBlockDetailsCache(args.FinalizedDetailsCacheDepth),
+		finalizedTxHashes:      args.FinalizedDetailsWithTxHashes,
+		pubKeyConverter:        args.PubKeyConverter,
+		eventsBlacklist:        make(map[string]struct{}, len(args.EventsBlacklist)),
+	}
+	for _, identifier := range args.EventsBlacklist {
+		dp.eventsBlacklist[identifier] = struct{}{}
 	}
 
 	emptyBlockContainer, err := createEmptyBlockCreatorContainer()
@@ -54,10 +110,236 @@ func checkBaseEventsPreProcessorArgs(args ArgsEventsPreProcessor) error {
 	if check.IfNil(args.Facade) {
 		return common.ErrNilFacadeHandler
 	}
+	if check.IfNil(args.MetricsCollector) {
+		return common.ErrNilMetricsCollector
+	}
+	if check.IfNil(args.ProcessedBlocksTracker) {
+		return common.ErrNilProcessedBlocksTracker
+	}
+	if args.RevertDetailsCacheDepth > 0 && check.IfNil(args.PubKeyConverter) {
+		return process.ErrNilPubKeyConverter
+	}
 
 	return nil
 }
 
+// isDuplicatedBlock returns true if the block was already processed, in which case the
+// block is dropped and counted as duplicated
+func (bep *baseEventsPreProcessor) isDuplicatedBlock(headerHash []byte) bool {
+	hash := hex.EncodeToString(headerHash)
+	if !bep.processedBlocks.has(hash) {
+		return false
+	}
+
+	log.Info("dropped duplicated block", "hash", hash)
+	bep.metricsCollector.AddDuplicatedBlock()
+
+	return true
+}
+
+// setBlockProcessed marks the block as processed, after its events were handled, records
+// it as the last processed block of its shard and updates the processing metrics. The lag
+// is only set for the blocks with a timestamp, which is in seconds
+func (bep *baseEventsPreProcessor) setBlockProcessed(headerHash []byte, header coreData.HeaderHandler, pool *outport.TransactionPool) {
+	hash := hex.EncodeToString(headerHash)
+	bep.processedBlocks.add(hash)
+	if bep.revertDetails.isEnabled() {
+		bep.revertDetails.add(hash, bep.getRevertDetails(header.GetShardID(), pool))
+	}
+	if bep.finalizedDetails.isEnabled() {
+		bep.finalizedDetails.add(hash, bep.getFinalizedDetails(header, pool))
+	}
+
+	processedAt := time.Now()
+	bep.processedBlocksTracker.SetProcessedBlock(data.ProcessedBlock{
+		ShardID:     header.GetShardID(),
+		Nonce:       header.GetNonce(),
+		Hash:        hash,
+		TimeStamp:   header.GetTimeStamp(),
+		ProcessedAt: processedAt.UnixNano(),
+	})
+
+	bep.metricsCollector.AddProcessedBlock(header.GetShardID(), countLogEvents(pool))
+	if header.GetTimeStamp() > 0 {
+		blockTime := time.Unix(int64(header.GetTimeStamp()), 0)
+		bep.metricsCollector.SetBlockProcessingLag(header.GetShardID(), processedAt.Sub(blockTime))
+	}
+}
+
+// countLogEvents returns the number of log events of the pool, without the blacklisted ones
+func countLogEvents(pool *outport.TransactionPool) uint64 {
+	if pool == nil {
+		return 0
+	}
+
+	numEvents := uint64(0)
+	for _, logData := range pool.Logs {
+		if logData == nil || logData.Log == nil {
+			continue
+		}
+
+		for _, event := range logData.Log.Events {
+			if event != nil {
+				numEvents++
+			}
+		}
+	}
+
+	return numEvents
+}
+
+// filterBlacklistedEvents drops the log events with a blacklisted identifier from the
+// transactions pool. The dropped events are set to nil instead of being removed, so that
+// the remaining events keep their index in the log. A block left without events is still
+// handled, with an empty list of events, since its txs and scrs are published as well
+func (bep *baseEventsPreProcessor) filterBlacklistedEvents(pool *outport.TransactionPool) {
+	if len(bep.eventsBlacklist) == 0 || pool == nil {
+		return
+	}
+
+	numFilteredEvents := make(map[string]uint64)
+	for _, logData := range pool.Logs {
+		if logData == nil || logData.Log == nil {
+			continue
+		}
+
+		for i, event := range logData.Log.Events {
+			if event == nil {
+				continue
+			}
+
+			identifier := string(event.GetIdentifier())
+			if _, isBlacklisted := bep.eventsBlacklist[identifier]; !isBlacklisted {
+				continue
+			}
+
+			logData.Log.Events[i] = nil
+			numFilteredEvents[identifier]++
+		}
+	}
+
+	for identifier, numEvents := range numFilteredEvents {
+		bep.metricsCollector.AddFilteredEvents(identifier, numEvents)
+	}
+}
+
+// getRevertDetails returns the transaction hashes of the block, together with the summaries
+// of the log events, without the blacklisted ones
+func (bep *baseEventsPreProcessor) getRevertDetails(shardID uint32, pool *outport.TransactionPool) blockDetails {
+	details := blockDetails{
+		shardID:  shardID,
+		txHashes: getTxHashes(pool),
+		events:   make([]data.RevertedEvent, 0),
+	}
+	if pool == nil {
+		return details
+	}
+
+	for _, logData := range pool.Logs {
+		if logData == nil || logData.Log == nil {
+			continue
+		}
+
+		for _, event := range logData.Log.Events {
+			if event == nil {
+				continue
+			}
+
+			details.events = append(details.events, data.RevertedEvent{
+				Address:    bep.pubKeyConverter.SilentEncode(event.GetAddress(), log),
+				Identifier: string(event.GetIdentifier()),
+				TxHash:     logData.TxHash,
+			})
+		}
+	}
+
+	return details
+}
+
+// getFinalizedDetails returns the header details of the block and, if enabled, its
+// transaction hashes
+func (bep *baseEventsPreProcessor) getFinalizedDetails(header coreData.HeaderHandler, pool *outport.TransactionPool) blockDetails {
+	details := blockDetails{
+		shardID:   header.GetShardID(),
+		nonce:     header.GetNonce(),
+		round:     header.GetRound(),
+		timeStamp: header.GetTimeStamp(),
+	}
+	if bep.finalizedTxHashes {
+		details.txHashes = getTxHashes(pool)
+	}
+
+	return details
+}
+
+// getTxHashes returns the sorted hashes of the block transactions, smart contract results,
+// invalid and reward transactions
+func getTxHashes(pool *outport.TransactionPool) []string {
+	txHashes := make([]string, 0)
+	if pool == nil {
+		return txHashes
+	}
+
+	for hash := range pool.Transactions {
+		txHashes = append(txHashes, hash)
+	}
+	for hash := range pool.SmartContractResults {
+		txHashes = append(txHashes, hash)
+	}
+	for hash := range pool.InvalidTxs {
+		txHashes = append(txHashes, hash)
+	}
+	for hash := range pool.Rewards {
+		txHashes = append(txHashes, hash)
+	}
+	sort.Strings(txHashes)
+
+	return txHashes
+}
+
+// setBlockReverted forgets the reverted block, so that it is processed again if committed.
+// If the block details are kept, they are added to the revert event, or it is marked with
+// details unavailable if the block is not among the recently processed ones
+func (bep *baseEventsPreProcessor) setBlockReverted(revertBlock *data.RevertBlock) {
+	bep.processedBlocks.remove(revertBlock.Hash)
+	bep.finalizedDetails.remove(revertBlock.Hash)
+	if !bep.revertDetails.isEnabled() {
+		return
+	}
+
+	details, ok := bep.revertDetails.get(revertBlock.Hash)
+	if !ok {
+		revertBlock.DetailsUnavailable = true
+		return
+	}
+
+	revertBlock.TxHashes = details.txHashes
+	revertBlock.Events = details.events
+	bep.revertDetails.remove(revertBlock.Hash)
+}
+
+// setBlockFinalized adds the block details to the finalized event, if they are kept, and
+// marks it as enriched. If the block is not among the recently processed ones, the event
+// only holds the block hash, marked as not enriched
+func (bep *baseEventsPreProcessor) setBlockFinalized(finalizedBlock *data.FinalizedBlock) {
+	if !bep.finalizedDetails.isEnabled() {
+		return
+	}
+
+	details, ok := bep.finalizedDetails.get(finalizedBlock.Hash)
+	finalizedBlock.Enriched = &ok
+	if !ok {
+		return
+	}
+
+	finalizedBlock.ShardID = &details.shardID
+	finalizedBlock.Nonce = details.nonce
+	finalizedBlock.Round = details.round
+	finalizedBlock.BlockTimeStamp = details.timeStamp
+	finalizedBlock.TxHashes = details.txHashes
+	bep.finalizedDetails.remove(finalizedBlock.Hash)
+}
+
 func (bep *baseEventsPreProcessor) getHeaderFromBytes(headerType core.HeaderType, headerBytes []byte) (header coreData.HeaderHandler, err error) {
 	creator, err := bep.emptyBlockCreator.Get(headerType)
 	if err != nil {
@@ -67,6 +349,105 @@ func (bep *baseEventsPreProcessor) getHeaderFromBytes(headerType core.HeaderType
 	return block.GetHeaderFromBytes(bep.marshaller, creator, headerBytes)
 }
 
+// handleRoundsInfo converts the observer rounds info and passes it to the facade
+func (bep *baseEventsPreProcessor) handleRoundsInfo(ctx context.Context, roundsInfo *outport.RoundsInfo) error {
+	rounds := make([]data.RoundInfo, 0, len(roundsInfo.GetRoundsInfo()))
+	for _, roundInfo := range roundsInfo.GetRoundsInfo() {
+		if roundInfo == nil {
+			continue
+		}
+
+		rounds = append(rounds, data.RoundInfo{
+			Round:            roundInfo.GetRound(),
+			BlockWasProposed: roundInfo.GetBlockWasProposed(),
+			SignersIndexes:   roundInfo.GetSignersIndexes(),
+			ShardID:          roundInfo.GetShardId(),
+			Epoch:            roundInfo.GetEpoch(),
+			Timestamp:        roundInfo.GetTimestamp(),
+		})
+	}
+
+	return bep.facade.HandleRoundEvents(data.RoundEvents{
+		ShardID:       roundsInfo.GetShardID(),
+		Rounds:        rounds,
+		CorrelationID: common.GetCorrelationID(ctx),
+		SpanContext:   trace.SpanContextFromContext(ctx),
+	})
+}
+
+// handleValidatorsRating converts the observer validators rating and passes it to the facade
+func (bep *baseEventsPreProcessor) handleValidatorsRating(ctx context.Context, validatorsRating *outport.ValidatorsRating) error {
+	ratings := make([]data.ValidatorRating, 0, len(validatorsRating.GetValidatorsRatingInfo()))
+	for _, ratingInfo := range validatorsRating.GetValidatorsRatingInfo() {
+		if ratingInfo == nil {
+			continue
+		}
+
+		ratings = append(ratings, data.ValidatorRating{
+			PublicKey: ratingInfo.GetPublicKey(),
+			Rating:    ratingInfo.GetRating(),
+		})
+	}
+
+	return bep.facade.HandleValidatorsRatingEvent(data.ValidatorsRatingEvent{
+		ShardID:          validatorsRating.GetShardID(),
+		Epoch:            validatorsRating.GetEpoch(),
+		ValidatorsRating: ratings,
+		CorrelationID:    common.GetCorrelationID(ctx),
+		SpanContext:      trace.SpanContextFromContext(ctx),
+	})
+}
+
+// handleAccounts converts the observer altered accounts and passes them to the facade. The
+// accounts are sorted by address, so that the published events do not depend on the map order
+func (bep *baseEventsPreProcessor) handleAccounts(ctx context.Context, accounts *outport.Accounts) error {
+	accountEvents := make([]data.AccountEvent, 0, len(accounts.GetAlteredAccounts()))
+	for _, alteredAccount := range accounts.GetAlteredAccounts() {
+		if alteredAccount == nil {
+			continue
+		}
+
+		accountEvents = append(accountEvents, data.AccountEvent{
+			Address: alteredAccount.GetAddress(),
+			Nonce:   alteredAccount.GetNonce(),
+			Balance: alteredAccount.GetBalance(),
+			Tokens:  getAccountTokenBalances(alteredAccount.GetTokens()),
+		})
+	}
+	sort.Slice(accountEvents, func(i, j int) bool {
+		return accountEvents[i].Address < accountEvents[j].Address
+	})
+
+	return bep.facade.HandleAccountsEvents(data.AccountsEvents{
+		ShardID:        accounts.GetShardID(),
+		BlockTimestamp: accounts.GetBlockTimestamp(),
+		Accounts:       accountEvents,
+		CorrelationID:  common.GetCorrelationID(ctx),
+		SpanContext:    trace.SpanContextFromContext(ctx),
+	})
+}
+
+func getAccountTokenBalances(tokens []*alteredAccount.AccountTokenData) []data.AccountTokenBalance {
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	tokenBalances := make([]data.AccountTokenBalance, 0, len(tokens))
+	for _, token := range tokens {
+		if token == nil {
+			continue
+		}
+
+		tokenBalances = append(tokenBalances, data.AccountTokenBalance{
+			Identifier: token.GetIdentifier(),
+			Nonce:      token.GetNonce(),
+			Balance:    token.GetBalance(),
+		})
+	}
+
+	return tokenBalances
+}
+
 func createEmptyBlockCreatorContainer() (EmptyBlockCreatorContainer, error) {
 	container := block.NewEmptyBlockCreatorsContainer()
 
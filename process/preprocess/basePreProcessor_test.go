@@ -3,17 +3,22 @@ package preprocess_test
 import (
 	"testing"
 
+	"github.com/multiversx/mx-chain-core-go/core"
 	"github.com/multiversx/mx-chain-core-go/core/mock"
 	"github.com/multiversx/mx-chain-notifier-go/common"
+	"github.com/multiversx/mx-chain-notifier-go/data"
 	"github.com/multiversx/mx-chain-notifier-go/mocks"
+	"github.com/multiversx/mx-chain-notifier-go/process"
 	"github.com/multiversx/mx-chain-notifier-go/process/preprocess"
 	"github.com/stretchr/testify/require"
 )
 
 func createMockEventsDataPreProcessorArgs() preprocess.ArgsEventsPreProcessor {
 	return preprocess.ArgsEventsPreProcessor{
-		Marshaller: &mock.MarshalizerMock{},
-		Facade:     &mocks.FacadeStub{},
+		Marshaller:             &mock.MarshalizerMock{},
+		Facade:                 &mocks.FacadeStub{},
+		MetricsCollector:       &mocks.MetricsCollectorStub{},
+		ProcessedBlocksTracker: &mocks.ProcessedBlocksTrackerStub{},
 	}
 }
 
@@ -42,6 +47,39 @@ func TestNewBaseEventsPreProcessor(t *testing.T) {
 		require.Equal(t, common.ErrNilFacadeHandler, err)
 	})
 
+	t.Run("nil metrics collector", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockEventsDataPreProcessorArgs()
+		args.MetricsCollector = nil
+
+		dp, err := preprocess.NewBaseEventsPreProcessor(args)
+		require.Nil(t, dp)
+		require.Equal(t, common.ErrNilMetricsCollector, err)
+	})
+
+	t.Run("nil processed blocks tracker", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockEventsDataPreProcessorArgs()
+		args.ProcessedBlocksTracker = nil
+
+		dp, err := preprocess.NewBaseEventsPreProcessor(args)
+		require.Nil(t, dp)
+		require.Equal(t, common.ErrNilProcessedBlocksTracker, err)
+	})
+
+	t.Run("nil pubkey converter with revert details", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockEventsDataPreProcessorArgs()
+		args.RevertDetailsCacheDepth = 10
+
+		dp, err := preprocess.NewBaseEventsPreProcessor(args)
+		require.Nil(t, dp)
+		require.Equal(t, process.ErrNilPubKeyConverter, err)
+	})
+
 	t.Run("should work", func(t *testing.T) {
 		t.Parallel()
 
@@ -51,3 +89,36 @@ func TestNewBaseEventsPreProcessor(t *testing.T) {
 		require.NotNil(t, dp)
 	})
 }
+
+// requireEventsTxContext checks the log events created from the block pushed by the preprocessor,
+// for a transactions pool holding:
+//   - the "aa01" transaction, with a fee of 100, and its "dd04" smart contract result
+//   - the "bb02" failed transaction, with a fee of 50
+//   - the "cc03" invalid transaction, with a fee of 10
+//
+// each of them with a log with a single "transfer" event, except "bb02" which signals the error
+func requireEventsTxContext(t *testing.T, blockData data.ArgsSaveBlockData) {
+	eventsInterceptor, err := process.NewEventsInterceptor(process.ArgsEventsInterceptor{
+		PubKeyConverter:    &mocks.PubkeyConverterMock{},
+		WithTxStatusAndFee: true,
+	})
+	require.Nil(t, err)
+
+	interceptorData, err := eventsInterceptor.ProcessBlockEvents(&blockData)
+	require.Nil(t, err)
+
+	events := make(map[string]data.Event)
+	for _, event := range interceptorData.LogEvents {
+		event.Address = ""
+		event.BlockNonce = 0
+		events[event.TxHash] = event
+	}
+
+	expectedEvents := map[string]data.Event{
+		"aa01": {Identifier: "transfer", TxHash: "aa01", TxStatus: common.TxStatusSuccess, TxFee: "100"},
+		"bb02": {Identifier: core.SignalErrorOperation, TxHash: "bb02", TxStatus: common.TxStatusFail, TxFee: "50"},
+		"cc03": {Identifier: "transfer", TxHash: "cc03", TxStatus: common.TxStatusInvalid, TxFee: "10"},
+		"dd04": {Identifier: "transfer", TxHash: "dd04", OriginalTxHash: "aa01", TxStatus: common.TxStatusSuccess, TxFee: "100"},
+	}
+	require.Equal(t, expectedEvents, events)
+}
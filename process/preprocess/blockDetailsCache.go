@@ -0,0 +1,108 @@
+package preprocess
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/multiversx/mx-chain-notifier-go/data"
+)
+
+// blockDetails holds the details of a processed block, added to its revert or finalized
+// event. Only the details needed by the event are set
+type blockDetails struct {
+	shardID   uint32
+	nonce     uint64
+	round     uint64
+	timeStamp uint64
+	txHashes  []string
+	events    []data.RevertedEvent
+}
+
+type blockDetailsEntry struct {
+	hash    string
+	details blockDetails
+}
+
+// blockDetailsCache keeps the details of the most recently processed blocks of each shard.
+// The oldest block of a shard is evicted when the shard has more than depth blocks. A cache
+// with zero depth holds no block
+type blockDetailsCache struct {
+	mut    sync.Mutex
+	depth  int
+	shards map[uint32]*list.List
+	blocks map[string]*list.Element
+}
+
+func newBlockDetailsCache(depth uint32) *blockDetailsCache {
+	return &blockDetailsCache{
+		depth:  int(depth),
+		shards: make(map[uint32]*list.List),
+		blocks: make(map[string]*list.Element),
+	}
+}
+
+func (bdc *blockDetailsCache) isEnabled() bool {
+	return bdc.depth > 0
+}
+
+// add saves the details of the block hash, replacing the previous ones, if any
+func (bdc *blockDetailsCache) add(hash string, details blockDetails) {
+	if !bdc.isEnabled() {
+		return
+	}
+
+	bdc.mut.Lock()
+	defer bdc.mut.Unlock()
+
+	bdc.removeUnprotected(hash)
+
+	order, ok := bdc.shards[details.shardID]
+	if !ok {
+		order = list.New()
+		bdc.shards[details.shardID] = order
+	}
+
+	bdc.blocks[hash] = order.PushFront(&blockDetailsEntry{
+		hash:    hash,
+		details: details,
+	})
+	if order.Len() <= bdc.depth {
+		return
+	}
+
+	oldest := order.Back()
+	order.Remove(oldest)
+	delete(bdc.blocks, oldest.Value.(*blockDetailsEntry).hash)
+}
+
+// get returns the details of the block hash, if the block is still in the cache
+func (bdc *blockDetailsCache) get(hash string) (blockDetails, bool) {
+	bdc.mut.Lock()
+	defer bdc.mut.Unlock()
+
+	element, ok := bdc.blocks[hash]
+	if !ok {
+		return blockDetails{}, false
+	}
+
+	return element.Value.(*blockDetailsEntry).details, true
+}
+
+// remove forgets the details of the block hash
+func (bdc *blockDetailsCache) remove(hash string) {
+	bdc.mut.Lock()
+	defer bdc.mut.Unlock()
+
+	bdc.removeUnprotected(hash)
+}
+
+func (bdc *blockDetailsCache) removeUnprotected(hash string) {
+	element, ok := bdc.blocks[hash]
+	if !ok {
+		return
+	}
+
+	entry := element.Value.(*blockDetailsEntry)
+	bdc.shards[entry.details.shardID].Remove(element)
+	delete(bdc.blocks, hash)
+}
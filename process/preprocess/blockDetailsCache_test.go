@@ -0,0 +1,68 @@
+package preprocess
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockDetailsCache(t *testing.T) {
+	t.Parallel()
+
+	t.Run("zero depth should hold no block", func(t *testing.T) {
+		t.Parallel()
+
+		cache := newBlockDetailsCache(0)
+		cache.add("hash1", blockDetails{txHashes: []string{"txHash1"}})
+
+		_, ok := cache.get("hash1")
+		require.False(t, ok)
+	})
+
+	t.Run("oldest block of the shard should be evicted", func(t *testing.T) {
+		t.Parallel()
+
+		cache := newBlockDetailsCache(2)
+		cache.add("hash1", blockDetails{txHashes: []string{"txHash1"}})
+		cache.add("hash2", blockDetails{shardID: 1})
+		cache.add("hash3", blockDetails{})
+		cache.add("hash4", blockDetails{})
+
+		_, ok := cache.get("hash1")
+		require.False(t, ok)
+		_, ok = cache.get("hash2")
+		require.True(t, ok)
+		_, ok = cache.get("hash3")
+		require.True(t, ok)
+		_, ok = cache.get("hash4")
+		require.True(t, ok)
+		require.Equal(t, 2, cache.shards[0].Len())
+		require.Equal(t, 3, len(cache.blocks))
+	})
+
+	t.Run("re-added block should replace the previous details", func(t *testing.T) {
+		t.Parallel()
+
+		cache := newBlockDetailsCache(2)
+		cache.add("hash1", blockDetails{txHashes: []string{"txHash1"}})
+		cache.add("hash1", blockDetails{txHashes: []string{"txHash2"}})
+
+		details, ok := cache.get("hash1")
+		require.True(t, ok)
+		require.Equal(t, []string{"txHash2"}, details.txHashes)
+		require.Equal(t, 1, cache.shards[0].Len())
+	})
+
+	t.Run("removed block should not be held", func(t *testing.T) {
+		t.Parallel()
+
+		cache := newBlockDetailsCache(2)
+		cache.add("hash1", blockDetails{})
+		cache.remove("hash1")
+		cache.remove("hash2")
+
+		_, ok := cache.get("hash1")
+		require.False(t, ok)
+		require.Equal(t, 0, cache.shards[0].Len())
+	})
+}
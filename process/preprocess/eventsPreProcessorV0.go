@@ -1,15 +1,19 @@
 package preprocess
 
 import (
+	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/multiversx/mx-chain-core-go/core"
 	coreData "github.com/multiversx/mx-chain-core-go/data"
 	nodeData "github.com/multiversx/mx-chain-core-go/data"
 	"github.com/multiversx/mx-chain-core-go/data/block"
 	"github.com/multiversx/mx-chain-core-go/data/outport"
+	"github.com/multiversx/mx-chain-notifier-go/common"
 	"github.com/multiversx/mx-chain-notifier-go/data"
 	"github.com/multiversx/mx-chain-notifier-go/process"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // TODO: dismiss this implementation after http integration is fully deprecated
@@ -30,12 +34,15 @@ func NewEventsPreProcessorV0(args ArgsEventsPreProcessor) (*eventsPreProcessorV0
 }
 
 // SaveBlock will handle the block info data
-func (d *eventsPreProcessorV0) SaveBlock(marshalledData []byte) error {
+func (d *eventsPreProcessorV0) SaveBlock(ctx context.Context, marshalledData []byte) error {
 	blockData := &data.OutportBlockDataOld{}
 	err := json.Unmarshal(marshalledData, blockData)
 	if err != nil {
 		return err
 	}
+	if d.isDuplicatedBlock(blockData.HeaderHash) {
+		return nil
+	}
 
 	header, err := d.getHeader(marshalledData)
 	if err != nil {
@@ -57,13 +64,20 @@ func (d *eventsPreProcessorV0) SaveBlock(marshalledData []byte) error {
 		NumberOfShards:         blockData.NumberOfShards,
 		TransactionsPool:       txsPool,
 		Header:                 header,
+		CorrelationID:          common.GetCorrelationID(ctx),
+		SpanContext:            trace.SpanContextFromContext(ctx),
+		Timestamp:              time.Now().UnixNano(),
 	}
 
+	d.filterBlacklistedEvents(saveBlockData.TransactionsPool)
+
 	err = d.facade.HandlePushEvents(*saveBlockData)
 	if err != nil {
 		return err
 	}
 
+	d.setBlockProcessed(blockData.HeaderHash, header, saveBlockData.TransactionsPool)
+
 	return nil
 }
 
@@ -75,6 +89,7 @@ func (d *eventsPreProcessorV0) parseTransactionsPool(txsPool *data.TransactionsP
 	return &outport.TransactionPool{
 		Transactions:         d.parseTxs(txsPool.Txs),
 		SmartContractResults: d.parseScrs(txsPool.Scrs),
+		InvalidTxs:           d.parseTxs(txsPool.Invalid),
 		Logs:                 d.parseLogs(txsPool.Logs),
 	}, nil
 }
@@ -157,29 +172,66 @@ func (d *eventsPreProcessorV0) getHeaderFromBytes(headerType core.HeaderType, he
 }
 
 // RevertIndexedBlock will handle the revert block event
-func (d *eventsPreProcessorV0) RevertIndexedBlock(marshalledData []byte) error {
+func (d *eventsPreProcessorV0) RevertIndexedBlock(ctx context.Context, marshalledData []byte) error {
 	revertBlock := &data.RevertBlock{}
 	err := d.marshaller.Unmarshal(revertBlock, marshalledData)
 	if err != nil {
 		return err
 	}
 
-	d.facade.HandleRevertEvents(*revertBlock)
-
-	return nil
+	revertBlock.CorrelationID = common.GetCorrelationID(ctx)
+	revertBlock.SpanContext = trace.SpanContextFromContext(ctx)
+	revertBlock.Timestamp = time.Now().UnixNano()
+	d.setBlockReverted(revertBlock)
+	return d.facade.HandleRevertEvents(*revertBlock)
 }
 
 // FinalizedBlock will handle the finalized block event
-func (d *eventsPreProcessorV0) FinalizedBlock(marshalledData []byte) error {
+func (d *eventsPreProcessorV0) FinalizedBlock(ctx context.Context, marshalledData []byte) error {
 	finalizedBlock := &data.FinalizedBlock{}
 	err := d.marshaller.Unmarshal(finalizedBlock, marshalledData)
 	if err != nil {
 		return err
 	}
 
-	d.facade.HandleFinalizedEvents(*finalizedBlock)
+	finalizedBlock.CorrelationID = common.GetCorrelationID(ctx)
+	finalizedBlock.SpanContext = trace.SpanContextFromContext(ctx)
+	finalizedBlock.Timestamp = time.Now().UnixNano()
+	d.setBlockFinalized(finalizedBlock)
+	return d.facade.HandleFinalizedEvents(*finalizedBlock)
+}
+
+// SaveRounds will handle the rounds info event
+func (d *eventsPreProcessorV0) SaveRounds(ctx context.Context, marshalledData []byte) error {
+	roundsInfo := &outport.RoundsInfo{}
+	err := d.marshaller.Unmarshal(roundsInfo, marshalledData)
+	if err != nil {
+		return err
+	}
 
-	return nil
+	return d.handleRoundsInfo(ctx, roundsInfo)
+}
+
+// SaveValidatorsRating will handle the validators rating event
+func (d *eventsPreProcessorV0) SaveValidatorsRating(ctx context.Context, marshalledData []byte) error {
+	validatorsRating := &outport.ValidatorsRating{}
+	err := d.marshaller.Unmarshal(validatorsRating, marshalledData)
+	if err != nil {
+		return err
+	}
+
+	return d.handleValidatorsRating(ctx, validatorsRating)
+}
+
+// SaveAccounts will handle the altered accounts event
+func (d *eventsPreProcessorV0) SaveAccounts(ctx context.Context, marshalledData []byte) error {
+	accounts := &outport.Accounts{}
+	err := d.marshaller.Unmarshal(accounts, marshalledData)
+	if err != nil {
+		return err
+	}
+
+	return d.handleAccounts(ctx, accounts)
 }
 
 // IsInterfaceNil returns true if there is no value under the interface
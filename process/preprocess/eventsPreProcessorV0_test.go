@@ -1,11 +1,17 @@
 package preprocess_test
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"math/big"
 	"testing"
 
+	"github.com/multiversx/mx-chain-core-go/core"
 	coreData "github.com/multiversx/mx-chain-core-go/data"
+	"github.com/multiversx/mx-chain-core-go/data/outport"
+	"github.com/multiversx/mx-chain-core-go/data/smartContractResult"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
 	"github.com/multiversx/mx-chain-core-go/marshal"
 	"github.com/multiversx/mx-chain-notifier-go/data"
 	notifierData "github.com/multiversx/mx-chain-notifier-go/data"
@@ -32,7 +38,7 @@ func TestPreProcessorV0_SaveBlock(t *testing.T) {
 		dp, err := preprocess.NewEventsPreProcessorV0(createMockEventsDataPreProcessorArgs())
 		require.Nil(t, err)
 
-		err = dp.SaveBlock(marshalledBlock)
+		err = dp.SaveBlock(context.Background(), marshalledBlock)
 		require.Equal(t, coreData.ErrInvalidHeaderType, err)
 	})
 
@@ -54,7 +60,7 @@ func TestPreProcessorV0_SaveBlock(t *testing.T) {
 		require.Nil(t, err)
 
 		marshalledBlock, _ := json.Marshal(outportBlock)
-		err = dp.SaveBlock(marshalledBlock)
+		err = dp.SaveBlock(context.Background(), marshalledBlock)
 		require.Equal(t, expectedErr, err)
 	})
 
@@ -78,11 +84,90 @@ func TestPreProcessorV0_SaveBlock(t *testing.T) {
 
 		marshalledBlock, err := json.Marshal(outportBlock)
 		require.Nil(t, err)
-		err = dp.SaveBlock(marshalledBlock)
+		err = dp.SaveBlock(context.Background(), marshalledBlock)
 		require.Nil(t, err)
 
 		require.True(t, wasCalled)
 	})
+
+	t.Run("should keep the tx context of the events", func(t *testing.T) {
+		t.Parallel()
+
+		var pushedBlock data.ArgsSaveBlockData
+		args := createMockEventsDataPreProcessorArgs()
+		args.Facade = &mocks.FacadeStub{
+			HandlePushEventsCalled: func(events data.ArgsSaveBlockData) error {
+				pushedBlock = events
+				return nil
+			},
+		}
+
+		dp, err := preprocess.NewEventsPreProcessorV0(args)
+		require.Nil(t, err)
+
+		outportBlock := blockData.OutportBlockV0()
+		txsPool := outportBlock.TransactionsPool
+		txsPool.Txs["aa01"] = &data.NodeTransaction{
+			TransactionHandler: &transaction.Transaction{},
+			FeeInfo:            outport.FeeInfo{Fee: big.NewInt(100)},
+		}
+		txsPool.Txs["bb02"] = &data.NodeTransaction{
+			TransactionHandler: &transaction.Transaction{},
+			FeeInfo:            outport.FeeInfo{Fee: big.NewInt(50)},
+		}
+		txsPool.Invalid = map[string]*data.NodeTransaction{
+			"cc03": {
+				TransactionHandler: &transaction.Transaction{},
+				FeeInfo:            outport.FeeInfo{Fee: big.NewInt(10)},
+			},
+		}
+		txsPool.Scrs["dd04"] = &data.NodeSmartContractResult{
+			TransactionHandler: &smartContractResult.SmartContractResult{OriginalTxHash: []byte{0xaa, 0x01}},
+		}
+		for txHash, identifier := range map[string]string{"aa01": "transfer", "bb02": core.SignalErrorOperation, "cc03": "transfer", "dd04": "transfer"} {
+			txsPool.Logs = append(txsPool.Logs, &data.LogData{
+				TxHash:     txHash,
+				LogHandler: &transaction.Log{Events: []*transaction.Event{{Identifier: []byte(identifier)}}},
+			})
+		}
+
+		marshalledBlock, err := json.Marshal(outportBlock)
+		require.Nil(t, err)
+		err = dp.SaveBlock(context.Background(), marshalledBlock)
+		require.Nil(t, err)
+
+		requireEventsTxContext(t, pushedBlock)
+	})
+
+	t.Run("resent block should be dropped", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockEventsDataPreProcessorArgs()
+		args.ProcessedBlocksCacheSize = 10
+
+		numPushEvents := 0
+		args.Facade = &mocks.FacadeStub{
+			HandlePushEventsCalled: func(events data.ArgsSaveBlockData) error {
+				numPushEvents++
+				return nil
+			},
+		}
+
+		dp, err := preprocess.NewEventsPreProcessorV0(args)
+		require.Nil(t, err)
+
+		outportBlock := blockData.OutportBlockV0()
+		outportBlock.HeaderHash = []byte("hash1")
+
+		marshalledBlock, err := json.Marshal(outportBlock)
+		require.Nil(t, err)
+		err = dp.SaveBlock(context.Background(), marshalledBlock)
+		require.Nil(t, err)
+		err = dp.SaveBlock(context.Background(), marshalledBlock)
+		require.Nil(t, err)
+
+		require.Equal(t, 1, numPushEvents)
+	})
 }
 
 func TestPreProcessorV0_RevertIndexerBlock(t *testing.T) {
@@ -99,7 +184,7 @@ func TestPreProcessorV0_RevertIndexerBlock(t *testing.T) {
 	require.Nil(t, err)
 
 	marshalledBlock, _ := json.Marshal(blockData)
-	err = dp.RevertIndexedBlock(marshalledBlock)
+	err = dp.RevertIndexedBlock(context.Background(), marshalledBlock)
 	require.Nil(t, err)
 }
 
@@ -114,6 +199,121 @@ func TestPreProcessorV0_FinalizedBlock(t *testing.T) {
 	require.Nil(t, err)
 
 	marshalledBlock, _ := json.Marshal(finalizedBlock)
-	err = dp.FinalizedBlock(marshalledBlock)
+	err = dp.FinalizedBlock(context.Background(), marshalledBlock)
 	require.Nil(t, err)
 }
+
+func TestPreProcessorV0_SaveValidatorsRating(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid payload should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockEventsDataPreProcessorArgs()
+		args.Marshaller = &marshal.JsonMarshalizer{}
+		args.Facade = &mocks.FacadeStub{
+			HandleValidatorsRatingEventCalled: func(event data.ValidatorsRatingEvent) error {
+				require.Fail(t, "should not have been called")
+				return nil
+			},
+		}
+
+		dp, err := preprocess.NewEventsPreProcessorV0(args)
+		require.Nil(t, err)
+
+		err = dp.SaveValidatorsRating(context.Background(), []byte("invalid"))
+		require.NotNil(t, err)
+	})
+
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		// legacy observers push the outport validators rating as json
+		marshalledRating := []byte(`{"shardID":1,"epoch":5,"validatorsRatingInfo":[{"publicKey":"pubKey1","rating":50.5},{"publicKey":"pubKey2","rating":100}]}`)
+
+		var validatorsRating data.ValidatorsRatingEvent
+		args := createMockEventsDataPreProcessorArgs()
+		args.Marshaller = &marshal.JsonMarshalizer{}
+		args.Facade = &mocks.FacadeStub{
+			HandleValidatorsRatingEventCalled: func(event data.ValidatorsRatingEvent) error {
+				validatorsRating = event
+				return nil
+			},
+		}
+
+		dp, err := preprocess.NewEventsPreProcessorV0(args)
+		require.Nil(t, err)
+
+		err = dp.SaveValidatorsRating(context.Background(), marshalledRating)
+		require.Nil(t, err)
+
+		require.Equal(t, uint32(1), validatorsRating.ShardID)
+		require.Equal(t, uint32(5), validatorsRating.Epoch)
+		require.Equal(t, []data.ValidatorRating{
+			{PublicKey: "pubKey1", Rating: 50.5},
+			{PublicKey: "pubKey2", Rating: 100},
+		}, validatorsRating.ValidatorsRating)
+		require.NotEmpty(t, validatorsRating.CorrelationID)
+	})
+}
+
+func TestPreProcessorV0_SaveAccounts(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid payload should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockEventsDataPreProcessorArgs()
+		args.Marshaller = &marshal.JsonMarshalizer{}
+		args.Facade = &mocks.FacadeStub{
+			HandleAccountsEventsCalled: func(event data.AccountsEvents) error {
+				require.Fail(t, "should not have been called")
+				return nil
+			},
+		}
+
+		dp, err := preprocess.NewEventsPreProcessorV0(args)
+		require.Nil(t, err)
+
+		err = dp.SaveAccounts(context.Background(), []byte("invalid"))
+		require.NotNil(t, err)
+	})
+
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		// legacy observers push the outport accounts as json, keyed by address
+		marshalledAccounts := []byte(`{"shardID":1,"blockTimestamp":1234,"alteredAccounts":{` +
+			`"erd1bob":{"address":"erd1bob","nonce":7,"balance":"2000"},` +
+			`"erd1alice":{"address":"erd1alice","nonce":3,"balance":"1000","tokens":[{"nonce":0,"identifier":"TKN-abcdef","balance":"50","properties":""}]}}}`)
+
+		var accountsEvents data.AccountsEvents
+		args := createMockEventsDataPreProcessorArgs()
+		args.Marshaller = &marshal.JsonMarshalizer{}
+		args.Facade = &mocks.FacadeStub{
+			HandleAccountsEventsCalled: func(event data.AccountsEvents) error {
+				accountsEvents = event
+				return nil
+			},
+		}
+
+		dp, err := preprocess.NewEventsPreProcessorV0(args)
+		require.Nil(t, err)
+
+		err = dp.SaveAccounts(context.Background(), marshalledAccounts)
+		require.Nil(t, err)
+
+		require.Equal(t, uint32(1), accountsEvents.ShardID)
+		require.Equal(t, uint64(1234), accountsEvents.BlockTimestamp)
+		require.Equal(t, []data.AccountEvent{
+			{
+				Address: "erd1alice",
+				Nonce:   3,
+				Balance: "1000",
+				Tokens:  []data.AccountTokenBalance{{Identifier: "TKN-abcdef", Nonce: 0, Balance: "50"}},
+			},
+			{Address: "erd1bob", Nonce: 7, Balance: "2000"},
+		}, accountsEvents.Accounts)
+		require.NotEmpty(t, accountsEvents.CorrelationID)
+	})
+}
@@ -1,12 +1,16 @@
 package preprocess
 
 import (
+	"context"
 	"encoding/hex"
 	"errors"
+	"time"
 
 	"github.com/multiversx/mx-chain-core-go/core"
 	"github.com/multiversx/mx-chain-core-go/data/outport"
+	"github.com/multiversx/mx-chain-notifier-go/common"
 	"github.com/multiversx/mx-chain-notifier-go/data"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var (
@@ -37,7 +41,7 @@ func NewEventsPreProcessorV1(args ArgsEventsPreProcessor) (*eventsPreProcessorV1
 }
 
 // SaveBlock will handle the block info data
-func (d *eventsPreProcessorV1) SaveBlock(marshalledData []byte) error {
+func (d *eventsPreProcessorV1) SaveBlock(ctx context.Context, marshalledData []byte) error {
 	outportBlock := &outport.OutportBlock{}
 	err := d.marshaller.Unmarshal(outportBlock, marshalledData)
 	if err != nil {
@@ -48,6 +52,9 @@ func (d *eventsPreProcessorV1) SaveBlock(marshalledData []byte) error {
 	if err != nil {
 		return err
 	}
+	if d.isDuplicatedBlock(outportBlock.BlockData.HeaderHash) {
+		return nil
+	}
 
 	header, err := d.getHeaderFromBytes(core.HeaderType(outportBlock.BlockData.HeaderType), outportBlock.BlockData.HeaderBytes)
 	if err != nil {
@@ -64,13 +71,20 @@ func (d *eventsPreProcessorV1) SaveBlock(marshalledData []byte) error {
 		NumberOfShards:         outportBlock.NumberOfShards,
 		TransactionsPool:       outportBlock.TransactionPool,
 		Header:                 header,
+		CorrelationID:          common.GetCorrelationID(ctx),
+		SpanContext:            trace.SpanContextFromContext(ctx),
+		Timestamp:              time.Now().UnixNano(),
 	}
 
+	d.filterBlacklistedEvents(saveBlockData.TransactionsPool)
+
 	err = d.facade.HandlePushEvents(*saveBlockData)
 	if err != nil {
 		return err
 	}
 
+	d.setBlockProcessed(outportBlock.BlockData.HeaderHash, header, saveBlockData.TransactionsPool)
+
 	return nil
 }
 
@@ -89,7 +103,7 @@ func checkBlockDataValid(block *outport.OutportBlock) error {
 }
 
 // RevertIndexedBlock will handle the revert block event
-func (d *eventsPreProcessorV1) RevertIndexedBlock(marshalledData []byte) error {
+func (d *eventsPreProcessorV1) RevertIndexedBlock(ctx context.Context, marshalledData []byte) error {
 	blockData := &outport.BlockData{}
 	err := d.marshaller.Unmarshal(blockData, marshalledData)
 	if err != nil {
@@ -106,15 +120,18 @@ func (d *eventsPreProcessorV1) RevertIndexedBlock(marshalledData []byte) error {
 		Nonce: header.GetNonce(),
 		Round: header.GetRound(),
 		Epoch: header.GetEpoch(),
-	}
 
-	d.facade.HandleRevertEvents(*revertData)
+		Timestamp:     time.Now().UnixNano(),
+		CorrelationID: common.GetCorrelationID(ctx),
+		SpanContext:   trace.SpanContextFromContext(ctx),
+	}
 
-	return nil
+	d.setBlockReverted(revertData)
+	return d.facade.HandleRevertEvents(*revertData)
 }
 
 // FinalizedBlock will handle the finalized block event
-func (d *eventsPreProcessorV1) FinalizedBlock(marshalledData []byte) error {
+func (d *eventsPreProcessorV1) FinalizedBlock(ctx context.Context, marshalledData []byte) error {
 	finalizedBlock := &outport.FinalizedBlock{}
 	err := d.marshaller.Unmarshal(finalizedBlock, marshalledData)
 	if err != nil {
@@ -122,12 +139,47 @@ func (d *eventsPreProcessorV1) FinalizedBlock(marshalledData []byte) error {
 	}
 
 	finalizedData := data.FinalizedBlock{
-		Hash: hex.EncodeToString(finalizedBlock.GetHeaderHash()),
+		Hash:          hex.EncodeToString(finalizedBlock.GetHeaderHash()),
+		Timestamp:     time.Now().UnixNano(),
+		CorrelationID: common.GetCorrelationID(ctx),
+		SpanContext:   trace.SpanContextFromContext(ctx),
 	}
 
-	d.facade.HandleFinalizedEvents(finalizedData)
+	d.setBlockFinalized(&finalizedData)
+	return d.facade.HandleFinalizedEvents(finalizedData)
+}
 
-	return nil
+// SaveRounds will handle the rounds info event
+func (d *eventsPreProcessorV1) SaveRounds(ctx context.Context, marshalledData []byte) error {
+	roundsInfo := &outport.RoundsInfo{}
+	err := d.marshaller.Unmarshal(roundsInfo, marshalledData)
+	if err != nil {
+		return err
+	}
+
+	return d.handleRoundsInfo(ctx, roundsInfo)
+}
+
+// SaveValidatorsRating will handle the validators rating event
+func (d *eventsPreProcessorV1) SaveValidatorsRating(ctx context.Context, marshalledData []byte) error {
+	validatorsRating := &outport.ValidatorsRating{}
+	err := d.marshaller.Unmarshal(validatorsRating, marshalledData)
+	if err != nil {
+		return err
+	}
+
+	return d.handleValidatorsRating(ctx, validatorsRating)
+}
+
+// SaveAccounts will handle the altered accounts event
+func (d *eventsPreProcessorV1) SaveAccounts(ctx context.Context, marshalledData []byte) error {
+	accounts := &outport.Accounts{}
+	err := d.marshaller.Unmarshal(accounts, marshalledData)
+	if err != nil {
+		return err
+	}
+
+	return d.handleAccounts(ctx, accounts)
 }
 
 // IsInterfaceNil returns true if there is no value under the interface
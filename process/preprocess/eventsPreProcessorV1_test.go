@@ -1,18 +1,29 @@
 package preprocess_test
 
 import (
+	"context"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"math/big"
 	"testing"
+	"time"
 
+	"github.com/multiversx/mx-chain-core-go/core"
 	coreData "github.com/multiversx/mx-chain-core-go/data"
+	"github.com/multiversx/mx-chain-core-go/data/alteredAccount"
 	"github.com/multiversx/mx-chain-core-go/data/block"
 	"github.com/multiversx/mx-chain-core-go/data/outport"
 	"github.com/multiversx/mx-chain-core-go/data/smartContractResult"
 	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-core-go/marshal"
+	"github.com/multiversx/mx-chain-notifier-go/common"
 	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/metrics"
 	"github.com/multiversx/mx-chain-notifier-go/mocks"
+	"github.com/multiversx/mx-chain-notifier-go/process"
 	"github.com/multiversx/mx-chain-notifier-go/process/preprocess"
+	"github.com/multiversx/mx-chain-notifier-go/testdata"
 	"github.com/stretchr/testify/require"
 )
 
@@ -29,7 +40,7 @@ func TestPreProcessorV1_SaveBlock(t *testing.T) {
 		dp, err := preprocess.NewEventsPreProcessorV1(createMockEventsDataPreProcessorArgs())
 		require.Nil(t, err)
 
-		err = dp.SaveBlock(marshalledBlock)
+		err = dp.SaveBlock(context.Background(), marshalledBlock)
 		require.Equal(t, preprocess.ErrNilBlockData, err)
 	})
 
@@ -43,7 +54,7 @@ func TestPreProcessorV1_SaveBlock(t *testing.T) {
 		require.Nil(t, err)
 
 		marshalledBlock, _ := json.Marshal(outportBlock)
-		err = dp.SaveBlock(marshalledBlock)
+		err = dp.SaveBlock(context.Background(), marshalledBlock)
 		require.Equal(t, preprocess.ErrNilTransactionPool, err)
 	})
 
@@ -57,7 +68,7 @@ func TestPreProcessorV1_SaveBlock(t *testing.T) {
 		require.Nil(t, err)
 
 		marshalledBlock, _ := json.Marshal(outportBlock)
-		err = dp.SaveBlock(marshalledBlock)
+		err = dp.SaveBlock(context.Background(), marshalledBlock)
 		require.Equal(t, preprocess.ErrNilHeaderGasConsumption, err)
 	})
 
@@ -71,7 +82,7 @@ func TestPreProcessorV1_SaveBlock(t *testing.T) {
 		require.Nil(t, err)
 
 		marshalledBlock, _ := json.Marshal(outportBlock)
-		err = dp.SaveBlock(marshalledBlock)
+		err = dp.SaveBlock(context.Background(), marshalledBlock)
 		require.Equal(t, coreData.ErrInvalidHeaderType, err)
 	})
 
@@ -93,7 +104,7 @@ func TestPreProcessorV1_SaveBlock(t *testing.T) {
 		require.Nil(t, err)
 
 		marshalledBlock, _ := json.Marshal(outportBlock)
-		err = dp.SaveBlock(marshalledBlock)
+		err = dp.SaveBlock(context.Background(), marshalledBlock)
 		require.Equal(t, expectedErr, err)
 	})
 
@@ -106,9 +117,489 @@ func TestPreProcessorV1_SaveBlock(t *testing.T) {
 		outportBlock := createDefaultOutportBlock()
 
 		marshalledBlock, _ := json.Marshal(outportBlock)
-		err = dp.SaveBlock(marshalledBlock)
+		err = dp.SaveBlock(context.Background(), marshalledBlock)
 		require.Nil(t, err)
 	})
+
+	t.Run("should set the ingestion timestamp", func(t *testing.T) {
+		t.Parallel()
+
+		var timestamp int64
+		args := createMockEventsDataPreProcessorArgs()
+		args.Facade = &mocks.FacadeStub{
+			HandlePushEventsCalled: func(events data.ArgsSaveBlockData) error {
+				timestamp = events.Timestamp
+				return nil
+			},
+		}
+
+		dp, err := preprocess.NewEventsPreProcessorV1(args)
+		require.Nil(t, err)
+
+		startTime := time.Now().UnixNano()
+		marshalledBlock, _ := json.Marshal(createDefaultOutportBlock())
+		err = dp.SaveBlock(context.Background(), marshalledBlock)
+		require.Nil(t, err)
+
+		require.GreaterOrEqual(t, timestamp, startTime)
+	})
+
+	t.Run("should keep the tx context of the events", func(t *testing.T) {
+		t.Parallel()
+
+		var pushedBlock data.ArgsSaveBlockData
+		args := createMockEventsDataPreProcessorArgs()
+		args.Facade = &mocks.FacadeStub{
+			HandlePushEventsCalled: func(events data.ArgsSaveBlockData) error {
+				pushedBlock = events
+				return nil
+			},
+		}
+
+		dp, err := preprocess.NewEventsPreProcessorV1(args)
+		require.Nil(t, err)
+
+		outportBlock := createDefaultOutportBlock()
+		txPool := outportBlock.TransactionPool
+		txPool.Transactions["aa01"] = &outport.TxInfo{
+			Transaction: &transaction.Transaction{},
+			FeeInfo:     &outport.FeeInfo{Fee: big.NewInt(100)},
+		}
+		txPool.Transactions["bb02"] = &outport.TxInfo{
+			Transaction: &transaction.Transaction{},
+			FeeInfo:     &outport.FeeInfo{Fee: big.NewInt(50)},
+		}
+		txPool.InvalidTxs = map[string]*outport.TxInfo{
+			"cc03": {
+				Transaction: &transaction.Transaction{},
+				FeeInfo:     &outport.FeeInfo{Fee: big.NewInt(10)},
+			},
+		}
+		txPool.SmartContractResults["dd04"] = &outport.SCRInfo{
+			SmartContractResult: &smartContractResult.SmartContractResult{OriginalTxHash: []byte{0xaa, 0x01}},
+		}
+		for txHash, identifier := range map[string]string{"aa01": "transfer", "bb02": core.SignalErrorOperation, "cc03": "transfer", "dd04": "transfer"} {
+			txPool.Logs = append(txPool.Logs, &outport.LogData{
+				TxHash: txHash,
+				Log:    &transaction.Log{Events: []*transaction.Event{{Identifier: []byte(identifier)}}},
+			})
+		}
+
+		marshalledBlock, _ := json.Marshal(outportBlock)
+		err = dp.SaveBlock(context.Background(), marshalledBlock)
+		require.Nil(t, err)
+
+		requireEventsTxContext(t, pushedBlock)
+	})
+}
+
+func TestPreProcessorV1_SaveBlockDuplicates(t *testing.T) {
+	t.Parallel()
+
+	t.Run("resent block should be dropped", func(t *testing.T) {
+		t.Parallel()
+
+		numPushEvents := 0
+		numDuplicatedBlocks := 0
+		args := createMockEventsDataPreProcessorArgs()
+		args.ProcessedBlocksCacheSize = 10
+		args.Facade = &mocks.FacadeStub{
+			HandlePushEventsCalled: func(events data.ArgsSaveBlockData) error {
+				numPushEvents++
+				return nil
+			},
+		}
+		args.MetricsCollector = &mocks.MetricsCollectorStub{
+			AddDuplicatedBlockCalled: func() {
+				numDuplicatedBlocks++
+			},
+		}
+
+		dp, err := preprocess.NewEventsPreProcessorV1(args)
+		require.Nil(t, err)
+
+		outportBlock := createDefaultOutportBlock()
+		outportBlock.BlockData.HeaderHash = []byte("hash1")
+		marshalledBlock, _ := json.Marshal(outportBlock)
+
+		err = dp.SaveBlock(context.Background(), marshalledBlock)
+		require.Nil(t, err)
+		err = dp.SaveBlock(context.Background(), marshalledBlock)
+		require.Nil(t, err)
+
+		require.Equal(t, 1, numPushEvents)
+		require.Equal(t, 1, numDuplicatedBlocks)
+	})
+
+	t.Run("block failed to be handled should be processed when resent", func(t *testing.T) {
+		t.Parallel()
+
+		numPushEvents := 0
+		expectedErr := errors.New("exp error")
+		args := createMockEventsDataPreProcessorArgs()
+		args.ProcessedBlocksCacheSize = 10
+		args.Facade = &mocks.FacadeStub{
+			HandlePushEventsCalled: func(events data.ArgsSaveBlockData) error {
+				numPushEvents++
+				if numPushEvents == 1 {
+					return expectedErr
+				}
+				return nil
+			},
+		}
+
+		dp, err := preprocess.NewEventsPreProcessorV1(args)
+		require.Nil(t, err)
+
+		outportBlock := createDefaultOutportBlock()
+		outportBlock.BlockData.HeaderHash = []byte("hash1")
+		marshalledBlock, _ := json.Marshal(outportBlock)
+
+		err = dp.SaveBlock(context.Background(), marshalledBlock)
+		require.Equal(t, expectedErr, err)
+		err = dp.SaveBlock(context.Background(), marshalledBlock)
+		require.Nil(t, err)
+
+		require.Equal(t, 2, numPushEvents)
+	})
+
+	t.Run("reverted block should be processed when committed again", func(t *testing.T) {
+		t.Parallel()
+
+		numPushEvents := 0
+		args := createMockEventsDataPreProcessorArgs()
+		args.ProcessedBlocksCacheSize = 10
+		args.Facade = &mocks.FacadeStub{
+			HandlePushEventsCalled: func(events data.ArgsSaveBlockData) error {
+				numPushEvents++
+				return nil
+			},
+		}
+
+		dp, err := preprocess.NewEventsPreProcessorV1(args)
+		require.Nil(t, err)
+
+		outportBlock := createDefaultOutportBlock()
+		outportBlock.BlockData.HeaderHash = []byte("hash1")
+		marshalledBlock, _ := json.Marshal(outportBlock)
+		marshalledRevert, _ := json.Marshal(outportBlock.BlockData)
+
+		err = dp.SaveBlock(context.Background(), marshalledBlock)
+		require.Nil(t, err)
+		err = dp.RevertIndexedBlock(context.Background(), marshalledRevert)
+		require.Nil(t, err)
+		err = dp.SaveBlock(context.Background(), marshalledBlock)
+		require.Nil(t, err)
+
+		require.Equal(t, 2, numPushEvents)
+	})
+
+	t.Run("disabled cache should process resent blocks", func(t *testing.T) {
+		t.Parallel()
+
+		numPushEvents := 0
+		args := createMockEventsDataPreProcessorArgs()
+		args.ProcessedBlocksCacheSize = 0
+		args.Facade = &mocks.FacadeStub{
+			HandlePushEventsCalled: func(events data.ArgsSaveBlockData) error {
+				numPushEvents++
+				return nil
+			},
+		}
+
+		dp, err := preprocess.NewEventsPreProcessorV1(args)
+		require.Nil(t, err)
+
+		outportBlock := createDefaultOutportBlock()
+		outportBlock.BlockData.HeaderHash = []byte("hash1")
+		marshalledBlock, _ := json.Marshal(outportBlock)
+
+		err = dp.SaveBlock(context.Background(), marshalledBlock)
+		require.Nil(t, err)
+		err = dp.SaveBlock(context.Background(), marshalledBlock)
+		require.Nil(t, err)
+
+		require.Equal(t, 2, numPushEvents)
+	})
+}
+
+func TestPreProcessorV1_SaveBlockProcessedBlocks(t *testing.T) {
+	t.Parallel()
+
+	createMarshalledBlock := func(hash string, shardID uint32, nonce uint64) []byte {
+		outportBlock := createDefaultOutportBlock()
+		outportBlock.BlockData.HeaderHash = []byte(hash)
+		outportBlock.BlockData.HeaderBytes, _ = json.Marshal(&block.Header{
+			ShardID:   shardID,
+			Nonce:     nonce,
+			TimeStamp: nonce * 6,
+		})
+		marshalledBlock, _ := json.Marshal(outportBlock)
+
+		return marshalledBlock
+	}
+
+	t.Run("should record the last processed block of each shard", func(t *testing.T) {
+		t.Parallel()
+
+		tracker := metrics.NewProcessedBlocksTracker()
+		args := createMockEventsDataPreProcessorArgs()
+		args.ProcessedBlocksTracker = tracker
+
+		dp, err := preprocess.NewEventsPreProcessorV1(args)
+		require.Nil(t, err)
+
+		err = dp.SaveBlock(context.Background(), createMarshalledBlock("hash1", 1, 10))
+		require.Nil(t, err)
+		err = dp.SaveBlock(context.Background(), createMarshalledBlock("hash2", 0, 20))
+		require.Nil(t, err)
+
+		processedBlocks := tracker.GetProcessedBlocks()
+		require.Equal(t, 2, len(processedBlocks))
+		require.Equal(t, uint32(0), processedBlocks[0].ShardID)
+		require.Equal(t, uint64(20), processedBlocks[0].Nonce)
+		require.Equal(t, hex.EncodeToString([]byte("hash2")), processedBlocks[0].Hash)
+		require.Equal(t, uint64(120), processedBlocks[0].TimeStamp)
+		require.NotZero(t, processedBlocks[0].ProcessedAt)
+		require.Equal(t, uint32(1), processedBlocks[1].ShardID)
+		require.Equal(t, uint64(10), processedBlocks[1].Nonce)
+	})
+
+	t.Run("block delivered out of order should not replace the last processed one", func(t *testing.T) {
+		t.Parallel()
+
+		numPushEvents := 0
+		tracker := metrics.NewProcessedBlocksTracker()
+		args := createMockEventsDataPreProcessorArgs()
+		args.ProcessedBlocksTracker = tracker
+		args.Facade = &mocks.FacadeStub{
+			HandlePushEventsCalled: func(events data.ArgsSaveBlockData) error {
+				numPushEvents++
+				return nil
+			},
+		}
+
+		dp, err := preprocess.NewEventsPreProcessorV1(args)
+		require.Nil(t, err)
+
+		err = dp.SaveBlock(context.Background(), createMarshalledBlock("hash2", 0, 2))
+		require.Nil(t, err)
+		err = dp.SaveBlock(context.Background(), createMarshalledBlock("hash1", 0, 1))
+		require.Nil(t, err)
+
+		require.Equal(t, 2, numPushEvents)
+		processedBlocks := tracker.GetProcessedBlocks()
+		require.Equal(t, 1, len(processedBlocks))
+		require.Equal(t, uint64(2), processedBlocks[0].Nonce)
+		require.Equal(t, hex.EncodeToString([]byte("hash2")), processedBlocks[0].Hash)
+	})
+
+	t.Run("duplicated block should be acknowledged without being published again", func(t *testing.T) {
+		t.Parallel()
+
+		numPushEvents := 0
+		numSetProcessedBlock := 0
+		args := createMockEventsDataPreProcessorArgs()
+		args.ProcessedBlocksCacheSize = 10
+		args.ProcessedBlocksTracker = &mocks.ProcessedBlocksTrackerStub{
+			SetProcessedBlockCalled: func(block data.ProcessedBlock) {
+				numSetProcessedBlock++
+			},
+		}
+		args.Facade = &mocks.FacadeStub{
+			HandlePushEventsCalled: func(events data.ArgsSaveBlockData) error {
+				numPushEvents++
+				return nil
+			},
+		}
+
+		dp, err := preprocess.NewEventsPreProcessorV1(args)
+		require.Nil(t, err)
+
+		marshalledBlock := createMarshalledBlock("hash1", 0, 1)
+		err = dp.SaveBlock(context.Background(), marshalledBlock)
+		require.Nil(t, err)
+		err = dp.SaveBlock(context.Background(), marshalledBlock)
+		require.Nil(t, err)
+
+		require.Equal(t, 1, numPushEvents)
+		require.Equal(t, 1, numSetProcessedBlock)
+	})
+
+	t.Run("should update the processing metrics", func(t *testing.T) {
+		t.Parallel()
+
+		numEventsPerShard := make(map[uint32]uint64)
+		lagPerShard := make(map[uint32]time.Duration)
+		args := createMockEventsDataPreProcessorArgs()
+		args.MetricsCollector = &mocks.MetricsCollectorStub{
+			AddProcessedBlockCalled: func(shardID uint32, numEvents uint64) {
+				numEventsPerShard[shardID] += numEvents
+			},
+			SetBlockProcessingLagCalled: func(shardID uint32, lag time.Duration) {
+				lagPerShard[shardID] = lag
+			},
+		}
+
+		dp, err := preprocess.NewEventsPreProcessorV1(args)
+		require.Nil(t, err)
+
+		outportBlock := createDefaultOutportBlock()
+		outportBlock.BlockData.HeaderBytes, _ = json.Marshal(&block.Header{
+			ShardID:   1,
+			TimeStamp: uint64(time.Now().Add(-time.Minute).Unix()),
+		})
+		outportBlock.TransactionPool.Logs = []*outport.LogData{
+			{
+				TxHash: "txHash1",
+				Log: &transaction.Log{
+					Events: []*transaction.Event{{Identifier: []byte("transfer")}, nil, {Identifier: []byte("swap")}},
+				},
+			},
+			{TxHash: "txHash2"},
+		}
+		marshalledBlock, _ := json.Marshal(outportBlock)
+
+		err = dp.SaveBlock(context.Background(), marshalledBlock)
+		require.Nil(t, err)
+		err = dp.SaveBlock(context.Background(), createMarshalledBlock("hash2", 0, 0))
+		require.Nil(t, err)
+
+		require.Equal(t, map[uint32]uint64{0: 0, 1: 2}, numEventsPerShard)
+		require.Equal(t, 1, len(lagPerShard))
+		require.True(t, lagPerShard[1] >= time.Minute)
+	})
+
+	t.Run("block failed to be handled should not be recorded", func(t *testing.T) {
+		t.Parallel()
+
+		tracker := metrics.NewProcessedBlocksTracker()
+		args := createMockEventsDataPreProcessorArgs()
+		args.ProcessedBlocksTracker = tracker
+		args.Facade = &mocks.FacadeStub{
+			HandlePushEventsCalled: func(events data.ArgsSaveBlockData) error {
+				return errors.New("expected error")
+			},
+		}
+
+		dp, err := preprocess.NewEventsPreProcessorV1(args)
+		require.Nil(t, err)
+
+		err = dp.SaveBlock(context.Background(), createMarshalledBlock("hash1", 0, 1))
+		require.NotNil(t, err)
+		require.Equal(t, 0, len(tracker.GetProcessedBlocks()))
+	})
+}
+
+func TestPreProcessorV1_SaveBlockEventsBlacklist(t *testing.T) {
+	t.Parallel()
+
+	createOutportBlockWithEvents := func(identifiers ...string) *outport.OutportBlock {
+		outportBlock := createDefaultOutportBlock()
+		events := make([]*transaction.Event, 0, len(identifiers))
+		for _, identifier := range identifiers {
+			events = append(events, &transaction.Event{Identifier: []byte(identifier)})
+		}
+		outportBlock.TransactionPool.Logs = []*outport.LogData{
+			{
+				TxHash: "txHash1",
+				Log:    &transaction.Log{Events: events},
+			},
+		}
+
+		return outportBlock
+	}
+
+	getIdentifiers := func(pushedBlock data.ArgsSaveBlockData) []string {
+		identifiers := make([]string, 0)
+		for _, event := range pushedBlock.TransactionsPool.Logs[0].Log.Events {
+			if event == nil {
+				identifiers = append(identifiers, "")
+				continue
+			}
+			identifiers = append(identifiers, string(event.Identifier))
+		}
+
+		return identifiers
+	}
+
+	t.Run("empty blacklist should keep all the events", func(t *testing.T) {
+		t.Parallel()
+
+		var pushedBlock data.ArgsSaveBlockData
+		args := createMockEventsDataPreProcessorArgs()
+		args.Facade = &mocks.FacadeStub{
+			HandlePushEventsCalled: func(events data.ArgsSaveBlockData) error {
+				pushedBlock = events
+				return nil
+			},
+		}
+		args.MetricsCollector = &mocks.MetricsCollectorStub{
+			AddFilteredEventsCalled: func(identifier string, numEvents uint64) {
+				require.Fail(t, "should have not been called")
+			},
+		}
+		dp, _ := preprocess.NewEventsPreProcessorV1(args)
+
+		marshalledBlock, _ := json.Marshal(createOutportBlockWithEvents("writeLog", "transfer"))
+		err := dp.SaveBlock(context.Background(), marshalledBlock)
+		require.Nil(t, err)
+
+		require.Equal(t, []string{"writeLog", "transfer"}, getIdentifiers(pushedBlock))
+	})
+
+	t.Run("should drop the blacklisted events and keep the index of the others", func(t *testing.T) {
+		t.Parallel()
+
+		var pushedBlock data.ArgsSaveBlockData
+		args := createMockEventsDataPreProcessorArgs()
+		args.EventsBlacklist = []string{"writeLog", "completedTxEvent"}
+		args.Facade = &mocks.FacadeStub{
+			HandlePushEventsCalled: func(events data.ArgsSaveBlockData) error {
+				pushedBlock = events
+				return nil
+			},
+		}
+		numFilteredEvents := make(map[string]uint64)
+		args.MetricsCollector = &mocks.MetricsCollectorStub{
+			AddFilteredEventsCalled: func(identifier string, numEvents uint64) {
+				numFilteredEvents[identifier] += numEvents
+			},
+		}
+		dp, _ := preprocess.NewEventsPreProcessorV1(args)
+
+		marshalledBlock, _ := json.Marshal(createOutportBlockWithEvents("writeLog", "transfer", "writeLog", "completedTxEvent"))
+		err := dp.SaveBlock(context.Background(), marshalledBlock)
+		require.Nil(t, err)
+
+		require.Equal(t, []string{"", "transfer", "", ""}, getIdentifiers(pushedBlock))
+		require.Equal(t, map[string]uint64{"writeLog": 2, "completedTxEvent": 1}, numFilteredEvents)
+	})
+
+	t.Run("block with only blacklisted events should still be handled", func(t *testing.T) {
+		t.Parallel()
+
+		wasCalled := false
+		var pushedBlock data.ArgsSaveBlockData
+		args := createMockEventsDataPreProcessorArgs()
+		args.EventsBlacklist = []string{"writeLog"}
+		args.Facade = &mocks.FacadeStub{
+			HandlePushEventsCalled: func(events data.ArgsSaveBlockData) error {
+				wasCalled = true
+				pushedBlock = events
+				return nil
+			},
+		}
+		dp, _ := preprocess.NewEventsPreProcessorV1(args)
+
+		marshalledBlock, _ := json.Marshal(createOutportBlockWithEvents("writeLog", "writeLog"))
+		err := dp.SaveBlock(context.Background(), marshalledBlock)
+		require.Nil(t, err)
+
+		require.True(t, wasCalled)
+		require.Equal(t, []string{"", ""}, getIdentifiers(pushedBlock))
+		require.Equal(t, 1, len(pushedBlock.TransactionsPool.Transactions))
+	})
 }
 
 func TestPreProcessorV1_RevertIndexerBlock(t *testing.T) {
@@ -131,7 +622,7 @@ func TestPreProcessorV1_RevertIndexerBlock(t *testing.T) {
 		require.Nil(t, err)
 
 		marshalledBlock, _ := json.Marshal(blockData)
-		err = dp.RevertIndexedBlock(marshalledBlock)
+		err = dp.RevertIndexedBlock(context.Background(), marshalledBlock)
 		require.Equal(t, coreData.ErrInvalidHeaderType, err)
 	})
 
@@ -152,24 +643,470 @@ func TestPreProcessorV1_RevertIndexerBlock(t *testing.T) {
 		require.Nil(t, err)
 
 		marshalledBlock, _ := json.Marshal(blockData)
-		err = dp.RevertIndexedBlock(marshalledBlock)
+		err = dp.RevertIndexedBlock(context.Background(), marshalledBlock)
 		require.Nil(t, err)
 	})
 }
 
-func TestPreProcessorV1_FinalizedBlock(t *testing.T) {
+func TestPreProcessorV1_RevertIndexedBlockDetails(t *testing.T) {
+	t.Parallel()
+
+	createPreProcessor := func(revertedBlocks *[]data.RevertBlock) process.DataProcessor {
+		args := createMockEventsDataPreProcessorArgs()
+		args.RevertDetailsCacheDepth = 2
+		args.PubKeyConverter = mocks.NewPubkeyConverterMock(32)
+		args.Facade = &mocks.FacadeStub{
+			HandleRevertEventsCalled: func(events data.RevertBlock) error {
+				*revertedBlocks = append(*revertedBlocks, events)
+				return nil
+			},
+		}
+
+		dp, err := preprocess.NewEventsPreProcessorV1(args)
+		require.Nil(t, err)
+
+		return dp
+	}
+
+	saveBlock := func(dp process.DataProcessor, hash string) *outport.OutportBlock {
+		outportBlock := createDefaultOutportBlock()
+		outportBlock.BlockData.HeaderHash = []byte(hash)
+		outportBlock.TransactionPool.Logs = []*outport.LogData{
+			{
+				TxHash: "txHash1",
+				Log: &transaction.Log{Events: []*transaction.Event{
+					{Address: []byte("addr1"), Identifier: []byte("transfer")},
+					nil,
+				}},
+			},
+		}
+		marshalledBlock, _ := json.Marshal(outportBlock)
+
+		err := dp.SaveBlock(context.Background(), marshalledBlock)
+		require.Nil(t, err)
+
+		return outportBlock
+	}
+
+	revertBlock := func(dp process.DataProcessor, outportBlock *outport.OutportBlock) {
+		marshalledRevert, _ := json.Marshal(outportBlock.BlockData)
+		err := dp.RevertIndexedBlock(context.Background(), marshalledRevert)
+		require.Nil(t, err)
+	}
+
+	t.Run("cached block should have the details", func(t *testing.T) {
+		t.Parallel()
+
+		revertedBlocks := make([]data.RevertBlock, 0)
+		dp := createPreProcessor(&revertedBlocks)
+
+		outportBlock := saveBlock(dp, "hash1")
+		revertBlock(dp, outportBlock)
+
+		require.Equal(t, 1, len(revertedBlocks))
+		require.Equal(t, []string{"scrHash1", "txHash1"}, revertedBlocks[0].TxHashes)
+		require.Equal(t, []data.RevertedEvent{
+			{Address: hex.EncodeToString([]byte("addr1")), Identifier: "transfer", TxHash: "txHash1"},
+		}, revertedBlocks[0].Events)
+		require.False(t, revertedBlocks[0].DetailsUnavailable)
+	})
+
+	t.Run("uncached block should be marked with details unavailable", func(t *testing.T) {
+		t.Parallel()
+
+		revertedBlocks := make([]data.RevertBlock, 0)
+		dp := createPreProcessor(&revertedBlocks)
+
+		outportBlock := createDefaultOutportBlock()
+		outportBlock.BlockData.HeaderHash = []byte("hash1")
+		revertBlock(dp, outportBlock)
+
+		require.Equal(t, 1, len(revertedBlocks))
+		require.Nil(t, revertedBlocks[0].TxHashes)
+		require.Nil(t, revertedBlocks[0].Events)
+		require.True(t, revertedBlocks[0].DetailsUnavailable)
+	})
+
+	t.Run("evicted block should be marked with details unavailable", func(t *testing.T) {
+		t.Parallel()
+
+		revertedBlocks := make([]data.RevertBlock, 0)
+		dp := createPreProcessor(&revertedBlocks)
+
+		outportBlock1 := saveBlock(dp, "hash1")
+		outportBlock2 := saveBlock(dp, "hash2")
+		_ = saveBlock(dp, "hash3")
+		revertBlock(dp, outportBlock1)
+		revertBlock(dp, outportBlock2)
+
+		require.Equal(t, 2, len(revertedBlocks))
+		require.True(t, revertedBlocks[0].DetailsUnavailable)
+		require.False(t, revertedBlocks[1].DetailsUnavailable)
+		require.Equal(t, []string{"scrHash1", "txHash1"}, revertedBlocks[1].TxHashes)
+	})
+
+	t.Run("disabled cache should not add the details", func(t *testing.T) {
+		t.Parallel()
+
+		revertedBlocks := make([]data.RevertBlock, 0)
+		args := createMockEventsDataPreProcessorArgs()
+		args.Facade = &mocks.FacadeStub{
+			HandleRevertEventsCalled: func(events data.RevertBlock) error {
+				revertedBlocks = append(revertedBlocks, events)
+				return nil
+			},
+		}
+		dp, _ := preprocess.NewEventsPreProcessorV1(args)
+
+		outportBlock := saveBlock(dp, "hash1")
+		revertBlock(dp, outportBlock)
+
+		require.Equal(t, 1, len(revertedBlocks))
+		require.Nil(t, revertedBlocks[0].TxHashes)
+		require.False(t, revertedBlocks[0].DetailsUnavailable)
+	})
+}
+
+func TestPreProcessorV1_FinalizedBlockDetails(t *testing.T) {
 	t.Parallel()
 
-	finalizedBlock := &outport.FinalizedBlock{
-		HeaderHash: []byte("headerHash1"),
+	createPreProcessor := func(finalizedBlocks *[]data.FinalizedBlock, withTxHashes bool) process.DataProcessor {
+		args := createMockEventsDataPreProcessorArgs()
+		args.FinalizedDetailsCacheDepth = 2
+		args.FinalizedDetailsWithTxHashes = withTxHashes
+		args.Facade = &mocks.FacadeStub{
+			HandleFinalizedEventsCalled: func(events data.FinalizedBlock) error {
+				*finalizedBlocks = append(*finalizedBlocks, events)
+				return nil
+			},
+		}
+
+		dp, err := preprocess.NewEventsPreProcessorV1(args)
+		require.Nil(t, err)
+
+		return dp
+	}
+
+	saveBlock := func(dp process.DataProcessor, hash string) {
+		outportBlock := createDefaultOutportBlock()
+		outportBlock.BlockData.HeaderHash = []byte(hash)
+		outportBlock.BlockData.HeaderBytes, _ = json.Marshal(&block.Header{
+			ShardID:   1,
+			Nonce:     10,
+			Round:     11,
+			TimeStamp: 12,
+		})
+		marshalledBlock, _ := json.Marshal(outportBlock)
+
+		err := dp.SaveBlock(context.Background(), marshalledBlock)
+		require.Nil(t, err)
 	}
 
-	dp, err := preprocess.NewEventsPreProcessorV1(createMockEventsDataPreProcessorArgs())
-	require.Nil(t, err)
+	finalizeBlock := func(dp process.DataProcessor, hash string) {
+		marshalledFinalized, _ := json.Marshal(&outport.FinalizedBlock{HeaderHash: []byte(hash)})
+		err := dp.FinalizedBlock(context.Background(), marshalledFinalized)
+		require.Nil(t, err)
+	}
+
+	t.Run("cached block should be enriched", func(t *testing.T) {
+		t.Parallel()
+
+		finalizedBlocks := make([]data.FinalizedBlock, 0)
+		dp := createPreProcessor(&finalizedBlocks, false)
+
+		saveBlock(dp, "hash1")
+		finalizeBlock(dp, "hash1")
+
+		require.Equal(t, 1, len(finalizedBlocks))
+		require.True(t, *finalizedBlocks[0].Enriched)
+		require.Equal(t, uint32(1), *finalizedBlocks[0].ShardID)
+		require.Equal(t, uint64(10), finalizedBlocks[0].Nonce)
+		require.Equal(t, uint64(11), finalizedBlocks[0].Round)
+		require.Equal(t, uint64(12), finalizedBlocks[0].BlockTimeStamp)
+		require.Nil(t, finalizedBlocks[0].TxHashes)
+	})
+
+	t.Run("cached block should have the tx hashes, if enabled", func(t *testing.T) {
+		t.Parallel()
+
+		finalizedBlocks := make([]data.FinalizedBlock, 0)
+		dp := createPreProcessor(&finalizedBlocks, true)
+
+		saveBlock(dp, "hash1")
+		finalizeBlock(dp, "hash1")
+
+		require.Equal(t, 1, len(finalizedBlocks))
+		require.True(t, *finalizedBlocks[0].Enriched)
+		require.Equal(t, []string{"scrHash1", "txHash1"}, finalizedBlocks[0].TxHashes)
+	})
+
+	t.Run("uncached or reverted block should not be enriched", func(t *testing.T) {
+		t.Parallel()
+
+		finalizedBlocks := make([]data.FinalizedBlock, 0)
+		dp := createPreProcessor(&finalizedBlocks, false)
+
+		finalizeBlock(dp, "hash1")
+
+		saveBlock(dp, "hash2")
+		marshalledRevert, _ := json.Marshal(&outport.BlockData{
+			HeaderHash:  []byte("hash2"),
+			HeaderBytes: createDefaultOutportBlock().BlockData.HeaderBytes,
+			HeaderType:  "Header",
+		})
+		err := dp.RevertIndexedBlock(context.Background(), marshalledRevert)
+		require.Nil(t, err)
+		finalizeBlock(dp, "hash2")
+
+		require.Equal(t, 2, len(finalizedBlocks))
+		for _, finalizedBlock := range finalizedBlocks {
+			require.False(t, *finalizedBlock.Enriched)
+			require.Nil(t, finalizedBlock.ShardID)
+			require.Zero(t, finalizedBlock.Nonce)
+		}
+	})
 
-	marshalledBlock, _ := json.Marshal(finalizedBlock)
-	err = dp.FinalizedBlock(marshalledBlock)
-	require.Nil(t, err)
+	t.Run("disabled cache should not mark the events", func(t *testing.T) {
+		t.Parallel()
+
+		finalizedBlocks := make([]data.FinalizedBlock, 0)
+		args := createMockEventsDataPreProcessorArgs()
+		args.Facade = &mocks.FacadeStub{
+			HandleFinalizedEventsCalled: func(events data.FinalizedBlock) error {
+				finalizedBlocks = append(finalizedBlocks, events)
+				return nil
+			},
+		}
+		dp, _ := preprocess.NewEventsPreProcessorV1(args)
+
+		saveBlock(dp, "hash1")
+		finalizeBlock(dp, "hash1")
+
+		require.Equal(t, 1, len(finalizedBlocks))
+		require.Nil(t, finalizedBlocks[0].Enriched)
+		require.Nil(t, finalizedBlocks[0].ShardID)
+	})
+}
+
+func TestPreProcessorV1_FinalizedBlock(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		finalizedBlock := &outport.FinalizedBlock{
+			HeaderHash: []byte("headerHash1"),
+		}
+
+		dp, err := preprocess.NewEventsPreProcessorV1(createMockEventsDataPreProcessorArgs())
+		require.Nil(t, err)
+
+		marshalledBlock, _ := json.Marshal(finalizedBlock)
+		err = dp.FinalizedBlock(context.Background(), marshalledBlock)
+		require.Nil(t, err)
+	})
+
+	t.Run("should set the correlation id from context", func(t *testing.T) {
+		t.Parallel()
+
+		correlationIDs := make([]string, 0)
+		args := createMockEventsDataPreProcessorArgs()
+		args.Facade = &mocks.FacadeStub{
+			HandleFinalizedEventsCalled: func(event data.FinalizedBlock) error {
+				correlationIDs = append(correlationIDs, event.CorrelationID)
+				return nil
+			},
+		}
+
+		dp, err := preprocess.NewEventsPreProcessorV1(args)
+		require.Nil(t, err)
+
+		marshalledBlock, _ := json.Marshal(&outport.FinalizedBlock{HeaderHash: []byte("headerHash1")})
+		ctx := common.ContextWithCorrelationID(context.Background(), "correlation-id-1")
+		err = dp.FinalizedBlock(ctx, marshalledBlock)
+		require.Nil(t, err)
+
+		// a new correlation id is generated if the context does not hold one
+		err = dp.FinalizedBlock(context.Background(), marshalledBlock)
+		require.Nil(t, err)
+
+		require.Len(t, correlationIDs, 2)
+		require.Equal(t, "correlation-id-1", correlationIDs[0])
+		require.NotEmpty(t, correlationIDs[1])
+		require.NotEqual(t, correlationIDs[0], correlationIDs[1])
+	})
+
+	t.Run("should set the ingestion timestamp", func(t *testing.T) {
+		t.Parallel()
+
+		var timestamp int64
+		args := createMockEventsDataPreProcessorArgs()
+		args.Facade = &mocks.FacadeStub{
+			HandleFinalizedEventsCalled: func(event data.FinalizedBlock) error {
+				timestamp = event.Timestamp
+				return nil
+			},
+		}
+
+		dp, err := preprocess.NewEventsPreProcessorV1(args)
+		require.Nil(t, err)
+
+		startTime := time.Now().UnixNano()
+		marshalledBlock, _ := json.Marshal(&outport.FinalizedBlock{HeaderHash: []byte("headerHash1")})
+		err = dp.FinalizedBlock(context.Background(), marshalledBlock)
+		require.Nil(t, err)
+
+		require.GreaterOrEqual(t, timestamp, startTime)
+		require.LessOrEqual(t, timestamp, time.Now().UnixNano())
+	})
+}
+
+func TestPreProcessorV1_SaveValidatorsRating(t *testing.T) {
+	t.Parallel()
+
+	marshaller := &marshal.GogoProtoMarshalizer{}
+
+	t.Run("invalid payload should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockEventsDataPreProcessorArgs()
+		args.Marshaller = marshaller
+		args.Facade = &mocks.FacadeStub{
+			HandleValidatorsRatingEventCalled: func(event data.ValidatorsRatingEvent) error {
+				require.Fail(t, "should not have been called")
+				return nil
+			},
+		}
+
+		dp, err := preprocess.NewEventsPreProcessorV1(args)
+		require.Nil(t, err)
+
+		err = dp.SaveValidatorsRating(context.Background(), []byte("invalid"))
+		require.NotNil(t, err)
+	})
+
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		marshalledRating, err := marshaller.Marshal(&outport.ValidatorsRating{
+			ShardID: 1,
+			Epoch:   5,
+			ValidatorsRatingInfo: []*outport.ValidatorRatingInfo{
+				{PublicKey: "pubKey1", Rating: 50.5},
+				{PublicKey: "pubKey2", Rating: 100},
+			},
+		})
+		require.Nil(t, err)
+
+		var validatorsRating data.ValidatorsRatingEvent
+		args := createMockEventsDataPreProcessorArgs()
+		args.Marshaller = marshaller
+		args.Facade = &mocks.FacadeStub{
+			HandleValidatorsRatingEventCalled: func(event data.ValidatorsRatingEvent) error {
+				validatorsRating = event
+				return nil
+			},
+		}
+
+		dp, err := preprocess.NewEventsPreProcessorV1(args)
+		require.Nil(t, err)
+
+		ctx := common.ContextWithCorrelationID(context.Background(), "correlation-id-1")
+		err = dp.SaveValidatorsRating(ctx, marshalledRating)
+		require.Nil(t, err)
+
+		require.Equal(t, data.ValidatorsRatingEvent{
+			ShardID: 1,
+			Epoch:   5,
+			ValidatorsRating: []data.ValidatorRating{
+				{PublicKey: "pubKey1", Rating: 50.5},
+				{PublicKey: "pubKey2", Rating: 100},
+			},
+			CorrelationID: "correlation-id-1",
+		}, validatorsRating)
+	})
+}
+
+func TestPreProcessorV1_SaveAccounts(t *testing.T) {
+	t.Parallel()
+
+	marshaller := &marshal.GogoProtoMarshalizer{}
+
+	t.Run("invalid payload should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockEventsDataPreProcessorArgs()
+		args.Marshaller = marshaller
+		args.Facade = &mocks.FacadeStub{
+			HandleAccountsEventsCalled: func(event data.AccountsEvents) error {
+				require.Fail(t, "should not have been called")
+				return nil
+			},
+		}
+
+		dp, err := preprocess.NewEventsPreProcessorV1(args)
+		require.Nil(t, err)
+
+		err = dp.SaveAccounts(context.Background(), []byte("invalid"))
+		require.NotNil(t, err)
+	})
+
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		marshalledAccounts, err := marshaller.Marshal(&outport.Accounts{
+			ShardID:        1,
+			BlockTimestamp: 1234,
+			AlteredAccounts: map[string]*alteredAccount.AlteredAccount{
+				"erd1bob": {Address: "erd1bob", Nonce: 7, Balance: "2000"},
+				"erd1alice": {
+					Address: "erd1alice",
+					Nonce:   3,
+					Balance: "1000",
+					Tokens: []*alteredAccount.AccountTokenData{
+						{Identifier: "NFT-abcdef", Nonce: 2, Balance: "1"},
+						{Identifier: "TKN-abcdef", Balance: "50"},
+					},
+				},
+			},
+		})
+		require.Nil(t, err)
+
+		var accountsEvents data.AccountsEvents
+		args := createMockEventsDataPreProcessorArgs()
+		args.Marshaller = marshaller
+		args.Facade = &mocks.FacadeStub{
+			HandleAccountsEventsCalled: func(event data.AccountsEvents) error {
+				accountsEvents = event
+				return nil
+			},
+		}
+
+		dp, err := preprocess.NewEventsPreProcessorV1(args)
+		require.Nil(t, err)
+
+		ctx := common.ContextWithCorrelationID(context.Background(), "correlation-id-1")
+		err = dp.SaveAccounts(ctx, marshalledAccounts)
+		require.Nil(t, err)
+
+		require.Equal(t, data.AccountsEvents{
+			ShardID:        1,
+			BlockTimestamp: 1234,
+			Accounts: []data.AccountEvent{
+				{
+					Address: "erd1alice",
+					Nonce:   3,
+					Balance: "1000",
+					Tokens: []data.AccountTokenBalance{
+						{Identifier: "NFT-abcdef", Nonce: 2, Balance: "1"},
+						{Identifier: "TKN-abcdef", Balance: "50"},
+					},
+				},
+				{Address: "erd1bob", Nonce: 7, Balance: "2000"},
+			},
+			CorrelationID: "correlation-id-1",
+		}, accountsEvents)
+	})
 }
 
 func createDefaultOutportBlock() *outport.OutportBlock {
@@ -218,3 +1155,42 @@ func createDefaultOutportBlock() *outport.OutportBlock {
 
 	return outportBlock
 }
+
+// BenchmarkPreProcessorV1_SaveBlock compares the round trip of a save block payload, marshalled
+// as the observer does and processed by the preprocessor, for the supported data marshallers
+func BenchmarkPreProcessorV1_SaveBlock(b *testing.B) {
+	marshallers := []struct {
+		name       string
+		marshaller marshal.Marshalizer
+	}{
+		{name: "json", marshaller: &marshal.JsonMarshalizer{}},
+		{name: "gogo protobuf", marshaller: &marshal.GogoProtoMarshalizer{}},
+	}
+
+	for _, m := range marshallers {
+		marshaller := m.marshaller
+		blockData, err := testdata.NewBlockData(marshaller)
+		require.Nil(b, err)
+		outportBlock := blockData.OutportBlockV1()
+
+		args := createMockEventsDataPreProcessorArgs()
+		args.Marshaller = marshaller
+		dp, err := preprocess.NewEventsPreProcessorV1(args)
+		require.Nil(b, err)
+
+		b.Run(m.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				marshalledBlock, errMarshal := marshaller.Marshal(outportBlock)
+				if errMarshal != nil {
+					b.Fatal(errMarshal)
+				}
+
+				errSave := dp.SaveBlock(context.Background(), marshalledBlock)
+				if errSave != nil {
+					b.Fatal(errSave)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package preprocess
+
+import (
+	"container/list"
+	"sync"
+)
+
+// processedBlocksCache keeps the hashes of the most recently processed blocks, so that
+// a block resent by the observer is not processed again. The least recently added hash
+// is evicted when the cache is full. A cache with zero capacity holds no hash
+type processedBlocksCache struct {
+	mut      sync.Mutex
+	capacity int
+	order    *list.List
+	hashes   map[string]*list.Element
+}
+
+func newProcessedBlocksCache(capacity uint32) *processedBlocksCache {
+	return &processedBlocksCache{
+		capacity: int(capacity),
+		order:    list.New(),
+		hashes:   make(map[string]*list.Element),
+	}
+}
+
+// has returns true if the block hash was already processed
+func (pbc *processedBlocksCache) has(hash string) bool {
+	pbc.mut.Lock()
+	defer pbc.mut.Unlock()
+
+	_, ok := pbc.hashes[hash]
+	return ok
+}
+
+// add marks the block hash as processed
+func (pbc *processedBlocksCache) add(hash string) {
+	if pbc.capacity == 0 {
+		return
+	}
+
+	pbc.mut.Lock()
+	defer pbc.mut.Unlock()
+
+	element, ok := pbc.hashes[hash]
+	if ok {
+		pbc.order.MoveToFront(element)
+		return
+	}
+
+	pbc.hashes[hash] = pbc.order.PushFront(hash)
+	if pbc.order.Len() <= pbc.capacity {
+		return
+	}
+
+	oldest := pbc.order.Back()
+	pbc.order.Remove(oldest)
+	delete(pbc.hashes, oldest.Value.(string))
+}
+
+// remove forgets the block hash, so that the block is processed again if resent
+func (pbc *processedBlocksCache) remove(hash string) {
+	pbc.mut.Lock()
+	defer pbc.mut.Unlock()
+
+	element, ok := pbc.hashes[hash]
+	if !ok {
+		return
+	}
+
+	pbc.order.Remove(element)
+	delete(pbc.hashes, hash)
+}
@@ -0,0 +1,46 @@
+package preprocess
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessedBlocksCache(t *testing.T) {
+	t.Parallel()
+
+	t.Run("zero capacity should hold no hash", func(t *testing.T) {
+		t.Parallel()
+
+		cache := newProcessedBlocksCache(0)
+		cache.add("hash1")
+
+		require.False(t, cache.has("hash1"))
+	})
+
+	t.Run("least recently added hash should be evicted", func(t *testing.T) {
+		t.Parallel()
+
+		cache := newProcessedBlocksCache(2)
+		cache.add("hash1")
+		cache.add("hash2")
+		cache.add("hash1")
+		cache.add("hash3")
+
+		require.True(t, cache.has("hash1"))
+		require.False(t, cache.has("hash2"))
+		require.True(t, cache.has("hash3"))
+	})
+
+	t.Run("removed hash should not be held", func(t *testing.T) {
+		t.Parallel()
+
+		cache := newProcessedBlocksCache(2)
+		cache.add("hash1")
+		cache.remove("hash1")
+		cache.remove("hash2")
+
+		require.False(t, cache.has("hash1"))
+		require.Equal(t, 0, cache.order.Len())
+	})
+}
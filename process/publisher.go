@@ -2,53 +2,119 @@ package process
 
 import (
 	"context"
+	"fmt"
+	"runtime"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/multiversx/mx-chain-core-go/core/check"
 	"github.com/multiversx/mx-chain-notifier-go/common"
 	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/metrics"
 )
 
+const (
+	broadcastQueueDepthMetric = "notifier_broadcast_queue_depth"
+
+	broadcastPending   = 0
+	broadcastStarted   = 1
+	broadcastCancelled = 2
+
+	publishErrorsBufferSize = 100
+
+	// closeGracePeriod is the time given to the publisher handler to close after the drain timeout
+	closeGracePeriod = time.Second
+)
+
+// broadcastFunc publishes one broadcast with the publisher handler and returns the publish
+// error, the context is done when the in-flight publish has to be aborted
+type broadcastFunc func(ctx context.Context, handler PublisherHandler) error
+
 type publisher struct {
 	handler PublisherHandler
 
-	broadcast                     chan data.BlockEvents
-	broadcastRevert               chan data.RevertBlock
-	broadcastFinalized            chan data.FinalizedBlock
-	broadcastTxs                  chan data.BlockTxs
-	broadcastBlockEventsWithOrder chan data.BlockEventsWithOrder
-	broadcastScrs                 chan data.BlockScrs
+	// numPendingBroadcasts holds the number of producers waiting for their events to be accepted
+	numPendingBroadcasts int64
+	maxPendingBroadcasts uint32
+	drainTimeout         time.Duration
+
+	// broadcasts holds all the event types in a single channel, so that the events are
+	// published in the order they were pushed by the producers
+	broadcasts chan broadcastFunc
+
+	// publishErrors holds the failures notified by the publisher handler, until they are read
+	publishErrors chan error
+
+	// publishCtx is passed to the publisher handler, it is cancelled when the drain timeout
+	// expires, so that the in-flight publish is aborted as well
+	publishCtx    context.Context
+	cancelPublish func()
 
 	cancelFunc func()
 	closeChan  chan struct{}
+	closeOnce  sync.Once
 	mutState   sync.RWMutex
+
+	// loopDone is closed when the processing loop has drained the pending events and closed the handler
+	loopDone   chan struct{}
+	errOnClose error
+}
+
+// ArgsPublisher defines the arguments needed for publisher creation
+type ArgsPublisher struct {
+	Handler PublisherHandler
+
+	// MaxPendingBroadcasts is the number of events waiting to be published above which
+	// the publisher is not ready; 0 means no limit
+	MaxPendingBroadcasts uint32
+
+	// BroadcastBufferSize is the number of events buffered before the producers are
+	// blocked; 0 means that each producer waits until the publish loop receives its events,
+	// which happens once the previous events are published, not until its own are published
+	BroadcastBufferSize uint32
+
+	// DrainTimeout limits the time spent on Close publishing the events which were
+	// already accepted; 0 means waiting until all of them are published
+	DrainTimeout time.Duration
 }
 
 // NewPublisher will create a new publisher component
-func NewPublisher(handler PublisherHandler) (*publisher, error) {
-	if check.IfNil(handler) {
+func NewPublisher(args ArgsPublisher) (*publisher, error) {
+	if check.IfNil(args.Handler) {
 		return nil, ErrNilPublisherHandler
 	}
 
 	p := &publisher{
-		handler:                       handler,
-		broadcast:                     make(chan data.BlockEvents),
-		broadcastRevert:               make(chan data.RevertBlock),
-		broadcastFinalized:            make(chan data.FinalizedBlock),
-		broadcastTxs:                  make(chan data.BlockTxs),
-		broadcastScrs:                 make(chan data.BlockScrs),
-		broadcastBlockEventsWithOrder: make(chan data.BlockEventsWithOrder),
-		closeChan:                     make(chan struct{}),
+		handler:              args.Handler,
+		maxPendingBroadcasts: args.MaxPendingBroadcasts,
+		drainTimeout:         args.DrainTimeout,
+		broadcasts:           make(chan broadcastFunc, args.BroadcastBufferSize),
+		publishErrors:        make(chan error, publishErrorsBufferSize),
+		closeChan:            make(chan struct{}),
+		loopDone:             make(chan struct{}),
+	}
+	p.publishCtx, p.cancelPublish = context.WithCancel(context.Background())
+
+	errorsNotifier, ok := args.Handler.(PublishErrorsNotifier)
+	if ok {
+		errorsNotifier.SetPublishErrorHandler(p.notifyPublishError)
 	}
 
 	return p, nil
 }
 
-// Run creates a goroutine and listens for events on the exposed channels
+// Run creates a goroutine and listens for events on the exposed channels. It errors if the
+// publisher is already closed, since the processing loop would never be cancelled
 func (p *publisher) Run() error {
 	p.mutState.Lock()
 	defer p.mutState.Unlock()
 
+	select {
+	case <-p.closeChan:
+		return ErrPublisherClosed
+	default:
+	}
 	if p.cancelFunc != nil {
 		return common.ErrLoopAlreadyStarted
 	}
@@ -65,84 +131,401 @@ func (p *publisher) run(ctx context.Context) {
 	for {
 		select {
 		case <-ctx.Done():
-			p.handler.Close()
+			p.drainAndCloseHandler()
 			return
-		case events := <-p.broadcast:
-			p.handler.Publish(events)
-		case revertBlock := <-p.broadcastRevert:
-			p.handler.PublishRevert(revertBlock)
-		case finalizedBlock := <-p.broadcastFinalized:
-			p.handler.PublishFinalized(finalizedBlock)
-		case blockTxs := <-p.broadcastTxs:
-			p.handler.PublishTxs(blockTxs)
-		case blockScrs := <-p.broadcastScrs:
-			p.handler.PublishScrs(blockScrs)
-		case blockEvents := <-p.broadcastBlockEventsWithOrder:
-			p.handler.PublishBlockEventsWithOrder(blockEvents)
+		case publish := <-p.broadcasts:
+			_ = publish(p.publishCtx, p.handler)
 		}
 	}
 }
 
-// Broadcast will handle the block events pushed by producers
-func (p *publisher) Broadcast(events data.BlockEvents) {
+func (p *publisher) drainAndCloseHandler() {
+	p.drain()
+	p.cancelPublish()
+	p.errOnClose = p.handler.Close()
+	close(p.loopDone)
+}
+
+// drain publishes the buffered events and the events of the producers which are still
+// waiting to be accepted when the publisher is closed. New broadcasts are no longer
+// accepted at this point, since the close channel is closed before the processing loop
+// is cancelled. The drain ends only after the producers which passed the close check have
+// returned, so that their events are not left in the buffer. If the drain timeout is set,
+// the events left after it expires are dropped
+func (p *publisher) drain() {
+	numDrained := 0
+	for {
+		select {
+		case <-p.publishCtx.Done():
+			log.Warn("publisher drain timeout, dropping the remaining events",
+				"num published", numDrained,
+				"num dropped", p.dropPendingBroadcasts(),
+				"timeout", p.drainTimeout,
+			)
+			return
+		default:
+		}
+
+		select {
+		case publish := <-p.broadcasts:
+			_ = publish(p.publishCtx, p.handler)
+			numDrained++
+		default:
+			if atomic.LoadInt64(&p.numPendingBroadcasts) > 0 || len(p.broadcasts) > 0 {
+				runtime.Gosched()
+				continue
+			}
+			if numDrained > 0 {
+				log.Debug("publisher drained the pending events", "num published", numDrained)
+			}
+			return
+		}
+	}
+}
+
+// dropPendingBroadcasts empties the broadcasts channel and returns the number of dropped events
+func (p *publisher) dropPendingBroadcasts() int {
+	numDropped := 0
+	for {
+		select {
+		case <-p.broadcasts:
+			numDropped++
+		default:
+			return numDropped
+		}
+	}
+}
+
+// enqueue waits until the broadcast is accepted by the processing loop or buffered
+func (p *publisher) enqueue(publish broadcastFunc) {
+	_ = p.enqueueWithContext(context.Background(), publish)
+}
+
+// enqueueWithContext waits until the broadcast is accepted by the processing loop or buffered.
+// It returns an error, without enqueueing the broadcast, if the publisher is closed or if the
+// context is done first, so that the producers are not blocked while the handler is hung
+func (p *publisher) enqueueWithContext(ctx context.Context, publish broadcastFunc) error {
+	atomic.AddInt64(&p.numPendingBroadcasts, 1)
+	defer atomic.AddInt64(&p.numPendingBroadcasts, -1)
+
 	select {
-	case p.broadcast <- events:
 	case <-p.closeChan:
+		return ErrPublisherClosed
+	default:
 	}
-}
 
-// BroadcastRevert will handle the revert event pushed by producers
-func (p *publisher) BroadcastRevert(events data.RevertBlock) {
 	select {
-	case p.broadcastRevert <- events:
+	case p.broadcasts <- publish:
+		return nil
 	case <-p.closeChan:
+		return ErrPublisherClosed
+	case <-ctx.Done():
+		return ctx.Err()
 	}
 }
 
-// BroadcastFinalized will handle the finalized event pushed by producers
-func (p *publisher) BroadcastFinalized(events data.FinalizedBlock) {
+// enqueueAndWait enqueues the broadcast and waits until it is published, returning the publish
+// error. If the context is done before the publish started, the broadcast is skipped and the
+// context error is returned. Once started, the publish result is waited, so that events which
+// are published are not reported as failed and are not sent again by the producer
+func (p *publisher) enqueueAndWait(ctx context.Context, publish broadcastFunc) error {
+	state := int32(broadcastPending)
+	publishResult := make(chan error, 1)
+	err := p.enqueueWithContext(ctx, func(publishCtx context.Context, handler PublisherHandler) error {
+		if !atomic.CompareAndSwapInt32(&state, broadcastPending, broadcastStarted) {
+			return nil
+		}
+
+		err := publish(publishCtx, handler)
+		publishResult <- err
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
 	select {
-	case p.broadcastFinalized <- events:
-	case <-p.closeChan:
+	case err = <-publishResult:
+		return err
+	case <-p.loopDone:
+		return p.getResultAfterLoopDone(publishResult)
+	case <-ctx.Done():
+		if atomic.CompareAndSwapInt32(&state, broadcastPending, broadcastCancelled) {
+			return ctx.Err()
+		}
+	}
+
+	select {
+	case err = <-publishResult:
+		return err
+	case <-p.loopDone:
+		return p.getResultAfterLoopDone(publishResult)
 	}
 }
 
-// BroadcastTxs will handle the txs event pushed by producers
-func (p *publisher) BroadcastTxs(events data.BlockTxs) {
+// getResultAfterLoopDone returns the publish result of a broadcast which may have been dropped
+// on the drain timeout
+func (p *publisher) getResultAfterLoopDone(publishResult chan error) error {
 	select {
-	case p.broadcastTxs <- events:
-	case <-p.closeChan:
+	case err := <-publishResult:
+		return err
+	default:
+		return ErrPublisherClosed
 	}
 }
 
+// Broadcast will handle the block events pushed by producers
+func (p *publisher) Broadcast(events data.BlockEvents) {
+	p.enqueue(func(ctx context.Context, handler PublisherHandler) error {
+		return handler.Publish(ctx, events)
+	})
+}
+
+// BroadcastWithContext will handle the block events pushed by producers and waits until they
+// are published. It returns the publish error, the context error if the context was done
+// before the publish started, or an error if the publisher was closed
+func (p *publisher) BroadcastWithContext(ctx context.Context, events data.BlockEvents) error {
+	return p.enqueueAndWait(ctx, func(publishCtx context.Context, handler PublisherHandler) error {
+		return handler.Publish(publishCtx, events)
+	})
+}
+
+// BroadcastRevert will handle the revert event pushed by producers
+func (p *publisher) BroadcastRevert(event data.RevertBlock) {
+	p.enqueue(func(ctx context.Context, handler PublisherHandler) error {
+		return handler.PublishRevert(ctx, event)
+	})
+}
+
+// BroadcastRevertWithContext will handle the revert event pushed by producers and waits until
+// it is published, same as BroadcastWithContext
+func (p *publisher) BroadcastRevertWithContext(ctx context.Context, event data.RevertBlock) error {
+	return p.enqueueAndWait(ctx, func(publishCtx context.Context, handler PublisherHandler) error {
+		return handler.PublishRevert(publishCtx, event)
+	})
+}
+
+// BroadcastFinalized will handle the finalized event pushed by producers
+func (p *publisher) BroadcastFinalized(event data.FinalizedBlock) {
+	p.enqueue(func(ctx context.Context, handler PublisherHandler) error {
+		return handler.PublishFinalized(ctx, event)
+	})
+}
+
+// BroadcastFinalizedWithContext will handle the finalized event pushed by producers and waits
+// until it is published, same as BroadcastWithContext
+func (p *publisher) BroadcastFinalizedWithContext(ctx context.Context, event data.FinalizedBlock) error {
+	return p.enqueueAndWait(ctx, func(publishCtx context.Context, handler PublisherHandler) error {
+		return handler.PublishFinalized(publishCtx, event)
+	})
+}
+
+// BroadcastTxs will handle the txs event pushed by producers
+func (p *publisher) BroadcastTxs(event data.BlockTxs) {
+	p.enqueue(func(ctx context.Context, handler PublisherHandler) error {
+		return handler.PublishTxs(ctx, event)
+	})
+}
+
+// BroadcastTxsWithContext will handle the txs event pushed by producers and waits until
+// it is published, same as BroadcastWithContext
+func (p *publisher) BroadcastTxsWithContext(ctx context.Context, event data.BlockTxs) error {
+	return p.enqueueAndWait(ctx, func(publishCtx context.Context, handler PublisherHandler) error {
+		return handler.PublishTxs(publishCtx, event)
+	})
+}
+
 // BroadcastScrs will handle the scrs event pushed by producers
-func (p *publisher) BroadcastScrs(events data.BlockScrs) {
+func (p *publisher) BroadcastScrs(event data.BlockScrs) {
+	p.enqueue(func(ctx context.Context, handler PublisherHandler) error {
+		return handler.PublishScrs(ctx, event)
+	})
+}
+
+// BroadcastScrsWithContext will handle the scrs event pushed by producers and waits until
+// it is published, same as BroadcastWithContext
+func (p *publisher) BroadcastScrsWithContext(ctx context.Context, event data.BlockScrs) error {
+	return p.enqueueAndWait(ctx, func(publishCtx context.Context, handler PublisherHandler) error {
+		return handler.PublishScrs(publishCtx, event)
+	})
+}
+
+// BroadcastBlockEventsWithOrder will handle the full block events pushed by producers
+func (p *publisher) BroadcastBlockEventsWithOrder(event data.BlockEventsWithOrder) {
+	p.enqueue(func(ctx context.Context, handler PublisherHandler) error {
+		return handler.PublishBlockEventsWithOrder(ctx, event)
+	})
+}
+
+// BroadcastBlockEventsWithOrderWithContext will handle the full block events pushed by producers
+// and waits until they are published, same as BroadcastWithContext
+func (p *publisher) BroadcastBlockEventsWithOrderWithContext(ctx context.Context, event data.BlockEventsWithOrder) error {
+	return p.enqueueAndWait(ctx, func(publishCtx context.Context, handler PublisherHandler) error {
+		return handler.PublishBlockEventsWithOrder(publishCtx, event)
+	})
+}
+
+// BroadcastTxEvents will handle the transaction notifications pushed by producers
+func (p *publisher) BroadcastTxEvents(event data.BlockTxEvents) {
+	p.enqueue(func(ctx context.Context, handler PublisherHandler) error {
+		return handler.PublishTxEvents(ctx, event)
+	})
+}
+
+// BroadcastTxEventsWithContext will handle the transaction notifications pushed by producers
+// and waits until they are published, same as BroadcastWithContext
+func (p *publisher) BroadcastTxEventsWithContext(ctx context.Context, event data.BlockTxEvents) error {
+	return p.enqueueAndWait(ctx, func(publishCtx context.Context, handler PublisherHandler) error {
+		return handler.PublishTxEvents(publishCtx, event)
+	})
+}
+
+// BroadcastRounds will handle the rounds info pushed by producers
+func (p *publisher) BroadcastRounds(event data.RoundEvents) {
+	p.enqueue(func(ctx context.Context, handler PublisherHandler) error {
+		return handler.PublishRounds(ctx, event)
+	})
+}
+
+// BroadcastRoundsWithContext will handle the rounds info pushed by producers and waits until
+// it is published, same as BroadcastWithContext
+func (p *publisher) BroadcastRoundsWithContext(ctx context.Context, event data.RoundEvents) error {
+	return p.enqueueAndWait(ctx, func(publishCtx context.Context, handler PublisherHandler) error {
+		return handler.PublishRounds(publishCtx, event)
+	})
+}
+
+// BroadcastValidatorsRating will handle the validators rating pushed by producers
+func (p *publisher) BroadcastValidatorsRating(event data.ValidatorsRatingEvent) {
+	p.enqueue(func(ctx context.Context, handler PublisherHandler) error {
+		return handler.PublishValidatorsRating(ctx, event)
+	})
+}
+
+// BroadcastValidatorsRatingWithContext will handle the validators rating pushed by producers
+// and waits until it is published, same as BroadcastWithContext
+func (p *publisher) BroadcastValidatorsRatingWithContext(ctx context.Context, event data.ValidatorsRatingEvent) error {
+	return p.enqueueAndWait(ctx, func(publishCtx context.Context, handler PublisherHandler) error {
+		return handler.PublishValidatorsRating(publishCtx, event)
+	})
+}
+
+// BroadcastAccounts will handle the altered accounts pushed by producers
+func (p *publisher) BroadcastAccounts(event data.AccountsEvents) {
+	p.enqueue(func(ctx context.Context, handler PublisherHandler) error {
+		return handler.PublishAccounts(ctx, event)
+	})
+}
+
+// BroadcastAccountsWithContext will handle the altered accounts pushed by producers
+// and waits until they are published, same as BroadcastWithContext
+func (p *publisher) BroadcastAccountsWithContext(ctx context.Context, event data.AccountsEvents) error {
+	return p.enqueueAndWait(ctx, func(publishCtx context.Context, handler PublisherHandler) error {
+		return handler.PublishAccounts(publishCtx, event)
+	})
+}
+
+// notifyPublishError sends the error on the errors channel. If nobody reads the errors and
+// the channel is full, the error is dropped, so that the publishing is not blocked
+func (p *publisher) notifyPublishError(err error) {
 	select {
-	case p.broadcastScrs <- events:
-	case <-p.closeChan:
+	case p.publishErrors <- err:
+	default:
+		log.Debug("publish errors channel is full, dropped publish error", "err", err.Error())
 	}
 }
 
-// BroadcastBlockEventsWithOrder will handle the full block events pushed by producers
-func (p *publisher) BroadcastBlockEventsWithOrder(events data.BlockEventsWithOrder) {
+// Errors returns the channel of the publish failures notified by the publisher handler,
+// such as the marshal errors or the events which could not be delivered to the broker.
+// The channel is buffered and it is never closed
+func (p *publisher) Errors() <-chan error {
+	return p.publishErrors
+}
+
+// GetHealthState returns up if the publishing loop has been started and not closed
+func (p *publisher) GetHealthState() string {
+	p.mutState.RLock()
+	defer p.mutState.RUnlock()
+
+	if p.cancelFunc == nil {
+		return common.HealthStateDown
+	}
+
 	select {
-	case p.broadcastBlockEventsWithOrder <- events:
 	case <-p.closeChan:
+		return common.HealthStateDown
+	default:
+		return common.HealthStateUp
+	}
+}
+
+// Ping returns an error if the publishing loop is not running or if there are too
+// many events waiting to be published
+func (p *publisher) Ping(_ context.Context) error {
+	if p.GetHealthState() != common.HealthStateUp {
+		return ErrPublisherNotRunning
+	}
+
+	numPendingBroadcasts := atomic.LoadInt64(&p.numPendingBroadcasts) + int64(len(p.broadcasts))
+	if p.maxPendingBroadcasts > 0 && numPendingBroadcasts > int64(p.maxPendingBroadcasts) {
+		return fmt.Errorf("%w: %d pending, max %d", ErrTooManyPendingBroadcasts, numPendingBroadcasts, p.maxPendingBroadcasts)
 	}
+
+	return nil
 }
 
-// Close will close the channels
+// GetMetricsForPrometheus returns the number of buffered events which are waiting to be published
+func (p *publisher) GetMetricsForPrometheus() string {
+	return metrics.GaugeMetric(broadcastQueueDepthMetric, uint64(len(p.broadcasts)))
+}
+
+// Close stops accepting new broadcasts, waits for the accepted events to be published,
+// up to the drain timeout, and then closes the publisher handler. When the drain timeout
+// expires, the context of the in-flight publish is cancelled. Broadcast calls after Close return without
+// publishing the events. If the drain timeout is set and the handler is still busy shortly after it
+// expires, Close returns ErrPublisherCloseTimeout without waiting for the handler
 func (p *publisher) Close() error {
 	p.mutState.RLock()
 	defer p.mutState.RUnlock()
 
-	if p.cancelFunc != nil {
+	p.closeOnce.Do(func() {
+		close(p.closeChan)
+
+		if p.drainTimeout > 0 {
+			time.AfterFunc(p.drainTimeout, p.cancelPublish)
+		}
+
+		if p.cancelFunc == nil {
+			go p.drainAndCloseHandler()
+			return
+		}
+
 		p.cancelFunc()
+	})
+
+	return p.waitLoopDone()
+}
+
+// waitLoopDone waits for the processing loop to drain the events and close the handler. If the
+// drain timeout is set, it stops waiting shortly after it expires, so that a hung publish does
+// not block the shutdown
+func (p *publisher) waitLoopDone() error {
+	if p.drainTimeout == 0 {
+		<-p.loopDone
+		return p.errOnClose
 	}
 
-	close(p.closeChan)
+	timer := time.NewTimer(p.drainTimeout + closeGracePeriod)
+	defer timer.Stop()
 
-	return nil
+	select {
+	case <-p.loopDone:
+		return p.errOnClose
+	case <-timer.C:
+		log.Warn("publisher close timeout, the publisher handler is still busy", "drain timeout", p.drainTimeout)
+		return ErrPublisherCloseTimeout
+	}
 }
 
 // IsInterfaceNil returns true if there is no value under the interface
@@ -1,6 +1,9 @@
 package process_test
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -19,7 +22,7 @@ func TestNewPublisher(t *testing.T) {
 	t.Run("nil handler", func(t *testing.T) {
 		t.Parallel()
 
-		p, err := process.NewPublisher(nil)
+		p, err := process.NewPublisher(process.ArgsPublisher{})
 		require.Nil(t, p)
 		require.Equal(t, process.ErrNilPublisherHandler, err)
 	})
@@ -27,7 +30,7 @@ func TestNewPublisher(t *testing.T) {
 	t.Run("should work", func(t *testing.T) {
 		t.Parallel()
 
-		p, err := process.NewPublisher(&mocks.PublisherHandlerStub{})
+		p, err := process.NewPublisher(process.ArgsPublisher{Handler: &mocks.PublisherHandlerStub{}})
 		require.Nil(t, err)
 		require.False(t, p.IsInterfaceNil())
 	})
@@ -39,7 +42,7 @@ func TestRun(t *testing.T) {
 	t.Run("should fail if triggered multiple times", func(t *testing.T) {
 		t.Parallel()
 
-		p, err := process.NewPublisher(&mocks.PublisherHandlerStub{})
+		p, err := process.NewPublisher(process.ArgsPublisher{Handler: &mocks.PublisherHandlerStub{}})
 		require.Nil(t, err)
 
 		err = p.Run()
@@ -50,6 +53,19 @@ func TestRun(t *testing.T) {
 		err = p.Run()
 		require.Equal(t, common.ErrLoopAlreadyStarted, err)
 	})
+
+	t.Run("should fail after close", func(t *testing.T) {
+		t.Parallel()
+
+		p, err := process.NewPublisher(process.ArgsPublisher{Handler: &mocks.PublisherHandlerStub{}})
+		require.Nil(t, err)
+
+		err = p.Close()
+		require.Nil(t, err)
+
+		err = p.Run()
+		require.Equal(t, process.ErrPublisherClosed, err)
+	})
 }
 
 func TestBroadcast(t *testing.T) {
@@ -59,13 +75,14 @@ func TestBroadcast(t *testing.T) {
 	numCalls := uint32(0)
 
 	ph := &mocks.PublisherHandlerStub{
-		PublishCalled: func(events data.BlockEvents) {
+		PublishCalled: func(_ context.Context, events data.BlockEvents) error {
 			atomic.AddUint32(&numCalls, 1)
 			wg.Done()
+			return nil
 		},
 	}
 
-	p, err := process.NewPublisher(ph)
+	p, err := process.NewPublisher(process.ArgsPublisher{Handler: ph})
 	require.Nil(t, err)
 
 	_ = p.Run()
@@ -86,13 +103,14 @@ func TestBroadcastRevert(t *testing.T) {
 	numCalls := uint32(0)
 
 	ph := &mocks.PublisherHandlerStub{
-		PublishRevertCalled: func(revertBlock data.RevertBlock) {
+		PublishRevertCalled: func(_ context.Context, revertBlock data.RevertBlock) error {
 			atomic.AddUint32(&numCalls, 1)
 			wg.Done()
+			return nil
 		},
 	}
 
-	p, err := process.NewPublisher(ph)
+	p, err := process.NewPublisher(process.ArgsPublisher{Handler: ph})
 	require.Nil(t, err)
 
 	_ = p.Run()
@@ -113,13 +131,14 @@ func TestBroadcastFinalized(t *testing.T) {
 	numCalls := uint32(0)
 
 	ph := &mocks.PublisherHandlerStub{
-		PublishFinalizedCalled: func(finalizedBlock data.FinalizedBlock) {
+		PublishFinalizedCalled: func(_ context.Context, finalizedBlock data.FinalizedBlock) error {
 			atomic.AddUint32(&numCalls, 1)
 			wg.Done()
+			return nil
 		},
 	}
 
-	p, err := process.NewPublisher(ph)
+	p, err := process.NewPublisher(process.ArgsPublisher{Handler: ph})
 	require.Nil(t, err)
 
 	_ = p.Run()
@@ -133,6 +152,290 @@ func TestBroadcastFinalized(t *testing.T) {
 	require.Equal(t, uint32(1), atomic.LoadUint32(&numCalls))
 }
 
+func TestBroadcastWithContext(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should publish the events", func(t *testing.T) {
+		t.Parallel()
+
+		publishedChan := make(chan string, 3)
+		ph := &mocks.PublisherHandlerStub{
+			PublishCalled: func(_ context.Context, events data.BlockEvents) error {
+				publishedChan <- "events"
+				return nil
+			},
+			PublishRevertCalled: func(_ context.Context, revertBlock data.RevertBlock) error {
+				publishedChan <- "revert"
+				return nil
+			},
+			PublishFinalizedCalled: func(_ context.Context, finalizedBlock data.FinalizedBlock) error {
+				publishedChan <- "finalized"
+				return nil
+			},
+		}
+
+		p, err := process.NewPublisher(process.ArgsPublisher{Handler: ph})
+		require.Nil(t, err)
+
+		_ = p.Run()
+		defer func() {
+			_ = p.Close()
+		}()
+
+		err = p.BroadcastWithContext(context.Background(), data.BlockEvents{})
+		require.Nil(t, err)
+		err = p.BroadcastRevertWithContext(context.Background(), data.RevertBlock{})
+		require.Nil(t, err)
+		err = p.BroadcastFinalizedWithContext(context.Background(), data.FinalizedBlock{})
+		require.Nil(t, err)
+
+		require.Equal(t, "events", <-publishedChan)
+		require.Equal(t, "revert", <-publishedChan)
+		require.Equal(t, "finalized", <-publishedChan)
+	})
+
+	t.Run("cancelled context should stop waiting for a hung publish", func(t *testing.T) {
+		t.Parallel()
+
+		publishStarted := make(chan struct{})
+		unblockPublish := make(chan struct{})
+		numCalls := uint32(0)
+		ph := &mocks.PublisherHandlerStub{
+			PublishCalled: func(_ context.Context, events data.BlockEvents) error {
+				if atomic.AddUint32(&numCalls, 1) == 1 {
+					close(publishStarted)
+					<-unblockPublish
+				}
+				return nil
+			},
+		}
+
+		p, err := process.NewPublisher(process.ArgsPublisher{Handler: ph})
+		require.Nil(t, err)
+
+		_ = p.Run()
+		defer func() {
+			close(unblockPublish)
+			_ = p.Close()
+		}()
+
+		p.Broadcast(data.BlockEvents{Hash: "hung"})
+		<-publishStarted
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		err = p.BroadcastWithContext(ctx, data.BlockEvents{Hash: "hash1"})
+		require.Equal(t, context.DeadlineExceeded, err)
+
+		cancelledCtx, cancelCtx := context.WithCancel(context.Background())
+		cancelCtx()
+
+		err = p.BroadcastRevertWithContext(cancelledCtx, data.RevertBlock{})
+		require.Equal(t, context.Canceled, err)
+		err = p.BroadcastFinalizedWithContext(cancelledCtx, data.FinalizedBlock{})
+		require.Equal(t, context.Canceled, err)
+
+		require.Equal(t, uint32(1), atomic.LoadUint32(&numCalls))
+	})
+
+	t.Run("should return after the events are published", func(t *testing.T) {
+		t.Parallel()
+
+		numPublished := uint32(0)
+		ph := &mocks.PublisherHandlerStub{
+			PublishCalled: func(_ context.Context, events data.BlockEvents) error {
+				time.Sleep(10 * time.Millisecond)
+				atomic.AddUint32(&numPublished, 1)
+				return nil
+			},
+		}
+		p, err := process.NewPublisher(process.ArgsPublisher{Handler: ph, BroadcastBufferSize: 10})
+		require.Nil(t, err)
+
+		_ = p.Run()
+		defer func() {
+			_ = p.Close()
+		}()
+
+		err = p.BroadcastWithContext(context.Background(), data.BlockEvents{Hash: "hash1"})
+		require.Nil(t, err)
+		require.Equal(t, uint32(1), atomic.LoadUint32(&numPublished))
+	})
+
+	t.Run("should return only the publish error of the broadcast", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		var notifyPublishError func(err error)
+		ph := &mocks.PublisherHandlerStub{
+			SetPublishErrorHandlerCalled: func(handler func(err error)) {
+				notifyPublishError = handler
+			},
+			PublishCalled: func(_ context.Context, events data.BlockEvents) error {
+				if events.Hash == "hash1" {
+					return expectedErr
+				}
+				if events.Hash == "other" {
+					notifyPublishError(errors.New("other error"))
+					return errors.New("other error")
+				}
+				return nil
+			},
+		}
+		p, err := process.NewPublisher(process.ArgsPublisher{Handler: ph, BroadcastBufferSize: 10})
+		require.Nil(t, err)
+
+		_ = p.Run()
+		defer func() {
+			_ = p.Close()
+		}()
+
+		err = p.BroadcastWithContext(context.Background(), data.BlockEvents{Hash: "hash1"})
+		require.Equal(t, expectedErr, err)
+
+		p.Broadcast(data.BlockEvents{Hash: "other"})
+		err = p.BroadcastWithContext(context.Background(), data.BlockEvents{Hash: "hash2"})
+		require.Nil(t, err)
+
+		// the errors notified by the handler are sent on the errors channel
+		require.Equal(t, 1, len(p.Errors()))
+	})
+
+	t.Run("should wait for the publish of all the event types", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		ph := &mocks.PublisherHandlerStub{
+			PublishTxsCalled: func(_ context.Context, blockTxs data.BlockTxs) error {
+				return expectedErr
+			},
+			PublishScrsCalled: func(_ context.Context, blockScrs data.BlockScrs) error {
+				return expectedErr
+			},
+			PublishBlockEventsWithOrderCalled: func(_ context.Context, blockTxs data.BlockEventsWithOrder) error {
+				return expectedErr
+			},
+			PublishTxEventsCalled: func(_ context.Context, blockTxEvents data.BlockTxEvents) error {
+				return expectedErr
+			},
+			PublishRoundsCalled: func(_ context.Context, roundEvents data.RoundEvents) error {
+				return expectedErr
+			},
+			PublishValidatorsRatingCalled: func(_ context.Context, validatorsRating data.ValidatorsRatingEvent) error {
+				return expectedErr
+			},
+			PublishAccountsCalled: func(_ context.Context, accountsEvents data.AccountsEvents) error {
+				return expectedErr
+			},
+		}
+		p, err := process.NewPublisher(process.ArgsPublisher{Handler: ph})
+		require.Nil(t, err)
+
+		_ = p.Run()
+		defer func() {
+			_ = p.Close()
+		}()
+
+		ctx := context.Background()
+		require.Equal(t, expectedErr, p.BroadcastTxsWithContext(ctx, data.BlockTxs{}))
+		require.Equal(t, expectedErr, p.BroadcastScrsWithContext(ctx, data.BlockScrs{}))
+		require.Equal(t, expectedErr, p.BroadcastBlockEventsWithOrderWithContext(ctx, data.BlockEventsWithOrder{}))
+		require.Equal(t, expectedErr, p.BroadcastTxEventsWithContext(ctx, data.BlockTxEvents{}))
+		require.Equal(t, expectedErr, p.BroadcastRoundsWithContext(ctx, data.RoundEvents{}))
+		require.Equal(t, expectedErr, p.BroadcastValidatorsRatingWithContext(ctx, data.ValidatorsRatingEvent{}))
+		require.Equal(t, expectedErr, p.BroadcastAccountsWithContext(ctx, data.AccountsEvents{}))
+	})
+
+	t.Run("context done while queued should skip the publish", func(t *testing.T) {
+		t.Parallel()
+
+		publishStarted := make(chan struct{})
+		unblockPublish := make(chan struct{})
+		publishedHashes := make(chan string, 3)
+		ph := &mocks.PublisherHandlerStub{
+			PublishCalled: func(_ context.Context, events data.BlockEvents) error {
+				if events.Hash == "hung" {
+					close(publishStarted)
+					<-unblockPublish
+				}
+				publishedHashes <- events.Hash
+				return nil
+			},
+		}
+		p, err := process.NewPublisher(process.ArgsPublisher{Handler: ph, BroadcastBufferSize: 10})
+		require.Nil(t, err)
+
+		_ = p.Run()
+
+		p.Broadcast(data.BlockEvents{Hash: "hung"})
+		<-publishStarted
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		err = p.BroadcastWithContext(ctx, data.BlockEvents{Hash: "hash1"})
+		require.Equal(t, context.DeadlineExceeded, err)
+
+		close(unblockPublish)
+		p.Broadcast(data.BlockEvents{Hash: "hash2"})
+		_ = p.Close()
+
+		require.Equal(t, "hung", <-publishedHashes)
+		require.Equal(t, "hash2", <-publishedHashes)
+		require.Equal(t, 0, len(publishedHashes))
+	})
+
+	t.Run("context done after the publish started should return the publish result", func(t *testing.T) {
+		t.Parallel()
+
+		publishStarted := make(chan struct{})
+		unblockPublish := make(chan struct{})
+		ph := &mocks.PublisherHandlerStub{
+			PublishCalled: func(_ context.Context, events data.BlockEvents) error {
+				close(publishStarted)
+				<-unblockPublish
+				return nil
+			},
+		}
+		p, err := process.NewPublisher(process.ArgsPublisher{Handler: ph})
+		require.Nil(t, err)
+
+		_ = p.Run()
+		defer func() {
+			_ = p.Close()
+		}()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			<-publishStarted
+			cancel()
+			time.Sleep(10 * time.Millisecond)
+			close(unblockPublish)
+		}()
+
+		err = p.BroadcastWithContext(ctx, data.BlockEvents{Hash: "hash1"})
+		require.Nil(t, err)
+	})
+
+	t.Run("closed publisher should error", func(t *testing.T) {
+		t.Parallel()
+
+		p, err := process.NewPublisher(process.ArgsPublisher{Handler: &mocks.PublisherHandlerStub{}})
+		require.Nil(t, err)
+
+		_ = p.Run()
+		_ = p.Close()
+
+		err = p.BroadcastWithContext(context.Background(), data.BlockEvents{})
+		require.Equal(t, process.ErrPublisherClosed, err)
+		err = p.BroadcastRevertWithContext(context.Background(), data.RevertBlock{})
+		require.Equal(t, process.ErrPublisherClosed, err)
+		err = p.BroadcastFinalizedWithContext(context.Background(), data.FinalizedBlock{})
+		require.Equal(t, process.ErrPublisherClosed, err)
+	})
+}
+
 func TestBroadcastTxs(t *testing.T) {
 	t.Parallel()
 
@@ -140,13 +443,14 @@ func TestBroadcastTxs(t *testing.T) {
 	numCalls := uint32(0)
 
 	ph := &mocks.PublisherHandlerStub{
-		PublishTxsCalled: func(blockTxs data.BlockTxs) {
+		PublishTxsCalled: func(_ context.Context, blockTxs data.BlockTxs) error {
 			atomic.AddUint32(&numCalls, 1)
 			wg.Done()
+			return nil
 		},
 	}
 
-	p, err := process.NewPublisher(ph)
+	p, err := process.NewPublisher(process.ArgsPublisher{Handler: ph})
 	require.Nil(t, err)
 
 	_ = p.Run()
@@ -167,13 +471,14 @@ func TestBroadcastScrs(t *testing.T) {
 	numCalls := uint32(0)
 
 	ph := &mocks.PublisherHandlerStub{
-		PublishScrsCalled: func(blockScrs data.BlockScrs) {
+		PublishScrsCalled: func(_ context.Context, blockScrs data.BlockScrs) error {
 			atomic.AddUint32(&numCalls, 1)
 			wg.Done()
+			return nil
 		},
 	}
 
-	p, err := process.NewPublisher(ph)
+	p, err := process.NewPublisher(process.ArgsPublisher{Handler: ph})
 	require.Nil(t, err)
 
 	_ = p.Run()
@@ -194,13 +499,14 @@ func TestBroadcastBlockEventsWithOrder(t *testing.T) {
 	numCalls := uint32(0)
 
 	ph := &mocks.PublisherHandlerStub{
-		PublishBlockEventsWithOrderCalled: func(blockTxs data.BlockEventsWithOrder) {
+		PublishBlockEventsWithOrderCalled: func(_ context.Context, blockTxs data.BlockEventsWithOrder) error {
 			atomic.AddUint32(&numCalls, 1)
 			wg.Done()
+			return nil
 		},
 	}
 
-	p, err := process.NewPublisher(ph)
+	p, err := process.NewPublisher(process.ArgsPublisher{Handler: ph})
 	require.Nil(t, err)
 
 	_ = p.Run()
@@ -214,6 +520,140 @@ func TestBroadcastBlockEventsWithOrder(t *testing.T) {
 	require.Equal(t, uint32(1), atomic.LoadUint32(&numCalls))
 }
 
+func TestBroadcastBufferSize(t *testing.T) {
+	t.Parallel()
+
+	t.Run("buffered events should be published in order", func(t *testing.T) {
+		t.Parallel()
+
+		mutPublished := sync.Mutex{}
+		published := make([]string, 0)
+		ph := &mocks.PublisherHandlerStub{
+			PublishCalled: func(_ context.Context, events data.BlockEvents) error {
+				mutPublished.Lock()
+				published = append(published, "push")
+				mutPublished.Unlock()
+				return nil
+			},
+			PublishRevertCalled: func(_ context.Context, revertBlock data.RevertBlock) error {
+				mutPublished.Lock()
+				published = append(published, "revert")
+				mutPublished.Unlock()
+				return nil
+			},
+			PublishFinalizedCalled: func(_ context.Context, finalizedBlock data.FinalizedBlock) error {
+				mutPublished.Lock()
+				published = append(published, "finalized")
+				mutPublished.Unlock()
+				return nil
+			},
+		}
+
+		p, err := process.NewPublisher(process.ArgsPublisher{Handler: ph, BroadcastBufferSize: 3})
+		require.Nil(t, err)
+
+		// the events are buffered while the loop is not started
+		p.Broadcast(data.BlockEvents{})
+		p.BroadcastRevert(data.RevertBlock{})
+		p.BroadcastFinalized(data.FinalizedBlock{})
+		require.Contains(t, p.GetMetricsForPrometheus(), "notifier_broadcast_queue_depth 3")
+
+		_ = p.Run()
+		defer func() {
+			_ = p.Close()
+		}()
+
+		require.Eventually(t, func() bool {
+			mutPublished.Lock()
+			defer mutPublished.Unlock()
+
+			return len(published) == 3
+		}, time.Second, 10*time.Millisecond)
+
+		require.Equal(t, []string{"push", "revert", "finalized"}, published)
+		require.Contains(t, p.GetMetricsForPrometheus(), "notifier_broadcast_queue_depth 0")
+	})
+
+	t.Run("full buffer should block the producers", func(t *testing.T) {
+		t.Parallel()
+
+		numCalls := uint32(0)
+		ph := &mocks.PublisherHandlerStub{
+			PublishCalled: func(_ context.Context, events data.BlockEvents) error {
+				atomic.AddUint32(&numCalls, 1)
+				return nil
+			},
+		}
+
+		p, err := process.NewPublisher(process.ArgsPublisher{Handler: ph, BroadcastBufferSize: 1})
+		require.Nil(t, err)
+
+		p.Broadcast(data.BlockEvents{})
+
+		broadcastDone := make(chan struct{})
+		go func() {
+			p.Broadcast(data.BlockEvents{})
+			close(broadcastDone)
+		}()
+
+		select {
+		case <-broadcastDone:
+			require.Fail(t, "broadcast should block while the buffer is full")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		_ = p.Run()
+		defer func() {
+			_ = p.Close()
+		}()
+
+		select {
+		case <-broadcastDone:
+		case <-time.After(time.Second):
+			require.Fail(t, "broadcast should not block after the loop is started")
+		}
+
+		require.Eventually(t, func() bool {
+			return atomic.LoadUint32(&numCalls) == 2
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("buffered events should count as pending", func(t *testing.T) {
+		t.Parallel()
+
+		unblockPublish := make(chan struct{})
+		ph := &mocks.PublisherHandlerStub{
+			PublishCalled: func(_ context.Context, events data.BlockEvents) error {
+				<-unblockPublish
+				return nil
+			},
+		}
+
+		p, err := process.NewPublisher(process.ArgsPublisher{Handler: ph, MaxPendingBroadcasts: 1, BroadcastBufferSize: 5})
+		require.Nil(t, err)
+
+		_ = p.Run()
+		defer func() {
+			_ = p.Close()
+		}()
+
+		// the first event blocks the publishing loop, the next ones are buffered
+		for i := 0; i < 3; i++ {
+			p.Broadcast(data.BlockEvents{})
+		}
+
+		require.Eventually(t, func() bool {
+			return errors.Is(p.Ping(context.Background()), process.ErrTooManyPendingBroadcasts)
+		}, time.Second, 10*time.Millisecond)
+
+		close(unblockPublish)
+
+		require.Eventually(t, func() bool {
+			return p.Ping(context.Background()) == nil
+		}, time.Second, 10*time.Millisecond)
+	})
+}
+
 func TestClose(t *testing.T) {
 	t.Parallel()
 
@@ -223,12 +663,13 @@ func TestClose(t *testing.T) {
 		numCalls := uint32(0)
 
 		ph := &mocks.PublisherHandlerStub{
-			PublishCalled: func(events data.BlockEvents) {
+			PublishCalled: func(_ context.Context, events data.BlockEvents) error {
 				atomic.AddUint32(&numCalls, 1)
+				return nil
 			},
 		}
 
-		p, err := process.NewPublisher(ph)
+		p, err := process.NewPublisher(process.ArgsPublisher{Handler: ph})
 		require.Nil(t, err)
 
 		_ = p.Run()
@@ -242,4 +683,431 @@ func TestClose(t *testing.T) {
 
 		require.Equal(t, uint32(0), atomic.LoadUint32(&numCalls))
 	})
+
+	t.Run("should publish accepted events before closing the handler", func(t *testing.T) {
+		t.Parallel()
+
+		numEvents := 20
+		mutPublished := sync.Mutex{}
+		published := make(map[string]struct{})
+		publishedAfterClose := false
+		closeCalled := uint32(0)
+		firstPublishStarted := make(chan struct{})
+		unblockFirstPublish := make(chan struct{})
+
+		ph := &mocks.PublisherHandlerStub{
+			PublishCalled: func(_ context.Context, events data.BlockEvents) error {
+				if events.Hash == "hash0" {
+					close(firstPublishStarted)
+					<-unblockFirstPublish
+				}
+
+				mutPublished.Lock()
+				defer mutPublished.Unlock()
+
+				if atomic.LoadUint32(&closeCalled) > 0 {
+					publishedAfterClose = true
+				}
+				published[events.Hash] = struct{}{}
+				return nil
+			},
+			CloseCalled: func() error {
+				atomic.AddUint32(&closeCalled, 1)
+				return nil
+			},
+		}
+
+		p, err := process.NewPublisher(process.ArgsPublisher{Handler: ph})
+		require.Nil(t, err)
+
+		_ = p.Run()
+
+		// the first event blocks the processing loop, while the next producers wait to be accepted
+		go p.Broadcast(data.BlockEvents{Hash: "hash0"})
+		<-firstPublishStarted
+
+		wg := sync.WaitGroup{}
+		wg.Add(numEvents - 1)
+		for i := 1; i < numEvents; i++ {
+			go func(idx int) {
+				defer wg.Done()
+				p.Broadcast(data.BlockEvents{Hash: fmt.Sprintf("hash%d", idx)})
+			}(i)
+		}
+
+		closeDone := make(chan error)
+		go func() {
+			closeDone <- p.Close()
+		}()
+
+		time.Sleep(50 * time.Millisecond)
+		close(unblockFirstPublish)
+
+		select {
+		case err = <-closeDone:
+			require.Nil(t, err)
+		case <-time.After(time.Second):
+			require.Fail(t, "close should not block")
+		}
+
+		// the producers which were not accepted should not block
+		wg.Wait()
+
+		// broadcasts after close should not block
+		p.Broadcast(data.BlockEvents{Hash: "late"})
+
+		mutPublished.Lock()
+		defer mutPublished.Unlock()
+
+		require.Equal(t, uint32(1), atomic.LoadUint32(&closeCalled))
+		require.False(t, publishedAfterClose)
+		require.Contains(t, published, "hash0")
+		require.NotContains(t, published, "late")
+	})
+
+	t.Run("should close the handler if the loop was not started", func(t *testing.T) {
+		t.Parallel()
+
+		closeCalled := false
+		ph := &mocks.PublisherHandlerStub{
+			CloseCalled: func() error {
+				closeCalled = true
+				return nil
+			},
+		}
+
+		p, err := process.NewPublisher(process.ArgsPublisher{Handler: ph})
+		require.Nil(t, err)
+
+		err = p.Close()
+		require.Nil(t, err)
+		require.True(t, closeCalled)
+
+		err = p.Close()
+		require.Nil(t, err)
+	})
+
+	t.Run("should publish all the buffered events within the drain timeout", func(t *testing.T) {
+		t.Parallel()
+
+		numEvents := 5
+		numPublished := uint32(0)
+		publishedBeforeClose := uint32(0)
+		ph := &mocks.PublisherHandlerStub{
+			PublishCalled: func(_ context.Context, events data.BlockEvents) error {
+				atomic.AddUint32(&numPublished, 1)
+				return nil
+			},
+			CloseCalled: func() error {
+				atomic.StoreUint32(&publishedBeforeClose, atomic.LoadUint32(&numPublished))
+				return nil
+			},
+		}
+
+		p, err := process.NewPublisher(process.ArgsPublisher{
+			Handler:             ph,
+			BroadcastBufferSize: uint32(numEvents),
+			DrainTimeout:        time.Second,
+		})
+		require.Nil(t, err)
+
+		for i := 0; i < numEvents; i++ {
+			p.Broadcast(data.BlockEvents{})
+		}
+
+		_ = p.Run()
+		err = p.Close()
+		require.Nil(t, err)
+		require.Equal(t, uint32(numEvents), atomic.LoadUint32(&publishedBeforeClose))
+	})
+
+	t.Run("should drop the remaining events after the drain timeout", func(t *testing.T) {
+		t.Parallel()
+
+		numEvents := 10
+		numPublished := uint32(0)
+		closeCalled := uint32(0)
+		ph := &mocks.PublisherHandlerStub{
+			PublishCalled: func(_ context.Context, events data.BlockEvents) error {
+				time.Sleep(50 * time.Millisecond)
+				atomic.AddUint32(&numPublished, 1)
+				return nil
+			},
+			CloseCalled: func() error {
+				atomic.AddUint32(&closeCalled, 1)
+				return nil
+			},
+		}
+
+		p, err := process.NewPublisher(process.ArgsPublisher{
+			Handler:             ph,
+			BroadcastBufferSize: uint32(numEvents),
+			DrainTimeout:        120 * time.Millisecond,
+		})
+		require.Nil(t, err)
+
+		for i := 0; i < numEvents; i++ {
+			p.Broadcast(data.BlockEvents{})
+		}
+
+		closeDone := make(chan error)
+		go func() {
+			closeDone <- p.Close()
+		}()
+
+		select {
+		case err = <-closeDone:
+			require.Nil(t, err)
+		case <-time.After(time.Second):
+			require.Fail(t, "close should not wait for all the events after the drain timeout")
+		}
+
+		published := atomic.LoadUint32(&numPublished)
+		require.Greater(t, published, uint32(0))
+		require.Less(t, published, uint32(numEvents))
+		require.Equal(t, uint32(1), atomic.LoadUint32(&closeCalled))
+		require.Contains(t, p.GetMetricsForPrometheus(), "notifier_broadcast_queue_depth 0")
+	})
+
+	t.Run("drain timeout should cancel the in-flight publish", func(t *testing.T) {
+		t.Parallel()
+
+		publishStarted := make(chan struct{})
+		publishErr := make(chan error, 1)
+		ph := &mocks.PublisherHandlerStub{
+			PublishCalled: func(ctx context.Context, events data.BlockEvents) error {
+				close(publishStarted)
+				<-ctx.Done()
+				publishErr <- ctx.Err()
+				return nil
+			},
+		}
+
+		p, err := process.NewPublisher(process.ArgsPublisher{
+			Handler:      ph,
+			DrainTimeout: 50 * time.Millisecond,
+		})
+		require.Nil(t, err)
+
+		_ = p.Run()
+		p.Broadcast(data.BlockEvents{})
+		<-publishStarted
+
+		err = p.Close()
+		require.Nil(t, err)
+		require.Equal(t, context.Canceled, <-publishErr)
+	})
+
+	t.Run("hung publish should not block close after the drain timeout", func(t *testing.T) {
+		t.Parallel()
+
+		publishStarted := make(chan struct{})
+		unblockPublish := make(chan struct{})
+		defer close(unblockPublish)
+
+		ph := &mocks.PublisherHandlerStub{
+			PublishCalled: func(_ context.Context, events data.BlockEvents) error {
+				close(publishStarted)
+				<-unblockPublish
+				return nil
+			},
+		}
+
+		p, err := process.NewPublisher(process.ArgsPublisher{
+			Handler:      ph,
+			DrainTimeout: 50 * time.Millisecond,
+		})
+		require.Nil(t, err)
+
+		_ = p.Run()
+		p.Broadcast(data.BlockEvents{})
+		<-publishStarted
+
+		closeDone := make(chan error)
+		go func() {
+			closeDone <- p.Close()
+		}()
+
+		select {
+		case err = <-closeDone:
+			require.Equal(t, process.ErrPublisherCloseTimeout, err)
+		case <-time.After(3 * time.Second):
+			require.Fail(t, "close should not wait for the hung publish")
+		}
+	})
+
+	t.Run("should publish buffered events if the loop was not started", func(t *testing.T) {
+		t.Parallel()
+
+		numCalls := uint32(0)
+		ph := &mocks.PublisherHandlerStub{
+			PublishCalled: func(_ context.Context, events data.BlockEvents) error {
+				atomic.AddUint32(&numCalls, 1)
+				return nil
+			},
+		}
+
+		p, err := process.NewPublisher(process.ArgsPublisher{Handler: ph, BroadcastBufferSize: 2})
+		require.Nil(t, err)
+
+		p.Broadcast(data.BlockEvents{})
+		p.Broadcast(data.BlockEvents{})
+
+		err = p.Close()
+		require.Nil(t, err)
+		require.Equal(t, uint32(2), atomic.LoadUint32(&numCalls))
+	})
+
+	t.Run("should publish the events accepted while closing", func(t *testing.T) {
+		t.Parallel()
+
+		for i := 0; i < 100; i++ {
+			p, err := process.NewPublisher(process.ArgsPublisher{Handler: &mocks.PublisherHandlerStub{}, BroadcastBufferSize: 10})
+			require.Nil(t, err)
+			require.Nil(t, p.Run())
+
+			numAccepted := uint32(0)
+			numPublished := uint32(0)
+			wg := sync.WaitGroup{}
+			for j := 0; j < 10; j++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+
+					for {
+						err := p.EnqueueWithContext(context.Background(), func() {
+							atomic.AddUint32(&numPublished, 1)
+						})
+						if err != nil {
+							return
+						}
+						atomic.AddUint32(&numAccepted, 1)
+					}
+				}()
+			}
+
+			err = p.Close()
+			require.Nil(t, err)
+			wg.Wait()
+			require.Equal(t, atomic.LoadUint32(&numAccepted), atomic.LoadUint32(&numPublished))
+		}
+	})
+}
+
+func TestErrors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should receive the errors notified by the handler", func(t *testing.T) {
+		t.Parallel()
+
+		var notifyPublishError func(err error)
+		handler := &mocks.PublisherHandlerStub{
+			SetPublishErrorHandlerCalled: func(handler func(err error)) {
+				notifyPublishError = handler
+			},
+		}
+		p, err := process.NewPublisher(process.ArgsPublisher{Handler: handler})
+		require.Nil(t, err)
+		require.NotNil(t, notifyPublishError)
+
+		expectedErr := errors.New("expected error")
+		notifyPublishError(expectedErr)
+
+		select {
+		case err = <-p.Errors():
+			require.Equal(t, expectedErr, err)
+		case <-time.After(time.Second):
+			require.Fail(t, "timeout waiting for the publish error")
+		}
+	})
+
+	t.Run("should not block if the errors are not read", func(t *testing.T) {
+		t.Parallel()
+
+		var notifyPublishError func(err error)
+		handler := &mocks.PublisherHandlerStub{
+			SetPublishErrorHandlerCalled: func(handler func(err error)) {
+				notifyPublishError = handler
+			},
+		}
+		p, err := process.NewPublisher(process.ArgsPublisher{Handler: handler})
+		require.Nil(t, err)
+
+		numErrors := 1000
+		for i := 0; i < numErrors; i++ {
+			notifyPublishError(fmt.Errorf("error %d", i))
+		}
+
+		require.Less(t, len(p.Errors()), numErrors)
+		require.Equal(t, "error 0", (<-p.Errors()).Error())
+	})
+}
+
+func TestGetHealthState(t *testing.T) {
+	t.Parallel()
+
+	p, err := process.NewPublisher(process.ArgsPublisher{Handler: &mocks.PublisherHandlerStub{}})
+	require.Nil(t, err)
+
+	require.Equal(t, common.HealthStateDown, p.GetHealthState())
+
+	_ = p.Run()
+	require.Equal(t, common.HealthStateUp, p.GetHealthState())
+
+	_ = p.Close()
+	require.Equal(t, common.HealthStateDown, p.GetHealthState())
+}
+
+func TestPing(t *testing.T) {
+	t.Parallel()
+
+	t.Run("not running publisher should error", func(t *testing.T) {
+		t.Parallel()
+
+		p, err := process.NewPublisher(process.ArgsPublisher{Handler: &mocks.PublisherHandlerStub{}})
+		require.Nil(t, err)
+
+		require.Equal(t, process.ErrPublisherNotRunning, p.Ping(context.Background()))
+
+		_ = p.Run()
+		require.Nil(t, p.Ping(context.Background()))
+
+		_ = p.Close()
+		require.Equal(t, process.ErrPublisherNotRunning, p.Ping(context.Background()))
+	})
+
+	t.Run("too many pending broadcasts should error", func(t *testing.T) {
+		t.Parallel()
+
+		unblockPublish := make(chan struct{})
+		ph := &mocks.PublisherHandlerStub{
+			PublishCalled: func(_ context.Context, events data.BlockEvents) error {
+				<-unblockPublish
+				return nil
+			},
+		}
+
+		p, err := process.NewPublisher(process.ArgsPublisher{Handler: ph, MaxPendingBroadcasts: 1})
+		require.Nil(t, err)
+
+		_ = p.Run()
+		defer func() {
+			_ = p.Close()
+		}()
+
+		// the first event blocks the publishing loop, the next ones are pending
+		for i := 0; i < 3; i++ {
+			go p.Broadcast(data.BlockEvents{})
+		}
+
+		require.Eventually(t, func() bool {
+			return errors.Is(p.Ping(context.Background()), process.ErrTooManyPendingBroadcasts)
+		}, time.Second, 10*time.Millisecond)
+
+		close(unblockPublish)
+
+		require.Eventually(t, func() bool {
+			return p.Ping(context.Background()) == nil
+		}, time.Second, 10*time.Millisecond)
+	})
 }
@@ -0,0 +1,90 @@
+package process
+
+import (
+	"math/big"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+)
+
+const (
+	// numTopicsPerTransfer is the number of topics of each transfer: token identifier, nonce and value
+	numTopicsPerTransfer = 3
+	maxNonceBytes        = 8
+)
+
+// tokenTransferIdentifiers holds the identifiers of the token transfer events and whether
+// they can hold multiple transfers
+var tokenTransferIdentifiers = map[string]bool{
+	core.BuiltInFunctionESDTTransfer:         false,
+	core.BuiltInFunctionESDTNFTTransfer:      false,
+	core.BuiltInFunctionMultiESDTNFTTransfer: true,
+}
+
+// decodeTokenTransfers decodes the topics of the ESDT/NFT transfer events, which hold the token
+// identifier, the nonce and the value of each transfer, followed by the receiver public key. The
+// multi transfer events can hold multiple transfers to the same receiver. It returns false for
+// the other events and if the topics of the event do not have the expected layout
+func decodeTokenTransfers(event data.Event, pubKeyConverter core.PubkeyConverter) ([]data.TokenTransfer, bool) {
+	isMultiTransfer, isTransfer := tokenTransferIdentifiers[event.Identifier]
+	if !isTransfer {
+		return nil, false
+	}
+
+	numTopics := len(event.Topics)
+	if numTopics <= numTopicsPerTransfer || (numTopics-1)%numTopicsPerTransfer != 0 {
+		return nil, false
+	}
+	numTransfers := (numTopics - 1) / numTopicsPerTransfer
+	if numTransfers > 1 && !isMultiTransfer {
+		return nil, false
+	}
+
+	receiverPubKey := event.Topics[numTopics-1]
+	if len(receiverPubKey) != pubKeyConverter.Len() {
+		return nil, false
+	}
+	receiver, err := pubKeyConverter.Encode(receiverPubKey)
+	if err != nil {
+		return nil, false
+	}
+
+	transfers := make([]data.TokenTransfer, 0, numTransfers)
+	for i := 0; i < numTransfers; i++ {
+		topics := event.Topics[i*numTopicsPerTransfer : (i+1)*numTopicsPerTransfer]
+
+		token := topics[0]
+		if !isValidTokenIdentifier(token) {
+			return nil, false
+		}
+		if len(topics[1]) > maxNonceBytes {
+			return nil, false
+		}
+
+		transfers = append(transfers, data.TokenTransfer{
+			Token:    string(token),
+			Nonce:    big.NewInt(0).SetBytes(topics[1]).Uint64(),
+			Value:    big.NewInt(0).SetBytes(topics[2]).String(),
+			Receiver: receiver,
+		})
+	}
+
+	return transfers, true
+}
+
+// isValidTokenIdentifier only checks the characters of the token identifier, e.g. "WEGLD-bd4d79",
+// since the ticker length limits differ between the token types
+func isValidTokenIdentifier(token []byte) bool {
+	if len(token) == 0 {
+		return false
+	}
+
+	for _, c := range token {
+		isAlphanumeric := (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+		if !isAlphanumeric && c != '-' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,144 @@
+package process_test
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/pubkeyConverter"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/process"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	aliceAddress  = "erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th"
+	alicePubKey   = "ATlHLv9ohncamC8wg9pdQh8kwpGB5jiIIo3IHKYNaeE="
+	bobAddress    = "erd1spyavw0956vq68xj8y4tenjpq2wd5a9p2c6j8gsz7ztyrnpxrruqzu66jx"
+	bobPubKey     = "gEnWOeWmmA0c0jkqvM5BApzadKFWNSOiAvCWQcwmGPg="
+	routerAddress = "erd1qqqqqqqqqqqqqpgqeel2kumf0r8ffyhth7pqdujjat9nx0862jpsg2pqaq"
+	routerPubKey  = "AAAAAAAAAAAFAM5+q3NpeM6Ukuu/ggbyUurLMzz6VIM="
+)
+
+// createTransferEvent creates an event with the base64 encoded topics, as returned by the api
+func createTransferEvent(t *testing.T, identifier string, topics ...string) data.Event {
+	event := data.Event{
+		Address:    aliceAddress,
+		Identifier: identifier,
+		Topics:     make([][]byte, 0, len(topics)),
+	}
+	for _, topic := range topics {
+		decoded, err := base64.StdEncoding.DecodeString(topic)
+		require.Nil(t, err)
+		event.Topics = append(event.Topics, decoded)
+	}
+
+	return event
+}
+
+func TestDecodeTokenTransfers(t *testing.T) {
+	t.Parallel()
+
+	converter, err := pubkeyConverter.NewBech32PubkeyConverter(32, "erd")
+	require.Nil(t, err)
+
+	t.Run("fungible transfer", func(t *testing.T) {
+		t.Parallel()
+
+		// WEGLD-bd4d79, no nonce, 1.5 WEGLD
+		event := createTransferEvent(t, core.BuiltInFunctionESDTTransfer, "V0VHTEQtYmQ0ZDc5", "", "FNESDXsWAAA=", bobPubKey)
+
+		transfers, ok := process.DecodeTokenTransfers(event, converter)
+		require.True(t, ok)
+		require.Equal(t, []data.TokenTransfer{
+			{Token: "WEGLD-bd4d79", Nonce: 0, Value: "1500000000000000000", Receiver: bobAddress},
+		}, transfers)
+	})
+
+	t.Run("nft transfer", func(t *testing.T) {
+		t.Parallel()
+
+		// XMEX-fda355, nonce 0x12a4c, quantity 1
+		event := createTransferEvent(t, core.BuiltInFunctionESDTNFTTransfer, "WE1FWC1mZGEzNTU=", "ASpM", "AQ==", routerPubKey)
+
+		transfers, ok := process.DecodeTokenTransfers(event, converter)
+		require.True(t, ok)
+		require.Equal(t, []data.TokenTransfer{
+			{Token: "XMEX-fda355", Nonce: 76364, Value: "1", Receiver: routerAddress},
+		}, transfers)
+	})
+
+	t.Run("multi transfer should expand all the transfers", func(t *testing.T) {
+		t.Parallel()
+
+		event := createTransferEvent(t, core.BuiltInFunctionMultiESDTNFTTransfer,
+			"VVNEQy1jNzZmMWY=", "", "DuaygA==",
+			"WE1FWC1mZGEzNTU=", "Dcs=", "AWNFeF2KAAA=",
+			"RUdMRC0wMDAwMDA=", "", "FNESDXsWAAA=",
+			routerPubKey,
+		)
+
+		transfers, ok := process.DecodeTokenTransfers(event, converter)
+		require.True(t, ok)
+		require.Equal(t, []data.TokenTransfer{
+			{Token: "USDC-c76f1f", Nonce: 0, Value: "250000000", Receiver: routerAddress},
+			{Token: "XMEX-fda355", Nonce: 3531, Value: "100000000000000000", Receiver: routerAddress},
+			{Token: "EGLD-000000", Nonce: 0, Value: "1500000000000000000", Receiver: routerAddress},
+		}, transfers)
+	})
+
+	t.Run("multi transfer with a single transfer", func(t *testing.T) {
+		t.Parallel()
+
+		event := createTransferEvent(t, core.BuiltInFunctionMultiESDTNFTTransfer, "TUVYLTQ1NWM1Nw==", "", "AWNFeF2KAAA=", alicePubKey)
+
+		transfers, ok := process.DecodeTokenTransfers(event, converter)
+		require.True(t, ok)
+		require.Equal(t, []data.TokenTransfer{
+			{Token: "MEX-455c57", Nonce: 0, Value: "100000000000000000", Receiver: aliceAddress},
+		}, transfers)
+	})
+
+	t.Run("empty value should be zero", func(t *testing.T) {
+		t.Parallel()
+
+		event := createTransferEvent(t, core.BuiltInFunctionESDTTransfer, "UklERS03ZDE4ZTk=", "", "", bobPubKey)
+
+		transfers, ok := process.DecodeTokenTransfers(event, converter)
+		require.True(t, ok)
+		require.Equal(t, "0", transfers[0].Value)
+	})
+
+	t.Run("other identifiers should not be decoded", func(t *testing.T) {
+		t.Parallel()
+
+		event := createTransferEvent(t, "swapTokensFixedInput", "V0VHTEQtYmQ0ZDc5", "", "FNESDXsWAAA=", bobPubKey)
+
+		transfers, ok := process.DecodeTokenTransfers(event, converter)
+		require.False(t, ok)
+		require.Nil(t, transfers)
+	})
+
+	t.Run("malformed topics should not be decoded", func(t *testing.T) {
+		t.Parallel()
+
+		testCases := map[string]data.Event{
+			"no topics":             createTransferEvent(t, core.BuiltInFunctionESDTTransfer),
+			"missing receiver":      createTransferEvent(t, core.BuiltInFunctionESDTTransfer, "V0VHTEQtYmQ0ZDc5", "", "FNESDXsWAAA="),
+			"extra topic":           createTransferEvent(t, core.BuiltInFunctionESDTNFTTransfer, "WE1FWC1mZGEzNTU=", "ASpM", "AQ==", routerPubKey, "AQ=="),
+			"multiple transfers":    createTransferEvent(t, core.BuiltInFunctionESDTTransfer, "V0VHTEQtYmQ0ZDc5", "", "AQ==", "TUVYLTQ1NWM1Nw==", "", "AQ==", bobPubKey),
+			"incomplete multi":      createTransferEvent(t, core.BuiltInFunctionMultiESDTNFTTransfer, "V0VHTEQtYmQ0ZDc5", "", "AQ==", "TUVYLTQ1NWM1Nw==", bobPubKey),
+			"short receiver":        createTransferEvent(t, core.BuiltInFunctionESDTTransfer, "V0VHTEQtYmQ0ZDc5", "", "AQ==", "AQID"),
+			"empty token":           createTransferEvent(t, core.BuiltInFunctionESDTTransfer, "", "", "AQ==", bobPubKey),
+			"invalid token":         createTransferEvent(t, core.BuiltInFunctionESDTTransfer, "AAEC", "", "AQ==", bobPubKey),
+			"nonce too large":       createTransferEvent(t, core.BuiltInFunctionESDTNFTTransfer, "WE1FWC1mZGEzNTU=", "AQIDBAUGBwgJ", "AQ==", bobPubKey),
+			"invalid multi element": createTransferEvent(t, core.BuiltInFunctionMultiESDTNFTTransfer, "VVNEQy1jNzZmMWY=", "", "AQ==", "", "", "AQ==", bobPubKey),
+		}
+
+		for name, event := range testCases {
+			transfers, ok := process.DecodeTokenTransfers(event, converter)
+			require.False(t, ok, name)
+			require.Nil(t, transfers, name)
+		}
+	})
+}
@@ -0,0 +1,117 @@
+package rabbitmq
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/multiversx/mx-chain-notifier-go/data"
+)
+
+// eventsBatcher accumulates the block events until the batch reaches the max number of
+// blocks or the window expires, then it hands over the batch to the flush handler. The
+// batches are flushed while holding the batcher lock, so they are published in order.
+// The batches flushed when the window expires are published with the batcher context,
+// which is cancelled on close
+type eventsBatcher struct {
+	mut          sync.Mutex
+	maxBlocks    int
+	window       time.Duration
+	flushHandler func(ctx context.Context, batch data.BlockEventsBatch)
+	batch        data.BlockEventsBatch
+	timer        *time.Timer
+	generation   uint64
+	closed       bool
+	ctx          context.Context
+	cancel       context.CancelFunc
+}
+
+func newEventsBatcher(maxBlocks uint32, window time.Duration, flushHandler func(ctx context.Context, batch data.BlockEventsBatch)) *eventsBatcher {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &eventsBatcher{
+		maxBlocks:    int(maxBlocks),
+		window:       window,
+		flushHandler: flushHandler,
+		batch:        newBlockEventsBatch(),
+		ctx:          ctx,
+		cancel:       cancel,
+	}
+}
+
+func newBlockEventsBatch() data.BlockEventsBatch {
+	return data.BlockEventsBatch{
+		Hashes: make([]string, 0),
+		Blocks: make([]data.BlockEvents, 0),
+	}
+}
+
+// add appends the block events to the current batch. The window of a batch starts with its
+// first block. After close, each block is flushed as a batch of its own. The batch flushed
+// by the add call is published with the provided context
+func (eb *eventsBatcher) add(ctx context.Context, events data.BlockEvents) {
+	eb.mut.Lock()
+	defer eb.mut.Unlock()
+
+	eb.batch.Hashes = append(eb.batch.Hashes, events.Hash)
+	eb.batch.Blocks = append(eb.batch.Blocks, events)
+
+	if eb.closed || (eb.maxBlocks > 0 && len(eb.batch.Blocks) >= eb.maxBlocks) {
+		eb.flush(ctx)
+		return
+	}
+	if len(eb.batch.Blocks) == 1 && eb.window > 0 {
+		generation := eb.generation
+		eb.timer = time.AfterFunc(eb.window, func() {
+			eb.flushExpired(generation)
+		})
+	}
+}
+
+// flushExpired flushes the batch whose window expired, unless it was already flushed
+func (eb *eventsBatcher) flushExpired(generation uint64) {
+	eb.mut.Lock()
+	defer eb.mut.Unlock()
+
+	if generation != eb.generation {
+		return
+	}
+
+	eb.flush(eb.ctx)
+}
+
+func (eb *eventsBatcher) flush(ctx context.Context) {
+	if eb.timer != nil {
+		eb.timer.Stop()
+		eb.timer = nil
+	}
+	eb.generation++
+
+	if len(eb.batch.Blocks) == 0 {
+		return
+	}
+
+	batch := eb.batch
+	eb.batch = newBlockEventsBatch()
+	eb.flushHandler(ctx, batch)
+}
+
+// flushPending flushes the current batch with the provided context, before its window expires
+func (eb *eventsBatcher) flushPending(ctx context.Context) {
+	eb.mut.Lock()
+	defer eb.mut.Unlock()
+
+	eb.flush(ctx)
+}
+
+// close cancels the batcher context, so that a hung flush of an expired batch does not
+// block the close, then flushes the current batch with the provided context
+func (eb *eventsBatcher) close(ctx context.Context) {
+	eb.cancel()
+
+	eb.mut.Lock()
+	defer eb.mut.Unlock()
+
+	eb.closed = true
+	eb.flush(ctx)
+}
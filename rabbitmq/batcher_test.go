@@ -0,0 +1,287 @@
+package rabbitmq_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/marshal"
+	"github.com/multiversx/mx-chain-notifier-go/config"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/data/payload"
+	"github.com/multiversx/mx-chain-notifier-go/mocks"
+	"github.com/multiversx/mx-chain-notifier-go/process"
+	"github.com/multiversx/mx-chain-notifier-go/rabbitmq"
+	"github.com/streadway/amqp"
+	"github.com/stretchr/testify/require"
+)
+
+type publishedMessagesCollector struct {
+	mut      sync.Mutex
+	messages map[string][]amqp.Publishing
+}
+
+func newPublishedMessagesCollector() *publishedMessagesCollector {
+	return &publishedMessagesCollector{
+		messages: make(map[string][]amqp.Publishing),
+	}
+}
+
+func (pmc *publishedMessagesCollector) publish(_ context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+	pmc.mut.Lock()
+	pmc.messages[exchange] = append(pmc.messages[exchange], msg)
+	pmc.mut.Unlock()
+
+	return nil
+}
+
+func (pmc *publishedMessagesCollector) get(exchange string) []amqp.Publishing {
+	pmc.mut.Lock()
+	defer pmc.mut.Unlock()
+
+	return append([]amqp.Publishing{}, pmc.messages[exchange]...)
+}
+
+func createMockArgsWithBatching(collector *publishedMessagesCollector, batching config.RabbitMQBatchingConfig) rabbitmq.ArgsRabbitMqPublisher {
+	args := createMockArgsRabbitMqPublisher()
+	args.Config.Batching = batching
+	args.Marshaller = &marshal.JsonMarshalizer{}
+	args.Client = &mocks.RabbitClientStub{
+		PublishCalled: collector.publish,
+	}
+
+	return args
+}
+
+func requireBatch(t *testing.T, msg amqp.Publishing, expectedBlocks []data.BlockEvents) {
+	batch := data.BlockEventsBatch{}
+	err := (&marshal.JsonMarshalizer{}).Unmarshal(&batch, msg.Body)
+	require.Nil(t, err)
+
+	expectedHashes := make([]string, 0, len(expectedBlocks))
+	for _, block := range expectedBlocks {
+		expectedHashes = append(expectedHashes, block.Hash)
+	}
+	require.Equal(t, expectedHashes, batch.Hashes)
+	require.Equal(t, expectedBlocks, batch.Blocks)
+	require.Equal(t, int64(len(expectedBlocks)), msg.Headers["batch_size"])
+}
+
+func TestRabbitMqPublisher_BatchingConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no flush trigger should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsWithBatching(newPublishedMessagesCollector(), config.RabbitMQBatchingConfig{Enabled: true})
+
+		publisher, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, publisher)
+		require.True(t, errors.Is(err, rabbitmq.ErrInvalidBatchingConfig))
+	})
+
+	t.Run("per event publish mode should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsWithBatching(newPublishedMessagesCollector(), config.RabbitMQBatchingConfig{Enabled: true, MaxBlocks: 2})
+		args.Config.PublishMode = "per-event"
+
+		publisher, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, publisher)
+		require.True(t, errors.Is(err, rabbitmq.ErrInvalidBatchingConfig))
+	})
+
+	t.Run("routing key template should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsWithBatching(newPublishedMessagesCollector(), config.RabbitMQBatchingConfig{Enabled: true, MaxBlocks: 2})
+		args.Config.EventsExchange = config.RabbitMQExchangeConfig{Name: "allevents", Type: "topic", RoutingKeyTemplate: "{shard}"}
+
+		publisher, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, publisher)
+		require.True(t, errors.Is(err, rabbitmq.ErrInvalidBatchingConfig))
+	})
+
+	t.Run("marshaller without batch support should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsWithBatching(newPublishedMessagesCollector(), config.RabbitMQBatchingConfig{Enabled: true, MaxBlocks: 2})
+		args.Marshaller = payload.NewProtoPayloadMarshaller()
+
+		publisher, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, publisher)
+		require.True(t, errors.Is(err, rabbitmq.ErrInvalidBatchingConfig))
+	})
+}
+
+func TestPublishBatches(t *testing.T) {
+	t.Parallel()
+
+	block1 := data.BlockEvents{Hash: "hash1", ShardID: 1, Events: []data.Event{{Address: "erd1addr", Identifier: "id1"}}}
+	block2 := data.BlockEvents{Hash: "hash2", ShardID: 1}
+	block3 := data.BlockEvents{Hash: "hash3", ShardID: 1}
+
+	t.Run("batch should be published when reaching max blocks", func(t *testing.T) {
+		t.Parallel()
+
+		collector := newPublishedMessagesCollector()
+		args := createMockArgsWithBatching(collector, config.RabbitMQBatchingConfig{Enabled: true, MaxBlocks: 2})
+
+		publisher, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, err)
+
+		publisher.Publish(context.Background(), block1)
+		require.Equal(t, 0, len(collector.get("allevents")))
+
+		publisher.Publish(context.Background(), block2)
+		publisher.Publish(context.Background(), block3)
+
+		messages := collector.get("allevents")
+		require.Equal(t, 1, len(messages))
+		requireBatch(t, messages[0], []data.BlockEvents{block1, block2})
+		require.Equal(t, "hash1,hash2", messages[0].Headers["hash"])
+	})
+
+	t.Run("batch should be published when the window expires", func(t *testing.T) {
+		t.Parallel()
+
+		collector := newPublishedMessagesCollector()
+		args := createMockArgsWithBatching(collector, config.RabbitMQBatchingConfig{Enabled: true, MaxBlocks: 10, WindowInMs: 50})
+
+		publisher, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, err)
+
+		publisher.Publish(context.Background(), block1)
+		publisher.Publish(context.Background(), block2)
+		require.Equal(t, 0, len(collector.get("allevents")))
+
+		require.Eventually(t, func() bool {
+			return len(collector.get("allevents")) == 1
+		}, time.Second, time.Millisecond*10)
+		requireBatch(t, collector.get("allevents")[0], []data.BlockEvents{block1, block2})
+
+		publisher.Publish(context.Background(), block3)
+		require.Eventually(t, func() bool {
+			return len(collector.get("allevents")) == 2
+		}, time.Second, time.Millisecond*10)
+		requireBatch(t, collector.get("allevents")[1], []data.BlockEvents{block3})
+	})
+
+	t.Run("pending batch should be published on close", func(t *testing.T) {
+		t.Parallel()
+
+		collector := newPublishedMessagesCollector()
+		args := createMockArgsWithBatching(collector, config.RabbitMQBatchingConfig{Enabled: true, MaxBlocks: 10, WindowInMs: 60000})
+
+		publisher, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, err)
+
+		publisher.Publish(context.Background(), block1)
+		publisher.Publish(context.Background(), block2)
+		require.Nil(t, publisher.Close())
+
+		messages := collector.get("allevents")
+		require.Equal(t, 1, len(messages))
+		requireBatch(t, messages[0], []data.BlockEvents{block1, block2})
+	})
+
+	t.Run("revert and finalized events should publish the pending batch first", func(t *testing.T) {
+		t.Parallel()
+
+		publishedExchanges := make([]string, 0)
+		collector := newPublishedMessagesCollector()
+		args := createMockArgsWithBatching(collector, config.RabbitMQBatchingConfig{Enabled: true, MaxBlocks: 10})
+		args.Client = &mocks.RabbitClientStub{
+			PublishCalled: func(ctx context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+				publishedExchanges = append(publishedExchanges, exchange)
+				return collector.publish(ctx, exchange, key, mandatory, immediate, msg)
+			},
+		}
+
+		publisher, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, err)
+
+		publisher.Publish(context.Background(), block1)
+		publisher.PublishTxs(context.Background(), data.BlockTxs{Hash: "hash1"})
+		publisher.PublishRevert(context.Background(), data.RevertBlock{Hash: "hash1"})
+		publisher.Publish(context.Background(), block2)
+		publisher.PublishFinalized(context.Background(), data.FinalizedBlock{Hash: "hash2"})
+
+		require.Equal(t, []string{"blocktxs", "allevents", "revert", "allevents", "finalized"}, publishedExchanges)
+		requireBatch(t, collector.get("allevents")[0], []data.BlockEvents{block1})
+		requireBatch(t, collector.get("allevents")[1], []data.BlockEvents{block2})
+	})
+	t.Run("hung batch flush should be cancelled by the drain timeout", func(t *testing.T) {
+		t.Parallel()
+
+		flushStarted := make(chan struct{})
+		flushErr := make(chan error, 1)
+		collector := newPublishedMessagesCollector()
+		args := createMockArgsWithBatching(collector, config.RabbitMQBatchingConfig{Enabled: true, MaxBlocks: 10, WindowInMs: 60000})
+		args.Client = &mocks.RabbitClientStub{
+			PublishCalled: func(ctx context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+				if exchange == "allevents" {
+					close(flushStarted)
+					<-ctx.Done()
+					flushErr <- ctx.Err()
+					return ctx.Err()
+				}
+				return collector.publish(ctx, exchange, key, mandatory, immediate, msg)
+			},
+		}
+
+		rabbitPublisher, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, err)
+
+		publisher, err := process.NewPublisher(process.ArgsPublisher{
+			Handler:      rabbitPublisher,
+			DrainTimeout: 50 * time.Millisecond,
+		})
+		require.Nil(t, err)
+		require.Nil(t, publisher.Run())
+
+		publisher.Broadcast(block1)
+		publisher.BroadcastFinalized(data.FinalizedBlock{Hash: "hash1"})
+		<-flushStarted
+
+		done := make(chan error)
+		go func() {
+			done <- publisher.Close()
+		}()
+
+		select {
+		case err = <-done:
+			require.Nil(t, err)
+		case <-time.After(time.Second):
+			require.Fail(t, "close should not wait for the hung batch flush")
+		}
+		require.Equal(t, context.Canceled, <-flushErr)
+	})
+
+	t.Run("hung flush of an expired batch should be cancelled on close", func(t *testing.T) {
+		t.Parallel()
+
+		flushStarted := make(chan struct{})
+		flushErr := make(chan error, 1)
+		args := createMockArgsWithBatching(newPublishedMessagesCollector(), config.RabbitMQBatchingConfig{Enabled: true, MaxBlocks: 10, WindowInMs: 10})
+		args.Client = &mocks.RabbitClientStub{
+			PublishCalled: func(ctx context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+				close(flushStarted)
+				<-ctx.Done()
+				flushErr <- ctx.Err()
+				return ctx.Err()
+			},
+		}
+
+		publisher, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, err)
+
+		publisher.Publish(context.Background(), block1)
+		<-flushStarted
+
+		require.Nil(t, publisher.Close())
+		require.Equal(t, context.Canceled, <-flushErr)
+	})
+}
@@ -0,0 +1,133 @@
+package rabbitmq
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// CircuitStateClosed is the state in which the events are published
+	CircuitStateClosed = "closed"
+	// CircuitStateOpen is the state in which the events are rejected without being published
+	CircuitStateOpen = "open"
+	// CircuitStateHalfOpen is the state in which one publish is allowed, in order to check
+	// whether the broker recovered
+	CircuitStateHalfOpen = "half-open"
+)
+
+// circuitBreaker stops the publishing after consecutive failures, so that a failing broker
+// is not retried for each event. It opens after failureThreshold consecutive failures and,
+// after openDuration, it half-opens and allows one publish: a success closes it, while a
+// failure opens it again. A zero threshold disables it
+type circuitBreaker struct {
+	failureThreshold uint32
+	openDuration     time.Duration
+	getTime          func() time.Time
+
+	mut                 sync.Mutex
+	state               string
+	numFailures         uint32
+	openedAt            time.Time
+	isHalfOpenAttempted bool
+}
+
+func newCircuitBreaker(failureThreshold uint32, openDuration time.Duration) *circuitBreaker {
+	return &circuitBreaker{
+		failureThreshold: failureThreshold,
+		openDuration:     openDuration,
+		getTime:          time.Now,
+		state:            CircuitStateClosed,
+	}
+}
+
+func (cb *circuitBreaker) isEnabled() bool {
+	return cb.failureThreshold > 0
+}
+
+// allow returns true if a publish can be attempted. In the half-open state, only the
+// first caller is allowed, until its result is recorded
+func (cb *circuitBreaker) allow() bool {
+	if !cb.isEnabled() {
+		return true
+	}
+
+	cb.mut.Lock()
+	defer cb.mut.Unlock()
+
+	cb.updateState()
+	switch cb.state {
+	case CircuitStateOpen:
+		return false
+	case CircuitStateHalfOpen:
+		if cb.isHalfOpenAttempted {
+			return false
+		}
+		cb.isHalfOpenAttempted = true
+		return true
+	default:
+		return true
+	}
+}
+
+// recordResult updates the state with the result of an allowed publish
+func (cb *circuitBreaker) recordResult(err error) {
+	if !cb.isEnabled() {
+		return
+	}
+
+	cb.mut.Lock()
+	defer cb.mut.Unlock()
+
+	if err == nil {
+		if cb.state != CircuitStateClosed {
+			log.Info("rabbitMQ publish circuit closed")
+		}
+		cb.state = CircuitStateClosed
+		cb.numFailures = 0
+		return
+	}
+
+	cb.numFailures++
+	if cb.state == CircuitStateHalfOpen || cb.numFailures >= cb.failureThreshold {
+		cb.open()
+	}
+}
+
+// ignoreResult does not count an allowed publish which failed since the connection was lost,
+// the events are buffered until reconnecting. A half-open circuit allows another publish
+func (cb *circuitBreaker) ignoreResult() {
+	if !cb.isEnabled() {
+		return
+	}
+
+	cb.mut.Lock()
+	cb.isHalfOpenAttempted = false
+	cb.mut.Unlock()
+}
+
+func (cb *circuitBreaker) open() {
+	if cb.state != CircuitStateOpen {
+		log.Warn("rabbitMQ publish circuit opened", "consecutive failures", cb.numFailures, "open duration", cb.openDuration)
+	}
+
+	cb.state = CircuitStateOpen
+	cb.openedAt = cb.getTime()
+	cb.isHalfOpenAttempted = false
+}
+
+// updateState half-opens the circuit once the open duration passed
+func (cb *circuitBreaker) updateState() {
+	if cb.state == CircuitStateOpen && cb.getTime().Sub(cb.openedAt) >= cb.openDuration {
+		cb.state = CircuitStateHalfOpen
+		cb.isHalfOpenAttempted = false
+	}
+}
+
+func (cb *circuitBreaker) getState() string {
+	cb.mut.Lock()
+	defer cb.mut.Unlock()
+
+	cb.updateState()
+
+	return cb.state
+}
@@ -0,0 +1,179 @@
+package rabbitmq
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+
+	"github.com/multiversx/mx-chain-notifier-go/config"
+	"github.com/streadway/amqp"
+)
+
+const amqpsScheme = "amqps"
+
+// GetConnectionURLs returns the rabbitMQ urls from config, Url being the first one. If
+// username or password environment variables are configured, their values replace the
+// credentials of each url, so that the credentials do not have to be stored in the config file
+func GetConnectionURLs(cfg config.RabbitMQConfig) ([]string, error) {
+	urls := cfg.GetURLs()
+	for index, rawURL := range urls {
+		connectionURL, err := getConnectionURL(rawURL, cfg)
+		if err != nil {
+			return nil, err
+		}
+		urls[index] = connectionURL
+	}
+
+	return urls, nil
+}
+
+func getConnectionURL(rawURL string, cfg config.RabbitMQConfig) (string, error) {
+	if cfg.UsernameEnvVar == "" && cfg.PasswordEnvVar == "" {
+		return rawURL, nil
+	}
+
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("%w: could not parse rabbitMQ url", err)
+	}
+
+	username := parsedURL.User.Username()
+	if cfg.UsernameEnvVar != "" {
+		username, err = getEnvVar(cfg.UsernameEnvVar)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	password, _ := parsedURL.User.Password()
+	if cfg.PasswordEnvVar != "" {
+		password, err = getEnvVar(cfg.PasswordEnvVar)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	parsedURL.User = url.UserPassword(username, password)
+
+	return parsedURL.String(), nil
+}
+
+func getEnvVar(name string) (string, error) {
+	value, ok := os.LookupEnv(name)
+	if !ok || value == "" {
+		return "", fmt.Errorf("%w: %s", ErrMissingCredentialsEnvVar, name)
+	}
+
+	return value, nil
+}
+
+// CreateTLSConfig creates the TLS config used for amqps connections. It returns nil
+// if no TLS option is configured, in which case the system root CAs are used for amqps
+func CreateTLSConfig(cfg config.RabbitMQTLSConfig) (*tls.Config, error) {
+	if cfg.CAFile == "" && cfg.CertFile == "" && cfg.KeyFile == "" && !cfg.InsecureSkipVerify {
+		return nil, nil
+	}
+	if (cfg.CertFile == "") != (cfg.KeyFile == "") {
+		return nil, fmt.Errorf("%w: both client certificate and key files should be provided", ErrInvalidTLSConfig)
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	}
+
+	if cfg.CAFile != "" {
+		caCert, err := ioutil.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("%w: could not read CA file: %s", ErrInvalidTLSConfig, err.Error())
+		}
+
+		caCertPool := x509.NewCertPool()
+		if !caCertPool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("%w: no certificate found in CA file %s", ErrInvalidTLSConfig, cfg.CAFile)
+		}
+		tlsConfig.RootCAs = caCertPool
+	}
+
+	if cfg.CertFile != "" {
+		clientCert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("%w: could not load client certificate: %s", ErrInvalidTLSConfig, err.Error())
+		}
+		tlsConfig.Certificates = []tls.Certificate{clientCert}
+	}
+
+	return tlsConfig, nil
+}
+
+func checkTLSScheme(rawURL string, tlsConfig *tls.Config) error {
+	if tlsConfig == nil {
+		return nil
+	}
+
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	if parsedURL.Scheme != amqpsScheme {
+		return fmt.Errorf("%w: TLS options require an %s url", ErrInvalidTLSConfig, amqpsScheme)
+	}
+
+	return nil
+}
+
+// dialFirstAvailable dials the urls in order, starting with the one at startIndex and
+// wrapping around, until a connection succeeds. It returns the connection together with
+// the index of its url, or the error of the last attempt if all of them failed
+func dialFirstAvailable(urls []string, startIndex int, dial func(url string) (*amqp.Connection, error)) (*amqp.Connection, int, error) {
+	var err error
+	for i := 0; i < len(urls); i++ {
+		index := (startIndex + i) % len(urls)
+
+		var conn *amqp.Connection
+		conn, err = dial(urls[index])
+		if err == nil {
+			return conn, index, nil
+		}
+
+		// the url is not logged, since it can hold the credentials
+		log.Warn("could not connect to rabbitMQ node", "url index", index, "err", err.Error())
+	}
+
+	return nil, 0, err
+}
+
+// convertDialError distinguishes the TLS handshake failures and the authentication
+// failures from the other connection errors
+func convertDialError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if isTLSError(err) {
+		return fmt.Errorf("%w: %s", ErrTLSHandshakeFailure, err.Error())
+	}
+
+	amqpErr, ok := err.(*amqp.Error)
+	if ok && amqpErr.Code == amqp.AccessRefused {
+		return fmt.Errorf("%w: %s", ErrAuthenticationFailure, amqpErr.Reason)
+	}
+
+	return err
+}
+
+func isTLSError(err error) bool {
+	var recordHeaderErr tls.RecordHeaderError
+	var unknownAuthorityErr x509.UnknownAuthorityError
+	var certificateInvalidErr x509.CertificateInvalidError
+	var hostnameErr x509.HostnameError
+
+	return errors.As(err, &recordHeaderErr) ||
+		errors.As(err, &unknownAuthorityErr) ||
+		errors.As(err, &certificateInvalidErr) ||
+		errors.As(err, &hostnameErr)
+}
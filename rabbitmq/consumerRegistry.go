@@ -0,0 +1,156 @@
+package rabbitmq
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-notifier-go/common"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/dispatcher"
+	"github.com/multiversx/mx-chain-notifier-go/filters"
+)
+
+// ArgsConsumerRegistry defines the arguments needed for consumer registry creation
+type ArgsConsumerRegistry struct {
+	// MaxSubscriptionsPerConsumer limits the number of subscription entries of a consumer,
+	// 0 means no limit
+	MaxSubscriptionsPerConsumer int
+
+	// PubKeyConverter is optional, it is used to normalize the subscription addresses in
+	// the same way as the hub subscriptions
+	PubKeyConverter core.PubkeyConverter
+}
+
+type consumerRegistry struct {
+	filter                      filters.EventFilter
+	maxSubscriptionsPerConsumer int
+	pubKeyConverter             core.PubkeyConverter
+
+	mutConsumers sync.RWMutex
+	consumers    map[string][]data.Subscription
+}
+
+// NewConsumerRegistry creates a new in memory registry for the subscriptions of the rabbitMQ
+// consumers, registered via the REST api. The subscriptions are matched with the same
+// filter as the hub dispatchers, including the glob addresses
+func NewConsumerRegistry(args ArgsConsumerRegistry) (*consumerRegistry, error) {
+	if args.MaxSubscriptionsPerConsumer < 0 {
+		return nil, dispatcher.ErrInvalidMaxSubscriptionsPerDispatcher
+	}
+
+	return &consumerRegistry{
+		filter:                      filters.NewGlobFilter(),
+		maxSubscriptionsPerConsumer: args.MaxSubscriptionsPerConsumer,
+		pubKeyConverter:             args.PubKeyConverter,
+		consumers:                   make(map[string][]data.Subscription),
+	}, nil
+}
+
+// Register sets the subscriptions of the consumer, replacing the previous ones, if any. A
+// consumer without subscription entries receives all the logs and events
+func (cr *consumerRegistry) Register(subscription data.ConsumerSubscription) error {
+	if subscription.ConsumerID == "" {
+		return ErrEmptyConsumerID
+	}
+	for _, entry := range subscription.SubscriptionEntries {
+		if entry.EventType != "" && entry.EventType != common.PushLogsAndEvents {
+			return ErrUnsupportedConsumerEventType
+		}
+	}
+
+	subscriptions, err := cr.createSubscriptions(subscription)
+	if err != nil {
+		return err
+	}
+
+	cr.mutConsumers.Lock()
+	cr.consumers[subscription.ConsumerID] = subscriptions
+	cr.mutConsumers.Unlock()
+
+	log.Info("registered rabbitMQ consumer", "consumerID", subscription.ConsumerID, "num subscriptions", len(subscriptions))
+
+	return nil
+}
+
+// createSubscriptions uses a subscription mapper, so that the entries are validated and get
+// the same match levels as the entries of the hub dispatchers
+func (cr *consumerRegistry) createSubscriptions(subscription data.ConsumerSubscription) ([]data.Subscription, error) {
+	mapper, err := dispatcher.NewSubscriptionMapper(dispatcher.ArgsSubscriptionMapper{
+		MaxSubscriptionsPerDispatcher: cr.maxSubscriptionsPerConsumer,
+		PubKeyConverter:               cr.pubKeyConverter,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the mapper is keyed by dispatcher id, so each consumer id gets a stable one
+	dispatcherID := uuid.NewSHA1(uuid.NameSpaceOID, []byte(subscription.ConsumerID))
+	err = mapper.MatchSubscribeEvent(data.SubscribeEvent{
+		DispatcherID:        dispatcherID,
+		SubscriptionEntries: subscription.SubscriptionEntries,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.DispatcherSubscriptions(dispatcherID), nil
+}
+
+// Unregister removes the subscriptions of the consumer
+func (cr *consumerRegistry) Unregister(consumerID string) error {
+	cr.mutConsumers.Lock()
+	_, ok := cr.consumers[consumerID]
+	delete(cr.consumers, consumerID)
+	cr.mutConsumers.Unlock()
+	if !ok {
+		return common.ErrConsumerNotFound
+	}
+
+	log.Info("unregistered rabbitMQ consumer", "consumerID", consumerID)
+
+	return nil
+}
+
+// MatchEvents returns the ids of the consumers which matched at least one event, sorted,
+// together with the matched events of each of them, in the block order
+func (cr *consumerRegistry) MatchEvents(events []data.Event) ([]string, map[string][]data.Event) {
+	cr.mutConsumers.RLock()
+	defer cr.mutConsumers.RUnlock()
+
+	consumerIDs := make([]string, 0)
+	matchedEvents := make(map[string][]data.Event)
+	for consumerID, subscriptions := range cr.consumers {
+		matched := cr.matchConsumerEvents(subscriptions, events)
+		if len(matched) == 0 {
+			continue
+		}
+
+		consumerIDs = append(consumerIDs, consumerID)
+		matchedEvents[consumerID] = matched
+	}
+	sort.Strings(consumerIDs)
+
+	return consumerIDs, matchedEvents
+}
+
+// matchConsumerEvents returns each event once, even if matched by multiple subscriptions
+func (cr *consumerRegistry) matchConsumerEvents(subscriptions []data.Subscription, events []data.Event) []data.Event {
+	matched := make([]data.Event, 0)
+	for _, event := range events {
+		for _, subscription := range subscriptions {
+			if cr.filter.MatchEvent(subscription, event) {
+				matched = append(matched, event)
+				break
+			}
+		}
+	}
+
+	return matched
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (cr *consumerRegistry) IsInterfaceNil() bool {
+	return cr == nil
+}
@@ -0,0 +1,164 @@
+package rabbitmq_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-notifier-go/common"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/dispatcher"
+	"github.com/multiversx/mx-chain-notifier-go/rabbitmq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewConsumerRegistry(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid max subscriptions", func(t *testing.T) {
+		t.Parallel()
+
+		registry, err := rabbitmq.NewConsumerRegistry(rabbitmq.ArgsConsumerRegistry{MaxSubscriptionsPerConsumer: -1})
+		require.True(t, check.IfNil(registry))
+		require.Equal(t, dispatcher.ErrInvalidMaxSubscriptionsPerDispatcher, err)
+	})
+
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		registry, err := rabbitmq.NewConsumerRegistry(rabbitmq.ArgsConsumerRegistry{})
+		require.Nil(t, err)
+		require.False(t, check.IfNil(registry))
+	})
+}
+
+func TestConsumerRegistry_Register(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty consumer id should error", func(t *testing.T) {
+		t.Parallel()
+
+		registry, _ := rabbitmq.NewConsumerRegistry(rabbitmq.ArgsConsumerRegistry{})
+
+		err := registry.Register(data.ConsumerSubscription{})
+		require.Equal(t, rabbitmq.ErrEmptyConsumerID, err)
+	})
+
+	t.Run("unsupported event type should error", func(t *testing.T) {
+		t.Parallel()
+
+		registry, _ := rabbitmq.NewConsumerRegistry(rabbitmq.ArgsConsumerRegistry{})
+
+		err := registry.Register(data.ConsumerSubscription{
+			ConsumerID:          "consumer1",
+			SubscriptionEntries: []data.SubscriptionEntry{{EventType: common.RevertBlockEvents}},
+		})
+		require.Equal(t, rabbitmq.ErrUnsupportedConsumerEventType, err)
+	})
+
+	t.Run("invalid glob pattern should error", func(t *testing.T) {
+		t.Parallel()
+
+		registry, _ := rabbitmq.NewConsumerRegistry(rabbitmq.ArgsConsumerRegistry{})
+
+		err := registry.Register(data.ConsumerSubscription{
+			ConsumerID:          "consumer1",
+			SubscriptionEntries: []data.SubscriptionEntry{{Address: "erd1[*"}},
+		})
+		require.True(t, errors.Is(err, dispatcher.ErrInvalidGlobPattern))
+	})
+
+	t.Run("too many subscriptions should error and keep the previous ones", func(t *testing.T) {
+		t.Parallel()
+
+		registry, _ := rabbitmq.NewConsumerRegistry(rabbitmq.ArgsConsumerRegistry{MaxSubscriptionsPerConsumer: 1})
+
+		err := registry.Register(data.ConsumerSubscription{
+			ConsumerID:          "consumer1",
+			SubscriptionEntries: []data.SubscriptionEntry{{Address: "erd1"}},
+		})
+		require.Nil(t, err)
+
+		err = registry.Register(data.ConsumerSubscription{
+			ConsumerID:          "consumer1",
+			SubscriptionEntries: []data.SubscriptionEntry{{Address: "erd2"}, {Address: "erd3"}},
+		})
+		require.True(t, errors.Is(err, dispatcher.ErrMaxSubscriptionsReached))
+
+		consumerIDs, _ := registry.MatchEvents([]data.Event{{Address: "erd1"}})
+		require.Equal(t, []string{"consumer1"}, consumerIDs)
+	})
+
+	t.Run("should replace the previous subscriptions", func(t *testing.T) {
+		t.Parallel()
+
+		registry, _ := rabbitmq.NewConsumerRegistry(rabbitmq.ArgsConsumerRegistry{MaxSubscriptionsPerConsumer: 1})
+
+		err := registry.Register(data.ConsumerSubscription{
+			ConsumerID:          "consumer1",
+			SubscriptionEntries: []data.SubscriptionEntry{{Address: "erd1"}},
+		})
+		require.Nil(t, err)
+		err = registry.Register(data.ConsumerSubscription{
+			ConsumerID:          "consumer1",
+			SubscriptionEntries: []data.SubscriptionEntry{{Address: "erd2"}},
+		})
+		require.Nil(t, err)
+
+		consumerIDs, matchedEvents := registry.MatchEvents([]data.Event{{Address: "erd1"}, {Address: "erd2"}})
+		require.Equal(t, []string{"consumer1"}, consumerIDs)
+		require.Equal(t, []data.Event{{Address: "erd2"}}, matchedEvents["consumer1"])
+	})
+}
+
+func TestConsumerRegistry_Unregister(t *testing.T) {
+	t.Parallel()
+
+	registry, _ := rabbitmq.NewConsumerRegistry(rabbitmq.ArgsConsumerRegistry{})
+
+	err := registry.Unregister("consumer1")
+	require.Equal(t, common.ErrConsumerNotFound, err)
+
+	err = registry.Register(data.ConsumerSubscription{ConsumerID: "consumer1"})
+	require.Nil(t, err)
+
+	err = registry.Unregister("consumer1")
+	require.Nil(t, err)
+
+	consumerIDs, _ := registry.MatchEvents([]data.Event{{Address: "erd1"}})
+	require.Equal(t, 0, len(consumerIDs))
+}
+
+func TestConsumerRegistry_MatchEvents(t *testing.T) {
+	t.Parallel()
+
+	registry, _ := rabbitmq.NewConsumerRegistry(rabbitmq.ArgsConsumerRegistry{})
+	_ = registry.Register(data.ConsumerSubscription{
+		ConsumerID: "consumer2",
+		SubscriptionEntries: []data.SubscriptionEntry{
+			{Address: "erd1", Identifiers: []string{"transfer", "swap"}},
+			{Address: "erd1"},
+		},
+	})
+	_ = registry.Register(data.ConsumerSubscription{
+		ConsumerID:          "consumer1",
+		SubscriptionEntries: []data.SubscriptionEntry{{Address: "erd1*staking"}},
+	})
+	_ = registry.Register(data.ConsumerSubscription{
+		ConsumerID:          "consumer3",
+		SubscriptionEntries: []data.SubscriptionEntry{{Identifier: "unknown"}},
+	})
+
+	events := []data.Event{
+		{Address: "erd1", Identifier: "transfer", TxHash: "txHash1"},
+		{Address: "erd1qqqstaking", Identifier: "delegate", TxHash: "txHash2"},
+		{Address: "erd1", Identifier: "swap", TxHash: "txHash3"},
+		{Address: "erd2", Identifier: "transfer", TxHash: "txHash4"},
+	}
+	consumerIDs, matchedEvents := registry.MatchEvents(events)
+
+	require.Equal(t, []string{"consumer1", "consumer2"}, consumerIDs)
+	require.Equal(t, []data.Event{events[1]}, matchedEvents["consumer1"])
+	require.Equal(t, []data.Event{events[0], events[2]}, matchedEvents["consumer2"])
+	require.Nil(t, matchedEvents["consumer3"])
+}
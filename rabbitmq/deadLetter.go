@@ -0,0 +1,130 @@
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"time"
+)
+
+const deadLetterContentType = "application/json"
+
+// DeadLetterEvent holds an event which could not be published to its exchange, together
+// with the failure details, so that it can be replayed manually. It is published to the
+// dead letter exchange and, if that fails too, appended to the spill file, one per line
+type DeadLetterEvent struct {
+	Exchange      string `json:"exchange"`
+	RoutingKey    string `json:"routingKey"`
+	Hash          string `json:"hash"`
+	CorrelationID string `json:"correlationId,omitempty"`
+	Error         string `json:"error"`
+	Timestamp     int64  `json:"timestamp"`
+	Attempts      uint32 `json:"attempts"`
+	Payload       []byte `json:"payload"`
+}
+
+// handleFailedEvent sends the event which could not be published after all the attempts to
+// the dead letter exchange, or to the spill file if the dead letter publish fails as well
+func (rp *rabbitMqPublisher) handleFailedEvent(event *bufferedEvent, attempts uint32, publishErr error) {
+	if rp.cfg.DeadLetterExchange.Name == "" && rp.cfg.DeadLetterSpillFile == "" {
+		return
+	}
+
+	deadLetterBytes, err := json.Marshal(DeadLetterEvent{
+		Exchange:      event.exchangeName,
+		RoutingKey:    event.routingKey,
+		Hash:          event.info.hash,
+		CorrelationID: event.info.correlationID,
+		Error:         publishErr.Error(),
+		Timestamp:     time.Now().Unix(),
+		Attempts:      attempts,
+		Payload:       event.payload,
+	})
+	if err != nil {
+		log.Error("could not marshal dead letter event", "exchange", event.exchangeName, "hash", event.info.hash, "err", err.Error())
+		return
+	}
+
+	if rp.cfg.DeadLetterExchange.Name != "" {
+		err = rp.publishToDeadLetterExchange(event, deadLetterBytes)
+		if err == nil {
+			return
+		}
+
+		log.Error("failed to publish event to the dead letter exchange",
+			"exchange", event.exchangeName,
+			"hash", event.info.hash,
+			"correlation id", event.info.correlationID,
+			"err", err.Error(),
+		)
+	}
+
+	if rp.cfg.DeadLetterSpillFile == "" {
+		return
+	}
+
+	err = rp.spillToFile(deadLetterBytes)
+	if err != nil {
+		log.Error("failed to write event to the dead letter spill file",
+			"file", rp.cfg.DeadLetterSpillFile,
+			"exchange", event.exchangeName,
+			"hash", event.info.hash,
+			"correlation id", event.info.correlationID,
+			"err", err.Error(),
+		)
+		return
+	}
+
+	log.Warn("event written to the dead letter spill file",
+		"file", rp.cfg.DeadLetterSpillFile,
+		"exchange", event.exchangeName,
+		"hash", event.info.hash,
+	)
+}
+
+// publishToDeadLetterExchange publishes the dead letter event once, with the routing key
+// of the original event. The message keeps the block details headers of the original event
+func (rp *rabbitMqPublisher) publishToDeadLetterExchange(event *bufferedEvent, deadLetterBytes []byte) error {
+	exchangeName := rp.cfg.DeadLetterExchange.Name
+
+	publishing := rp.createPublishing(event.info, deadLetterBytes)
+	publishing.ContentType = deadLetterContentType
+
+	err := rp.client.Publish(
+		context.Background(),
+		exchangeName,
+		event.routingKey,
+		false, // mandatory
+		false, // immediate
+		publishing,
+	)
+	rp.recordPublish(exchangeName, err)
+	if err != nil {
+		return err
+	}
+
+	log.Warn("published event to the dead letter exchange",
+		"exchange", event.exchangeName,
+		"dead letter exchange", exchangeName,
+		"hash", event.info.hash,
+		"correlation id", event.info.correlationID,
+	)
+
+	return nil
+}
+
+// spillToFile appends the dead letter event to the spill file, on a separate line
+func (rp *rabbitMqPublisher) spillToFile(deadLetterBytes []byte) error {
+	file, err := os.OpenFile(rp.cfg.DeadLetterSpillFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+
+	_, err = file.Write(append(deadLetterBytes, '\n'))
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	return file.Close()
+}
@@ -0,0 +1,215 @@
+package rabbitmq_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/multiversx/mx-chain-notifier-go/config"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/mocks"
+	"github.com/multiversx/mx-chain-notifier-go/rabbitmq"
+	"github.com/streadway/amqp"
+	"github.com/stretchr/testify/require"
+)
+
+const deadLetterExchangeName = "deadletter"
+
+func TestPublishToDeadLetter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid dead letter exchange type should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsRabbitMqPublisher()
+		args.Config.DeadLetterExchange = config.RabbitMQExchangeConfig{Name: deadLetterExchangeName}
+
+		publisher, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, publisher)
+		require.True(t, errors.Is(err, rabbitmq.ErrInvalidRabbitMqExchangeType))
+	})
+
+	t.Run("should declare the dead letter exchange", func(t *testing.T) {
+		t.Parallel()
+
+		declaredExchanges := make(map[string]string)
+		args := createMockArgsRabbitMqPublisher()
+		args.Config.DeadLetterExchange = config.RabbitMQExchangeConfig{Name: deadLetterExchangeName, Type: "fanout"}
+		args.Client = &mocks.RabbitClientStub{
+			ExchangeDeclareCalled: func(name, kind string, durable bool) error {
+				declaredExchanges[name] = kind
+				return nil
+			},
+		}
+
+		_, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, err)
+		require.Equal(t, "fanout", declaredExchanges[deadLetterExchangeName])
+	})
+
+	t.Run("failed event should be published to the dead letter exchange", func(t *testing.T) {
+		t.Parallel()
+
+		numAttempts := uint32(0)
+		var deadLetterMsg *amqp.Publishing
+		deadLetterRoutingKey := ""
+		args := createMockArgsRabbitMqPublisher()
+		args.Config.DeadLetterExchange = config.RabbitMQExchangeConfig{Name: deadLetterExchangeName, Type: "fanout"}
+		args.Config.DeadLetterSpillFile = filepath.Join(t.TempDir(), "spill.jsonl")
+		args.Client = &mocks.RabbitClientStub{
+			PublishCalled: func(_ context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+				if exchange == deadLetterExchangeName {
+					deadLetterMsg = &msg
+					deadLetterRoutingKey = key
+					return nil
+				}
+
+				numAttempts++
+				return rabbitmq.ErrPublishNotAcknowledged
+			},
+		}
+
+		publisher, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, err)
+
+		events := data.BlockEvents{Hash: "hash1", CorrelationID: "correlation1"}
+		startTime := time.Now().Unix()
+		publisher.Publish(context.Background(), events)
+
+		require.Equal(t, args.Config.PublishMaxAttempts, numAttempts)
+		require.NotNil(t, deadLetterMsg)
+		require.Equal(t, "", deadLetterRoutingKey)
+		require.Equal(t, "application/json", deadLetterMsg.ContentType)
+		require.Equal(t, "hash1", deadLetterMsg.Headers["hash"])
+
+		deadLetterEvent := rabbitmq.DeadLetterEvent{}
+		err = json.Unmarshal(deadLetterMsg.Body, &deadLetterEvent)
+		require.Nil(t, err)
+
+		expPayload, _ := args.Marshaller.Marshal(events)
+		require.Equal(t, args.Config.EventsExchange.Name, deadLetterEvent.Exchange)
+		require.Equal(t, "", deadLetterEvent.RoutingKey)
+		require.Equal(t, "hash1", deadLetterEvent.Hash)
+		require.Equal(t, "correlation1", deadLetterEvent.CorrelationID)
+		require.Equal(t, rabbitmq.ErrPublishNotAcknowledged.Error(), deadLetterEvent.Error)
+		require.Equal(t, args.Config.PublishMaxAttempts, deadLetterEvent.Attempts)
+		require.GreaterOrEqual(t, deadLetterEvent.Timestamp, startTime)
+		require.Equal(t, expPayload, deadLetterEvent.Payload)
+
+		_, err = os.Stat(args.Config.DeadLetterSpillFile)
+		require.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("dead letter publish failure should spill the event to file", func(t *testing.T) {
+		t.Parallel()
+
+		numDeadLetterAttempts := 0
+		args := createMockArgsRabbitMqPublisher()
+		args.Config.DeadLetterExchange = config.RabbitMQExchangeConfig{Name: deadLetterExchangeName, Type: "fanout"}
+		args.Config.DeadLetterSpillFile = filepath.Join(t.TempDir(), "spill.jsonl")
+		args.Client = &mocks.RabbitClientStub{
+			PublishCalled: func(_ context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+				if exchange == deadLetterExchangeName {
+					numDeadLetterAttempts++
+				}
+
+				return rabbitmq.ErrPublishConfirmTimeout
+			},
+		}
+
+		publisher, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, err)
+
+		publisher.PublishRevert(context.Background(), data.RevertBlock{Hash: "hash1"})
+		publisher.PublishFinalized(context.Background(), data.FinalizedBlock{Hash: "hash2"})
+
+		require.Equal(t, 2, numDeadLetterAttempts)
+
+		spilledEvents := readSpilledEvents(t, args.Config.DeadLetterSpillFile)
+		require.Equal(t, 2, len(spilledEvents))
+
+		expPayload, _ := args.Marshaller.Marshal(data.RevertBlock{Hash: "hash1"})
+		require.Equal(t, args.Config.RevertEventsExchange.Name, spilledEvents[0].Exchange)
+		require.Equal(t, "hash1", spilledEvents[0].Hash)
+		require.Equal(t, rabbitmq.ErrPublishConfirmTimeout.Error(), spilledEvents[0].Error)
+		require.Equal(t, args.Config.PublishMaxAttempts, spilledEvents[0].Attempts)
+		require.Equal(t, expPayload, spilledEvents[0].Payload)
+
+		require.Equal(t, args.Config.FinalizedEventsExchange.Name, spilledEvents[1].Exchange)
+		require.Equal(t, "hash2", spilledEvents[1].Hash)
+	})
+
+	t.Run("spill file without dead letter exchange should spill the event", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsRabbitMqPublisher()
+		args.Config.DeadLetterSpillFile = filepath.Join(t.TempDir(), "spill.jsonl")
+		args.Client = &mocks.RabbitClientStub{
+			PublishCalled: func(_ context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+				return rabbitmq.ErrPublishNotAcknowledged
+			},
+		}
+
+		publisher, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, err)
+
+		publisher.PublishTxs(context.Background(), data.BlockTxs{Hash: "hash1"})
+
+		spilledEvents := readSpilledEvents(t, args.Config.DeadLetterSpillFile)
+		require.Equal(t, 1, len(spilledEvents))
+		require.Equal(t, args.Config.BlockTxsExchange.Name, spilledEvents[0].Exchange)
+	})
+
+	t.Run("events buffered while disconnected should not be dead lettered", func(t *testing.T) {
+		t.Parallel()
+
+		deadLettered := false
+		args := createMockArgsRabbitMqPublisher()
+		args.Config.DeadLetterExchange = config.RabbitMQExchangeConfig{Name: deadLetterExchangeName, Type: "fanout"}
+		args.Client = &mocks.RabbitClientStub{
+			PublishCalled: func(_ context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+				if exchange == deadLetterExchangeName {
+					deadLettered = true
+				}
+
+				return rabbitmq.ErrConnectionFailure
+			},
+			IsConnectedCalled: func() bool {
+				return false
+			},
+		}
+
+		publisher, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, err)
+
+		publisher.Publish(context.Background(), data.BlockEvents{Hash: "hash1"})
+
+		require.False(t, deadLettered)
+	})
+}
+
+func readSpilledEvents(t *testing.T, spillFile string) []rabbitmq.DeadLetterEvent {
+	file, err := os.Open(spillFile)
+	require.Nil(t, err)
+	defer func() {
+		_ = file.Close()
+	}()
+
+	spilledEvents := make([]rabbitmq.DeadLetterEvent, 0)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		spilledEvent := rabbitmq.DeadLetterEvent{}
+		err = json.Unmarshal(scanner.Bytes(), &spilledEvent)
+		require.Nil(t, err)
+
+		spilledEvents = append(spilledEvents, spilledEvent)
+	}
+	require.Nil(t, scanner.Err())
+
+	return spilledEvents
+}
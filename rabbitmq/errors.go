@@ -10,3 +10,75 @@ var ErrInvalidRabbitMqExchangeName = errors.New("invalid rabbitmq exchange name"
 
 // ErrInvalidRabbitMqExchangeType signals that an empty rabbitmq exchange type has been provided
 var ErrInvalidRabbitMqExchangeType = errors.New("invalid rabbitmq exchange type")
+
+// ErrNoRabbitMqURL signals that no rabbitmq url has been provided
+var ErrNoRabbitMqURL = errors.New("no rabbitmq url provided")
+
+// ErrInvalidConfirmTimeout signals that an invalid publish confirm timeout has been provided
+var ErrInvalidConfirmTimeout = errors.New("invalid publish confirm timeout")
+
+// ErrInvalidPublishMode signals that an unknown publish mode has been provided
+var ErrInvalidPublishMode = errors.New("invalid publish mode")
+
+// ErrInvalidPublishMaxAttempts signals that an invalid number of publish attempts has been provided
+var ErrInvalidPublishMaxAttempts = errors.New("invalid publish max attempts")
+
+// ErrPublishNotAcknowledged signals that the broker did not acknowledge the published message
+var ErrPublishNotAcknowledged = errors.New("published message was not acknowledged")
+
+// ErrPublishConfirmTimeout signals that the broker confirmation was not received in time
+var ErrPublishConfirmTimeout = errors.New("timeout while waiting for publish confirm")
+
+// ErrConnectionFailure signals a rabbitmq connection failure
+var ErrConnectionFailure = errors.New("rabbitmq connection failure")
+
+// ErrChannelFailure signals a rabbitmq channel failure
+var ErrChannelFailure = errors.New("rabbitmq channel failure")
+
+// ErrPublishBufferFull signals that the event was dropped since the publish buffer is full
+var ErrPublishBufferFull = errors.New("publish buffer is full, event dropped")
+
+// ErrRoutingKeyTemplateOnFanout signals that a routing key template has been provided for a fanout exchange
+var ErrRoutingKeyTemplateOnFanout = errors.New("routing key template is not supported for fanout exchanges")
+
+// ErrExchangeConflict signals that the exchange already exists with different properties
+var ErrExchangeConflict = errors.New("exchange already exists with different properties")
+
+// ErrExchangeNotFound signals that the exchange does not exist on the broker
+var ErrExchangeNotFound = errors.New("exchange not found")
+
+// ErrInvalidTLSConfig signals that an invalid rabbitmq TLS config has been provided
+var ErrInvalidTLSConfig = errors.New("invalid rabbitmq TLS config")
+
+// ErrMissingCredentialsEnvVar signals that a configured credentials environment variable is not set
+var ErrMissingCredentialsEnvVar = errors.New("missing rabbitmq credentials environment variable")
+
+// ErrTLSHandshakeFailure signals that the TLS handshake with the broker failed
+var ErrTLSHandshakeFailure = errors.New("rabbitmq TLS handshake failure")
+
+// ErrAuthenticationFailure signals that the broker rejected the credentials
+var ErrAuthenticationFailure = errors.New("rabbitmq authentication failure")
+
+// ErrInvalidBatchingConfig signals that an invalid rabbitmq batching config has been provided
+var ErrInvalidBatchingConfig = errors.New("invalid rabbitmq batching config")
+
+// ErrInvalidCircuitBreakerConfig signals that an invalid rabbitmq circuit breaker config has been provided
+var ErrInvalidCircuitBreakerConfig = errors.New("invalid rabbitmq circuit breaker config")
+
+// ErrCircuitOpen signals that the event was not published since the publish circuit is open,
+// after consecutive publish failures
+var ErrCircuitOpen = errors.New("rabbitmq publish circuit is open")
+
+// ErrEmptyConsumerID signals that an empty consumer id has been provided
+var ErrEmptyConsumerID = errors.New("empty consumer id")
+
+// ErrUnsupportedConsumerEventType signals that the consumer subscribed to an event type
+// other than the logs and events
+var ErrUnsupportedConsumerEventType = errors.New("unsupported event type for consumer subscription")
+
+// ErrNilConsumerRegistry signals that a nil consumer registry has been provided
+var ErrNilConsumerRegistry = errors.New("nil consumer registry")
+
+// ErrConsumerEventsExchangeOnFanout signals that the consumer events exchange is a fanout
+// exchange, which would deliver the events of all the consumers to each of them
+var ErrConsumerEventsExchangeOnFanout = errors.New("consumer events exchange cannot be a fanout exchange")
@@ -0,0 +1,22 @@
+package rabbitmq
+
+import (
+	"time"
+
+	"github.com/streadway/amqp"
+)
+
+// ConvertDialError -
+func ConvertDialError(err error) error {
+	return convertDialError(err)
+}
+
+// DialFirstAvailable -
+func DialFirstAvailable(urls []string, startIndex int, dial func(url string) (*amqp.Connection, error)) (*amqp.Connection, int, error) {
+	return dialFirstAvailable(urls, startIndex, dial)
+}
+
+// SetCircuitBreakerTimeHandler -
+func (rp *rabbitMqPublisher) SetCircuitBreakerTimeHandler(handler func() time.Time) {
+	rp.circuitBreaker.getTime = handler
+}
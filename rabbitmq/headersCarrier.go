@@ -0,0 +1,33 @@
+package rabbitmq
+
+import (
+	"github.com/streadway/amqp"
+)
+
+// headersCarrier adapts the message headers to the propagation.TextMapCarrier interface
+type headersCarrier amqp.Table
+
+// Get returns the string value of the header
+func (hc headersCarrier) Get(key string) string {
+	value, ok := hc[key].(string)
+	if !ok {
+		return ""
+	}
+
+	return value
+}
+
+// Set sets the header value
+func (hc headersCarrier) Set(key string, value string) {
+	hc[key] = value
+}
+
+// Keys returns the headers names
+func (hc headersCarrier) Keys() []string {
+	keys := make([]string, 0, len(hc))
+	for key := range hc {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
@@ -0,0 +1,51 @@
+package rabbitmq
+
+import "github.com/multiversx/mx-chain-notifier-go/data"
+
+// identifiersFilter keeps the events with an allowed identifier. An empty allowlist allows
+// all the identifiers which are not denylisted
+type identifiersFilter struct {
+	allowed map[string]struct{}
+	denied  map[string]struct{}
+}
+
+func newIdentifiersFilter(allowlist []string, denylist []string) *identifiersFilter {
+	return &identifiersFilter{
+		allowed: toIdentifiersSet(allowlist),
+		denied:  toIdentifiersSet(denylist),
+	}
+}
+
+func toIdentifiersSet(identifiers []string) map[string]struct{} {
+	identifiersSet := make(map[string]struct{}, len(identifiers))
+	for _, identifier := range identifiers {
+		identifiersSet[identifier] = struct{}{}
+	}
+
+	return identifiersSet
+}
+
+func (f *identifiersFilter) isAllowed(identifier string) bool {
+	_, isDenied := f.denied[identifier]
+	if isDenied {
+		return false
+	}
+	if len(f.allowed) == 0 {
+		return true
+	}
+
+	_, isAllowed := f.allowed[identifier]
+	return isAllowed
+}
+
+// filterEvents returns the allowed events, in the block order
+func (f *identifiersFilter) filterEvents(events []data.Event) []data.Event {
+	filteredEvents := make([]data.Event, 0, len(events))
+	for _, event := range events {
+		if f.isAllowed(event.Identifier) {
+			filteredEvents = append(filteredEvents, event)
+		}
+	}
+
+	return filteredEvents
+}
@@ -1,18 +1,22 @@
 package rabbitmq
 
 import (
+	"context"
+
 	"github.com/multiversx/mx-chain-notifier-go/data"
 	"github.com/streadway/amqp"
 )
 
 // RabbitMqClient defines the behaviour of a rabbitMq client
 type RabbitMqClient interface {
-	Publish(exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error
-	ExchangeDeclare(name, kind string) error
+	Publish(ctx context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error
+	ExchangeDeclare(name, kind string, durable bool) error
+	ExchangeDeclarePassive(name, kind string, durable bool) error
 	ConnErrChan() chan *amqp.Error
 	CloseErrChan() chan *amqp.Error
 	Reconnect()
 	ReopenChannel()
+	IsConnected() bool
 	Close()
 	IsInterfaceNil() bool
 }
@@ -24,9 +28,34 @@ type PublisherService interface {
 	Broadcast(events data.BlockEvents)
 	BroadcastRevert(event data.RevertBlock)
 	BroadcastFinalized(event data.FinalizedBlock)
+	BroadcastWithContext(ctx context.Context, events data.BlockEvents) error
+	BroadcastRevertWithContext(ctx context.Context, event data.RevertBlock) error
+	BroadcastFinalizedWithContext(ctx context.Context, event data.FinalizedBlock) error
 	BroadcastTxs(event data.BlockTxs)
 	BroadcastScrs(event data.BlockScrs)
 	BroadcastBlockEventsWithOrder(event data.BlockEventsWithOrder)
+	BroadcastTxEvents(event data.BlockTxEvents)
+	BroadcastRounds(event data.RoundEvents)
+	BroadcastValidatorsRating(event data.ValidatorsRatingEvent)
+	BroadcastAccounts(event data.AccountsEvents)
+	BroadcastTxsWithContext(ctx context.Context, event data.BlockTxs) error
+	BroadcastScrsWithContext(ctx context.Context, event data.BlockScrs) error
+	BroadcastBlockEventsWithOrderWithContext(ctx context.Context, event data.BlockEventsWithOrder) error
+	BroadcastTxEventsWithContext(ctx context.Context, event data.BlockTxEvents) error
+	BroadcastRoundsWithContext(ctx context.Context, event data.RoundEvents) error
+	BroadcastValidatorsRatingWithContext(ctx context.Context, event data.ValidatorsRatingEvent) error
+	BroadcastAccountsWithContext(ctx context.Context, event data.AccountsEvents) error
+	GetHealthState() string
+	Ping(ctx context.Context) error
 	Close() error
 	IsInterfaceNil() bool
 }
+
+// ConsumerRegistry defines the behaviour of a component which holds the subscriptions of
+// the rabbitMQ consumers and matches the logs and events published for each of them
+type ConsumerRegistry interface {
+	Register(subscription data.ConsumerSubscription) error
+	Unregister(consumerID string) error
+	MatchEvents(events []data.Event) ([]string, map[string][]data.Event)
+	IsInterfaceNil() bool
+}
@@ -1,32 +1,141 @@
 package rabbitmq
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
 	"github.com/multiversx/mx-chain-core-go/core/check"
 	"github.com/multiversx/mx-chain-core-go/marshal"
 	logger "github.com/multiversx/mx-chain-logger-go"
 	"github.com/multiversx/mx-chain-notifier-go/common"
+	"github.com/multiversx/mx-chain-notifier-go/compress"
 	"github.com/multiversx/mx-chain-notifier-go/config"
 	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/data/payload"
+	"github.com/multiversx/mx-chain-notifier-go/metrics"
 	"github.com/streadway/amqp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
 	emptyStr = ""
+
+	fanoutExchangeType = "fanout"
+	directExchangeType = "direct"
+	topicExchangeType  = "topic"
+
+	publishSuccessPromMetric  = "rabbitmq_publish_success"
+	publishFailuresPromMetric = "rabbitmq_publish_failures"
+	droppedEventsPromMetric   = "rabbitmq_dropped_events"
+	bufferedEventsPromMetric  = "rabbitmq_buffered_events"
+	exchangePromLabel         = "exchange"
+
+	hashHeader          = "hash"
+	shardIDHeader       = "shard_id"
+	nonceHeader         = "nonce"
+	schemaVersionHeader = "schema_version"
+	batchSizeHeader     = "batch_size"
+	correlationIDHeader = "correlation_id"
+	chunkIndexHeader    = "chunk_index"
+	chunkTotalHeader    = "chunk_total"
+
+	maxPublishRetryInterval = 10 * time.Second
+
+	perBlockPublishMode = "per-block"
+	perEventPublishMode = "per-event"
 )
 
+// PayloadSchemaVersion defines the version of the published payloads structure, sent
+// in the schema_version message header. It should be increased on breaking changes
+const PayloadSchemaVersion = 1
+
 var log = logger.GetOrCreate("rabbitmq")
 
+// traceContextPropagator injects the trace context of the received payload in the
+// message headers, using the W3C traceparent and tracestate headers
+var traceContextPropagator = propagation.TraceContext{}
+
 // ArgsRabbitMqPublisher defines the arguments needed for rabbitmq publisher creation
 type ArgsRabbitMqPublisher struct {
-	Client     RabbitMqClient
-	Config     config.RabbitMQConfig
-	Marshaller marshal.Marshalizer
+	Client           RabbitMqClient
+	Config           config.RabbitMQConfig
+	Marshaller       marshal.Marshalizer
+	MetricsCollector common.MetricsCollector
+
+	// ConsumerRegistry holds the consumer subscriptions, it is needed only if the consumer
+	// events exchange is configured
+	ConsumerRegistry ConsumerRegistry
+
+	// ContentType is set on each published message, if not empty
+	ContentType string
+
+	// AckAfterPublish returns the publish errors instead of buffering the events while
+	// disconnected, since a buffered event is not yet published. Batching is not supported
+	AckAfterPublish bool
 }
 
 type rabbitMqPublisher struct {
-	client     RabbitMqClient
-	marshaller marshal.Marshalizer
-	cfg        config.RabbitMQConfig
+	client           RabbitMqClient
+	marshaller       marshal.Marshalizer
+	metricsCollector common.MetricsCollector
+	consumerRegistry ConsumerRegistry
+	cfg              config.RabbitMQConfig
+	retryInterval    time.Duration
+	contentType      string
+	deliveryMode     uint8
+	publishPerEvent  bool
+	ackAfterPublish  bool
+
+	// eventsRoutingKeyBuilder is set only if a routing key template is configured for the events exchange
+	eventsRoutingKeyBuilder *routingKeyBuilder
+
+	// batcher is set only if batching is enabled
+	batcher *eventsBatcher
+
+	circuitBreaker *circuitBreaker
+
+	// identifiersFilter is set only if an identifiers allowlist or denylist is configured
+	identifiersFilter *identifiersFilter
+
+	// mutPublish serializes publishing, so that buffered events are flushed in order
+	mutPublish sync.Mutex
+	buffer     []*bufferedEvent
+
+	// publishErrorHandler is set only if the caller should be notified on publish failures
+	mutPublishErrorHandler sync.RWMutex
+	publishErrorHandler    func(err error)
+
+	mutMetrics         sync.RWMutex
+	numPublishSuccess  map[string]uint64
+	numPublishFailures map[string]uint64
+	numDroppedEvents   map[string]uint64
+	numBufferedEvents  uint64
+}
+
+// messageInfo holds the block details which are sent as message headers
+type messageInfo struct {
+	hash        string
+	shardID     *uint32
+	nonce       *uint64
+	batchSize   *uint32
+	chunk       *data.EventsChunk
+	spanContext trace.SpanContext
+
+	// correlationID identifies the event in the log lines, it is sent as header if set
+	correlationID string
+}
+
+// bufferedEvent holds an event received while disconnected from the rabbitMQ server
+type bufferedEvent struct {
+	exchangeName string
+	routingKey   string
+	info         messageInfo
+	payload      []byte
 }
 
 // NewRabbitMqPublisher creates a new rabbitMQ publisher instance
@@ -37,9 +146,39 @@ func NewRabbitMqPublisher(args ArgsRabbitMqPublisher) (*rabbitMqPublisher, error
 	}
 
 	rp := &rabbitMqPublisher{
-		cfg:        args.Config,
-		client:     args.Client,
-		marshaller: args.Marshaller,
+		cfg:                args.Config,
+		client:             args.Client,
+		marshaller:         args.Marshaller,
+		metricsCollector:   args.MetricsCollector,
+		consumerRegistry:   args.ConsumerRegistry,
+		retryInterval:      time.Duration(args.Config.PublishRetryIntervalInMs) * time.Millisecond,
+		contentType:        args.ContentType,
+		deliveryMode:       amqp.Transient,
+		ackAfterPublish:    args.AckAfterPublish,
+		numPublishSuccess:  make(map[string]uint64),
+		numPublishFailures: make(map[string]uint64),
+		numDroppedEvents:   make(map[string]uint64),
+		buffer:             make([]*bufferedEvent, 0),
+	}
+
+	if args.Config.PersistentMessages {
+		rp.deliveryMode = amqp.Persistent
+	}
+	rp.publishPerEvent = args.Config.PublishMode == perEventPublishMode
+	rp.circuitBreaker = newCircuitBreaker(
+		args.Config.CircuitBreaker.FailureThreshold,
+		time.Duration(args.Config.CircuitBreaker.OpenDurationInSec)*time.Second,
+	)
+
+	if args.Config.EventsExchange.RoutingKeyTemplate != "" {
+		rp.eventsRoutingKeyBuilder = newRoutingKeyBuilder(args.Config.EventsExchange.RoutingKeyTemplate)
+	}
+	if len(args.Config.IdentifiersAllowlist) > 0 || len(args.Config.IdentifiersDenylist) > 0 {
+		rp.identifiersFilter = newIdentifiersFilter(args.Config.IdentifiersAllowlist, args.Config.IdentifiersDenylist)
+	}
+	if args.Config.Batching.Enabled {
+		window := time.Duration(args.Config.Batching.WindowInMs) * time.Millisecond
+		rp.batcher = newEventsBatcher(args.Config.Batching.MaxBlocks, window, rp.publishBatch)
 	}
 
 	err = rp.createExchanges()
@@ -57,6 +196,18 @@ func checkArgs(args ArgsRabbitMqPublisher) error {
 	if check.IfNil(args.Marshaller) {
 		return common.ErrNilMarshaller
 	}
+	if check.IfNil(args.MetricsCollector) {
+		return common.ErrNilMetricsCollector
+	}
+	if args.Config.PublishMaxAttempts == 0 {
+		return ErrInvalidPublishMaxAttempts
+	}
+	if !isSupportedPublishMode(args.Config.PublishMode) {
+		return fmt.Errorf("%w: %s", ErrInvalidPublishMode, args.Config.PublishMode)
+	}
+	if args.Config.CompressionAlgorithm != "" && !compress.IsSupportedAlgorithm(args.Config.CompressionAlgorithm) {
+		return fmt.Errorf("%w: %s", compress.ErrUnsupportedAlgorithm, args.Config.CompressionAlgorithm)
+	}
 
 	if args.Config.EventsExchange.Name == "" {
 		return ErrInvalidRabbitMqExchangeName
@@ -95,148 +246,970 @@ func checkArgs(args ArgsRabbitMqPublisher) error {
 		return ErrInvalidRabbitMqExchangeType
 	}
 
+	for _, exchange := range getConfiguredExchanges(args.Config) {
+		if !isSupportedExchangeType(exchange.Type) {
+			return fmt.Errorf("%w: %s for exchange %s", ErrInvalidRabbitMqExchangeType, exchange.Type, exchange.Name)
+		}
+	}
+	if args.Config.EventsExchange.RoutingKeyTemplate != "" && args.Config.EventsExchange.Type == fanoutExchangeType {
+		return ErrRoutingKeyTemplateOnFanout
+	}
+	if args.Config.ConsumerEventsExchange.Name != "" {
+		if check.IfNil(args.ConsumerRegistry) {
+			return ErrNilConsumerRegistry
+		}
+		if args.Config.ConsumerEventsExchange.Type == fanoutExchangeType {
+			return ErrConsumerEventsExchangeOnFanout
+		}
+	}
+
+	if args.Config.CircuitBreaker.FailureThreshold > 0 && args.Config.CircuitBreaker.OpenDurationInSec == 0 {
+		return fmt.Errorf("%w: open duration has to be set", ErrInvalidCircuitBreakerConfig)
+	}
+
+	return checkBatchingConfig(args)
+}
+
+// checkBatchingConfig checks that the batching has a flush trigger and that the batches
+// can be published with the configured publish mode and marshaller
+func checkBatchingConfig(args ArgsRabbitMqPublisher) error {
+	if !args.Config.Batching.Enabled {
+		return nil
+	}
+	if args.Config.Batching.MaxBlocks == 0 && args.Config.Batching.WindowInMs == 0 {
+		return fmt.Errorf("%w: either max blocks or window has to be set", ErrInvalidBatchingConfig)
+	}
+	if args.Config.PublishMode == perEventPublishMode {
+		return fmt.Errorf("%w: not supported in %s publish mode", ErrInvalidBatchingConfig, perEventPublishMode)
+	}
+	if args.Config.EventsExchange.RoutingKeyTemplate != "" {
+		return fmt.Errorf("%w: not supported with a routing key template", ErrInvalidBatchingConfig)
+	}
+	if args.Config.MaxEventsPerMessage > 0 {
+		return fmt.Errorf("%w: not supported with max events per message", ErrInvalidBatchingConfig)
+	}
+	if args.AckAfterPublish {
+		return fmt.Errorf("%w: not supported with ack after publish", ErrInvalidBatchingConfig)
+	}
+
+	_, err := args.Marshaller.Marshal(data.BlockEventsBatch{})
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidBatchingConfig, err.Error())
+	}
+
 	return nil
 }
 
-// checkAndCreateExchanges creates exchanges if they are not existing already
+func getConfiguredExchanges(cfg config.RabbitMQConfig) []config.RabbitMQExchangeConfig {
+	exchanges := []config.RabbitMQExchangeConfig{
+		cfg.EventsExchange,
+		cfg.RevertEventsExchange,
+		cfg.FinalizedEventsExchange,
+		cfg.BlockTxsExchange,
+		cfg.BlockScrsExchange,
+		cfg.BlockEventsExchange,
+	}
+
+	// tx events exchange is optional, for backwards compatibility with older configs
+	if cfg.TxEventsExchange.Name != "" {
+		exchanges = append(exchanges, cfg.TxEventsExchange)
+	}
+	if cfg.RoundsExchange.Name != "" {
+		exchanges = append(exchanges, cfg.RoundsExchange)
+	}
+	if cfg.ValidatorsRatingExchange.Name != "" {
+		exchanges = append(exchanges, cfg.ValidatorsRatingExchange)
+	}
+	if cfg.AccountsExchange.Name != "" {
+		exchanges = append(exchanges, cfg.AccountsExchange)
+	}
+	if cfg.CrossShardEventsExchange.Name != "" {
+		exchanges = append(exchanges, cfg.CrossShardEventsExchange)
+	}
+	if cfg.ConsumerEventsExchange.Name != "" {
+		exchanges = append(exchanges, cfg.ConsumerEventsExchange)
+	}
+	if cfg.DeadLetterExchange.Name != "" {
+		exchanges = append(exchanges, cfg.DeadLetterExchange)
+	}
+
+	return exchanges
+}
+
+func isSupportedExchangeType(exchangeType string) bool {
+	switch exchangeType {
+	case fanoutExchangeType, directExchangeType, topicExchangeType:
+		return true
+	default:
+		return false
+	}
+}
+
+// isSupportedPublishMode returns true for the known publish modes; an empty publish
+// mode defaults to per block, for backwards compatibility with older configs
+func isSupportedPublishMode(publishMode string) bool {
+	switch publishMode {
+	case emptyStr, perBlockPublishMode, perEventPublishMode:
+		return true
+	default:
+		return false
+	}
+}
+
+// createExchanges declares the configured exchanges, or only checks that they
+// exist if the exchange declare is skipped
 func (rp *rabbitMqPublisher) createExchanges() error {
-	err := rp.createExchange(rp.cfg.EventsExchange)
-	if err != nil {
-		return err
+	for _, exchange := range getConfiguredExchanges(rp.cfg) {
+		err := rp.createExchange(exchange)
+		if err != nil {
+			return err
+		}
 	}
-	err = rp.createExchange(rp.cfg.RevertEventsExchange)
-	if err != nil {
-		return err
+
+	return nil
+}
+
+func (rp *rabbitMqPublisher) createExchange(conf config.RabbitMQExchangeConfig) error {
+	declare := rp.client.ExchangeDeclare
+	if rp.cfg.SkipExchangeDeclare {
+		declare = rp.client.ExchangeDeclarePassive
 	}
-	err = rp.createExchange(rp.cfg.FinalizedEventsExchange)
+
+	err := declare(conf.Name, conf.Type, conf.Durable)
 	if err != nil {
-		return err
+		return fmt.Errorf("%w, exchange %s, type %s, durable %v", err, conf.Name, conf.Type, conf.Durable)
 	}
-	err = rp.createExchange(rp.cfg.BlockTxsExchange)
-	if err != nil {
-		return err
+
+	log.Info("checked and declared rabbitMQ exchange",
+		"name", conf.Name,
+		"type", conf.Type,
+		"durable", conf.Durable,
+		"passive", rp.cfg.SkipExchangeDeclare,
+	)
+
+	return nil
+}
+
+// Publish will publish logs and events to rabbitmq. The events are filtered by identifier
+// first, if configured, and the blocks left without events are skipped, if configured.
+// The events are published to all the configured exchanges, even if one of them fails,
+// and the first error is returned
+func (rp *rabbitMqPublisher) Publish(ctx context.Context, events data.BlockEvents) error {
+	if rp.identifiersFilter != nil {
+		events.Events = rp.identifiersFilter.filterEvents(events.Events)
 	}
-	err = rp.createExchange(rp.cfg.BlockScrsExchange)
-	if err != nil {
-		return err
+	if rp.cfg.SkipEmptyBlocks && len(events.Events) == 0 {
+		log.Trace("skipped publishing block without events", "hash", events.Hash, "correlation id", events.CorrelationID)
+		return nil
 	}
-	err = rp.createExchange(rp.cfg.BlockEventsExchange)
-	if err != nil {
-		return err
+
+	err := rp.publishToEventsExchange(ctx, events)
+	errCrossShard := rp.publishCrossShardEvents(ctx, events)
+	errConsumer := rp.publishConsumerEvents(ctx, events)
+
+	return getFirstError(err, errCrossShard, errConsumer)
+}
+
+func getFirstError(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
-func (rp *rabbitMqPublisher) createExchange(conf config.RabbitMQExchangeConfig) error {
-	err := rp.client.ExchangeDeclare(conf.Name, conf.Type)
+func (rp *rabbitMqPublisher) publishToEventsExchange(ctx context.Context, events data.BlockEvents) error {
+	if rp.publishPerEvent {
+		return rp.publishEachEvent(ctx, events)
+	}
+	if rp.eventsRoutingKeyBuilder != nil {
+		return rp.publishWithRoutingKeys(ctx, events)
+	}
+	if rp.batcher != nil {
+		rp.batcher.add(ctx, events)
+		return nil
+	}
+
+	err := rp.publishBlockEvents(ctx, rp.cfg.EventsExchange.Name, emptyStr, newEventsMessageInfo(events), events)
 	if err != nil {
-		return err
+		log.Error("failed to publish events to rabbitMQ", "hash", events.Hash, "correlation id", events.CorrelationID, "err", err.Error())
 	}
 
-	log.Info("checked and declared rabbitMQ exchange", "name", conf.Name, "type", conf.Type)
+	return err
+}
+
+// publishBlockEvents publishes the logs and events of the block as one message or, if the
+// block has more than the max events per message, as multiple messages, in order
+func (rp *rabbitMqPublisher) publishBlockEvents(ctx context.Context, exchangeName string, routingKey string, info messageInfo, events data.BlockEvents) error {
+	for _, chunk := range rp.splitEventsInChunks(events) {
+		eventsBytes, err := rp.marshaller.Marshal(chunk)
+		if err != nil {
+			err = fmt.Errorf("could not marshal events: %w", err)
+			rp.notifyPublishError(exchangeName, events.Hash, err)
+			return err
+		}
+
+		chunkInfo := info
+		chunkInfo.chunk = chunk.Chunk
+		err = rp.publishToExchange(ctx, exchangeName, routingKey, chunkInfo, eventsBytes)
+		if err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
 
-// Publish will publish logs and events to rabbitmq
-func (rp *rabbitMqPublisher) Publish(events data.BlockEvents) {
-	eventsBytes, err := rp.marshaller.Marshal(events)
+func (rp *rabbitMqPublisher) splitEventsInChunks(events data.BlockEvents) []data.BlockEvents {
+	maxEvents := int(rp.cfg.MaxEventsPerMessage)
+	if maxEvents == 0 || len(events.Events) <= maxEvents {
+		return []data.BlockEvents{events}
+	}
+
+	total := (len(events.Events) + maxEvents - 1) / maxEvents
+	chunks := make([]data.BlockEvents, 0, total)
+	for index := 0; index < total; index++ {
+		end := (index + 1) * maxEvents
+		if end > len(events.Events) {
+			end = len(events.Events)
+		}
+
+		chunk := events
+		chunk.Events = events.Events[index*maxEvents : end]
+		chunk.Chunk = &data.EventsChunk{
+			Index: uint32(index),
+			Total: uint32(total),
+		}
+		chunks = append(chunks, chunk)
+	}
+
+	return chunks
+}
+
+// publishBatch publishes the logs and events of the batched blocks as one message. The hash
+// header holds the comma separated hashes of the blocks and the batch size header the
+// number of blocks
+func (rp *rabbitMqPublisher) publishBatch(ctx context.Context, batch data.BlockEventsBatch) {
+	batchBytes, err := rp.marshaller.Marshal(batch)
 	if err != nil {
-		log.Error("could not marshal events", "err", err.Error())
+		log.Error("could not marshal events batch", "err", err.Error())
+		rp.notifyPublishError(rp.cfg.EventsExchange.Name, strings.Join(batch.Hashes, ","), err)
 		return
 	}
 
-	err = rp.publishFanout(rp.cfg.EventsExchange.Name, eventsBytes)
+	batchSize := uint32(len(batch.Blocks))
+	info := messageInfo{
+		hash:      strings.Join(batch.Hashes, ","),
+		batchSize: &batchSize,
+	}
+	err = rp.publishToExchange(ctx, rp.cfg.EventsExchange.Name, emptyStr, info, batchBytes)
+	if err != nil {
+		log.Error("failed to publish events batch to rabbitMQ", "hashes", info.hash, "err", err.Error())
+	}
+}
+
+// publishCrossShardEvents publishes the events of the cross shard transactions completed in
+// the block, as one message, if the cross shard events exchange is configured. The events
+// exchange still receives all the events
+func (rp *rabbitMqPublisher) publishCrossShardEvents(ctx context.Context, events data.BlockEvents) error {
+	if rp.cfg.CrossShardEventsExchange.Name == "" {
+		return nil
+	}
+
+	crossShardEvents := make([]data.Event, 0)
+	for _, event := range events.Events {
+		if event.CrossShard {
+			crossShardEvents = append(crossShardEvents, event)
+		}
+	}
+	if len(crossShardEvents) == 0 {
+		return nil
+	}
+
+	blockEvents := events
+	blockEvents.Events = crossShardEvents
+	err := rp.publishBlockEvents(ctx, rp.cfg.CrossShardEventsExchange.Name, emptyStr, newEventsMessageInfo(events), blockEvents)
 	if err != nil {
-		log.Error("failed to publish events to rabbitMQ", "err", err.Error())
+		log.Error("failed to publish cross shard events to rabbitMQ", "hash", events.Hash, "correlation id", events.CorrelationID, "err", err.Error())
 	}
+
+	return err
+}
+
+// publishConsumerEvents publishes, for each registered consumer, the events matched by its
+// subscriptions as one message, with the consumer id as routing key, if the consumer events
+// exchange is configured. The consumers without matched events get no message
+func (rp *rabbitMqPublisher) publishConsumerEvents(ctx context.Context, events data.BlockEvents) error {
+	if rp.cfg.ConsumerEventsExchange.Name == "" {
+		return nil
+	}
+
+	var firstErr error
+	consumerIDs, matchedEvents := rp.consumerRegistry.MatchEvents(events.Events)
+	for _, consumerID := range consumerIDs {
+		consumerEvents := events
+		consumerEvents.Events = matchedEvents[consumerID]
+		err := rp.publishBlockEvents(ctx, rp.cfg.ConsumerEventsExchange.Name, consumerID, newEventsMessageInfo(events), consumerEvents)
+		if err != nil {
+			log.Error("failed to publish consumer events to rabbitMQ",
+				"hash", events.Hash,
+				"consumerID", consumerID,
+				"correlation id", events.CorrelationID,
+				"err", err.Error(),
+			)
+			firstErr = getFirstError(firstErr, err)
+		}
+	}
+
+	return firstErr
+}
+
+// publishWithRoutingKeys publishes the events grouped by routing key, one message for each group
+func (rp *rabbitMqPublisher) publishWithRoutingKeys(ctx context.Context, events data.BlockEvents) error {
+	routingKeys, groups := rp.eventsRoutingKeyBuilder.groupEventsByRoutingKey(events)
+
+	var firstErr error
+	for _, routingKey := range routingKeys {
+		err := rp.publishBlockEvents(ctx, rp.cfg.EventsExchange.Name, routingKey, newEventsMessageInfo(events), groups[routingKey])
+		if err != nil {
+			log.Error("failed to publish events to rabbitMQ",
+				"hash", events.Hash,
+				"routing key", routingKey,
+				"correlation id", events.CorrelationID,
+				"err", err.Error(),
+			)
+			firstErr = getFirstError(firstErr, err)
+		}
+	}
+
+	return firstErr
+}
+
+// publishEachEvent publishes each event as a separate message, in the block order. The
+// routing key is the event address, or the one built from the routing key template, if
+// configured. An event that could not be published while disconnected is buffered together
+// with the next events of the block, so the block is resumed from the failed event
+func (rp *rabbitMqPublisher) publishEachEvent(ctx context.Context, events data.BlockEvents) error {
+	info := newEventsMessageInfo(events)
+
+	var firstErr error
+	for index, event := range events.Events {
+		eventBytes, err := rp.marshaller.Marshal(data.BlockEvent{
+			Hash:      events.Hash,
+			ShardID:   events.ShardID,
+			TimeStamp: events.TimeStamp,
+			Index:     uint32(index),
+			Event:     event,
+		})
+		if err != nil {
+			log.Error("could not marshal event", "err", err.Error())
+			rp.notifyPublishError(rp.cfg.EventsExchange.Name, events.Hash, err)
+			firstErr = getFirstError(firstErr, err)
+			continue
+		}
+
+		routingKey := rp.getEventRoutingKey(events.ShardID, event)
+		err = rp.publishToExchange(ctx, rp.cfg.EventsExchange.Name, routingKey, info, eventBytes)
+		if err != nil {
+			log.Error("failed to publish event to rabbitMQ",
+				"hash", events.Hash,
+				"index", index,
+				"routing key", routingKey,
+				"correlation id", events.CorrelationID,
+				"err", err.Error(),
+			)
+			firstErr = getFirstError(firstErr, err)
+		}
+	}
+
+	return firstErr
+}
+
+func (rp *rabbitMqPublisher) getEventRoutingKey(shardID uint32, event data.Event) string {
+	if rp.eventsRoutingKeyBuilder != nil {
+		return rp.eventsRoutingKeyBuilder.buildRoutingKey(shardID, event)
+	}
+
+	return sanitizeRoutingKeyWord(event.Address)
 }
 
 // PublishRevert will publish revert event to rabbitmq
-func (rp *rabbitMqPublisher) PublishRevert(revertBlock data.RevertBlock) {
+// The pending events batch is published first, so the revert follows the reverted block
+func (rp *rabbitMqPublisher) PublishRevert(ctx context.Context, revertBlock data.RevertBlock) error {
+	rp.flushPendingBatch(ctx)
+
 	revertBlockBytes, err := rp.marshaller.Marshal(revertBlock)
 	if err != nil {
 		log.Error("could not marshal revert event", "err", err.Error())
-		return
+		rp.notifyPublishError(rp.cfg.RevertEventsExchange.Name, revertBlock.Hash, err)
+		return err
 	}
 
-	err = rp.publishFanout(rp.cfg.RevertEventsExchange.Name, revertBlockBytes)
+	info := messageInfo{
+		hash:          revertBlock.Hash,
+		nonce:         &revertBlock.Nonce,
+		spanContext:   revertBlock.SpanContext,
+		correlationID: revertBlock.CorrelationID,
+	}
+	err = rp.publishToExchange(ctx, rp.cfg.RevertEventsExchange.Name, emptyStr, info, revertBlockBytes)
 	if err != nil {
-		log.Error("failed to publish revert event to rabbitMQ", "err", err.Error())
+		log.Error("failed to publish revert event to rabbitMQ", "hash", revertBlock.Hash, "correlation id", revertBlock.CorrelationID, "err", err.Error())
 	}
+
+	return err
 }
 
 // PublishFinalized will publish finalized event to rabbitmq
-func (rp *rabbitMqPublisher) PublishFinalized(finalizedBlock data.FinalizedBlock) {
+// The pending events batch is published first, so the finalized event follows the block
+func (rp *rabbitMqPublisher) PublishFinalized(ctx context.Context, finalizedBlock data.FinalizedBlock) error {
+	rp.flushPendingBatch(ctx)
+
 	finalizedBlockBytes, err := rp.marshaller.Marshal(finalizedBlock)
 	if err != nil {
 		log.Error("could not marshal finalized event", "err", err.Error())
-		return
+		rp.notifyPublishError(rp.cfg.FinalizedEventsExchange.Name, finalizedBlock.Hash, err)
+		return err
 	}
 
-	err = rp.publishFanout(rp.cfg.FinalizedEventsExchange.Name, finalizedBlockBytes)
+	info := messageInfo{
+		hash:          finalizedBlock.Hash,
+		spanContext:   finalizedBlock.SpanContext,
+		correlationID: finalizedBlock.CorrelationID,
+	}
+	err = rp.publishToExchange(ctx, rp.cfg.FinalizedEventsExchange.Name, emptyStr, info, finalizedBlockBytes)
 	if err != nil {
-		log.Error("failed to publish finalized event to rabbitMQ", "err", err.Error())
+		log.Error("failed to publish finalized event to rabbitMQ", "hash", finalizedBlock.Hash, "correlation id", finalizedBlock.CorrelationID, "err", err.Error())
+	}
+
+	return err
+}
+
+func (rp *rabbitMqPublisher) flushPendingBatch(ctx context.Context) {
+	if rp.batcher != nil {
+		rp.batcher.flushPending(ctx)
 	}
 }
 
 // PublishTxs will publish txs event to rabbitmq
-func (rp *rabbitMqPublisher) PublishTxs(blockTxs data.BlockTxs) {
+func (rp *rabbitMqPublisher) PublishTxs(ctx context.Context, blockTxs data.BlockTxs) error {
 	txsBlockBytes, err := rp.marshaller.Marshal(blockTxs)
 	if err != nil {
-		log.Error("could not marshal block txs event", "err", err.Error())
-		return
+		return rp.handleMarshalError("could not marshal block txs event", rp.cfg.BlockTxsExchange.Name, blockTxs.Hash, err)
 	}
 
-	err = rp.publishFanout(rp.cfg.BlockTxsExchange.Name, txsBlockBytes)
+	err = rp.publishToExchange(ctx, rp.cfg.BlockTxsExchange.Name, emptyStr, messageInfo{hash: blockTxs.Hash}, txsBlockBytes)
 	if err != nil {
-		log.Error("failed to publish block txs event to rabbitMQ", "err", err.Error())
+		log.Error("failed to publish block txs event to rabbitMQ", "hash", blockTxs.Hash, "err", err.Error())
 	}
+
+	return err
 }
 
 // PublishScrs will publish scrs event to rabbitmq
-func (rp *rabbitMqPublisher) PublishScrs(blockScrs data.BlockScrs) {
+func (rp *rabbitMqPublisher) PublishScrs(ctx context.Context, blockScrs data.BlockScrs) error {
 	scrsBlockBytes, err := rp.marshaller.Marshal(blockScrs)
 	if err != nil {
-		log.Error("could not marshal block scrs event", "err", err.Error())
-		return
+		return rp.handleMarshalError("could not marshal block scrs event", rp.cfg.BlockScrsExchange.Name, blockScrs.Hash, err)
 	}
 
-	err = rp.publishFanout(rp.cfg.BlockScrsExchange.Name, scrsBlockBytes)
+	err = rp.publishToExchange(ctx, rp.cfg.BlockScrsExchange.Name, emptyStr, messageInfo{hash: blockScrs.Hash}, scrsBlockBytes)
 	if err != nil {
-		log.Error("failed to publish block scrs event to rabbitMQ", "err", err.Error())
+		log.Error("failed to publish block scrs event to rabbitMQ", "hash", blockScrs.Hash, "err", err.Error())
 	}
+
+	return err
 }
 
 // PublishBlockEventsWithOrder will publish block events with order to rabbitmq
-func (rp *rabbitMqPublisher) PublishBlockEventsWithOrder(blockTxs data.BlockEventsWithOrder) {
+func (rp *rabbitMqPublisher) PublishBlockEventsWithOrder(ctx context.Context, blockTxs data.BlockEventsWithOrder) error {
 	txsBlockBytes, err := rp.marshaller.Marshal(blockTxs)
 	if err != nil {
-		log.Error("could not marshal block txs event", "err", err.Error())
-		return
+		return rp.handleMarshalError("could not marshal full block events", rp.cfg.BlockEventsExchange.Name, blockTxs.Hash, err)
 	}
 
-	err = rp.publishFanout(rp.cfg.BlockEventsExchange.Name, txsBlockBytes)
+	err = rp.publishToExchange(ctx, rp.cfg.BlockEventsExchange.Name, emptyStr, newShardMessageInfo(blockTxs.Hash, blockTxs.ShardID), txsBlockBytes)
 	if err != nil {
-		log.Error("failed to publish full block events to rabbitMQ", "err", err.Error())
+		log.Error("failed to publish full block events to rabbitMQ", "hash", blockTxs.Hash, "err", err.Error())
 	}
+
+	return err
+}
+
+// PublishTxEvents will publish transaction notifications to rabbitmq, if the exchange is configured
+func (rp *rabbitMqPublisher) PublishTxEvents(ctx context.Context, blockTxEvents data.BlockTxEvents) error {
+	if rp.cfg.TxEventsExchange.Name == "" {
+		return nil
+	}
+
+	txEventsBytes, err := rp.marshaller.Marshal(blockTxEvents)
+	if err != nil {
+		return rp.handleMarshalError("could not marshal block tx events", rp.cfg.TxEventsExchange.Name, blockTxEvents.Hash, err)
+	}
+
+	err = rp.publishToExchange(ctx, rp.cfg.TxEventsExchange.Name, emptyStr, newShardMessageInfo(blockTxEvents.Hash, blockTxEvents.ShardID), txEventsBytes)
+	if err != nil {
+		log.Error("failed to publish block tx events to rabbitMQ", "hash", blockTxEvents.Hash, "err", err.Error())
+	}
+
+	return err
+}
+
+// PublishRounds will publish the rounds info to rabbitmq, if the exchange is configured
+func (rp *rabbitMqPublisher) PublishRounds(ctx context.Context, roundEvents data.RoundEvents) error {
+	if rp.cfg.RoundsExchange.Name == "" {
+		return nil
+	}
+
+	roundsBytes, err := rp.marshaller.Marshal(roundEvents)
+	if err != nil {
+		return rp.handleMarshalError("could not marshal rounds info", rp.cfg.RoundsExchange.Name, emptyStr, err)
+	}
+
+	info := newShardMessageInfo(emptyStr, roundEvents.ShardID)
+	info.spanContext = roundEvents.SpanContext
+	info.correlationID = roundEvents.CorrelationID
+
+	err = rp.publishToExchange(ctx, rp.cfg.RoundsExchange.Name, emptyStr, info, roundsBytes)
+	if err != nil {
+		log.Error("failed to publish rounds info to rabbitMQ", "shard id", roundEvents.ShardID, "err", err.Error())
+	}
+
+	return err
+}
+
+// PublishValidatorsRating will publish the validators rating to rabbitmq, if the exchange is configured
+func (rp *rabbitMqPublisher) PublishValidatorsRating(ctx context.Context, validatorsRating data.ValidatorsRatingEvent) error {
+	if rp.cfg.ValidatorsRatingExchange.Name == "" {
+		return nil
+	}
+
+	validatorsRatingBytes, err := rp.marshaller.Marshal(validatorsRating)
+	if err != nil {
+		return rp.handleMarshalError("could not marshal validators rating", rp.cfg.ValidatorsRatingExchange.Name, emptyStr, err)
+	}
+
+	info := newShardMessageInfo(emptyStr, validatorsRating.ShardID)
+	info.spanContext = validatorsRating.SpanContext
+	info.correlationID = validatorsRating.CorrelationID
+
+	err = rp.publishToExchange(ctx, rp.cfg.ValidatorsRatingExchange.Name, emptyStr, info, validatorsRatingBytes)
+	if err != nil {
+		log.Error("failed to publish validators rating to rabbitMQ", "shard id", validatorsRating.ShardID, "epoch", validatorsRating.Epoch, "err", err.Error())
+	}
+
+	return err
+}
+
+// PublishAccounts will publish the altered accounts to rabbitmq, if the exchange is configured
+func (rp *rabbitMqPublisher) PublishAccounts(ctx context.Context, accountsEvents data.AccountsEvents) error {
+	if rp.cfg.AccountsExchange.Name == "" {
+		return nil
+	}
+
+	accountsBytes, err := rp.marshaller.Marshal(accountsEvents)
+	if err != nil {
+		return rp.handleMarshalError("could not marshal accounts", rp.cfg.AccountsExchange.Name, emptyStr, err)
+	}
+
+	info := newShardMessageInfo(emptyStr, accountsEvents.ShardID)
+	info.spanContext = accountsEvents.SpanContext
+	info.correlationID = accountsEvents.CorrelationID
+
+	err = rp.publishToExchange(ctx, rp.cfg.AccountsExchange.Name, emptyStr, info, accountsBytes)
+	if err != nil {
+		log.Error("failed to publish accounts to rabbitMQ", "shard id", accountsEvents.ShardID, "num accounts", len(accountsEvents.Accounts), "err", err.Error())
+	}
+
+	return err
+}
+
+// handleMarshalError logs, notifies and returns the marshal errors. The payload types without
+// a protobuf message are not published when the protobuf marshaller is used, so they are
+// only traced
+func (rp *rabbitMqPublisher) handleMarshalError(message string, exchangeName string, hash string, err error) error {
+	if errors.Is(err, payload.ErrUnsupportedPayloadType) {
+		log.Trace(message, "err", err.Error())
+		return nil
+	}
+
+	log.Error(message, "err", err.Error())
+	rp.notifyPublishError(exchangeName, hash, err)
+
+	return err
+}
+
+// publishToExchange publishes the payload to the exchange, with the provided routing key. While disconnected from the rabbitMQ
+// server, the events are buffered and they are published in the same order after the
+// connection is recovered, before any new event, unless the ack is sent after publish, in
+// which case the connection failure is returned
+func (rp *rabbitMqPublisher) publishToExchange(ctx context.Context, exchangeName string, routingKey string, info messageInfo, payload []byte) error {
+	rp.mutPublish.Lock()
+	defer rp.mutPublish.Unlock()
+
+	rp.flushBuffer(ctx)
+
+	event := &bufferedEvent{
+		exchangeName: exchangeName,
+		routingKey:   routingKey,
+		info:         info,
+		payload:      payload,
+	}
+
+	err := rp.publishOrBufferEvent(ctx, event)
+	if err != nil {
+		rp.notifyPublishError(exchangeName, info.hash, err)
+	}
+
+	return err
+}
+
+func (rp *rabbitMqPublisher) publishOrBufferEvent(ctx context.Context, event *bufferedEvent) error {
+	if rp.ackAfterPublish && !rp.client.IsConnected() {
+		return ErrConnectionFailure
+	}
+	if len(rp.buffer) > 0 || !rp.client.IsConnected() {
+		return rp.bufferEvent(event)
+	}
+	if !rp.circuitBreaker.allow() {
+		rp.rejectEvent(event)
+		return ErrCircuitOpen
+	}
+
+	attempts, err := rp.publishWithRetries(ctx, event.exchangeName, event.routingKey, event.info, event.payload)
+	if err != nil && !rp.client.IsConnected() {
+		rp.circuitBreaker.ignoreResult()
+		if rp.ackAfterPublish {
+			return err
+		}
+		return rp.bufferEvent(event)
+	}
+	if err != nil && ctx.Err() != nil {
+		// the publish was aborted by the caller, the broker is not at fault
+		rp.circuitBreaker.ignoreResult()
+		rp.handleFailedEvent(event, attempts, err)
+		return err
+	}
+	rp.circuitBreaker.recordResult(err)
+	if err != nil {
+		rp.handleFailedEvent(event, attempts, err)
+	}
+
+	return err
+}
+
+// flushBuffer publishes the buffered events, in order, while connected, while the
+// publish circuit is not open and until the context is done
+func (rp *rabbitMqPublisher) flushBuffer(ctx context.Context) {
+	for len(rp.buffer) > 0 && rp.client.IsConnected() && ctx.Err() == nil {
+		event := rp.buffer[0]
+		if !rp.circuitBreaker.allow() {
+			return
+		}
+
+		attempts, err := rp.publishWithRetries(ctx, event.exchangeName, event.routingKey, event.info, event.payload)
+		if err != nil && (!rp.client.IsConnected() || ctx.Err() != nil) {
+			rp.circuitBreaker.ignoreResult()
+			return
+		}
+		rp.circuitBreaker.recordResult(err)
+		if err != nil {
+			log.Error("failed to publish buffered event to rabbitMQ",
+				"exchange", event.exchangeName,
+				"hash", event.info.hash,
+				"correlation id", event.info.correlationID,
+				"err", err.Error(),
+			)
+			rp.handleFailedEvent(event, attempts, err)
+			rp.notifyPublishError(event.exchangeName, event.info.hash, err)
+		}
+
+		rp.buffer = rp.buffer[1:]
+		rp.setNumBufferedEvents()
+	}
+}
+
+// SetPublishErrorHandler sets the handler notified with the events which could not be
+// published, either because they could not be marshalled or because the publish failed.
+// The events buffered while disconnected are notified only if they are dropped or if
+// their publish fails after the connection is recovered
+func (rp *rabbitMqPublisher) SetPublishErrorHandler(handler func(err error)) {
+	rp.mutPublishErrorHandler.Lock()
+	rp.publishErrorHandler = handler
+	rp.mutPublishErrorHandler.Unlock()
+}
+
+// notifyPublishError wraps the error with the exchange and the block hash, so that the
+// failed event can be identified by the caller
+func (rp *rabbitMqPublisher) notifyPublishError(exchangeName string, hash string, err error) {
+	rp.mutPublishErrorHandler.RLock()
+	handler := rp.publishErrorHandler
+	rp.mutPublishErrorHandler.RUnlock()
+
+	if handler == nil {
+		return
+	}
+
+	handler(fmt.Errorf("%w, exchange %s, hash %s", err, exchangeName, hash))
 }
 
-func (rp *rabbitMqPublisher) publishFanout(exchangeName string, payload []byte) error {
-	return rp.client.Publish(
-		exchangeName,
-		emptyStr,
-		true,  // mandatory
-		false, // immediate
-		amqp.Publishing{
-			Body: payload,
-		},
+// rejectEvent counts the event as dropped, since the publish circuit is open, and sends it
+// to the dead letter exchange or spill file, if configured
+func (rp *rabbitMqPublisher) rejectEvent(event *bufferedEvent) {
+	rp.recordDroppedEvent(event.exchangeName)
+
+	log.Debug("rabbitMQ publish circuit is open, event not published",
+		"exchange", event.exchangeName,
+		"hash", event.info.hash,
+		"correlation id", event.info.correlationID,
 	)
+
+	rp.handleFailedEvent(event, 0, ErrCircuitOpen)
+}
+
+func (rp *rabbitMqPublisher) recordDroppedEvent(exchangeName string) {
+	rp.mutMetrics.Lock()
+	rp.numDroppedEvents[exchangeName]++
+	rp.mutMetrics.Unlock()
+	rp.metricsCollector.AddRabbitMQPublish(exchangeName, metrics.PublishStatusDropped)
+}
+
+// bufferEvent adds the event to the buffer. If the buffer is full, the new event is
+// dropped, in order to keep the buffered events contiguous
+func (rp *rabbitMqPublisher) bufferEvent(event *bufferedEvent) error {
+	if uint32(len(rp.buffer)) >= rp.cfg.MaxBufferedEvents {
+		rp.recordDroppedEvent(event.exchangeName)
+
+		return ErrPublishBufferFull
+	}
+
+	rp.buffer = append(rp.buffer, event)
+	rp.setNumBufferedEvents()
+
+	log.Debug("rabbitMQ not connected, buffered event",
+		"exchange", event.exchangeName,
+		"hash", event.info.hash,
+		"correlation id", event.info.correlationID,
+		"num buffered", len(rp.buffer),
+	)
+
+	return nil
+}
+
+func (rp *rabbitMqPublisher) setNumBufferedEvents() {
+	rp.mutMetrics.Lock()
+	rp.numBufferedEvents = uint64(len(rp.buffer))
+	rp.mutMetrics.Unlock()
+	rp.metricsCollector.SetRabbitMQBufferedEvents(uint64(len(rp.buffer)))
+}
+
+// publishWithRetries publishes the payload, retrying with exponential backoff until the
+// broker acknowledges it, the max number of attempts is reached or the context is done.
+// Retries block the caller, so events published on the same exchange are not reordered.
+// It returns the number of attempts made
+func (rp *rabbitMqPublisher) publishWithRetries(ctx context.Context, exchangeName string, routingKey string, info messageInfo, payload []byte) (uint32, error) {
+	var err error
+	var attempt uint32
+	retryInterval := rp.retryInterval
+	publishing := rp.createPublishing(info, payload)
+	err = rp.compressPublishing(&publishing)
+	if err != nil {
+		rp.recordPublish(exchangeName, err)
+		return 0, err
+	}
+	startTime := time.Now()
+
+	for attempt = 1; attempt <= rp.cfg.PublishMaxAttempts; attempt++ {
+		err = rp.client.Publish(
+			ctx,
+			exchangeName,
+			routingKey,
+			true,  // mandatory
+			false, // immediate
+			publishing,
+		)
+		if err == nil {
+			break
+		}
+		if attempt == rp.cfg.PublishMaxAttempts || !rp.client.IsConnected() {
+			break
+		}
+
+		log.Warn("failed to publish to rabbitMQ, will retry",
+			"exchange", exchangeName,
+			"hash", info.hash,
+			"correlation id", info.correlationID,
+			"attempt", attempt,
+			"retry interval", retryInterval,
+			"err", err.Error(),
+		)
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(retryInterval):
+		}
+		if ctx.Err() != nil {
+			err = ctx.Err()
+			break
+		}
+		retryInterval = nextRetryInterval(retryInterval)
+	}
+
+	rp.metricsCollector.AddRabbitMQPublishDuration(exchangeName, time.Since(startTime))
+	rp.recordPublish(exchangeName, err)
+	if err == nil {
+		log.Debug("published event to rabbitMQ",
+			"exchange", exchangeName,
+			"hash", info.hash,
+			"correlation id", info.correlationID,
+		)
+	}
+
+	return attempt, err
+}
+
+// recordPublish updates the publish metrics of the exchange with the publish result
+func (rp *rabbitMqPublisher) recordPublish(exchangeName string, err error) {
+	rp.mutMetrics.Lock()
+	if err != nil {
+		rp.numPublishFailures[exchangeName]++
+	} else {
+		rp.numPublishSuccess[exchangeName]++
+	}
+	rp.mutMetrics.Unlock()
+
+	if err != nil {
+		rp.metricsCollector.AddRabbitMQPublish(exchangeName, metrics.PublishStatusFailure)
+	} else {
+		rp.metricsCollector.AddRabbitMQPublish(exchangeName, metrics.PublishStatusSuccess)
+	}
+}
+
+// createPublishing creates the message to be published. The block details are set as
+// headers, so consumers can route or filter messages without decoding the payload
+func (rp *rabbitMqPublisher) createPublishing(info messageInfo, payload []byte) amqp.Publishing {
+	headers := amqp.Table{
+		hashHeader:          info.hash,
+		schemaVersionHeader: int32(PayloadSchemaVersion),
+	}
+	if info.shardID != nil {
+		headers[shardIDHeader] = int64(*info.shardID)
+	}
+	if info.nonce != nil {
+		headers[nonceHeader] = int64(*info.nonce)
+	}
+	if info.batchSize != nil {
+		headers[batchSizeHeader] = int64(*info.batchSize)
+	}
+	if info.chunk != nil {
+		headers[chunkIndexHeader] = int64(info.chunk.Index)
+		headers[chunkTotalHeader] = int64(info.chunk.Total)
+	}
+	if info.correlationID != "" {
+		headers[correlationIDHeader] = info.correlationID
+	}
+	if info.spanContext.IsValid() {
+		ctx := trace.ContextWithSpanContext(context.Background(), info.spanContext)
+		traceContextPropagator.Inject(ctx, headersCarrier(headers))
+	}
+
+	return amqp.Publishing{
+		Headers:      headers,
+		ContentType:  rp.contentType,
+		DeliveryMode: rp.deliveryMode,
+		Body:         payload,
+	}
 }
 
-// Close will trigger to close rabbitmq client
+// compressPublishing compresses the message body with the configured algorithm, which is
+// set as the content encoding, so that the consumers can decompress it
+func (rp *rabbitMqPublisher) compressPublishing(publishing *amqp.Publishing) error {
+	if rp.cfg.CompressionAlgorithm == "" {
+		return nil
+	}
+
+	body, err := compress.Compress(publishing.Body, rp.cfg.CompressionAlgorithm)
+	if err != nil {
+		return err
+	}
+
+	publishing.Body = body
+	publishing.ContentEncoding = rp.cfg.CompressionAlgorithm
+
+	return nil
+}
+
+func newEventsMessageInfo(events data.BlockEvents) messageInfo {
+	info := newShardMessageInfo(events.Hash, events.ShardID)
+	info.spanContext = events.SpanContext
+	info.correlationID = events.CorrelationID
+
+	return info
+}
+
+func newShardMessageInfo(hash string, shardID uint32) messageInfo {
+	return messageInfo{
+		hash:    hash,
+		shardID: &shardID,
+	}
+}
+
+func nextRetryInterval(retryInterval time.Duration) time.Duration {
+	retryInterval *= 2
+	if retryInterval > maxPublishRetryInterval {
+		return maxPublishRetryInterval
+	}
+
+	return retryInterval
+}
+
+// GetMetricsForPrometheus returns the number of successful, failed and dropped publish operations
+// for each exchange, together with the number of buffered events, in prometheus format
+func (rp *rabbitMqPublisher) GetMetricsForPrometheus() string {
+	rp.mutMetrics.RLock()
+	defer rp.mutMetrics.RUnlock()
+
+	stringBuilder := strings.Builder{}
+	stringBuilder.WriteString(metrics.CounterMetrics(publishSuccessPromMetric, exchangePromLabel, rp.numPublishSuccess))
+	stringBuilder.WriteString(metrics.CounterMetrics(publishFailuresPromMetric, exchangePromLabel, rp.numPublishFailures))
+	stringBuilder.WriteString(metrics.CounterMetrics(droppedEventsPromMetric, exchangePromLabel, rp.numDroppedEvents))
+	stringBuilder.WriteString(metrics.GaugeMetric(bufferedEventsPromMetric, rp.numBufferedEvents))
+
+	return stringBuilder.String()
+}
+
+// GetHealthState returns up if the connection to the rabbitMQ server is established
+func (rp *rabbitMqPublisher) GetHealthState() string {
+	if rp.client.IsConnected() {
+		return common.HealthStateUp
+	}
+
+	return common.HealthStateDown
+}
+
+// CircuitState returns the state of the publish circuit: closed, open or half-open. It is
+// always closed if the circuit breaker is not configured
+func (rp *rabbitMqPublisher) CircuitState() string {
+	return rp.circuitBreaker.getState()
+}
+
+// Ping returns an error if the connection to the rabbitMQ server is not established
+func (rp *rabbitMqPublisher) Ping(_ context.Context) error {
+	if !rp.client.IsConnected() {
+		return ErrConnectionFailure
+	}
+
+	return nil
+}
+
+// Close will publish the pending batch, if batching is enabled, then it will trigger
+// to close rabbitmq client
 func (rp *rabbitMqPublisher) Close() error {
+	if rp.batcher != nil {
+		rp.batcher.close(context.Background())
+	}
+
 	rp.client.Close()
 	return nil
 }
@@ -1,18 +1,29 @@
 package rabbitmq_test
 
 import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/multiversx/mx-chain-core-go/core/check"
 	"github.com/multiversx/mx-chain-core-go/core/mock"
+	"github.com/multiversx/mx-chain-core-go/marshal"
+	logger "github.com/multiversx/mx-chain-logger-go"
 	"github.com/multiversx/mx-chain-notifier-go/common"
+	"github.com/multiversx/mx-chain-notifier-go/compress"
 	"github.com/multiversx/mx-chain-notifier-go/config"
 	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/data/payload"
 	"github.com/multiversx/mx-chain-notifier-go/mocks"
+	"github.com/multiversx/mx-chain-notifier-go/process"
 	"github.com/multiversx/mx-chain-notifier-go/rabbitmq"
 	"github.com/streadway/amqp"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func createMockArgsRabbitMqPublisher() rabbitmq.ArgsRabbitMqPublisher {
@@ -43,8 +54,12 @@ func createMockArgsRabbitMqPublisher() rabbitmq.ArgsRabbitMqPublisher {
 				Name: "blockeventswithorder",
 				Type: "fanout",
 			},
+			PublishMaxAttempts:       3,
+			PublishRetryIntervalInMs: 1,
+			MaxBufferedEvents:        10,
 		},
-		Marshaller: &mock.MarshalizerMock{},
+		Marshaller:       &mock.MarshalizerMock{},
+		MetricsCollector: &mocks.MetricsCollectorStub{},
 	}
 }
 
@@ -73,6 +88,50 @@ func TestRabbitMqPublisher(t *testing.T) {
 		require.Equal(t, common.ErrNilMarshaller, err)
 	})
 
+	t.Run("nil metrics collector", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsRabbitMqPublisher()
+		args.MetricsCollector = nil
+
+		client, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.True(t, check.IfNil(client))
+		require.Equal(t, common.ErrNilMetricsCollector, err)
+	})
+
+	t.Run("invalid publish max attempts", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsRabbitMqPublisher()
+		args.Config.PublishMaxAttempts = 0
+
+		client, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.True(t, check.IfNil(client))
+		require.Equal(t, rabbitmq.ErrInvalidPublishMaxAttempts, err)
+	})
+
+	t.Run("invalid publish mode", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsRabbitMqPublisher()
+		args.Config.PublishMode = "per-tx"
+
+		client, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.True(t, check.IfNil(client))
+		require.True(t, errors.Is(err, rabbitmq.ErrInvalidPublishMode))
+	})
+
+	t.Run("invalid compression algorithm", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsRabbitMqPublisher()
+		args.Config.CompressionAlgorithm = "brotli"
+
+		client, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.True(t, check.IfNil(client))
+		require.True(t, errors.Is(err, compress.ErrUnsupportedAlgorithm))
+	})
+
 	t.Run("invalid events exchange name", func(t *testing.T) {
 		t.Parallel()
 
@@ -163,7 +222,7 @@ func TestRabbitMqPublisher(t *testing.T) {
 
 		wasCalled := false
 		args.Client = &mocks.RabbitClientStub{
-			ExchangeDeclareCalled: func(name, kind string) error {
+			ExchangeDeclareCalled: func(name, kind string, durable bool) error {
 				wasCalled = true
 				return nil
 			},
@@ -176,78 +235,137 @@ func TestRabbitMqPublisher(t *testing.T) {
 	})
 }
 
-func TestPublish(t *testing.T) {
+type exchangeDeclareArgs struct {
+	name    string
+	kind    string
+	durable bool
+}
+
+func TestRabbitMqPublisher_DeclareExchanges(t *testing.T) {
 	t.Parallel()
 
-	wasCalled := false
-	client := &mocks.RabbitClientStub{
-		PublishCalled: func(exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
-			wasCalled = true
-			return nil
-		},
+	expectedDeclares := []exchangeDeclareArgs{
+		{name: "allevents", kind: "topic", durable: true},
+		{name: "revert", kind: "fanout", durable: false},
+		{name: "finalized", kind: "fanout", durable: false},
+		{name: "blocktxs", kind: "fanout", durable: false},
+		{name: "blockscrs", kind: "fanout", durable: false},
+		{name: "blockeventswithorder", kind: "fanout", durable: false},
+		{name: "txevents", kind: "direct", durable: true},
+		{name: "crossshardevents", kind: "fanout", durable: true},
 	}
 
-	args := createMockArgsRabbitMqPublisher()
-	args.Client = client
+	createArgs := func() rabbitmq.ArgsRabbitMqPublisher {
+		args := createMockArgsRabbitMqPublisher()
+		args.Config.EventsExchange.Type = "topic"
+		args.Config.EventsExchange.Durable = true
+		args.Config.TxEventsExchange = config.RabbitMQExchangeConfig{
+			Name:    "txevents",
+			Type:    "direct",
+			Durable: true,
+		}
+		args.Config.CrossShardEventsExchange = config.RabbitMQExchangeConfig{
+			Name:    "crossshardevents",
+			Type:    "fanout",
+			Durable: true,
+		}
 
-	rabbitmq, err := rabbitmq.NewRabbitMqPublisher(args)
-	require.Nil(t, err)
+		return args
+	}
 
-	rabbitmq.Publish(data.BlockEvents{})
+	t.Run("should declare the configured exchanges", func(t *testing.T) {
+		t.Parallel()
 
-	require.True(t, wasCalled)
-}
+		args := createArgs()
 
-func TestPublishRevert(t *testing.T) {
-	t.Parallel()
+		declares := make([]exchangeDeclareArgs, 0)
+		args.Client = &mocks.RabbitClientStub{
+			ExchangeDeclareCalled: func(name, kind string, durable bool) error {
+				declares = append(declares, exchangeDeclareArgs{name: name, kind: kind, durable: durable})
+				return nil
+			},
+			ExchangeDeclarePassiveCalled: func(name, kind string, durable bool) error {
+				require.Fail(t, "should not have called passive declare")
+				return nil
+			},
+		}
 
-	wasCalled := false
-	client := &mocks.RabbitClientStub{
-		PublishCalled: func(exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
-			wasCalled = true
-			return nil
-		},
-	}
+		client, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, err)
+		require.NotNil(t, client)
+		require.Equal(t, expectedDeclares, declares)
+	})
 
-	args := createMockArgsRabbitMqPublisher()
-	args.Client = client
+	t.Run("skip exchange declare should only check the exchanges exist", func(t *testing.T) {
+		t.Parallel()
 
-	rabbitmq, err := rabbitmq.NewRabbitMqPublisher(args)
-	require.Nil(t, err)
+		args := createArgs()
+		args.Config.SkipExchangeDeclare = true
 
-	rabbitmq.PublishRevert(data.RevertBlock{})
+		declares := make([]exchangeDeclareArgs, 0)
+		args.Client = &mocks.RabbitClientStub{
+			ExchangeDeclareCalled: func(name, kind string, durable bool) error {
+				require.Fail(t, "should not have called declare")
+				return nil
+			},
+			ExchangeDeclarePassiveCalled: func(name, kind string, durable bool) error {
+				declares = append(declares, exchangeDeclareArgs{name: name, kind: kind, durable: durable})
+				return nil
+			},
+		}
 
-	require.True(t, wasCalled)
-}
+		client, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, err)
+		require.NotNil(t, client)
+		require.Equal(t, expectedDeclares, declares)
+	})
 
-func TestBroadcastFinalized(t *testing.T) {
-	t.Parallel()
+	t.Run("conflicting exchange should fail", func(t *testing.T) {
+		t.Parallel()
 
-	wasCalled := false
-	client := &mocks.RabbitClientStub{
-		PublishCalled: func(exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
-			wasCalled = true
-			return nil
-		},
-	}
+		args := createArgs()
+		args.Client = &mocks.RabbitClientStub{
+			ExchangeDeclareCalled: func(name, kind string, durable bool) error {
+				if name == "finalized" {
+					return rabbitmq.ErrExchangeConflict
+				}
+				return nil
+			},
+		}
 
-	args := createMockArgsRabbitMqPublisher()
-	args.Client = client
+		client, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.True(t, check.IfNil(client))
+		require.True(t, errors.Is(err, rabbitmq.ErrExchangeConflict))
+		require.Contains(t, err.Error(), "exchange finalized, type fanout")
+	})
 
-	rabbitmq, err := rabbitmq.NewRabbitMqPublisher(args)
-	require.Nil(t, err)
+	t.Run("missing exchange should fail", func(t *testing.T) {
+		t.Parallel()
 
-	rabbitmq.PublishFinalized(data.FinalizedBlock{})
+		args := createArgs()
+		args.Config.SkipExchangeDeclare = true
+		args.Client = &mocks.RabbitClientStub{
+			ExchangeDeclarePassiveCalled: func(name, kind string, durable bool) error {
+				if name == "txevents" {
+					return rabbitmq.ErrExchangeNotFound
+				}
+				return nil
+			},
+		}
 
-	require.True(t, wasCalled)
+		client, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.True(t, check.IfNil(client))
+		require.True(t, errors.Is(err, rabbitmq.ErrExchangeNotFound))
+		require.Contains(t, err.Error(), "exchange txevents, type direct")
+	})
 }
 
-func TestBroadcastTxs(t *testing.T) {
+func TestPublish(t *testing.T) {
 	t.Parallel()
 
 	wasCalled := false
 	client := &mocks.RabbitClientStub{
-		PublishCalled: func(exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+		PublishCalled: func(_ context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
 			wasCalled = true
 			return nil
 		},
@@ -259,71 +377,2064 @@ func TestBroadcastTxs(t *testing.T) {
 	rabbitmq, err := rabbitmq.NewRabbitMqPublisher(args)
 	require.Nil(t, err)
 
-	rabbitmq.PublishTxs(data.BlockTxs{})
+	rabbitmq.Publish(context.Background(), data.BlockEvents{})
 
 	require.True(t, wasCalled)
 }
 
-func TestBroadcastScrs(t *testing.T) {
+func TestPublishWithRetries(t *testing.T) {
 	t.Parallel()
 
-	wasCalled := false
-	client := &mocks.RabbitClientStub{
-		PublishCalled: func(exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
-			wasCalled = true
-			return nil
-		},
-	}
+	t.Run("nacked attempts should be retried until acknowledged", func(t *testing.T) {
+		t.Parallel()
 
-	args := createMockArgsRabbitMqPublisher()
-	args.Client = client
+		numNacks := 2
+		numAttempts := 0
+		publishedPayloads := make([][]byte, 0)
+		client := &mocks.RabbitClientStub{
+			PublishCalled: func(_ context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+				numAttempts++
+				if numAttempts <= numNacks {
+					return rabbitmq.ErrPublishNotAcknowledged
+				}
+
+				publishedPayloads = append(publishedPayloads, msg.Body)
+				return nil
+			},
+		}
 
-	rabbitmq, err := rabbitmq.NewRabbitMqPublisher(args)
-	require.Nil(t, err)
+		args := createMockArgsRabbitMqPublisher()
+		args.Client = client
+
+		rabbitmq, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, err)
 
-	rabbitmq.PublishScrs(data.BlockScrs{})
+		events := data.BlockEvents{Hash: "hash1"}
+		rabbitmq.Publish(context.Background(), events)
 
-	require.True(t, wasCalled)
+		expPayload, _ := args.Marshaller.Marshal(events)
+		require.Equal(t, numNacks+1, numAttempts)
+		require.Equal(t, [][]byte{expPayload}, publishedPayloads)
+	})
+
+	t.Run("should give up after max attempts", func(t *testing.T) {
+		t.Parallel()
+
+		numAttempts := uint32(0)
+		client := &mocks.RabbitClientStub{
+			PublishCalled: func(_ context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+				numAttempts++
+				return rabbitmq.ErrPublishConfirmTimeout
+			},
+		}
+
+		args := createMockArgsRabbitMqPublisher()
+		args.Client = client
+
+		rabbitmq, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, err)
+
+		rabbitmq.Publish(context.Background(), data.BlockEvents{Hash: "hash1"})
+
+		require.Equal(t, args.Config.PublishMaxAttempts, numAttempts)
+	})
+
+	t.Run("cancelled context should stop the retries", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		numAttempts := uint32(0)
+		client := &mocks.RabbitClientStub{
+			PublishCalled: func(_ context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+				numAttempts++
+				cancel()
+				return rabbitmq.ErrPublishConfirmTimeout
+			},
+		}
+
+		args := createMockArgsRabbitMqPublisher()
+		args.Config.PublishMaxAttempts = 10
+		args.Config.PublishRetryIntervalInMs = 10000
+		args.Client = client
+
+		rabbitmq, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, err)
+
+		publishDone := make(chan struct{})
+		go func() {
+			rabbitmq.Publish(ctx, data.BlockEvents{Hash: "hash1"})
+			close(publishDone)
+		}()
+
+		select {
+		case <-publishDone:
+		case <-time.After(time.Second):
+			require.Fail(t, "publish should not wait for the retry interval after the context is done")
+		}
+		require.Equal(t, uint32(1), numAttempts)
+	})
+
+	t.Run("retries should not reorder events", func(t *testing.T) {
+		t.Parallel()
+
+		nackedOnce := make(map[string]bool)
+		publishedHashes := make([]string, 0)
+		marshaller := &mock.MarshalizerMock{}
+		client := &mocks.RabbitClientStub{
+			PublishCalled: func(_ context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+				events := data.BlockEvents{}
+				_ = marshaller.Unmarshal(&events, msg.Body)
+
+				if !nackedOnce[events.Hash] {
+					nackedOnce[events.Hash] = true
+					return rabbitmq.ErrPublishNotAcknowledged
+				}
+
+				publishedHashes = append(publishedHashes, events.Hash)
+				return nil
+			},
+		}
+
+		args := createMockArgsRabbitMqPublisher()
+		args.Client = client
+		args.Marshaller = marshaller
+
+		rabbitmq, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, err)
+
+		rabbitmq.Publish(context.Background(), data.BlockEvents{Hash: "hash1"})
+		rabbitmq.Publish(context.Background(), data.BlockEvents{Hash: "hash2"})
+		rabbitmq.Publish(context.Background(), data.BlockEvents{Hash: "hash3"})
+
+		require.Equal(t, []string{"hash1", "hash2", "hash3"}, publishedHashes)
+	})
 }
 
-func TestBroadcastBlockEventsWithOrder(t *testing.T) {
+func TestPublishCircuitBreaker(t *testing.T) {
 	t.Parallel()
 
-	wasCalled := false
-	client := &mocks.RabbitClientStub{
-		PublishCalled: func(exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
-			wasCalled = true
-			return nil
-		},
-	}
+	t.Run("missing open duration should error", func(t *testing.T) {
+		t.Parallel()
 
-	args := createMockArgsRabbitMqPublisher()
-	args.Client = client
+		args := createMockArgsRabbitMqPublisher()
+		args.Config.CircuitBreaker.FailureThreshold = 2
 
-	rabbitmq, err := rabbitmq.NewRabbitMqPublisher(args)
-	require.Nil(t, err)
+		client, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.True(t, errors.Is(err, rabbitmq.ErrInvalidCircuitBreakerConfig))
+		require.True(t, check.IfNil(client))
+	})
 
-	rabbitmq.PublishBlockEventsWithOrder(data.BlockEventsWithOrder{})
+	t.Run("disabled circuit breaker should always publish", func(t *testing.T) {
+		t.Parallel()
 
-	require.True(t, wasCalled)
+		numAttempts := 0
+		args := createMockArgsRabbitMqPublisher()
+		args.Config.PublishMaxAttempts = 1
+		args.Client = &mocks.RabbitClientStub{
+			PublishCalled: func(_ context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+				numAttempts++
+				return rabbitmq.ErrPublishNotAcknowledged
+			},
+		}
+
+		publisher, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, err)
+
+		for i := 0; i < 5; i++ {
+			publisher.Publish(context.Background(), data.BlockEvents{Hash: "hash1"})
+		}
+
+		require.Equal(t, 5, numAttempts)
+		require.Equal(t, rabbitmq.CircuitStateClosed, publisher.CircuitState())
+	})
+
+	t.Run("should open, half-open and close", func(t *testing.T) {
+		t.Parallel()
+
+		shouldFail := true
+		numAttempts := 0
+		args := createMockArgsRabbitMqPublisher()
+		args.Config.PublishMaxAttempts = 1
+		args.Config.CircuitBreaker = config.RabbitMQCircuitBreakerConfig{
+			FailureThreshold:  2,
+			OpenDurationInSec: 10,
+		}
+		args.Client = &mocks.RabbitClientStub{
+			PublishCalled: func(_ context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+				numAttempts++
+				if shouldFail {
+					return rabbitmq.ErrPublishNotAcknowledged
+				}
+				return nil
+			},
+		}
+
+		publisher, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, err)
+
+		currentTime := time.Unix(1000, 0)
+		publisher.SetCircuitBreakerTimeHandler(func() time.Time {
+			return currentTime
+		})
+
+		publisher.Publish(context.Background(), data.BlockEvents{Hash: "hash1"})
+		require.Equal(t, rabbitmq.CircuitStateClosed, publisher.CircuitState())
+		publisher.Publish(context.Background(), data.BlockEvents{Hash: "hash2"})
+		require.Equal(t, rabbitmq.CircuitStateOpen, publisher.CircuitState())
+
+		publisher.Publish(context.Background(), data.BlockEvents{Hash: "hash3"})
+		require.Equal(t, 2, numAttempts)
+		require.Contains(t, publisher.GetMetricsForPrometheus(), `rabbitmq_dropped_events{exchange="allevents"} 1`)
+
+		currentTime = currentTime.Add(10 * time.Second)
+		require.Equal(t, rabbitmq.CircuitStateHalfOpen, publisher.CircuitState())
+
+		publisher.Publish(context.Background(), data.BlockEvents{Hash: "hash4"})
+		require.Equal(t, 3, numAttempts)
+		require.Equal(t, rabbitmq.CircuitStateOpen, publisher.CircuitState())
+
+		currentTime = currentTime.Add(10 * time.Second)
+		shouldFail = false
+		publisher.Publish(context.Background(), data.BlockEvents{Hash: "hash5"})
+		require.Equal(t, 4, numAttempts)
+		require.Equal(t, rabbitmq.CircuitStateClosed, publisher.CircuitState())
+
+		publisher.Publish(context.Background(), data.BlockEvents{Hash: "hash6"})
+		require.Equal(t, 5, numAttempts)
+	})
+
+	t.Run("rejected events should be sent to the dead letter exchange", func(t *testing.T) {
+		t.Parallel()
+
+		deadLetters := make([]rabbitmq.DeadLetterEvent, 0)
+		args := createMockArgsRabbitMqPublisher()
+		args.Config.PublishMaxAttempts = 1
+		args.Config.DeadLetterExchange = config.RabbitMQExchangeConfig{Name: "deadletter", Type: "fanout"}
+		args.Config.CircuitBreaker = config.RabbitMQCircuitBreakerConfig{
+			FailureThreshold:  1,
+			OpenDurationInSec: 10,
+		}
+		args.Client = &mocks.RabbitClientStub{
+			PublishCalled: func(_ context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+				if exchange == "deadletter" {
+					deadLetter := rabbitmq.DeadLetterEvent{}
+					_ = json.Unmarshal(msg.Body, &deadLetter)
+					deadLetters = append(deadLetters, deadLetter)
+					return nil
+				}
+				return rabbitmq.ErrPublishNotAcknowledged
+			},
+		}
+
+		publisher, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, err)
+
+		publisher.Publish(context.Background(), data.BlockEvents{Hash: "hash1"})
+		publisher.Publish(context.Background(), data.BlockEvents{Hash: "hash2"})
+
+		require.Equal(t, 2, len(deadLetters))
+		require.Equal(t, rabbitmq.ErrPublishNotAcknowledged.Error(), deadLetters[0].Error)
+		require.Equal(t, rabbitmq.ErrCircuitOpen.Error(), deadLetters[1].Error)
+		require.Equal(t, uint32(0), deadLetters[1].Attempts)
+	})
 }
 
-func TestClose(t *testing.T) {
+func TestPublishErrorHandler(t *testing.T) {
 	t.Parallel()
 
-	wasCalled := false
-	client := &mocks.RabbitClientStub{
-		CloseCalled: func() {
-			wasCalled = true
-		},
-	}
+	t.Run("publish failure should be notified", func(t *testing.T) {
+		t.Parallel()
 
-	args := createMockArgsRabbitMqPublisher()
-	args.Client = client
+		args := createMockArgsRabbitMqPublisher()
+		args.Config.PublishMaxAttempts = 1
+		args.Client = &mocks.RabbitClientStub{
+			PublishCalled: func(_ context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+				if exchange == "revert" {
+					return rabbitmq.ErrPublishNotAcknowledged
+				}
+				return nil
+			},
+		}
 
-	rabbitmq, err := rabbitmq.NewRabbitMqPublisher(args)
-	require.Nil(t, err)
+		publisher, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, err)
 
-	rabbitmq.Close()
+		notifiedErrors := make([]error, 0)
+		publisher.SetPublishErrorHandler(func(err error) {
+			notifiedErrors = append(notifiedErrors, err)
+		})
+
+		publisher.Publish(context.Background(), data.BlockEvents{Hash: "hash1"})
+		require.Equal(t, 0, len(notifiedErrors))
+
+		publisher.PublishRevert(context.Background(), data.RevertBlock{Hash: "hash2"})
+		require.Equal(t, 1, len(notifiedErrors))
+		require.True(t, errors.Is(notifiedErrors[0], rabbitmq.ErrPublishNotAcknowledged))
+		require.Contains(t, notifiedErrors[0].Error(), "exchange revert")
+		require.Contains(t, notifiedErrors[0].Error(), "hash hash2")
+	})
+
+	t.Run("marshal failure should be notified", func(t *testing.T) {
+		t.Parallel()
+
+		wasPublished := false
+		args := createMockArgsRabbitMqPublisher()
+		args.Marshaller = &mock.MarshalizerMock{Fail: true}
+		args.Client = &mocks.RabbitClientStub{
+			PublishCalled: func(_ context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+				wasPublished = true
+				return nil
+			},
+		}
+
+		publisher, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, err)
+
+		notifiedErrors := make([]error, 0)
+		publisher.SetPublishErrorHandler(func(err error) {
+			notifiedErrors = append(notifiedErrors, err)
+		})
+
+		publisher.Publish(context.Background(), data.BlockEvents{Hash: "hash1"})
+		publisher.PublishFinalized(context.Background(), data.FinalizedBlock{Hash: "hash2"})
+
+		require.False(t, wasPublished)
+		require.Equal(t, 2, len(notifiedErrors))
+		require.Contains(t, notifiedErrors[0].Error(), "exchange allevents, hash hash1")
+		require.Contains(t, notifiedErrors[1].Error(), "exchange finalized, hash hash2")
+	})
+
+	t.Run("dropped buffered event should be notified", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsRabbitMqPublisher()
+		args.Config.MaxBufferedEvents = 1
+		args.Client = &mocks.RabbitClientStub{
+			IsConnectedCalled: func() bool {
+				return false
+			},
+		}
+
+		publisher, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, err)
+
+		notifiedErrors := make([]error, 0)
+		publisher.SetPublishErrorHandler(func(err error) {
+			notifiedErrors = append(notifiedErrors, err)
+		})
+
+		publisher.Publish(context.Background(), data.BlockEvents{Hash: "hash1"})
+		require.Equal(t, 0, len(notifiedErrors))
+
+		publisher.Publish(context.Background(), data.BlockEvents{Hash: "hash2"})
+		require.Equal(t, 1, len(notifiedErrors))
+		require.True(t, errors.Is(notifiedErrors[0], rabbitmq.ErrPublishBufferFull))
+	})
+}
+
+func TestPublishWhileDisconnected(t *testing.T) {
+	t.Parallel()
+
+	t.Run("events should be buffered and published in order after reconnect", func(t *testing.T) {
+		t.Parallel()
+
+		isConnected := true
+		publishedHashes := make([]string, 0)
+		marshaller := &mock.MarshalizerMock{}
+		client := &mocks.RabbitClientStub{
+			PublishCalled: func(_ context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+				if !isConnected {
+					return rabbitmq.ErrConnectionFailure
+				}
+
+				events := data.BlockEvents{}
+				_ = marshaller.Unmarshal(&events, msg.Body)
+				publishedHashes = append(publishedHashes, events.Hash)
+
+				return nil
+			},
+			IsConnectedCalled: func() bool {
+				return isConnected
+			},
+		}
+
+		args := createMockArgsRabbitMqPublisher()
+		args.Client = client
+		args.Marshaller = marshaller
+
+		rabbitmq, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, err)
+
+		rabbitmq.Publish(context.Background(), data.BlockEvents{Hash: "hash1"})
+
+		// simulate connection close notification
+		isConnected = false
+		rabbitmq.Publish(context.Background(), data.BlockEvents{Hash: "hash2"})
+		rabbitmq.Publish(context.Background(), data.BlockEvents{Hash: "hash3"})
+
+		require.Equal(t, []string{"hash1"}, publishedHashes)
+		require.Contains(t, rabbitmq.GetMetricsForPrometheus(), "rabbitmq_buffered_events 2\n")
+
+		isConnected = true
+		rabbitmq.Publish(context.Background(), data.BlockEvents{Hash: "hash4"})
+
+		require.Equal(t, []string{"hash1", "hash2", "hash3", "hash4"}, publishedHashes)
+		require.Contains(t, rabbitmq.GetMetricsForPrometheus(), "rabbitmq_buffered_events 0\n")
+	})
+
+	t.Run("connection lost while publishing should buffer the event", func(t *testing.T) {
+		t.Parallel()
+
+		isConnected := true
+		wasConnectionLost := false
+		numPublished := 0
+		client := &mocks.RabbitClientStub{
+			PublishCalled: func(_ context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+				if !wasConnectionLost {
+					wasConnectionLost = true
+					isConnected = false
+					return rabbitmq.ErrConnectionFailure
+				}
+
+				numPublished++
+				return nil
+			},
+			IsConnectedCalled: func() bool {
+				return isConnected
+			},
+		}
+
+		args := createMockArgsRabbitMqPublisher()
+		args.Client = client
+
+		rabbitmq, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, err)
+
+		rabbitmq.Publish(context.Background(), data.BlockEvents{Hash: "hash1"})
+		require.Equal(t, 0, numPublished)
+		require.Contains(t, rabbitmq.GetMetricsForPrometheus(), "rabbitmq_buffered_events 1\n")
+
+		isConnected = true
+		rabbitmq.Publish(context.Background(), data.BlockEvents{Hash: "hash2"})
+		require.Equal(t, 2, numPublished)
+	})
+
+	t.Run("should drop new events when the buffer is full", func(t *testing.T) {
+		t.Parallel()
+
+		client := &mocks.RabbitClientStub{
+			PublishCalled: func(_ context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+				return rabbitmq.ErrConnectionFailure
+			},
+			IsConnectedCalled: func() bool {
+				return false
+			},
+		}
+
+		args := createMockArgsRabbitMqPublisher()
+		args.Client = client
+		args.Config.MaxBufferedEvents = 1
+
+		rabbitmq, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, err)
+
+		rabbitmq.Publish(context.Background(), data.BlockEvents{Hash: "hash1"})
+		rabbitmq.Publish(context.Background(), data.BlockEvents{Hash: "hash2"})
+		rabbitmq.PublishRevert(context.Background(), data.RevertBlock{Hash: "hash3"})
+
+		res := rabbitmq.GetMetricsForPrometheus()
+		require.Contains(t, res, "rabbitmq_buffered_events 1\n")
+		require.Contains(t, res, "rabbitmq_dropped_events{exchange=\"allevents\"} 1\n")
+		require.Contains(t, res, "rabbitmq_dropped_events{exchange=\"revert\"} 1\n")
+	})
+
+	t.Run("ack after publish should return the error and not buffer the events", func(t *testing.T) {
+		t.Parallel()
+
+		isConnected := false
+		numPublished := 0
+		client := &mocks.RabbitClientStub{
+			PublishCalled: func(_ context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+				numPublished++
+				return nil
+			},
+			IsConnectedCalled: func() bool {
+				return isConnected
+			},
+		}
+
+		args := createMockArgsRabbitMqPublisher()
+		args.Client = client
+		args.AckAfterPublish = true
+
+		publisher, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, err)
+
+		err = publisher.Publish(context.Background(), data.BlockEvents{Hash: "hash1"})
+		require.Equal(t, rabbitmq.ErrConnectionFailure, err)
+		err = publisher.PublishRevert(context.Background(), data.RevertBlock{Hash: "hash2"})
+		require.Equal(t, rabbitmq.ErrConnectionFailure, err)
+		require.Contains(t, publisher.GetMetricsForPrometheus(), "rabbitmq_buffered_events 0\n")
+
+		isConnected = true
+		err = publisher.Publish(context.Background(), data.BlockEvents{Hash: "hash3"})
+		require.Nil(t, err)
+		require.Equal(t, 1, numPublished)
+	})
+}
+
+func TestPublishWithRoutingKeyTemplate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("routing key template on fanout exchange should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsRabbitMqPublisher()
+		args.Config.EventsExchange.RoutingKeyTemplate = "<shardID>.<address>.<identifier>"
+
+		client, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.True(t, check.IfNil(client))
+		require.Equal(t, rabbitmq.ErrRoutingKeyTemplateOnFanout, err)
+	})
+
+	t.Run("unsupported exchange type should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsRabbitMqPublisher()
+		args.Config.BlockTxsExchange.Type = "headers"
+
+		client, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.True(t, check.IfNil(client))
+		require.True(t, errors.Is(err, rabbitmq.ErrInvalidRabbitMqExchangeType))
+	})
+
+	t.Run("events should be grouped by routing key", func(t *testing.T) {
+		t.Parallel()
+
+		marshaller := &mock.MarshalizerMock{}
+		publishedKeys := make([]string, 0)
+		publishedEvents := make(map[string]data.BlockEvents)
+		client := &mocks.RabbitClientStub{
+			PublishCalled: func(_ context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+				events := data.BlockEvents{}
+				_ = marshaller.Unmarshal(&events, msg.Body)
+
+				publishedKeys = append(publishedKeys, key)
+				publishedEvents[key] = events
+
+				return nil
+			},
+		}
+
+		args := createMockArgsRabbitMqPublisher()
+		args.Client = client
+		args.Marshaller = marshaller
+		args.Config.EventsExchange.Type = "topic"
+		args.Config.EventsExchange.RoutingKeyTemplate = "<shardID>.<address>.<identifier>"
+
+		rabbitmq, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, err)
+
+		blockEvents := data.BlockEvents{
+			Hash:    "hash1",
+			ShardID: 1,
+			Events: []data.Event{
+				{Address: "erd1alice", Identifier: "ESDTTransfer", TxHash: "txHash1"},
+				{Address: "erd1bob", Identifier: "ESDTTransfer", TxHash: "txHash2"},
+				{Address: "erd1alice", Identifier: "ESDTTransfer", TxHash: "txHash3"},
+			},
+		}
+		rabbitmq.Publish(context.Background(), blockEvents)
+
+		require.Equal(t, []string{"1.erd1alice.ESDTTransfer", "1.erd1bob.ESDTTransfer"}, publishedKeys)
+
+		aliceEvents := publishedEvents["1.erd1alice.ESDTTransfer"]
+		require.Equal(t, "hash1", aliceEvents.Hash)
+		require.Equal(t, uint32(1), aliceEvents.ShardID)
+		require.Equal(t, []data.Event{blockEvents.Events[0], blockEvents.Events[2]}, aliceEvents.Events)
+		require.Equal(t, []data.Event{blockEvents.Events[1]}, publishedEvents["1.erd1bob.ESDTTransfer"].Events)
+	})
+
+	t.Run("invalid routing key characters should be hex encoded", func(t *testing.T) {
+		t.Parallel()
+
+		publishedKeys := make([]string, 0)
+		client := &mocks.RabbitClientStub{
+			PublishCalled: func(_ context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+				publishedKeys = append(publishedKeys, key)
+				return nil
+			},
+		}
+
+		args := createMockArgsRabbitMqPublisher()
+		args.Client = client
+		args.Config.EventsExchange.Type = "topic"
+		args.Config.EventsExchange.RoutingKeyTemplate = "<shardID>.<address>.<identifier>"
+
+		rabbitmq, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, err)
+
+		rabbitmq.Publish(context.Background(), data.BlockEvents{
+			ShardID: 0,
+			Events: []data.Event{
+				{Address: "addr.with.dots", Identifier: "id*#"},
+				{Address: "erd1alice", Identifier: ""},
+			},
+		})
+
+		expKeys := []string{
+			"0." + hex.EncodeToString([]byte("addr.with.dots")) + "." + hex.EncodeToString([]byte("id*#")),
+			"0.erd1alice._",
+		}
+		require.Equal(t, expKeys, publishedKeys)
+	})
+
+	t.Run("without template should publish one message with empty routing key", func(t *testing.T) {
+		t.Parallel()
+
+		publishedKeys := make([]string, 0)
+		client := &mocks.RabbitClientStub{
+			PublishCalled: func(_ context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+				publishedKeys = append(publishedKeys, key)
+				return nil
+			},
+		}
+
+		args := createMockArgsRabbitMqPublisher()
+		args.Client = client
+
+		rabbitmq, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, err)
+
+		rabbitmq.Publish(context.Background(), data.BlockEvents{
+			Events: []data.Event{
+				{Address: "erd1alice"},
+				{Address: "erd1bob"},
+			},
+		})
+
+		require.Equal(t, []string{""}, publishedKeys)
+	})
+}
+
+func TestPublishPerEvent(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should publish each event, in order, keyed by address", func(t *testing.T) {
+		t.Parallel()
+
+		marshaller := &mock.MarshalizerMock{}
+		publishedKeys := make([]string, 0)
+		publishedEvents := make([]data.BlockEvent, 0)
+		client := &mocks.RabbitClientStub{
+			PublishCalled: func(_ context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+				event := data.BlockEvent{}
+				_ = marshaller.Unmarshal(&event, msg.Body)
+
+				publishedKeys = append(publishedKeys, key)
+				publishedEvents = append(publishedEvents, event)
+
+				return nil
+			},
+		}
+
+		args := createMockArgsRabbitMqPublisher()
+		args.Client = client
+		args.Marshaller = marshaller
+		args.Config.PublishMode = "per-event"
+
+		rabbitmq, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, err)
+
+		blockEvents := data.BlockEvents{
+			Hash:      "hash1",
+			ShardID:   1,
+			TimeStamp: 1234,
+			Events: []data.Event{
+				{Address: "erd1alice", TxHash: "txHash1"},
+				{Address: "erd1bob", TxHash: "txHash2"},
+				{Address: "erd1alice", TxHash: "txHash3"},
+			},
+		}
+		rabbitmq.Publish(context.Background(), blockEvents)
+
+		require.Equal(t, []string{"erd1alice", "erd1bob", "erd1alice"}, publishedKeys)
+		require.Len(t, publishedEvents, 3)
+		for index, event := range publishedEvents {
+			require.Equal(t, data.BlockEvent{
+				Hash:      "hash1",
+				ShardID:   1,
+				TimeStamp: 1234,
+				Index:     uint32(index),
+				Event:     blockEvents.Events[index],
+			}, event)
+		}
+	})
+
+	t.Run("routing key template should be applied to each event", func(t *testing.T) {
+		t.Parallel()
+
+		publishedKeys := make([]string, 0)
+		client := &mocks.RabbitClientStub{
+			PublishCalled: func(_ context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+				publishedKeys = append(publishedKeys, key)
+				return nil
+			},
+		}
+
+		args := createMockArgsRabbitMqPublisher()
+		args.Client = client
+		args.Config.PublishMode = "per-event"
+		args.Config.EventsExchange.Type = "topic"
+		args.Config.EventsExchange.RoutingKeyTemplate = "<shardID>.<address>.<identifier>"
+
+		rabbitmq, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, err)
+
+		rabbitmq.Publish(context.Background(), data.BlockEvents{
+			ShardID: 2,
+			Events: []data.Event{
+				{Address: "erd1alice", Identifier: "ESDTTransfer"},
+				{Address: "erd1alice", Identifier: "ESDTTransfer"},
+			},
+		})
+
+		require.Equal(t, []string{"2.erd1alice.ESDTTransfer", "2.erd1alice.ESDTTransfer"}, publishedKeys)
+	})
+
+	t.Run("connection lost mid block should resume from the failed event", func(t *testing.T) {
+		t.Parallel()
+
+		isConnected := true
+		wasConnectionLost := false
+		marshaller := &mock.MarshalizerMock{}
+		publishedTxHashes := make([]string, 0)
+		client := &mocks.RabbitClientStub{
+			PublishCalled: func(_ context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+				event := data.BlockEvent{}
+				_ = marshaller.Unmarshal(&event, msg.Body)
+
+				if event.Event.TxHash == "txHash2" && !wasConnectionLost {
+					wasConnectionLost = true
+					isConnected = false
+					return rabbitmq.ErrConnectionFailure
+				}
+
+				publishedTxHashes = append(publishedTxHashes, event.Event.TxHash)
+				return nil
+			},
+			IsConnectedCalled: func() bool {
+				return isConnected
+			},
+		}
+
+		args := createMockArgsRabbitMqPublisher()
+		args.Client = client
+		args.Marshaller = marshaller
+		args.Config.PublishMode = "per-event"
+
+		rabbitmq, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, err)
+
+		rabbitmq.Publish(context.Background(), data.BlockEvents{
+			Hash: "hash1",
+			Events: []data.Event{
+				{Address: "erd1alice", TxHash: "txHash1"},
+				{Address: "erd1alice", TxHash: "txHash2"},
+				{Address: "erd1alice", TxHash: "txHash3"},
+			},
+		})
+
+		require.Equal(t, []string{"txHash1"}, publishedTxHashes)
+		require.Contains(t, rabbitmq.GetMetricsForPrometheus(), "rabbitmq_buffered_events 2\n")
+
+		isConnected = true
+		rabbitmq.Publish(context.Background(), data.BlockEvents{
+			Hash:   "hash2",
+			Events: []data.Event{{Address: "erd1bob", TxHash: "txHash4"}},
+		})
+
+		require.Equal(t, []string{"txHash1", "txHash2", "txHash3", "txHash4"}, publishedTxHashes)
+	})
+}
+
+func TestPublishRevert(t *testing.T) {
+	t.Parallel()
+
+	wasCalled := false
+	client := &mocks.RabbitClientStub{
+		PublishCalled: func(_ context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+			wasCalled = true
+			return nil
+		},
+	}
+
+	args := createMockArgsRabbitMqPublisher()
+	args.Client = client
+
+	rabbitmq, err := rabbitmq.NewRabbitMqPublisher(args)
+	require.Nil(t, err)
+
+	rabbitmq.PublishRevert(context.Background(), data.RevertBlock{})
+
+	require.True(t, wasCalled)
+}
+
+func TestBroadcastFinalized(t *testing.T) {
+	t.Parallel()
+
+	wasCalled := false
+	client := &mocks.RabbitClientStub{
+		PublishCalled: func(_ context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+			wasCalled = true
+			return nil
+		},
+	}
+
+	args := createMockArgsRabbitMqPublisher()
+	args.Client = client
+
+	rabbitmq, err := rabbitmq.NewRabbitMqPublisher(args)
+	require.Nil(t, err)
+
+	rabbitmq.PublishFinalized(context.Background(), data.FinalizedBlock{})
+
+	require.True(t, wasCalled)
+}
+
+func TestBroadcastTxs(t *testing.T) {
+	t.Parallel()
+
+	wasCalled := false
+	client := &mocks.RabbitClientStub{
+		PublishCalled: func(_ context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+			wasCalled = true
+			return nil
+		},
+	}
+
+	args := createMockArgsRabbitMqPublisher()
+	args.Client = client
+
+	rabbitmq, err := rabbitmq.NewRabbitMqPublisher(args)
+	require.Nil(t, err)
+
+	rabbitmq.PublishTxs(context.Background(), data.BlockTxs{})
+
+	require.True(t, wasCalled)
+}
+
+func TestBroadcastScrs(t *testing.T) {
+	t.Parallel()
+
+	wasCalled := false
+	client := &mocks.RabbitClientStub{
+		PublishCalled: func(_ context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+			wasCalled = true
+			return nil
+		},
+	}
+
+	args := createMockArgsRabbitMqPublisher()
+	args.Client = client
+
+	rabbitmq, err := rabbitmq.NewRabbitMqPublisher(args)
+	require.Nil(t, err)
+
+	rabbitmq.PublishScrs(context.Background(), data.BlockScrs{})
+
+	require.True(t, wasCalled)
+}
+
+func TestBroadcastBlockEventsWithOrder(t *testing.T) {
+	t.Parallel()
+
+	wasCalled := false
+	client := &mocks.RabbitClientStub{
+		PublishCalled: func(_ context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+			wasCalled = true
+			return nil
+		},
+	}
+
+	args := createMockArgsRabbitMqPublisher()
+	args.Client = client
+
+	rabbitmq, err := rabbitmq.NewRabbitMqPublisher(args)
+	require.Nil(t, err)
+
+	rabbitmq.PublishBlockEventsWithOrder(context.Background(), data.BlockEventsWithOrder{})
+
+	require.True(t, wasCalled)
+}
+
+func TestBroadcastTxEvents(t *testing.T) {
+	t.Parallel()
+
+	t.Run("exchange not configured, should not publish", func(t *testing.T) {
+		t.Parallel()
+
+		wasCalled := false
+		client := &mocks.RabbitClientStub{
+			PublishCalled: func(_ context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+				wasCalled = true
+				return nil
+			},
+		}
+
+		args := createMockArgsRabbitMqPublisher()
+		args.Client = client
+
+		rabbitmq, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, err)
+
+		rabbitmq.PublishTxEvents(context.Background(), data.BlockTxEvents{})
+
+		require.False(t, wasCalled)
+	})
+
+	t.Run("should publish to tx events exchange", func(t *testing.T) {
+		t.Parallel()
+
+		wasCalled := false
+		client := &mocks.RabbitClientStub{
+			PublishCalled: func(_ context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+				require.Equal(t, "txevents", exchange)
+				wasCalled = true
+				return nil
+			},
+		}
+
+		args := createMockArgsRabbitMqPublisher()
+		args.Client = client
+		args.Config.TxEventsExchange = config.RabbitMQExchangeConfig{
+			Name: "txevents",
+			Type: "fanout",
+		}
+
+		rabbitmq, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, err)
+
+		rabbitmq.PublishTxEvents(context.Background(), data.BlockTxEvents{})
+
+		require.True(t, wasCalled)
+	})
+}
+
+func TestBroadcastRounds(t *testing.T) {
+	t.Parallel()
+
+	t.Run("exchange not configured, should not publish", func(t *testing.T) {
+		t.Parallel()
+
+		wasCalled := false
+		client := &mocks.RabbitClientStub{
+			PublishCalled: func(_ context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+				wasCalled = true
+				return nil
+			},
+		}
+
+		args := createMockArgsRabbitMqPublisher()
+		args.Client = client
+
+		rabbitmq, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, err)
+
+		rabbitmq.PublishRounds(context.Background(), data.RoundEvents{Rounds: []data.RoundInfo{{Round: 1}}})
+
+		require.False(t, wasCalled)
+	})
+
+	t.Run("should publish to rounds exchange", func(t *testing.T) {
+		t.Parallel()
+
+		roundEvents := data.RoundEvents{
+			ShardID:       1,
+			Rounds:        []data.RoundInfo{{Round: 10, BlockWasProposed: true, SignersIndexes: []uint64{0, 1}, ShardID: 1}},
+			CorrelationID: "correlation1",
+		}
+
+		wasCalled := false
+		client := &mocks.RabbitClientStub{
+			PublishCalled: func(_ context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+				require.Equal(t, "rounds", exchange)
+				require.Equal(t, "correlation1", msg.Headers["correlation_id"])
+				require.JSONEq(t, `{"shardId":1,"rounds":[{"round":10,"blockWasProposed":true,"signersIndexes":[0,1],"shardId":1,"epoch":0,"timestamp":0}]}`, string(msg.Body))
+				wasCalled = true
+				return nil
+			},
+		}
+
+		args := createMockArgsRabbitMqPublisher()
+		args.Client = client
+		args.Config.RoundsExchange = config.RabbitMQExchangeConfig{
+			Name: "rounds",
+			Type: "fanout",
+		}
+
+		rabbitmq, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, err)
+
+		rabbitmq.PublishRounds(context.Background(), roundEvents)
+
+		require.True(t, wasCalled)
+	})
+}
+
+func TestBroadcastValidatorsRating(t *testing.T) {
+	t.Parallel()
+
+	t.Run("exchange not configured, should not publish", func(t *testing.T) {
+		t.Parallel()
+
+		wasCalled := false
+		client := &mocks.RabbitClientStub{
+			PublishCalled: func(_ context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+				wasCalled = true
+				return nil
+			},
+		}
+
+		args := createMockArgsRabbitMqPublisher()
+		args.Client = client
+
+		rabbitmq, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, err)
+
+		rabbitmq.PublishValidatorsRating(context.Background(), data.ValidatorsRatingEvent{ValidatorsRating: []data.ValidatorRating{{PublicKey: "pubKey1"}}})
+
+		require.False(t, wasCalled)
+	})
+
+	t.Run("should publish to validators rating exchange", func(t *testing.T) {
+		t.Parallel()
+
+		validatorsRating := data.ValidatorsRatingEvent{
+			ShardID: 1,
+			Epoch:   5,
+			ValidatorsRating: []data.ValidatorRating{
+				{PublicKey: "pubKey1", Rating: 50.5},
+				{PublicKey: "pubKey2", Rating: 100},
+			},
+			CorrelationID: "correlation1",
+		}
+
+		wasCalled := false
+		client := &mocks.RabbitClientStub{
+			PublishCalled: func(_ context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+				require.Equal(t, "validatorsrating", exchange)
+				require.Equal(t, "correlation1", msg.Headers["correlation_id"])
+				require.JSONEq(t, `{"shardId":1,"epoch":5,"validatorsRating":[{"publicKey":"pubKey1","rating":50.5},{"publicKey":"pubKey2","rating":100}]}`, string(msg.Body))
+				wasCalled = true
+				return nil
+			},
+		}
+
+		args := createMockArgsRabbitMqPublisher()
+		args.Client = client
+		args.Config.ValidatorsRatingExchange = config.RabbitMQExchangeConfig{
+			Name: "validatorsrating",
+			Type: "fanout",
+		}
+
+		rabbitmq, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, err)
+
+		rabbitmq.PublishValidatorsRating(context.Background(), validatorsRating)
+
+		require.True(t, wasCalled)
+	})
+}
+
+func TestBroadcastAccounts(t *testing.T) {
+	t.Parallel()
+
+	t.Run("exchange not configured, should not publish", func(t *testing.T) {
+		t.Parallel()
+
+		wasCalled := false
+		client := &mocks.RabbitClientStub{
+			PublishCalled: func(_ context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+				wasCalled = true
+				return nil
+			},
+		}
+
+		args := createMockArgsRabbitMqPublisher()
+		args.Client = client
+
+		rabbitmq, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, err)
+
+		rabbitmq.PublishAccounts(context.Background(), data.AccountsEvents{Accounts: []data.AccountEvent{{Address: "erd1alice"}}})
+
+		require.False(t, wasCalled)
+	})
+
+	t.Run("should publish to accounts exchange", func(t *testing.T) {
+		t.Parallel()
+
+		accountsEvents := data.AccountsEvents{
+			ShardID:        1,
+			BlockTimestamp: 1234,
+			Accounts: []data.AccountEvent{
+				{Address: "erd1alice", Nonce: 3, Balance: "1000", Tokens: []data.AccountTokenBalance{{Identifier: "TKN-abcdef", Balance: "50"}}},
+				{Address: "erd1bob", Nonce: 7, Balance: "2000"},
+			},
+			CorrelationID: "correlation1",
+		}
+
+		wasCalled := false
+		client := &mocks.RabbitClientStub{
+			PublishCalled: func(_ context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+				require.Equal(t, "accounts", exchange)
+				require.Equal(t, "correlation1", msg.Headers["correlation_id"])
+				require.Equal(t, int64(1), msg.Headers["shard_id"])
+				require.JSONEq(t, `{"shardId":1,"blockTimestamp":1234,"accounts":[{"address":"erd1alice","nonce":3,"balance":"1000","tokens":[{"identifier":"TKN-abcdef","nonce":0,"balance":"50"}]},{"address":"erd1bob","nonce":7,"balance":"2000"}]}`, string(msg.Body))
+				wasCalled = true
+				return nil
+			},
+		}
+
+		args := createMockArgsRabbitMqPublisher()
+		args.Client = client
+		args.Config.AccountsExchange = config.RabbitMQExchangeConfig{
+			Name: "accounts",
+			Type: "fanout",
+		}
+
+		rabbitmq, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, err)
+
+		rabbitmq.PublishAccounts(context.Background(), accountsEvents)
+
+		require.True(t, wasCalled)
+	})
+}
+
+func TestPublishCrossShardEvents(t *testing.T) {
+	t.Parallel()
+
+	crossShardExchange := config.RabbitMQExchangeConfig{
+		Name: "crossshardevents",
+		Type: "fanout",
+	}
+	events := data.BlockEvents{
+		Hash:    "hash1",
+		ShardID: 1,
+		Events: []data.Event{
+			{Address: "addr1", TxHash: "txHash1"},
+			{Address: "addr2", TxHash: "txHash2", CrossShard: true},
+			{Address: "addr3", TxHash: "txHash3"},
+		},
+	}
+
+	t.Run("exchange not configured, should only publish to events exchange", func(t *testing.T) {
+		t.Parallel()
+
+		publishedExchanges := make([]string, 0)
+		args := createMockArgsRabbitMqPublisher()
+		args.Client = &mocks.RabbitClientStub{
+			PublishCalled: func(_ context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+				publishedExchanges = append(publishedExchanges, exchange)
+				return nil
+			},
+		}
+
+		publisher, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, err)
+
+		publisher.Publish(context.Background(), events)
+
+		require.Equal(t, []string{"allevents"}, publishedExchanges)
+	})
+
+	t.Run("should publish only the cross shard events to the cross shard exchange", func(t *testing.T) {
+		t.Parallel()
+
+		publishedMessages := make(map[string][]byte)
+		args := createMockArgsRabbitMqPublisher()
+		args.Config.CrossShardEventsExchange = crossShardExchange
+		args.Client = &mocks.RabbitClientStub{
+			PublishCalled: func(_ context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+				publishedMessages[exchange] = msg.Body
+				return nil
+			},
+		}
+
+		publisher, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, err)
+
+		publisher.Publish(context.Background(), events)
+
+		require.Equal(t, 2, len(publishedMessages))
+
+		allEvents := data.BlockEvents{}
+		err = args.Marshaller.Unmarshal(&allEvents, publishedMessages["allevents"])
+		require.Nil(t, err)
+		require.Equal(t, 3, len(allEvents.Events))
+
+		crossShardEvents := data.BlockEvents{}
+		err = args.Marshaller.Unmarshal(&crossShardEvents, publishedMessages["crossshardevents"])
+		require.Nil(t, err)
+		require.Equal(t, "hash1", crossShardEvents.Hash)
+		require.Equal(t, uint32(1), crossShardEvents.ShardID)
+		require.Equal(t, []data.Event{{Address: "addr2", TxHash: "txHash2"}}, crossShardEvents.Events)
+	})
+
+	t.Run("no cross shard events, should not publish to the cross shard exchange", func(t *testing.T) {
+		t.Parallel()
+
+		publishedExchanges := make([]string, 0)
+		args := createMockArgsRabbitMqPublisher()
+		args.Config.CrossShardEventsExchange = crossShardExchange
+		args.Client = &mocks.RabbitClientStub{
+			PublishCalled: func(_ context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+				publishedExchanges = append(publishedExchanges, exchange)
+				return nil
+			},
+		}
+
+		publisher, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, err)
+
+		publisher.Publish(context.Background(), data.BlockEvents{Hash: "hash1", Events: []data.Event{{Address: "addr1"}}})
+
+		require.Equal(t, []string{"allevents"}, publishedExchanges)
+	})
+}
+
+func TestPublishSkipEmptyBlocks(t *testing.T) {
+	t.Parallel()
+
+	createArgs := func(skipEmptyBlocks bool, publishedHashes *[]string) rabbitmq.ArgsRabbitMqPublisher {
+		args := createMockArgsRabbitMqPublisher()
+		args.Config.SkipEmptyBlocks = skipEmptyBlocks
+		args.Client = &mocks.RabbitClientStub{
+			PublishCalled: func(_ context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+				*publishedHashes = append(*publishedHashes, msg.Headers["hash"].(string))
+				return nil
+			},
+		}
+
+		return args
+	}
+
+	t.Run("empty blocks should be published if not skipped", func(t *testing.T) {
+		t.Parallel()
+
+		publishedHashes := make([]string, 0)
+		publisher, err := rabbitmq.NewRabbitMqPublisher(createArgs(false, &publishedHashes))
+		require.Nil(t, err)
+
+		publisher.Publish(context.Background(), data.BlockEvents{Hash: "hash1"})
+		publisher.Publish(context.Background(), data.BlockEvents{Hash: "hash2", Events: []data.Event{{Address: "addr1"}}})
+
+		require.Equal(t, []string{"hash1", "hash2"}, publishedHashes)
+	})
+
+	t.Run("empty blocks should be skipped", func(t *testing.T) {
+		t.Parallel()
+
+		publishedHashes := make([]string, 0)
+		publisher, err := rabbitmq.NewRabbitMqPublisher(createArgs(true, &publishedHashes))
+		require.Nil(t, err)
+
+		publisher.Publish(context.Background(), data.BlockEvents{Hash: "hash1"})
+		publisher.Publish(context.Background(), data.BlockEvents{Hash: "hash2", Events: []data.Event{{Address: "addr1"}}})
+		publisher.Publish(context.Background(), data.BlockEvents{Hash: "hash3", Events: make([]data.Event, 0)})
+
+		require.Equal(t, []string{"hash2"}, publishedHashes)
+	})
+
+	t.Run("empty blocks should not be added to the batch", func(t *testing.T) {
+		t.Parallel()
+
+		publishedBodies := make([][]byte, 0)
+		args := createMockArgsRabbitMqPublisher()
+		args.Config.SkipEmptyBlocks = true
+		args.Config.Batching = config.RabbitMQBatchingConfig{Enabled: true, MaxBlocks: 2}
+		args.Client = &mocks.RabbitClientStub{
+			PublishCalled: func(_ context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+				publishedBodies = append(publishedBodies, msg.Body)
+				return nil
+			},
+		}
+
+		publisher, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, err)
+
+		publisher.Publish(context.Background(), data.BlockEvents{Hash: "hash1", Events: []data.Event{{Address: "addr1"}}})
+		publisher.Publish(context.Background(), data.BlockEvents{Hash: "hash2"})
+		publisher.Publish(context.Background(), data.BlockEvents{Hash: "hash3", Events: []data.Event{{Address: "addr3"}}})
+
+		require.Equal(t, 1, len(publishedBodies))
+		batch := data.BlockEventsBatch{}
+		err = args.Marshaller.Unmarshal(&batch, publishedBodies[0])
+		require.Nil(t, err)
+		require.Equal(t, []string{"hash1", "hash3"}, batch.Hashes)
+	})
+}
+
+func TestPublishBatchingWithAckAfterPublish(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgsRabbitMqPublisher()
+	args.Config.Batching = config.RabbitMQBatchingConfig{Enabled: true, MaxBlocks: 2}
+	args.AckAfterPublish = true
+
+	publisher, err := rabbitmq.NewRabbitMqPublisher(args)
+	require.True(t, check.IfNil(publisher))
+	require.True(t, errors.Is(err, rabbitmq.ErrInvalidBatchingConfig))
+}
+
+func TestPublishMaxEventsPerMessage(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should not be supported with batching", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsRabbitMqPublisher()
+		args.Config.MaxEventsPerMessage = 2
+		args.Config.Batching = config.RabbitMQBatchingConfig{Enabled: true, MaxBlocks: 2}
+
+		publisher, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.True(t, check.IfNil(publisher))
+		require.True(t, errors.Is(err, rabbitmq.ErrInvalidBatchingConfig))
+	})
+
+	t.Run("should split the events in multiple messages", func(t *testing.T) {
+		t.Parallel()
+
+		publishedChunks := make([]data.BlockEvents, 0)
+		publishedHeaders := make([]amqp.Table, 0)
+		args := createMockArgsRabbitMqPublisher()
+		args.Config.MaxEventsPerMessage = 2
+		args.Client = &mocks.RabbitClientStub{
+			PublishCalled: func(_ context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+				published := data.BlockEvents{}
+				err := args.Marshaller.Unmarshal(&published, msg.Body)
+				require.Nil(t, err)
+				publishedChunks = append(publishedChunks, published)
+				publishedHeaders = append(publishedHeaders, msg.Headers)
+				return nil
+			},
+		}
+
+		publisher, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, err)
+
+		events := []data.Event{
+			{Address: "addr1", TxHash: "txHash1"},
+			{Address: "addr2", TxHash: "txHash2"},
+			{Address: "addr3", TxHash: "txHash3"},
+			{Address: "addr4", TxHash: "txHash4"},
+			{Address: "addr5", TxHash: "txHash5"},
+		}
+		publisher.Publish(context.Background(), data.BlockEvents{Hash: "hash1", ShardID: 1, Events: events})
+
+		require.Equal(t, 3, len(publishedChunks))
+		for index, chunk := range publishedChunks {
+			require.Equal(t, "hash1", chunk.Hash)
+			require.Equal(t, uint32(1), chunk.ShardID)
+			require.Equal(t, &data.EventsChunk{Index: uint32(index), Total: 3}, chunk.Chunk)
+			require.Equal(t, "hash1", publishedHeaders[index]["hash"])
+			require.Equal(t, int64(index), publishedHeaders[index]["chunk_index"])
+			require.Equal(t, int64(3), publishedHeaders[index]["chunk_total"])
+		}
+		require.Equal(t, events[0:2], publishedChunks[0].Events)
+		require.Equal(t, events[2:4], publishedChunks[1].Events)
+		require.Equal(t, events[4:], publishedChunks[2].Events)
+	})
+
+	t.Run("block within the limit should be published as one message", func(t *testing.T) {
+		t.Parallel()
+
+		publishedHeaders := make([]amqp.Table, 0)
+		args := createMockArgsRabbitMqPublisher()
+		args.Config.MaxEventsPerMessage = 2
+		args.Client = &mocks.RabbitClientStub{
+			PublishCalled: func(_ context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+				published := data.BlockEvents{}
+				err := args.Marshaller.Unmarshal(&published, msg.Body)
+				require.Nil(t, err)
+				require.Nil(t, published.Chunk)
+				publishedHeaders = append(publishedHeaders, msg.Headers)
+				return nil
+			},
+		}
+
+		publisher, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, err)
+
+		publisher.Publish(context.Background(), data.BlockEvents{Hash: "hash1", Events: []data.Event{{Address: "addr1"}, {Address: "addr2"}}})
+
+		require.Equal(t, 1, len(publishedHeaders))
+		_, hasChunkIndex := publishedHeaders[0]["chunk_index"]
+		require.False(t, hasChunkIndex)
+	})
+}
+
+func TestPublishIdentifiersFilter(t *testing.T) {
+	t.Parallel()
+
+	events := data.BlockEvents{
+		Hash: "hash1",
+		Events: []data.Event{
+			{Address: "addr1", Identifier: "ESDTTransfer"},
+			{Address: "addr2", Identifier: "writeLog"},
+			{Address: "addr3", Identifier: "ESDTNFTTransfer"},
+			{Address: "addr4", Identifier: "completedTxEvent", CrossShard: true},
+		},
+	}
+
+	publishWithFilter := func(t *testing.T, allowlist []string, denylist []string, skipEmptyBlocks bool, blockEvents data.BlockEvents) map[string][]string {
+		publishedIdentifiers := make(map[string][]string)
+		args := createMockArgsRabbitMqPublisher()
+		args.Config.IdentifiersAllowlist = allowlist
+		args.Config.IdentifiersDenylist = denylist
+		args.Config.SkipEmptyBlocks = skipEmptyBlocks
+		args.Config.CrossShardEventsExchange = config.RabbitMQExchangeConfig{Name: "crossshardevents", Type: "fanout"}
+		args.Client = &mocks.RabbitClientStub{
+			PublishCalled: func(_ context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+				published := data.BlockEvents{}
+				err := args.Marshaller.Unmarshal(&published, msg.Body)
+				require.Nil(t, err)
+				identifiers := make([]string, 0, len(published.Events))
+				for _, event := range published.Events {
+					identifiers = append(identifiers, event.Identifier)
+				}
+				publishedIdentifiers[exchange] = identifiers
+				return nil
+			},
+		}
+
+		publisher, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, err)
+
+		publisher.Publish(context.Background(), blockEvents)
+
+		return publishedIdentifiers
+	}
+
+	t.Run("no filter should publish all the events", func(t *testing.T) {
+		t.Parallel()
+
+		publishedIdentifiers := publishWithFilter(t, nil, nil, false, events)
+		require.Equal(t, []string{"ESDTTransfer", "writeLog", "ESDTNFTTransfer", "completedTxEvent"}, publishedIdentifiers["allevents"])
+	})
+
+	t.Run("allowlist should only publish the allowed identifiers", func(t *testing.T) {
+		t.Parallel()
+
+		publishedIdentifiers := publishWithFilter(t, []string{"ESDTTransfer", "ESDTNFTTransfer"}, nil, false, events)
+		require.Equal(t, []string{"ESDTTransfer", "ESDTNFTTransfer"}, publishedIdentifiers["allevents"])
+		_, publishedCrossShard := publishedIdentifiers["crossshardevents"]
+		require.False(t, publishedCrossShard)
+	})
+
+	t.Run("denylist should not publish the denied identifiers", func(t *testing.T) {
+		t.Parallel()
+
+		publishedIdentifiers := publishWithFilter(t, nil, []string{"writeLog"}, false, events)
+		require.Equal(t, []string{"ESDTTransfer", "ESDTNFTTransfer", "completedTxEvent"}, publishedIdentifiers["allevents"])
+		require.Equal(t, []string{"completedTxEvent"}, publishedIdentifiers["crossshardevents"])
+	})
+
+	t.Run("denylist should take precedence over the allowlist", func(t *testing.T) {
+		t.Parallel()
+
+		publishedIdentifiers := publishWithFilter(t, []string{"ESDTTransfer", "writeLog"}, []string{"writeLog"}, false, events)
+		require.Equal(t, []string{"ESDTTransfer"}, publishedIdentifiers["allevents"])
+	})
+
+	t.Run("block without allowed events should be published empty if not skipped", func(t *testing.T) {
+		t.Parallel()
+
+		publishedIdentifiers := publishWithFilter(t, []string{"unknown"}, nil, false, events)
+		identifiers, published := publishedIdentifiers["allevents"]
+		require.True(t, published)
+		require.Equal(t, 0, len(identifiers))
+	})
+
+	t.Run("block without allowed events should be skipped", func(t *testing.T) {
+		t.Parallel()
+
+		publishedIdentifiers := publishWithFilter(t, []string{"unknown"}, nil, true, events)
+		require.Equal(t, 0, len(publishedIdentifiers))
+	})
+
+	t.Run("filtering should not alter the received events", func(t *testing.T) {
+		t.Parallel()
+
+		blockEvents := data.BlockEvents{Hash: "hash1", Events: []data.Event{{Identifier: "writeLog"}, {Identifier: "ESDTTransfer"}}}
+		_ = publishWithFilter(t, nil, []string{"writeLog"}, false, blockEvents)
+		require.Equal(t, []data.Event{{Identifier: "writeLog"}, {Identifier: "ESDTTransfer"}}, blockEvents.Events)
+	})
+}
+
+func TestPublishConsumerEvents(t *testing.T) {
+	t.Parallel()
+
+	consumerExchange := config.RabbitMQExchangeConfig{
+		Name: "consumerevents",
+		Type: "direct",
+	}
+
+	t.Run("nil consumer registry should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsRabbitMqPublisher()
+		args.Config.ConsumerEventsExchange = consumerExchange
+
+		publisher, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.True(t, check.IfNil(publisher))
+		require.Equal(t, rabbitmq.ErrNilConsumerRegistry, err)
+	})
+
+	t.Run("fanout exchange should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsRabbitMqPublisher()
+		args.Config.ConsumerEventsExchange = config.RabbitMQExchangeConfig{Name: "consumerevents", Type: "fanout"}
+		args.ConsumerRegistry = &mocks.ConsumerRegistryStub{}
+
+		publisher, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.True(t, check.IfNil(publisher))
+		require.Equal(t, rabbitmq.ErrConsumerEventsExchangeOnFanout, err)
+	})
+
+	t.Run("should publish the matched events of each consumer", func(t *testing.T) {
+		t.Parallel()
+
+		registry, _ := rabbitmq.NewConsumerRegistry(rabbitmq.ArgsConsumerRegistry{})
+		_ = registry.Register(data.ConsumerSubscription{
+			ConsumerID:          "consumer1",
+			SubscriptionEntries: []data.SubscriptionEntry{{Address: "erd1b"}},
+		})
+		_ = registry.Register(data.ConsumerSubscription{
+			ConsumerID:          "consumer2",
+			SubscriptionEntries: []data.SubscriptionEntry{{Address: "erd1d"}},
+		})
+
+		type publishedMessage struct {
+			exchange   string
+			routingKey string
+			body       []byte
+		}
+		publishedMessages := make([]publishedMessage, 0)
+		args := createMockArgsRabbitMqPublisher()
+		args.Config.ConsumerEventsExchange = consumerExchange
+		args.ConsumerRegistry = registry
+		args.Client = &mocks.RabbitClientStub{
+			PublishCalled: func(_ context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+				publishedMessages = append(publishedMessages, publishedMessage{exchange: exchange, routingKey: key, body: msg.Body})
+				return nil
+			},
+		}
+
+		publisher, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, err)
+
+		publisher.Publish(context.Background(), data.BlockEvents{
+			Hash:    "hash1",
+			ShardID: 1,
+			Events: []data.Event{
+				{Address: "erd1a", TxHash: "txHash1"},
+				{Address: "erd1b", TxHash: "txHash2"},
+				{Address: "erd1c", TxHash: "txHash3"},
+			},
+		})
+
+		require.Equal(t, 2, len(publishedMessages))
+		require.Equal(t, "allevents", publishedMessages[0].exchange)
+		require.Equal(t, "consumerevents", publishedMessages[1].exchange)
+		require.Equal(t, "consumer1", publishedMessages[1].routingKey)
+
+		consumerEvents := data.BlockEvents{}
+		err = args.Marshaller.Unmarshal(&consumerEvents, publishedMessages[1].body)
+		require.Nil(t, err)
+		require.Equal(t, "hash1", consumerEvents.Hash)
+		require.Equal(t, uint32(1), consumerEvents.ShardID)
+		require.Equal(t, []data.Event{{Address: "erd1b", TxHash: "txHash2"}}, consumerEvents.Events)
+	})
+}
+
+func TestClose(t *testing.T) {
+	t.Parallel()
+
+	wasCalled := false
+	client := &mocks.RabbitClientStub{
+		CloseCalled: func() {
+			wasCalled = true
+		},
+	}
+
+	args := createMockArgsRabbitMqPublisher()
+	args.Client = client
+
+	rabbitmq, err := rabbitmq.NewRabbitMqPublisher(args)
+	require.Nil(t, err)
+
+	rabbitmq.Close()
 	require.True(t, wasCalled)
 }
+
+func TestGetMetricsForPrometheus(t *testing.T) {
+	t.Parallel()
+
+	expErr := errors.New("expected error")
+	client := &mocks.RabbitClientStub{
+		PublishCalled: func(_ context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+			if exchange == "revert" {
+				return expErr
+			}
+			return nil
+		},
+	}
+
+	collectedPublishes := make([]string, 0)
+	args := createMockArgsRabbitMqPublisher()
+	args.Client = client
+	args.MetricsCollector = &mocks.MetricsCollectorStub{
+		AddRabbitMQPublishCalled: func(exchange string, status string) {
+			collectedPublishes = append(collectedPublishes, exchange+":"+status)
+		},
+	}
+
+	rabbitmq, err := rabbitmq.NewRabbitMqPublisher(args)
+	require.Nil(t, err)
+
+	rabbitmq.Publish(context.Background(), data.BlockEvents{})
+	rabbitmq.Publish(context.Background(), data.BlockEvents{})
+	rabbitmq.PublishRevert(context.Background(), data.RevertBlock{})
+
+	res := rabbitmq.GetMetricsForPrometheus()
+	require.Contains(t, res, "rabbitmq_publish_success{exchange=\"allevents\"} 2\n")
+	require.Contains(t, res, "rabbitmq_publish_failures{exchange=\"revert\"} 1\n")
+	require.NotContains(t, res, "rabbitmq_publish_success{exchange=\"revert\"}")
+
+	expCollectedPublishes := []string{"allevents:success", "allevents:success", "revert:failure"}
+	require.Equal(t, expCollectedPublishes, collectedPublishes)
+}
+
+func TestRabbitMqPublisher_MetricsCollector(t *testing.T) {
+	t.Parallel()
+
+	t.Run("direct publish should record the outcome and the duration", func(t *testing.T) {
+		t.Parallel()
+
+		client := &mocks.RabbitClientStub{
+			PublishCalled: func(_ context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+				if exchange == "revert" {
+					return rabbitmq.ErrPublishConfirmTimeout
+				}
+				return nil
+			},
+		}
+
+		collectedPublishes := make([]string, 0)
+		collectedDurations := make([]string, 0)
+		args := createMockArgsRabbitMqPublisher()
+		args.Client = client
+		args.MetricsCollector = &mocks.MetricsCollectorStub{
+			AddRabbitMQPublishCalled: func(exchange string, status string) {
+				collectedPublishes = append(collectedPublishes, exchange+":"+status)
+			},
+			AddRabbitMQPublishDurationCalled: func(exchange string, duration time.Duration) {
+				collectedDurations = append(collectedDurations, exchange)
+			},
+		}
+
+		rabbitmq, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, err)
+
+		rabbitmq.Publish(context.Background(), data.BlockEvents{Hash: "hash1"})
+		rabbitmq.PublishFinalized(context.Background(), data.FinalizedBlock{Hash: "hash1"})
+		rabbitmq.PublishRevert(context.Background(), data.RevertBlock{Hash: "hash2"})
+
+		require.Equal(t, []string{"allevents:success", "finalized:success", "revert:failure"}, collectedPublishes)
+		require.Equal(t, []string{"allevents", "finalized", "revert"}, collectedDurations)
+	})
+
+	t.Run("buffered events should be recorded when flushed", func(t *testing.T) {
+		t.Parallel()
+
+		isConnected := false
+		client := &mocks.RabbitClientStub{
+			PublishCalled: func(_ context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+				return nil
+			},
+			IsConnectedCalled: func() bool {
+				return isConnected
+			},
+		}
+
+		collectedPublishes := make([]string, 0)
+		bufferedEvents := make([]uint64, 0)
+		args := createMockArgsRabbitMqPublisher()
+		args.Client = client
+		args.MetricsCollector = &mocks.MetricsCollectorStub{
+			AddRabbitMQPublishCalled: func(exchange string, status string) {
+				collectedPublishes = append(collectedPublishes, exchange+":"+status)
+			},
+			SetRabbitMQBufferedEventsCalled: func(numEvents uint64) {
+				bufferedEvents = append(bufferedEvents, numEvents)
+			},
+		}
+
+		rabbitmq, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, err)
+
+		rabbitmq.Publish(context.Background(), data.BlockEvents{Hash: "hash1"})
+		rabbitmq.PublishRevert(context.Background(), data.RevertBlock{Hash: "hash2"})
+		require.Empty(t, collectedPublishes)
+
+		isConnected = true
+		rabbitmq.Publish(context.Background(), data.BlockEvents{Hash: "hash3"})
+
+		require.Equal(t, []string{"allevents:success", "revert:success", "allevents:success"}, collectedPublishes)
+		require.Equal(t, []uint64{1, 2, 1, 0}, bufferedEvents)
+	})
+
+	t.Run("dropped events should be recorded", func(t *testing.T) {
+		t.Parallel()
+
+		client := &mocks.RabbitClientStub{
+			IsConnectedCalled: func() bool {
+				return false
+			},
+		}
+
+		collectedPublishes := make([]string, 0)
+		args := createMockArgsRabbitMqPublisher()
+		args.Client = client
+		args.Config.MaxBufferedEvents = 1
+		args.MetricsCollector = &mocks.MetricsCollectorStub{
+			AddRabbitMQPublishCalled: func(exchange string, status string) {
+				collectedPublishes = append(collectedPublishes, exchange+":"+status)
+			},
+		}
+
+		rabbitmq, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, err)
+
+		rabbitmq.Publish(context.Background(), data.BlockEvents{Hash: "hash1"})
+		rabbitmq.Publish(context.Background(), data.BlockEvents{Hash: "hash2"})
+		rabbitmq.PublishRevert(context.Background(), data.RevertBlock{Hash: "hash3"})
+
+		require.Equal(t, []string{"allevents:dropped", "revert:dropped"}, collectedPublishes)
+	})
+}
+
+func TestGetHealthState(t *testing.T) {
+	t.Parallel()
+
+	isConnected := true
+	args := createMockArgsRabbitMqPublisher()
+	args.Client = &mocks.RabbitClientStub{
+		IsConnectedCalled: func() bool {
+			return isConnected
+		},
+	}
+
+	rabbitmq, err := rabbitmq.NewRabbitMqPublisher(args)
+	require.Nil(t, err)
+
+	require.Equal(t, common.HealthStateUp, rabbitmq.GetHealthState())
+
+	isConnected = false
+	require.Equal(t, common.HealthStateDown, rabbitmq.GetHealthState())
+}
+
+func TestPing(t *testing.T) {
+	t.Parallel()
+
+	isConnected := true
+	args := createMockArgsRabbitMqPublisher()
+	args.Client = &mocks.RabbitClientStub{
+		IsConnectedCalled: func() bool {
+			return isConnected
+		},
+	}
+
+	publisher, err := rabbitmq.NewRabbitMqPublisher(args)
+	require.Nil(t, err)
+
+	require.Nil(t, publisher.Ping(context.Background()))
+
+	isConnected = false
+	require.Equal(t, rabbitmq.ErrConnectionFailure, publisher.Ping(context.Background()))
+}
+
+func TestPublishProtobufPayloads(t *testing.T) {
+	t.Parallel()
+
+	publishedMessages := make(map[string]amqp.Publishing)
+	marshaller := payload.NewProtoPayloadMarshaller()
+	args := createMockArgsRabbitMqPublisher()
+	args.Marshaller = marshaller
+	args.ContentType = "application/x-protobuf"
+	args.Client = &mocks.RabbitClientStub{
+		PublishCalled: func(_ context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+			publishedMessages[exchange] = msg
+			return nil
+		},
+	}
+
+	publisher, err := rabbitmq.NewRabbitMqPublisher(args)
+	require.Nil(t, err)
+
+	blockEvents := data.BlockEvents{
+		Hash:   "hash1",
+		Events: []data.Event{{Address: "erd1addr", Identifier: "ESDTTransfer", Topics: [][]byte{[]byte("topic1")}}},
+	}
+	revertBlock := data.RevertBlock{Hash: "hash1", Nonce: 2, Round: 3, Epoch: 1}
+	publisher.Publish(context.Background(), blockEvents)
+	publisher.PublishRevert(context.Background(), revertBlock)
+	publisher.PublishTxs(context.Background(), data.BlockTxs{Hash: "hash1"})
+
+	require.Len(t, publishedMessages, 2)
+	require.Equal(t, "application/x-protobuf", publishedMessages["allevents"].ContentType)
+
+	var publishedEvents data.BlockEvents
+	err = marshaller.Unmarshal(&publishedEvents, publishedMessages["allevents"].Body)
+	require.Nil(t, err)
+	require.Equal(t, blockEvents, publishedEvents)
+
+	var publishedRevert data.RevertBlock
+	err = marshaller.Unmarshal(&publishedRevert, publishedMessages["revert"].Body)
+	require.Nil(t, err)
+	require.Equal(t, revertBlock, publishedRevert)
+}
+
+func TestPublishCompressedPayloads(t *testing.T) {
+	t.Parallel()
+
+	for _, algorithm := range []string{compress.GzipAlgorithm, compress.ZstdAlgorithm} {
+		algorithm := algorithm
+		t.Run(algorithm, func(t *testing.T) {
+			t.Parallel()
+
+			var publishing amqp.Publishing
+			args := createMockArgsRabbitMqPublisher()
+			args.Config.CompressionAlgorithm = algorithm
+			args.Client = &mocks.RabbitClientStub{
+				PublishCalled: func(_ context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+					publishing = msg
+					return nil
+				},
+			}
+
+			publisher, err := rabbitmq.NewRabbitMqPublisher(args)
+			require.Nil(t, err)
+
+			events := make([]data.Event, 0, 50)
+			for i := 0; i < 50; i++ {
+				events = append(events, data.Event{Address: "erd1addr", Identifier: "ESDTTransfer", Topics: [][]byte{[]byte("topic1")}, TxHash: "txHash1"})
+			}
+			blockEvents := data.BlockEvents{Hash: "hash1", Events: events}
+			publisher.Publish(context.Background(), blockEvents)
+
+			expectedBody, _ := args.Marshaller.Marshal(blockEvents)
+			require.Equal(t, algorithm, publishing.ContentEncoding)
+			require.Less(t, len(publishing.Body), len(expectedBody))
+
+			body, err := compress.Decompress(publishing.Body, publishing.ContentEncoding)
+			require.Nil(t, err)
+			require.Equal(t, expectedBody, body)
+		})
+	}
+}
+
+func TestPublishingProperties(t *testing.T) {
+	t.Parallel()
+
+	publishWithCapture := func(t *testing.T, persistent bool, publish func(publisher process.PublisherHandler)) amqp.Publishing {
+		var publishing amqp.Publishing
+		numCalls := 0
+
+		args := createMockArgsRabbitMqPublisher()
+		args.Config.PersistentMessages = persistent
+		args.ContentType = "application/json"
+		args.Client = &mocks.RabbitClientStub{
+			PublishCalled: func(_ context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+				publishing = msg
+				numCalls++
+				return nil
+			},
+		}
+
+		publisher, err := rabbitmq.NewRabbitMqPublisher(args)
+		require.Nil(t, err)
+
+		publish(publisher)
+		require.Equal(t, 1, numCalls)
+
+		return publishing
+	}
+
+	t.Run("block events should have the shard id header", func(t *testing.T) {
+		t.Parallel()
+
+		publishing := publishWithCapture(t, true, func(publisher process.PublisherHandler) {
+			publisher.Publish(context.Background(), data.BlockEvents{Hash: "hash1", ShardID: 2})
+		})
+
+		expectedPublishing := amqp.Publishing{
+			Headers: amqp.Table{
+				"hash":           "hash1",
+				"shard_id":       int64(2),
+				"schema_version": int32(rabbitmq.PayloadSchemaVersion),
+			},
+			ContentType:  "application/json",
+			DeliveryMode: amqp.Persistent,
+			Body:         publishing.Body,
+		}
+		require.Equal(t, expectedPublishing, publishing)
+		require.NotEmpty(t, publishing.Body)
+	})
+
+	t.Run("revert event should have the nonce header", func(t *testing.T) {
+		t.Parallel()
+
+		publishing := publishWithCapture(t, true, func(publisher process.PublisherHandler) {
+			publisher.PublishRevert(context.Background(), data.RevertBlock{Hash: "hash2", Nonce: 11})
+		})
+
+		expectedPublishing := amqp.Publishing{
+			Headers: amqp.Table{
+				"hash":           "hash2",
+				"nonce":          int64(11),
+				"schema_version": int32(rabbitmq.PayloadSchemaVersion),
+			},
+			ContentType:  "application/json",
+			DeliveryMode: amqp.Persistent,
+			Body:         publishing.Body,
+		}
+		require.Equal(t, expectedPublishing, publishing)
+		require.NotEmpty(t, publishing.Body)
+	})
+
+	t.Run("not persistent finalized event should be transient", func(t *testing.T) {
+		t.Parallel()
+
+		publishing := publishWithCapture(t, false, func(publisher process.PublisherHandler) {
+			publisher.PublishFinalized(context.Background(), data.FinalizedBlock{Hash: "hash3"})
+		})
+
+		expectedPublishing := amqp.Publishing{
+			Headers: amqp.Table{
+				"hash":           "hash3",
+				"schema_version": int32(rabbitmq.PayloadSchemaVersion),
+			},
+			ContentType:  "application/json",
+			DeliveryMode: amqp.Transient,
+			Body:         publishing.Body,
+		}
+		require.Equal(t, expectedPublishing, publishing)
+		require.NotEmpty(t, publishing.Body)
+	})
+
+	t.Run("events with trace context should have the traceparent header", func(t *testing.T) {
+		t.Parallel()
+
+		spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    trace.TraceID{0x0a, 0x0b},
+			SpanID:     trace.SpanID{0x0c, 0x0d},
+			TraceFlags: trace.FlagsSampled,
+		})
+
+		publishing := publishWithCapture(t, false, func(publisher process.PublisherHandler) {
+			publisher.Publish(context.Background(), data.BlockEvents{Hash: "hash4", SpanContext: spanContext})
+		})
+
+		expectedTraceParent := "00-0a0b0000000000000000000000000000-0c0d000000000000-01"
+		require.Equal(t, expectedTraceParent, publishing.Headers["traceparent"])
+		require.Equal(t, "hash4", publishing.Headers["hash"])
+		require.NotContains(t, string(publishing.Body), "0a0b")
+	})
+
+	t.Run("events with correlation id should have the correlation_id header", func(t *testing.T) {
+		t.Parallel()
+
+		publishing := publishWithCapture(t, false, func(publisher process.PublisherHandler) {
+			publisher.PublishRevert(context.Background(), data.RevertBlock{Hash: "hash5", CorrelationID: "correlation1"})
+		})
+
+		require.Equal(t, "correlation1", publishing.Headers["correlation_id"])
+		require.NotContains(t, string(publishing.Body), "correlation1")
+	})
+}
+
+func TestRabbitMqPublisher_ShouldLogCorrelationID(t *testing.T) {
+	logPattern := logger.GetLogLevelPattern()
+	_ = logger.SetLogLevel("*:INFO,rabbitmq:DEBUG")
+	defer func() {
+		_ = logger.SetLogLevel(logPattern)
+	}()
+
+	logOutput := &mocks.LogOutputStub{}
+	err := logger.AddLogObserver(logOutput, &logger.PlainFormatter{})
+	require.Nil(t, err)
+	defer func() {
+		_ = logger.RemoveLogObserver(logOutput)
+	}()
+
+	isConnected := false
+	args := createMockArgsRabbitMqPublisher()
+	args.Client = &mocks.RabbitClientStub{
+		IsConnectedCalled: func() bool {
+			return isConnected
+		},
+	}
+
+	publisher, err := rabbitmq.NewRabbitMqPublisher(args)
+	require.Nil(t, err)
+
+	// the event is buffered while disconnected, then published after the connection is recovered
+	publisher.PublishFinalized(context.Background(), data.FinalizedBlock{Hash: "hash1", CorrelationID: "rabbit-correlation-id-1"})
+	require.Contains(t, logOutput.String(), "buffered event")
+	require.Contains(t, logOutput.String(), "rabbit-correlation-id-1")
+
+	isConnected = true
+	publisher.PublishRevert(context.Background(), data.RevertBlock{Hash: "hash2", CorrelationID: "rabbit-correlation-id-2"})
+	require.Contains(t, logOutput.String(), "published event to rabbitMQ")
+	require.Contains(t, logOutput.String(), "rabbit-correlation-id-2")
+}
+
+func benchmarkPublish(b *testing.B, publishMode string) {
+	args := createMockArgsRabbitMqPublisher()
+	args.Marshaller = &marshal.JsonMarshalizer{}
+	args.Config.PublishMode = publishMode
+	publisher, _ := rabbitmq.NewRabbitMqPublisher(args)
+
+	numEvents := 500
+	blockEvents := data.BlockEvents{
+		Hash:   "hash1",
+		Events: make([]data.Event, 0, numEvents),
+	}
+	for i := 0; i < numEvents; i++ {
+		blockEvents.Events = append(blockEvents.Events, data.Event{
+			Address:    fmt.Sprintf("erd1address%d", i%50),
+			Identifier: "ESDTTransfer",
+			Topics:     [][]byte{[]byte("topic1"), []byte("topic2")},
+			Data:       []byte("data"),
+			TxHash:     fmt.Sprintf("txHash%d", i),
+		})
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		publisher.Publish(context.Background(), blockEvents)
+	}
+}
+
+func BenchmarkRabbitMqPublisher_PublishPerBlock(b *testing.B) {
+	benchmarkPublish(b, "per-block")
+}
+
+func BenchmarkRabbitMqPublisher_PublishPerEvent(b *testing.B) {
+	benchmarkPublish(b, "per-event")
+}
@@ -1,6 +1,10 @@
 package rabbitmq
 
 import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
 	"sync"
 	"time"
 
@@ -8,127 +12,336 @@ import (
 )
 
 const (
-	reconnectRetryMs = 500
+	reconnectRetryMs    = 500
+	maxReconnectRetryMs = 30000
 
 	// ExchangeDeclare constants
-	isDurable  = true
 	autoDelete = false
 	isInternal = false
 	noWait     = false
+
+	confirmsBufferSize = 1
 )
 
+type exchangeDeclaration struct {
+	kind    string
+	durable bool
+	passive bool
+}
+
 type rabbitMqClient struct {
-	url    string
-	pubMut sync.Mutex
+	urls           []string
+	tlsConfig      *tls.Config
+	confirmTimeout time.Duration
+	pubMut         sync.Mutex
+
+	// mutConn protects the connection related fields, which are replaced on reconnect
+	mutConn     sync.RWMutex
+	conn        *amqp.Connection
+	ch          *amqp.Channel
+	isConnected bool
 
-	conn *amqp.Connection
-	ch   *amqp.Channel
+	// urlIndex is the index of the url of the current connection, -1 before connecting
+	urlIndex int
 
 	connErrCh chan *amqp.Error
 	chanErr   chan *amqp.Error
 	ackCh     chan uint64
 	nackCh    chan uint64
+
+	// lastDeliveryTag holds the delivery tag of the last message published on the
+	// current channel; the broker numbers the confirms starting from 1 for each channel
+	lastDeliveryTag uint64
+
+	// exchanges holds the declared exchanges, which are declared again after reconnect
+	exchanges map[string]exchangeDeclaration
+
+	closeChan chan struct{}
+	closeOnce sync.Once
 }
 
-// NewRabbitMQClient creates a new rabbitMQ client instance
-func NewRabbitMQClient(url string) (*rabbitMqClient, error) {
+// NewRabbitMQClient creates a new rabbitMQ client instance. Each published message
+// waits for the broker confirmation at most confirmTimeout. The connection and the
+// channel are monitored and re-established automatically if closed by the server.
+// The urls are tried in order until a connection succeeds, while the reconnect attempts
+// cycle through them, starting with the one after the url of the lost connection.
+// If tlsConfig is not nil, the urls should use the amqps scheme
+func NewRabbitMQClient(urls []string, tlsConfig *tls.Config, confirmTimeout time.Duration) (*rabbitMqClient, error) {
+	if len(urls) == 0 {
+		return nil, ErrNoRabbitMqURL
+	}
+	if confirmTimeout <= 0 {
+		return nil, ErrInvalidConfirmTimeout
+	}
+	for _, url := range urls {
+		err := checkTLSScheme(url, tlsConfig)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	rc := &rabbitMqClient{
-		url:    url,
-		pubMut: sync.Mutex{},
+		urls:           urls,
+		urlIndex:       -1,
+		tlsConfig:      tlsConfig,
+		confirmTimeout: confirmTimeout,
+		pubMut:         sync.Mutex{},
+		exchanges:      make(map[string]exchangeDeclaration),
+		closeChan:      make(chan struct{}),
 	}
 
 	err := rc.connect()
 	if err != nil {
 		return nil, err
 	}
+	rc.setConnected(true)
+
+	go rc.monitorConnection()
 
 	return rc, nil
 }
 
-// ExchangeDeclare will declare an exchange
-func (rc *rabbitMqClient) ExchangeDeclare(name, kind string) error {
-	return rc.ch.ExchangeDeclare(
+// ExchangeDeclare will declare an exchange, creating it if it does not exist. It fails
+// with ErrExchangeConflict if the exchange exists with different properties
+func (rc *rabbitMqClient) ExchangeDeclare(name, kind string, durable bool) error {
+	return rc.declareAndRecordExchange(name, exchangeDeclaration{
+		kind:    kind,
+		durable: durable,
+		passive: false,
+	})
+}
+
+// ExchangeDeclarePassive will check that an exchange exists, without creating it.
+// It fails with ErrExchangeNotFound if the exchange does not exist
+func (rc *rabbitMqClient) ExchangeDeclarePassive(name, kind string, durable bool) error {
+	return rc.declareAndRecordExchange(name, exchangeDeclaration{
+		kind:    kind,
+		durable: durable,
+		passive: true,
+	})
+}
+
+func (rc *rabbitMqClient) declareAndRecordExchange(name string, declaration exchangeDeclaration) error {
+	rc.mutConn.Lock()
+	defer rc.mutConn.Unlock()
+
+	err := rc.declareExchange(name, declaration)
+	if err != nil {
+		return err
+	}
+
+	rc.exchanges[name] = declaration
+
+	return nil
+}
+
+func (rc *rabbitMqClient) declareExchange(name string, declaration exchangeDeclaration) error {
+	declare := rc.ch.ExchangeDeclare
+	if declaration.passive {
+		declare = rc.ch.ExchangeDeclarePassive
+	}
+
+	err := declare(
 		name,
-		kind,
-		isDurable,
+		declaration.kind,
+		declaration.durable,
 		autoDelete,
 		isInternal,
 		noWait,
 		nil,
 	)
+
+	return convertDeclareError(err)
+}
+
+// convertDeclareError maps the broker channel exceptions returned on exchange declare
+func convertDeclareError(err error) error {
+	amqpErr, ok := err.(*amqp.Error)
+	if !ok {
+		return err
+	}
+
+	switch amqpErr.Code {
+	case amqp.PreconditionFailed:
+		return fmt.Errorf("%w: %s", ErrExchangeConflict, amqpErr.Reason)
+	case amqp.NotFound:
+		return fmt.Errorf("%w: %s", ErrExchangeNotFound, amqpErr.Reason)
+	default:
+		return err
+	}
 }
 
-// Publish will publich an item on the rabbitMq channel
-func (rc *rabbitMqClient) Publish(exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+// Publish will publish an item on the rabbitMq channel and wait for the broker
+// confirmation. It returns an error if the message was not acknowledged within the
+// confirm timeout, so that the caller can decide whether to publish it again.
+// While the connection is being recovered, it fails without blocking. If the context is done,
+// it stops waiting for the confirmation and returns the context error.
+func (rc *rabbitMqClient) Publish(ctx context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
 	rc.pubMut.Lock()
 	defer rc.pubMut.Unlock()
 
-	// In order to avoid losing any event, check rabbitmq ack event for the
-	// published message. If not-acknowledged, check if there is a connection or
-	// channel issue, and after that is solved try again.  This was done to
-	// make sure no event is lost, for example, if rabbitmq connection is not
-	// closing gracefully (port disabled from firewall), it may happen that the
-	// main loop will not catch the conn err event, and it will still try to
-	// publish the message.
-	for {
-		err := rc.ch.Publish(
-			exchange,
-			key,
-			mandatory,
-			immediate,
-			msg,
-		)
-		if err != nil {
-			log.Error("failed to publish message", "exchange", exchange, "error", err.Error())
-		}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	rc.mutConn.RLock()
+	isConnected := rc.isConnected
+	ch, ackCh, nackCh := rc.ch, rc.ackCh, rc.nackCh
+	rc.mutConn.RUnlock()
+
+	if !isConnected {
+		return ErrConnectionFailure
+	}
+
+	err := ch.Publish(
+		exchange,
+		key,
+		mandatory,
+		immediate,
+		msg,
+	)
+	if err != nil {
+		log.Error("failed to publish message", "exchange", exchange, "error", err.Error())
+		return err
+	}
+
+	rc.mutConn.Lock()
+	rc.lastDeliveryTag++
+	deliveryTag := rc.lastDeliveryTag
+	rc.mutConn.Unlock()
 
+	return rc.waitConfirm(ctx, ackCh, nackCh, deliveryTag)
+}
+
+func (rc *rabbitMqClient) waitConfirm(ctx context.Context, ackCh, nackCh chan uint64, deliveryTag uint64) error {
+	timer := time.NewTimer(rc.confirmTimeout)
+	defer timer.Stop()
+
+	// the confirm channels are closed by the library when the channel is closed
+	for {
 		select {
-		case deliveryTag := <-rc.ackCh:
-			log.Debug("Publish: published message ack", "deliveryTag", deliveryTag)
-			return err
-		case deliveryTag := <-rc.nackCh:
-			log.Debug("Publish: published message nack, will retry to publish message", "deliveryTag", deliveryTag)
-		case err := <-rc.connErrCh:
-			if err != nil {
-				log.Error("rabbitMQ connection failure", "err", err.Error())
-				rc.Reconnect()
+		case tag, ok := <-ackCh:
+			if !ok {
+				return fmt.Errorf("%w while waiting for publish confirm", ErrChannelFailure)
+			}
+			if tag < deliveryTag {
+				// late confirm for a message which already timed out
+				continue
+			}
+			log.Trace("Publish: published message ack", "deliveryTag", tag)
+			return nil
+		case tag, ok := <-nackCh:
+			if !ok {
+				return fmt.Errorf("%w while waiting for publish confirm", ErrChannelFailure)
 			}
-		case err := <-rc.chanErr:
-			if err != nil {
-				log.Error("rabbitMQ channel failure", "err", err.Error())
-				rc.ReopenChannel()
+			if tag < deliveryTag {
+				continue
 			}
+			log.Debug("Publish: published message nack", "deliveryTag", tag)
+			return ErrPublishNotAcknowledged
+		case <-timer.C:
+			return ErrPublishConfirmTimeout
+		case <-ctx.Done():
+			return ctx.Err()
 		}
 	}
 }
 
-// dial will return a rabbitMq connection
+// monitorConnection listens for connection and channel close notifications and
+// recovers them, until the client is closed
+func (rc *rabbitMqClient) monitorConnection() {
+	for {
+		rc.mutConn.RLock()
+		connErrCh, chanErr := rc.connErrCh, rc.chanErr
+		rc.mutConn.RUnlock()
+
+		select {
+		case <-rc.closeChan:
+			return
+		case err := <-connErrCh:
+			log.Error("rabbitMQ connection failure", "err", amqpErrorMessage(err))
+			rc.setConnected(false)
+			rc.Reconnect()
+		case err := <-chanErr:
+			log.Error("rabbitMQ channel failure", "err", amqpErrorMessage(err))
+			rc.setConnected(false)
+			rc.ReopenChannel()
+		}
+	}
+}
+
+func amqpErrorMessage(err *amqp.Error) string {
+	if err == nil {
+		return "closed"
+	}
+
+	return err.Error()
+}
+
+// dial will return a rabbitMq connection, over TLS if a TLS config is set
 func (rc *rabbitMqClient) dial(url string) (*amqp.Connection, error) {
-	return amqp.Dial(url)
+	var conn *amqp.Connection
+	var err error
+	if rc.tlsConfig != nil {
+		conn, err = amqp.DialTLS(url, rc.tlsConfig)
+	} else {
+		conn, err = amqp.Dial(url)
+	}
+	if err == nil {
+		return conn, nil
+	}
+
+	err = convertDialError(err)
+	switch {
+	case errors.Is(err, ErrTLSHandshakeFailure):
+		log.Error("rabbitMQ TLS handshake failed, check the TLS config", "err", err.Error())
+	case errors.Is(err, ErrAuthenticationFailure):
+		log.Error("rabbitMQ authentication failed, check the credentials", "err", err.Error())
+	}
+
+	return nil, err
 }
 
 // ConnErrChan will return connection error channel
 func (rc *rabbitMqClient) ConnErrChan() chan *amqp.Error {
+	rc.mutConn.RLock()
+	defer rc.mutConn.RUnlock()
+
 	return rc.connErrCh
 }
 
 // CloseErrChan will return closing error channel
 func (rc *rabbitMqClient) CloseErrChan() chan *amqp.Error {
+	rc.mutConn.RLock()
+	defer rc.mutConn.RUnlock()
+
 	return rc.chanErr
 }
 
 func (rc *rabbitMqClient) connect() error {
-	conn, err := rc.dial(rc.url)
+	rc.mutConn.RLock()
+	startIndex := (rc.urlIndex + 1) % len(rc.urls)
+	rc.mutConn.RUnlock()
+
+	conn, urlIndex, err := dialFirstAvailable(rc.urls, startIndex, rc.dial)
 	if err != nil {
 		return err
 	}
+	log.Info("connected to rabbitMQ", "url index", urlIndex, "num urls", len(rc.urls))
+
+	rc.mutConn.Lock()
+	defer rc.mutConn.Unlock()
+
 	rc.conn = conn
+	rc.urlIndex = urlIndex
 
-	rc.connErrCh = make(chan *amqp.Error)
+	// the notify channels are buffered, since the library blocks on sending the close notification
+	rc.connErrCh = make(chan *amqp.Error, 1)
 	rc.conn.NotifyClose(rc.connErrCh)
 
 	err = rc.openChannel()
 	if err != nil {
+		_ = conn.Close()
 		return err
 	}
 
@@ -142,45 +355,109 @@ func (rc *rabbitMqClient) openChannel() error {
 	}
 	rc.ch = ch
 
-	rc.chanErr = make(chan *amqp.Error)
+	rc.chanErr = make(chan *amqp.Error, 1)
 	rc.ch.NotifyClose(rc.chanErr)
-	rc.ackCh, rc.nackCh = rc.ch.NotifyConfirm(make(chan uint64), make(chan uint64))
+	rc.ackCh, rc.nackCh = rc.ch.NotifyConfirm(make(chan uint64, confirmsBufferSize), make(chan uint64, confirmsBufferSize))
+	rc.lastDeliveryTag = 0
 
-	return rc.ch.Confirm(false)
-}
+	err = rc.ch.Confirm(false)
+	if err != nil {
+		return err
+	}
 
-// Reconnect will try to reconnect to rabbitmq
-func (rc *rabbitMqClient) Reconnect() {
-	for {
-		time.Sleep(time.Millisecond * reconnectRetryMs)
+	return rc.redeclareExchanges()
+}
 
-		err := rc.connect()
+// redeclareExchanges declares again the exchanges, in case they were lost on server restart
+func (rc *rabbitMqClient) redeclareExchanges() error {
+	for name, declaration := range rc.exchanges {
+		err := rc.declareExchange(name, declaration)
 		if err != nil {
-			log.Debug("could not reconnect", "err", err.Error())
-		} else {
-			log.Debug("connection established after reconnect attempts")
-			break
+			return err
 		}
 	}
+
+	return nil
 }
 
-// ReopenChannel will try to reopen communication channel
+// Reconnect will try to reconnect to rabbitmq, with exponential backoff
+func (rc *rabbitMqClient) Reconnect() {
+	rc.retryWithBackoff("reconnect", rc.connect)
+}
+
+// ReopenChannel will try to reopen communication channel, with exponential backoff
 func (rc *rabbitMqClient) ReopenChannel() {
+	rc.retryWithBackoff("re-open channel", rc.reopenChannel)
+}
+
+func (rc *rabbitMqClient) reopenChannel() error {
+	rc.mutConn.RLock()
+	isConnClosed := rc.conn.IsClosed()
+	rc.mutConn.RUnlock()
+
+	// the channel is also closed when the connection is lost
+	if isConnClosed {
+		return rc.connect()
+	}
+
+	rc.mutConn.Lock()
+	defer rc.mutConn.Unlock()
+
+	return rc.openChannel()
+}
+
+func (rc *rabbitMqClient) retryWithBackoff(operation string, handler func() error) {
+	retryInterval := time.Millisecond * reconnectRetryMs
+	maxRetryInterval := time.Millisecond * maxReconnectRetryMs
+
 	for {
-		time.Sleep(time.Millisecond * reconnectRetryMs)
+		select {
+		case <-rc.closeChan:
+			return
+		case <-time.After(retryInterval):
+		}
 
-		err := rc.openChannel()
-		if err != nil {
-			log.Debug("could not re-open channel", "err", err.Error())
-		} else {
-			log.Debug("channel opened after reconnect attempts")
-			break
+		err := handler()
+		if err == nil {
+			log.Info("rabbitMQ connection recovered", "operation", operation)
+			rc.setConnected(true)
+			return
+		}
+
+		log.Debug("could not "+operation, "retry interval", retryInterval, "err", err.Error())
+
+		retryInterval *= 2
+		if retryInterval > maxRetryInterval {
+			retryInterval = maxRetryInterval
 		}
 	}
 }
 
+func (rc *rabbitMqClient) setConnected(isConnected bool) {
+	rc.mutConn.Lock()
+	rc.isConnected = isConnected
+	rc.mutConn.Unlock()
+}
+
+// IsConnected returns true if the connection and the channel to the rabbitMq server are open
+func (rc *rabbitMqClient) IsConnected() bool {
+	rc.mutConn.RLock()
+	defer rc.mutConn.RUnlock()
+
+	return rc.isConnected && rc.conn != nil && !rc.conn.IsClosed()
+}
+
 // Close will close rabbitMq client connection
 func (rc *rabbitMqClient) Close() {
+	rc.closeOnce.Do(func() {
+		close(rc.closeChan)
+	})
+
+	rc.mutConn.Lock()
+	defer rc.mutConn.Unlock()
+
+	rc.isConnected = false
+
 	err := rc.ch.Close()
 	if err != nil {
 		log.Error("failed to close rabbitMQ channel", "err", err.Error())
@@ -0,0 +1,108 @@
+package rabbitmq
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/multiversx/mx-chain-notifier-go/data"
+)
+
+const (
+	shardIDPlaceholder    = "<shardID>"
+	addressPlaceholder    = "<address>"
+	identifierPlaceholder = "<identifier>"
+
+	// emptyRoutingKeyWord replaces empty values, so that the number of words in the
+	// routing key is always the same
+	emptyRoutingKeyWord = "_"
+
+	// maxRoutingKeyLength is the maximum length of a routing key accepted by AMQP 0-9-1
+	maxRoutingKeyLength = 255
+)
+
+// routingKeyBuilder renders the routing key of an event based on a template
+type routingKeyBuilder struct {
+	template string
+}
+
+func newRoutingKeyBuilder(template string) *routingKeyBuilder {
+	return &routingKeyBuilder{
+		template: template,
+	}
+}
+
+// buildRoutingKey replaces the template placeholders with the sanitized event values
+func (rb *routingKeyBuilder) buildRoutingKey(shardID uint32, event data.Event) string {
+	replacer := strings.NewReplacer(
+		shardIDPlaceholder, fmt.Sprintf("%d", shardID),
+		addressPlaceholder, sanitizeRoutingKeyWord(event.Address),
+		identifierPlaceholder, sanitizeRoutingKeyWord(event.Identifier),
+	)
+
+	routingKey := replacer.Replace(rb.template)
+	if len(routingKey) > maxRoutingKeyLength {
+		return routingKey[:maxRoutingKeyLength]
+	}
+
+	return routingKey
+}
+
+// sanitizeRoutingKeyWord makes sure the value can be used as a single routing key word.
+// Values containing dots, which separate the words of topic routing keys, topic wildcards
+// or any other character besides letters, digits, "_" and "-" are hex encoded
+func sanitizeRoutingKeyWord(value string) string {
+	if len(value) == 0 {
+		return emptyRoutingKeyWord
+	}
+
+	for _, c := range value {
+		if !isValidRoutingKeyChar(c) {
+			return hex.EncodeToString([]byte(value))
+		}
+	}
+
+	return value
+}
+
+func isValidRoutingKeyChar(c rune) bool {
+	switch {
+	case c >= 'a' && c <= 'z':
+		return true
+	case c >= 'A' && c <= 'Z':
+		return true
+	case c >= '0' && c <= '9':
+		return true
+	case c == '_' || c == '-':
+		return true
+	default:
+		return false
+	}
+}
+
+// groupEventsByRoutingKey splits the block events into groups with the same routing key,
+// keeping the order of the events and of the groups by first occurrence
+func (rb *routingKeyBuilder) groupEventsByRoutingKey(blockEvents data.BlockEvents) ([]string, map[string]data.BlockEvents) {
+	routingKeys := make([]string, 0)
+	groups := make(map[string]data.BlockEvents)
+
+	for _, event := range blockEvents.Events {
+		routingKey := rb.buildRoutingKey(blockEvents.ShardID, event)
+
+		group, ok := groups[routingKey]
+		if !ok {
+			routingKeys = append(routingKeys, routingKey)
+			group = data.BlockEvents{
+				Hash:      blockEvents.Hash,
+				ShardID:   blockEvents.ShardID,
+				TimeStamp: blockEvents.TimeStamp,
+				Events:    make([]data.Event, 0),
+			}
+		}
+
+		group.Events = append(group.Events, event)
+		groups[routingKey] = group
+	}
+
+	return routingKeys, groups
+}
@@ -0,0 +1,56 @@
+package redis
+
+import (
+	"context"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+)
+
+// ArgsRedisBackplane defines the arguments needed for redis backplane creation
+type ArgsRedisBackplane struct {
+	Client  BackplaneClient
+	Channel string
+}
+
+type redisBackplane struct {
+	client  BackplaneClient
+	channel string
+}
+
+// NewRedisBackplane creates a backplane which shares the hub broadcasts between the notifier
+// instances through a redis pubsub channel. Redis pubsub is fire and forget, so the instances
+// which are not subscribed when a broadcast is published do not receive it
+func NewRedisBackplane(args ArgsRedisBackplane) (*redisBackplane, error) {
+	if check.IfNil(args.Client) {
+		return nil, ErrNilBackplaneClient
+	}
+	if args.Channel == "" {
+		return nil, ErrInvalidRedisChannelName
+	}
+
+	return &redisBackplane{
+		client:  args.Client,
+		channel: args.Channel,
+	}, nil
+}
+
+// Publish will publish the payload on the backplane channel
+func (rb *redisBackplane) Publish(ctx context.Context, payload []byte) error {
+	return rb.client.Publish(ctx, rb.channel, payload)
+}
+
+// Subscribe will call the handler for each payload published on the backplane channel, until
+// the context is done
+func (rb *redisBackplane) Subscribe(ctx context.Context, handler func(payload []byte)) error {
+	return rb.client.Subscribe(ctx, rb.channel, handler)
+}
+
+// Close will close the redis client
+func (rb *redisBackplane) Close() error {
+	return rb.client.Close()
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (rb *redisBackplane) IsInterfaceNil() bool {
+	return rb == nil
+}
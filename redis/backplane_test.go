@@ -0,0 +1,75 @@
+package redis_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-notifier-go/mocks"
+	"github.com/multiversx/mx-chain-notifier-go/redis"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRedisBackplane(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil client", func(t *testing.T) {
+		t.Parallel()
+
+		backplane, err := redis.NewRedisBackplane(redis.ArgsRedisBackplane{Channel: "channel"})
+		require.True(t, check.IfNil(backplane))
+		require.Equal(t, redis.ErrNilBackplaneClient, err)
+	})
+
+	t.Run("empty channel", func(t *testing.T) {
+		t.Parallel()
+
+		backplane, err := redis.NewRedisBackplane(redis.ArgsRedisBackplane{Client: &mocks.PubSubClientStub{}})
+		require.True(t, check.IfNil(backplane))
+		require.Equal(t, redis.ErrInvalidRedisChannelName, err)
+	})
+
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		backplane, err := redis.NewRedisBackplane(redis.ArgsRedisBackplane{
+			Client:  &mocks.PubSubClientStub{},
+			Channel: "channel",
+		})
+		require.Nil(t, err)
+		require.False(t, check.IfNil(backplane))
+	})
+}
+
+func TestRedisBackplane_PublishAndSubscribe(t *testing.T) {
+	t.Parallel()
+
+	var publishedChannel, subscribedChannel string
+	client := &mocks.PubSubClientStub{
+		PublishCalled: func(channel string, payload []byte) error {
+			publishedChannel = channel
+			return nil
+		},
+		SubscribeCalled: func(ctx context.Context, channel string, handler func(payload []byte)) error {
+			subscribedChannel = channel
+			handler([]byte("payload"))
+			return nil
+		},
+	}
+	backplane, _ := redis.NewRedisBackplane(redis.ArgsRedisBackplane{
+		Client:  client,
+		Channel: "backplane",
+	})
+
+	err := backplane.Publish(context.Background(), []byte("payload"))
+	require.Nil(t, err)
+	require.Equal(t, "backplane", publishedChannel)
+
+	var received []byte
+	err = backplane.Subscribe(context.Background(), func(payload []byte) {
+		received = payload
+	})
+	require.Nil(t, err)
+	require.Equal(t, "backplane", subscribedChannel)
+	require.Equal(t, []byte("payload"), received)
+}
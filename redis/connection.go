@@ -29,6 +29,60 @@ func CreateSimpleClient(cfg config.RedisConfig) (RedLockClient, error) {
 	return rc, nil
 }
 
+// CreatePubSubClient will create a redis client for publishing on channels. The client
+// reconnects automatically, so the connection is not required at startup
+func CreatePubSubClient(cfg config.RedisPubSubConfig) (PubSubClient, error) {
+	opt, err := getPubSubClientOptions(cfg)
+	if err != nil {
+		return nil, err
+	}
+	client := redis.NewClient(opt)
+
+	log.Debug("created redis pubsub connection", "address", opt.Addr, "db", opt.DB)
+
+	rc := NewRedisClientWrapper(client)
+	ok := rc.IsConnected(context.Background())
+	if !ok {
+		log.Warn("redis pubsub server is not reachable, will retry on publish", "address", opt.Addr)
+	}
+
+	return rc, nil
+}
+
+// getPubSubClientOptions uses the address, the password and the database index, if the
+// address is set, otherwise it parses the url
+func getPubSubClientOptions(cfg config.RedisPubSubConfig) (*redis.Options, error) {
+	if cfg.Address == "" {
+		return redis.ParseURL(cfg.Url)
+	}
+
+	return &redis.Options{
+		Addr:     cfg.Address,
+		Password: cfg.Password,
+		DB:       cfg.DB,
+	}, nil
+}
+
+// CreateBackplaneClient will create a redis client for publishing and subscribing on the
+// backplane channel. The client reconnects automatically, so the connection is not required at startup
+func CreateBackplaneClient(url string) (BackplaneClient, error) {
+	opt, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, err
+	}
+	client := redis.NewClient(opt)
+
+	log.Debug("created redis backplane connection", "connection url", url)
+
+	rc := NewRedisClientWrapper(client)
+	ok := rc.IsConnected(context.Background())
+	if !ok {
+		log.Warn("redis backplane server is not reachable, will retry", "connection url", url)
+	}
+
+	return rc, nil
+}
+
 // CreateFailoverClient will create a redis client for a redis setup with sentinel
 func CreateFailoverClient(cfg config.RedisConfig) (RedLockClient, error) {
 	client := redis.NewFailoverClient(&redis.FailoverOptions{
@@ -10,3 +10,18 @@ var ErrRedisConnectionFailed = errors.New("error connecting to redis")
 
 // ErrZeroValueReceived signals that a zero value has been received
 var ErrZeroValueReceived = errors.New("zero value received")
+
+// ErrNilPubSubClient signals that a nil pubsub client has been provided
+var ErrNilPubSubClient = errors.New("nil pubsub client")
+
+// ErrInvalidRedisChannelName signals that an empty redis channel name has been provided
+var ErrInvalidRedisChannelName = errors.New("invalid redis channel name")
+
+// ErrPublishBufferFull signals that the event was dropped since the publish buffer is full
+var ErrPublishBufferFull = errors.New("publish buffer is full, event dropped")
+
+// ErrNilBackplaneClient signals that a nil backplane client has been provided
+var ErrNilBackplaneClient = errors.New("nil backplane client")
+
+// ErrSubscriptionClosed signals that the redis channel subscription was closed
+var ErrSubscriptionClosed = errors.New("redis subscription closed")
@@ -9,13 +9,30 @@ import (
 // It makes sure that a duplicated entry is not processed multiple times.
 type LockService interface {
 	IsEventProcessed(ctx context.Context, blockHash string) (bool, error)
+	RemoveEvent(ctx context.Context, blockHash string) error
 	HasConnection(ctx context.Context) bool
 	IsInterfaceNil() bool
 }
 
+// PubSubClient defines the behaviour of a redis client able to publish messages on channels
+type PubSubClient interface {
+	Publish(ctx context.Context, channel string, payload []byte) error
+	IsConnected(ctx context.Context) bool
+	Close() error
+	IsInterfaceNil() bool
+}
+
+// BackplaneClient defines the behaviour of a redis client able to publish messages on channels
+// and to receive the messages published on them. Subscribe blocks until the context is done
+type BackplaneClient interface {
+	PubSubClient
+	Subscribe(ctx context.Context, channel string, handler func(payload []byte)) error
+}
+
 // RedLockClient defines the behaviour of a cache handler component
 type RedLockClient interface {
 	SetEntry(ctx context.Context, key string, value bool, ttl time.Duration) (bool, error)
+	RemoveEntry(ctx context.Context, key string) error
 	Ping(ctx context.Context) (string, error)
 	IsConnected(ctx context.Context) bool
 	IsInterfaceNil() bool
@@ -0,0 +1,377 @@
+package redis
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/marshal"
+	"github.com/multiversx/mx-chain-notifier-go/common"
+	"github.com/multiversx/mx-chain-notifier-go/config"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/metrics"
+)
+
+const (
+	publishSuccessPromMetric  = "redis_publish_success"
+	publishFailuresPromMetric = "redis_publish_failures"
+	droppedEventsPromMetric   = "redis_dropped_events"
+	publishRetriesPromMetric  = "redis_publish_retries"
+	bufferedEventsPromMetric  = "redis_buffered_events"
+	channelPromLabel          = "channel"
+
+	defaultPublishTimeout = time.Second
+)
+
+// ArgsRedisPublisher defines the arguments needed for redis pubsub publisher creation
+type ArgsRedisPublisher struct {
+	Client     PubSubClient
+	Config     config.RedisPubSubConfig
+	Marshaller marshal.Marshalizer
+
+	// AckAfterPublish returns the publish errors instead of buffering the events while the
+	// redis server is not reachable, since a buffered event is not yet published
+	AckAfterPublish bool
+}
+
+type redisPublisher struct {
+	client                 PubSubClient
+	marshaller             marshal.Marshalizer
+	cfg                    config.RedisPubSubConfig
+	eventsChannel          string
+	revertEventsChannel    string
+	finalizedEventsChannel string
+	publishTimeout         time.Duration
+	retryInterval          time.Duration
+	maxAttempts            uint32
+	ackAfterPublish        bool
+
+	// mutPublish serializes publishing, so that buffered events are flushed in order
+	mutPublish sync.Mutex
+	buffer     []*bufferedEvent
+
+	mutMetrics         sync.RWMutex
+	numPublishSuccess  map[string]uint64
+	numPublishFailures map[string]uint64
+	numDroppedEvents   map[string]uint64
+	numPublishRetries  map[string]uint64
+	numBufferedEvents  uint64
+}
+
+// bufferedEvent holds an event received while disconnected from the redis server
+type bufferedEvent struct {
+	channel string
+	hash    string
+	payload []byte
+}
+
+// NewRedisPublisher creates a new redis pubsub publisher instance. Only the block events,
+// revert and finalized events are published, the other events are ignored. The channel
+// prefix, if set, is prepended to all the channel names
+func NewRedisPublisher(args ArgsRedisPublisher) (*redisPublisher, error) {
+	err := checkPublisherArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	publishTimeout := time.Duration(args.Config.PublishTimeoutInMs) * time.Millisecond
+	if publishTimeout == 0 {
+		publishTimeout = defaultPublishTimeout
+	}
+
+	maxAttempts := args.Config.PublishMaxAttempts
+	if maxAttempts == 0 {
+		maxAttempts = 1
+	}
+
+	return &redisPublisher{
+		client:                 args.Client,
+		marshaller:             args.Marshaller,
+		cfg:                    args.Config,
+		eventsChannel:          args.Config.ChannelPrefix + args.Config.EventsChannel,
+		revertEventsChannel:    args.Config.ChannelPrefix + args.Config.RevertEventsChannel,
+		finalizedEventsChannel: args.Config.ChannelPrefix + args.Config.FinalizedEventsChannel,
+		publishTimeout:         publishTimeout,
+		retryInterval:          time.Duration(args.Config.RetryIntervalInMs) * time.Millisecond,
+		maxAttempts:            maxAttempts,
+		ackAfterPublish:        args.AckAfterPublish,
+		buffer:                 make([]*bufferedEvent, 0),
+		numPublishSuccess:      make(map[string]uint64),
+		numPublishFailures:     make(map[string]uint64),
+		numDroppedEvents:       make(map[string]uint64),
+		numPublishRetries:      make(map[string]uint64),
+	}, nil
+}
+
+func checkPublisherArgs(args ArgsRedisPublisher) error {
+	if check.IfNil(args.Client) {
+		return ErrNilPubSubClient
+	}
+	if check.IfNil(args.Marshaller) {
+		return common.ErrNilMarshaller
+	}
+	if args.Config.EventsChannel == "" {
+		return ErrInvalidRedisChannelName
+	}
+	if args.Config.RevertEventsChannel == "" {
+		return ErrInvalidRedisChannelName
+	}
+	if args.Config.FinalizedEventsChannel == "" {
+		return ErrInvalidRedisChannelName
+	}
+
+	return nil
+}
+
+// Publish will publish logs and events to the redis events channel
+func (rp *redisPublisher) Publish(ctx context.Context, events data.BlockEvents) error {
+	eventsBytes, err := rp.marshaller.Marshal(events)
+	if err != nil {
+		log.Error("could not marshal events", "err", err.Error())
+		return err
+	}
+
+	err = rp.publishToChannel(ctx, rp.eventsChannel, events.Hash, eventsBytes)
+	if err != nil {
+		log.Error("failed to publish events to redis", "hash", events.Hash, "err", err.Error())
+	}
+
+	return err
+}
+
+// PublishRevert will publish revert event to the redis revert events channel
+func (rp *redisPublisher) PublishRevert(ctx context.Context, revertBlock data.RevertBlock) error {
+	revertBlockBytes, err := rp.marshaller.Marshal(revertBlock)
+	if err != nil {
+		log.Error("could not marshal revert event", "err", err.Error())
+		return err
+	}
+
+	err = rp.publishToChannel(ctx, rp.revertEventsChannel, revertBlock.Hash, revertBlockBytes)
+	if err != nil {
+		log.Error("failed to publish revert event to redis", "hash", revertBlock.Hash, "err", err.Error())
+	}
+
+	return err
+}
+
+// PublishFinalized will publish finalized event to the redis finalized events channel
+func (rp *redisPublisher) PublishFinalized(ctx context.Context, finalizedBlock data.FinalizedBlock) error {
+	finalizedBlockBytes, err := rp.marshaller.Marshal(finalizedBlock)
+	if err != nil {
+		log.Error("could not marshal finalized event", "err", err.Error())
+		return err
+	}
+
+	err = rp.publishToChannel(ctx, rp.finalizedEventsChannel, finalizedBlock.Hash, finalizedBlockBytes)
+	if err != nil {
+		log.Error("failed to publish finalized event to redis", "hash", finalizedBlock.Hash, "err", err.Error())
+	}
+
+	return err
+}
+
+// PublishTxs does nothing, block txs are not published on redis
+func (rp *redisPublisher) PublishTxs(_ context.Context, _ data.BlockTxs) error {
+	return nil
+}
+
+// PublishScrs does nothing, block scrs are not published on redis
+func (rp *redisPublisher) PublishScrs(_ context.Context, _ data.BlockScrs) error {
+	return nil
+}
+
+// PublishBlockEventsWithOrder does nothing, full block events are not published on redis
+func (rp *redisPublisher) PublishBlockEventsWithOrder(_ context.Context, _ data.BlockEventsWithOrder) error {
+	return nil
+}
+
+// PublishTxEvents does nothing, tx events are not published on redis
+func (rp *redisPublisher) PublishTxEvents(_ context.Context, _ data.BlockTxEvents) error {
+	return nil
+}
+
+// PublishRounds does nothing, rounds info is not published on redis
+func (rp *redisPublisher) PublishRounds(_ context.Context, _ data.RoundEvents) error {
+	return nil
+}
+
+// PublishValidatorsRating does nothing, validators rating is not published on redis
+func (rp *redisPublisher) PublishValidatorsRating(_ context.Context, _ data.ValidatorsRatingEvent) error {
+	return nil
+}
+
+// PublishAccounts does nothing, altered accounts are not published on redis
+func (rp *redisPublisher) PublishAccounts(_ context.Context, _ data.AccountsEvents) error {
+	return nil
+}
+
+// publishToChannel publishes the payload to the redis channel. While the redis server is
+// not reachable, the events are buffered and they are published in the same order after
+// the connection is recovered, before any new event, unless the ack is sent after publish,
+// in which case the publish error is returned. The redis client reconnects on its own, so
+// the connection is checked again on each publish
+func (rp *redisPublisher) publishToChannel(ctx context.Context, channel string, hash string, payload []byte) error {
+	rp.mutPublish.Lock()
+	defer rp.mutPublish.Unlock()
+
+	event := &bufferedEvent{
+		channel: channel,
+		hash:    hash,
+		payload: payload,
+	}
+
+	rp.flushBuffer(ctx)
+	if len(rp.buffer) > 0 {
+		return rp.bufferEvent(event)
+	}
+
+	err := rp.publishEventWithRetries(ctx, event)
+	if err != nil && rp.ackAfterPublish {
+		return err
+	}
+	if err != nil {
+		log.Debug("failed to publish to redis, buffering event", "channel", channel, "err", err.Error())
+		return rp.bufferEvent(event)
+	}
+
+	return nil
+}
+
+// flushBuffer publishes the buffered events, in order, stopping at the first failure
+func (rp *redisPublisher) flushBuffer(ctx context.Context) {
+	for len(rp.buffer) > 0 {
+		err := rp.publishEvent(ctx, rp.buffer[0])
+		if err != nil {
+			return
+		}
+
+		rp.buffer = rp.buffer[1:]
+		rp.setNumBufferedEvents()
+	}
+}
+
+// publishEventWithRetries retries the failed publishes while the redis server is reachable,
+// up to the max number of attempts. While it is not reachable, the event is buffered
+// right away, since it will be published after the connection is recovered. The retries
+// stop when the context is done
+func (rp *redisPublisher) publishEventWithRetries(ctx context.Context, event *bufferedEvent) error {
+	err := rp.publishEvent(ctx, event)
+	for attempt := uint32(2); err != nil && attempt <= rp.maxAttempts; attempt++ {
+		if !rp.client.IsConnected(context.Background()) {
+			return err
+		}
+
+		log.Debug("failed to publish to redis, will retry",
+			"channel", event.channel,
+			"hash", event.hash,
+			"attempt", attempt,
+			"err", err.Error(),
+		)
+
+		rp.mutMetrics.Lock()
+		rp.numPublishRetries[event.channel]++
+		rp.mutMetrics.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(rp.retryInterval):
+		}
+		err = rp.publishEvent(ctx, event)
+	}
+
+	return err
+}
+
+func (rp *redisPublisher) publishEvent(ctx context.Context, event *bufferedEvent) error {
+	ctx, cancel := context.WithTimeout(ctx, rp.publishTimeout)
+	defer cancel()
+
+	err := rp.client.Publish(ctx, event.channel, event.payload)
+
+	rp.mutMetrics.Lock()
+	if err != nil {
+		rp.numPublishFailures[event.channel]++
+	} else {
+		rp.numPublishSuccess[event.channel]++
+	}
+	rp.mutMetrics.Unlock()
+
+	return err
+}
+
+// bufferEvent adds the event to the buffer. If the buffer is full, the new event is
+// dropped, in order to keep the buffered events contiguous
+func (rp *redisPublisher) bufferEvent(event *bufferedEvent) error {
+	if uint32(len(rp.buffer)) >= rp.cfg.MaxBufferedEvents {
+		rp.mutMetrics.Lock()
+		rp.numDroppedEvents[event.channel]++
+		rp.mutMetrics.Unlock()
+
+		return ErrPublishBufferFull
+	}
+
+	rp.buffer = append(rp.buffer, event)
+	rp.setNumBufferedEvents()
+
+	log.Debug("redis not reachable, buffered event",
+		"channel", event.channel,
+		"hash", event.hash,
+		"num buffered", len(rp.buffer),
+	)
+
+	return nil
+}
+
+func (rp *redisPublisher) setNumBufferedEvents() {
+	rp.mutMetrics.Lock()
+	rp.numBufferedEvents = uint64(len(rp.buffer))
+	rp.mutMetrics.Unlock()
+}
+
+// GetMetricsForPrometheus returns the number of successful, failed, retried and dropped publish
+// operations for each channel, together with the number of buffered events, in prometheus format
+func (rp *redisPublisher) GetMetricsForPrometheus() string {
+	rp.mutMetrics.RLock()
+	defer rp.mutMetrics.RUnlock()
+
+	stringBuilder := strings.Builder{}
+	stringBuilder.WriteString(metrics.CounterMetrics(publishSuccessPromMetric, channelPromLabel, rp.numPublishSuccess))
+	stringBuilder.WriteString(metrics.CounterMetrics(publishFailuresPromMetric, channelPromLabel, rp.numPublishFailures))
+	stringBuilder.WriteString(metrics.CounterMetrics(droppedEventsPromMetric, channelPromLabel, rp.numDroppedEvents))
+	stringBuilder.WriteString(metrics.CounterMetrics(publishRetriesPromMetric, channelPromLabel, rp.numPublishRetries))
+	stringBuilder.WriteString(metrics.GaugeMetric(bufferedEventsPromMetric, rp.numBufferedEvents))
+
+	return stringBuilder.String()
+}
+
+// GetHealthState returns up if the redis server is reachable
+func (rp *redisPublisher) GetHealthState() string {
+	if rp.client.IsConnected(context.Background()) {
+		return common.HealthStateUp
+	}
+
+	return common.HealthStateDown
+}
+
+// Ping returns an error if the redis server is not reachable
+func (rp *redisPublisher) Ping(ctx context.Context) error {
+	if !rp.client.IsConnected(ctx) {
+		return ErrRedisConnectionFailed
+	}
+
+	return nil
+}
+
+// Close will close the redis client
+func (rp *redisPublisher) Close() error {
+	return rp.client.Close()
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (rp *redisPublisher) IsInterfaceNil() bool {
+	return rp == nil
+}
@@ -0,0 +1,476 @@
+package redis_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/go-redis/redis/v8"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/marshal"
+	"github.com/multiversx/mx-chain-notifier-go/common"
+	"github.com/multiversx/mx-chain-notifier-go/config"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/mocks"
+	"github.com/multiversx/mx-chain-notifier-go/process"
+	"github.com/multiversx/mx-chain-notifier-go/redis"
+	"github.com/stretchr/testify/require"
+)
+
+func createMockArgsRedisPublisher() redis.ArgsRedisPublisher {
+	return redis.ArgsRedisPublisher{
+		Client: &mocks.PubSubClientStub{},
+		Config: config.RedisPubSubConfig{
+			Url:                    "redis://localhost:6379/0",
+			EventsChannel:          "all_events",
+			RevertEventsChannel:    "revert_events",
+			FinalizedEventsChannel: "finalized_events",
+			PublishTimeoutInMs:     100,
+			MaxBufferedEvents:      2,
+		},
+		Marshaller: &marshal.JsonMarshalizer{},
+	}
+}
+
+func TestNewRedisPublisher(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil client, should fail", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsRedisPublisher()
+		args.Client = nil
+
+		publisher, err := redis.NewRedisPublisher(args)
+		require.True(t, check.IfNil(publisher))
+		require.Equal(t, redis.ErrNilPubSubClient, err)
+	})
+
+	t.Run("nil marshaller, should fail", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsRedisPublisher()
+		args.Marshaller = nil
+
+		publisher, err := redis.NewRedisPublisher(args)
+		require.True(t, check.IfNil(publisher))
+		require.Equal(t, common.ErrNilMarshaller, err)
+	})
+
+	t.Run("empty channel name, should fail", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsRedisPublisher()
+		args.Config.FinalizedEventsChannel = ""
+
+		publisher, err := redis.NewRedisPublisher(args)
+		require.True(t, check.IfNil(publisher))
+		require.Equal(t, redis.ErrInvalidRedisChannelName, err)
+	})
+
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		publisher, err := redis.NewRedisPublisher(createMockArgsRedisPublisher())
+		require.Nil(t, err)
+		require.False(t, check.IfNil(publisher))
+	})
+}
+
+func TestRedisPublisher_Publish(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should publish json events on the configured channels", func(t *testing.T) {
+		t.Parallel()
+
+		published := make(map[string][]byte)
+		args := createMockArgsRedisPublisher()
+		args.Client = &mocks.PubSubClientStub{
+			PublishCalled: func(channel string, payload []byte) error {
+				published[channel] = payload
+				return nil
+			},
+		}
+
+		publisher, err := redis.NewRedisPublisher(args)
+		require.Nil(t, err)
+
+		publisher.Publish(context.Background(), data.BlockEvents{Hash: "hash1"})
+		publisher.PublishRevert(context.Background(), data.RevertBlock{Hash: "hash2"})
+		publisher.PublishFinalized(context.Background(), data.FinalizedBlock{Hash: "hash3"})
+		publisher.PublishTxs(context.Background(), data.BlockTxs{Hash: "hash4"})
+
+		require.Len(t, published, 3)
+
+		events := data.BlockEvents{}
+		err = args.Marshaller.Unmarshal(&events, published["all_events"])
+		require.Nil(t, err)
+		require.Equal(t, "hash1", events.Hash)
+
+		revertBlock := data.RevertBlock{}
+		err = args.Marshaller.Unmarshal(&revertBlock, published["revert_events"])
+		require.Nil(t, err)
+		require.Equal(t, "hash2", revertBlock.Hash)
+
+		finalizedBlock := data.FinalizedBlock{}
+		err = args.Marshaller.Unmarshal(&finalizedBlock, published["finalized_events"])
+		require.Nil(t, err)
+		require.Equal(t, "hash3", finalizedBlock.Hash)
+
+		require.Contains(t, publisher.GetMetricsForPrometheus(), `redis_publish_success{channel="all_events"} 1`)
+	})
+
+	t.Run("should buffer events while disconnected and publish them in order", func(t *testing.T) {
+		t.Parallel()
+
+		mutPublished := sync.Mutex{}
+		isConnected := false
+		published := make([]string, 0)
+
+		args := createMockArgsRedisPublisher()
+		args.Client = &mocks.PubSubClientStub{
+			PublishCalled: func(channel string, payload []byte) error {
+				mutPublished.Lock()
+				defer mutPublished.Unlock()
+
+				if !isConnected {
+					return errors.New("connection refused")
+				}
+
+				finalizedBlock := data.FinalizedBlock{}
+				_ = args.Marshaller.Unmarshal(&finalizedBlock, payload)
+				published = append(published, finalizedBlock.Hash)
+				return nil
+			},
+		}
+
+		publisher, err := redis.NewRedisPublisher(args)
+		require.Nil(t, err)
+
+		publisher.PublishFinalized(context.Background(), data.FinalizedBlock{Hash: "hash1"})
+		publisher.PublishFinalized(context.Background(), data.FinalizedBlock{Hash: "hash2"})
+		publisher.PublishFinalized(context.Background(), data.FinalizedBlock{Hash: "hash3"})
+		require.Empty(t, published)
+
+		metrics := publisher.GetMetricsForPrometheus()
+		require.Contains(t, metrics, "redis_buffered_events 2")
+		require.Contains(t, metrics, `redis_dropped_events{channel="finalized_events"} 1`)
+
+		mutPublished.Lock()
+		isConnected = true
+		mutPublished.Unlock()
+
+		publisher.PublishFinalized(context.Background(), data.FinalizedBlock{Hash: "hash4"})
+		require.Equal(t, []string{"hash1", "hash2", "hash4"}, published)
+		require.Contains(t, publisher.GetMetricsForPrometheus(), "redis_buffered_events 0")
+	})
+
+	t.Run("ack after publish should return the error and not buffer the events", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("connection refused")
+		args := createMockArgsRedisPublisher()
+		args.AckAfterPublish = true
+		args.Client = &mocks.PubSubClientStub{
+			PublishCalled: func(channel string, payload []byte) error {
+				return expectedErr
+			},
+		}
+
+		publisher, err := redis.NewRedisPublisher(args)
+		require.Nil(t, err)
+
+		err = publisher.Publish(context.Background(), data.BlockEvents{Hash: "hash1"})
+		require.True(t, errors.Is(err, expectedErr))
+		require.Contains(t, publisher.GetMetricsForPrometheus(), "redis_buffered_events 0")
+	})
+}
+
+func TestRedisPublisher_ChannelPrefix(t *testing.T) {
+	t.Parallel()
+
+	channels := make([]string, 0)
+	args := createMockArgsRedisPublisher()
+	args.Config.ChannelPrefix = "notifier:"
+	args.Client = &mocks.PubSubClientStub{
+		PublishCalled: func(channel string, payload []byte) error {
+			channels = append(channels, channel)
+			return nil
+		},
+	}
+
+	publisher, err := redis.NewRedisPublisher(args)
+	require.Nil(t, err)
+
+	publisher.Publish(context.Background(), data.BlockEvents{Hash: "hash1"})
+	publisher.PublishRevert(context.Background(), data.RevertBlock{Hash: "hash2"})
+	publisher.PublishFinalized(context.Background(), data.FinalizedBlock{Hash: "hash3"})
+
+	require.Equal(t, []string{"notifier:all_events", "notifier:revert_events", "notifier:finalized_events"}, channels)
+}
+
+func TestRedisPublisher_Retries(t *testing.T) {
+	t.Parallel()
+
+	t.Run("failed publish should be retried while connected", func(t *testing.T) {
+		t.Parallel()
+
+		numPublishCalls := 0
+		args := createMockArgsRedisPublisher()
+		args.Config.PublishMaxAttempts = 3
+		args.Client = &mocks.PubSubClientStub{
+			PublishCalled: func(channel string, payload []byte) error {
+				numPublishCalls++
+				if numPublishCalls < 3 {
+					return errors.New("temporary failure")
+				}
+
+				return nil
+			},
+			IsConnectedCalled: func() bool {
+				return true
+			},
+		}
+
+		publisher, err := redis.NewRedisPublisher(args)
+		require.Nil(t, err)
+
+		publisher.PublishRevert(context.Background(), data.RevertBlock{Hash: "hash1"})
+
+		require.Equal(t, 3, numPublishCalls)
+		metrics := publisher.GetMetricsForPrometheus()
+		require.Contains(t, metrics, `redis_publish_retries{channel="revert_events"} 2`)
+		require.Contains(t, metrics, `redis_publish_failures{channel="revert_events"} 2`)
+		require.Contains(t, metrics, `redis_publish_success{channel="revert_events"} 1`)
+		require.Contains(t, metrics, "redis_buffered_events 0")
+	})
+
+	t.Run("event should be buffered after max attempts", func(t *testing.T) {
+		t.Parallel()
+
+		numPublishCalls := 0
+		args := createMockArgsRedisPublisher()
+		args.Config.PublishMaxAttempts = 2
+		args.Client = &mocks.PubSubClientStub{
+			PublishCalled: func(channel string, payload []byte) error {
+				numPublishCalls++
+				return errors.New("publish failure")
+			},
+			IsConnectedCalled: func() bool {
+				return true
+			},
+		}
+
+		publisher, err := redis.NewRedisPublisher(args)
+		require.Nil(t, err)
+
+		publisher.Publish(context.Background(), data.BlockEvents{Hash: "hash1"})
+
+		require.Equal(t, 2, numPublishCalls)
+		require.Contains(t, publisher.GetMetricsForPrometheus(), "redis_buffered_events 1")
+	})
+
+	t.Run("failed publish should not be retried while disconnected", func(t *testing.T) {
+		t.Parallel()
+
+		numPublishCalls := 0
+		args := createMockArgsRedisPublisher()
+		args.Config.PublishMaxAttempts = 3
+		args.Client = &mocks.PubSubClientStub{
+			PublishCalled: func(channel string, payload []byte) error {
+				numPublishCalls++
+				return errors.New("connection refused")
+			},
+		}
+
+		publisher, err := redis.NewRedisPublisher(args)
+		require.Nil(t, err)
+
+		publisher.PublishFinalized(context.Background(), data.FinalizedBlock{Hash: "hash1"})
+
+		require.Equal(t, 1, numPublishCalls)
+		require.Contains(t, publisher.GetMetricsForPrometheus(), "redis_buffered_events 1")
+	})
+}
+
+func TestRedisPublisher_HealthAndClose(t *testing.T) {
+	t.Parallel()
+
+	isConnected := false
+	closeCalled := false
+	args := createMockArgsRedisPublisher()
+	args.Client = &mocks.PubSubClientStub{
+		IsConnectedCalled: func() bool {
+			return isConnected
+		},
+		CloseCalled: func() error {
+			closeCalled = true
+			return nil
+		},
+	}
+
+	publisher, err := redis.NewRedisPublisher(args)
+	require.Nil(t, err)
+
+	require.Equal(t, common.HealthStateDown, publisher.GetHealthState())
+	require.Equal(t, redis.ErrRedisConnectionFailed, publisher.Ping(context.Background()))
+
+	isConnected = true
+	require.Equal(t, common.HealthStateUp, publisher.GetHealthState())
+	require.Nil(t, publisher.Ping(context.Background()))
+
+	err = publisher.Close()
+	require.Nil(t, err)
+	require.True(t, closeCalled)
+}
+
+func createMiniredisPublisher(t *testing.T, cfg config.RedisPubSubConfig, ackAfterPublish bool) process.PublisherHandler {
+	args := createMockArgsRedisPublisher()
+	args.Config.Url = cfg.Url
+	args.Config.Address = cfg.Address
+	args.Config.Password = cfg.Password
+	args.Config.DB = cfg.DB
+	args.AckAfterPublish = ackAfterPublish
+
+	client, err := redis.CreatePubSubClient(args.Config)
+	require.Nil(t, err)
+	args.Client = client
+
+	publisher, err := redis.NewRedisPublisher(args)
+	require.Nil(t, err)
+
+	return publisher
+}
+
+// subscribeMiniredis returns the messages published on the channels, once the subscription is confirmed
+func subscribeMiniredis(t *testing.T, server *miniredis.Miniredis, password string, channels ...string) <-chan *goredis.Message {
+	client := goredis.NewClient(&goredis.Options{
+		Addr:     server.Addr(),
+		Password: password,
+	})
+	pubSub := client.Subscribe(context.Background(), channels...)
+	_, err := pubSub.Receive(context.Background())
+	require.Nil(t, err)
+
+	t.Cleanup(func() {
+		_ = pubSub.Close()
+		_ = client.Close()
+	})
+
+	return pubSub.Channel()
+}
+
+func requireMiniredisMessage(t *testing.T, messages <-chan *goredis.Message, channel string, expectedPayload interface{}) {
+	expectedBytes, err := json.Marshal(expectedPayload)
+	require.Nil(t, err)
+
+	select {
+	case message := <-messages:
+		require.Equal(t, channel, message.Channel)
+		require.Equal(t, string(expectedBytes), message.Payload)
+	case <-time.After(time.Second * 2):
+		require.Fail(t, "timeout waiting for the published message", "channel", channel)
+	}
+}
+
+func TestRedisPublisher_WithRedisServer(t *testing.T) {
+	t.Parallel()
+
+	blockEvents := data.BlockEvents{
+		Hash:   "hash1",
+		Events: []data.Event{{Address: "addr1", Identifier: "transfer"}},
+	}
+	revertBlock := data.RevertBlock{Hash: "hash2", Nonce: 2}
+	finalizedBlock := data.FinalizedBlock{Hash: "hash3"}
+
+	t.Run("should publish on the server selected by address, password and db", func(t *testing.T) {
+		t.Parallel()
+
+		server, err := miniredis.Run()
+		require.Nil(t, err)
+		defer server.Close()
+		server.RequireAuth("secret")
+
+		messages := subscribeMiniredis(t, server, "secret", "all_events", "revert_events", "finalized_events")
+		publisher := createMiniredisPublisher(t, config.RedisPubSubConfig{
+			Address:  server.Addr(),
+			Password: "secret",
+			DB:       2,
+		}, false)
+		defer publisher.Close()
+
+		require.Equal(t, common.HealthStateUp, publisher.GetHealthState())
+
+		require.Nil(t, publisher.Publish(context.Background(), blockEvents))
+		requireMiniredisMessage(t, messages, "all_events", blockEvents)
+
+		require.Nil(t, publisher.PublishRevert(context.Background(), revertBlock))
+		requireMiniredisMessage(t, messages, "revert_events", revertBlock)
+
+		require.Nil(t, publisher.PublishFinalized(context.Background(), finalizedBlock))
+		requireMiniredisMessage(t, messages, "finalized_events", finalizedBlock)
+	})
+
+	t.Run("should publish on the server selected by url", func(t *testing.T) {
+		t.Parallel()
+
+		server, err := miniredis.Run()
+		require.Nil(t, err)
+		defer server.Close()
+
+		messages := subscribeMiniredis(t, server, "", "all_events")
+		publisher := createMiniredisPublisher(t, config.RedisPubSubConfig{
+			Url: "redis://" + server.Addr() + "/0",
+		}, false)
+		defer publisher.Close()
+
+		require.Nil(t, publisher.Publish(context.Background(), blockEvents))
+		requireMiniredisMessage(t, messages, "all_events", blockEvents)
+	})
+
+	t.Run("wrong password should return the publish error with ack after publish", func(t *testing.T) {
+		t.Parallel()
+
+		server, err := miniredis.Run()
+		require.Nil(t, err)
+		defer server.Close()
+		server.RequireAuth("secret")
+
+		publisher := createMiniredisPublisher(t, config.RedisPubSubConfig{
+			Address:  server.Addr(),
+			Password: "wrong",
+		}, true)
+		defer publisher.Close()
+
+		require.Equal(t, common.HealthStateDown, publisher.GetHealthState())
+		require.NotNil(t, publisher.Publish(context.Background(), blockEvents))
+	})
+
+	t.Run("events published while the server is down should be published in order after it restarts", func(t *testing.T) {
+		t.Parallel()
+
+		server, err := miniredis.Run()
+		require.Nil(t, err)
+		defer server.Close()
+
+		publisher := createMiniredisPublisher(t, config.RedisPubSubConfig{
+			Address: server.Addr(),
+		}, false)
+		defer publisher.Close()
+
+		server.Close()
+		require.Nil(t, publisher.Publish(context.Background(), blockEvents))
+
+		require.Nil(t, server.Restart())
+		messages := subscribeMiniredis(t, server, "", "all_events", "revert_events")
+
+		require.Nil(t, publisher.PublishRevert(context.Background(), revertBlock))
+		requireMiniredisMessage(t, messages, "all_events", blockEvents)
+		requireMiniredisMessage(t, messages, "revert_events", revertBlock)
+	})
+}
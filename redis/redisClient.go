@@ -28,6 +28,11 @@ func (rc *redisClientWrapper) SetEntry(ctx context.Context, key string, value bo
 	return rc.redis.SetNX(ctx, key, value, ttl).Result()
 }
 
+// RemoveEntry will remove the key value entry from redis database
+func (rc *redisClientWrapper) RemoveEntry(ctx context.Context, key string) error {
+	return rc.redis.Del(ctx, key).Err()
+}
+
 // Ping will check if Redis instance is reponding
 func (rc *redisClientWrapper) Ping(ctx context.Context) (string, error) {
 	return rc.redis.Ping(ctx).Result()
@@ -39,6 +44,44 @@ func (rc *redisClientWrapper) IsConnected(ctx context.Context) bool {
 	return err == nil && pong == pongValue
 }
 
+// Publish will publish the payload on the redis channel
+func (rc *redisClientWrapper) Publish(ctx context.Context, channel string, payload []byte) error {
+	return rc.redis.Publish(ctx, channel, payload).Err()
+}
+
+// Subscribe will call the handler for each message published on the redis channel, until
+// the context is done. The subscription is restored by the redis client after a reconnect,
+// the messages published in the meantime are lost
+func (rc *redisClientWrapper) Subscribe(ctx context.Context, channel string, handler func(payload []byte)) error {
+	pubSub := rc.redis.Subscribe(ctx, channel)
+	defer func() {
+		_ = pubSub.Close()
+	}()
+
+	_, err := pubSub.Receive(ctx)
+	if err != nil {
+		return err
+	}
+
+	messages := pubSub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case message, ok := <-messages:
+			if !ok {
+				return ErrSubscriptionClosed
+			}
+			handler([]byte(message.Payload))
+		}
+	}
+}
+
+// Close will close the redis client and its connections pool
+func (rc *redisClientWrapper) Close() error {
+	return rc.redis.Close()
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (rc *redisClientWrapper) IsInterfaceNil() bool {
 	return rc == nil
@@ -40,6 +40,11 @@ func (r *redlockWrapper) IsEventProcessed(ctx context.Context, blockHash string)
 	return r.client.SetEntry(ctx, blockHash, true, r.ttl)
 }
 
+// RemoveEvent removes the lock of the item, so that it can be processed again
+func (r *redlockWrapper) RemoveEvent(ctx context.Context, blockHash string) error {
+	return r.client.RemoveEntry(ctx, blockHash)
+}
+
 // HasConnection returns true if the redis client is connected
 func (r *redlockWrapper) HasConnection(ctx context.Context) bool {
 	return r.client.IsConnected(ctx)
@@ -104,6 +104,54 @@ func TestRedlockWrapper_IsBlockProcessed(t *testing.T) {
 	})
 }
 
+func TestRedlockWrapper_RemoveEvent(t *testing.T) {
+	t.Parallel()
+
+	t.Run("removed key should be processed again", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockRedlockWrapperArgs()
+		args.Client = mocks.NewRedisClientMock()
+
+		redlock, err := redis.NewRedlockWrapper(args)
+		require.Nil(t, err)
+
+		ok, err := redlock.IsEventProcessed(context.Background(), "hash1")
+		require.Nil(t, err)
+		require.True(t, ok)
+
+		ok, err = redlock.IsEventProcessed(context.Background(), "hash1")
+		require.Nil(t, err)
+		require.False(t, ok)
+
+		err = redlock.RemoveEvent(context.Background(), "hash1")
+		require.Nil(t, err)
+
+		ok, err = redlock.IsEventProcessed(context.Background(), "hash1")
+		require.Nil(t, err)
+		require.True(t, ok)
+	})
+
+	t.Run("client error should be returned", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		args := createMockRedlockWrapperArgs()
+		args.Client = &mocks.RedisClientStub{
+			RemoveEntryCalled: func(key string) error {
+				require.Equal(t, "hash1", key)
+				return expectedErr
+			},
+		}
+
+		redlock, err := redis.NewRedlockWrapper(args)
+		require.Nil(t, err)
+
+		err = redlock.RemoveEvent(context.Background(), "hash1")
+		require.Equal(t, expectedErr, err)
+	})
+}
+
 func TestRedlockWrapper_HasConnection(t *testing.T) {
 	t.Parallel()
 
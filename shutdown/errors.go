@@ -0,0 +1,9 @@
+package shutdown
+
+import "errors"
+
+// ErrNilCloser signals that a nil component has been provided in a shutdown stage
+var ErrNilCloser = errors.New("nil closer provided")
+
+// ErrShutdownTimeout signals that the components were not closed before the shutdown deadline
+var ErrShutdownTimeout = errors.New("shutdown deadline exceeded")
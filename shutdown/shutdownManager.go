@@ -0,0 +1,109 @@
+package shutdown
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	logger "github.com/multiversx/mx-chain-logger-go"
+)
+
+var log = logger.GetOrCreate("shutdown")
+
+// Closer defines a component which is closed on shutdown
+type Closer interface {
+	Close() error
+	IsInterfaceNil() bool
+}
+
+// Stage holds the components which are closed at the same step of the shutdown
+type Stage struct {
+	Name    string
+	Closers []Closer
+}
+
+// ArgsShutdownManager defines the arguments needed for shutdown manager creation
+type ArgsShutdownManager struct {
+	Stages []Stage
+
+	// Timeout is the overall deadline of the shutdown. 0 means waiting until all the
+	// components are closed
+	Timeout time.Duration
+}
+
+type shutdownManager struct {
+	stages  []Stage
+	timeout time.Duration
+}
+
+// NewShutdownManager creates a component which closes the provided stages in order
+func NewShutdownManager(args ArgsShutdownManager) (*shutdownManager, error) {
+	for _, stage := range args.Stages {
+		for _, closer := range stage.Closers {
+			if check.IfNil(closer) {
+				return nil, fmt.Errorf("%w in stage %s", ErrNilCloser, stage.Name)
+			}
+		}
+	}
+
+	return &shutdownManager{
+		stages:  args.Stages,
+		timeout: args.Timeout,
+	}, nil
+}
+
+// Shutdown closes the stages in order, a stage being started only after all the components
+// of the previous one are closed. A close error is logged and the shutdown goes on with
+// the next components; the first error is returned. If the timeout expires, ErrShutdownTimeout
+// is returned without waiting for the components still closing, so that the caller can
+// force the exit
+func (sm *shutdownManager) Shutdown() error {
+	done := make(chan error, 1)
+	go func() {
+		done <- sm.closeStages()
+	}()
+
+	if sm.timeout == 0 {
+		return <-done
+	}
+
+	timer := time.NewTimer(sm.timeout)
+	defer timer.Stop()
+
+	select {
+	case err := <-done:
+		return err
+	case <-timer.C:
+		log.Error("shutdown deadline exceeded, forcing the exit", "timeout", sm.timeout)
+		return ErrShutdownTimeout
+	}
+}
+
+func (sm *shutdownManager) closeStages() error {
+	var firstErr error
+	for _, stage := range sm.stages {
+		log.Info("shutting down", "stage", stage.Name)
+		startTime := time.Now()
+
+		for _, closer := range stage.Closers {
+			err := closer.Close()
+			if err == nil {
+				continue
+			}
+
+			log.Error("failed to close component", "stage", stage.Name, "err", err.Error())
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+
+		log.Debug("shut down", "stage", stage.Name, "duration", time.Since(startTime))
+	}
+
+	return firstErr
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (sm *shutdownManager) IsInterfaceNil() bool {
+	return sm == nil
+}
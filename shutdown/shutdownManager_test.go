@@ -0,0 +1,128 @@
+package shutdown_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-notifier-go/shutdown"
+	"github.com/stretchr/testify/require"
+)
+
+type closerStub struct {
+	closeCalled func() error
+}
+
+func (cs *closerStub) Close() error {
+	return cs.closeCalled()
+}
+
+func (cs *closerStub) IsInterfaceNil() bool {
+	return cs == nil
+}
+
+func TestNewShutdownManager(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil closer", func(t *testing.T) {
+		t.Parallel()
+
+		manager, err := shutdown.NewShutdownManager(shutdown.ArgsShutdownManager{
+			Stages: []shutdown.Stage{{Name: "intake", Closers: []shutdown.Closer{nil}}},
+		})
+		require.True(t, check.IfNil(manager))
+		require.True(t, errors.Is(err, shutdown.ErrNilCloser))
+	})
+
+	t.Run("typed nil closer", func(t *testing.T) {
+		t.Parallel()
+
+		var closer *closerStub
+		manager, err := shutdown.NewShutdownManager(shutdown.ArgsShutdownManager{
+			Stages: []shutdown.Stage{{Name: "intake", Closers: []shutdown.Closer{closer}}},
+		})
+		require.True(t, check.IfNil(manager))
+		require.True(t, errors.Is(err, shutdown.ErrNilCloser))
+	})
+
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		manager, err := shutdown.NewShutdownManager(shutdown.ArgsShutdownManager{})
+		require.Nil(t, err)
+		require.False(t, check.IfNil(manager))
+		require.Nil(t, manager.Shutdown())
+	})
+}
+
+func TestShutdownManager_Shutdown(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should close the stages in order", func(t *testing.T) {
+		t.Parallel()
+
+		closed := make([]string, 0)
+		createCloser := func(name string) shutdown.Closer {
+			return &closerStub{closeCalled: func() error {
+				closed = append(closed, name)
+				return nil
+			}}
+		}
+
+		manager, _ := shutdown.NewShutdownManager(shutdown.ArgsShutdownManager{
+			Stages: []shutdown.Stage{
+				{Name: "intake", Closers: []shutdown.Closer{createCloser("ws connector"), createCloser("http intake")}},
+				{Name: "publisher", Closers: []shutdown.Closer{createCloser("publisher")}},
+				{Name: "web server", Closers: []shutdown.Closer{createCloser("web server")}},
+			},
+			Timeout: time.Second,
+		})
+
+		require.Nil(t, manager.Shutdown())
+		require.Equal(t, []string{"ws connector", "http intake", "publisher", "web server"}, closed)
+	})
+
+	t.Run("close error should not stop the shutdown", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		numClosed := 0
+		manager, _ := shutdown.NewShutdownManager(shutdown.ArgsShutdownManager{
+			Stages: []shutdown.Stage{
+				{Name: "intake", Closers: []shutdown.Closer{&closerStub{closeCalled: func() error {
+					numClosed++
+					return expectedErr
+				}}}},
+				{Name: "publisher", Closers: []shutdown.Closer{&closerStub{closeCalled: func() error {
+					numClosed++
+					return errors.New("other error")
+				}}}},
+			},
+		})
+
+		require.Equal(t, expectedErr, manager.Shutdown())
+		require.Equal(t, 2, numClosed)
+	})
+
+	t.Run("timeout should return without waiting for the components", func(t *testing.T) {
+		t.Parallel()
+
+		release := make(chan struct{})
+		defer close(release)
+
+		manager, _ := shutdown.NewShutdownManager(shutdown.ArgsShutdownManager{
+			Stages: []shutdown.Stage{
+				{Name: "publisher", Closers: []shutdown.Closer{&closerStub{closeCalled: func() error {
+					<-release
+					return nil
+				}}}},
+			},
+			Timeout: 50 * time.Millisecond,
+		})
+
+		startTime := time.Now()
+		require.Equal(t, shutdown.ErrShutdownTimeout, manager.Shutdown())
+		require.Less(t, time.Since(startTime), time.Second)
+	})
+}
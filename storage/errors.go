@@ -0,0 +1,12 @@
+package storage
+
+import "errors"
+
+// ErrEmptyPath signals that an empty event store path has been provided
+var ErrEmptyPath = errors.New("empty event store path")
+
+// ErrInvalidMaxBlocksPerQuery signals that an invalid max blocks per query value has been provided
+var ErrInvalidMaxBlocksPerQuery = errors.New("invalid max blocks per query")
+
+// ErrEmptySubscriptionsStorePath signals that an empty subscriptions store path has been provided
+var ErrEmptySubscriptionsStorePath = errors.New("empty subscriptions store path")
@@ -0,0 +1,164 @@
+package storage
+
+import (
+	"encoding/binary"
+	"encoding/json"
+
+	logger "github.com/multiversx/mx-chain-logger-go"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+var log = logger.GetOrCreate("storage")
+
+const nonceKeyLength = 8
+
+// ArgsEventStore defines the arguments needed for event store creation
+type ArgsEventStore struct {
+	Path string
+
+	// RetainedNonces is the number of nonces kept behind the latest saved block, the older
+	// blocks are pruned on save. 0 disables the pruning
+	RetainedNonces    uint64
+	MaxBlocksPerQuery uint32
+}
+
+// storedBlockEvents holds the saved block events, the nonce is not part of the
+// marshalled data.BlockEvents so it is kept explicitly
+type storedBlockEvents struct {
+	Nonce     uint64       `json:"nonce"`
+	Hash      string       `json:"hash"`
+	ShardID   uint32       `json:"shardId"`
+	TimeStamp uint64       `json:"timestamp"`
+	Events    []data.Event `json:"events"`
+}
+
+type eventStore struct {
+	db                *leveldb.DB
+	retainedNonces    uint64
+	maxBlocksPerQuery uint32
+}
+
+// NewEventStore opens, or creates, the leveldb event store at the provided path
+func NewEventStore(args ArgsEventStore) (*eventStore, error) {
+	err := checkArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := leveldb.OpenFile(args.Path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &eventStore{
+		db:                db,
+		retainedNonces:    args.RetainedNonces,
+		maxBlocksPerQuery: args.MaxBlocksPerQuery,
+	}, nil
+}
+
+func checkArgs(args ArgsEventStore) error {
+	if args.Path == "" {
+		return ErrEmptyPath
+	}
+	if args.MaxBlocksPerQuery == 0 {
+		return ErrInvalidMaxBlocksPerQuery
+	}
+
+	return nil
+}
+
+// Save stores the block events, keyed by nonce and hash, and prunes the blocks older
+// than the retained nonces
+func (es *eventStore) Save(events data.BlockEvents) error {
+	value, err := json.Marshal(storedBlockEvents{
+		Nonce:     events.Nonce,
+		Hash:      events.Hash,
+		ShardID:   events.ShardID,
+		TimeStamp: events.TimeStamp,
+		Events:    events.Events,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = es.db.Put(createKey(events.Nonce, events.Hash), value, nil)
+	if err != nil {
+		return err
+	}
+
+	return es.prune(events.Nonce)
+}
+
+func (es *eventStore) prune(latestNonce uint64) error {
+	if es.retainedNonces == 0 || latestNonce < es.retainedNonces {
+		return nil
+	}
+
+	oldestNonce := latestNonce - es.retainedNonces + 1
+	iterator := es.db.NewIterator(&util.Range{Limit: createKey(oldestNonce, "")}, nil)
+	defer iterator.Release()
+
+	batch := new(leveldb.Batch)
+	for iterator.Next() {
+		batch.Delete(append([]byte{}, iterator.Key()...))
+	}
+	err := iterator.Error()
+	if err != nil {
+		return err
+	}
+	if batch.Len() == 0 {
+		return nil
+	}
+
+	log.Debug("pruning event store", "num blocks", batch.Len(), "oldest retained nonce", oldestNonce)
+
+	return es.db.Write(batch, nil)
+}
+
+// GetFromNonce returns the stored block events starting with the provided nonce, in
+// nonce order, limited to the max blocks per query
+func (es *eventStore) GetFromNonce(nonce uint64) ([]data.BlockEvents, error) {
+	iterator := es.db.NewIterator(&util.Range{Start: createKey(nonce, "")}, nil)
+	defer iterator.Release()
+
+	blocks := make([]data.BlockEvents, 0)
+	for len(blocks) < int(es.maxBlocksPerQuery) && iterator.Next() {
+		stored := storedBlockEvents{}
+		err := json.Unmarshal(iterator.Value(), &stored)
+		if err != nil {
+			return nil, err
+		}
+
+		blocks = append(blocks, data.BlockEvents{
+			Hash:      stored.Hash,
+			ShardID:   stored.ShardID,
+			TimeStamp: stored.TimeStamp,
+			Events:    stored.Events,
+			Nonce:     stored.Nonce,
+		})
+	}
+
+	return blocks, iterator.Error()
+}
+
+// createKey returns the big endian nonce followed by the hash, so that the keys
+// are iterated in nonce order
+func createKey(nonce uint64, hash string) []byte {
+	key := make([]byte, nonceKeyLength, nonceKeyLength+len(hash))
+	binary.BigEndian.PutUint64(key, nonce)
+
+	return append(key, hash...)
+}
+
+// Close closes the underlying database
+func (es *eventStore) Close() error {
+	return es.db.Close()
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (es *eventStore) IsInterfaceNil() bool {
+	return es == nil
+}
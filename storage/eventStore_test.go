@@ -0,0 +1,156 @@
+package storage_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/dispatcher"
+	"github.com/multiversx/mx-chain-notifier-go/storage"
+	"github.com/stretchr/testify/require"
+)
+
+func createMockArgsEventStore(t *testing.T) storage.ArgsEventStore {
+	return storage.ArgsEventStore{
+		Path:              filepath.Join(t.TempDir(), "events"),
+		RetainedNonces:    0,
+		MaxBlocksPerQuery: 10,
+	}
+}
+
+func createEventStore(t *testing.T, args storage.ArgsEventStore) dispatcher.EventStore {
+	eventStore, err := storage.NewEventStore(args)
+	require.Nil(t, err)
+	t.Cleanup(func() {
+		_ = eventStore.Close()
+	})
+
+	return eventStore
+}
+
+func TestNewEventStore(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty path", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsEventStore(t)
+		args.Path = ""
+
+		eventStore, err := storage.NewEventStore(args)
+		require.True(t, check.IfNil(eventStore))
+		require.Equal(t, storage.ErrEmptyPath, err)
+	})
+
+	t.Run("invalid max blocks per query", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsEventStore(t)
+		args.MaxBlocksPerQuery = 0
+
+		eventStore, err := storage.NewEventStore(args)
+		require.True(t, check.IfNil(eventStore))
+		require.Equal(t, storage.ErrInvalidMaxBlocksPerQuery, err)
+	})
+
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		eventStore, err := storage.NewEventStore(createMockArgsEventStore(t))
+		require.Nil(t, err)
+		require.False(t, check.IfNil(eventStore))
+
+		require.Nil(t, eventStore.Close())
+	})
+}
+
+func TestEventStore_GetFromNonce(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return the blocks in nonce order", func(t *testing.T) {
+		t.Parallel()
+
+		eventStore := createEventStore(t, createMockArgsEventStore(t))
+
+		events := []data.Event{{Address: "addr1", Identifier: "id1", Topics: [][]byte{[]byte("topic1")}, TxHash: "txHash1"}}
+		require.Nil(t, eventStore.Save(data.BlockEvents{Hash: "hash3", Nonce: 3}))
+		require.Nil(t, eventStore.Save(data.BlockEvents{Hash: "hash1", Nonce: 1}))
+		require.Nil(t, eventStore.Save(data.BlockEvents{Hash: "hash2", Nonce: 2, ShardID: 1, TimeStamp: 1234, Events: events}))
+
+		blocks, err := eventStore.GetFromNonce(2)
+		require.Nil(t, err)
+		require.Equal(t, []data.BlockEvents{
+			{Hash: "hash2", Nonce: 2, ShardID: 1, TimeStamp: 1234, Events: events},
+			{Hash: "hash3", Nonce: 3},
+		}, blocks)
+
+		blocks, err = eventStore.GetFromNonce(4)
+		require.Nil(t, err)
+		require.Equal(t, 0, len(blocks))
+	})
+
+	t.Run("should limit the number of returned blocks", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsEventStore(t)
+		args.MaxBlocksPerQuery = 2
+		eventStore := createEventStore(t, args)
+
+		for nonce := uint64(1); nonce <= 5; nonce++ {
+			require.Nil(t, eventStore.Save(data.BlockEvents{Hash: "hash", Nonce: nonce}))
+		}
+
+		blocks, err := eventStore.GetFromNonce(2)
+		require.Nil(t, err)
+		require.Equal(t, 2, len(blocks))
+		require.Equal(t, uint64(2), blocks[0].Nonce)
+		require.Equal(t, uint64(3), blocks[1].Nonce)
+	})
+
+	t.Run("blocks with the same nonce should be kept", func(t *testing.T) {
+		t.Parallel()
+
+		eventStore := createEventStore(t, createMockArgsEventStore(t))
+
+		require.Nil(t, eventStore.Save(data.BlockEvents{Hash: "hashShard0", Nonce: 1}))
+		require.Nil(t, eventStore.Save(data.BlockEvents{Hash: "hashShard1", Nonce: 1, ShardID: 1}))
+
+		blocks, err := eventStore.GetFromNonce(1)
+		require.Nil(t, err)
+		require.Equal(t, 2, len(blocks))
+	})
+
+	t.Run("saved blocks should be available after reopening", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsEventStore(t)
+		eventStore, err := storage.NewEventStore(args)
+		require.Nil(t, err)
+		require.Nil(t, eventStore.Save(data.BlockEvents{Hash: "hash1", Nonce: 1}))
+		require.Nil(t, eventStore.Close())
+
+		reopenedEventStore := createEventStore(t, args)
+		blocks, err := reopenedEventStore.GetFromNonce(0)
+		require.Nil(t, err)
+		require.Equal(t, []data.BlockEvents{{Hash: "hash1", Nonce: 1}}, blocks)
+	})
+}
+
+func TestEventStore_SaveShouldPruneOldBlocks(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgsEventStore(t)
+	args.RetainedNonces = 3
+	eventStore := createEventStore(t, args)
+
+	for nonce := uint64(1); nonce <= 5; nonce++ {
+		require.Nil(t, eventStore.Save(data.BlockEvents{Hash: "hash", Nonce: nonce}))
+	}
+
+	blocks, err := eventStore.GetFromNonce(0)
+	require.Nil(t, err)
+	require.Equal(t, 3, len(blocks))
+	require.Equal(t, uint64(3), blocks[0].Nonce)
+	require.Equal(t, uint64(5), blocks[2].Nonce)
+}
@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// ArgsSubscriptionsStore defines the arguments needed for subscriptions store creation
+type ArgsSubscriptionsStore struct {
+	Path string
+}
+
+type subscriptionsStore struct {
+	db *leveldb.DB
+}
+
+// NewSubscriptionsStore opens, or creates, the leveldb store of the hub subscriptions at
+// the provided path. The subscriptions of each dispatcher are kept under its id
+func NewSubscriptionsStore(args ArgsSubscriptionsStore) (*subscriptionsStore, error) {
+	if args.Path == "" {
+		return nil, ErrEmptySubscriptionsStorePath
+	}
+
+	db, err := leveldb.OpenFile(args.Path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &subscriptionsStore{
+		db: db,
+	}, nil
+}
+
+// SaveSubscriptions stores the subscriptions of the dispatcher, replacing the previous ones
+func (ss *subscriptionsStore) SaveSubscriptions(dispatcherID uuid.UUID, subscriptions []data.Subscription) error {
+	value, err := json.Marshal(subscriptions)
+	if err != nil {
+		return err
+	}
+
+	return ss.db.Put(dispatcherID[:], value, nil)
+}
+
+// RemoveSubscriptions removes the subscriptions of the dispatcher
+func (ss *subscriptionsStore) RemoveSubscriptions(dispatcherID uuid.UUID) error {
+	return ss.db.Delete(dispatcherID[:], nil)
+}
+
+// LoadSubscriptions returns the stored subscriptions of all the dispatchers
+func (ss *subscriptionsStore) LoadSubscriptions() (map[uuid.UUID][]data.Subscription, error) {
+	iterator := ss.db.NewIterator(nil, nil)
+	defer iterator.Release()
+
+	subscriptions := make(map[uuid.UUID][]data.Subscription)
+	for iterator.Next() {
+		dispatcherID, err := uuid.FromBytes(iterator.Key())
+		if err != nil {
+			return nil, err
+		}
+
+		var dispatcherSubscriptions []data.Subscription
+		err = json.Unmarshal(iterator.Value(), &dispatcherSubscriptions)
+		if err != nil {
+			return nil, err
+		}
+
+		subscriptions[dispatcherID] = dispatcherSubscriptions
+	}
+
+	return subscriptions, iterator.Error()
+}
+
+// Close closes the underlying database
+func (ss *subscriptionsStore) Close() error {
+	return ss.db.Close()
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (ss *subscriptionsStore) IsInterfaceNil() bool {
+	return ss == nil
+}
@@ -0,0 +1,71 @@
+package storage_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/storage"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSubscriptionsStore(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty path", func(t *testing.T) {
+		t.Parallel()
+
+		store, err := storage.NewSubscriptionsStore(storage.ArgsSubscriptionsStore{})
+		require.True(t, check.IfNil(store))
+		require.Equal(t, storage.ErrEmptySubscriptionsStorePath, err)
+	})
+
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		store, err := storage.NewSubscriptionsStore(storage.ArgsSubscriptionsStore{Path: filepath.Join(t.TempDir(), "subscriptions")})
+		require.Nil(t, err)
+		require.False(t, check.IfNil(store))
+		require.Nil(t, store.Close())
+	})
+}
+
+func TestSubscriptionsStore_SaveAndLoad(t *testing.T) {
+	t.Parallel()
+
+	args := storage.ArgsSubscriptionsStore{Path: filepath.Join(t.TempDir(), "subscriptions")}
+	dispatcherID1, dispatcherID2 := uuid.New(), uuid.New()
+
+	store, _ := storage.NewSubscriptionsStore(args)
+	err := store.SaveSubscriptions(dispatcherID1, []data.Subscription{{ID: 1, Address: "erd1"}})
+	require.Nil(t, err)
+	err = store.SaveSubscriptions(dispatcherID1, []data.Subscription{{ID: 1, Address: "erd1"}, {ID: 3, Address: "erd3"}})
+	require.Nil(t, err)
+	err = store.SaveSubscriptions(dispatcherID2, []data.Subscription{{ID: 2, Address: "erd2"}})
+	require.Nil(t, err)
+	require.Nil(t, store.Close())
+
+	store, _ = storage.NewSubscriptionsStore(args)
+	subscriptions, err := store.LoadSubscriptions()
+	require.Nil(t, err)
+	require.Equal(t, map[uuid.UUID][]data.Subscription{
+		dispatcherID1: {{ID: 1, Address: "erd1"}, {ID: 3, Address: "erd3"}},
+		dispatcherID2: {{ID: 2, Address: "erd2"}},
+	}, subscriptions)
+
+	err = store.RemoveSubscriptions(dispatcherID1)
+	require.Nil(t, err)
+	require.Nil(t, store.Close())
+
+	store, _ = storage.NewSubscriptionsStore(args)
+	defer func() {
+		_ = store.Close()
+	}()
+	subscriptions, err = store.LoadSubscriptions()
+	require.Nil(t, err)
+	require.Equal(t, map[uuid.UUID][]data.Subscription{
+		dispatcherID2: {{ID: 2, Address: "erd2"}},
+	}, subscriptions)
+}
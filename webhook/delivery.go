@@ -0,0 +1,58 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// postJSON posts the json body once, with the provided extra headers, and returns
+// whether the delivery can be retried on failure. Only the network errors, server
+// errors and throttled requests are considered retryable
+func postJSON(client HTTPClient, requestTimeout time.Duration, webhookURL string, headers http.Header, body []byte) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+
+	req.Header.Set(contentTypeHeader, jsonContentType)
+	for key := range headers {
+		req.Header.Set(key, headers.Get(key))
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer func() {
+		// the body is drained so that the connection can be reused
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		return false, nil
+	}
+
+	retryable := resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests
+
+	return retryable, fmt.Errorf("%w: %d", ErrUnexpectedStatusCode, resp.StatusCode)
+}
+
+// computeSignature returns the hex encoded HMAC-SHA256 of the body
+func computeSignature(secret []byte, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	_, _ = mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
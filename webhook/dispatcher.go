@@ -0,0 +1,237 @@
+package webhook
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/multiversx/mx-chain-core-go/marshal"
+	"github.com/multiversx/mx-chain-notifier-go/common"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+)
+
+const (
+	registeredWebhookSignatureHeader = "X-Signature"
+	registeredWebhookMaxAttempts     = 3
+	pendingDeliveriesBufferSize      = 256
+)
+
+// argsWebhookDispatcher defines the arguments needed for webhook dispatcher creation
+type argsWebhookDispatcher struct {
+	Client         HTTPClient
+	Marshaller     marshal.Marshalizer
+	URL            string
+	Secret         string
+	RequestTimeout time.Duration
+	RetryInterval  time.Duration
+}
+
+type pendingDelivery struct {
+	eventType string
+	hash      string
+	body      []byte
+}
+
+// webhookDispatcher is the hub dispatcher of a webhook registered via the REST api. The
+// hub delivers the events synchronously, so they are only queued here and posted from a
+// separate goroutine. If the queue is full the events are dropped, so that a slow webhook
+// endpoint does not block the broadcasts to the other dispatchers
+type webhookDispatcher struct {
+	id             uuid.UUID
+	client         HTTPClient
+	marshaller     marshal.Marshalizer
+	url            string
+	secret         []byte
+	requestTimeout time.Duration
+	retryInterval  time.Duration
+
+	send      chan *pendingDelivery
+	closeChan chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+func newWebhookDispatcher(args argsWebhookDispatcher) *webhookDispatcher {
+	wd := &webhookDispatcher{
+		id:             uuid.New(),
+		client:         args.Client,
+		marshaller:     args.Marshaller,
+		url:            args.URL,
+		requestTimeout: args.RequestTimeout,
+		retryInterval:  args.RetryInterval,
+		send:           make(chan *pendingDelivery, pendingDeliveriesBufferSize),
+		closeChan:      make(chan struct{}),
+	}
+	if args.Secret != "" {
+		wd.secret = []byte(args.Secret)
+	}
+
+	wd.wg.Add(1)
+	go wd.deliverLoop()
+
+	return wd
+}
+
+// GetID returns the id corresponding to this dispatcher instance
+func (wd *webhookDispatcher) GetID() uuid.UUID {
+	return wd.id
+}
+
+// IsResumable returns false, the webhooks are registered again with a new id
+func (wd *webhookDispatcher) IsResumable() bool {
+	return false
+}
+
+// PushEvents will post the matched logs and events to the webhook url
+func (wd *webhookDispatcher) PushEvents(events []data.Event) {
+	wd.enqueue(common.PushLogsAndEvents, "", events)
+}
+
+// RevertEvent will post the revert event to the webhook url
+func (wd *webhookDispatcher) RevertEvent(event data.RevertBlock) {
+	wd.enqueue(common.RevertBlockEvents, event.Hash, event)
+}
+
+// FinalizedEvent will post the finalized event to the webhook url
+func (wd *webhookDispatcher) FinalizedEvent(event data.FinalizedBlock) {
+	wd.enqueue(common.FinalizedBlockEvents, event.Hash, event)
+}
+
+// TxsEvent will post the txs event to the webhook url
+func (wd *webhookDispatcher) TxsEvent(event data.BlockTxs) {
+	wd.enqueue(common.BlockTxs, event.Hash, event)
+}
+
+// BlockEvents will post the full block events to the webhook url
+func (wd *webhookDispatcher) BlockEvents(event data.BlockEventsWithOrder) {
+	wd.enqueue(common.BlockEvents, event.Hash, event)
+}
+
+// ScrsEvent will post the scrs event to the webhook url
+func (wd *webhookDispatcher) ScrsEvent(event data.BlockScrs) {
+	wd.enqueue(common.BlockScrs, event.Hash, event)
+}
+
+// BlockTxEvents will post the matched transaction notifications to the webhook url
+func (wd *webhookDispatcher) BlockTxEvents(event data.BlockTxEvents) {
+	wd.enqueue(common.TxEvents, event.Hash, event)
+}
+
+// RoundEvents will post the rounds info to the webhook url
+func (wd *webhookDispatcher) RoundEvents(event data.RoundEvents) {
+	wd.enqueue(common.RoundEvents, "", event)
+}
+
+// ValidatorsRatingEvent will post the validators rating to the webhook url
+func (wd *webhookDispatcher) ValidatorsRatingEvent(event data.ValidatorsRatingEvent) {
+	wd.enqueue(common.ValidatorsRatingEvents, "", event)
+}
+
+// AccountsEvents will post the matched altered accounts to the webhook url
+func (wd *webhookDispatcher) AccountsEvents(event data.AccountsEvents) {
+	wd.enqueue(common.AccountEvents, "", event)
+}
+
+// ReplayUnavailable will post the replay unavailable signal to the webhook url
+func (wd *webhookDispatcher) ReplayUnavailable(event data.ReplayUnavailable) {
+	wd.enqueue(common.ReplayUnavailable, event.FromHash, event)
+}
+
+func (wd *webhookDispatcher) enqueue(eventType string, hash string, eventData interface{}) {
+	body, err := wd.marshaller.Marshal(&webhookEvent{
+		Type: eventType,
+		Data: eventData,
+	})
+	if err != nil {
+		log.Error("could not marshal webhook event", "dispatcherID", wd.id, "event", eventType, "err", err.Error())
+		return
+	}
+
+	select {
+	case <-wd.closeChan:
+		return
+	default:
+	}
+
+	select {
+	case wd.send <- &pendingDelivery{eventType: eventType, hash: hash, body: body}:
+	default:
+		log.Warn("webhook delivery queue is full, event dropped",
+			"dispatcherID", wd.id,
+			"url", wd.url,
+			"event", eventType,
+			"hash", hash,
+		)
+	}
+}
+
+func (wd *webhookDispatcher) deliverLoop() {
+	defer wd.wg.Done()
+
+	for {
+		select {
+		case delivery := <-wd.send:
+			err := wd.deliverWithRetries(delivery)
+			if err != nil {
+				log.Error("failed to deliver event to registered webhook, event dropped",
+					"dispatcherID", wd.id,
+					"url", wd.url,
+					"event", delivery.eventType,
+					"hash", delivery.hash,
+					"err", err.Error(),
+				)
+			}
+		case <-wd.closeChan:
+			return
+		}
+	}
+}
+
+// deliverWithRetries posts the event, retrying with linear backoff until the endpoint
+// accepts it, the max number of attempts is reached or the dispatcher is closed
+func (wd *webhookDispatcher) deliverWithRetries(delivery *pendingDelivery) error {
+	headers := make(http.Header)
+	headers.Set(eventTypeHeader, delivery.eventType)
+	if len(wd.secret) > 0 {
+		headers.Set(registeredWebhookSignatureHeader, signaturePrefix+computeSignature(wd.secret, delivery.body))
+	}
+
+	var err error
+	var retryable bool
+	for attempt := 1; attempt <= registeredWebhookMaxAttempts; attempt++ {
+		retryable, err = postJSON(wd.client, wd.requestTimeout, wd.url, headers, delivery.body)
+		if err == nil || !retryable || attempt == registeredWebhookMaxAttempts {
+			return err
+		}
+
+		retryInterval := time.Duration(attempt) * wd.retryInterval
+		log.Debug("failed to deliver event to registered webhook, will retry",
+			"dispatcherID", wd.id,
+			"url", wd.url,
+			"attempt", attempt,
+			"retry interval", retryInterval,
+			"err", err.Error(),
+		)
+
+		timer := time.NewTimer(retryInterval)
+		select {
+		case <-timer.C:
+		case <-wd.closeChan:
+			timer.Stop()
+			return err
+		}
+	}
+
+	return err
+}
+
+// Close stops the deliveries, the queued events are dropped
+func (wd *webhookDispatcher) Close() error {
+	wd.closeOnce.Do(func() {
+		close(wd.closeChan)
+	})
+	wd.wg.Wait()
+
+	return nil
+}
@@ -0,0 +1,24 @@
+package webhook
+
+import "errors"
+
+// ErrNilHTTPClient signals that a nil http client has been provided
+var ErrNilHTTPClient = errors.New("nil http client")
+
+// ErrEmptyWebhookURLs signals that no webhook url has been provided
+var ErrEmptyWebhookURLs = errors.New("empty webhook urls")
+
+// ErrInvalidWebhookURL signals that an invalid webhook url has been provided
+var ErrInvalidWebhookURL = errors.New("invalid webhook url")
+
+// ErrInvalidMaxAttempts signals that an invalid number of delivery attempts has been provided
+var ErrInvalidMaxAttempts = errors.New("invalid max delivery attempts")
+
+// ErrUnexpectedStatusCode signals that the webhook endpoint responded with a non success status code
+var ErrUnexpectedStatusCode = errors.New("unexpected status code")
+
+// ErrNilHub signals that a nil hub has been provided
+var ErrNilHub = errors.New("nil hub")
+
+// ErrQueueWithAckAfterPublish signals that the delivery queues were enabled together with the ack after publish
+var ErrQueueWithAckAfterPublish = errors.New("webhook delivery queues can not be used with ack after publish")
@@ -0,0 +1,8 @@
+package webhook
+
+import "net/http"
+
+// HTTPClient defines the behaviour of the http client used to deliver the events
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
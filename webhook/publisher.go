@@ -0,0 +1,462 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/marshal"
+	logger "github.com/multiversx/mx-chain-logger-go"
+	"github.com/multiversx/mx-chain-notifier-go/common"
+	"github.com/multiversx/mx-chain-notifier-go/config"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/metrics"
+)
+
+const (
+	deliverySuccessPromMetric  = "webhook_delivery_success"
+	deliveryFailuresPromMetric = "webhook_delivery_failures"
+	droppedEventsPromMetric    = "webhook_dropped_events"
+	urlPromLabel               = "url"
+
+	contentTypeHeader      = "Content-Type"
+	jsonContentType        = "application/json"
+	eventTypeHeader        = "X-Notifier-Event-Type"
+	defaultSignatureHeader = "X-Notifier-Signature"
+	signaturePrefix        = "sha256="
+
+	defaultRequestTimeout = 5 * time.Second
+	maxRetryInterval      = 10 * time.Second
+)
+
+var log = logger.GetOrCreate("webhook")
+
+// ArgsWebhookPublisher defines the arguments needed for webhook publisher creation
+type ArgsWebhookPublisher struct {
+	Client     HTTPClient
+	Config     config.WebhookConfig
+	Marshaller marshal.Marshalizer
+
+	// AckAfterPublish requires the events to be delivered when published, so the delivery
+	// queues, which only hand over the events, can not be used
+	AckAfterPublish bool
+}
+
+// webhookEvent defines the structure of the body posted to the webhook urls
+type webhookEvent struct {
+	Type string      `json:"type"`
+	Data interface{} `json:"data"`
+}
+
+type webhookPublisher struct {
+	client          HTTPClient
+	marshaller      marshal.Marshalizer
+	urls            []string
+	urlsByEventType map[string][]string
+	rawPayload      bool
+	hmacSecret      []byte
+	signatureHeader string
+	requestTimeout  time.Duration
+	retryInterval   time.Duration
+	maxAttempts     uint32
+
+	mutMetrics          sync.RWMutex
+	numDeliverySuccess  map[string]uint64
+	numDeliveryFailures map[string]uint64
+	numDroppedEvents    map[string]uint64
+
+	queues    map[string]chan *pendingDelivery
+	closeChan chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+// NewWebhookPublisher creates a new webhook publisher instance, which posts each
+// broadcast as json to the configured urls. If a queue size is set, each url has its own
+// delivery queue and goroutine, so that a slow endpoint does not block the publishing
+func NewWebhookPublisher(args ArgsWebhookPublisher) (*webhookPublisher, error) {
+	err := checkArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	wp := &webhookPublisher{
+		client:              args.Client,
+		marshaller:          args.Marshaller,
+		urls:                args.Config.URLs,
+		urlsByEventType:     createURLsByEventType(args.Config),
+		rawPayload:          args.Config.RawPayload,
+		signatureHeader:     args.Config.SignatureHeader,
+		requestTimeout:      time.Duration(args.Config.RequestTimeoutInMs) * time.Millisecond,
+		retryInterval:       time.Duration(args.Config.RetryIntervalInMs) * time.Millisecond,
+		maxAttempts:         args.Config.MaxAttempts,
+		numDeliverySuccess:  make(map[string]uint64),
+		numDeliveryFailures: make(map[string]uint64),
+		numDroppedEvents:    make(map[string]uint64),
+		closeChan:           make(chan struct{}),
+	}
+
+	if args.Config.HMACSecret != "" {
+		wp.hmacSecret = []byte(args.Config.HMACSecret)
+	}
+	if wp.signatureHeader == "" {
+		wp.signatureHeader = defaultSignatureHeader
+	}
+	if wp.requestTimeout == 0 {
+		wp.requestTimeout = defaultRequestTimeout
+	}
+	if args.Config.QueueSize > 0 {
+		wp.startDeliveryQueues(args.Config.QueueSize)
+	}
+
+	return wp, nil
+}
+
+// createURLsByEventType returns the urls for the event types which have their own urls
+// configured, the other event types are posted to the default urls
+func createURLsByEventType(cfg config.WebhookConfig) map[string][]string {
+	urlsByEventType := make(map[string][]string)
+	if len(cfg.EventsURLs) > 0 {
+		urlsByEventType[common.PushLogsAndEvents] = cfg.EventsURLs
+	}
+	if len(cfg.RevertEventsURLs) > 0 {
+		urlsByEventType[common.RevertBlockEvents] = cfg.RevertEventsURLs
+	}
+	if len(cfg.FinalizedEventsURLs) > 0 {
+		urlsByEventType[common.FinalizedBlockEvents] = cfg.FinalizedEventsURLs
+	}
+
+	return urlsByEventType
+}
+
+func (wp *webhookPublisher) startDeliveryQueues(queueSize uint32) {
+	wp.queues = make(map[string]chan *pendingDelivery)
+
+	allURLs := [][]string{wp.urls}
+	for _, urls := range wp.urlsByEventType {
+		allURLs = append(allURLs, urls)
+	}
+
+	for _, urls := range allURLs {
+		for _, webhookURL := range urls {
+			_, exists := wp.queues[webhookURL]
+			if exists {
+				continue
+			}
+
+			queue := make(chan *pendingDelivery, queueSize)
+			wp.queues[webhookURL] = queue
+
+			wp.wg.Add(1)
+			go wp.deliverLoop(webhookURL, queue)
+		}
+	}
+}
+
+func checkArgs(args ArgsWebhookPublisher) error {
+	if args.Client == nil {
+		return ErrNilHTTPClient
+	}
+	if check.IfNil(args.Marshaller) {
+		return common.ErrNilMarshaller
+	}
+	allURLs := [][]string{args.Config.URLs, args.Config.EventsURLs, args.Config.RevertEventsURLs, args.Config.FinalizedEventsURLs}
+	numURLs := 0
+	for _, urls := range allURLs {
+		for _, webhookURL := range urls {
+			err := checkURL(webhookURL)
+			if err != nil {
+				return err
+			}
+		}
+		numURLs += len(urls)
+	}
+	if numURLs == 0 {
+		return ErrEmptyWebhookURLs
+	}
+	if args.Config.MaxAttempts == 0 {
+		return ErrInvalidMaxAttempts
+	}
+	if args.AckAfterPublish && args.Config.QueueSize > 0 {
+		return ErrQueueWithAckAfterPublish
+	}
+
+	return nil
+}
+
+func checkURL(webhookURL string) error {
+	parsedURL, err := url.Parse(webhookURL)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" {
+		return fmt.Errorf("%w: %s", ErrInvalidWebhookURL, webhookURL)
+	}
+
+	return nil
+}
+
+// Publish will post logs and events to the webhook urls
+func (wp *webhookPublisher) Publish(_ context.Context, events data.BlockEvents) error {
+	return wp.publish(common.PushLogsAndEvents, events.Hash, events)
+}
+
+// PublishRevert will post revert event to the webhook urls
+func (wp *webhookPublisher) PublishRevert(_ context.Context, revertBlock data.RevertBlock) error {
+	return wp.publish(common.RevertBlockEvents, revertBlock.Hash, revertBlock)
+}
+
+// PublishFinalized will post finalized event to the webhook urls
+func (wp *webhookPublisher) PublishFinalized(_ context.Context, finalizedBlock data.FinalizedBlock) error {
+	return wp.publish(common.FinalizedBlockEvents, finalizedBlock.Hash, finalizedBlock)
+}
+
+// PublishTxs will post txs event to the webhook urls
+func (wp *webhookPublisher) PublishTxs(_ context.Context, blockTxs data.BlockTxs) error {
+	return wp.publish(common.BlockTxs, blockTxs.Hash, blockTxs)
+}
+
+// PublishScrs will post scrs event to the webhook urls
+func (wp *webhookPublisher) PublishScrs(_ context.Context, blockScrs data.BlockScrs) error {
+	return wp.publish(common.BlockScrs, blockScrs.Hash, blockScrs)
+}
+
+// PublishBlockEventsWithOrder will post full block events to the webhook urls
+func (wp *webhookPublisher) PublishBlockEventsWithOrder(_ context.Context, blockTxs data.BlockEventsWithOrder) error {
+	return wp.publish(common.BlockEvents, blockTxs.Hash, blockTxs)
+}
+
+// PublishTxEvents will post transaction notifications to the webhook urls
+func (wp *webhookPublisher) PublishTxEvents(_ context.Context, blockTxEvents data.BlockTxEvents) error {
+	return wp.publish(common.TxEvents, blockTxEvents.Hash, blockTxEvents)
+}
+
+// PublishRounds will post the rounds info to the webhook urls
+func (wp *webhookPublisher) PublishRounds(_ context.Context, roundEvents data.RoundEvents) error {
+	return wp.publish(common.RoundEvents, "", roundEvents)
+}
+
+// PublishValidatorsRating will post the validators rating to the webhook urls
+func (wp *webhookPublisher) PublishValidatorsRating(_ context.Context, validatorsRating data.ValidatorsRatingEvent) error {
+	return wp.publish(common.ValidatorsRatingEvents, "", validatorsRating)
+}
+
+// PublishAccounts will post the altered accounts to the webhook urls
+func (wp *webhookPublisher) PublishAccounts(_ context.Context, accountsEvents data.AccountsEvents) error {
+	return wp.publish(common.AccountEvents, "", accountsEvents)
+}
+
+// publish posts the event to each url and returns the first delivery error. If the
+// deliveries are queued, the event is only handed over to the queues
+func (wp *webhookPublisher) publish(eventType string, hash string, eventData interface{}) error {
+	urls := wp.getURLs(eventType)
+	if len(urls) == 0 {
+		return nil
+	}
+
+	body, err := wp.marshalEvent(eventType, eventData)
+	if err != nil {
+		log.Error("could not marshal webhook event", "event", eventType, "err", err.Error())
+		return err
+	}
+
+	var firstErr error
+	for _, webhookURL := range urls {
+		delivery := &pendingDelivery{eventType: eventType, hash: hash, body: body}
+		if wp.queues != nil {
+			wp.enqueue(webhookURL, delivery)
+			continue
+		}
+
+		err = wp.deliverAndLog(webhookURL, delivery)
+		if err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("%w for url %s", err, webhookURL)
+		}
+	}
+
+	return firstErr
+}
+
+func (wp *webhookPublisher) getURLs(eventType string) []string {
+	urls, ok := wp.urlsByEventType[eventType]
+	if ok {
+		return urls
+	}
+
+	return wp.urls
+}
+
+// marshalEvent returns the body to be posted, which is either the event wrapped with its
+// type or, if configured, the event alone, same as the payloads published to rabbitMQ
+func (wp *webhookPublisher) marshalEvent(eventType string, eventData interface{}) ([]byte, error) {
+	if wp.rawPayload {
+		return wp.marshaller.Marshal(eventData)
+	}
+
+	return wp.marshaller.Marshal(&webhookEvent{
+		Type: eventType,
+		Data: eventData,
+	})
+}
+
+func (wp *webhookPublisher) enqueue(webhookURL string, delivery *pendingDelivery) {
+	select {
+	case <-wp.closeChan:
+		return
+	default:
+	}
+
+	select {
+	case wp.queues[webhookURL] <- delivery:
+	default:
+		wp.mutMetrics.Lock()
+		wp.numDroppedEvents[webhookURL]++
+		wp.mutMetrics.Unlock()
+
+		log.Warn("webhook delivery queue is full, event dropped",
+			"url", webhookURL,
+			"event", delivery.eventType,
+			"hash", delivery.hash,
+		)
+	}
+}
+
+func (wp *webhookPublisher) deliverLoop(webhookURL string, queue chan *pendingDelivery) {
+	defer wp.wg.Done()
+
+	for {
+		select {
+		case delivery := <-queue:
+			_ = wp.deliverAndLog(webhookURL, delivery)
+		case <-wp.closeChan:
+			return
+		}
+	}
+}
+
+func (wp *webhookPublisher) deliverAndLog(webhookURL string, delivery *pendingDelivery) error {
+	err := wp.deliverWithRetries(webhookURL, delivery.eventType, delivery.body)
+	if err != nil {
+		log.Error("failed to deliver event to webhook, event dropped",
+			"url", webhookURL,
+			"event", delivery.eventType,
+			"hash", delivery.hash,
+			"err", err.Error(),
+		)
+	}
+
+	return err
+}
+
+// deliverWithRetries posts the body, retrying with exponential backoff until the
+// endpoint accepts it, the max number of attempts is reached or the publisher is closed.
+// Only the network errors, server errors and throttled requests are retried
+func (wp *webhookPublisher) deliverWithRetries(webhookURL string, eventType string, body []byte) error {
+	var err error
+	var retryable bool
+	retryInterval := wp.retryInterval
+
+	for attempt := uint32(1); attempt <= wp.maxAttempts; attempt++ {
+		retryable, err = wp.deliver(webhookURL, eventType, body)
+		if err == nil || !retryable || attempt == wp.maxAttempts {
+			break
+		}
+
+		log.Warn("failed to deliver event to webhook, will retry",
+			"url", webhookURL,
+			"attempt", attempt,
+			"retry interval", retryInterval,
+			"err", err.Error(),
+		)
+
+		if !wp.waitRetryInterval(retryInterval) {
+			break
+		}
+		retryInterval = nextRetryInterval(retryInterval)
+	}
+
+	wp.mutMetrics.Lock()
+	if err != nil {
+		wp.numDeliveryFailures[webhookURL]++
+	} else {
+		wp.numDeliverySuccess[webhookURL]++
+	}
+	wp.mutMetrics.Unlock()
+
+	return err
+}
+
+// deliver posts the body once and returns whether the delivery can be retried on failure
+func (wp *webhookPublisher) deliver(webhookURL string, eventType string, body []byte) (bool, error) {
+	headers := make(http.Header)
+	headers.Set(eventTypeHeader, eventType)
+	if len(wp.hmacSecret) > 0 {
+		headers.Set(wp.signatureHeader, signaturePrefix+computeSignature(wp.hmacSecret, body))
+	}
+
+	return postJSON(wp.client, wp.requestTimeout, webhookURL, headers, body)
+}
+
+// waitRetryInterval returns false if the publisher was closed while waiting
+func (wp *webhookPublisher) waitRetryInterval(retryInterval time.Duration) bool {
+	timer := time.NewTimer(retryInterval)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-wp.closeChan:
+		return false
+	}
+}
+
+func nextRetryInterval(retryInterval time.Duration) time.Duration {
+	retryInterval *= 2
+	if retryInterval > maxRetryInterval {
+		return maxRetryInterval
+	}
+
+	return retryInterval
+}
+
+// GetMetricsForPrometheus returns the number of successful and failed deliveries, and
+// the number of events dropped because of a full queue, for each webhook url, in prometheus format
+func (wp *webhookPublisher) GetMetricsForPrometheus() string {
+	wp.mutMetrics.RLock()
+	defer wp.mutMetrics.RUnlock()
+
+	stringBuilder := strings.Builder{}
+	stringBuilder.WriteString(metrics.CounterMetrics(deliverySuccessPromMetric, urlPromLabel, wp.numDeliverySuccess))
+	stringBuilder.WriteString(metrics.CounterMetrics(deliveryFailuresPromMetric, urlPromLabel, wp.numDeliveryFailures))
+	stringBuilder.WriteString(metrics.CounterMetrics(droppedEventsPromMetric, urlPromLabel, wp.numDroppedEvents))
+
+	return stringBuilder.String()
+}
+
+// GetHealthState returns not applicable, since the webhook endpoints are external
+// and failed deliveries are dropped
+func (wp *webhookPublisher) GetHealthState() string {
+	return common.HealthStateNotApplicable
+}
+
+// Ping returns nil, since the webhook endpoints do not affect the notifier readiness
+func (wp *webhookPublisher) Ping(_ context.Context) error {
+	return nil
+}
+
+// Close stops the deliveries, the queued events are dropped
+func (wp *webhookPublisher) Close() error {
+	wp.closeOnce.Do(func() {
+		close(wp.closeChan)
+	})
+	wp.wg.Wait()
+
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (wp *webhookPublisher) IsInterfaceNil() bool {
+	return wp == nil
+}
@@ -0,0 +1,446 @@
+package webhook_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/marshal"
+	"github.com/multiversx/mx-chain-notifier-go/common"
+	"github.com/multiversx/mx-chain-notifier-go/config"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/webhook"
+	"github.com/stretchr/testify/require"
+)
+
+func createMockArgsWebhookPublisher(urls ...string) webhook.ArgsWebhookPublisher {
+	if len(urls) == 0 {
+		urls = []string{"http://localhost:8080/events"}
+	}
+
+	return webhook.ArgsWebhookPublisher{
+		Client: &http.Client{},
+		Config: config.WebhookConfig{
+			URLs:               urls,
+			RequestTimeoutInMs: 1000,
+			MaxAttempts:        3,
+			RetryIntervalInMs:  1,
+		},
+		Marshaller: &marshal.JsonMarshalizer{},
+	}
+}
+
+func TestNewWebhookPublisher(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil http client, should fail", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsWebhookPublisher()
+		args.Client = nil
+
+		publisher, err := webhook.NewWebhookPublisher(args)
+		require.True(t, check.IfNil(publisher))
+		require.Equal(t, webhook.ErrNilHTTPClient, err)
+	})
+
+	t.Run("nil marshaller, should fail", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsWebhookPublisher()
+		args.Marshaller = nil
+
+		publisher, err := webhook.NewWebhookPublisher(args)
+		require.True(t, check.IfNil(publisher))
+		require.Equal(t, common.ErrNilMarshaller, err)
+	})
+
+	t.Run("empty urls, should fail", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsWebhookPublisher()
+		args.Config.URLs = nil
+
+		publisher, err := webhook.NewWebhookPublisher(args)
+		require.True(t, check.IfNil(publisher))
+		require.Equal(t, webhook.ErrEmptyWebhookURLs, err)
+	})
+
+	t.Run("invalid url, should fail", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsWebhookPublisher("localhost:8080")
+
+		publisher, err := webhook.NewWebhookPublisher(args)
+		require.True(t, check.IfNil(publisher))
+		require.True(t, errors.Is(err, webhook.ErrInvalidWebhookURL))
+	})
+
+	t.Run("invalid event type url, should fail", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsWebhookPublisher()
+		args.Config.RevertEventsURLs = []string{"ftp://localhost/revert"}
+
+		publisher, err := webhook.NewWebhookPublisher(args)
+		require.True(t, check.IfNil(publisher))
+		require.True(t, errors.Is(err, webhook.ErrInvalidWebhookURL))
+	})
+
+	t.Run("only event type urls, should work", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsWebhookPublisher()
+		args.Config.URLs = nil
+		args.Config.FinalizedEventsURLs = []string{"http://localhost:8080/finalized"}
+
+		publisher, err := webhook.NewWebhookPublisher(args)
+		require.Nil(t, err)
+		require.False(t, check.IfNil(publisher))
+	})
+
+	t.Run("zero max attempts, should fail", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsWebhookPublisher()
+		args.Config.MaxAttempts = 0
+
+		publisher, err := webhook.NewWebhookPublisher(args)
+		require.True(t, check.IfNil(publisher))
+		require.Equal(t, webhook.ErrInvalidMaxAttempts, err)
+	})
+
+	t.Run("queue with ack after publish, should fail", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsWebhookPublisher()
+		args.Config.QueueSize = 10
+		args.AckAfterPublish = true
+
+		publisher, err := webhook.NewWebhookPublisher(args)
+		require.True(t, check.IfNil(publisher))
+		require.Equal(t, webhook.ErrQueueWithAckAfterPublish, err)
+	})
+
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		publisher, err := webhook.NewWebhookPublisher(createMockArgsWebhookPublisher())
+		require.Nil(t, err)
+		require.False(t, check.IfNil(publisher))
+		require.Equal(t, common.HealthStateNotApplicable, publisher.GetHealthState())
+	})
+}
+
+func TestWebhookPublisher_Publish(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should post the signed event to all urls", func(t *testing.T) {
+		t.Parallel()
+
+		secret := "secret"
+		mutRequests := sync.Mutex{}
+		numRequests := 0
+
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := ioutil.ReadAll(r.Body)
+			require.Nil(t, err)
+
+			mac := hmac.New(sha256.New, []byte(secret))
+			_, _ = mac.Write(body)
+			expectedSignature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+			require.Equal(t, expectedSignature, r.Header.Get("X-Notifier-Signature"))
+			require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			require.Equal(t, common.FinalizedBlockEvents, r.Header.Get("X-Notifier-Event-Type"))
+
+			event := struct {
+				Type string              `json:"type"`
+				Data data.FinalizedBlock `json:"data"`
+			}{}
+			err = json.Unmarshal(body, &event)
+			require.Nil(t, err)
+			require.Equal(t, common.FinalizedBlockEvents, event.Type)
+			require.Equal(t, "hash1", event.Data.Hash)
+
+			mutRequests.Lock()
+			numRequests++
+			mutRequests.Unlock()
+
+			w.WriteHeader(http.StatusOK)
+		})
+		server1 := httptest.NewServer(handler)
+		defer server1.Close()
+		server2 := httptest.NewServer(handler)
+		defer server2.Close()
+
+		args := createMockArgsWebhookPublisher(server1.URL, server2.URL)
+		args.Config.HMACSecret = secret
+
+		publisher, err := webhook.NewWebhookPublisher(args)
+		require.Nil(t, err)
+
+		err = publisher.PublishFinalized(context.Background(), data.FinalizedBlock{Hash: "hash1"})
+		require.Nil(t, err)
+
+		require.Equal(t, 2, numRequests)
+		require.Contains(t, publisher.GetMetricsForPrometheus(), `webhook_delivery_success{url="`+server1.URL+`"} 1`)
+	})
+
+	t.Run("should not sign the event if no secret is set", func(t *testing.T) {
+		t.Parallel()
+
+		signature := "not-called"
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			signature = r.Header.Get("X-Notifier-Signature")
+		}))
+		defer server.Close()
+
+		publisher, err := webhook.NewWebhookPublisher(createMockArgsWebhookPublisher(server.URL))
+		require.Nil(t, err)
+
+		publisher.Publish(context.Background(), data.BlockEvents{Hash: "hash1"})
+		require.Empty(t, signature)
+	})
+
+	t.Run("server errors should be retried", func(t *testing.T) {
+		t.Parallel()
+
+		numRequests := uint32(0)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddUint32(&numRequests, 1) < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		publisher, err := webhook.NewWebhookPublisher(createMockArgsWebhookPublisher(server.URL))
+		require.Nil(t, err)
+
+		publisher.PublishRevert(context.Background(), data.RevertBlock{Hash: "hash1"})
+
+		require.Equal(t, uint32(3), atomic.LoadUint32(&numRequests))
+		require.Contains(t, publisher.GetMetricsForPrometheus(), `webhook_delivery_success{url="`+server.URL+`"} 1`)
+	})
+
+	t.Run("event should be dropped after max attempts", func(t *testing.T) {
+		t.Parallel()
+
+		numRequests := uint32(0)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddUint32(&numRequests, 1)
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		publisher, err := webhook.NewWebhookPublisher(createMockArgsWebhookPublisher(server.URL))
+		require.Nil(t, err)
+
+		err = publisher.PublishTxs(context.Background(), data.BlockTxs{Hash: "hash1"})
+		require.NotNil(t, err)
+
+		require.Equal(t, uint32(3), atomic.LoadUint32(&numRequests))
+		require.Contains(t, publisher.GetMetricsForPrometheus(), `webhook_delivery_failures{url="`+server.URL+`"} 1`)
+	})
+
+	t.Run("client errors should not be retried", func(t *testing.T) {
+		t.Parallel()
+
+		numRequests := uint32(0)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddUint32(&numRequests, 1)
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		publisher, err := webhook.NewWebhookPublisher(createMockArgsWebhookPublisher(server.URL))
+		require.Nil(t, err)
+
+		publisher.PublishScrs(context.Background(), data.BlockScrs{Hash: "hash1"})
+
+		require.Equal(t, uint32(1), atomic.LoadUint32(&numRequests))
+	})
+}
+
+func TestWebhookPublisher_EventTypeURLs(t *testing.T) {
+	t.Parallel()
+
+	mutPaths := sync.Mutex{}
+	paths := make(map[string]string)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutPaths.Lock()
+		paths[r.Header.Get("X-Notifier-Event-Type")] = r.URL.Path
+		mutPaths.Unlock()
+	}))
+	defer server.Close()
+
+	args := createMockArgsWebhookPublisher(server.URL + "/default")
+	args.Config.EventsURLs = []string{server.URL + "/events"}
+	args.Config.RevertEventsURLs = []string{server.URL + "/revert"}
+	args.Config.FinalizedEventsURLs = []string{server.URL + "/finalized"}
+
+	publisher, err := webhook.NewWebhookPublisher(args)
+	require.Nil(t, err)
+
+	publisher.Publish(context.Background(), data.BlockEvents{Hash: "hash1"})
+	publisher.PublishRevert(context.Background(), data.RevertBlock{Hash: "hash1"})
+	publisher.PublishFinalized(context.Background(), data.FinalizedBlock{Hash: "hash1"})
+	publisher.PublishTxs(context.Background(), data.BlockTxs{Hash: "hash1"})
+
+	mutPaths.Lock()
+	defer mutPaths.Unlock()
+
+	require.Equal(t, map[string]string{
+		common.PushLogsAndEvents:    "/events",
+		common.RevertBlockEvents:    "/revert",
+		common.FinalizedBlockEvents: "/finalized",
+		common.BlockTxs:             "/default",
+	}, paths)
+}
+
+func TestWebhookPublisher_RawPayload(t *testing.T) {
+	t.Parallel()
+
+	secret := "secret"
+	var receivedBody []byte
+	var receivedSignature string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedBody, _ = ioutil.ReadAll(r.Body)
+		receivedSignature = r.Header.Get("X-Notifier-Signature")
+	}))
+	defer server.Close()
+
+	args := createMockArgsWebhookPublisher(server.URL)
+	args.Config.RawPayload = true
+	args.Config.HMACSecret = secret
+
+	publisher, err := webhook.NewWebhookPublisher(args)
+	require.Nil(t, err)
+
+	revertBlock := data.RevertBlock{Hash: "hash1", Nonce: 2}
+	publisher.PublishRevert(context.Background(), revertBlock)
+
+	expectedBody, _ := args.Marshaller.Marshal(revertBlock)
+	require.Equal(t, expectedBody, receivedBody)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(expectedBody)
+	require.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), receivedSignature)
+}
+
+func TestWebhookPublisher_Queue(t *testing.T) {
+	t.Parallel()
+
+	t.Run("slow endpoint should not block the publishing", func(t *testing.T) {
+		t.Parallel()
+
+		unblock := make(chan struct{})
+		slowServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-unblock
+		}))
+		defer slowServer.Close()
+
+		received := make(chan string, 10)
+		fastServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received <- r.Header.Get("X-Notifier-Event-Type")
+		}))
+		defer fastServer.Close()
+
+		args := createMockArgsWebhookPublisher(slowServer.URL, fastServer.URL)
+		args.Config.QueueSize = 10
+
+		publisher, err := webhook.NewWebhookPublisher(args)
+		require.Nil(t, err)
+		defer func() {
+			close(unblock)
+			_ = publisher.Close()
+		}()
+
+		publisher.Publish(context.Background(), data.BlockEvents{Hash: "hash1"})
+		publisher.PublishFinalized(context.Background(), data.FinalizedBlock{Hash: "hash1"})
+
+		for _, expectedEventType := range []string{common.PushLogsAndEvents, common.FinalizedBlockEvents} {
+			select {
+			case eventType := <-received:
+				require.Equal(t, expectedEventType, eventType)
+			case <-time.After(time.Second):
+				require.Fail(t, "event not delivered to the fast endpoint")
+			}
+		}
+	})
+
+	t.Run("events should be dropped when the queue is full", func(t *testing.T) {
+		t.Parallel()
+
+		requestReceived := make(chan struct{}, 1)
+		unblock := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestReceived <- struct{}{}
+			<-unblock
+		}))
+		defer server.Close()
+
+		args := createMockArgsWebhookPublisher(server.URL)
+		args.Config.QueueSize = 1
+
+		publisher, err := webhook.NewWebhookPublisher(args)
+		require.Nil(t, err)
+		defer func() {
+			close(unblock)
+			_ = publisher.Close()
+		}()
+
+		// the first event is being delivered, the second one is queued
+		publisher.Publish(context.Background(), data.BlockEvents{Hash: "hash1"})
+		<-requestReceived
+		publisher.Publish(context.Background(), data.BlockEvents{Hash: "hash2"})
+		publisher.Publish(context.Background(), data.BlockEvents{Hash: "hash3"})
+		publisher.Publish(context.Background(), data.BlockEvents{Hash: "hash4"})
+
+		require.Contains(t, publisher.GetMetricsForPrometheus(), `webhook_dropped_events{url="`+server.URL+`"} 2`)
+	})
+
+	t.Run("close should stop the retries", func(t *testing.T) {
+		t.Parallel()
+
+		numRequests := uint32(0)
+		requestReceived := make(chan struct{}, 1)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddUint32(&numRequests, 1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			requestReceived <- struct{}{}
+		}))
+		defer server.Close()
+
+		args := createMockArgsWebhookPublisher(server.URL)
+		args.Config.QueueSize = 1
+		args.Config.RetryIntervalInMs = 60000
+
+		publisher, err := webhook.NewWebhookPublisher(args)
+		require.Nil(t, err)
+
+		publisher.Publish(context.Background(), data.BlockEvents{Hash: "hash1"})
+		<-requestReceived
+
+		require.Nil(t, publisher.Close())
+		require.Equal(t, uint32(1), atomic.LoadUint32(&numRequests))
+		require.Contains(t, publisher.GetMetricsForPrometheus(), `webhook_delivery_failures{url="`+server.URL+`"} 1`)
+
+		// events published after close are ignored
+		publisher.Publish(context.Background(), data.BlockEvents{Hash: "hash2"})
+		require.Equal(t, uint32(1), atomic.LoadUint32(&numRequests))
+	})
+}
@@ -0,0 +1,131 @@
+package webhook
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/marshal"
+	"github.com/multiversx/mx-chain-notifier-go/common"
+	"github.com/multiversx/mx-chain-notifier-go/config"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/dispatcher"
+)
+
+// ArgsWebhookRegistry defines the arguments needed for webhook registry creation
+type ArgsWebhookRegistry struct {
+	Hub        dispatcher.Hub
+	Client     HTTPClient
+	Config     config.WebhookConfig
+	Marshaller marshal.Marshalizer
+}
+
+type webhookRegistry struct {
+	hub            dispatcher.Hub
+	client         HTTPClient
+	marshaller     marshal.Marshalizer
+	requestTimeout time.Duration
+	retryInterval  time.Duration
+
+	mutWebhooks sync.RWMutex
+	webhooks    map[uuid.UUID]*webhookDispatcher
+}
+
+// NewWebhookRegistry creates a new registry for the webhooks registered via the REST api.
+// Each webhook is registered in the hub as a dispatcher, so it receives only the events
+// matched by its subscription entries
+func NewWebhookRegistry(args ArgsWebhookRegistry) (*webhookRegistry, error) {
+	if check.IfNil(args.Hub) {
+		return nil, ErrNilHub
+	}
+	if args.Client == nil {
+		return nil, ErrNilHTTPClient
+	}
+	if check.IfNil(args.Marshaller) {
+		return nil, common.ErrNilMarshaller
+	}
+
+	wr := &webhookRegistry{
+		hub:            args.Hub,
+		client:         args.Client,
+		marshaller:     args.Marshaller,
+		requestTimeout: time.Duration(args.Config.RequestTimeoutInMs) * time.Millisecond,
+		retryInterval:  time.Duration(args.Config.RetryIntervalInMs) * time.Millisecond,
+		webhooks:       make(map[uuid.UUID]*webhookDispatcher),
+	}
+	if wr.requestTimeout == 0 {
+		wr.requestTimeout = defaultRequestTimeout
+	}
+
+	return wr, nil
+}
+
+// Register will register the webhook and subscribe it to the provided entries. It returns
+// the id of the webhook, which is needed to unregister it
+func (wr *webhookRegistry) Register(registration data.WebhookRegistration) (uuid.UUID, error) {
+	err := checkURL(registration.URL)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+
+	wd := newWebhookDispatcher(argsWebhookDispatcher{
+		Client:         wr.client,
+		Marshaller:     wr.marshaller,
+		URL:            registration.URL,
+		Secret:         registration.Secret,
+		RequestTimeout: wr.requestTimeout,
+		RetryInterval:  wr.retryInterval,
+	})
+
+	wr.mutWebhooks.Lock()
+	wr.webhooks[wd.GetID()] = wd
+	wr.mutWebhooks.Unlock()
+
+	wr.hub.RegisterEvent(wd)
+	err = wr.hub.Subscribe(data.SubscribeEvent{
+		DispatcherID:        wd.GetID(),
+		SubscriptionEntries: registration.SubscriptionEntries,
+	})
+	if err != nil {
+		wr.mutWebhooks.Lock()
+		delete(wr.webhooks, wd.GetID())
+		wr.mutWebhooks.Unlock()
+
+		wr.hub.UnregisterEvent(wd)
+		_ = wd.Close()
+
+		return uuid.UUID{}, err
+	}
+
+	log.Info("registered webhook", "dispatcherID", wd.GetID(), "url", registration.URL)
+
+	return wd.GetID(), nil
+}
+
+// Unregister will remove the webhook with the provided id, together with its subscriptions
+func (wr *webhookRegistry) Unregister(id uuid.UUID) error {
+	wr.mutWebhooks.Lock()
+	wd, ok := wr.webhooks[id]
+	delete(wr.webhooks, id)
+	wr.mutWebhooks.Unlock()
+	if !ok {
+		return common.ErrWebhookNotFound
+	}
+
+	err := wr.hub.DisconnectDispatcher(id)
+	if err != nil {
+		// the dispatcher might have been disconnected already via the hub api
+		log.Debug("could not disconnect webhook dispatcher", "dispatcherID", id, "err", err.Error())
+		_ = wd.Close()
+	}
+
+	log.Info("unregistered webhook", "dispatcherID", id, "url", wd.url)
+
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (wr *webhookRegistry) IsInterfaceNil() bool {
+	return wr == nil
+}
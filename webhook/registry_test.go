@@ -0,0 +1,409 @@
+package webhook_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/marshal"
+	"github.com/multiversx/mx-chain-notifier-go/common"
+	"github.com/multiversx/mx-chain-notifier-go/config"
+	"github.com/multiversx/mx-chain-notifier-go/data"
+	"github.com/multiversx/mx-chain-notifier-go/dispatcher"
+	"github.com/multiversx/mx-chain-notifier-go/dispatcher/hub"
+	"github.com/multiversx/mx-chain-notifier-go/filters"
+	"github.com/multiversx/mx-chain-notifier-go/mocks"
+	"github.com/multiversx/mx-chain-notifier-go/webhook"
+	"github.com/stretchr/testify/require"
+)
+
+const waitTimeout = 5 * time.Second
+
+func createMockArgsWebhookRegistry() webhook.ArgsWebhookRegistry {
+	return webhook.ArgsWebhookRegistry{
+		Hub:    &mocks.HubStub{},
+		Client: &http.Client{},
+		Config: config.WebhookConfig{
+			RequestTimeoutInMs: 1000,
+			RetryIntervalInMs:  1,
+		},
+		Marshaller: &marshal.JsonMarshalizer{},
+	}
+}
+
+type receivedRequest struct {
+	header http.Header
+	body   []byte
+}
+
+func startWebhookServer(t *testing.T, statusCodes ...int) (*httptest.Server, chan *receivedRequest) {
+	requests := make(chan *receivedRequest, 10)
+	numRequests := uint32(0)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		requests <- &receivedRequest{header: r.Header, body: body}
+
+		index := int(atomic.AddUint32(&numRequests, 1)) - 1
+		if index < len(statusCodes) {
+			w.WriteHeader(statusCodes[index])
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, requests
+}
+
+func waitRequest(t *testing.T, requests chan *receivedRequest) *receivedRequest {
+	select {
+	case req := <-requests:
+		return req
+	case <-time.After(waitTimeout):
+		require.Fail(t, "timeout waiting for webhook request")
+		return nil
+	}
+}
+
+func registerWithHubStub(t *testing.T, args webhook.ArgsWebhookRegistry, registration data.WebhookRegistration) dispatcher.EventDispatcher {
+	var registered dispatcher.EventDispatcher
+	args.Hub = &mocks.HubStub{
+		RegisterEventCalled: func(event dispatcher.EventDispatcher) {
+			registered = event
+		},
+	}
+
+	registry, err := webhook.NewWebhookRegistry(args)
+	require.Nil(t, err)
+
+	id, err := registry.Register(registration)
+	require.Nil(t, err)
+	require.Equal(t, id, registered.GetID())
+	t.Cleanup(func() {
+		_ = registered.Close()
+	})
+
+	return registered
+}
+
+func TestNewWebhookRegistry(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil hub, should fail", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsWebhookRegistry()
+		args.Hub = nil
+
+		registry, err := webhook.NewWebhookRegistry(args)
+		require.True(t, check.IfNil(registry))
+		require.Equal(t, webhook.ErrNilHub, err)
+	})
+
+	t.Run("nil http client, should fail", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsWebhookRegistry()
+		args.Client = nil
+
+		registry, err := webhook.NewWebhookRegistry(args)
+		require.True(t, check.IfNil(registry))
+		require.Equal(t, webhook.ErrNilHTTPClient, err)
+	})
+
+	t.Run("nil marshaller, should fail", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsWebhookRegistry()
+		args.Marshaller = nil
+
+		registry, err := webhook.NewWebhookRegistry(args)
+		require.True(t, check.IfNil(registry))
+		require.Equal(t, common.ErrNilMarshaller, err)
+	})
+
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		registry, err := webhook.NewWebhookRegistry(createMockArgsWebhookRegistry())
+		require.Nil(t, err)
+		require.False(t, check.IfNil(registry))
+	})
+}
+
+func TestWebhookRegistry_Register(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid url, should fail", func(t *testing.T) {
+		t.Parallel()
+
+		registerCalled := false
+		args := createMockArgsWebhookRegistry()
+		args.Hub = &mocks.HubStub{
+			RegisterEventCalled: func(event dispatcher.EventDispatcher) {
+				registerCalled = true
+			},
+		}
+
+		registry, err := webhook.NewWebhookRegistry(args)
+		require.Nil(t, err)
+
+		_, err = registry.Register(data.WebhookRegistration{URL: "ftp://localhost/events"})
+		require.True(t, errors.Is(err, webhook.ErrInvalidWebhookURL))
+		require.False(t, registerCalled)
+	})
+
+	t.Run("should register and subscribe the webhook in hub", func(t *testing.T) {
+		t.Parallel()
+
+		entries := []data.SubscriptionEntry{{Address: "erd1"}}
+		var registered dispatcher.EventDispatcher
+		var subscribeEvent data.SubscribeEvent
+		args := createMockArgsWebhookRegistry()
+		args.Hub = &mocks.HubStub{
+			RegisterEventCalled: func(event dispatcher.EventDispatcher) {
+				registered = event
+			},
+			SubscribeCalled: func(event data.SubscribeEvent) error {
+				subscribeEvent = event
+				return nil
+			},
+		}
+
+		registry, err := webhook.NewWebhookRegistry(args)
+		require.Nil(t, err)
+
+		id, err := registry.Register(data.WebhookRegistration{URL: "http://localhost:8080/events", SubscriptionEntries: entries})
+		require.Nil(t, err)
+		require.NotEqual(t, uuid.UUID{}, id)
+		require.Equal(t, id, registered.GetID())
+		require.Equal(t, data.SubscribeEvent{DispatcherID: id, SubscriptionEntries: entries}, subscribeEvent)
+
+		require.Nil(t, registered.Close())
+	})
+
+	t.Run("subscribe error should unregister the webhook", func(t *testing.T) {
+		t.Parallel()
+
+		var unregistered dispatcher.EventDispatcher
+		args := createMockArgsWebhookRegistry()
+		args.Hub = &mocks.HubStub{
+			SubscribeCalled: func(event data.SubscribeEvent) error {
+				return dispatcher.ErrMaxSubscriptionsReached
+			},
+			UnregisterEventCalled: func(event dispatcher.EventDispatcher) {
+				unregistered = event
+			},
+		}
+
+		registry, err := webhook.NewWebhookRegistry(args)
+		require.Nil(t, err)
+
+		id, err := registry.Register(data.WebhookRegistration{URL: "http://localhost:8080/events"})
+		require.Equal(t, dispatcher.ErrMaxSubscriptionsReached, err)
+		require.Equal(t, uuid.UUID{}, id)
+		require.NotNil(t, unregistered)
+		require.Equal(t, common.ErrWebhookNotFound, registry.Unregister(unregistered.GetID()))
+	})
+
+	t.Run("should post the events signed with the webhook secret", func(t *testing.T) {
+		t.Parallel()
+
+		server, requests := startWebhookServer(t)
+		secret := "secret"
+		registered := registerWithHubStub(t, createMockArgsWebhookRegistry(), data.WebhookRegistration{URL: server.URL, Secret: secret})
+
+		events := []data.Event{{Address: "erd1", Identifier: "transfer", TxHash: "txHash1"}}
+		registered.PushEvents(events)
+
+		req := waitRequest(t, requests)
+		require.Equal(t, "application/json", req.header.Get("Content-Type"))
+		require.Equal(t, common.PushLogsAndEvents, req.header.Get("X-Notifier-Event-Type"))
+		mac := hmac.New(sha256.New, []byte(secret))
+		_, _ = mac.Write(req.body)
+		require.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), req.header.Get("X-Signature"))
+
+		var receivedEvent struct {
+			Type string       `json:"type"`
+			Data []data.Event `json:"data"`
+		}
+		err := json.Unmarshal(req.body, &receivedEvent)
+		require.Nil(t, err)
+		require.Equal(t, common.PushLogsAndEvents, receivedEvent.Type)
+		require.Equal(t, events, receivedEvent.Data)
+	})
+
+	t.Run("without secret, should not sign the events", func(t *testing.T) {
+		t.Parallel()
+
+		server, requests := startWebhookServer(t)
+		registered := registerWithHubStub(t, createMockArgsWebhookRegistry(), data.WebhookRegistration{URL: server.URL})
+
+		registered.FinalizedEvent(data.FinalizedBlock{Hash: "hash1"})
+
+		req := waitRequest(t, requests)
+		require.Equal(t, common.FinalizedBlockEvents, req.header.Get("X-Notifier-Event-Type"))
+		require.Equal(t, "", req.header.Get("X-Signature"))
+	})
+
+	t.Run("server error should be retried", func(t *testing.T) {
+		t.Parallel()
+
+		server, requests := startWebhookServer(t, http.StatusInternalServerError, http.StatusTooManyRequests)
+		registered := registerWithHubStub(t, createMockArgsWebhookRegistry(), data.WebhookRegistration{URL: server.URL})
+
+		registered.RevertEvent(data.RevertBlock{Hash: "hash1"})
+
+		for i := 0; i < 3; i++ {
+			waitRequest(t, requests)
+		}
+
+		registered.RevertEvent(data.RevertBlock{Hash: "hash2"})
+		req := waitRequest(t, requests)
+		require.Contains(t, string(req.body), "hash2")
+	})
+
+	t.Run("should give up after 3 attempts", func(t *testing.T) {
+		t.Parallel()
+
+		statusCodes := []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway}
+		server, requests := startWebhookServer(t, statusCodes...)
+		registered := registerWithHubStub(t, createMockArgsWebhookRegistry(), data.WebhookRegistration{URL: server.URL})
+
+		registered.TxsEvent(data.BlockTxs{Hash: "hash1"})
+		registered.TxsEvent(data.BlockTxs{Hash: "hash2"})
+
+		for i := 0; i < 3; i++ {
+			req := waitRequest(t, requests)
+			require.Contains(t, string(req.body), "hash1")
+		}
+
+		req := waitRequest(t, requests)
+		require.Contains(t, string(req.body), "hash2")
+	})
+
+	t.Run("client error should not be retried", func(t *testing.T) {
+		t.Parallel()
+
+		server, requests := startWebhookServer(t, http.StatusBadRequest)
+		registered := registerWithHubStub(t, createMockArgsWebhookRegistry(), data.WebhookRegistration{URL: server.URL})
+
+		registered.ScrsEvent(data.BlockScrs{Hash: "hash1"})
+		registered.ScrsEvent(data.BlockScrs{Hash: "hash2"})
+
+		req := waitRequest(t, requests)
+		require.Contains(t, string(req.body), "hash1")
+
+		req = waitRequest(t, requests)
+		require.Contains(t, string(req.body), "hash2")
+	})
+}
+
+func TestWebhookRegistry_Unregister(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unknown webhook, should fail", func(t *testing.T) {
+		t.Parallel()
+
+		registry, err := webhook.NewWebhookRegistry(createMockArgsWebhookRegistry())
+		require.Nil(t, err)
+
+		err = registry.Unregister(uuid.New())
+		require.Equal(t, common.ErrWebhookNotFound, err)
+	})
+
+	t.Run("should disconnect the webhook from hub", func(t *testing.T) {
+		t.Parallel()
+
+		disconnectedIDs := make([]uuid.UUID, 0)
+		args := createMockArgsWebhookRegistry()
+		args.Hub = &mocks.HubStub{
+			DisconnectDispatcherCalled: func(dispatcherID uuid.UUID) error {
+				disconnectedIDs = append(disconnectedIDs, dispatcherID)
+				return nil
+			},
+		}
+
+		registry, err := webhook.NewWebhookRegistry(args)
+		require.Nil(t, err)
+
+		id, err := registry.Register(data.WebhookRegistration{URL: "http://localhost:8080/events"})
+		require.Nil(t, err)
+
+		err = registry.Unregister(id)
+		require.Nil(t, err)
+		require.Equal(t, []uuid.UUID{id}, disconnectedIDs)
+
+		err = registry.Unregister(id)
+		require.Equal(t, common.ErrWebhookNotFound, err)
+	})
+}
+
+func TestWebhookRegistry_WithCommonHub(t *testing.T) {
+	t.Parallel()
+
+	subscriptionMapper, err := dispatcher.NewSubscriptionMapper(dispatcher.ArgsSubscriptionMapper{})
+	require.Nil(t, err)
+
+	commonHub, err := hub.NewCommonHub(hub.ArgsCommonHub{
+		Filter:             filters.NewDefaultFilter(),
+		SubscriptionMapper: subscriptionMapper,
+		MetricsCollector:   &mocks.MetricsCollectorStub{},
+		EventStore:         &mocks.EventStoreStub{},
+	})
+	require.Nil(t, err)
+
+	server, requests := startWebhookServer(t)
+	args := createMockArgsWebhookRegistry()
+	args.Hub = commonHub
+
+	registry, err := webhook.NewWebhookRegistry(args)
+	require.Nil(t, err)
+
+	id, err := registry.Register(data.WebhookRegistration{
+		URL:                 server.URL,
+		SubscriptionEntries: []data.SubscriptionEntry{{Address: "erd1"}},
+	})
+	require.Nil(t, err)
+
+	blockEvents := data.BlockEvents{
+		Hash: "hash1",
+		Events: []data.Event{
+			{Address: "erd1", Identifier: "transfer"},
+			{Address: "erd2", Identifier: "transfer"},
+		},
+	}
+	commonHub.Publish(context.Background(), blockEvents)
+
+	req := waitRequest(t, requests)
+
+	var receivedEvent struct {
+		Data []data.Event `json:"data"`
+	}
+	err = json.Unmarshal(req.body, &receivedEvent)
+	require.Nil(t, err)
+	require.Equal(t, []data.Event{blockEvents.Events[0]}, receivedEvent.Data)
+
+	err = registry.Unregister(id)
+	require.Nil(t, err)
+
+	commonHub.Publish(context.Background(), blockEvents)
+
+	select {
+	case <-requests:
+		require.Fail(t, "unregistered webhook should not receive events")
+	case <-time.After(100 * time.Millisecond):
+	}
+}